@@ -26,6 +26,32 @@ type ImagesSpec struct {
 	//
 	// +optional
 	TempoGatewayOpa string `json:"tempoGatewayOpa,omitempty"`
+
+	// SPIFFEHelper defines the spiffe-helper sidecar container, which fetches the SPIFFE SVIDs of the Tempo components.
+	//
+	// +optional
+	SPIFFEHelper string `json:"spiffeHelper,omitempty"`
+
+	// OauthProxy defines the OpenShift OAuth proxy sidecar container, which protects the Jaeger Query UI.
+	//
+	// +optional
+	OauthProxy string `json:"oauthProxy,omitempty"`
+
+	// Memcached defines the memcached sidecar container, which caches the access reviews of the gateway.
+	//
+	// +optional
+	Memcached string `json:"memcached,omitempty"`
+
+	// TempoCLI defines the tempo-cli container image of the tempo-cli Jobs.
+	//
+	// +optional
+	TempoCLI string `json:"tempoCLI,omitempty"`
+
+	// Backup defines the rclone container image of the backup CronJob, which copies the traces to the backup bucket,
+	// of the Job which migrates the traces from a previous object storage, and of the tenant purge Jobs.
+	//
+	// +optional
+	Backup string `json:"backup,omitempty"`
 }
 
 // BuiltInCertManagement is the configuration for the built-in facility to generate and rotate
@@ -47,6 +73,18 @@ type BuiltInCertManagement struct {
 	CertRefresh metav1.Duration `json:"certRefresh,omitempty"`
 	// Enabled defines to flag to enable/disable built-in certificate management feature gate.
 	Enabled bool `json:"enabled,omitempty"`
+	// CASecret references an externally provided CA key pair, which signs all Tempo certificates
+	// instead of a CA generated by the operator. The secret must contain the `tls.crt` and `tls.key` fields.
+	// The operator does not rotate an externally provided CA.
+	CASecret *CASecretReference `json:"caSecret,omitempty"`
+}
+
+// CASecretReference is a reference to a secret containing a CA key pair.
+type CASecretReference struct {
+	// Name of the secret.
+	Name string `json:"name"`
+	// Namespace of the secret.
+	Namespace string `json:"namespace"`
 }
 
 // OpenShiftFeatureGates is the supported set of all operator features gates on OpenShift.
@@ -57,6 +95,13 @@ type OpenShiftFeatureGates struct {
 	// More details: https://docs.openshift.com/container-platform/latest/security/certificate_types_descriptions/service-ca-certificates.html
 	ServingCertsService bool `json:"servingCertsService,omitempty"`
 
+	// ServingCertsAllServices enables OpenShift service-ca annotations on the services of all TempoStack components.
+	// The certificates issued by the service-ca operator and the service-ca bundle are used for the
+	// HTTP and GRPC encryption between the components instead of the built-in cert management.
+	// The service-ca certificates can only be used for server authentication, therefore
+	// client certificates are not verified in this mode.
+	ServingCertsAllServices bool `json:"servingCertsAllServices,omitempty"`
+
 	// OpenShiftRoute enables creating OpenShift Route objects.
 	// More details: https://docs.openshift.com/container-platform/latest/networking/understanding-networking.html
 	OpenShiftRoute bool `json:"openshiftRoute,omitempty"`
@@ -84,6 +129,9 @@ const (
 	// TLSProfileModernType is a TLS security profile based on:
 	// https://wiki.mozilla.org/Security/Server_Side_TLS#Modern_compatibility
 	TLSProfileModernType TLSProfileType = "Modern"
+	// TLSProfileCustomType is a TLS security profile with user defined cipher suites
+	// and minimal TLS version. It can only be set on a TempoStack.
+	TLSProfileCustomType TLSProfileType = "Custom"
 )
 
 // MetricsFeatureGates configures metrics and alerts of the operator.
@@ -166,6 +214,13 @@ type FeatureGates struct {
 	// when using HTTPEncryption or GRPCEncryption.
 	TLSProfile string `json:"tlsProfile,omitempty"`
 
+	// FIPS restricts the TLS settings of the TempoStack components to FIPS 140 approved
+	// protocol versions (TLS 1.2 or later) and cipher suites.
+	// The TLS profile of the operator must not be Old, and the TempoStack instances cannot
+	// override the container images of the operator, as only these images are expected to be
+	// built with a FIPS validated cryptographic module.
+	FIPS bool `json:"fips,omitempty"`
+
 	// PrometheusOperator defines whether the Prometheus Operator CRD exists in the cluster.
 	// This CRD is part of prometheus-operator.
 	PrometheusOperator bool `json:"prometheusOperator,omitempty"`
@@ -189,6 +244,42 @@ type ProjectConfig struct {
 
 	// Distribution defines the operator distribution name.
 	Distribution string `json:"distribution"`
+
+	// WatchNamespaces restricts the operator to the TempoStacks in the given namespaces.
+	// All namespaces are watched if empty. The WATCH_NAMESPACE environment variable
+	// (a comma-separated list of namespaces) overrides this setting.
+	//
+	// Multiple operator instances can be installed in one cluster if they watch disjoint namespaces.
+	WatchNamespaces []string `json:"watchNamespaces,omitempty"`
+
+	// SecretNamespaces allow-lists the namespaces whose Secrets and ConfigMaps can be referenced by TempoStacks
+	// in other namespaces, e.g. to manage the object storage credentials of all tenants centrally.
+	// The operator copies the referenced Secrets and ConfigMaps into the namespace of the TempoStack
+	// and keeps the copies in sync.
+	SecretNamespaces []string `json:"secretNamespaces,omitempty"`
+}
+
+// WatchesNamespace returns true if the TempoStacks in the namespace are managed by this operator instance.
+func (c ProjectConfig) WatchesNamespace(namespace string) bool {
+	if len(c.WatchNamespaces) == 0 {
+		return true
+	}
+	for _, ns := range c.WatchNamespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsSecretNamespace returns true if TempoStacks in other namespaces can reference the Secrets and ConfigMaps of the namespace.
+func (c ProjectConfig) AllowsSecretNamespace(namespace string) bool {
+	for _, ns := range c.SecretNamespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
 }
 
 func init() {
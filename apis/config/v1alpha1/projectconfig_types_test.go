@@ -36,6 +36,26 @@ func TestValidateProjectConfig(t *testing.T) {
 			input:    ProjectConfig{},
 			expected: errors.New("invalid value '' for setting featureGates.tlsProfile (valid values: Old, Intermediate and Modern)"),
 		},
+		{
+			name: "fips with the intermediate tlsProfile",
+			input: ProjectConfig{
+				Gates: FeatureGates{
+					TLSProfile: string(TLSProfileIntermediateType),
+					FIPS:       true,
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "fips with the old tlsProfile",
+			input: ProjectConfig{
+				Gates: FeatureGates{
+					TLSProfile: string(TLSProfileOldType),
+					FIPS:       true,
+				},
+			},
+			expected: errors.New("the Old featureGates.tlsProfile allows TLS versions and cipher suites which are not FIPS approved, therefore it cannot be used with the featureGates.fips feature gate"),
+		},
 		{
 			name: "invalid tempo container image",
 			input: ProjectConfig{
@@ -72,6 +92,87 @@ func TestValidateProjectConfig(t *testing.T) {
 			},
 			expected: errors.New("invalid value 'abc@def' for setting images.tempoGateway"),
 		},
+		{
+			name: "invalid spiffeHelper container image",
+			input: ProjectConfig{
+				DefaultImages: ImagesSpec{
+					SPIFFEHelper: "abc@def",
+				},
+				Gates: FeatureGates{
+					TLSProfile: "Modern",
+				},
+			},
+			expected: errors.New("invalid value 'abc@def' for setting images.spiffeHelper"),
+		},
+		{
+			name: "invalid oauthProxy container image",
+			input: ProjectConfig{
+				DefaultImages: ImagesSpec{
+					OauthProxy: "abc@def",
+				},
+				Gates: FeatureGates{
+					TLSProfile: "Modern",
+				},
+			},
+			expected: errors.New("invalid value 'abc@def' for setting images.oauthProxy"),
+		},
+		{
+			name: "invalid memcached container image",
+			input: ProjectConfig{
+				DefaultImages: ImagesSpec{
+					Memcached: "abc@def",
+				},
+				Gates: FeatureGates{
+					TLSProfile: "Modern",
+				},
+			},
+			expected: errors.New("invalid value 'abc@def' for setting images.memcached"),
+		},
+		{
+			name: "service-ca certificates and built-in cert management",
+			input: ProjectConfig{
+				Gates: FeatureGates{
+					TLSProfile: "Modern",
+					OpenShift: OpenShiftFeatureGates{
+						ServingCertsAllServices: true,
+					},
+					BuiltInCertManagement: BuiltInCertManagement{
+						Enabled: true,
+					},
+				},
+			},
+			expected: errors.New("the featureGates.openshift.servingCertsAllServices and featureGates.builtInCertManagement feature gates cannot be enabled at the same time"),
+		},
+		{
+			name: "valid watch namespaces",
+			input: ProjectConfig{
+				Gates: FeatureGates{
+					TLSProfile: "Modern",
+				},
+				WatchNamespaces: []string{"team-a", "team-b"},
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid watch namespace",
+			input: ProjectConfig{
+				Gates: FeatureGates{
+					TLSProfile: "Modern",
+				},
+				WatchNamespaces: []string{"Team_A"},
+			},
+			expected: errors.New("invalid namespace 'Team_A' in setting watchNamespaces (must be a valid namespace name)"),
+		},
+		{
+			name: "invalid secret namespace",
+			input: ProjectConfig{
+				Gates: FeatureGates{
+					TLSProfile: "Modern",
+				},
+				SecretNamespaces: []string{"platform", "Shared_Secrets"},
+			},
+			expected: errors.New("invalid namespace 'Shared_Secrets' in setting secretNamespaces (must be a valid namespace name)"),
+		},
 	}
 
 	for _, test := range tests {
@@ -80,3 +181,19 @@ func TestValidateProjectConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestWatchesNamespace(t *testing.T) {
+	assert.True(t, ProjectConfig{}.WatchesNamespace("team-a"))
+
+	cfg := ProjectConfig{WatchNamespaces: []string{"team-a", "team-b"}}
+	assert.True(t, cfg.WatchesNamespace("team-b"))
+	assert.False(t, cfg.WatchesNamespace("team-c"))
+}
+
+func TestAllowsSecretNamespace(t *testing.T) {
+	assert.False(t, ProjectConfig{}.AllowsSecretNamespace("platform"))
+
+	cfg := ProjectConfig{SecretNamespaces: []string{"platform"}}
+	assert.True(t, cfg.AllowsSecretNamespace("platform"))
+	assert.False(t, cfg.AllowsSecretNamespace("team-a"))
+}
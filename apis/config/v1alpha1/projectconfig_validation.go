@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	dockerparser "github.com/novln/docker-parser"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // Validate validates the controller configuration (ProjectConfig).
@@ -18,6 +19,18 @@ func (c *ProjectConfig) Validate() error {
 		return fmt.Errorf("invalid value '%s' for setting featureGates.tlsProfile (valid values: %s, %s and %s)", c.Gates.TLSProfile, TLSProfileOldType, TLSProfileIntermediateType, TLSProfileModernType)
 	}
 
+	if c.Gates.FIPS && c.Gates.TLSProfile == string(TLSProfileOldType) {
+		return errors.New("the Old featureGates.tlsProfile allows TLS versions and cipher suites which are not FIPS approved, therefore it cannot be used with the featureGates.fips feature gate")
+	}
+
+	if c.Gates.OpenShift.ServingCertsAllServices && c.Gates.BuiltInCertManagement.Enabled {
+		return errors.New("the featureGates.openshift.servingCertsAllServices and featureGates.builtInCertManagement feature gates cannot be enabled at the same time")
+	}
+
+	if ca := c.Gates.BuiltInCertManagement.CASecret; ca != nil && (ca.Name == "" || ca.Namespace == "") {
+		return errors.New("the name and namespace of featureGates.builtInCertManagement.caSecret must be set")
+	}
+
 	if c.DefaultImages.Tempo != "" {
 		_, err := dockerparser.Parse(c.DefaultImages.Tempo)
 		if err != nil {
@@ -36,6 +49,47 @@ func (c *ProjectConfig) Validate() error {
 			return fmt.Errorf("invalid value '%s' for setting images.tempoGateway", c.DefaultImages.TempoGateway)
 		}
 	}
+	if c.DefaultImages.SPIFFEHelper != "" {
+		_, err := dockerparser.Parse(c.DefaultImages.SPIFFEHelper)
+		if err != nil {
+			return fmt.Errorf("invalid value '%s' for setting images.spiffeHelper", c.DefaultImages.SPIFFEHelper)
+		}
+	}
+	if c.DefaultImages.OauthProxy != "" {
+		_, err := dockerparser.Parse(c.DefaultImages.OauthProxy)
+		if err != nil {
+			return fmt.Errorf("invalid value '%s' for setting images.oauthProxy", c.DefaultImages.OauthProxy)
+		}
+	}
+	if c.DefaultImages.Memcached != "" {
+		_, err := dockerparser.Parse(c.DefaultImages.Memcached)
+		if err != nil {
+			return fmt.Errorf("invalid value '%s' for setting images.memcached", c.DefaultImages.Memcached)
+		}
+	}
+	if c.DefaultImages.TempoCLI != "" {
+		_, err := dockerparser.Parse(c.DefaultImages.TempoCLI)
+		if err != nil {
+			return fmt.Errorf("invalid value '%s' for setting images.tempoCLI", c.DefaultImages.TempoCLI)
+		}
+	}
+	if c.DefaultImages.Backup != "" {
+		_, err := dockerparser.Parse(c.DefaultImages.Backup)
+		if err != nil {
+			return fmt.Errorf("invalid value '%s' for setting images.backup", c.DefaultImages.Backup)
+		}
+	}
+
+	for _, namespace := range c.WatchNamespaces {
+		if len(validation.IsDNS1123Label(namespace)) > 0 {
+			return fmt.Errorf("invalid namespace '%s' in setting watchNamespaces (must be a valid namespace name)", namespace)
+		}
+	}
+	for _, namespace := range c.SecretNamespaces {
+		if len(validation.IsDNS1123Label(namespace)) > 0 {
+			return fmt.Errorf("invalid namespace '%s' in setting secretNamespaces (must be a valid namespace name)", namespace)
+		}
+	}
 
 	if c.Gates.Observability.Metrics.CreateServiceMonitors && !c.Gates.PrometheusOperator {
 		return errors.New("the prometheusOperator feature gate must be enabled to create a ServiceMonitor for the operator")
@@ -16,6 +16,11 @@ func (in *BuiltInCertManagement) DeepCopyInto(out *BuiltInCertManagement) {
 	out.CACertRefresh = in.CACertRefresh
 	out.CertValidity = in.CertValidity
 	out.CertRefresh = in.CertRefresh
+	if in.CASecret != nil {
+		in, out := &in.CASecret, &out.CASecret
+		*out = new(CASecretReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuiltInCertManagement.
@@ -28,11 +33,26 @@ func (in *BuiltInCertManagement) DeepCopy() *BuiltInCertManagement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CASecretReference) DeepCopyInto(out *CASecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CASecretReference.
+func (in *CASecretReference) DeepCopy() *CASecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CASecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureGates) DeepCopyInto(out *FeatureGates) {
 	*out = *in
 	out.OpenShift = in.OpenShift
-	out.BuiltInCertManagement = in.BuiltInCertManagement
+	in.BuiltInCertManagement.DeepCopyInto(&out.BuiltInCertManagement)
 	out.Observability = in.Observability
 }
 
@@ -113,7 +133,17 @@ func (in *ProjectConfig) DeepCopyInto(out *ProjectConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ControllerManagerConfigurationSpec.DeepCopyInto(&out.ControllerManagerConfigurationSpec)
 	out.DefaultImages = in.DefaultImages
-	out.Gates = in.Gates
+	in.Gates.DeepCopyInto(&out.Gates)
+	if in.WatchNamespaces != nil {
+		in, out := &in.WatchNamespaces, &out.WatchNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretNamespaces != nil {
+		in, out := &in.SecretNamespaces, &out.SecretNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectConfig.
@@ -3,7 +3,7 @@ package v1alpha1
 // ModeType is the authentication/authorization mode in which Tempo Gateway
 // will be configured.
 //
-// +kubebuilder:validation:Enum=static;openshift
+// +kubebuilder:validation:Enum=static;openshift;trustedHeader
 type ModeType string
 
 const (
@@ -12,17 +12,26 @@ const (
 	ModeStatic ModeType = "static"
 	// ModeOpenShift mode uses TokenReview API for authentication and subject access review for authorization.
 	ModeOpenShift ModeType = "openshift"
+	// ModeTrustedHeader mode accepts the tenant header (X-Scope-OrgID) of the requests without authentication.
+	// The authentication has to be performed by an upstream proxy or collector.
+	ModeTrustedHeader ModeType = "trustedHeader"
 )
 
 // TenantsSpec defines the mode, authentication and authorization
 // configuration of the tempo gateway component.
+//
+// Storage isolation between tenants is supported by object prefix only, per-tenant buckets are not supported.
+// All tenants share the object storage bucket of the TempoStack, and Tempo stores the blocks of each tenant
+// below its tenant ID, i.e. the tenantId of a tenant is its object prefix in the bucket.
+// With the gateway, every tenant must be mapped to its own prefix.
+// Deploy a separate TempoStack per tenant if the data must be stored in separate buckets.
 type TenantsSpec struct {
 	// Mode defines the multitenancy mode.
 	//
 	// +required
 	// +kubebuilder:validation:Required
 	// +kubebuilder:default:=static
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:static","urn:alm:descriptor:com.tectonic.ui:select:openshift"},displayName="Mode"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:static","urn:alm:descriptor:com.tectonic.ui:select:openshift","urn:alm:descriptor:com.tectonic.ui:select:trustedHeader"},displayName="Mode"
 	Mode ModeType `json:"mode"`
 
 	// Authentication defines the tempo-gateway component authentication configuration spec per tenant.
@@ -37,6 +46,35 @@ type TenantsSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Authorization"
 	Authorization *AuthorizationSpec `json:"authorization,omitempty"`
+	// TrustedHeader restricts the clients which are allowed to set the tenant header in trustedHeader mode.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Trusted Header"
+	TrustedHeader *TrustedHeaderSpec `json:"trustedHeader,omitempty"`
+}
+
+// TrustedHeaderSpec defines the clients which are trusted to set the tenant header.
+type TrustedHeaderSpec struct {
+	// AllowedCIDRs restricts the ingress traffic of the distributor and query-frontend to the given
+	// source IP ranges (CIDR notation) with a NetworkPolicy.
+	// The traffic between the components of the TempoStack is always allowed.
+	// Note that the metrics endpoints are restricted as well, therefore the IP range of the
+	// monitoring stack needs to be included.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Allowed CIDRs"
+	AllowedCIDRs []string `json:"allowedCIDRs,omitempty"`
+
+	// RequireClientCertificate requires the clients to present a client certificate signed by the
+	// CA of the receivers (spec.template.distributor.tls.caName) to ingest traces.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Require Client Certificate",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	RequireClientCertificate bool `json:"requireClientCertificate,omitempty"`
 }
 
 // SubjectKind is a kind of Tempo Gateway RBAC subject.
@@ -79,6 +117,45 @@ type AuthorizationSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Static Role Bindings"
 	RoleBindings []RoleBindingsSpec `json:"roleBindings"`
+	// Policy references a custom Rego policy, which replaces the built-in policy of the static mode.
+	// The roles and role bindings are not evaluated if a custom policy is configured.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Custom Policy"
+	Policy *OPAPolicySpec `json:"policy,omitempty"`
+}
+
+// OPAPolicySpec references a Rego policy stored in a ConfigMap or Secret
+// in the same namespace as the TempoStack.
+type OPAPolicySpec struct {
+	// ConfigMap is the name of the ConfigMap containing the policy.
+	// Exactly one of configMap or secret must be set.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap",displayName="ConfigMap"
+	ConfigMap string `json:"configMap,omitempty"`
+	// Secret is the name of the Secret containing the policy.
+	// Exactly one of configMap or secret must be set.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:Secret",displayName="Secret"
+	Secret string `json:"secret,omitempty"`
+	// Key is the key of the Rego file in the ConfigMap or Secret.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="policy.rego"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Key"
+	Key string `json:"key,omitempty"`
+	// Query is the Rego query evaluated for each request, e.g. data.tempostack.allow.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Query"
+	Query string `json:"query"`
 }
 
 // PermissionType is a Tempo Gateway RBAC permission.
@@ -101,8 +178,7 @@ type RoleSpec struct {
 	Permissions []PermissionType `json:"permissions"`
 }
 
-// TenantSecretSpec is a secret reference containing name only
-// for a secret living in the same namespace as the (Tempo) TempoStack custom resource.
+// TenantSecretSpec is a reference to a tenant secret.
 type TenantSecretSpec struct {
 	// Name of a secret in the namespace configured for tenant secrets.
 	//
@@ -110,9 +186,19 @@ type TenantSecretSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:Secret",displayName="Tenant Secret Name"
 	Name string `json:"name"`
+
+	// Namespace of the secret, if it is not in the namespace of the TempoStack custom resource.
+	// The namespace must be allow-listed in the secretNamespaces setting of the operator.
+	// The operator copies the secret with the same name into the namespace of the TempoStack and keeps the copy in sync.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tenant Secret Namespace"
+	Namespace string `json:"namespace,omitempty"`
 }
 
-// AuthenticationSpec defines the oidc configuration per tenant for tempo Gateway component.
+// AuthenticationSpec defines a tenant: its authentication in the tempo Gateway component,
+// and its limits, retention and Grafana datasource.
 type AuthenticationSpec struct {
 	// TenantName defines the name of the tenant.
 	// The value of this field should be sent in X-Scope-OrgID header to identify the tenant.
@@ -122,6 +208,8 @@ type AuthenticationSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tenant Name"
 	TenantName string `json:"tenantName"`
 	// TenantID defines the id of the tenant.
+	// The gateway sends the tenant ID to Tempo, which stores the blocks of the tenant below this prefix in the object storage.
+	// Therefore the tenant ID must be unique and may only contain alphanumeric characters or !-_.*'().
 	//
 	// +required
 	// +kubebuilder:validation:Required
@@ -133,6 +221,61 @@ type AuthenticationSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OIDC Configuration"
 	OIDC *OIDCSpec `json:"oidc,omitempty"`
+	// MTLS defines the spec for the client certificate authentication of the tenant.
+	// Only one of oidc or mTLS can be set.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="mTLS Configuration"
+	MTLS *MTLSSpec `json:"mTLS,omitempty"`
+	// Audiences defines the audiences of the bound service account tokens accepted for the tenant in openshift mode.
+	// The gateway reviews the bearer tokens with these audiences, i.e. a token is only accepted if it was minted
+	// for at least one of the audiences, for example with a projected service account token volume.
+	// By default the tokens are reviewed with the audiences of the API server.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Token Audiences"
+	Audiences []string `json:"audiences,omitempty"`
+	// Limits defines the ingestion and query limits of the tenant.
+	// They take precedence over the limits of the tenant in spec.limits.perTenant.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tenant Limits"
+	Limits *RateLimitSpec `json:"limits,omitempty"`
+	// Retention defines the retention of the traces of the tenant.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tenant Retention"
+	Retention *RetentionConfig `json:"retention,omitempty"`
+	// GrafanaDatasource provisions a Grafana datasource for the tenant.
+	// The datasources of all tenants are stored in a ConfigMap with the grafana_datasource label,
+	// which is loaded by the Grafana datasource sidecar.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Grafana Datasource",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	GrafanaDatasource bool `json:"grafanaDatasource,omitempty"`
+}
+
+// MTLSSpec defines the client certificate authentication of a tenant.
+//
+// The clients of the tenant need to present a client certificate signed by the CA of the tenant.
+// The common name (CN) of the certificate, or the first DNS or email SAN if the common name is empty,
+// is used as the subject in the role bindings (spec.tenants.authorization.roleBindings).
+// The gateway uses the certificate of the built-in cert management as serving certificate,
+// therefore the featureGates.httpEncryption feature gate is required.
+type MTLSSpec struct {
+	// CA is the name of a ConfigMap containing the CA bundle (service-ca.crt) used to verify the client certificates of the tenant.
+	// It needs to be in the same namespace as the TempoStack custom resource.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap",displayName="CA ConfigMap Name"
+	CA string `json:"caName"`
 }
 
 // OIDCSpec defines the oidc configuration spec for Tempo Gateway component.
@@ -165,4 +308,29 @@ type OIDCSpec struct {
 	// +optional
 	// +kubebuilder:validation:Optional
 	UsernameClaim string `json:"usernameClaim,omitempty"`
+	// GroupMappings grants permissions on this tenant to the groups of the identity provider.
+	// The operator renders a role and a role binding for each mapping into the gateway RBAC configuration.
+	// Requires the groupClaim field.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Mappings"
+	GroupMappings []OIDCGroupMappingSpec `json:"groupMappings,omitempty"`
+}
+
+// OIDCGroupMappingSpec maps a group of the identity provider to permissions on a tenant.
+type OIDCGroupMappingSpec struct {
+	// Group is the name of the group in the group claim of the ID token.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group"
+	Group string `json:"group"`
+	// Permissions are the permissions of the group on the tenant.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Permissions"
+	Permissions []PermissionType `json:"permissions"`
 }
@@ -0,0 +1,28 @@
+package v1alpha1
+
+import (
+	"github.com/grafana/tempo-operator/apis/config/v1alpha1"
+)
+
+// EffectiveFeatureGates returns the feature gates of the operator configuration
+// with the overrides of the TempoStack applied.
+func EffectiveFeatureGates(fg v1alpha1.FeatureGates, tempo TempoStack) v1alpha1.FeatureGates {
+	overrides := tempo.Spec.FeatureGates
+	if overrides == nil {
+		return fg
+	}
+
+	if overrides.HTTPEncryption != nil {
+		fg.HTTPEncryption = *overrides.HTTPEncryption
+	}
+	if overrides.GRPCEncryption != nil {
+		fg.GRPCEncryption = *overrides.GRPCEncryption
+	}
+	if overrides.OpenShiftRoute != nil {
+		fg.OpenShift.OpenShiftRoute = *overrides.OpenShiftRoute
+	}
+	if overrides.PrometheusOperator != nil {
+		fg.PrometheusOperator = *overrides.PrometheusOperator
+	}
+	return fg
+}
@@ -0,0 +1,53 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	"github.com/grafana/tempo-operator/apis/config/v1alpha1"
+)
+
+func TestEffectiveFeatureGates(t *testing.T) {
+	fg := v1alpha1.FeatureGates{
+		HTTPEncryption: true,
+		GRPCEncryption: true,
+		TLSProfile:     string(v1alpha1.TLSProfileModernType),
+	}
+
+	tests := []struct {
+		name     string
+		input    *FeatureGatesSpec
+		expected v1alpha1.FeatureGates
+	}{
+		{
+			name:     "no overrides",
+			expected: fg,
+		},
+		{
+			name:  "override all",
+			input: &FeatureGatesSpec{HTTPEncryption: pointer.Bool(false), GRPCEncryption: pointer.Bool(false), OpenShiftRoute: pointer.Bool(true), PrometheusOperator: pointer.Bool(true)},
+			expected: v1alpha1.FeatureGates{
+				OpenShift:          v1alpha1.OpenShiftFeatureGates{OpenShiftRoute: true},
+				PrometheusOperator: true,
+				TLSProfile:         string(v1alpha1.TLSProfileModernType),
+			},
+		},
+		{
+			name:  "override some",
+			input: &FeatureGatesSpec{GRPCEncryption: pointer.Bool(false)},
+			expected: v1alpha1.FeatureGates{
+				HTTPEncryption: true,
+				TLSProfile:     string(v1alpha1.TLSProfileModernType),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := TempoStack{Spec: TempoStackSpec{FeatureGates: test.input}}
+			assert.Equal(t, test.expected, EffectiveFeatureGates(fg, tempo))
+		})
+	}
+}
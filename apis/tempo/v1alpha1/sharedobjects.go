@@ -0,0 +1,60 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// SharedObjectKind is the kind of an object referenced from another namespace.
+type SharedObjectKind string
+
+const (
+	// SharedSecret is a Secret referenced from another namespace.
+	SharedSecret SharedObjectKind = "Secret"
+	// SharedConfigMap is a ConfigMap referenced from another namespace.
+	SharedConfigMap SharedObjectKind = "ConfigMap"
+)
+
+// SharedObjectReference is a reference of a TempoStack to a Secret or ConfigMap in another namespace.
+//
+// +kubebuilder:object:generate=false
+type SharedObjectReference struct {
+	Kind      SharedObjectKind
+	Namespace string
+	Name      string
+	// Path is the field of the TempoStack containing the namespace of the reference.
+	Path *field.Path
+}
+
+// SharedObjectReferences returns the Secrets and ConfigMaps which are referenced from a namespace
+// other than the namespace of the TempoStack.
+func SharedObjectReferences(tempo TempoStack) []SharedObjectReference {
+	var refs []SharedObjectReference
+	add := func(kind SharedObjectKind, namespace string, name string, path *field.Path) {
+		if namespace == "" || namespace == tempo.Namespace || name == "" {
+			return
+		}
+		refs = append(refs, SharedObjectReference{Kind: kind, Namespace: namespace, Name: name, Path: path})
+	}
+
+	storagePath := field.NewPath("spec", "storage")
+	add(SharedSecret, tempo.Spec.Storage.Secret.Namespace, tempo.Spec.Storage.Secret.Name, storagePath.Child("secret", "namespace"))
+	if migrateFrom := tempo.Spec.Storage.MigrateFrom; migrateFrom != nil {
+		add(SharedSecret, migrateFrom.Namespace, migrateFrom.Name, storagePath.Child("migrateFrom", "namespace"))
+	}
+	if tls := tempo.Spec.Storage.TLS; tls != nil {
+		add(SharedConfigMap, tls.CANamespace, tls.CA, storagePath.Child("tls", "caNamespace"))
+	}
+	if backup := tempo.Spec.Backup; backup != nil {
+		add(SharedSecret, backup.Secret.Namespace, backup.Secret.Name, field.NewPath("spec", "backup", "secret", "namespace"))
+	}
+	if tempo.Spec.Tenants != nil {
+		for i, tenant := range tempo.Spec.Tenants.Authentication {
+			if tenant.OIDC == nil || tenant.OIDC.Secret == nil {
+				continue
+			}
+			add(SharedSecret, tenant.OIDC.Secret.Namespace, tenant.OIDC.Secret.Name,
+				field.NewPath("spec", "tenants", "authentication").Index(i).Child("oidc", "secret", "namespace"))
+		}
+	}
+	return refs
+}
@@ -18,6 +18,22 @@ func ValidateStorageSecret(tempo TempoStack, storageSecret corev1.Secret) field.
 
 	var allErrs field.ErrorList
 
+	if tempo.Spec.Storage.SecretProviderClass != "" && tempo.Spec.Storage.Secret.Type == ObjectStorageSecretAzure {
+		allErrs = append(allErrs, field.Invalid(
+			field.NewPath("spec").Child("storage").Child("secretProviderClass"),
+			tempo.Spec.Storage.SecretProviderClass,
+			"secret provider classes are not supported by the azure storage type",
+		))
+	}
+
+	if tempo.Spec.Storage.S3 != nil && tempo.Spec.Storage.Secret.Type != ObjectStorageSecretS3 {
+		allErrs = append(allErrs, field.Invalid(
+			field.NewPath("spec").Child("storage").Child("s3"),
+			tempo.Spec.Storage.S3,
+			fmt.Sprintf("the s3 options are not supported by the %s storage type", tempo.Spec.Storage.Secret.Type),
+		))
+	}
+
 	switch tempo.Spec.Storage.Secret.Type {
 	case ObjectStorageSecretAzure:
 		allErrs = append(allErrs, validateAzureSecret(tempo, path, storageSecret)...)
@@ -65,6 +81,18 @@ func validateAzureSecret(tempo TempoStack, path *field.Path, storageSecret corev
 	}
 
 	allErrs = append(allErrs, ensureNotEmpty(tempo, path, storageSecret, secretFields)...)
+
+	if environment, ok := storageSecret.Data["environment"]; ok {
+		switch string(environment) {
+		case "AzureGlobal", "AzureUSGovernment", "AzureChinaCloud":
+		default:
+			allErrs = append(allErrs, field.Invalid(
+				path,
+				tempo.Spec.Storage.Secret,
+				"\"environment\" field of storage secret must be one of AzureGlobal, AzureUSGovernment or AzureChinaCloud",
+			))
+		}
+	}
 	return allErrs
 }
 
@@ -72,10 +100,15 @@ func validateGCSSecret(tempo TempoStack, path *field.Path, storageSecret corev1.
 	var allErrs field.ErrorList
 	secretFields := []string{
 		"bucketname",
-		"key.json",
+	}
+	// The credentials are mounted from the CSI volume if a SecretProviderClass is set.
+	if tempo.Spec.Storage.SecretProviderClass == "" {
+		secretFields = append(secretFields, "key.json")
 	}
 
 	allErrs = append(allErrs, ensureNotEmpty(tempo, path, storageSecret, secretFields)...)
+	// The endpoint is optional and allows to use GCS emulators or private endpoints.
+	allErrs = append(allErrs, validateEndpoint(tempo, path, storageSecret)...)
 	return allErrs
 }
 
@@ -84,24 +117,33 @@ func validateS3Secret(tempo TempoStack, path *field.Path, storageSecret corev1.S
 	secretFields := []string{
 		"endpoint",
 		"bucket",
-		"access_key_id",
-		"access_key_secret",
+	}
+	// The credentials are mounted from the CSI volume if a SecretProviderClass is set.
+	if tempo.Spec.Storage.SecretProviderClass == "" {
+		secretFields = append(secretFields, "access_key_id", "access_key_secret")
 	}
 
 	allErrs = append(allErrs, ensureNotEmpty(tempo, path, storageSecret, secretFields)...)
 
-	if endpoint, ok := storageSecret.Data["endpoint"]; ok {
-		u, err := url.ParseRequestURI(string(endpoint))
+	allErrs = append(allErrs, validateEndpoint(tempo, path, storageSecret)...)
+	return allErrs
+}
 
-		// ParseRequestURI also accepts absolute paths, therefore we need to check if the URL scheme is set
-		if err != nil || u.Scheme == "" {
-			allErrs = append(allErrs, field.Invalid(
-				path,
-				tempo.Spec.Storage.Secret,
-				"\"endpoint\" field of storage secret must be a valid URL",
-			))
-		}
+func validateEndpoint(tempo TempoStack, path *field.Path, storageSecret corev1.Secret) field.ErrorList {
+	endpoint, ok := storageSecret.Data["endpoint"]
+	if !ok {
+		return nil
 	}
 
-	return allErrs
+	u, err := url.ParseRequestURI(string(endpoint))
+
+	// ParseRequestURI also accepts absolute paths, therefore we need to check if the URL scheme is set
+	if err != nil || u.Scheme == "" {
+		return field.ErrorList{field.Invalid(
+			path,
+			tempo.Spec.Storage.Secret,
+			"\"endpoint\" field of storage secret must be a valid URL",
+		)}
+	}
+	return nil
 }
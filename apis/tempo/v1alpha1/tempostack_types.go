@@ -1,9 +1,12 @@
 package v1alpha1
 
 import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/grafana/tempo-operator/apis/config/v1alpha1"
 )
@@ -27,6 +30,8 @@ const (
 type TempoStackSpec struct {
 	// ManagementState defines if the CR should be managed by the operator or not.
 	// Default is managed.
+	// An Unmanaged TempoStack is not reconciled, therefore changes of the generated resources, e.g. hot fixes
+	// during an incident, are not reverted. Switching back to Managed reverts all changes of the generated resources.
 	//
 	// +required
 	// +kubebuilder:validation:Required
@@ -34,6 +39,14 @@ type TempoStackSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Managed","urn:alm:descriptor:com.tectonic.ui:select:Unmanaged"},displayName="Management State"
 	ManagementState ManagementStateType `json:"managementState,omitempty"`
 
+	// Hibernate scales all components to zero replicas, e.g. for development stacks which are only used during work hours.
+	// The persistent volumes and the configuration are kept, and the components are scaled up again once hibernation is disabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Hibernate",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Hibernate bool `json:"hibernate,omitempty"`
+
 	// LimitSpec is used to limit ingestion and querying rates.
 	//
 	// +optional
@@ -63,6 +76,15 @@ type TempoStackSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Container Images"
 	Images v1alpha1.ImagesSpec `json:"images,omitempty"`
 
+	// ImagePullSecrets are the secrets used to pull the container images of all components,
+	// e.g. from a private registry. The secrets need to be in the same namespace as the TempoStack custom resource.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image Pull Secrets"
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
 	// Storage defines the spec for the object storage endpoint to store traces.
 	// User is required to create secret and supply it.
 	//
@@ -70,6 +92,14 @@ type TempoStackSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Object Storage"
 	Storage ObjectStorageSpec `json:"storage"`
 
+	// Cache defines an existing memcached or redis cache, which caches the bloom filters and the Parquet footers of the blocks.
+	// If unset, no cache is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cache"
+	Cache *CacheSpec `json:"cache,omitempty"`
+
 	// NOTE: currently this field is not considered.
 	// Retention period defined by dataset.
 	// User can specify how long data should be stored.
@@ -96,10 +126,12 @@ type TempoStackSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tempo Component Templates"
 	Template TempoTemplateSpec `json:"template,omitempty"`
 
-	// NOTE: currently this field is not considered.
-	// ReplicationFactor is used to define how many component replicas should exist.
+	// ReplicationFactor defines to how many ingesters each trace is written. Defaults to 1.
+	// A replication factor of 3 tolerates the loss of an ingester, but requires at least 2 ingester replicas
+	// and increases the storage and network costs.
 	//
 	// +optional
+	// +kubebuilder:validation:Minimum=1
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Replication Factor"
 	ReplicationFactor int `json:"replicationFactor,omitempty"`
 
@@ -116,343 +148,2843 @@ type TempoStackSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Observability"
 	Observability ObservabilitySpec `json:"observability,omitempty"`
-}
 
-// ObservabilitySpec defines how telemetry data gets handled.
-type ObservabilitySpec struct {
-	// Metrics defines the metrics configuration for operands.
+	// CertManager configures cert-manager to issue the certificates of the Tempo components,
+	// as an alternative to the built-in cert management of the operator.
+	// Requires the httpEncryption or grpcEncryption feature gate.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Config"
-	Metrics MetricsConfigSpec `json:"metrics,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="cert-manager"
+	CertManager *CertManagerSpec `json:"certManager,omitempty"`
 
-	// Tracing defines a config for operands.
+	// TLSProfile overrides the TLS security profile of the operator for this TempoStack.
+	// If not set, the profile configured in the featureGates.tlsProfile setting
+	// (or the cluster TLS policy on OpenShift) is used.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tracing Config"
-	Tracing TracingConfigSpec `json:"tracing,omitempty"`
-}
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS Profile"
+	TLSProfile *TLSProfileSpec `json:"tlsProfile,omitempty"`
 
-// MetricsConfigSpec defines a metrics config.
-type MetricsConfigSpec struct {
-	// CreateServiceMonitors specifies if ServiceMonitors should be created for Tempo components.
+	// SPIFFE configures the Tempo components to use the X.509 SVIDs issued by SPIRE
+	// as client and serving certificates of the internal mTLS connections,
+	// as an alternative to the built-in cert management of the operator.
+	// Requires the httpEncryption or grpcEncryption feature gate.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Create ServiceMonitors for Tempo components"
-	CreateServiceMonitors bool `json:"createServiceMonitors,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="SPIFFE"
+	SPIFFE *SPIFFESpec `json:"spiffe,omitempty"`
 
-	// CreatePrometheusRules specifies if Prometheus rules for alerts should be created for Tempo components.
+	// Ports overrides the ports of the HTTP and gRPC servers of the Tempo components.
+	// The Services keep the default ports and forward to the configured ports.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Create PrometheusRules for Tempo components"
-	CreatePrometheusRules bool `json:"createPrometheusRules,omitempty"`
-}
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Server Ports"
+	Ports *ServerPortsSpec `json:"ports,omitempty"`
 
-// TracingConfigSpec defines a tracing config including endpoints and sampling.
-type TracingConfigSpec struct {
-	// SamplingFraction defines the sampling ratio. Valid values are 0 to 1.
+	// GRPCServer tunes the gRPC servers of the Tempo components and the gRPC clients of the query path.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Sampling Fraction"
-	SamplingFraction string `json:"sampling_fraction,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="gRPC Server"
+	GRPCServer *GRPCServerSpec `json:"grpcServer,omitempty"`
 
-	// JaegerAgentEndpoint defines the jaeger endpoint data gets send to.
+	// Memberlist configures the gossip between the Tempo components, which propagates the hash rings.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:default:="localhost:6831"
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jaeger-Agent-Endpoint"
-	JaegerAgentEndpoint string `json:"jaeger_agent_endpoint,omitempty"`
-}
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Memberlist"
+	Memberlist *MemberlistSpec `json:"memberlist,omitempty"`
 
-// PodStatusMap defines the type for mapping pod status to pod name.
-type PodStatusMap map[corev1.PodPhase][]string
+	// KVStore configures the key-value store of the hash rings. Defaults to the memberlist.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ring KV Store"
+	KVStore *KVStoreSpec `json:"kvStore,omitempty"`
 
-// ComponentStatus defines the status of each component.
-type ComponentStatus struct {
-	// Compactor is a map to the pod status of the compactor pod.
+	// ExtraConfig defines additional configuration, which is merged into the configuration generated by the operator.
+	// Use it for settings which are not exposed in the TempoStack CR.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Compactor",order=5
-	Compactor PodStatusMap `json:"compactor"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Extra Configuration"
+	ExtraConfig *ExtraConfigSpec `json:"extraConfig,omitempty"`
 
-	// Distributor is a map to the per pod status of the distributor deployment
+	// NetworkPolicy configures NetworkPolicies, which restrict the traffic of all components
+	// to the flows required by the TempoStack.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Distributor",order=1
-	Distributor PodStatusMap `json:"distributor"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Network Policy"
+	NetworkPolicy *NetworkPolicySpec `json:"networkPolicy,omitempty"`
 
-	// Ingester is a map to the per pod status of the ingester statefulset
+	// ServiceMesh configures the TempoStack to run inside an Istio service mesh (e.g. OpenShift Service Mesh).
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Ingester",order=2
-	Ingester PodStatusMap `json:"ingester"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Mesh"
+	ServiceMesh *ServiceMeshSpec `json:"serviceMesh,omitempty"`
 
-	// Querier is a map to the per pod status of the querier deployment
+	// ServiceTopology configures the routing of the traffic to the internal Services of the components,
+	// e.g. to reduce the cross-zone traffic in multi-zone clusters.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Querier",order=3
-	Querier PodStatusMap `json:"querier"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Topology"
+	ServiceTopology *ServiceTopologySpec `json:"serviceTopology,omitempty"`
 
-	// QueryFrontend is a map to the per pod status of the query frontend deployment
+	// OpenTelemetryCollector creates an OpenTelemetryCollector of the OpenTelemetry Operator,
+	// which receives OTLP traces and exports them to the gateway or distributor of this TempoStack.
+	// Requires the OpenTelemetry Operator to be installed in the cluster.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Query Frontend",order=4
-	QueryFrontend PodStatusMap `json:"queryFrontend"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OpenTelemetry Collector"
+	OpenTelemetryCollector *OpenTelemetryCollectorSpec `json:"openTelemetryCollector,omitempty"`
 
-	// Gateway is a map to the per pod status of the query frontend deployment
+	// Backup creates a CronJob which periodically copies the traces, including the blocks metadata
+	// and the tenant indexes, from the object storage of this TempoStack to a backup bucket.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Query Frontend",order=4
-	Gateway PodStatusMap `json:"gateway"`
-}
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Backup"
+	Backup *BackupSpec `json:"backup,omitempty"`
 
-// TempoStackStatus defines the observed state of TempoStack.
-type TempoStackStatus struct {
-	// Version of the Tempo Operator.
+	// CLIJobs creates a Job for each entry, which runs a tempo-cli command against the object storage of this TempoStack,
+	// e.g. to list or analyse the blocks of a tenant for troubleshooting.
+	// The output of the command is available in the logs of the Job.
+	//
 	// +optional
-	OperatorVersion string `json:"operatorVersion,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="tempo-cli Jobs"
+	CLIJobs []TempoCLIJobSpec `json:"cliJobs,omitempty"`
 
-	// Version of the managed Tempo instance.
+	// TenantPurges creates a Job for each entry, which deletes all blocks of a tenant from the object storage
+	// of this TempoStack, e.g. to honor a data deletion request. The progress of the Jobs is reported in
+	// status.tenantPurges.
+	//
 	// +optional
-	TempoVersion string `json:"tempoVersion,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tenant Purges"
+	TenantPurges []TenantPurgeSpec `json:"tenantPurges,omitempty"`
 
-	// DEPRECATED. Version of the Tempo Query component used.
+	// Canary enables the operator to periodically write a synthetic trace to the distributor and to query it back
+	// from the query-frontend, to detect a broken write or read path. The result is reported in the PipelineHealthy
+	// condition and in the tempostack_canary_* metrics of the operator.
+	//
 	// +optional
-	TempoQueryVersion string `json:"tempoQueryVersion,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Canary"
+	Canary *CanarySpec `json:"canary,omitempty"`
 
-	// Components provides summary of all Tempo pod status grouped
-	// per component.
+	// FeatureGates overrides feature gates of the operator configuration for this TempoStack.
+	// Feature gates which are not set are inherited from the operator configuration.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	Components ComponentStatus `json:"components,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Feature Gates"
+	FeatureGates *FeatureGatesSpec `json:"featureGates,omitempty"`
+}
 
-	// Conditions of the Tempo deployment health.
+// FeatureGatesSpec defines the feature gates which can be overridden per TempoStack.
+type FeatureGatesSpec struct {
+	// HTTPEncryption overrides the featureGates.httpEncryption feature gate,
+	// which enables the TLS encryption of the HTTP connections between the components.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
-	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="HTTP Encryption",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	HTTPEncryption *bool `json:"httpEncryption,omitempty"`
+
+	// GRPCEncryption overrides the featureGates.grpcEncryption feature gate,
+	// which enables the TLS encryption of the gRPC connections between the components.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="gRPC Encryption",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	GRPCEncryption *bool `json:"grpcEncryption,omitempty"`
+
+	// OpenShiftRoute overrides the featureGates.openshift.openshiftRoute feature gate,
+	// which allows to expose the components with OpenShift Routes.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OpenShift Route",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	OpenShiftRoute *bool `json:"openshiftRoute,omitempty"`
+
+	// PrometheusOperator overrides the featureGates.prometheusOperator feature gate,
+	// which allows to create ServiceMonitors and PrometheusRules of the Prometheus Operator.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prometheus Operator",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	PrometheusOperator *bool `json:"prometheusOperator,omitempty"`
 }
 
-// ConditionStatus defines the status of a condition (e.g. ready, failed, pending or configuration error).
-type ConditionStatus string
+// BackupSpec defines the backup of the object storage of the TempoStack.
+//
+// The CronJob is named tempo-<name>-backup and synchronizes the backup bucket with the storage bucket,
+// i.e. each run only copies the new blocks and the updated metadata, and removes the deleted blocks from the backup.
+// A backup can be started manually with "kubectl create job --from=cronjob/tempo-<name>-backup <job name>".
+// The traces are restored by creating a new TempoStack whose storage secret points to the backup bucket
+// (or to a bucket where the backup was copied to).
+type BackupSpec struct {
+	// Schedule of the backup in cron format, e.g. "0 1 * * *".
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Schedule"
+	Schedule string `json:"schedule"`
 
-const (
-	// ConditionReady defines that all components are ready.
-	ConditionReady ConditionStatus = "Ready"
-	// ConditionFailed defines that one or more components are in a failed state.
-	ConditionFailed ConditionStatus = "Failed"
-	// ConditionPending defines that one or more components are in a pending state.
-	ConditionPending ConditionStatus = "Pending"
-	// ConditionConfigurationError defines that there is a configuration error.
-	ConditionConfigurationError ConditionStatus = "ConfigurationError"
-)
+	// Secret of the backup bucket, in the same format as the storage secret.
+	// The secret needs to be in the same namespace as the TempoStack custom resource, unless its namespace is set.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Backup Storage Secret"
+	Secret ObjectStorageSecretSpec `json:"secret"`
 
-// AllStatusConditions lists all possible status conditions.
-var AllStatusConditions = []ConditionStatus{ConditionReady, ConditionFailed, ConditionPending, ConditionConfigurationError}
+	// Suspend pauses the scheduled backups, e.g. during a restore.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Suspend",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Suspend bool `json:"suspend,omitempty"`
+}
 
-// ConditionReason defines possible reasons for each condition.
-type ConditionReason string
+// TempoCLICommand is a command of tempo-cli.
+//
+// +kubebuilder:validation:Enum=list-blocks;analyse-block;search-blocks;gen-index
+type TempoCLICommand string
 
 const (
-	// ReasonReady defines a healthy tempo instance.
-	ReasonReady ConditionReason = "Ready"
-	// ReasonInvalidStorageConfig defines that the object storage configuration is invalid (missing or incomplete storage secret).
-	ReasonInvalidStorageConfig ConditionReason = "InvalidStorageConfig"
-	// ReasonFailedComponents when all/some Tempo components fail to roll out.
-	ReasonFailedComponents ConditionReason = "FailedComponents"
-	// ReasonPendingComponents when all/some Tempo components pending dependencies.
-	ReasonPendingComponents ConditionReason = "PendingComponents"
-	// ReasonCouldNotGetOpenShiftBaseDomain when operator cannot get OpenShift base domain, that is used for OAuth redirect URL.
-	ReasonCouldNotGetOpenShiftBaseDomain ConditionReason = "CouldNotGetOpenShiftBaseDomain"
-	// ReasonCouldNotGetOpenShiftTLSPolicy when operator cannot get OpenShift TLS security cluster policy.
-	ReasonCouldNotGetOpenShiftTLSPolicy ConditionReason = "CouldNotGetOpenShiftTLSPolicy"
-	// ReasonMissingGatewayTenantSecret when operator cannot get Secret containing sensitive Gateway information.
-	ReasonMissingGatewayTenantSecret ConditionReason = "ReasonMissingGatewayTenantSecret"
-	// ReasonInvalidTenantsConfiguration when the tenant configuration provided is invalid.
-	ReasonInvalidTenantsConfiguration ConditionReason = "InvalidTenantsConfiguration"
-	// ReasonFailedReconciliation when the operator failed to reconcile.
-	ReasonFailedReconciliation ConditionReason = "FailedReconciliation"
+	// TempoCLIListBlocks lists the blocks of the tenant.
+	TempoCLIListBlocks TempoCLICommand = "list-blocks"
+	// TempoCLIAnalyseBlock prints the attributes of a block of the tenant with the most data.
+	TempoCLIAnalyseBlock TempoCLICommand = "analyse-block"
+	// TempoCLISearchBlocks searches the blocks of the tenant for traces with an attribute.
+	TempoCLISearchBlocks TempoCLICommand = "search-blocks"
+	// TempoCLIGenIndex regenerates the index of the blocks of the tenant.
+	TempoCLIGenIndex TempoCLICommand = "gen-index"
 )
 
-// Resources defines resources configuration.
-type Resources struct {
-	// The total amount of resources for Tempo instance.
-	// The operator autonomously splits resources between deployed Tempo components.
-	// Only limits are supported, the operator calculates requests automatically.
-	// See http://github.com/grafana/tempo/issues/1540.
+// TempoCLIJobSpec defines a Job running a tempo-cli command.
+//
+// The Job is named tempo-<name>-cli-<job name> and runs once. A completed Job is not updated
+// if the spec of the entry changes, delete the Job to run the command again with the current spec.
+type TempoCLIJobSpec struct {
+	// Name of the Job, unique within the TempoStack.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name"
+	Name string `json:"name"`
+
+	// Command is the tempo-cli command.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Command"
+	Command TempoCLICommand `json:"command"`
+
+	// Tenant is the ID of the tenant whose blocks are processed. Required if multi-tenancy is enabled,
+	// defaults to the tenant of single-tenant deployments.
 	//
 	// +optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements"
-	Total *corev1.ResourceRequirements `json:"total,omitempty"`
-}
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tenant ID"
+	Tenant string `json:"tenant,omitempty"`
 
-// SearchSpec specified the global search parameters.
-type SearchSpec struct {
-	// Limit used for search requests if none is set by the caller (default: 20)
+	// BlockID is the ID of the analysed block. Required by the analyse-block command.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Limit used for search requests if none is set by the caller, this limit the number of traces returned by the query"
-	DefaultResultLimit *int `json:"defaultResultLimit,omitempty"`
-	// The maximum allowed time range for a search, default: 0s which means unlimited.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Block ID"
+	BlockID string `json:"blockID,omitempty"`
+
+	// Attribute is the name and value of the searched attribute in the format name=value, e.g. service.name=frontend.
+	// Required by the search-blocks command.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max search time range allowed"
-	MaxDuration metav1.Duration `json:"maxDuration,omitempty"`
-	// The maximum allowed value of the limit parameter on search requests. If the search request limit parameter
-	// exceeds the value configured here it will be set to the value configured here.
-	// The default value of 0 disables this limit.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Attribute"
+	Attribute string `json:"attribute,omitempty"`
+
+	// Args are additional flags of the command, e.g. --include-compacted for list-blocks
+	// or --start and --end for search-blocks.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="The maximum allowed value of the limit parameter on search requests, this determine the max number of traces allowed to be returned"
-	MaxResultLimit int `json:"maxResultLimit,omitempty"`
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Additional Arguments"
+	Args []string `json:"args,omitempty"`
 }
 
-// ObjectStorageSecretType defines the type of storage which can be used with the Tempo cluster.
+// TenantPurgeSpec defines a Job deleting all blocks of a tenant.
 //
-// +kubebuilder:validation:Enum=azure;gcs;s3
-type ObjectStorageSecretType string
-
-const (
-	// ObjectStorageSecretAzure when using Azure Storage for Tempo storage.
-	ObjectStorageSecretAzure ObjectStorageSecretType = "azure"
-
-	// ObjectStorageSecretGCS when using Google Cloud Storage for Tempo storage.
-	ObjectStorageSecretGCS ObjectStorageSecretType = "gcs"
-
-	// ObjectStorageSecretS3 when using S3 for Tempo storage.
-	ObjectStorageSecretS3 ObjectStorageSecretType = "s3"
-)
-
-// ObjectStorageSecretSpec is a secret reference containing name only, no namespace.
-type ObjectStorageSecretSpec struct {
-	// Type of object storage that should be used
+// The Job is named tempo-<name>-purge-<purge name> and removes the <tenant ID> prefix of the storage bucket
+// with rclone, i.e. the blocks, their meta.json files and the tenant index. It runs once, delete the Job to run
+// the purge again. The Job fails if the bucket does not contain any blocks of the tenant. Traces which are still in the ingesters, or blocks which are compacted while the Job runs,
+// are written to the object storage after the purge. Stop sending the traces of the tenant before the purge,
+// and wait until the ingesters flushed their traces.
+type TenantPurgeSpec struct {
+	// Name of the Job, unique within the TempoStack.
 	//
 	// +required
 	// +kubebuilder:validation:Required
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:azure","urn:alm:descriptor:com.tectonic.ui:select:gcs","urn:alm:descriptor:com.tectonic.ui:select:s3"},displayName="Object Storage Secret Type"
-	Type ObjectStorageSecretType `json:"type"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name"
+	Name string `json:"name"`
 
-	// Name of a secret in the namespace configured for object storage secrets.
+	// Tenant is the ID of the tenant whose blocks are deleted, i.e. the tenantId of the tenant in multi-tenant
+	// deployments or single-tenant otherwise.
 	//
 	// +required
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:MinLength=1
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:Secret",displayName="Object Storage Secret Name"
-	Name string `json:"name"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tenant ID"
+	Tenant string `json:"tenant"`
 }
 
-// ObjectStorageSpec defines the requirements to access the object
-// storage bucket to persist traces by the ingester component.
-type ObjectStorageSpec struct {
-	// TLS configuration for reaching the object storage endpoint.
+// CanarySpec defines the synthetic traces which are written and read back by the operator.
+//
+// The operator sends the traces with OTLP/HTTP directly to the distributor, i.e. the gateway is bypassed,
+// and queries the trace written by the previous probe by its ID. The operator pod needs to be allowed to
+// connect to the distributor and the query-frontend, e.g. by spec.tenants.trustedHeader.allowedCIDRs.
+type CanarySpec struct {
+	// Tenant is the tenant of the synthetic traces. Required if multi-tenancy is enabled.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS Config"
-	TLS *ObjectStorageTLSSpec `json:"tls,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tenant ID"
+	Tenant string `json:"tenant,omitempty"`
 
-	// Secret for object storage authentication.
-	// Name of a secret in the same namespace as the TempoStack custom resource.
+	// Interval between two probes, default: 1m, minimum: 10s.
 	//
-	// +kubebuilder:validation:Required
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Object Storage Secret"
-	Secret ObjectStorageSecretSpec `json:"secret"`
-	// Don't forget to update storageSecretField in tempostack_controller.go if this field name changes.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Interval",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Interval metav1.Duration `json:"interval,omitempty"`
 }
 
-// ObjectStorageTLSSpec is the TLS configuration for reaching the object storage endpoint.
-type ObjectStorageTLSSpec struct {
-	// CA is the name of a ConfigMap containing a CA certificate.
+// OpenTelemetryCollectorSpec defines the OpenTelemetryCollector which exports the traces to the TempoStack.
+//
+// The collector is named tempo-<name>-otel and the OpenTelemetry Operator exposes its OTLP receivers
+// with the tempo-<name>-otel-collector Service.
+type OpenTelemetryCollectorSpec struct {
+	// Tenant is the tenant of the exported traces. Required if multi-tenancy is enabled.
+	// In openshift mode, the operator grants the service account of the collector the permission
+	// to write the traces of this tenant.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tenant"
+	Tenant string `json:"tenant,omitempty"`
+
+	// CA is the name of a ConfigMap containing the CA bundle (service-ca.crt) used to verify the certificate
+	// of the distributor receivers if TLS is enabled. Defaults to the CA bundle of the operator.
 	// It needs to be in the same namespace as the TempoStack custom resource.
 	//
 	// +optional
-	// +kubebuilder:validation:optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap",displayName="CA ConfigMap Name"
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CA ConfigMap",xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap"
 	CA string `json:"caName,omitempty"`
 }
 
-// TempoTemplateSpec defines the template of all requirements to configure
-// scheduling of all Tempo components to be deployed.
-type TempoTemplateSpec struct {
-	// Distributor defines the distributor component spec.
+// ServiceTopologySpec defines the routing of the traffic to the internal Services of the components.
+// The gateway Service and Services of type NodePort or LoadBalancer are not affected.
+//
+// The ring clients, e.g. the queriers and distributors connecting to the ingesters, connect to the pod IPs
+// and bypass the Services. Use the zone-aware replication of the ingesters to keep this traffic within a zone.
+type ServiceTopologySpec struct {
+	// TopologyAwareRouting enables the topology aware routing of the Services,
+	// which prefers endpoints in the same zone as the client.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Distributor pods"
-	Distributor TempoComponentSpec `json:"distributor,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Topology Aware Routing",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	TopologyAwareRouting bool `json:"topologyAwareRouting,omitempty"`
 
-	// Ingester defines the ingester component spec.
+	// InternalTrafficPolicy defines the internal traffic policy of the Services. Defaults to Cluster.
+	// The Local policy routes the traffic only to endpoints on the same node as the client
+	// and drops the traffic if there is no such endpoint.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingester pods"
-	Ingester TempoComponentSpec `json:"ingester,omitempty"`
+	// +kubebuilder:validation:Enum=Cluster;Local
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Internal Traffic Policy"
+	InternalTrafficPolicy corev1.ServiceInternalTrafficPolicy `json:"internalTrafficPolicy,omitempty"`
+}
 
-	// Compactor defines the tempo compactor component spec.
+// ServiceMeshSpec defines the integration of the TempoStack into a service mesh.
+type ServiceMeshSpec struct {
+	// Enabled injects the Istio sidecar into the pods of all components and disables the TLS encryption
+	// between the components managed by the operator in favor of the mTLS of the service mesh.
+	// The memberlist and gRPC ports bypass the sidecar, because the components connect to each other
+	// by pod IP on these ports.
+	//
+	// The operator flushes the ingesters and probes the hash rings on the HTTP port of the pods,
+	// therefore the PeerAuthentication of the namespace must allow plain text traffic on this port.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Compactor pods"
-	Compactor TempoComponentSpec `json:"compactor,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled,omitempty"`
+}
 
-	// Querier defines the querier component spec.
+// NetworkPolicySpec defines the NetworkPolicies of the TempoStack.
+type NetworkPolicySpec struct {
+	// Enabled creates a NetworkPolicy for every component, which allows only the traffic between the components,
+	// the ingestion of spans by the receivers, the queries, the scraping of the metrics,
+	// DNS and the egress traffic to the object storage.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Querier pods"
-	Querier TempoComponentSpec `json:"querier,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled,omitempty"`
 
-	// TempoQueryFrontendSpec defines the query frontend spec.
+	// ReceiverNamespaceSelector selects the namespaces allowed to send spans to the receivers of the distributor.
+	// If empty, the receivers accept spans from everywhere.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Query Frontend pods"
-	QueryFrontend TempoQueryFrontendSpec `json:"queryFrontend,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Receiver Namespace Selector"
+	ReceiverNamespaceSelector *metav1.LabelSelector `json:"receiverNamespaceSelector,omitempty"`
 
-	// Gateway defines the tempo gateway spec.
+	// ObjectStorageCIDRs restricts the egress traffic to the object storage to the given IP ranges (CIDR notation).
+	// If empty, the components accessing the object storage can connect to any destination.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway pods"
-	Gateway TempoGatewaySpec `json:"gateway,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Object Storage CIDRs"
+	ObjectStorageCIDRs []string `json:"objectStorageCIDRs,omitempty"`
 }
 
-// TempoComponentSpec defines specific schedule settings for tempo components.
-type TempoComponentSpec struct {
-	// Replicas represents the number of replicas to create for this component.
+// ExtraConfigSpec defines additional configuration of the Tempo components.
+type ExtraConfigSpec struct {
+	// Tempo defines additional Tempo configuration, which is deep-merged into the generated tempo.yaml.
+	// Values set here take precedence over the values generated by the operator.
+	// Overriding settings managed by the operator, for example the ports, the storage backend or the TLS settings,
+	// can break the TempoStack and results in a warning.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tempo Configuration"
+	Tempo apiextensionsv1.JSON `json:"tempo,omitempty"`
+}
+
+// ServerPortsSpec defines the ports of the HTTP and gRPC servers of the Tempo components.
+type ServerPortsSpec struct {
+	// HTTP is the port of the HTTP server. Defaults to 3200.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="HTTP Port",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	HTTP int32 `json:"http,omitempty"`
+
+	// GRPC is the port of the gRPC server. Defaults to 9095.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="gRPC Port",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	GRPC int32 `json:"grpc,omitempty"`
+}
+
+// MemberlistInstanceAddrType defines the address a component advertises to the memberlist and the hash rings.
+//
+// +kubebuilder:validation:Enum=default;podIP
+type MemberlistInstanceAddrType string
+
+const (
+	// MemberlistInstanceAddrDefault uses the first private IP address of the eth0 or en0 network interface.
+	MemberlistInstanceAddrDefault MemberlistInstanceAddrType = "default"
+	// MemberlistInstanceAddrPodIP uses the IP address of the pod, as reported by the Kubernetes API.
+	MemberlistInstanceAddrPodIP MemberlistInstanceAddrType = "podIP"
+)
+
+// MemberlistSpec defines the gossip between the Tempo components.
+// Unset settings keep the defaults of Tempo.
+type MemberlistSpec struct {
+	// InstanceAddrType defines the address which the components advertise to the other members
+	// and register in the hash rings. Defaults to default, which uses the first private IP address
+	// of the eth0 or en0 network interface. Use podIP if the pods do not have such an interface, e.g. with some CNIs.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Instance Address Type"
+	InstanceAddrType MemberlistInstanceAddrType `json:"instanceAddrType,omitempty"`
+
+	// Port is the port of the memberlist. Defaults to 7946.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Port",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	Port int32 `json:"port,omitempty"`
+
+	// GossipInterval defines the interval between the gossip messages. Defaults to 200ms.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gossip Interval",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	GossipInterval metav1.Duration `json:"gossipInterval,omitempty"`
+
+	// ClusterDomain is the DNS domain of the cluster, which is used to resolve the gossip-ring Service.
+	// By default the Service is resolved with the search domains of the pods.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster Domain",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+
+	// TLS encrypts the gossip between the members.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS"
+	TLS *MemberlistTLSSpec `json:"tls,omitempty"`
+}
+
+// MemberlistTLSSpec defines the TLS settings of the memberlist.
+// All members use the same certificate, which is presented to and verified by the other members.
+type MemberlistTLSSpec struct {
+	// CertName is the name of a Secret containing the certificate (tls.crt) and private key (tls.key) of the members.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Certificate Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	CertName string `json:"certName"`
+
+	// CA is the name of a ConfigMap containing the CA bundle (service-ca.crt) used to verify the certificate of the members.
+	// If empty, the system CA bundle is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CA ConfigMap",xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap"
+	CA string `json:"caName,omitempty"`
+
+	// ServerName is the name used to verify the certificate of the members.
+	// Defaults to the fully qualified domain name of the gossip-ring Service.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Server Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ServerName string `json:"serverName,omitempty"`
+}
+
+// KVStoreType defines the type of the key-value store of the hash rings.
+//
+// +kubebuilder:validation:Enum=memberlist;etcd;consul
+type KVStoreType string
+
+const (
+	// KVStoreMemberlist propagates the hash rings with the gossip between the Tempo components.
+	KVStoreMemberlist KVStoreType = "memberlist"
+	// KVStoreEtcd stores the hash rings in etcd.
+	KVStoreEtcd KVStoreType = "etcd"
+	// KVStoreConsul stores the hash rings in Consul.
+	KVStoreConsul KVStoreType = "consul"
+)
+
+// KVStoreSpec defines the key-value store of the hash rings.
+// The etcd cluster or Consul is not managed by the operator. The keys of the hash rings
+// are prefixed with the namespace and the name of the TempoStack, therefore it can be shared by several TempoStacks.
+type KVStoreSpec struct {
+	// Type is the type of the key-value store.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Type"
+	Type KVStoreType `json:"type"`
+
+	// Etcd configures the etcd cluster. It is required if the type is etcd.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="etcd"
+	Etcd *EtcdSpec `json:"etcd,omitempty"`
+
+	// Consul configures the Consul agent. It is required if the type is consul.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Consul"
+	Consul *ConsulSpec `json:"consul,omitempty"`
+}
+
+// EtcdSpec defines the connection to an etcd cluster.
+type EtcdSpec struct {
+	// Endpoints are the addresses (host:port) of the etcd members.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Endpoints"
+	Endpoints []string `json:"endpoints"`
+
+	// AuthSecret is the name of a Secret containing the username (key: username) and password (key: password)
+	// of the etcd user.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Authentication Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	AuthSecret string `json:"authSecret,omitempty"`
+
+	// TLS enables TLS for the connections to etcd.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS"
+	TLS *EtcdTLSSpec `json:"tls,omitempty"`
+}
+
+// EtcdTLSSpec defines the TLS settings of the connections to etcd.
+type EtcdTLSSpec struct {
+	// CA is the name of a ConfigMap containing the CA bundle (service-ca.crt) used to verify the certificate of etcd.
+	// If empty, the system CA bundle is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CA ConfigMap",xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap"
+	CA string `json:"caName,omitempty"`
+
+	// CertName is the name of a Secret containing the client certificate (tls.crt) and private key (tls.key).
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Client Certificate Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	CertName string `json:"certName,omitempty"`
+
+	// ServerName is the name used to verify the certificate of etcd,
+	// if it differs from the host names of the endpoints.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Server Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ServerName string `json:"serverName,omitempty"`
+}
+
+// ConsulSpec defines the connection to a Consul agent.
+type ConsulSpec struct {
+	// Host is the address (host:port) of the Consul agent.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Host",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Host string `json:"host"`
+
+	// AuthSecret is the name of a Secret containing the ACL token (key: token).
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ACL Token Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	AuthSecret string `json:"authSecret,omitempty"`
+}
+
+// GRPCServerSpec defines the settings of the gRPC servers of the Tempo components.
+// Unset settings keep the defaults of Tempo.
+type GRPCServerSpec struct {
+	// MaxRecvMsgSizeMiB defines the maximum size of a received message in MiB. Defaults to 4.
+	// The querier and the ingester clients of the query path accept messages of the same size.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Receive Message Size in MiB",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxRecvMsgSizeMiB *int `json:"maxRecvMsgSizeMiB,omitempty"`
+
+	// MaxSendMsgSizeMiB defines the maximum size of a sent message in MiB. Defaults to 4.
+	// Increase this setting if large traces cannot be retrieved.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Send Message Size in MiB",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxSendMsgSizeMiB *int `json:"maxSendMsgSizeMiB,omitempty"`
+
+	// KeepaliveTime defines the interval after which the server pings an idle client connection.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Keepalive Time",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	KeepaliveTime *metav1.Duration `json:"keepaliveTime,omitempty"`
+
+	// KeepaliveTimeout defines how long the server waits for the response to a ping before closing the connection.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Keepalive Timeout",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	KeepaliveTimeout *metav1.Duration `json:"keepaliveTimeout,omitempty"`
+
+	// MaxConnectionAge defines the maximum age of a client connection. The server closes older connections,
+	// so the clients reconnect and the load is rebalanced behind L4 load balancers.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Connection Age",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	MaxConnectionAge *metav1.Duration `json:"maxConnectionAge,omitempty"`
+
+	// MaxConnectionAgeGrace defines how long the pending requests of a connection can complete
+	// after the maximum connection age is reached.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Connection Age Grace",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	MaxConnectionAgeGrace *metav1.Duration `json:"maxConnectionAgeGrace,omitempty"`
+}
+
+// SPIFFESpec defines the SPIFFE workload identity integration of a TempoStack.
+// The SVIDs are fetched from the SPIFFE Workload API by a spiffe-helper sidecar.
+// The SPIRE registration entries of the Tempo pods must contain the DNS names of the Tempo services,
+// because the internal clients verify the service hostnames.
+type SPIFFESpec struct {
+	// CSIDriver is the name of the SPIFFE CSI driver, which mounts the Workload API socket into the pods.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="csi.spiffe.io"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CSI Driver"
+	CSIDriver string `json:"csiDriver,omitempty"`
+
+	// AgentSocketName is the file name of the Workload API socket in the volume mounted by the CSI driver.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="spire-agent.sock"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Agent Socket Name"
+	AgentSocketName string `json:"agentSocketName,omitempty"`
+}
+
+// TLSProfileSpec defines the TLS security profile of a TempoStack.
+type TLSProfileSpec struct {
+	// Type is the TLS security profile based on the Mozilla definitions:
+	// https://wiki.mozilla.org/Security/Server_Side_TLS
+	// The Custom type uses the cipher suites and minimal TLS version defined in this spec.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Old;Intermediate;Modern;Custom
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Old","urn:alm:descriptor:com.tectonic.ui:select:Intermediate","urn:alm:descriptor:com.tectonic.ui:select:Modern","urn:alm:descriptor:com.tectonic.ui:select:Custom"},displayName="Type"
+	Type v1alpha1.TLSProfileType `json:"type"`
+
+	// Ciphers is the list of cipher suites negotiated during the TLS handshake, using the OpenSSL names
+	// (e.g. ECDHE-RSA-AES128-GCM-SHA256). Required for the Custom type.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ciphers"
+	Ciphers []string `json:"ciphers,omitempty"`
+
+	// MinTLSVersion is the minimal version of the TLS protocol negotiated during the TLS handshake.
+	// Required for the Custom type.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=VersionTLS10;VersionTLS11;VersionTLS12;VersionTLS13
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Minimal TLS Version"
+	MinTLSVersion string `json:"minTLSVersion,omitempty"`
+}
+
+// CertManagerSpec defines the cert-manager integration of a TempoStack.
+type CertManagerSpec struct {
+	// IssuerRef references the cert-manager Issuer or ClusterIssuer,
+	// which issues the certificates of all Tempo components.
+	// To issue the certificates from a HashiCorp Vault PKI mount, reference a Vault issuer of cert-manager
+	// which authenticates with the Kubernetes auth method of Vault.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Issuer Reference"
+	IssuerRef CertManagerIssuerReference `json:"issuerRef"`
+
+	// Duration is the requested lifetime of the certificates. Defaults to the default of the issuer.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Certificate Duration"
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore defines how long before the expiry the certificates are renewed.
+	// Defaults to a third of the certificate duration.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Renew Before"
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// CertManagerIssuerKind defines the kind of a cert-manager issuer.
+//
+// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+type CertManagerIssuerKind string
+
+const (
+	// CertManagerIssuer references a namespaced cert-manager Issuer.
+	CertManagerIssuer CertManagerIssuerKind = "Issuer"
+	// CertManagerClusterIssuer references a cert-manager ClusterIssuer.
+	CertManagerClusterIssuer CertManagerIssuerKind = "ClusterIssuer"
+)
+
+// CertManagerIssuerReference is a reference to a cert-manager issuer.
+type CertManagerIssuerReference struct {
+	// Name of the issuer.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name"
+	Name string `json:"name"`
+
+	// Kind of the issuer.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=Issuer
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Issuer","urn:alm:descriptor:com.tectonic.ui:select:ClusterIssuer"},displayName="Kind"
+	Kind CertManagerIssuerKind `json:"kind,omitempty"`
+
+	// Group of the issuer. Defaults to cert-manager.io.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group"
+	Group string `json:"group,omitempty"`
+}
+
+// ObservabilitySpec defines how telemetry data gets handled.
+type ObservabilitySpec struct {
+	// Metrics defines the metrics configuration for operands.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Config"
+	Metrics MetricsConfigSpec `json:"metrics,omitempty"`
+
+	// Tracing defines a config for operands.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tracing Config"
+	Tracing TracingConfigSpec `json:"tracing,omitempty"`
+
+	// Logging defines the default log level and the log format of the Tempo components.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Logging Config"
+	Logging LoggingConfigSpec `json:"logging,omitempty"`
+
+	// Grafana defines the Grafana dashboards of the Tempo components.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Grafana Config"
+	Grafana GrafanaConfigSpec `json:"grafana,omitempty"`
+}
+
+// GrafanaConfigSpec defines the Grafana dashboards of the Tempo components.
+type GrafanaConfigSpec struct {
+	// CreateDashboards specifies if a ConfigMap with the operational dashboards of the Tempo components is created.
+	// The ConfigMap is created in the namespace of the TempoStack and is discovered by the Grafana dashboard sidecar.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Create Grafana Dashboards",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	CreateDashboards bool `json:"createDashboards,omitempty"`
+
+	// DashboardsLabels defines the labels of the dashboards ConfigMap, which are used by the
+	// Grafana dashboard sidecar to discover the dashboards. Defaults to grafana_dashboard: "1".
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Dashboards Labels"
+	DashboardsLabels map[string]string `json:"dashboardsLabels,omitempty"`
+
+	// CreateDatasource specifies if GrafanaDatasources of the Grafana Operator are created for this TempoStack,
+	// one datasource per tenant if multi-tenancy is enabled. Requires the Grafana Operator to be installed in the cluster.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Create Grafana Operator Datasource",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	CreateDatasource bool `json:"createDatasource,omitempty"`
+
+	// InstanceSelector selects the Grafana instances of the Grafana Operator which receive the datasources.
+	// It is required if createDatasource is enabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Grafana Instance Selector"
+	InstanceSelector *metav1.LabelSelector `json:"instanceSelector,omitempty"`
+}
+
+// MetricsConfigSpec defines a metrics config.
+type MetricsConfigSpec struct {
+	// CreateServiceMonitors specifies if ServiceMonitors should be created for Tempo components.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Create ServiceMonitors for Tempo components"
+	CreateServiceMonitors bool `json:"createServiceMonitors,omitempty"`
+
+	// CreatePrometheusRules specifies if Prometheus rules for alerts should be created for Tempo components.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Create PrometheusRules for Tempo components"
+	CreatePrometheusRules bool `json:"createPrometheusRules,omitempty"`
+
+	// ServiceMonitors configures the ServiceMonitors of individual components, keyed by the component name
+	// (e.g. distributor, ingester, compactor, querier, query-frontend, gateway or metrics-generator).
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ServiceMonitors"
+	ServiceMonitors map[string]ServiceMonitorSpec `json:"serviceMonitors,omitempty"`
+}
+
+// ServiceMonitorSpec defines the scrape configuration of the ServiceMonitor of a component.
+type ServiceMonitorSpec struct {
+	// Interval at which the metrics of the component are scraped, e.g. 30s.
+	// Defaults to the global scrape interval of Prometheus.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern:="^(0|(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Scrape Interval"
+	Interval string `json:"interval,omitempty"`
+
+	// ScrapeTimeout is the timeout after which the scrape is ended. Must not be greater than the interval.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern:="^(0|(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Scrape Timeout"
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+
+	// HonorLabels chooses the metric's labels on collisions with target labels.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Honor Labels",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	HonorLabels bool `json:"honorLabels,omitempty"`
+
+	// MetricRelabelConfigs are applied to the scraped samples before ingestion,
+	// e.g. to drop high-cardinality series.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metric Relabel Configs"
+	MetricRelabelConfigs []RelabelConfig `json:"metricRelabelConfigs,omitempty"`
+}
+
+// RelabelAction is the action of a relabel config.
+//
+// +kubebuilder:validation:Enum=replace;Replace;keep;Keep;drop;Drop;hashmod;HashMod;labelmap;LabelMap;labeldrop;LabelDrop;labelkeep;LabelKeep
+type RelabelAction string
+
+// RelabelConfig is a Prometheus relabel config.
+// See https://prometheus.io/docs/prometheus/latest/configuration/configuration/#relabel_config.
+type RelabelConfig struct {
+	// SourceLabels selects the values of existing labels, which are concatenated by the separator.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+
+	// Separator placed between the concatenated source label values. Defaults to ';'.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Separator string `json:"separator,omitempty"`
+
+	// TargetLabel is the label to which the resulting value is written in a replace action.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TargetLabel string `json:"targetLabel,omitempty"`
+
+	// Regex against which the concatenated value is matched. Defaults to '(.*)'.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Regex string `json:"regex,omitempty"`
+
+	// Modulus to take of the hash of the source label values.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Modulus uint64 `json:"modulus,omitempty"`
+
+	// Replacement value against which a regex replace is performed. Defaults to '$1'.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Replacement string `json:"replacement,omitempty"`
+
+	// Action to perform based on the regex matching. Defaults to 'replace'.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Action RelabelAction `json:"action,omitempty"`
+}
+
+// TracingConfigSpec defines a tracing config including endpoints and sampling.
+type TracingConfigSpec struct {
+	// SamplingFraction defines the sampling ratio. Valid values are 0 to 1.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Sampling Fraction"
+	SamplingFraction string `json:"sampling_fraction,omitempty"`
+
+	// JaegerAgentEndpoint defines the jaeger endpoint data gets send to.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="localhost:6831"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jaeger-Agent-Endpoint"
+	JaegerAgentEndpoint string `json:"jaeger_agent_endpoint,omitempty"`
+}
+
+// LoggingConfigSpec defines the logging of the Tempo components.
+type LoggingConfigSpec struct {
+	// Level is the default log level of the Tempo components.
+	// It can be overridden per component with spec.template.<component>.logLevel. Defaults to info.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Log Level",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:debug","urn:alm:descriptor:com.tectonic.ui:select:info","urn:alm:descriptor:com.tectonic.ui:select:warn","urn:alm:descriptor:com.tectonic.ui:select:error"}
+	Level LogLevel `json:"level,omitempty"`
+
+	// Format is the log format of the Tempo components. Defaults to logfmt.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Log Format",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:logfmt","urn:alm:descriptor:com.tectonic.ui:select:json"}
+	Format LogFormat `json:"format,omitempty"`
+}
+
+// LogLevel defines the log level of a component.
+//
+// +kubebuilder:validation:Enum=debug;info;warn;error
+type LogLevel string
+
+const (
+	// LogLevelDebug logs debug, info, warning and error messages.
+	LogLevelDebug LogLevel = "debug"
+	// LogLevelInfo logs info, warning and error messages.
+	LogLevelInfo LogLevel = "info"
+	// LogLevelWarn logs warning and error messages.
+	LogLevelWarn LogLevel = "warn"
+	// LogLevelError logs error messages.
+	LogLevelError LogLevel = "error"
+)
+
+// LogFormat defines the format of the log messages.
+//
+// +kubebuilder:validation:Enum=logfmt;json
+type LogFormat string
+
+const (
+	// LogFormatLogfmt writes the log messages in the logfmt format.
+	LogFormatLogfmt LogFormat = "logfmt"
+	// LogFormatJSON writes the log messages in the JSON format.
+	LogFormatJSON LogFormat = "json"
+)
+
+// PodStatusMap defines the type for mapping pod status to pod name.
+type PodStatusMap map[corev1.PodPhase][]string
+
+// ComponentStatus defines the status of each component.
+type ComponentStatus struct {
+	// Compactor is a map to the pod status of the compactor pod.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Compactor",order=5
+	Compactor PodStatusMap `json:"compactor"`
+
+	// Distributor is a map to the per pod status of the distributor deployment
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Distributor",order=1
+	Distributor PodStatusMap `json:"distributor"`
+
+	// Ingester is a map to the per pod status of the ingester statefulset
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Ingester",order=2
+	Ingester PodStatusMap `json:"ingester"`
+
+	// Querier is a map to the per pod status of the querier deployment
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Querier",order=3
+	Querier PodStatusMap `json:"querier"`
+
+	// QueryFrontend is a map to the per pod status of the query frontend deployment
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Query Frontend",order=4
+	QueryFrontend PodStatusMap `json:"queryFrontend"`
+
+	// Gateway is a map to the per pod status of the query frontend deployment
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Query Frontend",order=4
+	Gateway PodStatusMap `json:"gateway"`
+
+	// MetricsGenerator is a map to the per pod status of the metrics-generator deployment
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Metrics Generator",order=6
+	MetricsGenerator PodStatusMap `json:"metricsGenerator,omitempty"`
+}
+
+// TempoStackStatus defines the observed state of TempoStack.
+type TempoStackStatus struct {
+	// Version of the Tempo Operator.
+	// +optional
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+
+	// Version of the managed Tempo instance, updated once all components are configured with a new version.
+	// The version is empty if the Tempo image is not tagged with a version.
+	// +optional
+	TempoVersion string `json:"tempoVersion,omitempty"`
+
+	// DEPRECATED. Version of the Tempo Query component used.
+	// +optional
+	TempoQueryVersion string `json:"tempoQueryVersion,omitempty"`
+
+	// ReconciledOperatorVersion is the version of the Tempo Operator which last reconciled the instance successfully.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Reconciled Operator Version"
+	ReconciledOperatorVersion string `json:"reconciledOperatorVersion,omitempty"`
+
+	// ObservedGeneration is the generation of the TempoStack which was last reconciled successfully.
+	// +optional
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ConfigChecksum is the checksum of the Tempo configuration which was last applied successfully.
+	// The pods of all components are rolled out whenever the checksum changes.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Config Checksum"
+	ConfigChecksum string `json:"configChecksum,omitempty"`
+
+	// Images are the container images which were last applied successfully.
+	// +optional
+	// +kubebuilder:validation:Optional
+	Images v1alpha1.ImagesSpec `json:"images,omitempty"`
+
+	// ImageDigests maps the container images of the running pods to the image digests
+	// which were resolved by the container runtime, e.g. docker.io/grafana/tempo@sha256:...
+	// +optional
+	// +kubebuilder:validation:Optional
+	ImageDigests map[string]string `json:"imageDigests,omitempty"`
+
+	// Components provides summary of all Tempo pod status grouped
+	// per component.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Components ComponentStatus `json:"components,omitempty"`
+
+	// Conditions of the Tempo deployment health.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Rings shows the health of the hash rings, as reported by the ring status pages of the distributor.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Rings"
+	Rings []RingStatus `json:"rings,omitempty"`
+
+	// TenantPurges shows the progress of the tenant purge Jobs.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Tenant Purges"
+	TenantPurges []TenantPurgeStatus `json:"tenantPurges,omitempty"`
+}
+
+// TenantPurgePhase defines the phase of a tenant purge Job.
+//
+// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed
+type TenantPurgePhase string
+
+const (
+	// TenantPurgePending defines that the Job is not created yet or its pod is not running yet.
+	TenantPurgePending TenantPurgePhase = "Pending"
+	// TenantPurgeRunning defines that the blocks of the tenant are being deleted.
+	TenantPurgeRunning TenantPurgePhase = "Running"
+	// TenantPurgeSucceeded defines that all blocks of the tenant were deleted.
+	TenantPurgeSucceeded TenantPurgePhase = "Succeeded"
+	// TenantPurgeFailed defines that the Job failed, see the logs of its pods.
+	TenantPurgeFailed TenantPurgePhase = "Failed"
+)
+
+// TenantPurgeStatus defines the status of a tenant purge Job.
+type TenantPurgeStatus struct {
+	// Name of the purge, as in spec.tenantPurges.
+	Name string `json:"name"`
+
+	// Tenant is the ID of the purged tenant.
+	Tenant string `json:"tenant"`
+
+	// Phase of the Job.
+	Phase TenantPurgePhase `json:"phase"`
+
+	// CompletionTime is the time when the Job succeeded.
+	//
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// RingStatus defines the health of a hash ring.
+type RingStatus struct {
+	// Name of the ring, e.g. ingester or metrics-generator.
+	Name string `json:"name"`
+
+	// Members is the number of members in the ring.
+	//
+	// +optional
+	Members int `json:"members,omitempty"`
+
+	// UnhealthyMembers lists the members of the ring which are not active or missed their heartbeat.
+	//
+	// +optional
+	UnhealthyMembers []string `json:"unhealthyMembers,omitempty"`
+
+	// Error contains the error of the last probe of the ring.
+	//
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// ConditionStatus defines the status of a condition (e.g. ready, failed, pending or configuration error).
+type ConditionStatus string
+
+const (
+	// ConditionReady defines that all components are ready.
+	ConditionReady ConditionStatus = "Ready"
+	// ConditionFailed defines that one or more components are in a failed state.
+	ConditionFailed ConditionStatus = "Failed"
+	// ConditionPending defines that one or more components are in a pending state.
+	ConditionPending ConditionStatus = "Pending"
+	// ConditionConfigurationError defines that there is a configuration error.
+	ConditionConfigurationError ConditionStatus = "ConfigurationError"
+	// ConditionUnmanaged defines that the TempoStack is not managed by the operator.
+	ConditionUnmanaged ConditionStatus = "Unmanaged"
+)
+
+// AllStatusConditions lists all possible status conditions.
+var AllStatusConditions = []ConditionStatus{ConditionReady, ConditionFailed, ConditionPending, ConditionConfigurationError, ConditionUnmanaged}
+
+const (
+	// ConditionCompactorReady defines that all compactor pods are ready.
+	ConditionCompactorReady ConditionStatus = "CompactorReady"
+	// ConditionDistributorReady defines that all distributor pods are ready.
+	ConditionDistributorReady ConditionStatus = "DistributorReady"
+	// ConditionIngesterReady defines that all ingester pods are ready.
+	ConditionIngesterReady ConditionStatus = "IngesterReady"
+	// ConditionQuerierReady defines that all querier pods are ready.
+	ConditionQuerierReady ConditionStatus = "QuerierReady"
+	// ConditionQueryFrontendReady defines that all query-frontend pods are ready.
+	ConditionQueryFrontendReady ConditionStatus = "QueryFrontendReady"
+	// ConditionGatewayReady defines that all gateway pods are ready.
+	ConditionGatewayReady ConditionStatus = "GatewayReady"
+	// ConditionMetricsGeneratorReady defines that all metrics-generator pods are ready.
+	ConditionMetricsGeneratorReady ConditionStatus = "MetricsGeneratorReady"
+	// ConditionPipelineHealthy defines that the synthetic traces of the canary are written and read back successfully.
+	ConditionPipelineHealthy ConditionStatus = "PipelineHealthy"
+)
+
+// AllComponentConditions lists the status conditions of the individual components and of the canary.
+// In contrast to the conditions in AllStatusConditions, multiple component conditions can be true at the same time.
+var AllComponentConditions = []ConditionStatus{
+	ConditionCompactorReady,
+	ConditionDistributorReady,
+	ConditionIngesterReady,
+	ConditionQuerierReady,
+	ConditionQueryFrontendReady,
+	ConditionGatewayReady,
+	ConditionMetricsGeneratorReady,
+	ConditionPipelineHealthy,
+}
+
+// ConditionReason defines possible reasons for each condition.
+type ConditionReason string
+
+const (
+	// ReasonReady defines a healthy tempo instance.
+	ReasonReady ConditionReason = "Ready"
+	// ReasonInvalidStorageConfig defines that the object storage configuration is invalid (missing or incomplete storage secret).
+	ReasonInvalidStorageConfig ConditionReason = "InvalidStorageConfig"
+	// ReasonFailedComponents when all/some Tempo components fail to roll out.
+	ReasonFailedComponents ConditionReason = "FailedComponents"
+	// ReasonPendingComponents when all/some Tempo components pending dependencies.
+	ReasonPendingComponents ConditionReason = "PendingComponents"
+	// ReasonCouldNotGetOpenShiftBaseDomain when operator cannot get OpenShift base domain, that is used for OAuth redirect URL.
+	ReasonCouldNotGetOpenShiftBaseDomain ConditionReason = "CouldNotGetOpenShiftBaseDomain"
+	// ReasonCouldNotGetOpenShiftTLSPolicy when operator cannot get OpenShift TLS security cluster policy.
+	ReasonCouldNotGetOpenShiftTLSPolicy ConditionReason = "CouldNotGetOpenShiftTLSPolicy"
+	// ReasonMissingGatewayTenantSecret when operator cannot get Secret containing sensitive Gateway information.
+	ReasonMissingGatewayTenantSecret ConditionReason = "ReasonMissingGatewayTenantSecret"
+	// ReasonInvalidTenantsConfiguration when the tenant configuration provided is invalid.
+	ReasonInvalidTenantsConfiguration ConditionReason = "InvalidTenantsConfiguration"
+	// ReasonMissingRouteCertificate when operator cannot get the Secret or ConfigMap containing the certificates of a Route.
+	ReasonMissingRouteCertificate ConditionReason = "MissingRouteCertificate"
+	// ReasonFailedReconciliation when the operator failed to reconcile.
+	ReasonFailedReconciliation ConditionReason = "FailedReconciliation"
+	// ReasonUnmanaged when the management state of the TempoStack is Unmanaged.
+	ReasonUnmanaged ConditionReason = "Unmanaged"
+	// ReasonCanaryWriteFailed when the canary could not write the synthetic trace to the distributor.
+	ReasonCanaryWriteFailed ConditionReason = "CanaryWriteFailed"
+	// ReasonCanaryReadFailed when the canary could not read the synthetic trace back from the query-frontend.
+	ReasonCanaryReadFailed ConditionReason = "CanaryReadFailed"
+	// ReasonIngesterFlushInProgress when the update of the TempoStack is postponed until the ingesters flushed their traces.
+	ReasonIngesterFlushInProgress ConditionReason = "IngesterFlushInProgress"
+	// ReasonCouldNotCopySecret when the operator cannot copy a Secret or ConfigMap referenced from another namespace.
+	ReasonCouldNotCopySecret ConditionReason = "CouldNotCopySecret"
+)
+
+// Resources defines resources configuration.
+type Resources struct {
+	// The total amount of resources for Tempo instance.
+	// The operator autonomously splits resources between deployed Tempo components.
+	// Only limits are supported, the operator calculates requests automatically.
+	// See http://github.com/grafana/tempo/issues/1540.
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements"
+	Total *corev1.ResourceRequirements `json:"total,omitempty"`
+
+	// Profile defines a predefined total amount of resources for the Tempo instance,
+	// which is split between the Tempo components like spec.resources.total.
+	// It cannot be used together with spec.resources.total.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Profile",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:small","urn:alm:descriptor:com.tectonic.ui:select:medium","urn:alm:descriptor:com.tectonic.ui:select:large"}
+	Profile ResourceProfile `json:"profile,omitempty"`
+
+	// DisableGoRuntimeTuning disables setting the GOMEMLIMIT and GOMAXPROCS environment variables
+	// of the Tempo containers according to their memory and CPU limits.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Disable Go Runtime Tuning",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	DisableGoRuntimeTuning bool `json:"disableGoRuntimeTuning,omitempty"`
+
+	// VerticalPodAutoscaler creates a VerticalPodAutoscaler for each component, to size the components
+	// according to the recommendations of the VerticalPodAutoscaler instead of spec.resources.total.
+	// Requires the VerticalPodAutoscaler to be installed in the cluster.
+	// Do not combine it with the CPU or memory based horizontal autoscaling of a component.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Vertical Pod Autoscaler"
+	VerticalPodAutoscaler *VerticalPodAutoscalerSpec `json:"verticalPodAutoscaler,omitempty"`
+}
+
+// ResourceProfile defines a predefined total amount of resources of a Tempo instance.
+//
+// +kubebuilder:validation:Enum=small;medium;large
+type ResourceProfile string
+
+const (
+	// ResourceProfileSmall defines a total of 2 CPUs and 4Gi memory.
+	ResourceProfileSmall ResourceProfile = "small"
+	// ResourceProfileMedium defines a total of 6 CPUs and 16Gi memory.
+	ResourceProfileMedium ResourceProfile = "medium"
+	// ResourceProfileLarge defines a total of 12 CPUs and 48Gi memory.
+	ResourceProfileLarge ResourceProfile = "large"
+)
+
+// VerticalPodAutoscalerUpdateMode defines how the VerticalPodAutoscaler applies its recommendations.
+//
+// +kubebuilder:validation:Enum=Off;Initial;Auto
+type VerticalPodAutoscalerUpdateMode string
+
+const (
+	// VerticalPodAutoscalerUpdateModeOff only computes recommendations, the resources of the pods are not changed.
+	VerticalPodAutoscalerUpdateModeOff VerticalPodAutoscalerUpdateMode = "Off"
+	// VerticalPodAutoscalerUpdateModeInitial applies the recommendations when a pod is created.
+	VerticalPodAutoscalerUpdateModeInitial VerticalPodAutoscalerUpdateMode = "Initial"
+	// VerticalPodAutoscalerUpdateModeAuto applies the recommendations when a pod is created
+	// and evicts pods whose resources differ significantly from the recommendations.
+	VerticalPodAutoscalerUpdateModeAuto VerticalPodAutoscalerUpdateMode = "Auto"
+)
+
+// VerticalPodAutoscalerSpec defines the VerticalPodAutoscalers of the components.
+type VerticalPodAutoscalerSpec struct {
+	// UpdateMode defines how the recommendations are applied. Defaults to Off.
+	// The Initial and Auto modes cannot be used together with spec.resources.total or spec.resources.profile.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=Off
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Update Mode",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Off","urn:alm:descriptor:com.tectonic.ui:select:Initial","urn:alm:descriptor:com.tectonic.ui:select:Auto"}
+	UpdateMode VerticalPodAutoscalerUpdateMode `json:"updateMode,omitempty"`
+
+	// MinAllowed defines the minimum resources which are recommended for each container.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Minimum Allowed Resources"
+	MinAllowed corev1.ResourceList `json:"minAllowed,omitempty"`
+
+	// MaxAllowed defines the maximum resources which are recommended for each container.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maximum Allowed Resources"
+	MaxAllowed corev1.ResourceList `json:"maxAllowed,omitempty"`
+}
+
+// SearchSpec specified the global search parameters.
+type SearchSpec struct {
+	// Limit used for search requests if none is set by the caller (default: 20)
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Limit used for search requests if none is set by the caller, this limit the number of traces returned by the query"
+	DefaultResultLimit *int `json:"defaultResultLimit,omitempty"`
+	// The maximum allowed time range for a search, default: 0s which means unlimited.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max search time range allowed"
+	MaxDuration metav1.Duration `json:"maxDuration,omitempty"`
+	// The maximum allowed value of the limit parameter on search requests. If the search request limit parameter
+	// exceeds the value configured here it will be set to the value configured here.
+	// The default value of 0 disables this limit.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="The maximum allowed value of the limit parameter on search requests, this determine the max number of traces allowed to be returned"
+	MaxResultLimit int `json:"maxResultLimit,omitempty"`
+	// QueryTimeout is the timeout of the search and trace by ID requests in the queriers, default: 30s.
+	// The HTTP server timeouts of the Tempo components are raised to one minute above the query timeout
+	// if they are shorter. Ingresses and Routes in front of Tempo might need a longer timeout as well,
+	// for example with the haproxy.router.openshift.io/timeout annotation.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Query Timeout",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	QueryTimeout metav1.Duration `json:"queryTimeout,omitempty"`
+}
+
+// ObjectStorageSecretType defines the type of storage which can be used with the Tempo cluster.
+//
+// +kubebuilder:validation:Enum=azure;gcs;s3
+type ObjectStorageSecretType string
+
+const (
+	// ObjectStorageSecretAzure when using Azure Storage for Tempo storage.
+	ObjectStorageSecretAzure ObjectStorageSecretType = "azure"
+
+	// ObjectStorageSecretGCS when using Google Cloud Storage for Tempo storage.
+	ObjectStorageSecretGCS ObjectStorageSecretType = "gcs"
+
+	// ObjectStorageSecretS3 when using S3 for Tempo storage.
+	ObjectStorageSecretS3 ObjectStorageSecretType = "s3"
+)
+
+// ObjectStorageSecretSpec is a reference to an object storage secret.
+type ObjectStorageSecretSpec struct {
+	// Type of object storage that should be used
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:azure","urn:alm:descriptor:com.tectonic.ui:select:gcs","urn:alm:descriptor:com.tectonic.ui:select:s3"},displayName="Object Storage Secret Type"
+	Type ObjectStorageSecretType `json:"type"`
+
+	// Name of a secret in the namespace configured for object storage secrets.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:Secret",displayName="Object Storage Secret Name"
+	Name string `json:"name"`
+
+	// Namespace of the secret, if it is not in the namespace of the TempoStack custom resource.
+	// The namespace must be allow-listed in the secretNamespaces setting of the operator.
+	// The operator copies the secret with the same name into the namespace of the TempoStack and keeps the copy in sync.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Object Storage Secret Namespace"
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ObjectStorageSpec defines the requirements to access the object
+// storage bucket to persist traces by the ingester component.
+type ObjectStorageSpec struct {
+	// TLS configuration for reaching the object storage endpoint.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS Config"
+	TLS *ObjectStorageTLSSpec `json:"tls,omitempty"`
+
+	// Secret for object storage authentication.
+	// Name of a secret in the same namespace as the TempoStack custom resource, unless its namespace is set.
+	//
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Object Storage Secret"
+	Secret ObjectStorageSecretSpec `json:"secret"`
+	// Don't forget to update storageSecretField in tempostack_controller.go if this field name changes.
+
+	// MigrateFrom is the secret of the previous object storage, in the same format as the storage secret.
+	// If set, the operator runs the tempo-<name>-migrate Job, which copies the blocks of the previous object storage
+	// to the object storage of this TempoStack with rclone. The copied blocks are visible to Tempo
+	// once the Job completed, the traces received in the meantime are written to the new object storage.
+	// The secret needs to be in the same namespace as the TempoStack custom resource, unless its namespace is set.
+	// Remove the field once the Job completed.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Migrate From Storage Secret"
+	MigrateFrom *ObjectStorageSecretSpec `json:"migrateFrom,omitempty"`
+
+	// SecretProviderClass is the name of a SecretProviderClass of the Secrets Store CSI driver,
+	// which provides the object storage credentials. It needs to be in the same namespace as the TempoStack custom resource.
+	// If set, the credentials are mounted from the CSI volume and the storage secret only needs to contain
+	// the non-sensitive fields (e.g. bucket and endpoint).
+	// The SecretProviderClass must provide a "credentials" file (AWS shared credentials file) for s3
+	// or a "key.json" file for gcs. The azure storage type is not supported.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret Provider Class"
+	SecretProviderClass string `json:"secretProviderClass,omitempty"`
+
+	// Hedging configures hedged requests to the object storage backend,
+	// which reduce the tail latency of reads against slow object stores.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Hedged Requests"
+	Hedging *ObjectStorageHedgingSpec `json:"hedging,omitempty"`
+
+	// S3 configures the connection to a s3 object storage. It is only supported by the s3 storage type.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="S3"
+	S3 *ObjectStorageS3Spec `json:"s3,omitempty"`
+
+	// MaxRetries defines how many times the query frontend retries a failed query,
+	// e.g. after an error of a flaky object storage. Defaults to 2, 0 disables the retries.
+	// The object storage clients of Tempo retry failed requests with a built-in backoff, which is not configurable.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Retries",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxRetries *int `json:"maxRetries,omitempty"`
+
+	// BlockFormat is the format of the blocks written to the object storage.
+	// If empty, the default format of the deployed Tempo version is used.
+	// The format must be supported by the Tempo version of the tempo image, new formats should only be
+	// adopted after all components run a Tempo version which is able to read them.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Block Format",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:vParquet","urn:alm:descriptor:com.tectonic.ui:select:vParquet2","urn:alm:descriptor:com.tectonic.ui:select:vParquet3","urn:alm:descriptor:com.tectonic.ui:select:vParquet4"}
+	BlockFormat BlockFormat `json:"blockFormat,omitempty"`
+}
+
+// BlockFormat defines the format of the trace blocks.
+//
+// +kubebuilder:validation:Enum=vParquet;vParquet2;vParquet3;vParquet4
+type BlockFormat string
+
+const (
+	// BlockFormatVParquet is the first Parquet block format, supported since Tempo 2.0.
+	BlockFormatVParquet BlockFormat = "vParquet"
+	// BlockFormatVParquet2 is supported since Tempo 2.2.
+	BlockFormatVParquet2 BlockFormat = "vParquet2"
+	// BlockFormatVParquet3 adds dedicated attribute columns, supported since Tempo 2.3.
+	BlockFormatVParquet3 BlockFormat = "vParquet3"
+	// BlockFormatVParquet4 adds support for events and links in TraceQL, supported since Tempo 2.5.
+	BlockFormatVParquet4 BlockFormat = "vParquet4"
+)
+
+// CacheBackend defines the type of an external cache.
+//
+// +kubebuilder:validation:Enum=memcached;redis
+type CacheBackend string
+
+const (
+	// CacheBackendMemcached uses memcached as cache.
+	CacheBackendMemcached CacheBackend = "memcached"
+	// CacheBackendRedis uses redis as cache.
+	CacheBackendRedis CacheBackend = "redis"
+)
+
+// CacheSpec defines an external cache.
+type CacheSpec struct {
+	// Backend is the type of the cache.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Backend",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:memcached","urn:alm:descriptor:com.tectonic.ui:select:redis"}
+	Backend CacheBackend `json:"backend"`
+
+	// Endpoints are the addresses of the cache instances, e.g. memcached.cache.svc:11211.
+	// Multiple redis endpoints are only supported by a redis cluster.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Endpoints"
+	Endpoints []string `json:"endpoints"`
+
+	// Timeout is the timeout of the requests to the cache.
+	// If unset, the default of Tempo is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Timeout",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+
+	// AuthSecret is the name of a Secret in the same namespace containing the password key.
+	// Only supported by redis.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Credentials Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	AuthSecret string `json:"authSecret,omitempty"`
+
+	// TLS enables TLS for the connections to the cache.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS"
+	TLS *CacheTLSSpec `json:"tls,omitempty"`
+}
+
+// CacheTLSSpec defines the TLS connections to the cache.
+type CacheTLSSpec struct {
+	// CA is the name of a ConfigMap containing the CA bundle (service-ca.crt) used to verify the certificate of the cache.
+	// If empty, the system CA bundle is used. Only supported by memcached.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CA ConfigMap",xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap"
+	CA string `json:"caName,omitempty"`
+
+	// CertName is the name of a Secret containing the client certificate (tls.crt) and private key (tls.key).
+	// Only supported by memcached.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Client Certificate Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	CertName string `json:"certName,omitempty"`
+
+	// ServerName is the name used to verify the certificate of the cache,
+	// if it differs from the host names of the endpoints. Only supported by memcached.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Server Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ServerName string `json:"serverName,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the certificate of the cache.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Skip Certificate Verification",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ObjectStorageHedgingSpec defines the hedged requests configuration of the object storage backend.
+type ObjectStorageHedgingSpec struct {
+	// RequestsAt defines the duration after which a hedged request is issued.
+	// Hedged requests are disabled if unset or zero.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Hedge Requests At",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RequestsAt metav1.Duration `json:"requestsAt,omitempty"`
+
+	// RequestsUpTo defines the maximum number of requests issued, including the initial request.
+	// Defaults to 2.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=2
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Hedge Requests Up To",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	RequestsUpTo int `json:"requestsUpTo,omitempty"`
+}
+
+// ObjectStorageS3Spec defines the connection to a s3 object storage.
+type ObjectStorageS3Spec struct {
+	// DualStack enables the dual-stack (IPv4 and IPv6) endpoint of AWS S3, e.g. for IPv6-only clusters.
+	// The regional endpoint in the storage secret, e.g. https://s3.us-east-1.amazonaws.com,
+	// is replaced with the dual-stack endpoint of the region, e.g. https://s3.dualstack.us-east-1.amazonaws.com.
+	// S3-compatible object storages need to provide an IPv6 endpoint in the storage secret instead.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Dual-Stack Endpoint",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	DualStack bool `json:"dualStack,omitempty"`
+}
+
+// ObjectStorageTLSSpec is the TLS configuration for reaching the object storage endpoint.
+type ObjectStorageTLSSpec struct {
+	// CA is the name of a ConfigMap containing a CA certificate.
+	// It needs to be in the same namespace as the TempoStack custom resource, unless caNamespace is set.
+	//
+	// +optional
+	// +kubebuilder:validation:optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap",displayName="CA ConfigMap Name"
+	CA string `json:"caName,omitempty"`
+
+	// CANamespace is the namespace of the CA ConfigMap, if it is not in the namespace of the TempoStack custom resource.
+	// The namespace must be allow-listed in the secretNamespaces setting of the operator.
+	// The operator copies the ConfigMap with the same name into the namespace of the TempoStack and keeps the copy in sync.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CA ConfigMap Namespace"
+	CANamespace string `json:"caNamespace,omitempty"`
+}
+
+// TempoTemplateSpec defines the template of all requirements to configure
+// scheduling of all Tempo components to be deployed.
+type TempoTemplateSpec struct {
+	// Distributor defines the distributor component spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Distributor pods"
+	Distributor TempoDistributorSpec `json:"distributor,omitempty"`
+
+	// Ingester defines the ingester component spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingester pods"
+	Ingester TempoIngesterSpec `json:"ingester,omitempty"`
+
+	// Compactor defines the tempo compactor component spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Compactor pods"
+	Compactor TempoCompactorSpec `json:"compactor,omitempty"`
+
+	// Querier defines the querier component spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Querier pods"
+	Querier TempoQuerierSpec `json:"querier,omitempty"`
+
+	// TempoQueryFrontendSpec defines the query frontend spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Query Frontend pods"
+	QueryFrontend TempoQueryFrontendSpec `json:"queryFrontend,omitempty"`
+
+	// Gateway defines the tempo gateway spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway pods"
+	Gateway TempoGatewaySpec `json:"gateway,omitempty"`
+
+	// MetricsGenerator defines the tempo metrics-generator spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Generator pods"
+	MetricsGenerator TempoMetricsGeneratorSpec `json:"metricsGenerator,omitempty"`
+
+	// Memcached defines a memcached cache managed by the operator, an alternative to an external cache in spec.cache.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Memcached pods"
+	Memcached TempoMemcachedSpec `json:"memcached,omitempty"`
+}
+
+// TempoMemcachedSpec extends TempoComponentSpec with the parameters of the memcached cache managed by the operator.
+type TempoMemcachedSpec struct {
+	// TempoComponentSpec is embedded to extend this definition with further options.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:",inline"`
+
+	// Enabled defines if the operator deploys a memcached cache, which caches the bloom filters and the Parquet footers of the blocks.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MemoryLimitMB is the memory in megabytes used for the items of each memcached instance. Defaults to 1024.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=64
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Memory Limit (MB)",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MemoryLimitMB int `json:"memoryLimitMB,omitempty"`
+
+	// MaxConnections is the maximum number of simultaneous connections of each memcached instance. Defaults to 1024.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Connections",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxConnections int `json:"maxConnections,omitempty"`
+}
+
+// MetricsGeneratorProcessor defines a processor of the metrics-generator.
+//
+// +kubebuilder:validation:Enum=service-graphs;span-metrics
+type MetricsGeneratorProcessor string
+
+const (
+	// MetricsGeneratorProcessorServiceGraphs generates metrics describing the relationships between services.
+	MetricsGeneratorProcessorServiceGraphs MetricsGeneratorProcessor = "service-graphs"
+	// MetricsGeneratorProcessorSpanMetrics generates request, error and duration (RED) metrics from the spans.
+	MetricsGeneratorProcessorSpanMetrics MetricsGeneratorProcessor = "span-metrics"
+)
+
+// TempoMetricsGeneratorSpec extends TempoComponentSpec with metrics-generator parameters.
+type TempoMetricsGeneratorSpec struct {
+	// TempoComponentSpec is embedded to extend this definition with further options.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:",inline"`
+
+	// Enabled defines if the metrics-generator is deployed.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Processors defines the enabled processors of the metrics-generator.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Processors"
+	Processors []MetricsGeneratorProcessor `json:"processors,omitempty"`
+
+	// RemoteWrite defines the Prometheus remote write endpoints receiving the generated metrics.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Remote Write"
+	RemoteWrite []RemoteWriteSpec `json:"remoteWrite,omitempty"`
+}
+
+// RemoteWriteSpec defines a Prometheus remote write endpoint.
+// Either the URL must be set or OpenShiftMonitoring must be enabled.
+type RemoteWriteSpec struct {
+	// URL of the remote write endpoint, e.g. http://prometheus:9090/api/v1/write.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="URL"
+	URL string `json:"url,omitempty"`
+
+	// OpenShiftMonitoring sends the metrics to the Prometheus of the OpenShift in-cluster monitoring stack.
+	// The metrics-generator authenticates with the token of its service account and verifies the
+	// endpoint with the service CA bundle. The remote write receiver must be enabled in the
+	// cluster monitoring configuration, and the service account must be allowed to write metrics.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OpenShift Monitoring",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	OpenShiftMonitoring bool `json:"openshiftMonitoring,omitempty"`
+
+	// Headers are added to every remote write request.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Headers"
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Auth defines the authentication with the remote write endpoint.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Authentication"
+	Auth *RemoteWriteAuthSpec `json:"auth,omitempty"`
+
+	// TLS defines the TLS connection to the remote write endpoint.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS"
+	TLS *RemoteWriteTLSSpec `json:"tls,omitempty"`
+}
+
+// RemoteWriteAuthType defines the authentication type of a remote write endpoint.
+//
+// +kubebuilder:validation:Enum=basic;bearer
+type RemoteWriteAuthType string
+
+const (
+	// RemoteWriteAuthTypeBasic uses the username and password keys of the Secret for HTTP basic authentication.
+	RemoteWriteAuthTypeBasic RemoteWriteAuthType = "basic"
+	// RemoteWriteAuthTypeBearer sends the token key of the Secret as bearer token.
+	RemoteWriteAuthTypeBearer RemoteWriteAuthType = "bearer"
+)
+
+// RemoteWriteAuthSpec defines the authentication with a remote write endpoint.
+type RemoteWriteAuthSpec struct {
+	// Type is the authentication type.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Type",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:basic","urn:alm:descriptor:com.tectonic.ui:select:bearer"}
+	Type RemoteWriteAuthType `json:"type"`
+
+	// Secret is the name of a Secret in the same namespace containing the credentials.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Credentials Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	Secret string `json:"secret"`
+}
+
+// RemoteWriteTLSSpec defines the TLS connection to a remote write endpoint.
+type RemoteWriteTLSSpec struct {
+	// CA is the name of a ConfigMap containing the CA bundle (service-ca.crt) used to verify the certificate of the endpoint.
+	// If empty, the system CA bundle is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CA ConfigMap",xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap"
+	CA string `json:"caName,omitempty"`
+
+	// CertName is the name of a Secret containing the client certificate (tls.crt) and private key (tls.key).
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Client Certificate Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	CertName string `json:"certName,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the certificate of the endpoint.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Skip Certificate Verification",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// TempoCompactorSpec extends TempoComponentSpec with compactor parameters.
+type TempoCompactorSpec struct {
+	// TempoComponentSpec is embedded to extend this definition with further options.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:",inline"`
+
+	// CompactionWindow is the time range of the blocks which are compacted together. Defaults to 1h.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Compaction Window",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	CompactionWindow metav1.Duration `json:"compactionWindow,omitempty"`
+
+	// MaxBlockBytes is the maximum size of a compacted block in bytes. Defaults to 100 GiB.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Block Bytes",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxBlockBytes *int `json:"maxBlockBytes,omitempty"`
+
+	// RetentionConcurrency is the number of tenants for which the retention is applied concurrently. Defaults to 10.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retention Concurrency",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	RetentionConcurrency *int `json:"retentionConcurrency,omitempty"`
+
+	// CompactionCycle is the time between the compaction cycles. Defaults to 30s.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Compaction Cycle",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	CompactionCycle metav1.Duration `json:"compactionCycle,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget of the compactor.
+	// The operator creates a PodDisruptionBudget if the compactor runs more than one replica.
+	// Multiple compactors share the blocks to compact and to delete using the compactor ring.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Disruption Budget"
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// VolumeClaimTemplate defines a persistent volume for the temporary data of the compactor.
+	// The compactor uses an emptyDir volume if no volume claim template is set.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Volume Claim Template"
+	VolumeClaimTemplate *VolumeClaimTemplateSpec `json:"volumeClaimTemplate,omitempty"`
+}
+
+// TempoIngesterSpec extends TempoComponentSpec with ingester parameters.
+type TempoIngesterSpec struct {
+	// TempoComponentSpec is embedded to extend this definition with further options.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:",inline"`
+
+	// VolumeClaimTemplate defines the persistent volume of the ingester.
+	// Unset fields fall back to spec.storageClassName and spec.storageSize.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Volume Claim Template"
+	VolumeClaimTemplate *VolumeClaimTemplateSpec `json:"volumeClaimTemplate,omitempty"`
+
+	// TraceIdlePeriod is the time after which a trace without new spans is flushed to the WAL. Defaults to 10s.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Trace Idle Period",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	TraceIdlePeriod metav1.Duration `json:"traceIdlePeriod,omitempty"`
+
+	// MaxBlockDuration is the maximum time a block is open for new traces before it is cut. Defaults to 10m.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Block Duration",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	MaxBlockDuration metav1.Duration `json:"maxBlockDuration,omitempty"`
+
+	// MaxBlockBytes is the maximum size of a block in bytes before it is cut. Defaults to 500 MiB.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Block Bytes",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxBlockBytes *int `json:"maxBlockBytes,omitempty"`
+
+	// CompleteBlockTimeout is the time a completed block is kept in the ingester after it was flushed to the object storage.
+	// Defaults to 15m.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Complete Block Timeout",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	CompleteBlockTimeout metav1.Duration `json:"completeBlockTimeout,omitempty"`
+
+	// FlushCheckPeriod is the interval in which the ingester checks for traces to flush to the WAL and for blocks to cut. Defaults to 10s.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Flush Check Period",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	FlushCheckPeriod metav1.Duration `json:"flushCheckPeriod,omitempty"`
+
+	// ConcurrentFlushes is the number of blocks flushed concurrently to the object storage. Defaults to 4.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Concurrent Flushes",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	ConcurrentFlushes *int `json:"concurrentFlushes,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget of the ingesters.
+	// The operator creates a PodDisruptionBudget if more than one ingester is deployed.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Disruption Budget"
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// ZoneAwareReplication replicates the traces across availability zones, so that a zone outage doesn't lose in-flight traces.
+	// The operator creates one ingester StatefulSet per zone, and the replicas of the ingester are deployed in each zone.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Zone Aware Replication"
+	ZoneAwareReplication *ZoneAwareReplicationSpec `json:"zoneAwareReplication,omitempty"`
+}
+
+// ZoneAwareReplicationSpec defines the zone-aware replication of the ingesters.
+type ZoneAwareReplicationSpec struct {
+	// Zones is the list of availability zones, i.e. the values of the topology key node label.
+	// The replication factor must be greater than or equal to the number of zones.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=2
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Zones"
+	Zones []string `json:"zones"`
+
+	// TopologyKey is the node label which contains the availability zone of a node.
+	// Defaults to topology.kubernetes.io/zone.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Topology Key"
+	TopologyKey string `json:"topologyKey,omitempty"`
+}
+
+// TempoComponentSpec defines specific schedule settings for tempo components.
+type TempoComponentSpec struct {
+	// Replicas represents the number of replicas to create for this component.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Component Replicas"
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// NodeSelector is the simplest recommended form of node selection constraint.
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Node Selector"
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations defines component specific pod tolerations.
+	//
+	// +optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// PodLabels defines additional labels of the pods of this component, e.g. for cost allocation or log routing.
+	// Labels managed by the operator take precedence and cannot be overwritten.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Labels"
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// PodAnnotations defines additional annotations of the pods of this component, e.g. for service mesh injection.
+	// Annotations managed by the operator take precedence and cannot be overwritten.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Annotations"
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// ServiceLabels defines additional labels of the Services of this component.
+	// Labels managed by the operator take precedence and cannot be overwritten.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Labels"
+	ServiceLabels map[string]string `json:"serviceLabels,omitempty"`
+
+	// ServiceAnnotations defines additional annotations of the Services of this component,
+	// e.g. to configure the load balancer of a cloud provider, topology aware routing or a service mesh.
+	// Annotations managed by the operator take precedence and cannot be overwritten.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Annotations"
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+
+	// Resources defines the resources of this component, which take precedence over the resources
+	// computed from spec.resources.total or spec.resources.profile.
+	// The resources of the memcached component are derived from its memory limit instead.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resources",xDescriptors="urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ExtraContainers defines additional containers of the pods of this component, e.g. log shippers.
+	// The schema of the extra containers and volumes is not part of the CRD, to keep the size of the CRD
+	// below the size limit of the API server. They are validated when the pods are created.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Extra Containers"
+	ExtraContainers []corev1.Container `json:"extraContainers,omitempty"`
+
+	// ExtraInitContainers defines additional init containers of the pods of this component,
+	// which run after the init containers managed by the operator.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Extra Init Containers"
+	ExtraInitContainers []corev1.Container `json:"extraInitContainers,omitempty"`
+
+	// ExtraVolumes defines additional volumes of the pods of this component,
+	// which can be mounted by the extra containers and extra init containers.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Extra Volumes"
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// Affinity defines the scheduling constraints of the pods of this component,
+	// e.g. to schedule the ingesters on storage-optimized nodes.
+	// It replaces the default affinity, which prefers to schedule the pods on different nodes and zones.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Affinity"
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints defines how the pods of this component are spread across
+	// failure domains, e.g. zones. Constraints without a label selector select the pods of this component.
+	// The default affinity only prefers to schedule the pods on different nodes and zones.
+	//
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Topology Spread Constraints"
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of the pods of this component,
+	// e.g. to give the ingesters a higher priority and avoid the eviction of ingesters with unflushed traces.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Priority Class Name",xDescriptors="urn:alm:descriptor:io.kubernetes:PriorityClass"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// LogLevel overrides the log level of this component, for example to enable debug logging for a single component.
+	// Defaults to spec.observability.logging.level. It is not supported by the memcached component.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Log Level",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:debug","urn:alm:descriptor:com.tectonic.ui:select:info","urn:alm:descriptor:com.tectonic.ui:select:warn","urn:alm:descriptor:com.tectonic.ui:select:error"}
+	LogLevel LogLevel `json:"logLevel,omitempty"`
+
+	// PodSecurityContext overrides the security context of the pods of this component.
+	// By default, the pods comply with the restricted Pod Security Standard.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Security Context"
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// SecurityContext overrides the security context of the containers of this component.
+	// It does not apply to the extra containers, which define their own security context.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Container Security Context"
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// Env defines additional environment variables of the containers of this component,
+	// e.g. proxy settings or the configuration of cloud SDKs.
+	// Environment variables managed by the operator take precedence and cannot be overwritten.
+	// It does not apply to the extra containers, which define their own environment variables.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Environment Variables"
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// EnvFrom defines Secrets and ConfigMaps whose keys are added as environment variables to the containers
+	// of this component. Environment variables defined in env or managed by the operator take precedence.
+	// It does not apply to the extra containers, which define their own environment variables.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Environment Variables From"
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+}
+
+// VolumeClaimTemplateSpec defines the PersistentVolumeClaim of a component.
+type VolumeClaimTemplateSpec struct {
+	// StorageClassName of the PersistentVolumeClaim.
+	// Defaults to spec.storageClassName.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage Class Name"
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// Size of the PersistentVolumeClaim.
+	// Defaults to spec.storageSize.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Size"
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// AccessModes of the PersistentVolumeClaim.
+	// Defaults to ReadWriteOnce.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Access Modes"
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+
+	// Annotations defines additional annotations of the PersistentVolumeClaim.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Annotations"
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// TempoDistributorSpec extends TempoComponentSpec with distributor parameters.
+type TempoDistributorSpec struct {
+	// TempoComponentSpec is embedded to extend this definition with further options.
+	//
+	// The field is inlined to keep the existing distributor settings at their current path.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:",inline"`
+
+	// TLS defines the TLS configuration of the distributor receivers.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Receivers TLS"
+	TLS ReceiversTLSSpec `json:"tls,omitempty"`
+
+	// Receivers defines additional receivers of the distributor.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Receivers"
+	Receivers ReceiversSpec `json:"receivers,omitempty"`
+
+	// ServiceType defines the type of the distributor Service.
+	// Use NodePort or LoadBalancer to expose the receivers to agents outside of the cluster.
+	// This option is not supported if the gateway is enabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +kubebuilder:default:=ClusterIP
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Type"
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// LogReceivedSpans configures the distributor to log the trace and span IDs of all received spans.
+	// This option is meant for debugging and should not be enabled permanently in high volume environments.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Log Received Spans"
+	LogReceivedSpans *LogReceivedSpansSpec `json:"logReceivedSpans,omitempty"`
+
+	// Autoscaling configures a HorizontalPodAutoscaler for the distributor.
+	// The replicas of the distributor cannot be set if autoscaling is enabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Autoscaling"
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget of the distributor.
+	// The operator creates a PodDisruptionBudget if the distributor runs more than one replica.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Disruption Budget"
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+}
+
+// TempoQuerierSpec extends TempoComponentSpec with querier parameters.
+type TempoQuerierSpec struct {
+	// TempoComponentSpec is embedded to extend this definition with further options.
+	//
+	// The field is inlined to keep the existing querier settings at their current path.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	TempoComponentSpec `json:",inline"`
+
+	// Autoscaling configures a HorizontalPodAutoscaler for the querier.
+	// The replicas of the querier cannot be set if autoscaling is enabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Autoscaling"
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget of the querier.
+	// The operator creates a PodDisruptionBudget if the querier runs more than one replica.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Disruption Budget"
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+}
+
+// LogReceivedSpansSpec defines the logging of received spans by the distributor.
+// Tempo does not support filtering the logged spans by tenant, the tenant of a span is not part of the log line.
+type LogReceivedSpansSpec struct {
+	// Enabled defines if the received spans are logged.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled,omitempty"`
+
+	// IncludeAllAttributes defines if the span name, service name and all attributes of the spans are logged.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Include All Attributes",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	IncludeAllAttributes bool `json:"includeAllAttributes,omitempty"`
+
+	// FilterByStatusError defines if only spans with the error status are logged.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Filter by Status Error",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	FilterByStatusError bool `json:"filterByStatusError,omitempty"`
+}
+
+// ReceiversSpec defines additional receivers of the distributor.
+type ReceiversSpec struct {
+	// Kafka configures the distributor to consume spans from Kafka topics.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kafka"
+	Kafka *KafkaReceiverSpec `json:"kafka,omitempty"`
+
+	// Zipkin configures the Zipkin receiver.
+	// If not specified, the Zipkin receiver is enabled on the default port 9411 if the gateway is disabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Zipkin"
+	Zipkin *ZipkinReceiverSpec `json:"zipkin,omitempty"`
+
+	// Jaeger configures the protocols of the Jaeger receiver.
+	// If not specified, all protocols are enabled on their default ports if the gateway is disabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jaeger"
+	Jaeger *JaegerReceiverSpec `json:"jaeger,omitempty"`
+
+	// OTLP configures the ports of the OTLP receiver.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OTLP"
+	OTLP *OTLPReceiverSpec `json:"otlp,omitempty"`
+}
+
+// OTLPReceiverSpec defines the ports and the exposure of the OTLP receiver of the distributor.
+type OTLPReceiverSpec struct {
+	// GRPCPort is the port of the OTLP gRPC protocol. Defaults to 4317.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="gRPC Port",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	GRPCPort int32 `json:"grpcPort,omitempty"`
+
+	// HTTPPort is the port of the OTLP HTTP protocol. Defaults to 4318.
+	// The OTLP HTTP protocol is only enabled if the gateway is disabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="HTTP Port",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	HTTPPort int32 `json:"httpPort,omitempty"`
+
+	// GRPC defines the server settings of the OTLP gRPC protocol.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="gRPC Settings"
+	GRPC OTLPGRPCSpec `json:"grpc,omitempty"`
+
+	// GRPCIngress exposes the OTLP gRPC receiver outside of the cluster.
+	// An Ingress is annotated to use the gRPC backend protocol of the ingress-nginx controller.
+	// A Route requires TLS on the distributor receivers, and supports the passthrough and reencrypt termination only.
+	// Additional hosts are only supported by an Ingress.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="gRPC Ingress"
+	GRPCIngress IngressSpec `json:"grpcIngress,omitempty"`
+
+	// HTTPIngress exposes the OTLP HTTP receiver outside of the cluster.
+	// Additional hosts are only supported by an Ingress.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="HTTP Ingress"
+	HTTPIngress IngressSpec `json:"httpIngress,omitempty"`
+}
+
+// JaegerReceiverSpec defines the protocols of the Jaeger receiver of the distributor.
+// A protocol which is not specified is enabled on its default port.
+// The gateway does not support the Jaeger protocols, therefore they can only be enabled if the gateway is disabled.
+type JaegerReceiverSpec struct {
+	// ThriftHTTP configures the Thrift HTTP protocol. The default port is 14268.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Thrift HTTP"
+	ThriftHTTP *ReceiverProtocolSpec `json:"thriftHttp,omitempty"`
+
+	// ThriftBinary configures the Thrift binary protocol (UDP). The default port is 6832.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Thrift Binary"
+	ThriftBinary *ReceiverProtocolSpec `json:"thriftBinary,omitempty"`
+
+	// ThriftCompact configures the Thrift compact protocol (UDP). The default port is 6831.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Thrift Compact"
+	ThriftCompact *ReceiverProtocolSpec `json:"thriftCompact,omitempty"`
+
+	// GRPC configures the gRPC protocol. The default port is 14250.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="gRPC"
+	GRPC *ReceiverProtocolSpec `json:"grpc,omitempty"`
+}
+
+// ReceiverProtocolSpec defines a protocol of a receiver.
+type ReceiverProtocolSpec struct {
+	// Enabled defines if the protocol is enabled. Defaults to true.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+
+	// Port is the port of the protocol. Defaults to the default port of the protocol.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Port",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	Port int32 `json:"port,omitempty"`
+}
+
+// ZipkinReceiverSpec defines the Zipkin receiver of the distributor.
+// The gateway does not support the Zipkin protocol, therefore the Zipkin receiver can only be enabled if the gateway is disabled.
+type ZipkinReceiverSpec struct {
+	// Enabled defines if the Zipkin receiver is enabled. Defaults to true.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+
+	// Port is the port of the Zipkin receiver. Defaults to 9411.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default:=9411
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Port",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	Port int32 `json:"port,omitempty"`
+
+	// Ingress exposes the Zipkin receiver outside of the cluster.
+	// Additional hosts and custom Route certificates are not supported.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingress"
+	Ingress IngressSpec `json:"ingress,omitempty"`
+}
+
+// OTLPGRPCSpec defines the server settings of the OTLP gRPC protocol.
+// Unset settings keep the defaults of Tempo.
+type OTLPGRPCSpec struct {
+	// MaxRecvMsgSizeMiB defines the maximum size of a received message in MiB.
+	// Increase this setting if large batches are rejected with ResourceExhausted.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Receive Message Size in MiB",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxRecvMsgSizeMiB *int `json:"maxRecvMsgSizeMiB,omitempty"`
+
+	// MaxConcurrentStreams defines the maximum number of concurrent streams of a client connection.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Concurrent Streams",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxConcurrentStreams *int `json:"maxConcurrentStreams,omitempty"`
+
+	// ReadBufferSize defines the size of the read buffer of a connection in bytes.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Read Buffer Size in Bytes",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	ReadBufferSize *int `json:"readBufferSize,omitempty"`
+
+	// WriteBufferSize defines the size of the write buffer of a connection in bytes.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Write Buffer Size in Bytes",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	WriteBufferSize *int `json:"writeBufferSize,omitempty"`
+}
+
+// KafkaEncoding defines the encoding of the spans in a Kafka topic.
+//
+// +kubebuilder:validation:Enum=otlp_proto;otlp_json;jaeger_proto;jaeger_json;zipkin_proto;zipkin_json;zipkin_thrift
+type KafkaEncoding string
+
+// KafkaSASLMechanism defines the SASL mechanism of the Kafka authentication.
+//
+// +kubebuilder:validation:Enum=PLAIN;SCRAM-SHA-256;SCRAM-SHA-512
+type KafkaSASLMechanism string
+
+const (
+	// KafkaSASLMechanismPlain authenticates with a plain username and password.
+	KafkaSASLMechanismPlain KafkaSASLMechanism = "PLAIN"
+	// KafkaSASLMechanismSCRAMSHA256 authenticates with the SCRAM-SHA-256 mechanism.
+	KafkaSASLMechanismSCRAMSHA256 KafkaSASLMechanism = "SCRAM-SHA-256"
+	// KafkaSASLMechanismSCRAMSHA512 authenticates with the SCRAM-SHA-512 mechanism.
+	KafkaSASLMechanismSCRAMSHA512 KafkaSASLMechanism = "SCRAM-SHA-512"
+)
+
+// KafkaReceiverSpec defines the Kafka receiver of the distributor.
+// Kafka messages do not contain a tenant, therefore the Kafka receiver does not support multitenancy.
+type KafkaReceiverSpec struct {
+	// Brokers is the list of the Kafka brokers, e.g. kafka-bootstrap.kafka.svc:9092.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Brokers"
+	Brokers []string `json:"brokers"`
+
+	// Topic is the name of the Kafka topic to consume the spans from. Defaults to otlp_spans.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="otlp_spans"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Topic"
+	Topic string `json:"topic,omitempty"`
+
+	// Encoding is the encoding of the spans in the topic. Defaults to otlp_proto.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="otlp_proto"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Encoding"
+	Encoding KafkaEncoding `json:"encoding,omitempty"`
+
+	// GroupID is the consumer group of the distributors. Defaults to tempo.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="tempo"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Consumer Group ID"
+	GroupID string `json:"groupId,omitempty"`
+
+	// ProtocolVersion is the Kafka protocol version. Defaults to 2.0.0.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="2.0.0"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Protocol Version"
+	ProtocolVersion string `json:"protocolVersion,omitempty"`
+
+	// SASL defines the SASL authentication with the brokers.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="SASL Authentication"
+	SASL *KafkaSASLSpec `json:"sasl,omitempty"`
+
+	// TLS enables TLS for the connections to the brokers.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS"
+	TLS *KafkaTLSSpec `json:"tls,omitempty"`
+}
+
+// KafkaSASLSpec defines the SASL authentication with the Kafka brokers.
+type KafkaSASLSpec struct {
+	// Secret is the name of a Secret in the same namespace containing the username and password keys.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Credentials Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	Secret string `json:"secret"`
+
+	// Mechanism is the SASL mechanism. Defaults to PLAIN.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="PLAIN"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Mechanism"
+	Mechanism KafkaSASLMechanism `json:"mechanism,omitempty"`
+}
+
+// KafkaTLSSpec defines the TLS connections to the Kafka brokers.
+type KafkaTLSSpec struct {
+	// CA is the name of a ConfigMap containing the CA bundle (service-ca.crt) used to verify the certificates of the brokers.
+	// If empty, the system CA bundle is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CA ConfigMap",xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap"
+	CA string `json:"caName,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the certificates of the brokers.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Skip Certificate Verification",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ReceiversTLSSpec is the TLS configuration of the OTLP, Jaeger and Zipkin receivers.
+type ReceiversTLSSpec struct {
+	// Enabled enables TLS termination on the receivers.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled,omitempty"`
+
+	// CertName is the name of a Secret containing the serving certificate (tls.crt) and private key (tls.key).
+	// If empty, the distributor certificate managed by the operator or cert-manager is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Certificate Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	CertName string `json:"certName,omitempty"`
+
+	// CA is the name of a ConfigMap containing the CA bundle (service-ca.crt) used to verify client certificates.
+	// If set, the receivers require and verify client certificates (mTLS).
+	// It needs to be in the same namespace as the TempoStack custom resource.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap",displayName="Client CA ConfigMap Name"
+	CA string `json:"caName,omitempty"`
+
+	// MinTLSVersion overrides the minimal TLS version of the TLS profile for the receivers.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=VersionTLS10;VersionTLS11;VersionTLS12;VersionTLS13
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Minimal TLS Version"
+	MinTLSVersion string `json:"minTLSVersion,omitempty"`
+
+	// OTLPGRPC overrides the TLS settings of the OTLP gRPC receiver.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OTLP gRPC"
+	OTLPGRPC *ReceiverTLSOverrideSpec `json:"otlpGrpc,omitempty"`
+
+	// OTLPHTTP overrides the TLS settings of the OTLP HTTP receiver.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OTLP HTTP"
+	OTLPHTTP *ReceiverTLSOverrideSpec `json:"otlpHttp,omitempty"`
+
+	// Jaeger overrides the TLS settings of the Jaeger Thrift HTTP and gRPC receivers.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jaeger"
+	Jaeger *ReceiverTLSOverrideSpec `json:"jaeger,omitempty"`
+
+	// Zipkin overrides the TLS settings of the Zipkin receiver.
+	//
 	// +optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Component Replicas"
-	Replicas *int32 `json:"replicas,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Zipkin"
+	Zipkin *ReceiverTLSOverrideSpec `json:"zipkin,omitempty"`
+}
 
-	// NodeSelector is the simplest recommended form of node selection constraint.
+// ReceiverTLSOverrideSpec overrides the TLS settings of a single receiver.
+// Settings which are not specified are inherited from the TLS settings of all receivers.
+type ReceiverTLSOverrideSpec struct {
+	// CertName is the name of a Secret containing the serving certificate (tls.crt) and private key (tls.key) of the receiver.
 	//
 	// +optional
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Node Selector"
-	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Certificate Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	CertName string `json:"certName,omitempty"`
 
-	// Tolerations defines component specific pod tolerations.
+	// CA is the name of a ConfigMap containing the CA bundle (service-ca.crt) used to verify client certificates of the receiver.
 	//
 	// +optional
-	// +listType=atomic
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tolerations"
-	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap",displayName="Client CA ConfigMap Name"
+	CA string `json:"caName,omitempty"`
+
+	// MinTLSVersion is the minimal TLS version of the receiver.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=VersionTLS10;VersionTLS11;VersionTLS12;VersionTLS13
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Minimal TLS Version"
+	MinTLSVersion string `json:"minTLSVersion,omitempty"`
 }
 
 // TempoGatewaySpec extends TempoComponentSpec with gateway parameters.
@@ -473,6 +3005,271 @@ type TempoGatewaySpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jaeger gateway Ingress Settings"
 	Ingress IngressSpec `json:"ingress,omitempty"`
+	// Autoscaling scales the gateway horizontally with a HorizontalPodAutoscaler.
+	// The replicas of the gateway component are managed by the autoscaler if this option is set.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Autoscaling"
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+	// PodDisruptionBudget configures the PodDisruptionBudget of the gateway.
+	// The operator creates a PodDisruptionBudget if the gateway runs more than one replica.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Disruption Budget"
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+	// RateLimits defines per-tenant rate limits of the HTTP requests to the gateway.
+	// The limits are evaluated by each gateway replica independently.
+	// The OTLP/gRPC ingestion is limited by the ingestion limits of the distributor (spec.limits).
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Rate Limits"
+	RateLimits []GatewayRateLimitSpec `json:"rateLimits,omitempty"`
+	// AuditLog enables structured audit logs of the requests to the gateway.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Audit Log"
+	AuditLog *GatewayAuditLogSpec `json:"auditLog,omitempty"`
+	// AccessReviewCache caches the results of the TokenReviews and SubjectAccessReviews of the openshift mode
+	// in a memcached sidecar container, to reduce the load on the API server and the latency of the requests.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Access Review Cache"
+	AccessReviewCache *GatewayAccessReviewCacheSpec `json:"accessReviewCache,omitempty"`
+	// RBAC filters the query results of the openshift mode by the namespaces which the user has access to.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Query RBAC"
+	RBAC RBACSpec `json:"rbac,omitempty"`
+}
+
+// RBACSpec defines the attribute-based access control of the query results.
+type RBACSpec struct {
+	// Enabled filters the spans returned by the gateway by their k8s.namespace.name resource attribute.
+	// A user only receives the spans of the namespaces in which they are allowed to get the traces of the tenant,
+	// which allows multiple teams to share a tenant. Cluster administrators receive all spans.
+	// Spans without the k8s.namespace.name resource attribute are only returned to cluster administrators.
+	// Requires a gateway image (spec.images.tempoGateway) which supports the --traces.query-rbac flag of observatorium/api,
+	// i.e. an image built on 2023-11-01 or later. The default gateway image does not support it.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// GatewayAccessReviewCacheSpec defines the cache of the access reviews in openshift mode.
+type GatewayAccessReviewCacheSpec struct {
+	// TTL is the time after which a cached access review expires. Defaults to 1m.
+	// Changes of the permissions of a user take effect after this time at the latest.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="1m"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TTL"
+	TTL metav1.Duration `json:"ttl,omitempty"`
+
+	// SizeMB is the maximum memory of the cache in megabytes. Defaults to 64.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=64
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Size (MB)"
+	SizeMB int32 `json:"sizeMB,omitempty"`
+}
+
+// GatewayAuditLogOutput defines the output of the gateway audit logs.
+//
+// +kubebuilder:validation:Enum=stdout
+type GatewayAuditLogOutput string
+
+const (
+	// GatewayAuditLogOutputStdout writes the audit logs to the standard output of the gateway containers.
+	GatewayAuditLogOutputStdout GatewayAuditLogOutput = "stdout"
+)
+
+// GatewayAuditLogSpec defines the audit logs of the gateway.
+// Every request is logged in JSON format with the tenant, subject, method, path and response status,
+// and every authorization decision is logged with the tenant, subject, verb and result.
+type GatewayAuditLogSpec struct {
+	// Output defines where the audit logs are written to. Defaults to stdout.
+	// The gateway cannot write its logs to a file, therefore a file output is not supported.
+	// Use the cluster log forwarding to ship the audit logs to a persistent storage.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="stdout"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Output"
+	Output GatewayAuditLogOutput `json:"output,omitempty"`
+}
+
+// GatewayRateLimitSpec defines the rate limit of a tenant at the gateway.
+type GatewayRateLimitSpec struct {
+	// Tenant is the name of the tenant (spec.tenants.authentication[].tenantName).
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tenant"
+	Tenant string `json:"tenant"`
+
+	// Endpoint is a regular expression matching the request paths of the limit.
+	// Defaults to all endpoints of the tenant.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Endpoint"
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Limit is the maximum number of requests in a window. It is also the maximum burst size.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Limit"
+	Limit int32 `json:"limit"`
+
+	// Window is the time window of the limit. Defaults to 1s.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="1s"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Window"
+	Window metav1.Duration `json:"window,omitempty"`
+}
+
+// PodDisruptionBudgetSpec defines the PodDisruptionBudget of a component.
+type PodDisruptionBudgetSpec struct {
+	// MaxUnavailable is the maximum number or percentage of unavailable pods during voluntary disruptions,
+	// e.g. node drains. Defaults to 1.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Unavailable"
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// AutoscalingSpec defines the HorizontalPodAutoscaler of a component.
+type AutoscalingSpec struct {
+	// MinReplicas is the lower limit for the number of replicas. Defaults to 1.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Minimum Replicas"
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of replicas.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maximum Replicas"
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetCPUUtilization is the target average CPU utilization in percent of the requested CPU.
+	// Defaults to 80 if no target is set. Requires resource requests (spec.resources).
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target CPU Utilization"
+	TargetCPUUtilization *int32 `json:"targetCPUUtilization,omitempty"`
+
+	// TargetMemoryUtilization is the target average memory utilization in percent of the requested memory.
+	// Requires resource requests (spec.resources).
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target Memory Utilization"
+	TargetMemoryUtilization *int32 `json:"targetMemoryUtilization,omitempty"`
+
+	// Metrics defines additional metrics of the HorizontalPodAutoscaler, e.g. custom or external metrics.
+	// If only custom metrics are set, the CPU utilization target is not added by default.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics"
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+
+	// Keda configures a KEDA ScaledObject instead of a HorizontalPodAutoscaler, to scale on tracing specific
+	// signals like the queue length of the distributor or the lag of a Kafka topic.
+	// Requires KEDA to be installed in the cluster.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="KEDA"
+	Keda *KedaSpec `json:"keda,omitempty"`
+}
+
+// KedaSpec defines the KEDA ScaledObject of a component.
+// The minimum and maximum replicas and the CPU and memory utilization targets of the
+// autoscaling configuration are applied to the ScaledObject.
+type KedaSpec struct {
+	// Triggers defines the KEDA triggers, for example a Prometheus query or the lag of a Kafka consumer group.
+	// See https://keda.sh/docs/latest/scalers/ for the available triggers.
+	// The CPU and memory utilization targets are added as cpu and memory triggers,
+	// at least one trigger or utilization target is required.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Triggers"
+	Triggers []KedaTriggerSpec `json:"triggers,omitempty"`
+
+	// PollingInterval is the interval in seconds to check each trigger. Defaults to 30 seconds.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Polling Interval"
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+
+	// CooldownPeriod is the period in seconds to wait after the last trigger reported active
+	// before scaling down. Defaults to 300 seconds.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cooldown Period"
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+}
+
+// KedaTriggerSpec defines a KEDA trigger.
+type KedaTriggerSpec struct {
+	// Type is the type of the trigger, e.g. prometheus or kafka.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Type"
+	Type string `json:"type"`
+
+	// Name is an optional name of the trigger.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name"
+	Name string `json:"name,omitempty"`
+
+	// Metadata defines the configuration of the trigger, e.g. the server address, query and threshold of a Prometheus trigger.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metadata"
+	Metadata map[string]string `json:"metadata"`
+
+	// AuthenticationRef is the name of a KEDA TriggerAuthentication in the namespace of the TempoStack.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Authentication Reference"
+	AuthenticationRef string `json:"authenticationRef,omitempty"`
 }
 
 // TempoQueryFrontendSpec extends TempoComponentSpec with frontend specific parameters.
@@ -492,6 +3289,84 @@ type TempoQueryFrontendSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jaeger Query Settings"
 	JaegerQuery JaegerQuerySpec `json:"jaegerQuery"`
+
+	// Search defines the tuning parameters of the search requests in the query-frontend.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Search Tuning"
+	Search *QueryFrontendSearchSpec `json:"search,omitempty"`
+
+	// ResultsCache caches the results of the search jobs in the query-frontend, which speeds up repeated searches.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Results Cache"
+	ResultsCache *QueryFrontendResultsCacheSpec `json:"resultsCache,omitempty"`
+}
+
+// QueryFrontendResultsCacheSpec defines the results cache of the query-frontend.
+// The results are stored in the cache of the TempoStack, i.e. the cache of spec.cache or the memcached
+// managed by the operator (spec.template.memcached). The results cache requires Tempo 2.4 or later.
+type QueryFrontendResultsCacheSpec struct {
+	// Enabled enables the results cache.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled,omitempty"`
+
+	// TTL is the time after which a cached result expires.
+	// If unset, the results are kept until they are evicted by the cache.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TTL",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	TTL metav1.Duration `json:"ttl,omitempty"`
+}
+
+// QueryFrontendSearchSpec defines the tuning parameters of the search requests in the query-frontend.
+// The query-frontend shards a search into jobs, which query the ingesters for recent data and the object storage for older data.
+type QueryFrontendSearchSpec struct {
+	// ConcurrentJobs is the number of jobs of a search executed concurrently. Defaults to 2000.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Concurrent Jobs",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	ConcurrentJobs *int `json:"concurrentJobs,omitempty"`
+
+	// TargetBytesPerJob is the amount of data in bytes searched by a single job in the object storage.
+	// If unset, the default of Tempo is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target Bytes Per Job",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	TargetBytesPerJob *int `json:"targetBytesPerJob,omitempty"`
+
+	// MaxDuration is the maximum allowed time range of a search. Overrides spec.search.maxDuration if set.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Search Duration",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	MaxDuration metav1.Duration `json:"maxDuration,omitempty"`
+
+	// QueryBackendAfter is the age of the data after which it is searched in the object storage.
+	// If unset, the default of Tempo is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Query Backend After",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	QueryBackendAfter metav1.Duration `json:"queryBackendAfter,omitempty"`
+
+	// QueryIngestersUntil is the age of the data until which it is searched in the ingesters.
+	// If unset, the default of Tempo is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Query Ingesters Until",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	QueryIngestersUntil metav1.Duration `json:"queryIngestersUntil,omitempty"`
 }
 
 // JaegerQuerySpec defines Jaeger Query options.
@@ -515,6 +3390,36 @@ type JaegerQuerySpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jaeger Query UI Monitor Tab Settings"
 	MonitorTab JaegerQueryMonitor `json:"monitorTab"`
+
+	// Authentication protects the Jaeger Query UI with an OpenShift OAuth proxy sidecar.
+	// This option is only available on OpenShift and if the gateway is disabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jaeger Query UI Authentication"
+	Authentication *JaegerQueryAuthenticationSpec `json:"authentication,omitempty"`
+}
+
+// JaegerQueryAuthenticationSpec defines the OpenShift OAuth proxy in front of the Jaeger Query UI.
+// The proxy serves plain HTTP, therefore the Route needs to use the edge TLS termination.
+// The operator registers the Route as OAuth redirect URI of the default service account.
+// A custom service account (spec.serviceAccount) needs the serviceaccounts.openshift.io/oauth-redirectreference.primary
+// annotation referencing the Route of the Jaeger Query UI.
+type JaegerQueryAuthenticationSpec struct {
+	// Enabled injects the OAuth proxy sidecar into the query-frontend pods.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled bool `json:"enabled"`
+
+	// SAR is the SubjectAccessReview which authorizes the users of the Jaeger Query UI, in JSON format.
+	// Defaults to {"namespace": "<TempoStack namespace>", "resource": "pods", "verb": "get"}.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="SubjectAccessReview"
+	SAR string `json:"sar,omitempty"`
 }
 
 // JaegerQueryMonitor defines configuration for the service monitoring tab in the Jaeger console.
@@ -524,7 +3429,8 @@ type JaegerQuerySpec struct {
 // which derives span RED metrics from spans and exports the metrics to Prometheus.
 type JaegerQueryMonitor struct {
 	// Enabled enables monitoring tab in Jaeger console.
-	// PrometheusEndpoint needs to be set to enable the feature.
+	// PrometheusEndpoint needs to be set to enable the feature, unless the metrics-generator
+	// sends its metrics to the OpenShift in-cluster monitoring stack.
 	// +optional
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled"
@@ -532,10 +3438,58 @@ type JaegerQueryMonitor struct {
 
 	// PrometheusEndpoint configures endpoint to the Prometheus that contains span RED metrics.
 	// For instance on OpenShift this is set to https://thanos-querier.openshift-monitoring.svc.cluster.local:9091
+	// If empty and the metrics-generator sends its metrics to the OpenShift in-cluster monitoring stack,
+	// the Thanos Querier of the OpenShift monitoring stack is used.
 	// +optional
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prometheus endpoint"
 	PrometheusEndpoint string `json:"prometheusEndpoint"`
+
+	// REDMetricsNamespace is the prefix of the span RED metrics.
+	// Defaults to traces_spanmetrics if the span-metrics processor of the metrics-generator is enabled,
+	// otherwise the metrics of the OpenTelemetry Collector spanmetrics connector (without prefix) are queried.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="RED metrics namespace",xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	REDMetricsNamespace *string `json:"redMetricsNamespace,omitempty"`
+
+	// BearerTokenSecret is the name of a Secret in the same namespace containing the bearer token (token)
+	// used to authenticate with Prometheus.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Bearer Token Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	BearerTokenSecret string `json:"bearerTokenSecret,omitempty"`
+
+	// TLS defines the TLS connection to Prometheus.
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS"
+	TLS *JaegerQueryMonitorTLSSpec `json:"tls,omitempty"`
+}
+
+// JaegerQueryMonitorTLSSpec defines the TLS connection to the Prometheus of the monitoring tab.
+type JaegerQueryMonitorTLSSpec struct {
+	// CA is the name of a ConfigMap containing the CA bundle (service-ca.crt) used to verify the certificate of Prometheus.
+	// If empty, the system CA bundle is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CA ConfigMap",xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap"
+	CA string `json:"caName,omitempty"`
+
+	// CertName is the name of a Secret containing the client certificate (tls.crt) and private key (tls.key).
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Client Certificate Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	CertName string `json:"certName,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the certificate of Prometheus.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Skip Certificate Verification",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 }
 
 // IngressSpec defines Jaeger Query Ingress options.
@@ -555,6 +3509,14 @@ type IngressSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Annotations"
 	Annotations map[string]string `json:"annotations,omitempty"`
 
+	// Labels defines additional labels of the Ingress or Route object.
+	// The labels managed by the operator cannot be overwritten.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Labels"
+	Labels map[string]string `json:"labels,omitempty"`
+
 	// Host defines the hostname of the Ingress object.
 	//
 	// +optional
@@ -562,6 +3524,35 @@ type IngressSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Host"
 	Host string `json:"host,omitempty"`
 
+	// TLSSecretName is the name of a Secret in the same namespace containing the certificate (tls.crt)
+	// and the private key (tls.key) of the hostname, referenced in the TLS configuration of the Ingress.
+	// The secret can be issued by cert-manager, for example with the cert-manager.io/cluster-issuer annotation.
+	// Routes use the certificate of route.certificateSecret instead.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// Path defines the path prefix under which the component is exposed, for example /jaeger.
+	// Only supported by the Jaeger Query UI, which is served under this path. Defaults to /.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^/`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Path"
+	Path string `json:"path,omitempty"`
+
+	// AdditionalHosts defines further hostnames under which the component is exposed.
+	// An Ingress object contains a rule for every hostname, whereas an additional Route object is created
+	// for every additional hostname, because a Route supports a single hostname only.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Additional Hosts"
+	AdditionalHosts []IngressHostSpec `json:"additionalHosts,omitempty"`
+
 	// IngressClassName is the name of an IngressClass cluster resource. Ingress
 	// controller implementations use this field to know whether they should be
 	// serving this Ingress resource.
@@ -576,6 +3567,26 @@ type IngressSpec struct {
 	Route RouteSpec `json:"route,omitempty"`
 }
 
+// IngressHostSpec defines an additional hostname of an Ingress or Route.
+type IngressHostSpec struct {
+	// Host defines the hostname.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Host"
+	Host string `json:"host"`
+
+	// TLSSecretName is the name of a Secret in the same namespace containing the certificate (tls.crt)
+	// and the private key (tls.key) of the hostname.
+	// The Secret is referenced in the TLS configuration of an Ingress, and its certificate is copied into a Route.
+	// Routes only support a custom certificate with the edge and reencrypt termination.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+}
+
 // RouteSpec defines OpenShift Route specific options.
 type RouteSpec struct {
 	// Termination specifies the termination type. By default "edge" is used.
@@ -584,6 +3595,24 @@ type RouteSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS Termination Policy"
 	Termination TLSRouteTerminationType `json:"termination,omitempty"`
+
+	// CertificateSecret is the name of a Secret in the same namespace containing the certificate (tls.crt),
+	// the private key (tls.key) and optionally the CA certificate (ca.crt) served by the router.
+	// Only supported by the edge and reencrypt termination. By default the certificate of the router is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Certificate Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	CertificateSecret string `json:"certificateSecret,omitempty"`
+
+	// DestinationCAConfigMap is the name of a ConfigMap in the same namespace containing the CA certificate (service-ca.crt),
+	// which the router uses to verify the certificate of the service.
+	// Only supported by the reencrypt termination. By default the OpenShift service CA is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Destination CA ConfigMap",xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap"
+	DestinationCAConfigMap string `json:"destinationCAConfigMap,omitempty"`
 }
 
 // LimitSpec defines Global and PerTenant rate limits.
@@ -601,6 +3630,17 @@ type LimitSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Global Limit"
 	Global RateLimitSpec `json:"global"`
+
+	// PerTenantRuntimeOverridesConfigMap is the name of a ConfigMap in the namespace of the TempoStack,
+	// which contains the per-tenant runtime overrides of Tempo in the overrides.yaml key.
+	// Tempo reloads the overrides periodically, therefore changes are applied without restarting the pods.
+	// It cannot be combined with spec.limits.perTenant, spec.retention.perTenant
+	// and the limits or retention of the tenants in spec.tenants.authentication.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Per Tenant Runtime Overrides ConfigMap",xDescriptors="urn:alm:descriptor:io.kubernetes:ConfigMap"
+	PerTenantRuntimeOverridesConfigMap string `json:"perTenantRuntimeOverridesConfigMap,omitempty"`
 }
 
 // RateLimitSpec defines rate limits for Ingestion and Query components.
@@ -681,6 +3721,8 @@ type QueryLimit struct {
 // RetentionSpec defines global and per tenant retention configurations.
 type RetentionSpec struct {
 	// PerTenant is used to configure retention per tenant.
+	// The key is the tenant ID sent by the gateway, or the tenant name if the gateway is disabled.
+	// The retention of a tenant in spec.tenants.authentication takes precedence.
 	//
 	// +optional
 	// +kubebuilder:validation:Optional
@@ -2,19 +2,30 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
+	"github.com/prometheus/common/model"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -23,6 +34,8 @@ import (
 
 	"github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
+	"github.com/grafana/tempo-operator/internal/version"
+	"github.com/grafana/tempo-operator/internal/tlsprofile"
 )
 
 var (
@@ -39,11 +52,11 @@ const defaultRouteGatewayTLSTermination = TLSRouteTerminationTypePassthrough
 const defaultUITLSTermination = TLSRouteTerminationTypeEdge
 
 // SetupWebhookWithManager initializes the webhook.
-func (r *TempoStack) SetupWebhookWithManager(mgr ctrl.Manager, ctrlConfig v1alpha1.ProjectConfig) error {
+func (r *TempoStack) SetupWebhookWithManager(mgr ctrl.Manager, ctrlConfig v1alpha1.ProjectConfig, componentPods ComponentPodsFunc) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		WithDefaulter(NewDefaulter(ctrlConfig)).
-		WithValidator(&validator{client: mgr.GetClient(), ctrlConfig: ctrlConfig}).
+		WithValidator(NewValidator(mgr.GetClient(), ctrlConfig, componentPods)).
 		Complete()
 }
 
@@ -69,6 +82,11 @@ func (d *Defaulter) Default(ctx context.Context, obj runtime.Object) error {
 	}
 
 	log := ctrl.LoggerFrom(ctx).WithName("tempostack-webhook")
+	if !d.ctrlConfig.WatchesNamespace(r.Namespace) {
+		// The TempoStack is managed by another operator instance.
+		log.V(1).Info("skipping defaulter webhook, namespace is not watched", "name", r.Name, "namespace", r.Namespace)
+		return nil
+	}
 	log.V(1).Info("running defaulter webhook", "name", r.Name)
 
 	if r.Labels == nil {
@@ -103,6 +121,22 @@ func (d *Defaulter) Default(ctx context.Context, obj runtime.Object) error {
 		}
 		r.Spec.Images.TempoGatewayOpa = d.ctrlConfig.DefaultImages.TempoGatewayOpa
 	}
+	if r.Spec.Images.SPIFFEHelper == "" && r.Spec.SPIFFE != nil {
+		r.Spec.Images.SPIFFEHelper = d.ctrlConfig.DefaultImages.SPIFFEHelper
+	}
+	if r.Spec.Images.OauthProxy == "" && r.Spec.Template.QueryFrontend.JaegerQuery.Authentication != nil &&
+		r.Spec.Template.QueryFrontend.JaegerQuery.Authentication.Enabled {
+		r.Spec.Images.OauthProxy = d.ctrlConfig.DefaultImages.OauthProxy
+	}
+	if r.Spec.Images.Memcached == "" && (r.Spec.Template.Gateway.AccessReviewCache != nil || r.Spec.Template.Memcached.Enabled) {
+		r.Spec.Images.Memcached = d.ctrlConfig.DefaultImages.Memcached
+	}
+	if r.Spec.Images.TempoCLI == "" && len(r.Spec.CLIJobs) > 0 {
+		r.Spec.Images.TempoCLI = d.ctrlConfig.DefaultImages.TempoCLI
+	}
+	if r.Spec.Images.Backup == "" && (r.Spec.Backup != nil || r.Spec.Storage.MigrateFrom != nil || len(r.Spec.TenantPurges) > 0) {
+		r.Spec.Images.Backup = d.ctrlConfig.DefaultImages.Backup
+	}
 
 	if r.Spec.ServiceAccount == "" {
 		r.Spec.ServiceAccount = naming.DefaultServiceAccountName(r.Name)
@@ -130,7 +164,8 @@ func (d *Defaulter) Default(ctx context.Context, obj runtime.Object) error {
 	}
 
 	// Default replicas for distributor if not specified.
-	if r.Spec.Template.Distributor.Replicas == nil {
+	// The replicas of an autoscaled distributor are managed by the HorizontalPodAutoscaler.
+	if r.Spec.Template.Distributor.Replicas == nil && r.Spec.Template.Distributor.Autoscaling == nil {
 		r.Spec.Template.Distributor.Replicas = defaultComponentReplicas
 	}
 
@@ -148,26 +183,141 @@ func (d *Defaulter) Default(ctx context.Context, obj runtime.Object) error {
 		r.Spec.Template.Gateway.Ingress.Route.Termination = defaultRouteGatewayTLSTermination
 	}
 
+	if r.Spec.Template.Gateway.AuditLog != nil && r.Spec.Template.Gateway.AuditLog.Output == "" {
+		r.Spec.Template.Gateway.AuditLog.Output = GatewayAuditLogOutputStdout
+	}
+
 	// Terminate TLS of the JaegerQuery Route on the Edge by default
 	if r.Spec.Template.QueryFrontend.JaegerQuery.Ingress.Type == IngressTypeRoute && r.Spec.Template.QueryFrontend.JaegerQuery.Ingress.Route.Termination == "" {
 		r.Spec.Template.QueryFrontend.JaegerQuery.Ingress.Route.Termination = defaultUITLSTermination
 	}
+
+	// The Zipkin receiver serves TLS itself if TLS of the receivers is enabled.
+	if zipkin := r.Spec.Template.Distributor.Receivers.Zipkin; zipkin != nil && zipkin.Ingress.Type == IngressTypeRoute && zipkin.Ingress.Route.Termination == "" {
+		zipkin.Ingress.Route.Termination = TLSRouteTerminationTypeEdge
+		if r.Spec.Template.Distributor.TLS.Enabled {
+			zipkin.Ingress.Route.Termination = TLSRouteTerminationTypePassthrough
+		}
+	}
+
+	// gRPC requires HTTP/2 to the backend, which the OpenShift router supports with the passthrough and reencrypt termination only.
+	if otlp := r.Spec.Template.Distributor.Receivers.OTLP; otlp != nil {
+		if otlp.GRPCIngress.Type == IngressTypeRoute && otlp.GRPCIngress.Route.Termination == "" {
+			otlp.GRPCIngress.Route.Termination = TLSRouteTerminationTypePassthrough
+		}
+		if otlp.HTTPIngress.Type == IngressTypeRoute && otlp.HTTPIngress.Route.Termination == "" {
+			otlp.HTTPIngress.Route.Termination = TLSRouteTerminationTypeEdge
+			if r.Spec.Template.Distributor.TLS.Enabled {
+				otlp.HTTPIngress.Route.Termination = TLSRouteTerminationTypePassthrough
+			}
+		}
+	}
 	return nil
 }
 
 //+kubebuilder:webhook:path=/validate-tempo-grafana-com-v1alpha1-tempostack,mutating=false,failurePolicy=fail,sideEffects=None,groups=tempo.grafana.com,resources=tempostacks,verbs=create;update,versions=v1alpha1,name=vtempostack.tempo.grafana.com,admissionReviewVersions=v1
 
+// NewValidator creates a new instance of the validator, which implements functions for validating the Tempo CR.
+// The client is used to check the resources referenced by the TempoStack, e.g. the service account and the storage secret.
+// The componentPods function computes the resources of the components, which are checked against the ResourceQuotas
+// and LimitRanges of the namespace.
+func NewValidator(k8sClient client.Client, ctrlConfig v1alpha1.ProjectConfig, componentPods ComponentPodsFunc) admission.CustomValidator {
+	return &validator{client: k8sClient, ctrlConfig: ctrlConfig, componentPods: componentPods}
+}
+
 type validator struct {
-	client     client.Client
-	ctrlConfig v1alpha1.ProjectConfig
+	client        client.Client
+	ctrlConfig    v1alpha1.ProjectConfig
+	componentPods ComponentPodsFunc
 }
 
 func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
-	return v.validate(ctx, obj)
+	warnings, err := v.validate(ctx, obj)
+	if err != nil {
+		return warnings, err
+	}
+	// The remaining quota is only checked for new TempoStacks, the used quota of an existing TempoStack includes its own pods.
+	return append(warnings, v.resourceQuotaWarnings(ctx, *obj.(*TempoStack))...), nil
 }
 
 func (v *validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
-	return v.validate(ctx, newObj)
+	warnings, err := v.validate(ctx, newObj)
+	if err != nil {
+		return warnings, err
+	}
+
+	oldTempo, ok := oldObj.(*TempoStack)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a TempoStack object but got %T", oldObj))
+	}
+	newTempo := newObj.(*TempoStack)
+	if errs := validateVolumeClaimUpdate(*oldTempo, *newTempo); len(errs) > 0 {
+		return warnings, apierrors.NewInvalid(newTempo.GroupVersionKind().GroupKind(), newTempo.Name, errs)
+	}
+	return append(warnings, storageUpdateWarnings(*oldTempo, *newTempo)...), nil
+}
+
+// validateVolumeClaimUpdate rejects changes of the persistent volume of the ingesters.
+// The volume claim templates of a StatefulSet are immutable, and the volumes of the existing ingesters are not resized.
+func validateVolumeClaimUpdate(oldTempo, newTempo TempoStack) field.ErrorList {
+	type volumeClaim struct {
+		storageClassName string
+		size             resource.Quantity
+		accessModes      []corev1.PersistentVolumeAccessMode
+		annotations      map[string]string
+	}
+	// ingesterVolumeClaim returns the effective volume claim of the ingesters, see manifestutils.PersistentVolumeClaim.
+	ingesterVolumeClaim := func(tempo TempoStack) volumeClaim {
+		claim := volumeClaim{size: tempo.Spec.StorageSize, accessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}}
+		if tempo.Spec.StorageClassName != nil {
+			claim.storageClassName = *tempo.Spec.StorageClassName
+		}
+		tpl := tempo.Spec.Template.Ingester.VolumeClaimTemplate
+		if tpl == nil {
+			return claim
+		}
+		if tpl.StorageClassName != nil {
+			claim.storageClassName = *tpl.StorageClassName
+		}
+		if tpl.Size != nil {
+			claim.size = *tpl.Size
+		}
+		if len(tpl.AccessModes) > 0 {
+			claim.accessModes = tpl.AccessModes
+		}
+		if len(tpl.Annotations) > 0 {
+			claim.annotations = tpl.Annotations
+		}
+		return claim
+	}
+
+	oldClaim, newClaim := ingesterVolumeClaim(oldTempo), ingesterVolumeClaim(newTempo)
+	if oldClaim.storageClassName == newClaim.storageClassName &&
+		oldClaim.size.Cmp(newClaim.size) == 0 &&
+		reflect.DeepEqual(oldClaim.accessModes, newClaim.accessModes) &&
+		reflect.DeepEqual(oldClaim.annotations, newClaim.annotations) {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(
+		field.NewPath("spec", "template", "ingester", "volumeClaimTemplate"),
+		"the persistent volume of the ingesters cannot be changed, "+
+			"spec.storageClassName, spec.storageSize and spec.template.ingester.volumeClaimTemplate are immutable",
+	)}
+}
+
+// storageUpdateWarnings warns if the object storage of a TempoStack changes
+// without migrating the blocks of the previous object storage.
+func storageUpdateWarnings(oldTempo, newTempo TempoStack) admission.Warnings {
+	if oldTempo.Spec.Storage.Secret == newTempo.Spec.Storage.Secret {
+		return nil
+	}
+	if newTempo.Spec.Storage.MigrateFrom != nil && *newTempo.Spec.Storage.MigrateFrom == oldTempo.Spec.Storage.Secret {
+		return nil
+	}
+	return admission.Warnings{
+		"the object storage secret changed, traces stored in the previous object storage will not be available anymore " +
+			"unless spec.storage.migrateFrom is set to the previous storage secret",
+	}
 }
 
 func (v *validator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -195,8 +345,12 @@ func (v *validator) validateServiceAccount(ctx context.Context, tempo TempoStack
 }
 
 func (v *validator) validateStorage(ctx context.Context, tempo TempoStack) field.ErrorList {
+	namespace := tempo.Namespace
+	if tempo.Spec.Storage.Secret.Namespace != "" {
+		namespace = tempo.Spec.Storage.Secret.Namespace
+	}
 	storageSecret := &corev1.Secret{}
-	err := v.client.Get(ctx, types.NamespacedName{Namespace: tempo.Namespace, Name: tempo.Spec.Storage.Secret.Name}, storageSecret)
+	err := v.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: tempo.Spec.Storage.Secret.Name}, storageSecret)
 	if err != nil {
 		// Do not fail the validation here, the user can create the storage secret later.
 		// The operator will remain in a ConfigurationError status condition until the storage secret is set.
@@ -206,11 +360,44 @@ func (v *validator) validateStorage(ctx context.Context, tempo TempoStack) field
 	return ValidateStorageSecret(tempo, *storageSecret)
 }
 
+// validateSharedObjects validates the Secrets and ConfigMaps referenced from other namespaces.
+// The operator copies them with the same name into the namespace of the TempoStack,
+// therefore objects of the same kind and name cannot be referenced from different namespaces.
+func (v *validator) validateSharedObjects(tempo TempoStack) field.ErrorList {
+	var errs field.ErrorList
+	namespaces := map[string]string{}
+	for _, ref := range SharedObjectReferences(tempo) {
+		if !v.ctrlConfig.AllowsSecretNamespace(ref.Namespace) {
+			errs = append(errs, field.Invalid(ref.Path, ref.Namespace,
+				"the namespace is not allow-listed in the secretNamespaces setting of the operator"))
+			continue
+		}
+
+		key := string(ref.Kind) + "/" + ref.Name
+		if namespace, found := namespaces[key]; found && namespace != ref.Namespace {
+			errs = append(errs, field.Invalid(ref.Path, ref.Namespace,
+				fmt.Sprintf("the %s %s is already referenced from the namespace %s", ref.Kind, ref.Name, namespace)))
+			continue
+		}
+		namespaces[key] = ref.Namespace
+	}
+	return errs
+}
+
 func (v *validator) validateReplicationFactor(tempo TempoStack) field.ErrorList {
 	// Validate minimum quorum on ingestors according to replicas and replication factor
 	replicatonFactor := tempo.Spec.ReplicationFactor
+	if replicatonFactor < 1 {
+		return field.ErrorList{field.Invalid(field.NewPath("spec").Child("ReplicationFactor"), replicatonFactor,
+			"the replication factor must be positive",
+		)}
+	}
 	// Ingester replicas should not be nil at this point, due defauler.
 	ingesterReplicas := int(*tempo.Spec.Template.Ingester.Replicas)
+	// With zone-aware replication, the replicas are deployed in each zone.
+	if zoneAware := tempo.Spec.Template.Ingester.ZoneAwareReplication; zoneAware != nil && len(zoneAware.Zones) > 0 {
+		ingesterReplicas *= len(zoneAware.Zones)
+	}
 	quorum := int(math.Floor(float64(replicatonFactor)/2.0) + 1)
 	// if ingester replicas less than quorum (which depends on replication factor), then doesn't allow to deploy as it is an
 	// invalid configuration. Quorum equal to replicas doesn't allow you to lose ingesters but is a valid configuration.
@@ -245,7 +432,7 @@ func (v *validator) validateQueryFrontend(tempo TempoStack) field.ErrorList {
 
 	if tempo.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab.Enabled {
 		prometheusEndpointPath := field.NewPath("spec").Child("template").Child("queryFrontend").Child("jaegerQuery").Child("monitorTab").Child("prometheusEndpoint")
-		if tempo.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab.PrometheusEndpoint == "" {
+		if tempo.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab.PrometheusEndpoint == "" && !metricsGeneratorSendsToOpenShiftMonitoring(tempo) {
 			return field.ErrorList{field.Invalid(
 				prometheusEndpointPath,
 				tempo.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab.PrometheusEndpoint,
@@ -257,41 +444,6 @@ func (v *validator) validateQueryFrontend(tempo TempoStack) field.ErrorList {
 	return nil
 }
 
-func (v *validator) validateGateway(tempo TempoStack) field.ErrorList {
-	path := field.NewPath("spec").Child("template").Child("gateway").Child("enabled")
-	if tempo.Spec.Template.Gateway.Enabled {
-		if !tempo.Spec.Template.QueryFrontend.JaegerQuery.Enabled {
-			return field.ErrorList{
-				field.Invalid(path, tempo.Spec.Template.Gateway.Enabled,
-					"to use the gateway, please enable jaegerQuery",
-				)}
-		}
-
-		if tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress.Type != IngressTypeNone {
-			return field.ErrorList{
-				field.Invalid(path, tempo.Spec.Template.Gateway.Enabled,
-					"cannot enable gateway and jaeger query ingress at the same time, please use the Jaeger UI from the gateway",
-				)}
-		}
-
-		if tempo.Spec.Tenants == nil {
-			return field.ErrorList{
-				field.Invalid(path, tempo.Spec.Template.Gateway.Enabled,
-					"to enable the gateway, please configure tenants",
-				)}
-		}
-
-		if tempo.Spec.Template.Gateway.Ingress.Type == IngressTypeRoute && !v.ctrlConfig.Gates.OpenShift.OpenShiftRoute {
-			return field.ErrorList{field.Invalid(
-				field.NewPath("spec").Child("template").Child("gateway").Child("ingress").Child("type"),
-				tempo.Spec.Template.Gateway.Ingress.Type,
-				"please enable the featureGates.openshift.openshiftRoute feature gate to use Routes",
-			)}
-		}
-	}
-	return nil
-}
-
 func (v *validator) validateObservability(tempo TempoStack) field.ErrorList {
 	observabilityBase := field.NewPath("spec").Child("observability")
 	metricsBase := observabilityBase.Child("metrics")
@@ -345,120 +497,1713 @@ func (v *validator) validateObservability(tempo TempoStack) field.ErrorList {
 	return nil
 }
 
-func (v *validator) validateTenantConfigs(tempo TempoStack) field.ErrorList {
-	if err := ValidateTenantConfigs(tempo); err != nil {
-		return field.ErrorList{
-			field.Invalid(
-				field.NewPath("spec").Child("template").Child("tenants"),
-				tempo.Spec.Template.Gateway.Enabled,
-				err.Error(),
-			)}
+func (v *validator) validateVolumeClaimTemplates(tempo TempoStack) field.ErrorList {
+	templateBase := field.NewPath("spec").Child("template")
+	templates := []struct {
+		path string
+		tpl  *VolumeClaimTemplateSpec
+	}{
+		{path: "ingester", tpl: tempo.Spec.Template.Ingester.VolumeClaimTemplate},
+		{path: "compactor", tpl: tempo.Spec.Template.Compactor.VolumeClaimTemplate},
 	}
-	return nil
+
+	var allErrs field.ErrorList
+	for _, c := range templates {
+		tpl := c.tpl
+		if tpl != nil && tpl.Size != nil && tpl.Size.Cmp(zeroQuantity) <= 0 {
+			allErrs = append(allErrs, field.Invalid(
+				templateBase.Child(c.path).Child("volumeClaimTemplate").Child("size"),
+				tpl.Size.String(),
+				"size must be greater than zero",
+			))
+		}
+	}
+
+	return allErrs
 }
 
-func (v *validator) validateStackName(tempo TempoStack) field.ErrorList {
-	// We need to check this because the name is used as a label value for app.kubernetes.io/instance
-	// Only validate the length, because the DNS rules are enforced by the functions in the `naming` package.
-	if len(tempo.Name) > maxLabelLength {
-		return field.ErrorList{
-			field.Invalid(
-				field.NewPath("metadata").Child("name"),
-				tempo.Name,
-				fmt.Sprintf("must be no more than %d characters", maxLabelLength),
-			)}
+func (v *validator) validateCertManager(tempo TempoStack) field.ErrorList {
+	if tempo.Spec.CertManager == nil {
+		return nil
+	}
+
+	if !v.ctrlConfig.Gates.HTTPEncryption && !v.ctrlConfig.Gates.GRPCEncryption {
+		return field.ErrorList{field.Invalid(
+			field.NewPath("spec").Child("certManager"),
+			tempo.Spec.CertManager,
+			"please enable the featureGates.httpEncryption or featureGates.grpcEncryption feature gate to use cert-manager",
+		)}
+	}
+
+	if v.ctrlConfig.Gates.OpenShift.ServingCertsAllServices {
+		return field.ErrorList{field.Invalid(
+			field.NewPath("spec").Child("certManager"),
+			tempo.Spec.CertManager,
+			"cannot use cert-manager if the featureGates.openshift.servingCertsAllServices feature gate is enabled",
+		)}
+	}
+
+	certManager := tempo.Spec.CertManager
+	if certManager.Duration != nil && certManager.RenewBefore != nil && certManager.RenewBefore.Duration >= certManager.Duration.Duration {
+		return field.ErrorList{field.Invalid(
+			field.NewPath("spec").Child("certManager").Child("renewBefore"),
+			certManager.RenewBefore.Duration.String(),
+			"must be shorter than the certificate duration",
+		)}
 	}
 	return nil
 }
 
-func (v *validator) validateDeprecatedFields(tempo TempoStack) field.ErrorList {
-	if tempo.Spec.LimitSpec.Global.Query.MaxSearchBytesPerTrace != nil {
-		return field.ErrorList{
-			field.Invalid(
-				field.NewPath("spec").Child("limits").Child("global").Child("query").Child("maxSearchBytesPerTrace"),
-				tempo.Spec.LimitSpec.Global.Query.MaxSearchBytesPerTrace,
-				"this field is deprecated and must be unset",
-			)}
+func (v *validator) validateSPIFFE(tempo TempoStack) field.ErrorList {
+	if tempo.Spec.SPIFFE == nil {
+		return nil
 	}
-	for tenant, limits := range tempo.Spec.LimitSpec.PerTenant {
-		if limits.Query.MaxSearchBytesPerTrace != nil {
-			return field.ErrorList{
-				field.Invalid(
-					field.NewPath("spec").Child("limits").Child("perTenant").Key(tenant).Child("query").Child("maxSearchBytesPerTrace"),
-					limits.Query.MaxSearchBytesPerTrace,
-					"this field is deprecated and must be unset",
-				)}
-		}
+
+	path := field.NewPath("spec").Child("spiffe")
+	if !v.ctrlConfig.Gates.HTTPEncryption && !v.ctrlConfig.Gates.GRPCEncryption {
+		return field.ErrorList{field.Invalid(
+			path,
+			tempo.Spec.SPIFFE,
+			"please enable the featureGates.httpEncryption or featureGates.grpcEncryption feature gate to use SPIFFE",
+		)}
+	}
+
+	if tempo.Spec.CertManager != nil {
+		return field.ErrorList{field.Invalid(
+			path,
+			tempo.Spec.SPIFFE,
+			"cannot use SPIFFE and spec.certManager at the same time",
+		)}
+	}
+
+	if v.ctrlConfig.Gates.OpenShift.ServingCertsAllServices {
+		return field.ErrorList{field.Invalid(
+			path,
+			tempo.Spec.SPIFFE,
+			"cannot use SPIFFE if the featureGates.openshift.servingCertsAllServices feature gate is enabled",
+		)}
+	}
+
+	if tempo.Spec.Images.SPIFFEHelper == "" {
+		return field.ErrorList{field.Required(
+			field.NewPath("spec").Child("images").Child("spiffeHelper"),
+			"please specify the spiffe-helper image or configure a default image in the operator configuration",
+		)}
 	}
 
+	// Prometheus cannot fetch SVIDs, therefore it cannot scrape the components via mTLS.
+	if v.ctrlConfig.Gates.HTTPEncryption && tempo.Spec.Observability.Metrics.CreateServiceMonitors {
+		return field.ErrorList{field.Invalid(
+			field.NewPath("spec").Child("observability").Child("metrics").Child("createServiceMonitors"),
+			true,
+			"cannot create ServiceMonitors if SPIFFE and the featureGates.httpEncryption feature gate are enabled",
+		)}
+	}
 	return nil
 }
 
-func (v *validator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
-	tempo, ok := obj.(*TempoStack)
-	if !ok {
-		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a TempoStack object but got %T", obj))
+func (v *validator) validateComponentAutoscaling(tempo TempoStack) field.ErrorList {
+	templateBase := field.NewPath("spec").Child("template")
+	distributor := tempo.Spec.Template.Distributor
+	querier := tempo.Spec.Template.Querier
+
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateAutoscaling(templateBase.Child("distributor"), templateBase.Child("distributor"),
+		"distributor", distributor.Replicas, distributor.Autoscaling)...)
+	allErrs = append(allErrs, validateAutoscaling(templateBase.Child("querier"), templateBase.Child("querier"),
+		"querier", querier.Replicas, querier.Autoscaling)...)
+	return allErrs
+}
+
+// validateAutoscaling validates the autoscaling settings of a component.
+// The replicas are managed by the HorizontalPodAutoscaler and therefore cannot be set at the same time.
+func validateAutoscaling(path, componentPath *field.Path, component string, replicas *int32, autoscaling *AutoscalingSpec) field.ErrorList {
+	if autoscaling == nil {
+		return nil
 	}
 
-	log := ctrl.LoggerFrom(ctx).WithName("tempostack-webhook")
-	log.V(1).Info("running validating webhook", "name", tempo.Name)
+	if replicas != nil {
+		return field.ErrorList{field.Invalid(
+			componentPath.Child("replicas"),
+			*replicas,
+			fmt.Sprintf("cannot set the replicas of the %s if autoscaling is enabled", component),
+		)}
+	}
 
-	var allErrs field.ErrorList
-	allErrs = append(allErrs, v.validateStackName(*tempo)...)
-	allErrs = append(allErrs, v.validateServiceAccount(ctx, *tempo)...)
-	allErrs = append(allErrs, v.validateStorage(ctx, *tempo)...)
-	allErrs = append(allErrs, v.validateReplicationFactor(*tempo)...)
-	allErrs = append(allErrs, v.validateQueryFrontend(*tempo)...)
-	allErrs = append(allErrs, v.validateGateway(*tempo)...)
-	allErrs = append(allErrs, v.validateTenantConfigs(*tempo)...)
-	allErrs = append(allErrs, v.validateObservability(*tempo)...)
-	allErrs = append(allErrs, v.validateDeprecatedFields(*tempo)...)
+	if autoscaling.MinReplicas != nil && *autoscaling.MinReplicas > autoscaling.MaxReplicas {
+		return field.ErrorList{field.Invalid(
+			path.Child("autoscaling").Child("minReplicas"),
+			*autoscaling.MinReplicas,
+			"must be less than or equal to maxReplicas",
+		)}
+	}
 
-	if len(allErrs) == 0 {
-		return nil, nil
+	if autoscaling.Keda != nil && len(autoscaling.Metrics) > 0 {
+		return field.ErrorList{field.Invalid(
+			path.Child("autoscaling").Child("metrics"),
+			autoscaling.Metrics,
+			"metrics cannot be used together with KEDA, please define KEDA triggers instead",
+		)}
 	}
-	return nil, apierrors.NewInvalid(tempo.GroupVersionKind().GroupKind(), tempo.Name, allErrs)
+
+	// KEDA rejects a ScaledObject without triggers.
+	if autoscaling.Keda != nil && len(autoscaling.Keda.Triggers) == 0 &&
+		autoscaling.TargetCPUUtilization == nil && autoscaling.TargetMemoryUtilization == nil {
+		return field.ErrorList{field.Required(
+			path.Child("autoscaling").Child("keda").Child("triggers"),
+			"at least one KEDA trigger or a CPU or memory utilization target is required",
+		)}
+	}
+	return nil
+}
+
+// exposedIngress is an IngressSpec of a TempoStack which exposes a component.
+type exposedIngress struct {
+	path *field.Path
+	spec IngressSpec
+	// pathPrefix is true if the component can be served under a path prefix.
+	pathPrefix bool
 }
 
-// ValidateTenantConfigs validates the tenants mode specification.
-func ValidateTenantConfigs(tempo TempoStack) error {
-	if tempo.Spec.Tenants == nil {
+// exposedIngresses returns the IngressSpecs of a TempoStack, which expose a component.
+func exposedIngresses(tempo TempoStack) []exposedIngress {
+	var ingresses []exposedIngress
+	if gateway := tempo.Spec.Template.Gateway; gateway.Enabled && gateway.Ingress.Type != IngressTypeNone {
+		ingresses = append(ingresses, exposedIngress{
+			path: field.NewPath("spec").Child("template").Child("gateway").Child("ingress"),
+			spec: gateway.Ingress,
+		})
+	}
+	if jaegerQuery := tempo.Spec.Template.QueryFrontend.JaegerQuery; jaegerQuery.Enabled && jaegerQuery.Ingress.Type != IngressTypeNone {
+		ingresses = append(ingresses, exposedIngress{
+			path:       field.NewPath("spec").Child("template").Child("queryFrontend").Child("jaegerQuery").Child("ingress"),
+			spec:       jaegerQuery.Ingress,
+			pathPrefix: true,
+		})
+	}
+	if zipkin := tempo.Spec.Template.Distributor.Receivers.Zipkin; zipkin != nil && zipkin.Enabled && zipkin.Ingress.Type != IngressTypeNone {
+		ingresses = append(ingresses, exposedIngress{
+			path: field.NewPath("spec").Child("template").Child("distributor").Child("receivers").Child("zipkin").Child("ingress"),
+			spec: zipkin.Ingress,
+		})
+	}
+	if otlp := tempo.Spec.Template.Distributor.Receivers.OTLP; otlp != nil && !tempo.Spec.Template.Gateway.Enabled {
+		otlpPath := field.NewPath("spec").Child("template").Child("distributor").Child("receivers").Child("otlp")
+		if otlp.GRPCIngress.Type != IngressTypeNone {
+			ingresses = append(ingresses, exposedIngress{
+				path: otlpPath.Child("grpcIngress"),
+				spec: otlp.GRPCIngress,
+			})
+		}
+		if otlp.HTTPIngress.Type != IngressTypeNone {
+			ingresses = append(ingresses, exposedIngress{
+				path: otlpPath.Child("httpIngress"),
+				spec: otlp.HTTPIngress,
+			})
+		}
+	}
+	return ingresses
+}
+
+// ingressHosts returns the hostname and the additional hostnames of an IngressSpec.
+func ingressHosts(ingress IngressSpec) []string {
+	var hosts []string
+	if ingress.Host != "" {
+		hosts = append(hosts, ingress.Host)
+	}
+	for _, additionalHost := range ingress.AdditionalHosts {
+		hosts = append(hosts, additionalHost.Host)
+	}
+	return hosts
+}
+
+func (v *validator) validateIngressHosts(ctx context.Context, tempo TempoStack) field.ErrorList {
+	ingresses := exposedIngresses(tempo)
+	if len(ingresses) == 0 {
 		return nil
 	}
 
-	tenants := tempo.Spec.Tenants
-	if tenants.Mode == ModeStatic {
-		// If the static mode is combined with the gateway, we will need the following fields
-		// otherwise this will just enable tempo multitenancy without the gateway
-		if tempo.Spec.Template.Gateway.Enabled {
-			if tenants.Authentication == nil {
-				return fmt.Errorf("spec.tenants.authentication is required in static mode")
+	// Collect the hostnames of all other TempoStacks of the cluster.
+	// Do not fail the validation if the TempoStacks cannot be listed, the Ingress controller rejects duplicate hosts anyway.
+	usedHosts := map[string]string{}
+	stacks := &TempoStackList{}
+	if err := v.client.List(ctx, stacks); err == nil {
+		for _, stack := range stacks.Items {
+			if stack.Namespace == tempo.Namespace && stack.Name == tempo.Name {
+				continue
 			}
-
-			if tenants.Authorization == nil {
-				return fmt.Errorf("spec.tenants.authorization is required in static mode")
+			for _, ingress := range exposedIngresses(stack) {
+				for _, host := range ingressHosts(ingress.spec) {
+					usedHosts[host] = fmt.Sprintf("%s/%s", stack.Namespace, stack.Name)
+				}
 			}
+		}
+	}
 
-			if tenants.Authorization.Roles == nil {
-				return fmt.Errorf("spec.tenants.authorization.roles is required in static mode")
+	var errs field.ErrorList
+	hosts := map[string]bool{}
+	for _, ingress := range ingresses {
+		if host := ingress.spec.Host; host != "" {
+			hosts[host] = true
+			if stack, ok := usedHosts[host]; ok {
+				errs = append(errs, field.Invalid(ingress.path.Child("host"), host,
+					fmt.Sprintf("the host is already used by the TempoStack %s", stack)))
 			}
+		}
 
-			if tenants.Authorization.RoleBindings == nil {
-				return fmt.Errorf("spec.tenants.authorization.roleBindings is required in static mode")
+		for i, additionalHost := range ingress.spec.AdditionalHosts {
+			path := ingress.path.Child("additionalHosts").Index(i)
+			route := ingress.spec.Route
+			switch {
+			case hosts[additionalHost.Host]:
+				errs = append(errs, field.Duplicate(path.Child("host"), additionalHost.Host))
+			case usedHosts[additionalHost.Host] != "":
+				errs = append(errs, field.Invalid(path.Child("host"), additionalHost.Host,
+					fmt.Sprintf("the host is already used by the TempoStack %s", usedHosts[additionalHost.Host])))
+			case additionalHost.TLSSecretName != "" && ingress.spec.Type == IngressTypeRoute &&
+				route.Termination != TLSRouteTerminationTypeEdge && route.Termination != TLSRouteTerminationTypeReencrypt:
+				errs = append(errs, field.Invalid(path.Child("tlsSecretName"), additionalHost.TLSSecretName,
+					"a custom certificate is only supported by the edge and reencrypt termination"))
 			}
+			hosts[additionalHost.Host] = true
 		}
-	} else if tenants.Mode == ModeOpenShift {
-		if !tempo.Spec.Template.Gateway.Enabled {
-			return fmt.Errorf("openshift mode requires gateway enabled")
+	}
+	return errs
+}
+
+// validateIngressTLSAndPaths validates the TLS secret and the path prefix of the exposed components.
+func (v *validator) validateIngressTLSAndPaths(tempo TempoStack) field.ErrorList {
+	var errs field.ErrorList
+	for _, ingress := range exposedIngresses(tempo) {
+		if ingress.spec.TLSSecretName != "" && ingress.spec.Type == IngressTypeRoute {
+			errs = append(errs, field.Forbidden(ingress.path.Child("tlsSecretName"),
+				"the TLS secret is only supported by an Ingress, please use route.certificateSecret for a Route"))
 		}
-		if tenants.Authorization != nil {
-			return fmt.Errorf("spec.tenants.authorization should not be defined in openshift mode")
+
+		path := ingress.spec.Path
+		if path == "" || path == "/" {
+			continue
 		}
-		for _, auth := range tenants.Authentication {
-			if auth.OIDC != nil {
-				return fmt.Errorf("spec.tenants.authentication.oidc should not be defined in openshift mode")
-			}
+		switch {
+		case !ingress.pathPrefix:
+			errs = append(errs, field.Forbidden(ingress.path.Child("path"),
+				"a path prefix is only supported by the Jaeger Query UI"))
+		case !strings.HasPrefix(path, "/"):
+			errs = append(errs, field.Invalid(ingress.path.Child("path"), path, "the path must start with /"))
+		case ingress.spec.Type == IngressTypeRoute && ingress.spec.Route.Termination == TLSRouteTerminationTypePassthrough:
+			errs = append(errs, field.Invalid(ingress.path.Child("path"), path,
+				"a path prefix is not supported by the passthrough termination"))
 		}
 	}
-	return nil
+	return errs
+}
+
+func (v *validator) validatePorts(tempo TempoStack) field.ErrorList {
+	type port struct {
+		path        *field.Path
+		value       int32
+		defaultPort int32
+	}
+
+	ports := []port{
+		{field.NewPath("spec").Child("memberlist").Child("port"), 0, 7946},
+		{field.NewPath("spec").Child("ports").Child("http"), 0, 3200},
+		{field.NewPath("spec").Child("ports").Child("grpc"), 0, 9095},
+		{field.NewPath("spec").Child("template").Child("distributor").Child("receivers").Child("otlp").Child("grpcPort"), 0, 4317},
+		{field.NewPath("spec").Child("template").Child("distributor").Child("receivers").Child("otlp").Child("httpPort"), 0, 4318},
+	}
+	if memberlist := tempo.Spec.Memberlist; memberlist != nil {
+		ports[0].value = memberlist.Port
+	}
+	if serverPorts := tempo.Spec.Ports; serverPorts != nil {
+		ports[1].value = serverPorts.HTTP
+		ports[2].value = serverPorts.GRPC
+	}
+	if otlp := tempo.Spec.Template.Distributor.Receivers.OTLP; otlp != nil {
+		ports[3].value = otlp.GRPCPort
+		ports[4].value = otlp.HTTPPort
+	}
+
+	var errs field.ErrorList
+	used := map[int32]bool{}
+	for _, p := range ports {
+		value := p.value
+		if value == 0 {
+			value = p.defaultPort
+		}
+		if used[value] {
+			errs = append(errs, field.Duplicate(p.path, value))
+		}
+		used[value] = true
+	}
+	return errs
+}
+
+func (v *validator) validateGRPCServer(tempo TempoStack) field.ErrorList {
+	server := tempo.Spec.GRPCServer
+	if server == nil {
+		return nil
+	}
+	path := field.NewPath("spec").Child("grpcServer")
+
+	var errs field.ErrorList
+	durations := []struct {
+		name  string
+		value *metav1.Duration
+	}{
+		{"keepaliveTime", server.KeepaliveTime},
+		{"keepaliveTimeout", server.KeepaliveTimeout},
+		{"maxConnectionAge", server.MaxConnectionAge},
+		{"maxConnectionAgeGrace", server.MaxConnectionAgeGrace},
+	}
+	for _, d := range durations {
+		if d.value != nil && d.value.Duration <= 0 {
+			errs = append(errs, field.Invalid(path.Child(d.name), d.value.Duration.String(), "the duration must be positive"))
+		}
+	}
+	counts := []struct {
+		name  string
+		value *int
+	}{
+		{"maxRecvMsgSizeMiB", server.MaxRecvMsgSizeMiB},
+		{"maxSendMsgSizeMiB", server.MaxSendMsgSizeMiB},
+	}
+	for _, c := range counts {
+		if c.value != nil && *c.value <= 0 {
+			errs = append(errs, field.Invalid(path.Child(c.name), *c.value, "the value must be positive"))
+		}
+	}
+
+	if server.MaxConnectionAgeGrace != nil && server.MaxConnectionAge == nil {
+		errs = append(errs, field.Invalid(path.Child("maxConnectionAgeGrace"), server.MaxConnectionAgeGrace.Duration.String(),
+			"the grace period requires maxConnectionAge"))
+	}
+	return errs
+}
+
+func (v *validator) validateMemberlist(tempo TempoStack) field.ErrorList {
+	memberlist := tempo.Spec.Memberlist
+	if memberlist == nil {
+		return nil
+	}
+	path := field.NewPath("spec").Child("memberlist")
+
+	var errs field.ErrorList
+	if memberlist.GossipInterval.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("gossipInterval"), memberlist.GossipInterval.Duration.String(),
+			"the duration must be positive"))
+	}
+	if memberlist.ClusterDomain != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(memberlist.ClusterDomain) {
+			errs = append(errs, field.Invalid(path.Child("clusterDomain"), memberlist.ClusterDomain, msg))
+		}
+	}
+	return errs
+}
+
+func (v *validator) validateKVStore(tempo TempoStack) field.ErrorList {
+	kvStore := tempo.Spec.KVStore
+	if kvStore == nil {
+		return nil
+	}
+	path := field.NewPath("spec").Child("kvStore")
+
+	var errs field.ErrorList
+	switch kvStore.Type {
+	case KVStoreEtcd:
+		if kvStore.Etcd == nil {
+			errs = append(errs, field.Required(path.Child("etcd"), "the etcd settings are required for the etcd KV store"))
+		}
+	case KVStoreConsul:
+		if kvStore.Consul == nil {
+			errs = append(errs, field.Required(path.Child("consul"), "the Consul settings are required for the consul KV store"))
+		}
+	}
+	if kvStore.Etcd != nil && kvStore.Type != KVStoreEtcd {
+		errs = append(errs, field.Invalid(path.Child("etcd"), "", "the etcd settings require the etcd KV store"))
+	}
+	if kvStore.Consul != nil && kvStore.Type != KVStoreConsul {
+		errs = append(errs, field.Invalid(path.Child("consul"), "", "the Consul settings require the consul KV store"))
+	}
+	return errs
+}
+
+func (v *validator) validateDistributorService(tempo TempoStack) field.ErrorList {
+	serviceType := tempo.Spec.Template.Distributor.ServiceType
+	if serviceType == "" || serviceType == corev1.ServiceTypeClusterIP || !tempo.Spec.Template.Gateway.Enabled {
+		return nil
+	}
+
+	return field.ErrorList{field.Invalid(
+		field.NewPath("spec").Child("template").Child("distributor").Child("serviceType"),
+		serviceType,
+		"the distributor cannot be exposed directly if the gateway is enabled, please expose the gateway instead",
+	)}
+}
+
+func (v *validator) validateMetricsGenerator(tempo TempoStack) field.ErrorList {
+	generator := tempo.Spec.Template.MetricsGenerator
+	if !generator.Enabled {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("template").Child("metricsGenerator")
+	var errs field.ErrorList
+	if len(generator.Processors) == 0 {
+		errs = append(errs, field.Required(path.Child("processors"), "at least one processor must be enabled"))
+	}
+	for i, remoteWrite := range generator.RemoteWrite {
+		errs = append(errs, validateRemoteWrite(remoteWrite, path.Child("remoteWrite").Index(i))...)
+	}
+	return errs
+}
+
+// metricsGeneratorSendsToOpenShiftMonitoring returns true if the metrics-generator sends its metrics
+// to the OpenShift in-cluster monitoring stack.
+func metricsGeneratorSendsToOpenShiftMonitoring(tempo TempoStack) bool {
+	if !tempo.Spec.Template.MetricsGenerator.Enabled {
+		return false
+	}
+	for _, remoteWrite := range tempo.Spec.Template.MetricsGenerator.RemoteWrite {
+		if remoteWrite.OpenShiftMonitoring {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *validator) validateCache(tempo TempoStack) field.ErrorList {
+	if tempo.Spec.Template.Memcached.Enabled {
+		path := field.NewPath("spec").Child("template").Child("memcached")
+		switch {
+		case tempo.Spec.Cache != nil:
+			return field.ErrorList{field.Invalid(path.Child("enabled"), true,
+				"the memcached cache managed by the operator cannot be used together with an external cache in spec.cache")}
+		case tempo.Spec.Images.Memcached == "":
+			return field.ErrorList{field.Invalid(path.Child("enabled"), true,
+				"please specify a memcached image in the CR or in the operator configuration")}
+		}
+		if tempo.Spec.Template.Memcached.LogLevel != "" {
+			return field.ErrorList{field.Forbidden(path.Child("logLevel"), "the log level of memcached cannot be configured")}
+		}
+		return nil
+	}
+
+	cache := tempo.Spec.Cache
+	if cache == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("cache")
+	var errs field.ErrorList
+	if len(cache.Endpoints) == 0 {
+		errs = append(errs, field.Required(path.Child("endpoints"), "at least one endpoint is required"))
+	}
+	for i, endpoint := range cache.Endpoints {
+		if _, _, err := net.SplitHostPort(endpoint); err != nil {
+			errs = append(errs, field.Invalid(path.Child("endpoints").Index(i), endpoint, "must be a host:port address"))
+		}
+	}
+	if cache.Timeout.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("timeout"), cache.Timeout.Duration.String(), "the duration must not be negative"))
+	}
+	if cache.Backend == CacheBackendMemcached && cache.AuthSecret != "" {
+		errs = append(errs, field.Forbidden(path.Child("authSecret"), "authentication is only supported by redis"))
+	}
+	if cache.Backend == CacheBackendRedis && cache.TLS != nil {
+		if cache.TLS.CA != "" {
+			errs = append(errs, field.Forbidden(path.Child("tls").Child("caName"),
+				"a custom CA is only supported by memcached, redis uses the system CA bundle"))
+		}
+		if cache.TLS.CertName != "" {
+			errs = append(errs, field.Forbidden(path.Child("tls").Child("certName"), "client certificates are only supported by memcached"))
+		}
+		if cache.TLS.ServerName != "" {
+			errs = append(errs, field.Forbidden(path.Child("tls").Child("serverName"), "a custom server name is only supported by memcached"))
+		}
+	}
+	return errs
+}
+
+// blockFormatMinTempoVersion contains the first Tempo version supporting a block format.
+var blockFormatMinTempoVersion = map[BlockFormat]*semver.Version{
+	BlockFormatVParquet:  semver.MustParse("2.0.0"),
+	BlockFormatVParquet2: semver.MustParse("2.2.0"),
+	BlockFormatVParquet3: semver.MustParse("2.3.0"),
+	BlockFormatVParquet4: semver.MustParse("2.5.0"),
+}
+
+func (v *validator) validateBlockFormat(tempo TempoStack) field.ErrorList {
+	format := tempo.Spec.Storage.BlockFormat
+	if format == "" {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("storage").Child("blockFormat")
+	minVersion, ok := blockFormatMinTempoVersion[format]
+	if !ok {
+		return field.ErrorList{field.NotSupported(path, format, []string{
+			string(BlockFormatVParquet), string(BlockFormatVParquet2), string(BlockFormatVParquet3), string(BlockFormatVParquet4),
+		})}
+	}
+
+	// The version can only be verified if the tempo image is tagged with a version.
+	tempoVersion := version.ImageVersion(tempo.Spec.Images.Tempo)
+	if tempoVersion == nil || !tempoVersion.LessThan(minVersion) {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path, format, fmt.Sprintf(
+		"the block format requires Tempo %s or later, the tempo image %s uses Tempo %s",
+		minVersion, tempo.Spec.Images.Tempo, tempoVersion))}
+}
+
+func (v *validator) validateCompactor(tempo TempoStack) field.ErrorList {
+	compactor := tempo.Spec.Template.Compactor
+	path := field.NewPath("spec").Child("template").Child("compactor")
+
+	var errs field.ErrorList
+	durations := []struct {
+		name  string
+		value metav1.Duration
+	}{
+		{"compactionWindow", compactor.CompactionWindow},
+		{"compactionCycle", compactor.CompactionCycle},
+	}
+	for _, d := range durations {
+		if d.value.Duration < 0 {
+			errs = append(errs, field.Invalid(path.Child(d.name), d.value.Duration.String(), "the duration must not be negative"))
+		}
+	}
+	counts := []struct {
+		name  string
+		value *int
+	}{
+		{"maxBlockBytes", compactor.MaxBlockBytes},
+		{"retentionConcurrency", compactor.RetentionConcurrency},
+	}
+	for _, c := range counts {
+		if c.value != nil && *c.value <= 0 {
+			errs = append(errs, field.Invalid(path.Child(c.name), *c.value, "the value must be positive"))
+		}
+	}
+	return errs
+}
+
+func (v *validator) validateIngester(tempo TempoStack) field.ErrorList {
+	ingester := tempo.Spec.Template.Ingester
+	path := field.NewPath("spec").Child("template").Child("ingester")
+
+	var errs field.ErrorList
+	durations := []struct {
+		name  string
+		value metav1.Duration
+	}{
+		{"traceIdlePeriod", ingester.TraceIdlePeriod},
+		{"maxBlockDuration", ingester.MaxBlockDuration},
+		{"completeBlockTimeout", ingester.CompleteBlockTimeout},
+		{"flushCheckPeriod", ingester.FlushCheckPeriod},
+	}
+	for _, d := range durations {
+		if d.value.Duration < 0 {
+			errs = append(errs, field.Invalid(path.Child(d.name), d.value.Duration.String(), "the duration must not be negative"))
+		}
+	}
+	counts := []struct {
+		name  string
+		value *int
+	}{
+		{"maxBlockBytes", ingester.MaxBlockBytes},
+		{"concurrentFlushes", ingester.ConcurrentFlushes},
+	}
+	for _, c := range counts {
+		if c.value != nil && *c.value <= 0 {
+			errs = append(errs, field.Invalid(path.Child(c.name), *c.value, "the value must be positive"))
+		}
+	}
+
+	if zoneAware := ingester.ZoneAwareReplication; zoneAware != nil {
+		zonesPath := path.Child("zoneAwareReplication").Child("zones")
+		for i, zone := range zoneAware.Zones {
+			// The zone is part of the StatefulSet name and of a label value.
+			for _, msg := range validation.IsDNS1123Label(zone) {
+				errs = append(errs, field.Invalid(zonesPath.Index(i), zone, msg))
+			}
+		}
+		if len(zoneAware.Zones) > tempo.Spec.ReplicationFactor {
+			errs = append(errs, field.Invalid(
+				zonesPath,
+				zoneAware.Zones,
+				fmt.Sprintf("the replication factor (%d) must be greater than or equal to the number of zones", tempo.Spec.ReplicationFactor),
+			))
+		}
+	}
+	return errs
+}
+
+func (v *validator) validateSearch(tempo TempoStack) field.ErrorList {
+	search := tempo.Spec.SearchSpec
+	path := field.NewPath("spec").Child("search")
+
+	var errs field.ErrorList
+	if search.MaxDuration.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("maxDuration"), search.MaxDuration.Duration.String(), "the duration must not be negative"))
+	}
+	if search.QueryTimeout.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("queryTimeout"), search.QueryTimeout.Duration.String(), "the duration must not be negative"))
+	}
+	return errs
+}
+
+func (v *validator) validateQueryFrontendSearch(tempo TempoStack) field.ErrorList {
+	search := tempo.Spec.Template.QueryFrontend.Search
+	if search == nil {
+		return nil
+	}
+	path := field.NewPath("spec").Child("template").Child("queryFrontend").Child("search")
+
+	var errs field.ErrorList
+	durations := []struct {
+		name  string
+		value metav1.Duration
+	}{
+		{"maxDuration", search.MaxDuration},
+		{"queryBackendAfter", search.QueryBackendAfter},
+		{"queryIngestersUntil", search.QueryIngestersUntil},
+	}
+	for _, d := range durations {
+		if d.value.Duration < 0 {
+			errs = append(errs, field.Invalid(path.Child(d.name), d.value.Duration.String(), "the duration must not be negative"))
+		}
+	}
+	counts := []struct {
+		name  string
+		value *int
+	}{
+		{"concurrentJobs", search.ConcurrentJobs},
+		{"targetBytesPerJob", search.TargetBytesPerJob},
+	}
+	for _, c := range counts {
+		if c.value != nil && *c.value <= 0 {
+			errs = append(errs, field.Invalid(path.Child(c.name), *c.value, "the value must be positive"))
+		}
+	}
+	if search.QueryBackendAfter.Duration > 0 && search.QueryIngestersUntil.Duration > 0 &&
+		search.QueryIngestersUntil.Duration < search.QueryBackendAfter.Duration {
+		errs = append(errs, field.Invalid(path.Child("queryIngestersUntil"), search.QueryIngestersUntil.Duration.String(),
+			"must not be less than queryBackendAfter, otherwise recent data is neither searched in the ingesters nor in the object storage"))
+	}
+	return errs
+}
+
+// resultsCacheMinTempoVersion is the first Tempo version supporting the results cache of the query-frontend.
+var resultsCacheMinTempoVersion = semver.MustParse("2.4.0")
+
+func (v *validator) validateResultsCache(tempo TempoStack) field.ErrorList {
+	resultsCache := tempo.Spec.Template.QueryFrontend.ResultsCache
+	if resultsCache == nil || !resultsCache.Enabled {
+		return nil
+	}
+	path := field.NewPath("spec").Child("template").Child("queryFrontend").Child("resultsCache")
+
+	var errs field.ErrorList
+	if tempo.Spec.Cache == nil && !tempo.Spec.Template.Memcached.Enabled {
+		errs = append(errs, field.Invalid(path.Child("enabled"), resultsCache.Enabled,
+			"the results cache requires spec.cache or spec.template.memcached"))
+	}
+	if resultsCache.TTL.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("ttl"), resultsCache.TTL.Duration.String(), "the duration must not be negative"))
+	}
+
+	// The version can only be verified if the tempo image is tagged with a version.
+	if tempoVersion := version.ImageVersion(tempo.Spec.Images.Tempo); tempoVersion != nil && tempoVersion.LessThan(resultsCacheMinTempoVersion) {
+		errs = append(errs, field.Invalid(path.Child("enabled"), resultsCache.Enabled, fmt.Sprintf(
+			"the results cache requires Tempo %s or later, the tempo image %s uses Tempo %s",
+			resultsCacheMinTempoVersion, tempo.Spec.Images.Tempo, tempoVersion)))
+	}
+	return errs
+}
+
+// extraConfigManagedKeys contains the keys of the Tempo configuration which are managed by the operator.
+// Overriding them in spec.extraConfig.tempo can break the TempoStack.
+var extraConfigManagedKeys = []string{
+	"distributor.receivers",
+	"ingester.lifecycler.ring",
+	"ingester.lifecycler.tokens_file_path",
+	"ingester_client",
+	"internal_server",
+	"memberlist.join_members",
+	"metrics_generator.storage",
+	"metrics_generator_client",
+	"multitenancy_enabled",
+	"overrides.per_tenant_override_config",
+	"querier.frontend_worker",
+	"server.grpc_listen_port",
+	"server.grpc_tls_config",
+	"server.http_listen_port",
+	"server.http_tls_config",
+	"server.tls_cipher_suites",
+	"server.tls_min_version",
+	"storage.trace.azure",
+	"storage.trace.backend",
+	"storage.trace.gcs",
+	"storage.trace.local",
+	"storage.trace.s3",
+	"storage.trace.wal.path",
+}
+
+func (v *validator) validateExtraConfig(tempo TempoStack) field.ErrorList {
+	if tempo.Spec.ExtraConfig == nil || len(tempo.Spec.ExtraConfig.Tempo.Raw) == 0 {
+		return nil
+	}
+
+	cfg := map[string]interface{}{}
+	if err := json.Unmarshal(tempo.Spec.ExtraConfig.Tempo.Raw, &cfg); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec").Child("extraConfig").Child("tempo"),
+			string(tempo.Spec.ExtraConfig.Tempo.Raw), "the extra configuration must be an object")}
+	}
+	return nil
+}
+
+// extraConfigWarnings warns if the extra configuration overrides settings managed by the operator.
+func extraConfigWarnings(tempo TempoStack) admission.Warnings {
+	if tempo.Spec.ExtraConfig == nil || len(tempo.Spec.ExtraConfig.Tempo.Raw) == 0 {
+		return nil
+	}
+
+	cfg := map[string]interface{}{}
+	if err := json.Unmarshal(tempo.Spec.ExtraConfig.Tempo.Raw, &cfg); err != nil {
+		return nil
+	}
+
+	var warnings admission.Warnings
+	for _, key := range configKeys(cfg, "") {
+		for _, managed := range extraConfigManagedKeys {
+			if key == managed || strings.HasPrefix(key, managed+".") || strings.HasPrefix(managed, key+".") {
+				warnings = append(warnings, fmt.Sprintf(
+					"spec.extraConfig.tempo overrides %s, which is managed by the operator. This can break the TempoStack", key))
+				break
+			}
+		}
+	}
+	return warnings
+}
+
+// configKeys returns the sorted, dot-separated paths of all values in a configuration.
+func configKeys(cfg map[string]interface{}, prefix string) []string {
+	var keys []string
+	for key, value := range cfg {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			keys = append(keys, configKeys(nested, key)...)
+		} else {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func validateRemoteWrite(remoteWrite RemoteWriteSpec, path *field.Path) field.ErrorList {
+	if remoteWrite.OpenShiftMonitoring {
+		var errs field.ErrorList
+		if remoteWrite.URL != "" {
+			errs = append(errs, field.Invalid(path.Child("url"), remoteWrite.URL,
+				"the URL cannot be set if OpenShift monitoring is enabled"))
+		}
+		if remoteWrite.Auth != nil {
+			errs = append(errs, field.Forbidden(path.Child("auth"),
+				"OpenShift monitoring uses the token of the service account, custom authentication is not supported"))
+		}
+		if remoteWrite.TLS != nil {
+			errs = append(errs, field.Forbidden(path.Child("tls"),
+				"OpenShift monitoring uses the service CA bundle, custom TLS settings are not supported"))
+		}
+		return errs
+	}
+
+	if remoteWrite.URL == "" {
+		return field.ErrorList{field.Required(path.Child("url"), "the URL is required if OpenShift monitoring is not enabled")}
+	}
+
+	var errs field.ErrorList
+	u, err := url.ParseRequestURI(remoteWrite.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, field.Invalid(path.Child("url"), remoteWrite.URL,
+			"must be an absolute http or https URL"))
+	}
+	if remoteWrite.Auth != nil && remoteWrite.Auth.Secret == "" {
+		errs = append(errs, field.Required(path.Child("auth").Child("secret"), "the credentials secret is required"))
+	}
+	return errs
+}
+
+func (v *validator) validateRouteCertificates(tempo TempoStack) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateRouteSpec(
+		tempo.Spec.Template.Gateway.Ingress.Route,
+		field.NewPath("spec").Child("template").Child("gateway").Child("ingress").Child("route"),
+	)...)
+	errs = append(errs, validateRouteSpec(
+		tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress.Route,
+		field.NewPath("spec").Child("template").Child("queryFrontend").Child("jaegerQuery").Child("ingress").Child("route"),
+	)...)
+	return errs
+}
+
+// validateRouteSpec validates that the custom certificates of a Route match its TLS termination.
+func validateRouteSpec(route RouteSpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if route.CertificateSecret != "" && route.Termination != TLSRouteTerminationTypeEdge && route.Termination != TLSRouteTerminationTypeReencrypt {
+		errs = append(errs, field.Invalid(path.Child("certificateSecret"), route.CertificateSecret,
+			"a custom certificate is only supported by the edge and reencrypt termination"))
+	}
+	if route.DestinationCAConfigMap != "" && route.Termination != TLSRouteTerminationTypeReencrypt {
+		errs = append(errs, field.Invalid(path.Child("destinationCAConfigMap"), route.DestinationCAConfigMap,
+			"a destination CA is only supported by the reencrypt termination"))
+	}
+	return errs
+}
+
+func (v *validator) validateTLSProfile(tempo TempoStack) field.ErrorList {
+	if tempo.Spec.TLSProfile == nil {
+		return nil
+	}
+
+	profile := tempo.Spec.TLSProfile
+	path := field.NewPath("spec").Child("tlsProfile")
+	switch profile.Type {
+	case v1alpha1.TLSProfileOldType,
+		v1alpha1.TLSProfileIntermediateType,
+		v1alpha1.TLSProfileModernType:
+		var allErrs field.ErrorList
+		if len(profile.Ciphers) > 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("ciphers"), profile.Ciphers, "ciphers can only be set for the Custom type"))
+		}
+		if profile.MinTLSVersion != "" {
+			allErrs = append(allErrs, field.Invalid(path.Child("minTLSVersion"), profile.MinTLSVersion, "minTLSVersion can only be set for the Custom type"))
+		}
+		return allErrs
+	case v1alpha1.TLSProfileCustomType:
+		var allErrs field.ErrorList
+		if len(profile.Ciphers) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("ciphers"), "ciphers are required for the Custom type"))
+		}
+		switch profile.MinTLSVersion {
+		case "VersionTLS10", "VersionTLS11", "VersionTLS12", "VersionTLS13":
+		default:
+			allErrs = append(allErrs, field.Invalid(
+				path.Child("minTLSVersion"),
+				profile.MinTLSVersion,
+				"valid values are VersionTLS10, VersionTLS11, VersionTLS12 and VersionTLS13",
+			))
+		}
+		return allErrs
+	default:
+		return field.ErrorList{field.Invalid(
+			path.Child("type"),
+			profile.Type,
+			fmt.Sprintf("valid values are %s, %s, %s and %s", v1alpha1.TLSProfileOldType, v1alpha1.TLSProfileIntermediateType, v1alpha1.TLSProfileModernType, v1alpha1.TLSProfileCustomType),
+		)}
+	}
+}
+
+// validateFIPS validates that the TLS settings and container images of the TempoStack are FIPS compliant,
+// if the fips feature gate is enabled.
+func (v *validator) validateFIPS(tempo TempoStack) field.ErrorList {
+	if !v.ctrlConfig.Gates.FIPS {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	if profile := tempo.Spec.TLSProfile; profile != nil {
+		path := field.NewPath("spec").Child("tlsProfile")
+		if profile.Type == v1alpha1.TLSProfileOldType {
+			allErrs = append(allErrs, field.Invalid(path.Child("type"), profile.Type,
+				"the Old TLS profile is not supported when the fips feature gate is enabled"))
+		}
+		if profile.Type == v1alpha1.TLSProfileCustomType {
+			if nonFIPS := tlsprofile.NonFIPSCiphers(profile.Ciphers); len(nonFIPS) > 0 {
+				allErrs = append(allErrs, field.Invalid(path.Child("ciphers"), nonFIPS,
+					"the cipher suites are not FIPS approved"))
+			}
+			allErrs = append(allErrs, validateFIPSTLSVersion(path.Child("minTLSVersion"), profile.MinTLSVersion)...)
+		}
+	}
+
+	receiversTLS := tempo.Spec.Template.Distributor.TLS
+	receiversPath := field.NewPath("spec").Child("template").Child("distributor").Child("tls")
+	allErrs = append(allErrs, validateFIPSTLSVersion(receiversPath.Child("minTLSVersion"), receiversTLS.MinTLSVersion)...)
+	for _, receiver := range []struct {
+		name     string
+		override *ReceiverTLSOverrideSpec
+	}{
+		{name: "otlpGrpc", override: receiversTLS.OTLPGRPC},
+		{name: "otlpHttp", override: receiversTLS.OTLPHTTP},
+		{name: "jaeger", override: receiversTLS.Jaeger},
+		{name: "zipkin", override: receiversTLS.Zipkin},
+	} {
+		if receiver.override != nil {
+			allErrs = append(allErrs, validateFIPSTLSVersion(receiversPath.Child(receiver.name).Child("minTLSVersion"), receiver.override.MinTLSVersion)...)
+		}
+	}
+
+	// Only the default images of the operator are expected to be built with a FIPS validated cryptographic module.
+	imagesPath := field.NewPath("spec").Child("images")
+	for _, image := range []struct {
+		name         string
+		value        string
+		defaultValue string
+	}{
+		{name: "tempo", value: tempo.Spec.Images.Tempo, defaultValue: v.ctrlConfig.DefaultImages.Tempo},
+		{name: "tempoQuery", value: tempo.Spec.Images.TempoQuery, defaultValue: v.ctrlConfig.DefaultImages.TempoQuery},
+		{name: "tempoGateway", value: tempo.Spec.Images.TempoGateway, defaultValue: v.ctrlConfig.DefaultImages.TempoGateway},
+		{name: "tempoGatewayOpa", value: tempo.Spec.Images.TempoGatewayOpa, defaultValue: v.ctrlConfig.DefaultImages.TempoGatewayOpa},
+		{name: "spiffeHelper", value: tempo.Spec.Images.SPIFFEHelper, defaultValue: v.ctrlConfig.DefaultImages.SPIFFEHelper},
+		{name: "oauthProxy", value: tempo.Spec.Images.OauthProxy, defaultValue: v.ctrlConfig.DefaultImages.OauthProxy},
+		{name: "memcached", value: tempo.Spec.Images.Memcached, defaultValue: v.ctrlConfig.DefaultImages.Memcached},
+		{name: "tempoCLI", value: tempo.Spec.Images.TempoCLI, defaultValue: v.ctrlConfig.DefaultImages.TempoCLI},
+		{name: "backup", value: tempo.Spec.Images.Backup, defaultValue: v.ctrlConfig.DefaultImages.Backup},
+	} {
+		if image.value != "" && image.value != image.defaultValue {
+			allErrs = append(allErrs, field.Invalid(imagesPath.Child(image.name), image.value,
+				"custom container images are not supported when the fips feature gate is enabled"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateFIPSTLSVersion(path *field.Path, minTLSVersion string) field.ErrorList {
+	switch minTLSVersion {
+	case "VersionTLS10", "VersionTLS11":
+		return field.ErrorList{field.Invalid(path, minTLSVersion,
+			"the minimal TLS version must be VersionTLS12 or VersionTLS13 when the fips feature gate is enabled")}
+	}
+	return nil
+}
+
+func (v *validator) validateStackName(tempo TempoStack) field.ErrorList {
+	// We need to check this because the name is used as a label value for app.kubernetes.io/instance
+	// Only validate the length, because the DNS rules are enforced by the functions in the `naming` package.
+	if len(tempo.Name) > maxLabelLength {
+		return field.ErrorList{
+			field.Invalid(
+				field.NewPath("metadata").Child("name"),
+				tempo.Name,
+				fmt.Sprintf("must be no more than %d characters", maxLabelLength),
+			)}
+	}
+	return nil
+}
+
+func (v *validator) validateDeprecatedFields(tempo TempoStack) field.ErrorList {
+	if tempo.Spec.LimitSpec.Global.Query.MaxSearchBytesPerTrace != nil {
+		return field.ErrorList{
+			field.Invalid(
+				field.NewPath("spec").Child("limits").Child("global").Child("query").Child("maxSearchBytesPerTrace"),
+				tempo.Spec.LimitSpec.Global.Query.MaxSearchBytesPerTrace,
+				"this field is deprecated and must be unset",
+			)}
+	}
+	for tenant, limits := range tempo.Spec.LimitSpec.PerTenant {
+		if limits.Query.MaxSearchBytesPerTrace != nil {
+			return field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec").Child("limits").Child("perTenant").Key(tenant).Child("query").Child("maxSearchBytesPerTrace"),
+					limits.Query.MaxSearchBytesPerTrace,
+					"this field is deprecated and must be unset",
+				)}
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) validateLimits(tempo TempoStack) field.ErrorList {
+	path := field.NewPath("spec").Child("limits")
+	errs := validateRateLimitSpec(tempo.Spec.LimitSpec.Global, path.Child("global"))
+
+	// Iterate over the sorted tenants to report the errors in a stable order.
+	tenants := make([]string, 0, len(tempo.Spec.LimitSpec.PerTenant))
+	for tenant := range tempo.Spec.LimitSpec.PerTenant {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+
+	// The limits of a tenant in spec.tenants.authentication take precedence over spec.limits.perTenant.
+	tenantLimits := map[string]bool{}
+	if tempo.Spec.Tenants != nil {
+		for _, tenant := range tempo.Spec.Tenants.Authentication {
+			if tenant.Limits == nil {
+				continue
+			}
+			if tempo.Spec.Template.Gateway.Enabled {
+				tenantLimits[tenant.TenantID] = true
+			} else {
+				tenantLimits[tenant.TenantName] = true
+			}
+		}
+	}
+
+	for _, tenant := range tenants {
+		tenantPath := path.Child("perTenant").Key(tenant)
+		if tenant == "" {
+			errs = append(errs, field.Invalid(tenantPath, tenant, "the tenant must not be empty"))
+			continue
+		}
+		if tenantLimits[tenant] {
+			errs = append(errs, field.Duplicate(tenantPath, tenant))
+		}
+		errs = append(errs, validateRateLimitSpec(tempo.Spec.LimitSpec.PerTenant[tenant], tenantPath)...)
+	}
+	return errs
+}
+
+// validateRuntimeOverrides validates that the user-managed runtime overrides are not combined with the per-tenant
+// overrides generated by the operator, because Tempo supports only a single per-tenant overrides file.
+func (v *validator) validateRuntimeOverrides(tempo TempoStack) field.ErrorList {
+	if tempo.Spec.LimitSpec.PerTenantRuntimeOverridesConfigMap == "" {
+		return nil
+	}
+
+	const msg = "per-tenant overrides cannot be combined with spec.limits.perTenantRuntimeOverridesConfigMap, " +
+		"configure them in the runtime overrides ConfigMap instead"
+	var errs field.ErrorList
+	if len(tempo.Spec.LimitSpec.PerTenant) > 0 {
+		errs = append(errs, field.Forbidden(field.NewPath("spec").Child("limits").Child("perTenant"), msg))
+	}
+	if len(tempo.Spec.Retention.PerTenant) > 0 {
+		errs = append(errs, field.Forbidden(field.NewPath("spec").Child("retention").Child("perTenant"), msg))
+	}
+	if tempo.Spec.Tenants != nil {
+		path := field.NewPath("spec").Child("tenants").Child("authentication")
+		for i, tenant := range tempo.Spec.Tenants.Authentication {
+			if tenant.Limits != nil {
+				errs = append(errs, field.Forbidden(path.Index(i).Child("limits"), msg))
+			}
+			if tenant.Retention != nil {
+				errs = append(errs, field.Forbidden(path.Index(i).Child("retention"), msg))
+			}
+		}
+	}
+	return errs
+}
+
+func (v *validator) validateRetention(tempo TempoStack) field.ErrorList {
+	path := field.NewPath("spec").Child("retention")
+	var errs field.ErrorList
+	if tempo.Spec.Retention.Global.Traces.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("global").Child("traces"),
+			tempo.Spec.Retention.Global.Traces.Duration.String(), "the retention must not be negative"))
+	}
+
+	// Iterate over the sorted tenants to report the errors in a stable order.
+	tenants := make([]string, 0, len(tempo.Spec.Retention.PerTenant))
+	for tenant := range tempo.Spec.Retention.PerTenant {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+
+	// The retention of a tenant in spec.tenants.authentication takes precedence over spec.retention.perTenant.
+	tenantRetention := map[string]bool{}
+	if tempo.Spec.Tenants != nil {
+		for _, tenant := range tempo.Spec.Tenants.Authentication {
+			if tenant.Retention == nil {
+				continue
+			}
+			if tempo.Spec.Template.Gateway.Enabled {
+				tenantRetention[tenant.TenantID] = true
+			} else {
+				tenantRetention[tenant.TenantName] = true
+			}
+		}
+	}
+
+	for _, tenant := range tenants {
+		tenantPath := path.Child("perTenant").Key(tenant)
+		if tenant == "" {
+			errs = append(errs, field.Invalid(tenantPath, tenant, "the tenant must not be empty"))
+			continue
+		}
+		if tenantRetention[tenant] {
+			errs = append(errs, field.Duplicate(tenantPath, tenant))
+		}
+		if retention := tempo.Spec.Retention.PerTenant[tenant].Traces.Duration; retention <= 0 {
+			errs = append(errs, field.Invalid(tenantPath.Child("traces"), retention.String(), "the retention must be positive"))
+		}
+	}
+	return errs
+}
+
+// validateVerticalPodAutoscaler validates that the VerticalPodAutoscaler only manages the resources of the
+// components if they are not computed from spec.resources.total or spec.resources.profile.
+func (v *validator) validateVerticalPodAutoscaler(tempo TempoStack) field.ErrorList {
+	vpa := tempo.Spec.Resources.VerticalPodAutoscaler
+	if vpa == nil || vpa.UpdateMode == "" || vpa.UpdateMode == VerticalPodAutoscalerUpdateModeOff {
+		return nil
+	}
+	if tempo.Spec.Resources.Total == nil && tempo.Spec.Resources.Profile == "" {
+		return nil
+	}
+
+	return field.ErrorList{field.Invalid(
+		field.NewPath("spec").Child("resources").Child("verticalPodAutoscaler").Child("updateMode"),
+		vpa.UpdateMode,
+		"the VerticalPodAutoscaler can only update the resources if spec.resources.total and spec.resources.profile are not set",
+	)}
+}
+
+// validateResources validates that the resource profile is not combined with the total resources,
+// and that the requests of the component resources do not exceed their limits.
+func (v *validator) validateResources(tempo TempoStack) field.ErrorList {
+	var errs field.ErrorList
+	if tempo.Spec.Resources.Profile != "" && tempo.Spec.Resources.Total != nil {
+		errs = append(errs, field.Invalid(
+			field.NewPath("spec").Child("resources").Child("profile"),
+			tempo.Spec.Resources.Profile,
+			"the resource profile cannot be used together with spec.resources.total",
+		))
+	}
+
+	templateBase := field.NewPath("spec").Child("template")
+	components := []struct {
+		path      *field.Path
+		resources *corev1.ResourceRequirements
+	}{
+		{templateBase.Child("distributor"), tempo.Spec.Template.Distributor.Resources},
+		{templateBase.Child("ingester"), tempo.Spec.Template.Ingester.Resources},
+		{templateBase.Child("compactor"), tempo.Spec.Template.Compactor.Resources},
+		{templateBase.Child("querier"), tempo.Spec.Template.Querier.Resources},
+		{templateBase.Child("queryFrontend").Child("component"), tempo.Spec.Template.QueryFrontend.Resources},
+		{templateBase.Child("gateway").Child("component"), tempo.Spec.Template.Gateway.Resources},
+		{templateBase.Child("metricsGenerator"), tempo.Spec.Template.MetricsGenerator.Resources},
+	}
+	for _, component := range components {
+		if component.resources == nil {
+			continue
+		}
+
+		path := component.path.Child("resources").Child("requests")
+		for name, request := range component.resources.Requests {
+			limit, ok := component.resources.Limits[name]
+			if ok && request.Cmp(limit) > 0 {
+				errs = append(errs, field.Invalid(path.Key(string(name)), request.String(),
+					fmt.Sprintf("the request must be less than or equal to the limit (%s)", limit.String()),
+				))
+			}
+		}
+	}
+	return errs
+}
+
+// reservedPodLabels are the pod labels managed by the operator, which are used in the selectors of the components.
+var reservedPodLabels = []string{
+	"app.kubernetes.io/name",
+	"app.kubernetes.io/instance",
+	"app.kubernetes.io/component",
+	"app.kubernetes.io/managed-by",
+	"tempo-gossip-member",
+	"tempo.grafana.com/zone",
+}
+
+// validatePodMetadata validates the custom pod labels and annotations of all components.
+func (v *validator) validatePodMetadata(tempo TempoStack) field.ErrorList {
+	templateBase := field.NewPath("spec").Child("template")
+	components := []struct {
+		path *field.Path
+		spec TempoComponentSpec
+	}{
+		{templateBase.Child("distributor"), tempo.Spec.Template.Distributor.TempoComponentSpec},
+		{templateBase.Child("ingester"), tempo.Spec.Template.Ingester.TempoComponentSpec},
+		{templateBase.Child("compactor"), tempo.Spec.Template.Compactor.TempoComponentSpec},
+		{templateBase.Child("querier"), tempo.Spec.Template.Querier.TempoComponentSpec},
+		{templateBase.Child("queryFrontend").Child("component"), tempo.Spec.Template.QueryFrontend.TempoComponentSpec},
+		{templateBase.Child("gateway").Child("component"), tempo.Spec.Template.Gateway.TempoComponentSpec},
+		{templateBase.Child("metricsGenerator"), tempo.Spec.Template.MetricsGenerator.TempoComponentSpec},
+		{templateBase.Child("memcached"), tempo.Spec.Template.Memcached.TempoComponentSpec},
+	}
+
+	var errs field.ErrorList
+	for _, component := range components {
+		labelsPath := component.path.Child("podLabels")
+		errs = append(errs, metav1validation.ValidateLabels(component.spec.PodLabels, labelsPath)...)
+		for _, label := range reservedPodLabels {
+			if _, ok := component.spec.PodLabels[label]; ok {
+				errs = append(errs, field.Forbidden(labelsPath.Key(label), "the label is managed by the operator"))
+			}
+		}
+		errs = append(errs, apimachineryvalidation.ValidateAnnotations(component.spec.PodAnnotations, component.path.Child("podAnnotations"))...)
+	}
+	return errs
+}
+
+// validateGrafana validates the Grafana integration settings.
+func (v *validator) validateGrafana(tempo TempoStack) field.ErrorList {
+	grafana := tempo.Spec.Observability.Grafana
+	if grafana.CreateDatasource && grafana.InstanceSelector == nil {
+		return field.ErrorList{field.Required(
+			field.NewPath("spec").Child("observability").Child("grafana").Child("instanceSelector"),
+			"the Grafana instance selector is required to create a datasource",
+		)}
+	}
+	return nil
+}
+
+// serviceMonitorComponents are the components with a ServiceMonitor.
+var serviceMonitorComponents = []string{
+	"compactor",
+	"distributor",
+	"ingester",
+	"querier",
+	"query-frontend",
+	"gateway",
+	"metrics-generator",
+}
+
+// validateServiceMonitors validates the per-component ServiceMonitor configuration.
+func (v *validator) validateServiceMonitors(tempo TempoStack) field.ErrorList {
+	monitors := tempo.Spec.Observability.Metrics.ServiceMonitors
+	components := make([]string, 0, len(monitors))
+	for component := range monitors {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+
+	base := field.NewPath("spec").Child("observability").Child("metrics").Child("serviceMonitors")
+	var errs field.ErrorList
+	for _, component := range components {
+		path := base.Key(component)
+		monitor := monitors[component]
+
+		found := false
+		for _, c := range serviceMonitorComponents {
+			if c == component {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, field.NotSupported(path, component, serviceMonitorComponents))
+			continue
+		}
+
+		var interval, timeout model.Duration
+		var err error
+		if monitor.Interval != "" {
+			if interval, err = model.ParseDuration(monitor.Interval); err != nil {
+				errs = append(errs, field.Invalid(path.Child("interval"), monitor.Interval, err.Error()))
+			}
+		}
+		if monitor.ScrapeTimeout != "" {
+			if timeout, err = model.ParseDuration(monitor.ScrapeTimeout); err != nil {
+				errs = append(errs, field.Invalid(path.Child("scrapeTimeout"), monitor.ScrapeTimeout, err.Error()))
+			}
+		}
+		if interval > 0 && timeout > interval {
+			errs = append(errs, field.Invalid(path.Child("scrapeTimeout"), monitor.ScrapeTimeout,
+				"the scrape timeout must not be greater than the scrape interval"))
+		}
+
+		for i, relabel := range monitor.MetricRelabelConfigs {
+			if relabel.Regex == "" {
+				continue
+			}
+			if _, err := regexp.Compile(relabel.Regex); err != nil {
+				errs = append(errs, field.Invalid(path.Child("metricRelabelConfigs").Index(i).Child("regex"), relabel.Regex, err.Error()))
+			}
+		}
+	}
+	return errs
+}
+
+// validateNetworkPolicy validates the object storage CIDRs and the receiver namespace selector of the NetworkPolicies.
+func (v *validator) validateNetworkPolicy(tempo TempoStack) field.ErrorList {
+	networkPolicy := tempo.Spec.NetworkPolicy
+	if networkPolicy == nil || !networkPolicy.Enabled {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("networkPolicy")
+	var errs field.ErrorList
+	for i, cidr := range networkPolicy.ObjectStorageCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			errs = append(errs, field.Invalid(path.Child("objectStorageCIDRs").Index(i), cidr, "invalid CIDR"))
+		}
+	}
+	errs = append(errs, metav1validation.ValidateLabelSelector(networkPolicy.ReceiverNamespaceSelector,
+		metav1validation.LabelSelectorValidationOptions{}, path.Child("receiverNamespaceSelector"))...)
+	return errs
+}
+
+func (v *validator) validateServiceMesh(tempo TempoStack) field.ErrorList {
+	serviceMesh := tempo.Spec.ServiceMesh
+	if serviceMesh == nil || !serviceMesh.Enabled {
+		return nil
+	}
+
+	// Inside a service mesh, the sidecars encrypt the traffic between the components.
+	path := field.NewPath("spec").Child("serviceMesh").Child("enabled")
+	var errs field.ErrorList
+	if tempo.Spec.CertManager != nil {
+		errs = append(errs, field.Invalid(path, serviceMesh.Enabled,
+			"the service mesh cannot be combined with spec.certManager, the traffic is encrypted by the sidecars"))
+	}
+	if tempo.Spec.SPIFFE != nil {
+		errs = append(errs, field.Invalid(path, serviceMesh.Enabled,
+			"the service mesh cannot be combined with spec.spiffe, the traffic is encrypted by the sidecars"))
+	}
+	return errs
+}
+
+func (v *validator) validateServiceTopology(tempo TempoStack) field.ErrorList {
+	topology := tempo.Spec.ServiceTopology
+	if topology == nil {
+		return nil
+	}
+
+	// Kubernetes ignores the topology aware routing of Services with the Local internal traffic policy.
+	if topology.TopologyAwareRouting && topology.InternalTrafficPolicy == corev1.ServiceInternalTrafficPolicyLocal {
+		return field.ErrorList{field.Invalid(
+			field.NewPath("spec").Child("serviceTopology").Child("topologyAwareRouting"),
+			topology.TopologyAwareRouting,
+			"topology aware routing cannot be combined with the Local internal traffic policy",
+		)}
+	}
+	return nil
+}
+
+func (v *validator) validateOpenTelemetryCollector(tempo TempoStack) field.ErrorList {
+	collector := tempo.Spec.OpenTelemetryCollector
+	if collector == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("openTelemetryCollector")
+	var errs field.ErrorList
+	if tenants := tempo.Spec.Tenants; tenants != nil {
+		if collector.Tenant == "" {
+			errs = append(errs, field.Required(path.Child("tenant"), "the tenant is required if multi-tenancy is enabled"))
+		} else if len(tenants.Authentication) > 0 && !containsTenant(tenants.Authentication, collector.Tenant) {
+			errs = append(errs, field.Invalid(path.Child("tenant"), collector.Tenant, "the tenant must be configured in spec.tenants.authentication"))
+		}
+
+		// The credentials of the OIDC provider of static tenants cannot be provisioned for the collector.
+		if tempo.Spec.Template.Gateway.Enabled && tenants.Mode == ModeStatic {
+			errs = append(errs, field.Invalid(field.NewPath("spec").Child("tenants").Child("mode"), tenants.Mode,
+				"the OpenTelemetry Collector is not supported in static mode, the collector cannot authenticate with the OIDC provider"))
+		}
+	} else if collector.Tenant != "" {
+		errs = append(errs, field.Invalid(path.Child("tenant"), collector.Tenant, "the tenant can only be set if multi-tenancy is enabled"))
+	}
+
+	// The operator does not issue client certificates for the collector.
+	receiversTLS := tempo.Spec.Template.Distributor.TLS
+	if !tempo.Spec.Template.Gateway.Enabled && receiversTLS.Enabled &&
+		(receiversTLS.CA != "" || (receiversTLS.OTLPGRPC != nil && receiversTLS.OTLPGRPC.CA != "")) {
+		errs = append(errs, field.Forbidden(path,
+			"the OpenTelemetry Collector cannot be combined with receivers which require client certificates"))
+	}
+	return errs
+}
+
+func containsTenant(authentication []AuthenticationSpec, tenant string) bool {
+	for _, auth := range authentication {
+		if auth.TenantName == tenant {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *validator) validateBackup(tempo TempoStack) field.ErrorList {
+	backup := tempo.Spec.Backup
+	if backup == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("backup")
+	var errs field.ErrorList
+	if backup.Schedule == "" {
+		errs = append(errs, field.Required(path.Child("schedule"), "the schedule of the backup is required"))
+	}
+	if backup.Secret.Name == "" {
+		errs = append(errs, field.Required(path.Child("secret").Child("name"), "the secret of the backup bucket is required"))
+	} else if backup.Secret.Name == tempo.Spec.Storage.Secret.Name {
+		errs = append(errs, field.Invalid(path.Child("secret").Child("name"), backup.Secret.Name,
+			"the backup bucket must be different from the storage bucket"))
+	}
+	switch backup.Secret.Type {
+	case ObjectStorageSecretAzure, ObjectStorageSecretGCS, ObjectStorageSecretS3:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("secret").Child("type"), backup.Secret.Type,
+			[]string{string(ObjectStorageSecretAzure), string(ObjectStorageSecretGCS), string(ObjectStorageSecretS3)}))
+	}
+
+	// The backup job reads the credentials of the storage bucket from the storage secret.
+	if tempo.Spec.Storage.SecretProviderClass != "" {
+		errs = append(errs, field.Forbidden(path,
+			"the backup is not supported if the storage credentials are provided by a secret provider class"))
+	}
+	return errs
+}
+
+func (v *validator) validateStorageMigration(tempo TempoStack) field.ErrorList {
+	migrateFrom := tempo.Spec.Storage.MigrateFrom
+	if migrateFrom == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("storage").Child("migrateFrom")
+	var errs field.ErrorList
+	if migrateFrom.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), "the secret of the previous object storage is required"))
+	} else if migrateFrom.Name == tempo.Spec.Storage.Secret.Name {
+		errs = append(errs, field.Invalid(path.Child("name"), migrateFrom.Name,
+			"the secret of the previous object storage must be different from the storage secret"))
+	}
+	switch migrateFrom.Type {
+	case ObjectStorageSecretAzure, ObjectStorageSecretGCS, ObjectStorageSecretS3:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("type"), migrateFrom.Type,
+			[]string{string(ObjectStorageSecretAzure), string(ObjectStorageSecretGCS), string(ObjectStorageSecretS3)}))
+	}
+
+	// The migration job reads the credentials of the storage bucket from the storage secret.
+	if tempo.Spec.Storage.SecretProviderClass != "" {
+		errs = append(errs, field.Forbidden(path,
+			"the migration is not supported if the storage credentials are provided by a secret provider class"))
+	}
+	return errs
+}
+
+func (v *validator) validateTenantPurges(tempo TempoStack) field.ErrorList {
+	var errs field.ErrorList
+	names := map[string]bool{}
+	for i, purge := range tempo.Spec.TenantPurges {
+		path := field.NewPath("spec").Child("tenantPurges").Index(i)
+
+		jobName := fmt.Sprintf("tempo-%s-purge-%s", tempo.Name, purge.Name)
+		if purge.Name == "" {
+			errs = append(errs, field.Required(path.Child("name"), "the name of the purge is required"))
+		} else if msgs := validation.IsDNS1123Label(jobName); len(msgs) > 0 {
+			errs = append(errs, field.Invalid(path.Child("name"), purge.Name,
+				fmt.Sprintf("the name of the Job %s is invalid: %s", jobName, strings.Join(msgs, ", "))))
+		} else if names[purge.Name] {
+			errs = append(errs, field.Duplicate(path.Child("name"), purge.Name))
+		}
+		names[purge.Name] = true
+
+		// The tenant ID is the prefix which is deleted from the bucket,
+		// an empty prefix or a relative path would delete the blocks of all tenants.
+		if purge.Tenant == "" {
+			errs = append(errs, field.Required(path.Child("tenant"), "the tenant is required"))
+		} else if !isValidTenantID(purge.Tenant) {
+			errs = append(errs, field.Invalid(path.Child("tenant"), purge.Tenant, fmt.Sprintf(
+				"it must consist of at most %d alphanumeric characters or !-_.*'(), and must not be . or ..", maxTenantIDLength)))
+		}
+	}
+
+	// The purge jobs read the credentials of the storage bucket from the storage secret.
+	if len(tempo.Spec.TenantPurges) > 0 && tempo.Spec.Storage.SecretProviderClass != "" {
+		errs = append(errs, field.Forbidden(field.NewPath("spec").Child("tenantPurges"),
+			"the tenant purges are not supported if the storage credentials are provided by a secret provider class"))
+	}
+	return errs
+}
+
+// minCanaryInterval is the minimal interval between two probes of the canary.
+const minCanaryInterval = 10 * time.Second
+
+func (v *validator) validateCanary(tempo TempoStack) field.ErrorList {
+	canary := tempo.Spec.Canary
+	if canary == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	path := field.NewPath("spec").Child("canary")
+	// The OTLP HTTP receiver of the distributor is disabled if the gateway is enabled.
+	if tempo.Spec.Template.Gateway.Enabled {
+		errs = append(errs, field.Forbidden(path, "the canary is not supported if the gateway is enabled"))
+	}
+
+	if tempo.Spec.Tenants != nil && canary.Tenant == "" {
+		errs = append(errs, field.Required(path.Child("tenant"), "the tenant is required if multi-tenancy is enabled"))
+	} else if !isValidTenantID(canary.Tenant) {
+		errs = append(errs, field.Invalid(path.Child("tenant"), canary.Tenant, fmt.Sprintf(
+			"it must consist of at most %d alphanumeric characters or !-_.*'(), and must not be . or ..", maxTenantIDLength)))
+	}
+
+	if canary.Interval.Duration != 0 && canary.Interval.Duration < minCanaryInterval {
+		errs = append(errs, field.Invalid(path.Child("interval"), canary.Interval.Duration.String(),
+			fmt.Sprintf("the interval must be at least %s", minCanaryInterval)))
+	}
+
+	// The canary authenticates with the certificate of the distributor,
+	// and verifies the receivers with the CA bundle of the TempoStack.
+	receiversTLS := tempo.Spec.Template.Distributor.TLS
+	otlpHTTPCustomTLS := receiversTLS.OTLPHTTP != nil && (receiversTLS.OTLPHTTP.CertName != "" || receiversTLS.OTLPHTTP.CA != "")
+	if receiversTLS.Enabled && (receiversTLS.CertName != "" || receiversTLS.CA != "" || otlpHTTPCustomTLS) {
+		errs = append(errs, field.Forbidden(path,
+			"the canary is not supported if the OTLP HTTP receiver uses a custom certificate or client CA"))
+	}
+	return errs
+}
+
+func (v *validator) validateCLIJobs(tempo TempoStack) field.ErrorList {
+	var errs field.ErrorList
+	names := map[string]bool{}
+	for i, job := range tempo.Spec.CLIJobs {
+		path := field.NewPath("spec").Child("cliJobs").Index(i)
+
+		// The name of the Job is also the value of the job-name label of its pods.
+		// Validate the unsanitized name, naming.Name would lowercase it and replace invalid characters.
+		jobName := fmt.Sprintf("tempo-%s-cli-%s", tempo.Name, job.Name)
+		if job.Name == "" {
+			errs = append(errs, field.Required(path.Child("name"), "the name of the job is required"))
+		} else if msgs := validation.IsDNS1123Label(jobName); len(msgs) > 0 {
+			errs = append(errs, field.Invalid(path.Child("name"), job.Name,
+				fmt.Sprintf("the name of the Job %s is invalid: %s", jobName, strings.Join(msgs, ", "))))
+		} else if names[job.Name] {
+			errs = append(errs, field.Duplicate(path.Child("name"), job.Name))
+		}
+		names[job.Name] = true
+
+		if tempo.Spec.Tenants != nil && job.Tenant == "" {
+			errs = append(errs, field.Required(path.Child("tenant"), "the tenant is required if multi-tenancy is enabled"))
+		}
+
+		switch job.Command {
+		case TempoCLIListBlocks, TempoCLIGenIndex:
+		case TempoCLIAnalyseBlock:
+			if job.BlockID == "" {
+				errs = append(errs, field.Required(path.Child("blockID"), "the block ID is required by the analyse-block command"))
+			}
+		case TempoCLISearchBlocks:
+			if name, _, found := strings.Cut(job.Attribute, "="); !found || name == "" {
+				errs = append(errs, field.Invalid(path.Child("attribute"), job.Attribute,
+					"the attribute must be in the format name=value for the search-blocks command"))
+			}
+		default:
+			errs = append(errs, field.NotSupported(path.Child("command"), job.Command, []string{
+				string(TempoCLIListBlocks), string(TempoCLIAnalyseBlock), string(TempoCLISearchBlocks), string(TempoCLIGenIndex),
+			}))
+		}
+	}
+	return errs
+}
+
+func validateRateLimitSpec(spec RateLimitSpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	limits := []struct {
+		path  *field.Path
+		value *int
+	}{
+		{path.Child("ingestion").Child("ingestionBurstSizeBytes"), spec.Ingestion.IngestionBurstSizeBytes},
+		{path.Child("ingestion").Child("ingestionRateLimitBytes"), spec.Ingestion.IngestionRateLimitBytes},
+		{path.Child("ingestion").Child("maxBytesPerTrace"), spec.Ingestion.MaxBytesPerTrace},
+		{path.Child("ingestion").Child("maxTracesPerUser"), spec.Ingestion.MaxTracesPerUser},
+		{path.Child("query").Child("maxBytesPerTagValues"), spec.Query.MaxBytesPerTagValues},
+	}
+	for _, limit := range limits {
+		if limit.value != nil && *limit.value < 0 {
+			errs = append(errs, field.Invalid(limit.path, *limit.value, "the limit must not be negative"))
+		}
+	}
+	if spec.Query.MaxSearchDuration.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("query").Child("maxSearchDuration"),
+			spec.Query.MaxSearchDuration.Duration.String(), "the duration must not be negative"))
+	}
+	return errs
+}
+
+func (v *validator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	tempo, ok := obj.(*TempoStack)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a TempoStack object but got %T", obj))
+	}
+
+	log := ctrl.LoggerFrom(ctx).WithName("tempostack-webhook")
+	if !v.ctrlConfig.WatchesNamespace(tempo.Namespace) {
+		// The TempoStack is managed by another operator instance.
+		log.V(1).Info("skipping validating webhook, namespace is not watched", "name", tempo.Name, "namespace", tempo.Namespace)
+		return nil, nil
+	}
+	log.V(1).Info("running validating webhook", "name", tempo.Name)
+
+	// Validate the TempoStack against the feature gates with its overrides applied.
+	v = &validator{client: v.client, ctrlConfig: v.ctrlConfig, componentPods: v.componentPods}
+	v.ctrlConfig.Gates = EffectiveFeatureGates(v.ctrlConfig.Gates, *tempo)
+
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, v.validateStackName(*tempo)...)
+	allErrs = append(allErrs, v.validateServiceAccount(ctx, *tempo)...)
+	allErrs = append(allErrs, v.validateStorage(ctx, *tempo)...)
+	allErrs = append(allErrs, v.validateSharedObjects(*tempo)...)
+	allErrs = append(allErrs, v.validateReplicationFactor(*tempo)...)
+	allErrs = append(allErrs, v.validateQueryFrontend(*tempo)...)
+	allErrs = append(allErrs, v.validateGateway(*tempo)...)
+	allErrs = append(allErrs, v.validateTenantConfigs(*tempo)...)
+	allErrs = append(allErrs, v.validateObservability(*tempo)...)
+	allErrs = append(allErrs, v.validateDeprecatedFields(*tempo)...)
+	allErrs = append(allErrs, v.validateLimits(*tempo)...)
+	allErrs = append(allErrs, v.validateRetention(*tempo)...)
+	allErrs = append(allErrs, v.validateVolumeClaimTemplates(*tempo)...)
+	allErrs = append(allErrs, v.validateCertManager(*tempo)...)
+	allErrs = append(allErrs, v.validateReceiversTLS(*tempo)...)
+	allErrs = append(allErrs, v.validateTLSProfile(*tempo)...)
+	allErrs = append(allErrs, v.validateSPIFFE(*tempo)...)
+	allErrs = append(allErrs, v.validateGatewayAutoscaling(*tempo)...)
+	allErrs = append(allErrs, v.validateComponentAutoscaling(*tempo)...)
+	allErrs = append(allErrs, v.validateGatewayRateLimits(*tempo)...)
+	allErrs = append(allErrs, v.validateGatewayAuditLog(*tempo)...)
+	allErrs = append(allErrs, v.validateGatewayMTLS(*tempo)...)
+	allErrs = append(allErrs, v.validateJaegerQueryAuthentication(*tempo)...)
+	allErrs = append(allErrs, v.validateGatewayAccessReviewCache(*tempo)...)
+	allErrs = append(allErrs, v.validateRouteCertificates(*tempo)...)
+	allErrs = append(allErrs, v.validateGatewayRBAC(*tempo)...)
+	allErrs = append(allErrs, v.validateIngressHosts(ctx, *tempo)...)
+	allErrs = append(allErrs, v.validateKafkaReceiver(*tempo)...)
+	allErrs = append(allErrs, v.validateZipkinReceiver(*tempo)...)
+	allErrs = append(allErrs, v.validateOTLPIngresses(*tempo)...)
+	allErrs = append(allErrs, v.validateJaegerReceiver(*tempo)...)
+	allErrs = append(allErrs, v.validatePorts(*tempo)...)
+	allErrs = append(allErrs, v.validateMemberlist(*tempo)...)
+	allErrs = append(allErrs, v.validateKVStore(*tempo)...)
+	allErrs = append(allErrs, v.validateDistributorService(*tempo)...)
+	allErrs = append(allErrs, v.validateMetricsGenerator(*tempo)...)
+	allErrs = append(allErrs, v.validateIngester(*tempo)...)
+	allErrs = append(allErrs, v.validateCompactor(*tempo)...)
+	allErrs = append(allErrs, v.validateBlockFormat(*tempo)...)
+	allErrs = append(allErrs, v.validateCache(*tempo)...)
+	allErrs = append(allErrs, v.validateSearch(*tempo)...)
+	allErrs = append(allErrs, v.validateQueryFrontendSearch(*tempo)...)
+	allErrs = append(allErrs, v.validateResultsCache(*tempo)...)
+	allErrs = append(allErrs, v.validateExtraConfig(*tempo)...)
+	allErrs = append(allErrs, v.validateRuntimeOverrides(*tempo)...)
+	allErrs = append(allErrs, v.validateVerticalPodAutoscaler(*tempo)...)
+	allErrs = append(allErrs, v.validateResources(*tempo)...)
+	allErrs = append(allErrs, v.validateResourceQuotas(ctx, *tempo)...)
+	allErrs = append(allErrs, v.validatePodMetadata(*tempo)...)
+	allErrs = append(allErrs, v.validateGrafana(*tempo)...)
+	allErrs = append(allErrs, v.validateServiceMonitors(*tempo)...)
+	allErrs = append(allErrs, v.validateNetworkPolicy(*tempo)...)
+	allErrs = append(allErrs, v.validateIngressTLSAndPaths(*tempo)...)
+	allErrs = append(allErrs, v.validateGRPCServer(*tempo)...)
+	allErrs = append(allErrs, v.validateServiceMesh(*tempo)...)
+	allErrs = append(allErrs, v.validateServiceTopology(*tempo)...)
+	allErrs = append(allErrs, v.validateOpenTelemetryCollector(*tempo)...)
+	allErrs = append(allErrs, v.validateFIPS(*tempo)...)
+	allErrs = append(allErrs, v.validateBackup(*tempo)...)
+	allErrs = append(allErrs, v.validateStorageMigration(*tempo)...)
+	allErrs = append(allErrs, v.validateCLIJobs(*tempo)...)
+	allErrs = append(allErrs, v.validateTenantPurges(*tempo)...)
+	allErrs = append(allErrs, v.validateCanary(*tempo)...)
+
+	if len(allErrs) == 0 {
+		return extraConfigWarnings(*tempo), nil
+	}
+	return nil, apierrors.NewInvalid(tempo.GroupVersionKind().GroupKind(), tempo.Name, allErrs)
 }
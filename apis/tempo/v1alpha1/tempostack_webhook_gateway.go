@@ -0,0 +1,245 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/grafana/tempo-operator/internal/version"
+)
+
+func (v *validator) validateGateway(tempo TempoStack) field.ErrorList {
+	path := field.NewPath("spec").Child("template").Child("gateway").Child("enabled")
+	if tempo.Spec.Template.Gateway.Enabled {
+		if !tempo.Spec.Template.QueryFrontend.JaegerQuery.Enabled {
+			return field.ErrorList{
+				field.Invalid(path, tempo.Spec.Template.Gateway.Enabled,
+					"to use the gateway, please enable jaegerQuery",
+				)}
+		}
+
+		if tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress.Type != IngressTypeNone {
+			return field.ErrorList{
+				field.Invalid(path, tempo.Spec.Template.Gateway.Enabled,
+					"cannot enable gateway and jaeger query ingress at the same time, please use the Jaeger UI from the gateway",
+				)}
+		}
+
+		if tempo.Spec.Tenants == nil {
+			return field.ErrorList{
+				field.Invalid(path, tempo.Spec.Template.Gateway.Enabled,
+					"to enable the gateway, please configure tenants",
+				)}
+		}
+
+		if tempo.Spec.Template.Gateway.Ingress.Type == IngressTypeRoute && !v.ctrlConfig.Gates.OpenShift.OpenShiftRoute {
+			return field.ErrorList{field.Invalid(
+				field.NewPath("spec").Child("template").Child("gateway").Child("ingress").Child("type"),
+				tempo.Spec.Template.Gateway.Ingress.Type,
+				"please enable the featureGates.openshift.openshiftRoute feature gate to use Routes",
+			)}
+		}
+	}
+	return nil
+}
+
+func (v *validator) validateGatewayAutoscaling(tempo TempoStack) field.ErrorList {
+	gateway := tempo.Spec.Template.Gateway
+	path := field.NewPath("spec").Child("template").Child("gateway")
+	return validateAutoscaling(path, path.Child("component"), "gateway", gateway.Replicas, gateway.Autoscaling)
+}
+
+func (v *validator) validateGatewayRateLimits(tempo TempoStack) field.ErrorList {
+	rateLimits := tempo.Spec.Template.Gateway.RateLimits
+	if len(rateLimits) == 0 {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("template").Child("gateway").Child("rateLimits")
+	if !tempo.Spec.Template.Gateway.Enabled {
+		return field.ErrorList{field.Invalid(
+			path,
+			rateLimits,
+			"please enable the gateway to use rate limits",
+		)}
+	}
+
+	tenantNames := map[string]bool{}
+	if tempo.Spec.Tenants != nil {
+		for _, auth := range tempo.Spec.Tenants.Authentication {
+			tenantNames[auth.TenantName] = true
+		}
+	}
+
+	var errs field.ErrorList
+	for i, rateLimit := range rateLimits {
+		if !tenantNames[rateLimit.Tenant] {
+			errs = append(errs, field.Invalid(
+				path.Index(i).Child("tenant"),
+				rateLimit.Tenant,
+				"tenant is not defined in spec.tenants.authentication",
+			))
+		}
+		if _, err := regexp.Compile(rateLimit.Endpoint); err != nil {
+			errs = append(errs, field.Invalid(
+				path.Index(i).Child("endpoint"),
+				rateLimit.Endpoint,
+				fmt.Sprintf("invalid regular expression: %v", err),
+			))
+		}
+		if rateLimit.Limit < 1 {
+			errs = append(errs, field.Invalid(
+				path.Index(i).Child("limit"),
+				rateLimit.Limit,
+				"must be greater than 0",
+			))
+		}
+	}
+	return errs
+}
+
+func (v *validator) validateGatewayAuditLog(tempo TempoStack) field.ErrorList {
+	auditLog := tempo.Spec.Template.Gateway.AuditLog
+	if auditLog == nil || tempo.Spec.Template.Gateway.Enabled {
+		return nil
+	}
+
+	return field.ErrorList{field.Invalid(
+		field.NewPath("spec").Child("template").Child("gateway").Child("auditLog"),
+		auditLog,
+		"please enable the gateway to use audit logs",
+	)}
+}
+
+func (v *validator) validateGatewayMTLS(tempo TempoStack) field.ErrorList {
+	if tempo.Spec.Tenants == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("tenants").Child("authentication")
+	var errs field.ErrorList
+	for i, auth := range tempo.Spec.Tenants.Authentication {
+		if auth.MTLS == nil {
+			continue
+		}
+
+		switch {
+		case tempo.Spec.Tenants.Mode != ModeStatic:
+			errs = append(errs, field.Invalid(path.Index(i).Child("mTLS"), auth.MTLS,
+				"client certificate authentication is only supported in static mode"))
+		case auth.OIDC != nil:
+			errs = append(errs, field.Invalid(path.Index(i).Child("mTLS"), auth.MTLS,
+				"only one of oidc or mTLS can be set"))
+		case auth.MTLS.CA == "":
+			errs = append(errs, field.Invalid(path.Index(i).Child("mTLS").Child("caName"), auth.MTLS.CA,
+				"please specify the name of the CA ConfigMap"))
+		case !v.ctrlConfig.Gates.HTTPEncryption:
+			errs = append(errs, field.Invalid(path.Index(i).Child("mTLS"), auth.MTLS,
+				"please enable the featureGates.httpEncryption feature gate to use client certificate authentication"))
+		}
+	}
+	return errs
+}
+
+func (v *validator) validateJaegerQueryAuthentication(tempo TempoStack) field.ErrorList {
+	jaegerQuery := tempo.Spec.Template.QueryFrontend.JaegerQuery
+	if jaegerQuery.Authentication == nil || !jaegerQuery.Authentication.Enabled {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("template").Child("queryFrontend").Child("jaegerQuery").Child("authentication")
+	switch {
+	case !jaegerQuery.Enabled:
+		return field.ErrorList{field.Invalid(path, jaegerQuery.Authentication,
+			"please enable the Jaeger Query UI to use authentication")}
+	case tempo.Spec.Template.Gateway.Enabled:
+		return field.ErrorList{field.Invalid(path, jaegerQuery.Authentication,
+			"cannot enable authentication of the Jaeger Query UI if the gateway is enabled, the gateway authenticates the users")}
+	case !v.ctrlConfig.Gates.OpenShift.OpenShiftRoute:
+		return field.ErrorList{field.Invalid(path, jaegerQuery.Authentication,
+			"please enable the featureGates.openshift.openshiftRoute feature gate to use authentication")}
+	case jaegerQuery.Ingress.Type != IngressTypeRoute:
+		return field.ErrorList{field.Invalid(path, jaegerQuery.Authentication,
+			"authentication requires a Route for the Jaeger Query UI")}
+	case jaegerQuery.Ingress.Route.Termination != TLSRouteTerminationTypeEdge:
+		return field.ErrorList{field.Invalid(path, jaegerQuery.Authentication,
+			"authentication requires the edge TLS termination of the Route")}
+	case tempo.Spec.Images.OauthProxy == "":
+		return field.ErrorList{field.Invalid(path, jaegerQuery.Authentication,
+			"please specify an oauthProxy image in the CR or in the operator configuration")}
+	}
+	return nil
+}
+
+func (v *validator) validateGatewayAccessReviewCache(tempo TempoStack) field.ErrorList {
+	cache := tempo.Spec.Template.Gateway.AccessReviewCache
+	if cache == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("template").Child("gateway").Child("accessReviewCache")
+	switch {
+	case !tempo.Spec.Template.Gateway.Enabled || tempo.Spec.Tenants == nil || tempo.Spec.Tenants.Mode != ModeOpenShift:
+		return field.ErrorList{field.Invalid(path, cache,
+			"the access review cache is only supported by the gateway in openshift mode")}
+	case cache.TTL.Duration != 0 && cache.TTL.Duration < time.Second:
+		return field.ErrorList{field.Invalid(path.Child("ttl"), cache.TTL.Duration.String(),
+			"must be at least 1s")}
+	case tempo.Spec.Images.Memcached == "":
+		return field.ErrorList{field.Invalid(path, cache,
+			"please specify a memcached image in the CR or in the operator configuration")}
+	}
+	return nil
+}
+
+func (v *validator) validateGatewayRBAC(tempo TempoStack) field.ErrorList {
+	gateway := tempo.Spec.Template.Gateway
+	if !gateway.RBAC.Enabled {
+		return nil
+	}
+
+	if !gateway.Enabled || tempo.Spec.Tenants == nil || tempo.Spec.Tenants.Mode != ModeOpenShift {
+		return field.ErrorList{field.Invalid(
+			field.NewPath("spec").Child("template").Child("gateway").Child("rbac").Child("enabled"),
+			gateway.RBAC.Enabled,
+			"query RBAC is only supported by the gateway in openshift mode",
+		)}
+	}
+
+	// The gateway container fails to start if the image predates the --traces.query-rbac flag of observatorium/api.
+	// The build date can only be verified if the gateway image is tagged with its build date.
+	image := tempo.Spec.Images.TempoGateway
+	if image == "" {
+		image = v.ctrlConfig.DefaultImages.TempoGateway
+	}
+	if built, ok := gatewayImageBuildDate(image); ok && built.Before(gatewayQueryRBACMinBuildDate) {
+		return field.ErrorList{field.Invalid(
+			field.NewPath("spec").Child("images").Child("tempoGateway"),
+			tempo.Spec.Images.TempoGateway,
+			fmt.Sprintf("query RBAC requires a gateway image built on %s or later, the gateway image %s was built on %s",
+				gatewayQueryRBACMinBuildDate.Format(time.DateOnly), image, built.Format(time.DateOnly)),
+		)}
+	}
+	return nil
+}
+
+// gatewayQueryRBACMinBuildDate is the build date of the first observatorium/api image supporting the --traces.query-rbac flag.
+var gatewayQueryRBACMinBuildDate = time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC)
+
+// gatewayImageTagRegex matches the tags of the observatorium/api images, which contain their build date, e.g. main-2023-09-13-14e06c6.
+var gatewayImageTagRegex = regexp.MustCompile(`^[a-z0-9.]+-(\d{4}-\d{2}-\d{2})-[0-9a-f]+$`)
+
+// gatewayImageBuildDate returns the build date of an observatorium/api image, or false if the tag does not contain the build date.
+func gatewayImageBuildDate(image string) (time.Time, bool) {
+	matches := gatewayImageTagRegex.FindStringSubmatch(version.ImageTag(image))
+	if matches == nil {
+		return time.Time{}, false
+	}
+	built, err := time.Parse(time.DateOnly, matches[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return built, true
+}
@@ -0,0 +1,806 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+
+	"github.com/grafana/tempo-operator/apis/config/v1alpha1"
+)
+
+func TestValidateGatewayAndJaegerQuery(t *testing.T) {
+	path := field.NewPath("spec").Child("template").Child("gateway").Child("enabled")
+
+	tests := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name: "valid configuration enabled both",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					ReplicationFactor: 3,
+					Template: TempoTemplateSpec{
+						QueryFrontend: TempoQueryFrontendSpec{
+							JaegerQuery: JaegerQuerySpec{
+								Enabled: true,
+							},
+						},
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+					Tenants: &TenantsSpec{
+						Mode: ModeStatic,
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "valid config disable gateway and enable jaegerQuery",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					ReplicationFactor: 3,
+					Template: TempoTemplateSpec{
+						QueryFrontend: TempoQueryFrontendSpec{
+							JaegerQuery: JaegerQuerySpec{
+								Enabled: true,
+								Ingress: IngressSpec{
+									Type: "route",
+								},
+							},
+						},
+						Gateway: TempoGatewaySpec{
+							Enabled: false,
+						},
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "valid config disable both",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					ReplicationFactor: 3,
+					Template: TempoTemplateSpec{
+						QueryFrontend: TempoQueryFrontendSpec{
+							JaegerQuery: JaegerQuerySpec{
+								Enabled: false,
+								Ingress: IngressSpec{
+									Type: "route",
+								},
+							},
+						},
+						Gateway: TempoGatewaySpec{
+							Enabled: false,
+						},
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid config disable jaegerQuery",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					ReplicationFactor: 3,
+					Template: TempoTemplateSpec{
+						QueryFrontend: TempoQueryFrontendSpec{
+							JaegerQuery: JaegerQuerySpec{
+								Enabled: false,
+								Ingress: IngressSpec{
+									Type: "ingress",
+								},
+							},
+						},
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, true,
+					"to use the gateway, please enable jaegerQuery",
+				),
+			},
+		},
+		{
+			name: "invalid configuration, ingress and gateway enabled",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					ReplicationFactor: 3,
+					Template: TempoTemplateSpec{
+						QueryFrontend: TempoQueryFrontendSpec{
+							JaegerQuery: JaegerQuerySpec{
+								Enabled: true,
+								Ingress: IngressSpec{
+									Type: "ingress",
+								},
+							},
+						},
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+					Tenants: &TenantsSpec{
+						Mode: ModeStatic,
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, true,
+					"cannot enable gateway and jaeger query ingress at the same time, please use the Jaeger UI from the gateway",
+				),
+			},
+		},
+		{
+			name: "invalid configuration, gateway enabled but no tenant configured",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						QueryFrontend: TempoQueryFrontendSpec{
+							JaegerQuery: JaegerQuerySpec{
+								Enabled: true,
+							},
+						},
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, true,
+					"to enable the gateway, please configure tenants",
+				),
+			},
+		},
+		{
+			name: "valid ingress configuration",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					ReplicationFactor: 3,
+					Template: TempoTemplateSpec{
+						QueryFrontend: TempoQueryFrontendSpec{
+							JaegerQuery: JaegerQuerySpec{
+								Enabled: true,
+							},
+						},
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+							Ingress: IngressSpec{
+								Type: "ingress",
+							},
+						},
+					},
+					Tenants: &TenantsSpec{
+						Mode: ModeStatic,
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid route, feature gateway disabled",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					ReplicationFactor: 3,
+					Template: TempoTemplateSpec{
+						QueryFrontend: TempoQueryFrontendSpec{
+							JaegerQuery: JaegerQuerySpec{
+								Enabled: true,
+							},
+						},
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+							Ingress: IngressSpec{
+								Type: "route",
+							},
+						},
+					},
+					Tenants: &TenantsSpec{
+						Mode: ModeStatic,
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec").Child("template").Child("gateway").Child("ingress").Child("type"),
+					IngressType("route"),
+					"please enable the featureGates.openshift.openshiftRoute feature gate to use Routes",
+				),
+			},
+		},
+		{
+			name: "invalid configuration, enable two ingesss",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					ReplicationFactor: 3,
+					Template: TempoTemplateSpec{
+						QueryFrontend: TempoQueryFrontendSpec{
+							JaegerQuery: JaegerQuerySpec{
+								Enabled: true,
+								Ingress: IngressSpec{
+									Type: "ingress",
+								},
+							},
+						},
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+							Ingress: IngressSpec{
+								Type: "ingress",
+							},
+						},
+					},
+					Tenants: &TenantsSpec{
+						Mode: ModeStatic,
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					path,
+					true,
+					"cannot enable gateway and jaeger query ingress at the same time, please use the Jaeger UI from the gateway",
+				),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validator := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+			errs := validator.validateGateway(test.input)
+			assert.Equal(t, test.expected, errs)
+		})
+	}
+}
+
+func TestValidateGatewayAutoscaling(t *testing.T) {
+	path := field.NewPath("spec", "template", "gateway")
+	tt := []struct {
+		name     string
+		input    TempoGatewaySpec
+		expected field.ErrorList
+	}{
+		{
+			name:  "autoscaling disabled",
+			input: TempoGatewaySpec{Enabled: true},
+		},
+		{
+			name: "valid autoscaling",
+			input: TempoGatewaySpec{
+				Enabled:     true,
+				Autoscaling: &AutoscalingSpec{MinReplicas: pointer.Int32(2), MaxReplicas: 5},
+			},
+		},
+		{
+			name: "replicas and autoscaling",
+			input: TempoGatewaySpec{
+				Enabled:            true,
+				TempoComponentSpec: TempoComponentSpec{Replicas: pointer.Int32(3)},
+				Autoscaling:        &AutoscalingSpec{MaxReplicas: 5},
+			},
+			expected: field.ErrorList{field.Invalid(
+				path.Child("component", "replicas"),
+				int32(3),
+				"cannot set the replicas of the gateway if autoscaling is enabled",
+			)},
+		},
+		{
+			name: "minReplicas greater than maxReplicas",
+			input: TempoGatewaySpec{
+				Enabled:     true,
+				Autoscaling: &AutoscalingSpec{MinReplicas: pointer.Int32(6), MaxReplicas: 5},
+			},
+			expected: field.ErrorList{field.Invalid(
+				path.Child("autoscaling", "minReplicas"),
+				int32(6),
+				"must be less than or equal to maxReplicas",
+			)},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			tempo := TempoStack{Spec: TempoStackSpec{Template: TempoTemplateSpec{Gateway: tc.input}}}
+			assert.Equal(t, tc.expected, v.validateGatewayAutoscaling(tempo))
+		})
+	}
+}
+
+func TestValidateGatewayRateLimits(t *testing.T) {
+	path := field.NewPath("spec", "template", "gateway", "rateLimits")
+	tenants := &TenantsSpec{
+		Mode: ModeStatic,
+		Authentication: []AuthenticationSpec{
+			{TenantName: "dev", TenantID: "1610b0c3-c509-4592-a256-a1871353dbfa"},
+		},
+	}
+	tt := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name:  "no rate limits",
+			input: TempoStack{},
+		},
+		{
+			name: "valid rate limit",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: tenants,
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled:    true,
+							RateLimits: []GatewayRateLimitSpec{{Tenant: "dev", Endpoint: "/api/traces/v1/dev/.*", Limit: 10}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "gateway disabled",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: tenants,
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							RateLimits: []GatewayRateLimitSpec{{Tenant: "dev", Limit: 10}},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(
+				path,
+				[]GatewayRateLimitSpec{{Tenant: "dev", Limit: 10}},
+				"please enable the gateway to use rate limits",
+			)},
+		},
+		{
+			name: "invalid rate limit",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: tenants,
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled:    true,
+							RateLimits: []GatewayRateLimitSpec{{Tenant: "prod", Endpoint: "(", Limit: 0}},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Index(0).Child("tenant"), "prod", "tenant is not defined in spec.tenants.authentication"),
+				field.Invalid(path.Index(0).Child("endpoint"), "(", "invalid regular expression: error parsing regexp: missing closing ): `(`"),
+				field.Invalid(path.Index(0).Child("limit"), int32(0), "must be greater than 0"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateGatewayRateLimits(tc.input))
+		})
+	}
+}
+
+func TestValidateGatewayAuditLog(t *testing.T) {
+	auditLog := &GatewayAuditLogSpec{Output: GatewayAuditLogOutputStdout}
+	tt := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name:  "no audit log",
+			input: TempoStack{},
+		},
+		{
+			name: "gateway enabled",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{Enabled: true, AuditLog: auditLog},
+					},
+				},
+			},
+		},
+		{
+			name: "gateway disabled",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{AuditLog: auditLog},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec", "template", "gateway", "auditLog"),
+				auditLog,
+				"please enable the gateway to use audit logs",
+			)},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateGatewayAuditLog(tc.input))
+		})
+	}
+}
+
+func TestValidateGatewayMTLS(t *testing.T) {
+	path := field.NewPath("spec", "tenants", "authentication").Index(0).Child("mTLS")
+	mtls := &MTLSSpec{CA: "dev-ca"}
+	tt := []struct {
+		name     string
+		input    TempoStack
+		gates    v1alpha1.FeatureGates
+		expected field.ErrorList
+	}{
+		{
+			name:  "no tenants",
+			input: TempoStack{},
+		},
+		{
+			name: "valid",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode:           ModeStatic,
+						Authentication: []AuthenticationSpec{{TenantName: "dev", MTLS: mtls}},
+					},
+				},
+			},
+			gates: v1alpha1.FeatureGates{HTTPEncryption: true},
+		},
+		{
+			name: "openshift mode",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode:           ModeOpenShift,
+						Authentication: []AuthenticationSpec{{TenantName: "dev", MTLS: mtls}},
+					},
+				},
+			},
+			gates: v1alpha1.FeatureGates{HTTPEncryption: true},
+			expected: field.ErrorList{
+				field.Invalid(path, mtls, "client certificate authentication is only supported in static mode"),
+			},
+		},
+		{
+			name: "oidc and mTLS",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode:           ModeStatic,
+						Authentication: []AuthenticationSpec{{TenantName: "dev", OIDC: &OIDCSpec{}, MTLS: mtls}},
+					},
+				},
+			},
+			gates: v1alpha1.FeatureGates{HTTPEncryption: true},
+			expected: field.ErrorList{
+				field.Invalid(path, mtls, "only one of oidc or mTLS can be set"),
+			},
+		},
+		{
+			name: "missing CA",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode:           ModeStatic,
+						Authentication: []AuthenticationSpec{{TenantName: "dev", MTLS: &MTLSSpec{}}},
+					},
+				},
+			},
+			gates: v1alpha1.FeatureGates{HTTPEncryption: true},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("caName"), "", "please specify the name of the CA ConfigMap"),
+			},
+		},
+		{
+			name: "http encryption disabled",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode:           ModeStatic,
+						Authentication: []AuthenticationSpec{{TenantName: "dev", MTLS: mtls}},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, mtls, "please enable the featureGates.httpEncryption feature gate to use client certificate authentication"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: v1alpha1.ProjectConfig{Gates: tc.gates}}
+			assert.Equal(t, tc.expected, v.validateGatewayMTLS(tc.input))
+		})
+	}
+}
+
+func TestValidateJaegerQueryAuthentication(t *testing.T) {
+	path := field.NewPath("spec", "template", "queryFrontend", "jaegerQuery", "authentication")
+	authentication := &JaegerQueryAuthenticationSpec{Enabled: true}
+	jaegerQuery := func(ingressType IngressType, termination TLSRouteTerminationType) JaegerQuerySpec {
+		return JaegerQuerySpec{
+			Enabled: true,
+			Ingress: IngressSpec{
+				Type:  ingressType,
+				Route: RouteSpec{Termination: termination},
+			},
+			Authentication: authentication,
+		}
+	}
+	gates := v1alpha1.FeatureGates{OpenShift: v1alpha1.OpenShiftFeatureGates{OpenShiftRoute: true}}
+
+	tt := []struct {
+		name     string
+		input    TempoStack
+		gates    v1alpha1.FeatureGates
+		expected field.ErrorList
+	}{
+		{
+			name:  "authentication disabled",
+			input: TempoStack{},
+		},
+		{
+			name: "valid",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Images: v1alpha1.ImagesSpec{OauthProxy: "oauth-proxy:latest"},
+					Template: TempoTemplateSpec{
+						QueryFrontend: TempoQueryFrontendSpec{
+							JaegerQuery: jaegerQuery(IngressTypeRoute, TLSRouteTerminationTypeEdge),
+						},
+					},
+				},
+			},
+			gates: gates,
+		},
+		{
+			name: "gateway enabled",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Images: v1alpha1.ImagesSpec{OauthProxy: "oauth-proxy:latest"},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{Enabled: true},
+						QueryFrontend: TempoQueryFrontendSpec{
+							JaegerQuery: jaegerQuery(IngressTypeRoute, TLSRouteTerminationTypeEdge),
+						},
+					},
+				},
+			},
+			gates: gates,
+			expected: field.ErrorList{field.Invalid(path, authentication,
+				"cannot enable authentication of the Jaeger Query UI if the gateway is enabled, the gateway authenticates the users")},
+		},
+		{
+			name: "route feature gate disabled",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Images: v1alpha1.ImagesSpec{OauthProxy: "oauth-proxy:latest"},
+					Template: TempoTemplateSpec{
+						QueryFrontend: TempoQueryFrontendSpec{
+							JaegerQuery: jaegerQuery(IngressTypeRoute, TLSRouteTerminationTypeEdge),
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(path, authentication,
+				"please enable the featureGates.openshift.openshiftRoute feature gate to use authentication")},
+		},
+		{
+			name: "ingress instead of route",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Images: v1alpha1.ImagesSpec{OauthProxy: "oauth-proxy:latest"},
+					Template: TempoTemplateSpec{
+						QueryFrontend: TempoQueryFrontendSpec{
+							JaegerQuery: jaegerQuery(IngressTypeIngress, ""),
+						},
+					},
+				},
+			},
+			gates: gates,
+			expected: field.ErrorList{field.Invalid(path, authentication,
+				"authentication requires a Route for the Jaeger Query UI")},
+		},
+		{
+			name: "passthrough termination",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Images: v1alpha1.ImagesSpec{OauthProxy: "oauth-proxy:latest"},
+					Template: TempoTemplateSpec{
+						QueryFrontend: TempoQueryFrontendSpec{
+							JaegerQuery: jaegerQuery(IngressTypeRoute, TLSRouteTerminationTypePassthrough),
+						},
+					},
+				},
+			},
+			gates: gates,
+			expected: field.ErrorList{field.Invalid(path, authentication,
+				"authentication requires the edge TLS termination of the Route")},
+		},
+		{
+			name: "missing image",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						QueryFrontend: TempoQueryFrontendSpec{
+							JaegerQuery: jaegerQuery(IngressTypeRoute, TLSRouteTerminationTypeEdge),
+						},
+					},
+				},
+			},
+			gates: gates,
+			expected: field.ErrorList{field.Invalid(path, authentication,
+				"please specify an oauthProxy image in the CR or in the operator configuration")},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: v1alpha1.ProjectConfig{Gates: tc.gates}}
+			assert.Equal(t, tc.expected, v.validateJaegerQueryAuthentication(tc.input))
+		})
+	}
+}
+
+func TestValidateGatewayAccessReviewCache(t *testing.T) {
+	path := field.NewPath("spec", "template", "gateway", "accessReviewCache")
+	cache := &GatewayAccessReviewCacheSpec{TTL: metav1.Duration{Duration: 5 * time.Minute}, SizeMB: 128}
+	tempoStack := func(mode ModeType, cache *GatewayAccessReviewCacheSpec, image string) TempoStack {
+		return TempoStack{
+			Spec: TempoStackSpec{
+				Images:  v1alpha1.ImagesSpec{Memcached: image},
+				Tenants: &TenantsSpec{Mode: mode},
+				Template: TempoTemplateSpec{
+					Gateway: TempoGatewaySpec{
+						Enabled:           true,
+						AccessReviewCache: cache,
+					},
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name:  "no cache",
+			input: TempoStack{},
+		},
+		{
+			name:  "valid",
+			input: tempoStack(ModeOpenShift, cache, "memcached:latest"),
+		},
+		{
+			name:  "static mode",
+			input: tempoStack(ModeStatic, cache, "memcached:latest"),
+			expected: field.ErrorList{field.Invalid(path, cache,
+				"the access review cache is only supported by the gateway in openshift mode")},
+		},
+		{
+			name:  "ttl too short",
+			input: tempoStack(ModeOpenShift, &GatewayAccessReviewCacheSpec{TTL: metav1.Duration{Duration: time.Millisecond}}, "memcached:latest"),
+			expected: field.ErrorList{field.Invalid(path.Child("ttl"), "1ms",
+				"must be at least 1s")},
+		},
+		{
+			name:  "missing image",
+			input: tempoStack(ModeOpenShift, cache, ""),
+			expected: field.ErrorList{field.Invalid(path, cache,
+				"please specify a memcached image in the CR or in the operator configuration")},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateGatewayAccessReviewCache(tc.input))
+		})
+	}
+}
+
+func TestValidateGatewayRBAC(t *testing.T) {
+	path := field.NewPath("spec", "template", "gateway", "rbac", "enabled")
+	tempoStack := func(gateway bool, mode ModeType) TempoStack {
+		return TempoStack{
+			Spec: TempoStackSpec{
+				Images:  v1alpha1.ImagesSpec{TempoGateway: "quay.io/observatorium/api:query-rbac"},
+				Tenants: &TenantsSpec{Mode: mode},
+				Template: TempoTemplateSpec{
+					Gateway: TempoGatewaySpec{
+						Enabled: gateway,
+						RBAC:    RBACSpec{Enabled: true},
+					},
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name:  "disabled",
+			input: TempoStack{},
+		},
+		{
+			name:  "valid",
+			input: tempoStack(true, ModeOpenShift),
+		},
+		{
+			name:  "static mode",
+			input: tempoStack(true, ModeStatic),
+			expected: field.ErrorList{field.Invalid(path, true,
+				"query RBAC is only supported by the gateway in openshift mode")},
+		},
+		{
+			name:  "gateway disabled",
+			input: tempoStack(false, ModeOpenShift),
+			expected: field.ErrorList{field.Invalid(path, true,
+				"query RBAC is only supported by the gateway in openshift mode")},
+		},
+		{
+			name: "default gateway image",
+			input: func() TempoStack {
+				tempo := tempoStack(true, ModeOpenShift)
+				tempo.Spec.Images.TempoGateway = ""
+				return tempo
+			}(),
+			expected: field.ErrorList{field.Invalid(field.NewPath("spec", "images", "tempoGateway"), "",
+				"query RBAC requires a gateway image built on 2023-11-01 or later, the gateway image quay.io/observatorium/api:main-2023-09-13-14e06c6 was built on 2023-09-13")},
+		},
+		{
+			name: "old gateway image",
+			input: func() TempoStack {
+				tempo := tempoStack(true, ModeOpenShift)
+				tempo.Spec.Images.TempoGateway = "quay.io/observatorium/api:main-2023-10-31-0123abc"
+				return tempo
+			}(),
+			expected: field.ErrorList{field.Invalid(field.NewPath("spec", "images", "tempoGateway"), "quay.io/observatorium/api:main-2023-10-31-0123abc",
+				"query RBAC requires a gateway image built on 2023-11-01 or later, the gateway image quay.io/observatorium/api:main-2023-10-31-0123abc was built on 2023-10-31")},
+		},
+		{
+			name: "recent gateway image",
+			input: func() TempoStack {
+				tempo := tempoStack(true, ModeOpenShift)
+				tempo.Spec.Images.TempoGateway = "quay.io/observatorium/api:main-2024-02-20-9f8e7d6@sha256:0123"
+				return tempo
+			}(),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: v1alpha1.ProjectConfig{
+				DefaultImages: v1alpha1.ImagesSpec{TempoGateway: "quay.io/observatorium/api:main-2023-09-13-14e06c6"},
+			}}
+			assert.Equal(t, tc.expected, v.validateGatewayRBAC(tc.input))
+		})
+	}
+}
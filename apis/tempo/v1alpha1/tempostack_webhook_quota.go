@@ -0,0 +1,351 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ComponentPods are the pods of a TempoStack component.
+//
+// +kubebuilder:object:generate=false
+type ComponentPods struct {
+	// Component is the name of the component, e.g. query-frontend.
+	Component string
+	// Replicas is the number of pods, or the minimum number of pods if the component is autoscaled.
+	Replicas int32
+	// Containers are the resources of the containers of a pod.
+	Containers []corev1.ResourceRequirements
+	// PriorityClassName is the name of the PriorityClass of the pods, which is matched by the scopes of the ResourceQuotas.
+	PriorityClassName string
+}
+
+// ComponentPodsFunc returns the pods of the components of a TempoStack.
+//
+// +kubebuilder:object:generate=false
+type ComponentPodsFunc func(tempo TempoStack) []ComponentPods
+
+// componentResourcesPaths are the fields configuring the resources of the components, by component name.
+var componentResourcesPaths = map[string]*field.Path{
+	"distributor":       field.NewPath("spec", "template", "distributor", "resources"),
+	"ingester":          field.NewPath("spec", "template", "ingester", "resources"),
+	"compactor":         field.NewPath("spec", "template", "compactor", "resources"),
+	"querier":           field.NewPath("spec", "template", "querier", "resources"),
+	"query-frontend":    field.NewPath("spec", "template", "queryFrontend", "component", "resources"),
+	"gateway":           field.NewPath("spec", "template", "gateway", "component", "resources"),
+	"metrics-generator": field.NewPath("spec", "template", "metricsGenerator", "resources"),
+}
+
+// quotaUsage is the usage of the ResourceQuotas of a namespace by the pods of a TempoStack.
+type quotaUsage struct {
+	quotas []corev1.ResourceQuota
+	// components are the usages of the pods of each component, in the order of the components.
+	components []componentQuotaUsage
+	// errs are the violations of the LimitRanges of the namespace.
+	errs field.ErrorList
+}
+
+// componentQuotaUsage is the usage of the ResourceQuotas by the pods of a component.
+type componentQuotaUsage struct {
+	priorityClassName string
+	// bestEffort is true if no container of the pods sets a request or limit.
+	bestEffort bool
+	// used are the resources of all pods of the component, by ResourceQuota resource name.
+	used corev1.ResourceList
+	// unset are the requests and limits which are not set by the containers, by ResourceQuota resource name.
+	unset map[corev1.ResourceName]*field.Path
+}
+
+// matchesScopes returns true if the pods of the component are tracked by the ResourceQuota,
+// i.e. if they match all scopes and all expressions of the scope selector of the ResourceQuota.
+func (c componentQuotaUsage) matchesScopes(quota corev1.ResourceQuota) bool {
+	for _, scope := range quota.Spec.Scopes {
+		if !c.matchesScope(corev1.ScopedResourceSelectorRequirement{ScopeName: scope, Operator: corev1.ScopeSelectorOpExists}) {
+			return false
+		}
+	}
+	if quota.Spec.ScopeSelector != nil {
+		for _, requirement := range quota.Spec.ScopeSelector.MatchExpressions {
+			if !c.matchesScope(requirement) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchesScope evaluates a scope like the quota admission of Kubernetes. The pods of the components are never
+// terminating, because they do not set activeDeadlineSeconds. Other scopes, e.g. CrossNamespacePodAffinity,
+// depend on settings which are not known here and never match.
+func (c componentQuotaUsage) matchesScope(requirement corev1.ScopedResourceSelectorRequirement) bool {
+	switch requirement.ScopeName {
+	case corev1.ResourceQuotaScopeTerminating:
+		return false
+	case corev1.ResourceQuotaScopeNotTerminating:
+		return true
+	case corev1.ResourceQuotaScopeBestEffort:
+		return c.bestEffort
+	case corev1.ResourceQuotaScopeNotBestEffort:
+		return !c.bestEffort
+	case corev1.ResourceQuotaScopePriorityClass:
+		found := false
+		for _, value := range requirement.Values {
+			if value == c.priorityClassName {
+				found = true
+			}
+		}
+		switch requirement.Operator {
+		case corev1.ScopeSelectorOpExists:
+			return c.priorityClassName != ""
+		case corev1.ScopeSelectorOpIn:
+			return found
+		case corev1.ScopeSelectorOpNotIn:
+			return !found
+		}
+	}
+	return false
+}
+
+// usedBy returns the resources of the pods tracked by the ResourceQuota, and the requests and limits
+// which are not set by the containers of these pods.
+func (u *quotaUsage) usedBy(quota corev1.ResourceQuota) (corev1.ResourceList, map[corev1.ResourceName]*field.Path) {
+	used := corev1.ResourceList{}
+	unset := map[corev1.ResourceName]*field.Path{}
+	for _, component := range u.components {
+		if !component.matchesScopes(quota) {
+			continue
+		}
+		for name, quantity := range component.used {
+			addQuantity(used, name, quantity, 1)
+		}
+		for name, path := range component.unset {
+			if _, found := unset[name]; !found {
+				unset[name] = path
+			}
+		}
+	}
+	return used, unset
+}
+
+// quotaUsage computes the resources of the pods of the TempoStack with the defaults of the LimitRanges of the namespace applied,
+// and validates them against the LimitRanges. It returns nil if the namespace has neither ResourceQuotas nor LimitRanges.
+func (v *validator) quotaUsage(ctx context.Context, tempo TempoStack) *quotaUsage {
+	if v.componentPods == nil {
+		return nil
+	}
+	// The ResourceQuotas and LimitRanges are not validated if they cannot be listed.
+	limitRanges := &corev1.LimitRangeList{}
+	if err := v.client.List(ctx, limitRanges, client.InNamespace(tempo.Namespace)); err != nil {
+		return nil
+	}
+	quotas := &corev1.ResourceQuotaList{}
+	if err := v.client.List(ctx, quotas, client.InNamespace(tempo.Namespace)); err != nil {
+		return nil
+	}
+	if len(limitRanges.Items) == 0 && len(quotas.Items) == 0 {
+		return nil
+	}
+
+	usage := &quotaUsage{quotas: quotas.Items}
+	reported := map[string]bool{}
+	addErr := func(err *field.Error) {
+		// The containers of a pod share the resources of the component, report each violation once.
+		if !reported[err.Error()] {
+			reported[err.Error()] = true
+			usage.errs = append(usage.errs, err)
+		}
+	}
+
+	for _, component := range v.componentPods(tempo) {
+		path := componentResourcesPaths[component.Component]
+		componentUsage := componentQuotaUsage{
+			priorityClassName: component.PriorityClassName,
+			bestEffort:        true,
+			used:              corev1.ResourceList{},
+			unset:             map[corev1.ResourceName]*field.Path{},
+		}
+		markUnset := func(path *field.Path, names ...corev1.ResourceName) {
+			for _, name := range names {
+				if _, found := componentUsage.unset[name]; !found {
+					componentUsage.unset[name] = path
+				}
+			}
+		}
+		podRequests, podLimits := corev1.ResourceList{}, corev1.ResourceList{}
+		for _, container := range component.Containers {
+			resources := applyLimitRangeDefaults(container, limitRanges.Items)
+			if len(resources.Requests) > 0 || len(resources.Limits) > 0 {
+				componentUsage.bestEffort = false
+			}
+			for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+				request, hasRequest := resources.Requests[name]
+				limit, hasLimit := resources.Limits[name]
+				if hasRequest {
+					addQuantity(podRequests, name, request, 1)
+				} else {
+					markUnset(path.Child("requests").Key(string(name)), name, corev1.ResourceName("requests."+name))
+				}
+				if hasLimit {
+					addQuantity(podLimits, name, limit, 1)
+				} else {
+					markUnset(path.Child("limits").Key(string(name)), corev1.ResourceName("limits."+name))
+				}
+
+				for _, limitRange := range limitRanges.Items {
+					for _, item := range limitRange.Spec.Limits {
+						if item.Type != corev1.LimitTypeContainer {
+							continue
+						}
+						if min, ok := item.Min[name]; ok && hasRequest && request.Cmp(min) < 0 {
+							addErr(field.Invalid(path.Child("requests").Key(string(name)), request.String(),
+								fmt.Sprintf("the request is less than the minimum of the LimitRange %s (%s)", limitRange.Name, min.String())))
+						}
+						if max, ok := item.Max[name]; ok && !hasLimit {
+							addErr(field.Required(path.Child("limits").Key(string(name)),
+								fmt.Sprintf("the LimitRange %s requires a limit", limitRange.Name)))
+						} else if ok && limit.Cmp(max) > 0 {
+							addErr(field.Invalid(path.Child("limits").Key(string(name)), limit.String(),
+								fmt.Sprintf("the limit exceeds the maximum of the LimitRange %s (%s)", limitRange.Name, max.String())))
+						}
+					}
+				}
+			}
+		}
+
+		for _, limitRange := range limitRanges.Items {
+			for _, item := range limitRange.Spec.Limits {
+				if item.Type != corev1.LimitTypePod {
+					continue
+				}
+				for name, max := range item.Max {
+					if limit, ok := podLimits[name]; ok && limit.Cmp(max) > 0 {
+						addErr(field.Invalid(path.Child("limits").Key(string(name)), limit.String(),
+							fmt.Sprintf("the limits of the containers of a pod exceed the maximum of the LimitRange %s (%s)", limitRange.Name, max.String())))
+					}
+				}
+			}
+		}
+
+		for name, request := range podRequests {
+			addQuantity(componentUsage.used, name, request, component.Replicas)
+			addQuantity(componentUsage.used, corev1.ResourceName("requests."+name), request, component.Replicas)
+		}
+		for name, limit := range podLimits {
+			addQuantity(componentUsage.used, corev1.ResourceName("limits."+name), limit, component.Replicas)
+		}
+		componentUsage.used[corev1.ResourcePods] = *resource.NewQuantity(int64(component.Replicas), resource.DecimalSI)
+		usage.components = append(usage.components, componentUsage)
+	}
+	return usage
+}
+
+// applyLimitRangeDefaults applies the default requests and limits of the LimitRanges to the resources of a container.
+// Like in Kubernetes, an unset request defaults to the limit of the container, then to the default request
+// of the LimitRange, and then to the default limit of the LimitRange.
+func applyLimitRangeDefaults(container corev1.ResourceRequirements, limitRanges []corev1.LimitRange) corev1.ResourceRequirements {
+	resources := container.DeepCopy()
+	setDefaults := func(list *corev1.ResourceList, defaults corev1.ResourceList) {
+		for name, quantity := range defaults {
+			if _, ok := (*list)[name]; ok {
+				continue
+			}
+			if *list == nil {
+				*list = corev1.ResourceList{}
+			}
+			(*list)[name] = quantity.DeepCopy()
+		}
+	}
+
+	setDefaults(&resources.Requests, resources.Limits)
+	for _, limitRange := range limitRanges {
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type == corev1.LimitTypeContainer {
+				setDefaults(&resources.Limits, item.Default)
+				setDefaults(&resources.Requests, item.DefaultRequest)
+				setDefaults(&resources.Requests, item.Default)
+			}
+		}
+	}
+	return *resources
+}
+
+// addQuantity adds n times the quantity to the resource of the list.
+func addQuantity(list corev1.ResourceList, name corev1.ResourceName, quantity resource.Quantity, n int32) {
+	total := list[name]
+	total.Add(*resource.NewMilliQuantity(quantity.MilliValue()*int64(n), quantity.Format))
+	list[name] = total
+}
+
+// sortedResourceNames returns the resource names of the list in alphabetical order.
+func sortedResourceNames(list corev1.ResourceList) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(list))
+	for name := range list {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// validateResourceQuotas rejects TempoStacks whose pods violate the LimitRanges of the namespace
+// or can never fit into the ResourceQuotas of the namespace, which would leave the TempoStack partially deployed.
+func (v *validator) validateResourceQuotas(ctx context.Context, tempo TempoStack) field.ErrorList {
+	usage := v.quotaUsage(ctx, tempo)
+	if usage == nil {
+		return nil
+	}
+
+	errs := usage.errs
+	for _, quota := range usage.quotas {
+		scoped, unset := usage.usedBy(quota)
+		for _, name := range sortedResourceNames(quota.Spec.Hard) {
+			if path, found := unset[name]; found {
+				errs = append(errs, field.Required(path,
+					fmt.Sprintf("the ResourceQuota %s requires %s to be set for all containers", quota.Name, name)))
+				continue
+			}
+			used, found := scoped[name]
+			hard := quota.Spec.Hard[name]
+			if found && used.Cmp(hard) > 0 {
+				errs = append(errs, field.Forbidden(field.NewPath("spec", "resources"),
+					fmt.Sprintf("the pods of the TempoStack require %s %s, which exceeds the ResourceQuota %s (%s)",
+						used.String(), name, quota.Name, hard.String())))
+			}
+		}
+	}
+	return errs
+}
+
+// resourceQuotaWarnings warns if the pods of the TempoStack exceed the ResourceQuotas of the namespace
+// which are already used by other workloads, i.e. some pods might not be created until resources are released.
+func (v *validator) resourceQuotaWarnings(ctx context.Context, tempo TempoStack) admission.Warnings {
+	if !v.ctrlConfig.WatchesNamespace(tempo.Namespace) {
+		return nil
+	}
+	usage := v.quotaUsage(ctx, tempo)
+	if usage == nil {
+		return nil
+	}
+
+	var warnings admission.Warnings
+	for _, quota := range usage.quotas {
+		scoped, _ := usage.usedBy(quota)
+		for _, name := range sortedResourceNames(quota.Spec.Hard) {
+			used, found := scoped[name]
+			hard := quota.Spec.Hard[name]
+			remaining := hard.DeepCopy()
+			remaining.Sub(quota.Status.Used[name])
+			if found && used.Cmp(hard) <= 0 && used.Cmp(remaining) > 0 {
+				warnings = append(warnings, fmt.Sprintf(
+					"the pods of the TempoStack require %s %s, but only %s are left in the ResourceQuota %s, some pods might not be created",
+					used.String(), name, remaining.String(), quota.Name))
+			}
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,242 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/grafana/tempo-operator/apis/config/v1alpha1"
+)
+
+type resourceQuotaListFake struct {
+	client.Client
+	limitRanges []corev1.LimitRange
+	quotas      []corev1.ResourceQuota
+}
+
+func (f *resourceQuotaListFake) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	switch l := list.(type) {
+	case *corev1.LimitRangeList:
+		l.Items = f.limitRanges
+	case *corev1.ResourceQuotaList:
+		l.Items = f.quotas
+	}
+	return nil
+}
+
+func TestValidateResourceQuotas(t *testing.T) {
+	path := field.NewPath("spec", "template", "ingester", "resources")
+	componentPods := func(tempo TempoStack) []ComponentPods {
+		return []ComponentPods{
+			{Component: "ingester", Replicas: 2, PriorityClassName: "high", Containers: []corev1.ResourceRequirements{{
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			}}},
+			{Component: "query-frontend", Replicas: 1, Containers: []corev1.ResourceRequirements{{}, {}}},
+		}
+	}
+	quota := func(hard corev1.ResourceList) []corev1.ResourceQuota {
+		return []corev1.ResourceQuota{{
+			ObjectMeta: metav1.ObjectMeta{Name: "quota"},
+			Spec:       corev1.ResourceQuotaSpec{Hard: hard},
+		}}
+	}
+	scopedQuota := func(hard corev1.ResourceList, scopes []corev1.ResourceQuotaScope, selector *corev1.ScopeSelector) []corev1.ResourceQuota {
+		return []corev1.ResourceQuota{{
+			ObjectMeta: metav1.ObjectMeta{Name: "quota"},
+			Spec:       corev1.ResourceQuotaSpec{Hard: hard, Scopes: scopes, ScopeSelector: selector},
+		}}
+	}
+	priorityClassSelector := func(operator corev1.ScopeSelectorOperator, values ...string) *corev1.ScopeSelector {
+		return &corev1.ScopeSelector{MatchExpressions: []corev1.ScopedResourceSelectorRequirement{{
+			ScopeName: corev1.ResourceQuotaScopePriorityClass,
+			Operator:  operator,
+			Values:    values,
+		}}}
+	}
+	limitRange := func(item corev1.LimitRangeItem) []corev1.LimitRange {
+		return []corev1.LimitRange{{
+			ObjectMeta: metav1.ObjectMeta{Name: "limits"},
+			Spec:       corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{item}},
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		limitRanges []corev1.LimitRange
+		quotas      []corev1.ResourceQuota
+		expected    field.ErrorList
+	}{
+		{
+			name: "no quotas",
+		},
+		{
+			name: "within quota",
+			limitRanges: limitRange(corev1.LimitRangeItem{
+				Type:           corev1.LimitTypeContainer,
+				DefaultRequest: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			}),
+			quotas: quota(corev1.ResourceList{
+				corev1.ResourceRequestsCPU: resource.MustParse("2"),
+				corev1.ResourcePods:        resource.MustParse("3"),
+			}),
+		},
+		{
+			name: "quota exceeded",
+			limitRanges: limitRange(corev1.LimitRangeItem{
+				Type:           corev1.LimitTypeContainer,
+				DefaultRequest: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			}),
+			quotas: quota(corev1.ResourceList{
+				corev1.ResourceRequestsCPU: resource.MustParse("1"),
+				corev1.ResourcePods:        resource.MustParse("2"),
+			}),
+			expected: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "resources"),
+					"the pods of the TempoStack require 3 pods, which exceeds the ResourceQuota quota (2)"),
+				field.Forbidden(field.NewPath("spec", "resources"),
+					"the pods of the TempoStack require 1200m requests.cpu, which exceeds the ResourceQuota quota (1)"),
+			},
+		},
+		{
+			name: "quota requires unset resource",
+			quotas: quota(corev1.ResourceList{
+				corev1.ResourceLimitsMemory: resource.MustParse("10Gi"),
+			}),
+			expected: field.ErrorList{
+				field.Required(field.NewPath("spec", "template", "queryFrontend", "component", "resources", "limits").Key("memory"),
+					"the ResourceQuota quota requires limits.memory to be set for all containers"),
+			},
+		},
+		{
+			name: "quota of other priority class",
+			quotas: scopedQuota(corev1.ResourceList{corev1.ResourcePods: resource.MustParse("1")}, nil,
+				priorityClassSelector(corev1.ScopeSelectorOpIn, "low")),
+		},
+		{
+			name: "quota of priority class exceeded",
+			quotas: scopedQuota(corev1.ResourceList{corev1.ResourcePods: resource.MustParse("1")}, nil,
+				priorityClassSelector(corev1.ScopeSelectorOpIn, "high")),
+			expected: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "resources"),
+					"the pods of the TempoStack require 2 pods, which exceeds the ResourceQuota quota (1)"),
+			},
+		},
+		{
+			name: "quota without priority class exceeded",
+			quotas: scopedQuota(corev1.ResourceList{corev1.ResourcePods: resource.MustParse("0")}, nil,
+				priorityClassSelector(corev1.ScopeSelectorOpNotIn, "high")),
+			expected: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "resources"),
+					"the pods of the TempoStack require 1 pods, which exceeds the ResourceQuota quota (0)"),
+			},
+		},
+		{
+			name:   "terminating quota",
+			quotas: scopedQuota(corev1.ResourceList{corev1.ResourcePods: resource.MustParse("1")}, []corev1.ResourceQuotaScope{corev1.ResourceQuotaScopeTerminating}, nil),
+		},
+		{
+			name:   "best effort quota exceeded",
+			quotas: scopedQuota(corev1.ResourceList{corev1.ResourcePods: resource.MustParse("0")}, []corev1.ResourceQuotaScope{corev1.ResourceQuotaScopeBestEffort}, nil),
+			expected: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "resources"),
+					"the pods of the TempoStack require 1 pods, which exceeds the ResourceQuota quota (0)"),
+			},
+		},
+		{
+			name: "not best effort quota ignores unset resources of best effort pods",
+			quotas: scopedQuota(corev1.ResourceList{corev1.ResourceLimitsMemory: resource.MustParse("10Gi")},
+				[]corev1.ResourceQuotaScope{corev1.ResourceQuotaScopeNotBestEffort, corev1.ResourceQuotaScopeNotTerminating}, nil),
+		},
+		{
+			name: "container limits out of range",
+			limitRanges: limitRange(corev1.LimitRangeItem{
+				Type: corev1.LimitTypeContainer,
+				Min:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				Max:  corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+			}),
+			expected: field.ErrorList{
+				field.Invalid(path.Child("requests").Key("cpu"), "500m", "the request is less than the minimum of the LimitRange limits (1)"),
+				field.Invalid(path.Child("limits").Key("memory"), "1Gi", "the limit exceeds the maximum of the LimitRange limits (512Mi)"),
+				field.Required(field.NewPath("spec", "template", "queryFrontend", "component", "resources", "limits").Key("memory"),
+					"the LimitRange limits requires a limit"),
+			},
+		},
+		{
+			name: "default limits within range",
+			limitRanges: limitRange(corev1.LimitRangeItem{
+				Type:    corev1.LimitTypeContainer,
+				Default: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("400m")},
+				Max:     corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			}),
+		},
+		{
+			name: "pod limits exceed maximum",
+			limitRanges: []corev1.LimitRange{{
+				ObjectMeta: metav1.ObjectMeta{Name: "limits"},
+				Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{
+					{Type: corev1.LimitTypeContainer, Default: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("400m")}},
+					{Type: corev1.LimitTypePod, Max: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("600m")}},
+				}},
+			}},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "template", "queryFrontend", "component", "resources", "limits").Key("cpu"), "800m",
+					"the limits of the containers of a pod exceed the maximum of the LimitRange limits (600m)"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{
+				client:        &resourceQuotaListFake{limitRanges: tc.limitRanges, quotas: tc.quotas},
+				componentPods: componentPods,
+			}
+			assert.Equal(t, tc.expected, v.validateResourceQuotas(context.Background(), TempoStack{}))
+		})
+	}
+}
+
+func TestResourceQuotaWarnings(t *testing.T) {
+	componentPods := func(tempo TempoStack) []ComponentPods {
+		return []ComponentPods{{Component: "ingester", Replicas: 2, Containers: []corev1.ResourceRequirements{{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		}}}}
+	}
+	quota := corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota"},
+		Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("4Gi")}},
+		Status:     corev1.ResourceQuotaStatus{Used: corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("3Gi")}},
+	}
+
+	v := &validator{
+		client:        &resourceQuotaListFake{quotas: []corev1.ResourceQuota{quota}},
+		componentPods: componentPods,
+	}
+	assert.Equal(t, admission.Warnings{
+		"the pods of the TempoStack require 2Gi requests.memory, but only 1Gi are left in the ResourceQuota quota, some pods might not be created",
+	}, v.resourceQuotaWarnings(context.Background(), TempoStack{}))
+
+	v.ctrlConfig = v1alpha1.ProjectConfig{WatchNamespaces: []string{"other"}}
+	assert.Empty(t, v.resourceQuotaWarnings(context.Background(), TempoStack{}))
+
+	// The pods of the TempoStack do not have a PriorityClass.
+	quota.Spec.ScopeSelector = &corev1.ScopeSelector{MatchExpressions: []corev1.ScopedResourceSelectorRequirement{{
+		ScopeName: corev1.ResourceQuotaScopePriorityClass,
+		Operator:  corev1.ScopeSelectorOpExists,
+	}}}
+	v = &validator{
+		client:        &resourceQuotaListFake{quotas: []corev1.ResourceQuota{quota}},
+		componentPods: componentPods,
+	}
+	assert.Empty(t, v.resourceQuotaWarnings(context.Background(), TempoStack{}))
+}
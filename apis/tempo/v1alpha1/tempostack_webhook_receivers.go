@@ -0,0 +1,203 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func (v *validator) validateReceiversTLS(tempo TempoStack) field.ErrorList {
+	receiversTLS := tempo.Spec.Template.Distributor.TLS
+	path := field.NewPath("spec").Child("template").Child("distributor").Child("tls")
+	if !receiversTLS.Enabled {
+		if receiversTLS.CA != "" {
+			return field.ErrorList{field.Invalid(
+				path.Child("caName"),
+				receiversTLS.CA,
+				"please enable TLS on the distributor receivers to verify client certificates",
+			)}
+		}
+		if receiversTLS.OTLPGRPC != nil || receiversTLS.OTLPHTTP != nil || receiversTLS.Jaeger != nil || receiversTLS.Zipkin != nil {
+			return field.ErrorList{field.Invalid(
+				path.Child("enabled"),
+				receiversTLS.Enabled,
+				"please enable TLS on the distributor receivers to override the TLS settings of a receiver",
+			)}
+		}
+		return nil
+	}
+
+	if tempo.Spec.Template.Gateway.Enabled {
+		return field.ErrorList{field.Invalid(
+			path.Child("enabled"),
+			receiversTLS.Enabled,
+			"cannot enable TLS on the distributor receivers if the gateway is enabled",
+		)}
+	}
+
+	builtInCerts := v.ctrlConfig.Gates.BuiltInCertManagement.Enabled && tempo.Spec.SPIFFE == nil
+	certsManaged := builtInCerts || v.ctrlConfig.Gates.OpenShift.ServingCertsAllServices || tempo.Spec.CertManager != nil
+	if receiversTLS.CertName == "" && !certsManaged {
+		return field.ErrorList{field.Invalid(
+			path.Child("certName"),
+			receiversTLS.CertName,
+			"please specify a certificate secret or enable the featureGates.builtInCertManagement or featureGates.openshift.servingCertsAllServices feature gate or spec.certManager",
+		)}
+	}
+	return nil
+}
+
+func (v *validator) validateKafkaReceiver(tempo TempoStack) field.ErrorList {
+	kafka := tempo.Spec.Template.Distributor.Receivers.Kafka
+	if kafka == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("template").Child("distributor").Child("receivers").Child("kafka")
+	var errs field.ErrorList
+	if tempo.Spec.Tenants != nil {
+		errs = append(errs, field.Invalid(path, kafka,
+			"the kafka receiver is not supported with multitenancy"))
+	}
+	if len(kafka.Brokers) == 0 {
+		errs = append(errs, field.Required(path.Child("brokers"), "at least one broker must be defined"))
+	}
+	if kafka.SASL != nil && kafka.SASL.Secret == "" {
+		errs = append(errs, field.Required(path.Child("sasl").Child("secret"), "the SASL credentials secret must be defined"))
+	}
+	return errs
+}
+
+func (v *validator) validateZipkinReceiver(tempo TempoStack) field.ErrorList {
+	zipkin := tempo.Spec.Template.Distributor.Receivers.Zipkin
+	if zipkin == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("template").Child("distributor").Child("receivers").Child("zipkin")
+	ingressPath := path.Child("ingress")
+	var errs field.ErrorList
+	if zipkin.Enabled && tempo.Spec.Template.Gateway.Enabled {
+		errs = append(errs, field.Invalid(path.Child("enabled"), zipkin.Enabled,
+			"the zipkin receiver is not supported with the gateway"))
+	}
+	if zipkin.Ingress.Type != IngressTypeNone && !zipkin.Enabled {
+		errs = append(errs, field.Invalid(ingressPath.Child("type"), zipkin.Ingress.Type,
+			"the zipkin receiver must be enabled to be exposed"))
+	}
+	if zipkin.Ingress.Type == IngressTypeRoute && !v.ctrlConfig.Gates.OpenShift.OpenShiftRoute {
+		errs = append(errs, field.Invalid(ingressPath.Child("type"), zipkin.Ingress.Type,
+			"please enable the featureGates.openshift.openshiftRoute feature gate to use Routes"))
+	}
+	if len(zipkin.Ingress.AdditionalHosts) > 0 {
+		errs = append(errs, field.Forbidden(ingressPath.Child("additionalHosts"),
+			"additional hosts are not supported by the zipkin receiver"))
+	}
+	if zipkin.Ingress.Route.CertificateSecret != "" || zipkin.Ingress.Route.DestinationCAConfigMap != "" {
+		errs = append(errs, field.Forbidden(ingressPath.Child("route"),
+			"custom route certificates are not supported by the zipkin receiver"))
+	}
+	return errs
+}
+
+func (v *validator) validateOTLPIngresses(tempo TempoStack) field.ErrorList {
+	otlp := tempo.Spec.Template.Distributor.Receivers.OTLP
+	if otlp == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("template").Child("distributor").Child("receivers").Child("otlp")
+	var errs field.ErrorList
+	for _, ingress := range []struct {
+		path *field.Path
+		spec IngressSpec
+		grpc bool
+	}{
+		{path.Child("grpcIngress"), otlp.GRPCIngress, true},
+		{path.Child("httpIngress"), otlp.HTTPIngress, false},
+	} {
+		if ingress.spec.Type == IngressTypeNone {
+			continue
+		}
+		if tempo.Spec.Template.Gateway.Enabled {
+			errs = append(errs, field.Invalid(ingress.path.Child("type"), ingress.spec.Type,
+				"the OTLP receiver cannot be exposed if the gateway is enabled, please expose the gateway instead"))
+			continue
+		}
+		if ingress.spec.Type != IngressTypeRoute {
+			continue
+		}
+
+		if !v.ctrlConfig.Gates.OpenShift.OpenShiftRoute {
+			errs = append(errs, field.Invalid(ingress.path.Child("type"), ingress.spec.Type,
+				"please enable the featureGates.openshift.openshiftRoute feature gate to use Routes"))
+		}
+		if len(ingress.spec.AdditionalHosts) > 0 {
+			errs = append(errs, field.Forbidden(ingress.path.Child("additionalHosts"),
+				"additional hosts are only supported by an Ingress of the OTLP receiver"))
+		}
+		if ingress.spec.Route.CertificateSecret != "" || ingress.spec.Route.DestinationCAConfigMap != "" {
+			errs = append(errs, field.Forbidden(ingress.path.Child("route"),
+				"custom route certificates are not supported by the OTLP receiver"))
+		}
+		if !ingress.grpc {
+			continue
+		}
+		if !tempo.Spec.Template.Distributor.TLS.Enabled {
+			errs = append(errs, field.Invalid(ingress.path.Child("type"), ingress.spec.Type,
+				"a Route of the OTLP gRPC receiver requires TLS on the distributor receivers"))
+		}
+		if termination := ingress.spec.Route.Termination; termination == TLSRouteTerminationTypeEdge || termination == TLSRouteTerminationTypeInsecure {
+			errs = append(errs, field.Invalid(ingress.path.Child("route").Child("termination"), termination,
+				"a Route of the OTLP gRPC receiver supports the passthrough and reencrypt termination only"))
+		}
+	}
+	return errs
+}
+
+func (v *validator) validateJaegerReceiver(tempo TempoStack) field.ErrorList {
+	jaeger := tempo.Spec.Template.Distributor.Receivers.Jaeger
+	if jaeger == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("template").Child("distributor").Child("receivers").Child("jaeger")
+	protocols := []struct {
+		name        string
+		spec        *ReceiverProtocolSpec
+		defaultPort int32
+		udp         bool
+	}{
+		{"thriftHttp", jaeger.ThriftHTTP, 14268, false},
+		{"thriftBinary", jaeger.ThriftBinary, 6832, true},
+		{"thriftCompact", jaeger.ThriftCompact, 6831, true},
+		{"grpc", jaeger.GRPC, 14250, false},
+	}
+
+	var errs field.ErrorList
+	ports := map[string]bool{}
+	for _, protocol := range protocols {
+		if protocol.spec != nil && !protocol.spec.Enabled {
+			continue
+		}
+		if tempo.Spec.Template.Gateway.Enabled {
+			errs = append(errs, field.Invalid(path.Child(protocol.name), protocol.spec,
+				"the jaeger receiver is not supported with the gateway, please disable the protocol"))
+			continue
+		}
+
+		port := protocol.defaultPort
+		if protocol.spec != nil && protocol.spec.Port != 0 {
+			port = protocol.spec.Port
+		}
+		key := fmt.Sprintf("%d/tcp", port)
+		if protocol.udp {
+			key = fmt.Sprintf("%d/udp", port)
+		}
+		if ports[key] {
+			errs = append(errs, field.Duplicate(path.Child(protocol.name).Child("port"), port))
+		}
+		ports[key] = true
+	}
+	return errs
+}
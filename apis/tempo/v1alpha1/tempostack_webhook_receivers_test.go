@@ -0,0 +1,480 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/grafana/tempo-operator/apis/config/v1alpha1"
+)
+
+func TestValidateReceiversTLS(t *testing.T) {
+	path := field.NewPath("spec", "template", "distributor", "tls")
+	tt := []struct {
+		name       string
+		ctrlConfig v1alpha1.ProjectConfig
+		input      TempoStack
+		expected   field.ErrorList
+	}{
+		{
+			name:  "TLS disabled",
+			input: TempoStack{},
+		},
+		{
+			name: "custom certificate",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						Distributor: TempoDistributorSpec{
+							TLS: ReceiversTLSSpec{Enabled: true, CertName: "receiver-cert"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "certificate issued by the OpenShift service-ca operator",
+			ctrlConfig: v1alpha1.ProjectConfig{
+				Gates: v1alpha1.FeatureGates{
+					OpenShift: v1alpha1.OpenShiftFeatureGates{ServingCertsAllServices: true},
+				},
+			},
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						Distributor: TempoDistributorSpec{
+							TLS: ReceiversTLSSpec{Enabled: true},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "certificate managed by the operator",
+			ctrlConfig: v1alpha1.ProjectConfig{
+				Gates: v1alpha1.FeatureGates{
+					BuiltInCertManagement: v1alpha1.BuiltInCertManagement{Enabled: true},
+				},
+			},
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						Distributor: TempoDistributorSpec{
+							TLS: ReceiversTLSSpec{Enabled: true},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "missing certificate",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						Distributor: TempoDistributorSpec{
+							TLS: ReceiversTLSSpec{Enabled: true},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					path.Child("certName"),
+					"",
+					"please specify a certificate secret or enable the featureGates.builtInCertManagement or featureGates.openshift.servingCertsAllServices feature gate or spec.certManager",
+				),
+			},
+		},
+		{
+			name: "client CA without TLS",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						Distributor: TempoDistributorSpec{
+							TLS: ReceiversTLSSpec{CA: "client-ca"},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					path.Child("caName"),
+					"client-ca",
+					"please enable TLS on the distributor receivers to verify client certificates",
+				),
+			},
+		},
+		{
+			name: "receiver override without TLS",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						Distributor: TempoDistributorSpec{
+							TLS: ReceiversTLSSpec{OTLPHTTP: &ReceiverTLSOverrideSpec{MinTLSVersion: "VersionTLS12"}},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					path.Child("enabled"),
+					false,
+					"please enable TLS on the distributor receivers to override the TLS settings of a receiver",
+				),
+			},
+		},
+		{
+			name: "gateway enabled",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						Distributor: TempoDistributorSpec{
+							TLS: ReceiversTLSSpec{Enabled: true, CertName: "receiver-cert"},
+						},
+						Gateway: TempoGatewaySpec{Enabled: true},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					path.Child("enabled"),
+					true,
+					"cannot enable TLS on the distributor receivers if the gateway is enabled",
+				),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: tc.ctrlConfig}
+			assert.Equal(t, tc.expected, v.validateReceiversTLS(tc.input))
+		})
+	}
+}
+
+func TestValidateKafkaReceiver(t *testing.T) {
+	path := field.NewPath("spec", "template", "distributor", "receivers", "kafka")
+	tempoStack := func(tenants *TenantsSpec, kafka *KafkaReceiverSpec) TempoStack {
+		return TempoStack{
+			Spec: TempoStackSpec{
+				Tenants: tenants,
+				Template: TempoTemplateSpec{
+					Distributor: TempoDistributorSpec{
+						Receivers: ReceiversSpec{Kafka: kafka},
+					},
+				},
+			},
+		}
+	}
+
+	multitenantKafka := &KafkaReceiverSpec{Brokers: []string{"kafka:9092"}}
+
+	tt := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name:  "disabled",
+			input: TempoStack{},
+		},
+		{
+			name: "valid",
+			input: tempoStack(nil, &KafkaReceiverSpec{
+				Brokers: []string{"kafka:9092"},
+				SASL:    &KafkaSASLSpec{Secret: "kafka-credentials"},
+			}),
+		},
+		{
+			name:  "multitenancy",
+			input: tempoStack(&TenantsSpec{Mode: ModeStatic}, multitenantKafka),
+			expected: field.ErrorList{field.Invalid(path, multitenantKafka,
+				"the kafka receiver is not supported with multitenancy")},
+		},
+		{
+			name:  "missing brokers",
+			input: tempoStack(nil, &KafkaReceiverSpec{}),
+			expected: field.ErrorList{field.Required(path.Child("brokers"),
+				"at least one broker must be defined")},
+		},
+		{
+			name: "missing SASL secret",
+			input: tempoStack(nil, &KafkaReceiverSpec{
+				Brokers: []string{"kafka:9092"},
+				SASL:    &KafkaSASLSpec{},
+			}),
+			expected: field.ErrorList{field.Required(path.Child("sasl", "secret"),
+				"the SASL credentials secret must be defined")},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateKafkaReceiver(tc.input))
+		})
+	}
+}
+
+func TestValidateZipkinReceiver(t *testing.T) {
+	path := field.NewPath("spec", "template", "distributor", "receivers", "zipkin")
+	tempoStack := func(gateway bool, zipkin *ZipkinReceiverSpec) TempoStack {
+		return TempoStack{
+			Spec: TempoStackSpec{
+				Template: TempoTemplateSpec{
+					Gateway: TempoGatewaySpec{Enabled: gateway},
+					Distributor: TempoDistributorSpec{
+						Receivers: ReceiversSpec{Zipkin: zipkin},
+					},
+				},
+			},
+		}
+	}
+	routeGate := v1alpha1.ProjectConfig{
+		Gates: v1alpha1.FeatureGates{
+			OpenShift: v1alpha1.OpenShiftFeatureGates{
+				OpenShiftRoute: true,
+			},
+		},
+	}
+
+	tt := []struct {
+		name       string
+		input      TempoStack
+		ctrlConfig v1alpha1.ProjectConfig
+		expected   field.ErrorList
+	}{
+		{
+			name:  "not configured",
+			input: tempoStack(true, nil),
+		},
+		{
+			name: "valid",
+			input: tempoStack(false, &ZipkinReceiverSpec{
+				Enabled: true,
+				Port:    9412,
+				Ingress: IngressSpec{Type: IngressTypeRoute},
+			}),
+			ctrlConfig: routeGate,
+		},
+		{
+			name:  "disabled with gateway",
+			input: tempoStack(true, &ZipkinReceiverSpec{Enabled: false}),
+		},
+		{
+			name:  "enabled with gateway",
+			input: tempoStack(true, &ZipkinReceiverSpec{Enabled: true}),
+			expected: field.ErrorList{field.Invalid(path.Child("enabled"), true,
+				"the zipkin receiver is not supported with the gateway")},
+		},
+		{
+			name:  "exposed but disabled",
+			input: tempoStack(false, &ZipkinReceiverSpec{Ingress: IngressSpec{Type: IngressTypeIngress}}),
+			expected: field.ErrorList{field.Invalid(path.Child("ingress", "type"), IngressTypeIngress,
+				"the zipkin receiver must be enabled to be exposed")},
+		},
+		{
+			name:  "route without feature gate",
+			input: tempoStack(false, &ZipkinReceiverSpec{Enabled: true, Ingress: IngressSpec{Type: IngressTypeRoute}}),
+			expected: field.ErrorList{field.Invalid(path.Child("ingress", "type"), IngressTypeRoute,
+				"please enable the featureGates.openshift.openshiftRoute feature gate to use Routes")},
+		},
+		{
+			name: "additional hosts and route certificates",
+			input: tempoStack(false, &ZipkinReceiverSpec{
+				Enabled: true,
+				Ingress: IngressSpec{
+					Type:            IngressTypeRoute,
+					AdditionalHosts: []IngressHostSpec{{Host: "zipkin.example.com"}},
+					Route:           RouteSpec{Termination: TLSRouteTerminationTypeEdge, CertificateSecret: "cert"},
+				},
+			}),
+			ctrlConfig: routeGate,
+			expected: field.ErrorList{
+				field.Forbidden(path.Child("ingress", "additionalHosts"),
+					"additional hosts are not supported by the zipkin receiver"),
+				field.Forbidden(path.Child("ingress", "route"),
+					"custom route certificates are not supported by the zipkin receiver"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: tc.ctrlConfig}
+			assert.Equal(t, tc.expected, v.validateZipkinReceiver(tc.input))
+		})
+	}
+}
+
+func TestValidateJaegerReceiver(t *testing.T) {
+	path := field.NewPath("spec", "template", "distributor", "receivers", "jaeger")
+	tempoStack := func(gateway bool, jaeger *JaegerReceiverSpec) TempoStack {
+		return TempoStack{
+			Spec: TempoStackSpec{
+				Template: TempoTemplateSpec{
+					Gateway: TempoGatewaySpec{Enabled: gateway},
+					Distributor: TempoDistributorSpec{
+						Receivers: ReceiversSpec{Jaeger: jaeger},
+					},
+				},
+			},
+		}
+	}
+	disabled := &ReceiverProtocolSpec{Enabled: false}
+
+	tt := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name:  "not configured",
+			input: tempoStack(true, nil),
+		},
+		{
+			name: "valid",
+			input: tempoStack(false, &JaegerReceiverSpec{
+				ThriftBinary: disabled,
+				GRPC:         &ReceiverProtocolSpec{Enabled: true, Port: 14251},
+			}),
+		},
+		{
+			name: "all protocols disabled with gateway",
+			input: tempoStack(true, &JaegerReceiverSpec{
+				ThriftHTTP:    disabled,
+				ThriftBinary:  disabled,
+				ThriftCompact: disabled,
+				GRPC:          disabled,
+			}),
+		},
+		{
+			name: "protocol enabled with gateway",
+			input: tempoStack(true, &JaegerReceiverSpec{
+				ThriftHTTP:    disabled,
+				ThriftBinary:  disabled,
+				ThriftCompact: disabled,
+			}),
+			expected: field.ErrorList{field.Invalid(path.Child("grpc"), (*ReceiverProtocolSpec)(nil),
+				"the jaeger receiver is not supported with the gateway, please disable the protocol")},
+		},
+		{
+			name: "port conflict",
+			input: tempoStack(false, &JaegerReceiverSpec{
+				GRPC: &ReceiverProtocolSpec{Enabled: true, Port: 14268},
+			}),
+			expected: field.ErrorList{field.Duplicate(path.Child("grpc", "port"), int32(14268))},
+		},
+		{
+			name: "same port for TCP and UDP",
+			input: tempoStack(false, &JaegerReceiverSpec{
+				ThriftCompact: &ReceiverProtocolSpec{Enabled: true, Port: 14268},
+			}),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateJaegerReceiver(tc.input))
+		})
+	}
+}
+
+func TestValidateOTLPIngresses(t *testing.T) {
+	path := field.NewPath("spec", "template", "distributor", "receivers", "otlp")
+	tempoStack := func(gateway bool, tlsEnabled bool, otlp *OTLPReceiverSpec) TempoStack {
+		return TempoStack{
+			Spec: TempoStackSpec{
+				Template: TempoTemplateSpec{
+					Gateway: TempoGatewaySpec{Enabled: gateway},
+					Distributor: TempoDistributorSpec{
+						TLS:       ReceiversTLSSpec{Enabled: tlsEnabled},
+						Receivers: ReceiversSpec{OTLP: otlp},
+					},
+				},
+			},
+		}
+	}
+	routeGate := v1alpha1.ProjectConfig{
+		Gates: v1alpha1.FeatureGates{
+			OpenShift: v1alpha1.OpenShiftFeatureGates{
+				OpenShiftRoute: true,
+			},
+		},
+	}
+
+	tt := []struct {
+		name       string
+		input      TempoStack
+		ctrlConfig v1alpha1.ProjectConfig
+		expected   field.ErrorList
+	}{
+		{
+			name:  "not configured",
+			input: tempoStack(true, false, nil),
+		},
+		{
+			name: "ingresses without gateway",
+			input: tempoStack(false, false, &OTLPReceiverSpec{
+				GRPCIngress: IngressSpec{Type: IngressTypeIngress, AdditionalHosts: []IngressHostSpec{{Host: "otlp.example.com"}}},
+				HTTPIngress: IngressSpec{Type: IngressTypeIngress},
+			}),
+		},
+		{
+			name: "ingress with gateway",
+			input: tempoStack(true, false, &OTLPReceiverSpec{
+				HTTPIngress: IngressSpec{Type: IngressTypeIngress},
+			}),
+			expected: field.ErrorList{field.Invalid(path.Child("httpIngress", "type"), IngressTypeIngress,
+				"the OTLP receiver cannot be exposed if the gateway is enabled, please expose the gateway instead")},
+		},
+		{
+			name: "gRPC route with TLS",
+			input: tempoStack(false, true, &OTLPReceiverSpec{
+				GRPCIngress: IngressSpec{Type: IngressTypeRoute, Route: RouteSpec{Termination: TLSRouteTerminationTypePassthrough}},
+			}),
+			ctrlConfig: routeGate,
+		},
+		{
+			name: "gRPC route without TLS and with edge termination",
+			input: tempoStack(false, false, &OTLPReceiverSpec{
+				GRPCIngress: IngressSpec{Type: IngressTypeRoute, Route: RouteSpec{Termination: TLSRouteTerminationTypeEdge}},
+			}),
+			ctrlConfig: routeGate,
+			expected: field.ErrorList{
+				field.Invalid(path.Child("grpcIngress", "type"), IngressTypeRoute,
+					"a Route of the OTLP gRPC receiver requires TLS on the distributor receivers"),
+				field.Invalid(path.Child("grpcIngress", "route", "termination"), TLSRouteTerminationTypeEdge,
+					"a Route of the OTLP gRPC receiver supports the passthrough and reencrypt termination only"),
+			},
+		},
+		{
+			name: "HTTP route without feature gate, with additional hosts and certificates",
+			input: tempoStack(false, false, &OTLPReceiverSpec{
+				HTTPIngress: IngressSpec{
+					Type:            IngressTypeRoute,
+					AdditionalHosts: []IngressHostSpec{{Host: "otlp.example.com"}},
+					Route:           RouteSpec{Termination: TLSRouteTerminationTypeEdge, CertificateSecret: "cert"},
+				},
+			}),
+			expected: field.ErrorList{
+				field.Invalid(path.Child("httpIngress", "type"), IngressTypeRoute,
+					"please enable the featureGates.openshift.openshiftRoute feature gate to use Routes"),
+				field.Forbidden(path.Child("httpIngress", "additionalHosts"),
+					"additional hosts are only supported by an Ingress of the OTLP receiver"),
+				field.Forbidden(path.Child("httpIngress", "route"),
+					"custom route certificates are not supported by the OTLP receiver"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: tc.ctrlConfig}
+			assert.Equal(t, tc.expected, v.validateOTLPIngresses(tc.input))
+		})
+	}
+}
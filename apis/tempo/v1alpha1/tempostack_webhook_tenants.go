@@ -0,0 +1,237 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func (v *validator) validateTenantConfigs(tempo TempoStack) field.ErrorList {
+	if err := ValidateTenantConfigs(tempo); err != nil {
+		return field.ErrorList{
+			field.Invalid(
+				field.NewPath("spec").Child("template").Child("tenants"),
+				tempo.Spec.Template.Gateway.Enabled,
+				err.Error(),
+			)}
+	}
+	return nil
+}
+
+// validateStaticAuthorization validates the references between the roles, role bindings and tenants of the static mode.
+func validateStaticAuthorization(tenants *TenantsSpec) error {
+	tenantNames := map[string]bool{}
+	for _, auth := range tenants.Authentication {
+		tenantNames[auth.TenantName] = true
+	}
+
+	roleNames := map[string]bool{}
+	for i, role := range tenants.Authorization.Roles {
+		if roleNames[role.Name] {
+			return fmt.Errorf("spec.tenants.authorization.roles[%d]: duplicate role name %q", i, role.Name)
+		}
+		roleNames[role.Name] = true
+
+		for _, tenant := range role.Tenants {
+			if !tenantNames[tenant] {
+				return fmt.Errorf("spec.tenants.authorization.roles[%d]: tenant %q is not defined in spec.tenants.authentication", i, tenant)
+			}
+		}
+	}
+
+	for i, binding := range tenants.Authorization.RoleBindings {
+		for _, role := range binding.Roles {
+			if !roleNames[role] {
+				return fmt.Errorf("spec.tenants.authorization.roleBindings[%d]: role %q is not defined in spec.tenants.authorization.roles", i, role)
+			}
+		}
+	}
+	return nil
+}
+
+func hasOIDCGroupMappings(tenants *TenantsSpec) bool {
+	for _, auth := range tenants.Authentication {
+		if auth.OIDC != nil && len(auth.OIDC.GroupMappings) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func validateOIDCGroupMappings(tenants *TenantsSpec) error {
+	for i, auth := range tenants.Authentication {
+		if auth.OIDC == nil || len(auth.OIDC.GroupMappings) == 0 {
+			continue
+		}
+		if auth.OIDC.GroupClaim == "" {
+			return fmt.Errorf("spec.tenants.authentication[%d].oidc.groupClaim is required to map groups to permissions", i)
+		}
+		groups := map[string]bool{}
+		for _, mapping := range auth.OIDC.GroupMappings {
+			if groups[mapping.Group] {
+				return fmt.Errorf("spec.tenants.authentication[%d].oidc.groupMappings: duplicate group %q", i, mapping.Group)
+			}
+			groups[mapping.Group] = true
+		}
+	}
+	return nil
+}
+
+// ValidateTenantConfigs validates the tenants mode specification.
+func ValidateTenantConfigs(tempo TempoStack) error {
+	if tempo.Spec.Tenants == nil {
+		return nil
+	}
+
+	if err := validateTenantsMode(tempo); err != nil {
+		return err
+	}
+	if tempo.Spec.Template.Gateway.Enabled {
+		return validateTenantStoragePrefixes(tempo.Spec.Tenants)
+	}
+	return nil
+}
+
+func validateTenantsMode(tempo TempoStack) error {
+	tenants := tempo.Spec.Tenants
+	if tenants.Mode != ModeOpenShift {
+		for _, auth := range tenants.Authentication {
+			if len(auth.Audiences) > 0 {
+				return fmt.Errorf("spec.tenants.authentication.audiences should only be defined in openshift mode")
+			}
+		}
+	}
+
+	if tenants.Mode == ModeStatic {
+		// If the static mode is combined with the gateway, we will need the following fields
+		// otherwise this will just enable tempo multitenancy without the gateway
+		if tempo.Spec.Template.Gateway.Enabled {
+			if tenants.Authentication == nil {
+				return fmt.Errorf("spec.tenants.authentication is required in static mode")
+			}
+
+			if err := validateOIDCGroupMappings(tenants); err != nil {
+				return err
+			}
+
+			// The roles and role bindings of the groups are generated from the group mappings.
+			groupMappings := hasOIDCGroupMappings(tenants)
+			if tenants.Authorization == nil && groupMappings {
+				return nil
+			}
+
+			if tenants.Authorization == nil {
+				return fmt.Errorf("spec.tenants.authorization is required in static mode")
+			}
+
+			if policy := tenants.Authorization.Policy; policy != nil {
+				if (policy.ConfigMap == "") == (policy.Secret == "") {
+					return fmt.Errorf("exactly one of spec.tenants.authorization.policy.configMap or spec.tenants.authorization.policy.secret must be set")
+				}
+				if policy.Query == "" {
+					return fmt.Errorf("spec.tenants.authorization.policy.query is required")
+				}
+				return nil
+			}
+
+			if tenants.Authorization.Roles == nil && !groupMappings {
+				return fmt.Errorf("spec.tenants.authorization.roles is required in static mode")
+			}
+
+			if tenants.Authorization.RoleBindings == nil && !groupMappings {
+				return fmt.Errorf("spec.tenants.authorization.roleBindings is required in static mode")
+			}
+
+			if err := validateStaticAuthorization(tenants); err != nil {
+				return err
+			}
+		}
+	} else if tenants.Mode == ModeOpenShift {
+		if !tempo.Spec.Template.Gateway.Enabled {
+			return fmt.Errorf("openshift mode requires gateway enabled")
+		}
+		if tenants.Authorization != nil {
+			return fmt.Errorf("spec.tenants.authorization should not be defined in openshift mode")
+		}
+		for _, auth := range tenants.Authentication {
+			if auth.OIDC != nil {
+				return fmt.Errorf("spec.tenants.authentication.oidc should not be defined in openshift mode")
+			}
+		}
+	} else if tenants.Mode == ModeTrustedHeader {
+		if err := validateTrustedHeader(tempo); err != nil {
+			return err
+		}
+	}
+
+	if tenants.TrustedHeader != nil && tenants.Mode != ModeTrustedHeader {
+		return fmt.Errorf("spec.tenants.trustedHeader should only be defined in trustedHeader mode")
+	}
+	return nil
+}
+
+// validateTenantStoragePrefixes validates that every tenant of the gateway is mapped to its own prefix in the object storage.
+// The gateway sends the tenant ID in the X-Scope-OrgID header, and Tempo stores the blocks of a tenant below this prefix.
+func validateTenantStoragePrefixes(tenants *TenantsSpec) error {
+	prefixes := map[string]string{}
+	for i, auth := range tenants.Authentication {
+		if auth.TenantID == "" {
+			return fmt.Errorf("spec.tenants.authentication[%d].tenantId is required, it is the object storage prefix of tenant %q", i, auth.TenantName)
+		}
+		if !isValidTenantID(auth.TenantID) {
+			return fmt.Errorf("spec.tenants.authentication[%d].tenantId %q is not a valid object storage prefix: "+
+				"it must consist of at most %d alphanumeric characters or !-_.*'()", i, auth.TenantID, maxTenantIDLength)
+		}
+		if other, found := prefixes[auth.TenantID]; found {
+			return fmt.Errorf("spec.tenants.authentication[%d].tenantId %q is already the object storage prefix of tenant %q", i, auth.TenantID, other)
+		}
+		prefixes[auth.TenantID] = auth.TenantName
+	}
+	return nil
+}
+
+// maxTenantIDLength is the maximum length of a tenant ID accepted by Tempo.
+const maxTenantIDLength = 150
+
+// isValidTenantID returns true if Tempo accepts the tenant ID, i.e. if it is safe to use as an object storage prefix.
+func isValidTenantID(id string) bool {
+	if len(id) > maxTenantIDLength || id == "." || id == ".." {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("!-_.*'()", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func validateTrustedHeader(tempo TempoStack) error {
+	tenants := tempo.Spec.Tenants
+	if tempo.Spec.Template.Gateway.Enabled {
+		return fmt.Errorf("trustedHeader mode requires the gateway to be disabled")
+	}
+	if tenants.Authorization != nil {
+		return fmt.Errorf("spec.tenants.authorization should not be defined in trustedHeader mode")
+	}
+	if tenants.TrustedHeader == nil {
+		return nil
+	}
+
+	for i, cidr := range tenants.TrustedHeader.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("spec.tenants.trustedHeader.allowedCIDRs[%d]: invalid CIDR %q", i, cidr)
+		}
+	}
+
+	tls := tempo.Spec.Template.Distributor.TLS
+	if tenants.TrustedHeader.RequireClientCertificate && (!tls.Enabled || tls.CA == "") {
+		return fmt.Errorf("spec.tenants.trustedHeader.requireClientCertificate requires spec.template.distributor.tls with a caName")
+	}
+	return nil
+}
@@ -0,0 +1,566 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTenantConfigs(t *testing.T) {
+	tt := []struct {
+		name    string
+		input   TempoStack
+		wantErr error
+	}{
+		{
+			name: "missing tenants",
+			input: TempoStack{
+				Spec: TempoStackSpec{},
+			},
+		},
+		{
+			name: "another mode",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{},
+				},
+			},
+		},
+		{
+			name: "static missing authentication",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeStatic,
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authentication is required in static mode"),
+		},
+		{
+			name: "static missing authorization",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode:           ModeStatic,
+						Authentication: []AuthenticationSpec{},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authorization is required in static mode"),
+		},
+		{
+			name: "static missing roles",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode:           ModeStatic,
+						Authorization:  &AuthorizationSpec{},
+						Authentication: []AuthenticationSpec{},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authorization.roles is required in static mode"),
+		},
+		{
+			name: "static missing role bindings",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeStatic,
+						Authorization: &AuthorizationSpec{
+							Roles: []RoleSpec{},
+						},
+						Authentication: []AuthenticationSpec{},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authorization.roleBindings is required in static mode"),
+		},
+		{
+			name: "static valid authorization",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeStatic,
+						Authentication: []AuthenticationSpec{
+							{TenantName: "dev", TenantID: "1610b0c3-c509-4592-a256-a1871353dbfa"},
+						},
+						Authorization: &AuthorizationSpec{
+							Roles: []RoleSpec{
+								{Name: "read", Resources: []string{"traces"}, Tenants: []string{"dev"}, Permissions: []PermissionType{Read}},
+							},
+							RoleBindings: []RoleBindingsSpec{
+								{Name: "binding", Roles: []string{"read"}, Subjects: []Subject{{Name: "admin", Kind: User}}},
+							},
+						},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "static duplicate role",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeStatic,
+						Authentication: []AuthenticationSpec{
+							{TenantName: "dev", TenantID: "1610b0c3-c509-4592-a256-a1871353dbfa"},
+						},
+						Authorization: &AuthorizationSpec{
+							Roles: []RoleSpec{
+								{Name: "read", Resources: []string{"traces"}, Tenants: []string{"dev"}, Permissions: []PermissionType{Read}},
+								{Name: "read", Resources: []string{"traces"}, Tenants: []string{"dev"}, Permissions: []PermissionType{Read}},
+							},
+							RoleBindings: []RoleBindingsSpec{
+								{Name: "binding", Roles: []string{"read"}, Subjects: []Subject{{Name: "admin", Kind: User}}},
+							},
+						},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authorization.roles[1]: duplicate role name \"read\""),
+		},
+		{
+			name: "static role references undefined tenant",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeStatic,
+						Authentication: []AuthenticationSpec{
+							{TenantName: "dev", TenantID: "1610b0c3-c509-4592-a256-a1871353dbfa"},
+						},
+						Authorization: &AuthorizationSpec{
+							Roles: []RoleSpec{
+								{Name: "read", Resources: []string{"traces"}, Tenants: []string{"prod"}, Permissions: []PermissionType{Read}},
+							},
+							RoleBindings: []RoleBindingsSpec{
+								{Name: "binding", Roles: []string{"read"}, Subjects: []Subject{{Name: "admin", Kind: User}}},
+							},
+						},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authorization.roles[0]: tenant \"prod\" is not defined in spec.tenants.authentication"),
+		},
+		{
+			name: "static role binding references undefined role",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeStatic,
+						Authentication: []AuthenticationSpec{
+							{TenantName: "dev", TenantID: "1610b0c3-c509-4592-a256-a1871353dbfa"},
+						},
+						Authorization: &AuthorizationSpec{
+							Roles: []RoleSpec{
+								{Name: "read", Resources: []string{"traces"}, Tenants: []string{"dev"}, Permissions: []PermissionType{Read}},
+							},
+							RoleBindings: []RoleBindingsSpec{
+								{Name: "binding", Roles: []string{"write"}, Subjects: []Subject{{Name: "admin", Kind: User}}},
+							},
+						},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authorization.roleBindings[0]: role \"write\" is not defined in spec.tenants.authorization.roles"),
+		},
+		{
+			name: "static custom policy",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode:           ModeStatic,
+						Authentication: []AuthenticationSpec{},
+						Authorization: &AuthorizationSpec{
+							Policy: &OPAPolicySpec{ConfigMap: "policy", Key: "policy.rego", Query: "data.tempostack.allow"},
+						},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "static custom policy without reference",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode:           ModeStatic,
+						Authentication: []AuthenticationSpec{},
+						Authorization: &AuthorizationSpec{
+							Policy: &OPAPolicySpec{Query: "data.tempostack.allow"},
+						},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("exactly one of spec.tenants.authorization.policy.configMap or spec.tenants.authorization.policy.secret must be set"),
+		},
+		{
+			name: "static custom policy without query",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode:           ModeStatic,
+						Authentication: []AuthenticationSpec{},
+						Authorization: &AuthorizationSpec{
+							Policy: &OPAPolicySpec{Secret: "policy", Key: "policy.rego"},
+						},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authorization.policy.query is required"),
+		},
+		{
+			name: "static OIDC group mappings without authorization",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeStatic,
+						Authentication: []AuthenticationSpec{
+							{
+								TenantName: "dev",
+								TenantID:   "1610b0c3-c509-4592-a256-a1871353dbfa",
+								OIDC: &OIDCSpec{
+									GroupClaim: "groups",
+									GroupMappings: []OIDCGroupMappingSpec{
+										{Group: "readers", Permissions: []PermissionType{Read}},
+									},
+								},
+							},
+						},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "static OIDC group mappings without group claim",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeStatic,
+						Authentication: []AuthenticationSpec{
+							{
+								TenantName: "dev",
+								TenantID:   "1610b0c3-c509-4592-a256-a1871353dbfa",
+								OIDC: &OIDCSpec{
+									GroupClaim: "",
+									GroupMappings: []OIDCGroupMappingSpec{
+										{Group: "readers", Permissions: []PermissionType{Read}},
+									},
+								},
+							},
+						},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authentication[0].oidc.groupClaim is required to map groups to permissions"),
+		},
+		{
+			name: "static OIDC group mappings with duplicate group",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeStatic,
+						Authentication: []AuthenticationSpec{
+							{
+								TenantName: "dev",
+								TenantID:   "1610b0c3-c509-4592-a256-a1871353dbfa",
+								OIDC: &OIDCSpec{
+									GroupClaim: "groups",
+									GroupMappings: []OIDCGroupMappingSpec{
+										{Group: "readers", Permissions: []PermissionType{Read}},
+										{Group: "readers", Permissions: []PermissionType{Read}},
+									},
+								},
+							},
+						},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authentication[0].oidc.groupMappings: duplicate group \"readers\""),
+		},
+		{
+			name: "openshift: RBAC should not be defined",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeOpenShift,
+						Authorization: &AuthorizationSpec{
+							Roles: []RoleSpec{},
+						},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authorization should not be defined in openshift mode"),
+		},
+		{
+			name: "openshift: OIDC should not be defined",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeOpenShift,
+						Authentication: []AuthenticationSpec{
+							{
+								OIDC: &OIDCSpec{},
+							},
+						},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authentication.oidc should not be defined in openshift mode"),
+		},
+		{
+			name: "trustedHeader: valid",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeTrustedHeader,
+						TrustedHeader: &TrustedHeaderSpec{
+							AllowedCIDRs:             []string{"10.0.0.0/8"},
+							RequireClientCertificate: true,
+						},
+					},
+					Template: TempoTemplateSpec{
+						Distributor: TempoDistributorSpec{
+							TLS: ReceiversTLSSpec{Enabled: true, CA: "ca"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "trustedHeader: gateway enabled",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeTrustedHeader,
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("trustedHeader mode requires the gateway to be disabled"),
+		},
+		{
+			name: "trustedHeader: invalid CIDR",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode:          ModeTrustedHeader,
+						TrustedHeader: &TrustedHeaderSpec{AllowedCIDRs: []string{"10.0.0.0/8", "10.0.0.1"}},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.trustedHeader.allowedCIDRs[1]: invalid CIDR \"10.0.0.1\""),
+		},
+		{
+			name: "trustedHeader: client certificate without CA",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode:          ModeTrustedHeader,
+						TrustedHeader: &TrustedHeaderSpec{RequireClientCertificate: true},
+					},
+					Template: TempoTemplateSpec{
+						Distributor: TempoDistributorSpec{
+							TLS: ReceiversTLSSpec{Enabled: true},
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.trustedHeader.requireClientCertificate requires spec.template.distributor.tls with a caName"),
+		},
+		{
+			name: "trustedHeader defined in another mode",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode:          ModeStatic,
+						TrustedHeader: &TrustedHeaderSpec{},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.trustedHeader should only be defined in trustedHeader mode"),
+		},
+		{
+			name: "token audiences in openshift mode",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeOpenShift,
+						Authentication: []AuthenticationSpec{
+							{TenantName: "dev", TenantID: "abcd1", Audiences: []string{"tempo-gateway"}},
+						},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{Enabled: true},
+					},
+				},
+			},
+		},
+		{
+			name: "token audiences in static mode",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeStatic,
+						Authentication: []AuthenticationSpec{
+							{TenantName: "dev", TenantID: "abcd1", Audiences: []string{"tempo-gateway"}},
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authentication.audiences should only be defined in openshift mode"),
+		},
+		{
+			name: "gateway: tenant without storage prefix",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeOpenShift,
+						Authentication: []AuthenticationSpec{
+							{TenantName: "dev", TenantID: "abcd1"},
+							{TenantName: "prod"},
+						},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authentication[1].tenantId is required, it is the object storage prefix of tenant \"prod\""),
+		},
+		{
+			name: "gateway: invalid storage prefix",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeOpenShift,
+						Authentication: []AuthenticationSpec{
+							{TenantName: "dev", TenantID: "team-a/dev"},
+						},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authentication[0].tenantId \"team-a/dev\" is not a valid object storage prefix: " +
+				"it must consist of at most 150 alphanumeric characters or !-_.*'()"),
+		},
+		{
+			name: "gateway: shared storage prefix",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Mode: ModeOpenShift,
+						Authentication: []AuthenticationSpec{
+							{TenantName: "dev", TenantID: "abcd1"},
+							{TenantName: "prod", TenantID: "abcd1"},
+						},
+					},
+					Template: TempoTemplateSpec{
+						Gateway: TempoGatewaySpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("spec.tenants.authentication[1].tenantId \"abcd1\" is already the object storage prefix of tenant \"dev\""),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateTenantConfigs(tc.input)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
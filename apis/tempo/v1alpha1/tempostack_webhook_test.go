@@ -7,14 +7,19 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
@@ -104,11 +109,15 @@ func TestDefault(t *testing.T) {
 						DefaultResultLimit: &defaultDefaultResultLimit,
 					},
 					Template: TempoTemplateSpec{
-						Distributor: TempoComponentSpec{
-							Replicas: pointer.Int32(1),
+						Distributor: TempoDistributorSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: pointer.Int32(1),
+							},
 						},
-						Ingester: TempoComponentSpec{
-							Replicas: pointer.Int32(1),
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: pointer.Int32(1),
+							},
 						},
 					},
 				},
@@ -156,11 +165,76 @@ func TestDefault(t *testing.T) {
 						DefaultResultLimit: &defaultDefaultResultLimit,
 					},
 					Template: TempoTemplateSpec{
-						Distributor: TempoComponentSpec{
-							Replicas: pointer.Int32(1),
+						Distributor: TempoDistributorSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: pointer.Int32(1),
+							},
+						},
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: pointer.Int32(1),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "distributor replicas are not defaulted if autoscaling is enabled",
+			input: &TempoStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						Distributor: TempoDistributorSpec{
+							Autoscaling: &AutoscalingSpec{MaxReplicas: 5},
+						},
+					},
+				},
+			},
+			expected: &TempoStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by":   "tempo-operator",
+						"tempo.grafana.com/distribution": "upstream",
+					},
+				},
+				Spec: TempoStackSpec{
+					ReplicationFactor: 1,
+					Images: v1alpha1.ImagesSpec{
+						Tempo:           "docker.io/grafana/tempo:x.y.z",
+						TempoQuery:      "docker.io/grafana/tempo-query:x.y.z",
+						TempoGateway:    "docker.io/observatorium/gateway:1.2.3",
+						TempoGatewayOpa: "docker.io/observatorium/opa-openshift:1.2.3",
+					},
+					ServiceAccount: "tempo-test",
+					Retention: RetentionSpec{
+						Global: RetentionConfig{
+							Traces: metav1.Duration{Duration: 48 * time.Hour},
+						},
+					},
+					StorageSize: resource.MustParse("10Gi"),
+					LimitSpec: LimitSpec{
+						Global: RateLimitSpec{
+							Query: QueryLimit{
+								MaxSearchDuration: metav1.Duration{Duration: 0},
+							},
+						},
+					},
+					SearchSpec: SearchSpec{
+						MaxDuration:        metav1.Duration{Duration: 0},
+						DefaultResultLimit: &defaultDefaultResultLimit,
+					},
+					Template: TempoTemplateSpec{
+						Distributor: TempoDistributorSpec{
+							Autoscaling: &AutoscalingSpec{MaxReplicas: 5},
 						},
-						Ingester: TempoComponentSpec{
-							Replicas: pointer.Int32(1),
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: pointer.Int32(1),
+							},
 						},
 					},
 				},
@@ -213,11 +287,15 @@ func TestDefault(t *testing.T) {
 						DefaultResultLimit: &defaultDefaultResultLimit,
 					},
 					Template: TempoTemplateSpec{
-						Distributor: TempoComponentSpec{
-							Replicas: pointer.Int32(1),
+						Distributor: TempoDistributorSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: pointer.Int32(1),
+							},
 						},
-						Ingester: TempoComponentSpec{
-							Replicas: pointer.Int32(1),
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: pointer.Int32(1),
+							},
 						},
 						QueryFrontend: TempoQueryFrontendSpec{
 							JaegerQuery: JaegerQuerySpec{
@@ -266,6 +344,38 @@ func TestValidateStorageSecret(t *testing.T) {
 			},
 		},
 	}
+	tempoGCS := TempoStack{
+		Spec: TempoStackSpec{
+			Storage: ObjectStorageSpec{
+				Secret: ObjectStorageSecretSpec{
+					Name: "testsecret",
+					Type: "gcs",
+				},
+			},
+		},
+	}
+	tempoS3CSI := TempoStack{
+		Spec: TempoStackSpec{
+			Storage: ObjectStorageSpec{
+				Secret: ObjectStorageSecretSpec{
+					Name: "testsecret",
+					Type: "s3",
+				},
+				SecretProviderClass: "tempo-storage",
+			},
+		},
+	}
+	tempoAzureCSI := TempoStack{
+		Spec: TempoStackSpec{
+			Storage: ObjectStorageSpec{
+				Secret: ObjectStorageSecretSpec{
+					Name: "testsecret",
+					Type: "azure",
+				},
+				SecretProviderClass: "tempo-storage",
+			},
+		},
+	}
 
 	tempoUnknown := TempoStack{
 		Spec: TempoStackSpec{
@@ -345,6 +455,21 @@ func TestValidateStorageSecret(t *testing.T) {
 				field.Invalid(path, tempoAzure.Spec.Storage.Secret, "storage secret must contain \"account_key\" field"),
 			},
 		},
+		{
+			name:  "invalid Azure environment",
+			tempo: tempoAzure,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"container":    []byte("container-test"),
+					"account_name": []byte("account"),
+					"account_key":  []byte("key"),
+					"environment":  []byte("AzureMoon"),
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, tempoAzure.Spec.Storage.Secret, "\"environment\" field of storage secret must be one of AzureGlobal, AzureUSGovernment or AzureChinaCloud"),
+			},
+		},
 		{
 			name:  "empty S3 secret",
 			tempo: tempoS3,
@@ -411,6 +536,85 @@ func TestValidateStorageSecret(t *testing.T) {
 			},
 			expected: nil,
 		},
+		{
+			name:  "invalid GCS endpoint",
+			tempo: tempoGCS,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"bucketname": []byte("bucket"),
+					"key.json":   []byte("{}"),
+					"endpoint":   []byte("invalid"),
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, tempoGCS.Spec.Storage.Secret, "\"endpoint\" field of storage secret must be a valid URL"),
+			},
+		},
+		{
+			name:  "valid GCS secret with custom endpoint",
+			tempo: tempoGCS,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"bucketname": []byte("bucket"),
+					"key.json":   []byte("{}"),
+					"endpoint":   []byte("http://fake-gcs-server:4443/storage/v1/"),
+				},
+			},
+			expected: nil,
+		},
+		{
+			name:  "valid S3 secret with secret provider class",
+			tempo: tempoS3CSI,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"endpoint": []byte("http://minio.minio.svc:9000"),
+					"bucket":   []byte("bucket"),
+				},
+			},
+			expected: nil,
+		},
+		{
+			name:  "secret provider class with Azure secret",
+			tempo: tempoAzureCSI,
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"container":    []byte("container-test"),
+					"account_name": []byte("account"),
+					"account_key":  []byte("key"),
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec", "storage", "secretProviderClass"),
+					"tempo-storage",
+					"secret provider classes are not supported by the azure storage type",
+				),
+			},
+		},
+		{
+			name: "s3 options with GCS secret",
+			tempo: TempoStack{
+				Spec: TempoStackSpec{
+					Storage: ObjectStorageSpec{
+						Secret: ObjectStorageSecretSpec{Name: "testsecret", Type: "gcs"},
+						S3:     &ObjectStorageS3Spec{DualStack: true},
+					},
+				},
+			},
+			input: corev1.Secret{
+				Data: map[string][]byte{
+					"bucketname": []byte("bucket"),
+					"key.json":   []byte("{}"),
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec", "storage", "s3"),
+					&ObjectStorageS3Spec{DualStack: true},
+					"the s3 options are not supported by the gcs storage type",
+				),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -436,8 +640,10 @@ func TestValidateReplicationFactor(t *testing.T) {
 				Spec: TempoStackSpec{
 					ReplicationFactor: 3,
 					Template: TempoTemplateSpec{
-						Ingester: TempoComponentSpec{
-							Replicas: pointer.Int32(2),
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: pointer.Int32(2),
+							},
 						},
 					},
 				},
@@ -450,22 +656,44 @@ func TestValidateReplicationFactor(t *testing.T) {
 				Spec: TempoStackSpec{
 					ReplicationFactor: 3,
 					Template: TempoTemplateSpec{
-						Ingester: TempoComponentSpec{
-							Replicas: pointer.Int32(3),
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: pointer.Int32(3),
+							},
 						},
 					},
 				},
 			},
 			expected: nil,
 		},
+		{
+			name: "error replication factor not positive",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					ReplicationFactor: -1,
+					Template: TempoTemplateSpec{
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: pointer.Int32(1),
+							},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, -1, "the replication factor must be positive"),
+			},
+		},
 		{
 			name: "error replicas less than floor(replication_factor/2) + 1",
 			input: TempoStack{
 				Spec: TempoStackSpec{
 					ReplicationFactor: 3,
 					Template: TempoTemplateSpec{
-						Ingester: TempoComponentSpec{
-							Replicas: pointer.Int32(1),
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: pointer.Int32(1),
+							},
 						},
 					},
 				},
@@ -475,6 +703,23 @@ func TestValidateReplicationFactor(t *testing.T) {
 					fmt.Sprintf("replica factor of %d requires at least %d ingester replicas", 3, 2),
 				)},
 		},
+		{
+			name: "replicas are deployed in each zone",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					ReplicationFactor: 3,
+					Template: TempoTemplateSpec{
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: pointer.Int32(1),
+							},
+							ZoneAwareReplication: &ZoneAwareReplicationSpec{Zones: []string{"zone-a", "zone-b", "zone-c"}},
+						},
+					},
+				},
+			},
+			expected: nil,
+		},
 	}
 
 	for _, test := range tests {
@@ -625,6 +870,33 @@ func TestValidateQueryFrontend(t *testing.T) {
 				),
 			},
 		},
+		{
+			name: "monitor tab enabled, metrics-generator sends to OpenShift monitoring",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					ReplicationFactor: 3,
+					Template: TempoTemplateSpec{
+						QueryFrontend: TempoQueryFrontendSpec{
+							JaegerQuery: JaegerQuerySpec{
+								Enabled: true,
+								MonitorTab: JaegerQueryMonitor{
+									Enabled: true,
+								},
+							},
+						},
+						MetricsGenerator: TempoMetricsGeneratorSpec{
+							Enabled:     true,
+							Processors:  []MetricsGeneratorProcessor{MetricsGeneratorProcessorSpanMetrics},
+							RemoteWrite: []RemoteWriteSpec{{OpenShiftMonitoring: true}},
+						},
+					},
+				},
+			},
+			ctrlConfig: v1alpha1.ProjectConfig{
+				Gates: v1alpha1.FeatureGates{},
+			},
+			expected: nil,
+		},
 	}
 
 	for _, test := range tests {
@@ -636,764 +908,3170 @@ func TestValidateQueryFrontend(t *testing.T) {
 	}
 }
 
-func TestValidateGatewayAndJaegerQuery(t *testing.T) {
-	path := field.NewPath("spec").Child("template").Child("gateway").Child("enabled")
+func TestValidatorObservabilityTracingConfig(t *testing.T) {
+	observabilityBase := field.NewPath("spec").Child("observability")
+	metricsBase := observabilityBase.Child("metrics")
+	tracingBase := observabilityBase.Child("tracing")
 
-	tests := []struct {
-		name     string
-		input    TempoStack
-		expected field.ErrorList
+	tt := []struct {
+		name       string
+		input      TempoStack
+		ctrlConfig v1alpha1.ProjectConfig
+		expected   field.ErrorList
 	}{
 		{
-			name: "valid configuration enabled both",
+			name: "not set",
 			input: TempoStack{
-				Spec: TempoStackSpec{
-					ReplicationFactor: 3,
-					Template: TempoTemplateSpec{
-						QueryFrontend: TempoQueryFrontendSpec{
-							JaegerQuery: JaegerQuerySpec{
-								Enabled: true,
-							},
-						},
-						Gateway: TempoGatewaySpec{
-							Enabled: true,
-						},
-					},
-					Tenants: &TenantsSpec{
-						Mode: ModeStatic,
-					},
-				},
+				Spec: TempoStackSpec{},
 			},
-			expected: nil,
 		},
 		{
-			name: "valid config disable gateway and enable jaegerQuery",
+			name: "createServiceMonitors enabled and prometheusOperator feature gate set",
 			input: TempoStack{
 				Spec: TempoStackSpec{
-					ReplicationFactor: 3,
-					Template: TempoTemplateSpec{
-						QueryFrontend: TempoQueryFrontendSpec{
-							JaegerQuery: JaegerQuerySpec{
-								Enabled: true,
-								Ingress: IngressSpec{
-									Type: "route",
-								},
-							},
-						},
-						Gateway: TempoGatewaySpec{
-							Enabled: false,
+					Observability: ObservabilitySpec{
+						Metrics: MetricsConfigSpec{
+							CreateServiceMonitors: true,
 						},
 					},
 				},
 			},
+			ctrlConfig: v1alpha1.ProjectConfig{
+				Gates: v1alpha1.FeatureGates{
+					PrometheusOperator: true,
+				},
+			},
 			expected: nil,
 		},
 		{
-			name: "valid config disable both",
+			name: "createServiceMonitors enabled but prometheusOperator feature gate not set",
 			input: TempoStack{
 				Spec: TempoStackSpec{
-					ReplicationFactor: 3,
-					Template: TempoTemplateSpec{
-						QueryFrontend: TempoQueryFrontendSpec{
-							JaegerQuery: JaegerQuerySpec{
-								Enabled: false,
-								Ingress: IngressSpec{
-									Type: "route",
-								},
-							},
-						},
-						Gateway: TempoGatewaySpec{
-							Enabled: false,
+					Observability: ObservabilitySpec{
+						Metrics: MetricsConfigSpec{
+							CreateServiceMonitors: true,
 						},
 					},
 				},
 			},
-			expected: nil,
+			expected: field.ErrorList{
+				field.Invalid(
+					metricsBase.Child("createServiceMonitors"),
+					true,
+					"the prometheusOperator feature gate must be enabled to create ServiceMonitors for Tempo components",
+				),
+			},
 		},
 		{
-			name: "invalid config disable jaegerQuery",
+			name: "createPrometheusRules enabled but prometheusOperator feature gate not set",
 			input: TempoStack{
 				Spec: TempoStackSpec{
-					ReplicationFactor: 3,
-					Template: TempoTemplateSpec{
-						QueryFrontend: TempoQueryFrontendSpec{
-							JaegerQuery: JaegerQuerySpec{
-								Enabled: false,
-								Ingress: IngressSpec{
-									Type: "ingress",
-								},
-							},
-						},
-						Gateway: TempoGatewaySpec{
-							Enabled: true,
+					Observability: ObservabilitySpec{
+						Metrics: MetricsConfigSpec{
+							CreatePrometheusRules: true,
 						},
 					},
 				},
 			},
 			expected: field.ErrorList{
-				field.Invalid(path, true,
-					"to use the gateway, please enable jaegerQuery",
+				field.Invalid(
+					metricsBase.Child("createPrometheusRules"),
+					true,
+					"the prometheusOperator feature gate must be enabled to create PrometheusRules for Tempo components",
 				),
 			},
 		},
 		{
-			name: "invalid configuration, ingress and gateway enabled",
+			name: "createPrometheusRules and createServiceMonitors enabled and prometheusOperator feature gate set",
 			input: TempoStack{
 				Spec: TempoStackSpec{
-					ReplicationFactor: 3,
-					Template: TempoTemplateSpec{
-						QueryFrontend: TempoQueryFrontendSpec{
-							JaegerQuery: JaegerQuerySpec{
-								Enabled: true,
-								Ingress: IngressSpec{
-									Type: "ingress",
-								},
-							},
-						},
-						Gateway: TempoGatewaySpec{
-							Enabled: true,
+					Observability: ObservabilitySpec{
+						Metrics: MetricsConfigSpec{
+							CreateServiceMonitors: true,
+							CreatePrometheusRules: true,
 						},
 					},
-					Tenants: &TenantsSpec{
-						Mode: ModeStatic,
-					},
 				},
 			},
-			expected: field.ErrorList{
-				field.Invalid(path, true,
-					"cannot enable gateway and jaeger query ingress at the same time, please use the Jaeger UI from the gateway",
-				),
+			ctrlConfig: v1alpha1.ProjectConfig{
+				Gates: v1alpha1.FeatureGates{
+					PrometheusOperator: true,
+				},
 			},
+			expected: nil,
 		},
 		{
-			name: "invalid configuration, gateway enabled but no tenant configured",
+			name: "createPrometheusRules enabled but createServiceMonitors not enabled",
 			input: TempoStack{
 				Spec: TempoStackSpec{
-					Template: TempoTemplateSpec{
-						QueryFrontend: TempoQueryFrontendSpec{
-							JaegerQuery: JaegerQuerySpec{
-								Enabled: true,
-							},
-						},
-						Gateway: TempoGatewaySpec{
-							Enabled: true,
+					Observability: ObservabilitySpec{
+						Metrics: MetricsConfigSpec{
+							CreatePrometheusRules: true,
 						},
 					},
 				},
 			},
+			ctrlConfig: v1alpha1.ProjectConfig{
+				Gates: v1alpha1.FeatureGates{
+					PrometheusOperator: true,
+				},
+			},
 			expected: field.ErrorList{
-				field.Invalid(path, true,
-					"to enable the gateway, please configure tenants",
+				field.Invalid(
+					metricsBase.Child("createPrometheusRules"),
+					true,
+					"the Prometheus rules alert based on collected metrics, therefore the createServiceMonitors feature must be enabled when enabling the createPrometheusRules feature",
 				),
 			},
 		},
 		{
-			name: "valid ingress configuration",
+			name: "sampling fraction not a float",
 			input: TempoStack{
 				Spec: TempoStackSpec{
-					ReplicationFactor: 3,
-					Template: TempoTemplateSpec{
-						QueryFrontend: TempoQueryFrontendSpec{
-							JaegerQuery: JaegerQuerySpec{
-								Enabled: true,
-							},
-						},
-						Gateway: TempoGatewaySpec{
-							Enabled: true,
-							Ingress: IngressSpec{
-								Type: "ingress",
-							},
+					Observability: ObservabilitySpec{
+						Tracing: TracingConfigSpec{
+							SamplingFraction: "a",
 						},
 					},
-					Tenants: &TenantsSpec{
-						Mode: ModeStatic,
-					},
 				},
 			},
-			expected: nil,
+			expected: field.ErrorList{
+				field.Invalid(
+					tracingBase.Child("sampling_fraction"),
+					"a",
+					"strconv.ParseFloat: parsing \"a\": invalid syntax",
+				),
+			},
 		},
 		{
-			name: "invalid route, feature gateway disabled",
+			name: "invalid jaeger agent address",
 			input: TempoStack{
 				Spec: TempoStackSpec{
-					ReplicationFactor: 3,
-					Template: TempoTemplateSpec{
-						QueryFrontend: TempoQueryFrontendSpec{
-							JaegerQuery: JaegerQuerySpec{
-								Enabled: true,
-							},
-						},
-						Gateway: TempoGatewaySpec{
-							Enabled: true,
-							Ingress: IngressSpec{
-								Type: "route",
-							},
+					Observability: ObservabilitySpec{
+						Tracing: TracingConfigSpec{
+							SamplingFraction:    "0.5",
+							JaegerAgentEndpoint: "--invalid--",
 						},
 					},
-					Tenants: &TenantsSpec{
-						Mode: ModeStatic,
-					},
 				},
 			},
 			expected: field.ErrorList{
 				field.Invalid(
-					field.NewPath("spec").Child("template").Child("gateway").Child("ingress").Child("type"),
-					IngressType("route"),
-					"please enable the featureGates.openshift.openshiftRoute feature gate to use Routes",
+					tracingBase.Child("jaeger_agent_endpoint"),
+					"--invalid--",
+					"address --invalid--: missing port in address",
 				),
 			},
 		},
 		{
-			name: "invalid configuration, enable two ingesss",
+			name: "valid configuration",
 			input: TempoStack{
 				Spec: TempoStackSpec{
-					ReplicationFactor: 3,
-					Template: TempoTemplateSpec{
-						QueryFrontend: TempoQueryFrontendSpec{
-							JaegerQuery: JaegerQuerySpec{
-								Enabled: true,
-								Ingress: IngressSpec{
-									Type: "ingress",
-								},
-							},
-						},
-						Gateway: TempoGatewaySpec{
-							Enabled: true,
-							Ingress: IngressSpec{
-								Type: "ingress",
-							},
+					Observability: ObservabilitySpec{
+						Tracing: TracingConfigSpec{
+							SamplingFraction:    "0.5",
+							JaegerAgentEndpoint: "agent:1234",
 						},
 					},
-					Tenants: &TenantsSpec{
-						Mode: ModeStatic,
-					},
 				},
 			},
-			expected: field.ErrorList{
-				field.Invalid(
-					path,
-					true,
-					"cannot enable gateway and jaeger query ingress at the same time, please use the Jaeger UI from the gateway",
-				),
-			},
 		},
 	}
 
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			validator := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
-			errs := validator.validateGateway(test.input)
-			assert.Equal(t, test.expected, errs)
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: tc.ctrlConfig}
+			assert.Equal(t, tc.expected, v.validateObservability(tc.input))
 		})
 	}
 }
 
-func TestValidateTenantConfigs(t *testing.T) {
+func TestValidatorValidate(t *testing.T) {
+
+	gvType := metav1.TypeMeta{
+		APIVersion: "testv1",
+		Kind:       "something",
+	}
 	tt := []struct {
-		name    string
-		input   TempoStack
-		wantErr error
+		name     string
+		input    runtime.Object
+		expected error
 	}{
 		{
-			name: "missing tenants",
-			input: TempoStack{
-				Spec: TempoStackSpec{},
-			},
-		},
-		{
-			name: "another mode",
-			input: TempoStack{
-				Spec: TempoStackSpec{
-					Tenants: &TenantsSpec{},
-				},
-			},
+			name:     "not a tempo object",
+			input:    new(corev1.Pod),
+			expected: apierrors.NewBadRequest("expected a TempoStack object but got *v1.Pod"),
 		},
 		{
-			name: "static missing authentication",
-			input: TempoStack{
-				Spec: TempoStackSpec{
-					Tenants: &TenantsSpec{
-						Mode: ModeStatic,
-					},
-					Template: TempoTemplateSpec{
-						Gateway: TempoGatewaySpec{
-							Enabled: true,
-						},
-					},
+			name: "pass all validators",
+			input: &TempoStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-obj",
+					Namespace: "abc",
 				},
-			},
-			wantErr: fmt.Errorf("spec.tenants.authentication is required in static mode"),
-		},
-		{
-			name: "static missing authorization",
-			input: TempoStack{
+				TypeMeta: gvType,
 				Spec: TempoStackSpec{
-					Tenants: &TenantsSpec{
-						Mode:           ModeStatic,
-						Authentication: []AuthenticationSpec{},
-					},
-					Template: TempoTemplateSpec{
-						Gateway: TempoGatewaySpec{
-							Enabled: true,
+					ServiceAccount:    naming.DefaultServiceAccountName("test-obj"),
+					ReplicationFactor: 1,
+					Storage: ObjectStorageSpec{
+						Secret: ObjectStorageSecretSpec{
+							Name: "not-found",
 						},
 					},
-				},
-			},
-			wantErr: fmt.Errorf("spec.tenants.authorization is required in static mode"),
-		},
-		{
-			name: "static missing roles",
-			input: TempoStack{
-				Spec: TempoStackSpec{
-					Tenants: &TenantsSpec{
-						Mode:           ModeStatic,
-						Authorization:  &AuthorizationSpec{},
-						Authentication: []AuthenticationSpec{},
-					},
 					Template: TempoTemplateSpec{
-						Gateway: TempoGatewaySpec{
-							Enabled: true,
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: func(i int32) *int32 { return &i }(1),
+							},
 						},
 					},
 				},
 			},
-			wantErr: fmt.Errorf("spec.tenants.authorization.roles is required in static mode"),
 		},
 		{
-			name: "static missing role bindings",
-			input: TempoStack{
-				Spec: TempoStackSpec{
-					Tenants: &TenantsSpec{
-						Mode: ModeStatic,
-						Authorization: &AuthorizationSpec{
-							Roles: []RoleSpec{},
-						},
-						Authentication: []AuthenticationSpec{},
-					},
-					Template: TempoTemplateSpec{
-						Gateway: TempoGatewaySpec{
-							Enabled: true,
-						},
-					},
+			// cert-manager requires the httpEncryption or grpcEncryption feature gate
+			name: "feature gate overrides",
+			input: &TempoStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-obj",
+					Namespace: "abc",
 				},
-			},
-			wantErr: fmt.Errorf("spec.tenants.authorization.roleBindings is required in static mode"),
-		},
-		{
-			name: "openshift: RBAC should not be defined",
-			input: TempoStack{
+				TypeMeta: gvType,
 				Spec: TempoStackSpec{
-					Tenants: &TenantsSpec{
-						Mode: ModeOpenShift,
-						Authorization: &AuthorizationSpec{
-							Roles: []RoleSpec{},
+					ServiceAccount:    naming.DefaultServiceAccountName("test-obj"),
+					ReplicationFactor: 1,
+					Storage: ObjectStorageSpec{
+						Secret: ObjectStorageSecretSpec{
+							Name: "not-found",
 						},
 					},
 					Template: TempoTemplateSpec{
-						Gateway: TempoGatewaySpec{
-							Enabled: true,
-						},
-					},
-				},
-			},
-			wantErr: fmt.Errorf("spec.tenants.authorization should not be defined in openshift mode"),
-		},
-		{
-			name: "openshift: OIDC should not be defined",
-			input: TempoStack{
-				Spec: TempoStackSpec{
-					Tenants: &TenantsSpec{
-						Mode: ModeOpenShift,
-						Authentication: []AuthenticationSpec{
-							{
-								OIDC: &OIDCSpec{},
+						Ingester: TempoIngesterSpec{
+							TempoComponentSpec: TempoComponentSpec{
+								Replicas: func(i int32) *int32 { return &i }(1),
 							},
 						},
 					},
-					Template: TempoTemplateSpec{
-						Gateway: TempoGatewaySpec{
-							Enabled: true,
-						},
-					},
+					CertManager:  &CertManagerSpec{IssuerRef: CertManagerIssuerReference{Name: "issuer"}},
+					FeatureGates: &FeatureGatesSpec{HTTPEncryption: pointer.Bool(true)},
 				},
 			},
-			wantErr: fmt.Errorf("spec.tenants.authentication.oidc should not be defined in openshift mode"),
 		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			err := ValidateTenantConfigs(tc.input)
-			assert.Equal(t, tc.wantErr, err)
+			v := &validator{ctrlConfig: v1alpha1.ProjectConfig{}, client: &k8sFake{}}
+			_, err := v.validate(context.Background(), tc.input)
+			assert.Equal(t, tc.expected, err)
 		})
 	}
 }
 
-func TestValidatorObservabilityTracingConfig(t *testing.T) {
-	observabilityBase := field.NewPath("spec").Child("observability")
-	metricsBase := observabilityBase.Child("metrics")
-	tracingBase := observabilityBase.Child("tracing")
+func TestValidateCertManager(t *testing.T) {
+	certManager := &CertManagerSpec{
+		IssuerRef: CertManagerIssuerReference{Name: "ca-issuer"},
+	}
+	tempo := TempoStack{
+		Spec: TempoStackSpec{
+			CertManager: certManager,
+		},
+	}
 
-	tt := []struct {
-		name       string
-		input      TempoStack
-		ctrlConfig v1alpha1.ProjectConfig
-		expected   field.ErrorList
+	v := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+	assert.Equal(t, field.ErrorList{
+		field.Invalid(
+			field.NewPath("spec", "certManager"),
+			certManager,
+			"please enable the featureGates.httpEncryption or featureGates.grpcEncryption feature gate to use cert-manager",
+		),
+	}, v.validateCertManager(tempo))
+
+	v = &validator{ctrlConfig: v1alpha1.ProjectConfig{
+		Gates: v1alpha1.FeatureGates{
+			GRPCEncryption: true,
+		},
+	}}
+	assert.Empty(t, v.validateCertManager(tempo))
+	assert.Empty(t, v.validateCertManager(TempoStack{}))
+
+	certManager.Duration = &metav1.Duration{Duration: time.Hour}
+	certManager.RenewBefore = &metav1.Duration{Duration: 2 * time.Hour}
+	assert.Equal(t, field.ErrorList{
+		field.Invalid(
+			field.NewPath("spec", "certManager", "renewBefore"),
+			"2h0m0s",
+			"must be shorter than the certificate duration",
+		),
+	}, v.validateCertManager(tempo))
+
+	certManager.RenewBefore = nil
+	v.ctrlConfig.Gates.OpenShift.ServingCertsAllServices = true
+	assert.Equal(t, field.ErrorList{
+		field.Invalid(
+			field.NewPath("spec", "certManager"),
+			certManager,
+			"cannot use cert-manager if the featureGates.openshift.servingCertsAllServices feature gate is enabled",
+		),
+	}, v.validateCertManager(tempo))
+}
+
+func TestValidateTLSProfile(t *testing.T) {
+	v := &validator{}
+	assert.Empty(t, v.validateTLSProfile(TempoStack{}))
+	assert.Empty(t, v.validateTLSProfile(TempoStack{
+		Spec: TempoStackSpec{
+			TLSProfile: &TLSProfileSpec{Type: v1alpha1.TLSProfileModernType},
+		},
+	}))
+	assert.Equal(t, field.ErrorList{
+		field.Invalid(
+			field.NewPath("spec", "tlsProfile", "type"),
+			v1alpha1.TLSProfileType("abc"),
+			"valid values are Old, Intermediate, Modern and Custom",
+		),
+	}, v.validateTLSProfile(TempoStack{
+		Spec: TempoStackSpec{
+			TLSProfile: &TLSProfileSpec{Type: "abc"},
+		},
+	}))
+	assert.Empty(t, v.validateTLSProfile(TempoStack{
+		Spec: TempoStackSpec{
+			TLSProfile: &TLSProfileSpec{
+				Type:          v1alpha1.TLSProfileCustomType,
+				Ciphers:       []string{"ECDHE-RSA-AES128-GCM-SHA256"},
+				MinTLSVersion: "VersionTLS12",
+			},
+		},
+	}))
+	assert.Equal(t, field.ErrorList{
+		field.Required(field.NewPath("spec", "tlsProfile", "ciphers"), "ciphers are required for the Custom type"),
+		field.Invalid(
+			field.NewPath("spec", "tlsProfile", "minTLSVersion"),
+			"",
+			"valid values are VersionTLS10, VersionTLS11, VersionTLS12 and VersionTLS13",
+		),
+	}, v.validateTLSProfile(TempoStack{
+		Spec: TempoStackSpec{
+			TLSProfile: &TLSProfileSpec{Type: v1alpha1.TLSProfileCustomType},
+		},
+	}))
+	assert.Equal(t, field.ErrorList{
+		field.Invalid(
+			field.NewPath("spec", "tlsProfile", "minTLSVersion"),
+			"VersionTLS12",
+			"minTLSVersion can only be set for the Custom type",
+		),
+	}, v.validateTLSProfile(TempoStack{
+		Spec: TempoStackSpec{
+			TLSProfile: &TLSProfileSpec{Type: v1alpha1.TLSProfileModernType, MinTLSVersion: "VersionTLS12"},
+		},
+	}))
+}
+
+func TestStorageUpdateWarnings(t *testing.T) {
+	oldTempo := TempoStack{
+		Spec: TempoStackSpec{
+			Storage: ObjectStorageSpec{
+				Secret: ObjectStorageSecretSpec{
+					Name: "old-secret",
+					Type: ObjectStorageSecretS3,
+				},
+			},
+		},
+	}
+
+	assert.Empty(t, storageUpdateWarnings(oldTempo, *oldTempo.DeepCopy()))
+
+	newTempo := oldTempo.DeepCopy()
+	newTempo.Spec.Storage.Secret.Name = "new-secret"
+	assert.Len(t, storageUpdateWarnings(oldTempo, *newTempo), 1)
+
+	newTempo.Spec.Storage.MigrateFrom = &oldTempo.Spec.Storage.Secret
+	assert.Empty(t, storageUpdateWarnings(oldTempo, *newTempo))
+}
+
+func TestValidateVolumeClaimUpdate(t *testing.T) {
+	oldTempo := TempoStack{
+		Spec: TempoStackSpec{
+			StorageClassName: pointer.String("standard"),
+			StorageSize:      resource.MustParse("10Gi"),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		update   func(tempo *TempoStack)
+		expected bool
 	}{
 		{
-			name: "not set",
+			name:   "no change",
+			update: func(tempo *TempoStack) {},
+		},
+		{
+			name: "same size in the volume claim template",
+			update: func(tempo *TempoStack) {
+				size := resource.MustParse("10240Mi")
+				tempo.Spec.Template.Ingester.VolumeClaimTemplate = &VolumeClaimTemplateSpec{Size: &size}
+			},
+		},
+		{
+			name: "storage size changed",
+			update: func(tempo *TempoStack) {
+				tempo.Spec.StorageSize = resource.MustParse("20Gi")
+			},
+			expected: true,
+		},
+		{
+			name: "storage class changed in the volume claim template",
+			update: func(tempo *TempoStack) {
+				tempo.Spec.Template.Ingester.VolumeClaimTemplate = &VolumeClaimTemplateSpec{StorageClassName: pointer.String("fast")}
+			},
+			expected: true,
+		},
+		{
+			name: "access modes changed",
+			update: func(tempo *TempoStack) {
+				tempo.Spec.Template.Ingester.VolumeClaimTemplate = &VolumeClaimTemplateSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+				}
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			newTempo := oldTempo.DeepCopy()
+			tc.update(newTempo)
+			errs := validateVolumeClaimUpdate(oldTempo, *newTempo)
+			if tc.expected {
+				assert.Len(t, errs, 1)
+			} else {
+				assert.Empty(t, errs)
+			}
+		})
+	}
+}
+
+func TestValidateName(t *testing.T) {
+
+	longName := "tgqwkjwqkehkqjwhekjwqhekjhwkjehwkqjehkjqwhekjqwhekjqhwkjehkqwj" +
+		"554678789021123234554678789021123234554678789021123234554678" +
+		"tgqwkjwqkehkqjwhekjwqhekjhwkjehwkqjehkjqwhekjqwhekjqhwkjehkqwj"
+
+	tt := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name: "all good",
 			input: TempoStack{
-				Spec: TempoStackSpec{},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-obj",
+					Namespace: "abc",
+				},
 			},
 		},
 		{
-			name: "createServiceMonitors enabled and prometheusOperator feature gate set",
+			name: "too long",
+			input: TempoStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      longName,
+					Namespace: "abc",
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					field.NewPath("metadata").Child("name"),
+					longName,
+					fmt.Sprintf("must be no more than %d characters", maxLabelLength),
+				)},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: v1alpha1.ProjectConfig{}, client: &k8sFake{}}
+			assert.Equal(t, tc.expected, v.validateStackName(tc.input))
+		})
+	}
+}
+
+func TestValidateDeprecatedFields(t *testing.T) {
+	zero := 0
+	one := 1
+
+	tt := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name:  "no deprecated fields",
+			input: TempoStack{},
+		},
+		{
+			name: "deprecated global maxSearchBytesPerTrace set to 0",
 			input: TempoStack{
 				Spec: TempoStackSpec{
-					Observability: ObservabilitySpec{
-						Metrics: MetricsConfigSpec{
-							CreateServiceMonitors: true,
+					LimitSpec: LimitSpec{
+						Global: RateLimitSpec{
+							Query: QueryLimit{
+								MaxSearchBytesPerTrace: &zero,
+							},
 						},
 					},
 				},
 			},
-			ctrlConfig: v1alpha1.ProjectConfig{
-				Gates: v1alpha1.FeatureGates{
-					PrometheusOperator: true,
+			expected: field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec", "limits", "global", "query", "maxSearchBytesPerTrace"),
+					&zero,
+					"this field is deprecated and must be unset",
+				),
+			},
+		},
+		{
+			name: "deprecated global maxSearchBytesPerTrace set to 1",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					LimitSpec: LimitSpec{
+						Global: RateLimitSpec{
+							Query: QueryLimit{
+								MaxSearchBytesPerTrace: &one,
+							},
+						},
+					},
 				},
 			},
-			expected: nil,
+			expected: field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec", "limits", "global", "query", "maxSearchBytesPerTrace"),
+					&one,
+					"this field is deprecated and must be unset",
+				),
+			},
 		},
 		{
-			name: "createServiceMonitors enabled but prometheusOperator feature gate not set",
+			name: "deprecated per-tenant maxSearchBytesPerTrace set to 0",
 			input: TempoStack{
 				Spec: TempoStackSpec{
-					Observability: ObservabilitySpec{
-						Metrics: MetricsConfigSpec{
-							CreateServiceMonitors: true,
+					LimitSpec: LimitSpec{
+						PerTenant: map[string]RateLimitSpec{
+							"tenant1": {
+								Query: QueryLimit{
+									MaxSearchBytesPerTrace: &zero,
+								},
+							},
 						},
 					},
 				},
 			},
 			expected: field.ErrorList{
 				field.Invalid(
-					metricsBase.Child("createServiceMonitors"),
-					true,
-					"the prometheusOperator feature gate must be enabled to create ServiceMonitors for Tempo components",
+					field.NewPath("spec.limits.perTenant[tenant1].query.maxSearchBytesPerTrace"),
+					&zero,
+					"this field is deprecated and must be unset",
 				),
 			},
 		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+			assert.Equal(t, tc.expected, v.validateDeprecatedFields(tc.input))
+		})
+	}
+}
+
+func TestValidateVolumeClaimTemplates(t *testing.T) {
+	zero := resource.MustParse("0Gi")
+	size := resource.MustParse("20Gi")
+
+	tt := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
 		{
-			name: "createPrometheusRules enabled but prometheusOperator feature gate not set",
+			name:  "no volume claim templates",
+			input: TempoStack{},
+		},
+		{
+			name: "volume claim templates on ingester and compactor",
 			input: TempoStack{
 				Spec: TempoStackSpec{
-					Observability: ObservabilitySpec{
-						Metrics: MetricsConfigSpec{
-							CreatePrometheusRules: true,
+					Template: TempoTemplateSpec{
+						Ingester: TempoIngesterSpec{
+							VolumeClaimTemplate: &VolumeClaimTemplateSpec{Size: &size},
+						},
+						Compactor: TempoCompactorSpec{
+							VolumeClaimTemplate: &VolumeClaimTemplateSpec{Size: &size},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "zero size",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						Ingester: TempoIngesterSpec{
+							VolumeClaimTemplate: &VolumeClaimTemplateSpec{Size: &zero},
 						},
 					},
 				},
 			},
 			expected: field.ErrorList{
 				field.Invalid(
-					metricsBase.Child("createPrometheusRules"),
-					true,
-					"the prometheusOperator feature gate must be enabled to create PrometheusRules for Tempo components",
+					field.NewPath("spec", "template", "ingester", "volumeClaimTemplate", "size"),
+					"0",
+					"size must be greater than zero",
 				),
 			},
 		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
+			assert.Equal(t, tc.expected, v.validateVolumeClaimTemplates(tc.input))
+		})
+	}
+}
+
+type k8sFake struct {
+	client.Client
+}
+
+func (*k8sFake) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return fmt.Errorf("mock: fails always")
+}
+
+func (*k8sFake) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return fmt.Errorf("mock: fails always")
+}
+
+type tempoStackListFake struct {
+	client.Client
+	stacks []TempoStack
+}
+
+func (f *tempoStackListFake) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	list.(*TempoStackList).Items = f.stacks
+	return nil
+}
+
+func TestValidateSPIFFE(t *testing.T) {
+	spiffe := &SPIFFESpec{CSIDriver: "csi.spiffe.io", AgentSocketName: "spire-agent.sock"}
+	path := field.NewPath("spec", "spiffe")
+	encryption := v1alpha1.ProjectConfig{
+		Gates: v1alpha1.FeatureGates{
+			HTTPEncryption: true,
+			GRPCEncryption: true,
+		},
+	}
+
+	tt := []struct {
+		name       string
+		ctrlConfig v1alpha1.ProjectConfig
+		input      TempoStack
+		expected   field.ErrorList
+	}{
+		{
+			name:  "SPIFFE disabled",
+			input: TempoStack{},
+		},
+		{
+			name:       "valid configuration",
+			ctrlConfig: encryption,
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					SPIFFE: spiffe,
+					Images: v1alpha1.ImagesSpec{SPIFFEHelper: "ghcr.io/spiffe/spiffe-helper:0.7.0"},
+				},
+			},
+		},
+		{
+			name: "encryption disabled",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					SPIFFE: spiffe,
+				},
+			},
+			expected: field.ErrorList{field.Invalid(
+				path,
+				spiffe,
+				"please enable the featureGates.httpEncryption or featureGates.grpcEncryption feature gate to use SPIFFE",
+			)},
+		},
+		{
+			name:       "cert-manager",
+			ctrlConfig: encryption,
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					SPIFFE:      spiffe,
+					CertManager: &CertManagerSpec{IssuerRef: CertManagerIssuerReference{Name: "ca-issuer"}},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(
+				path,
+				spiffe,
+				"cannot use SPIFFE and spec.certManager at the same time",
+			)},
+		},
+		{
+			name:       "missing spiffe-helper image",
+			ctrlConfig: encryption,
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					SPIFFE: spiffe,
+				},
+			},
+			expected: field.ErrorList{field.Required(
+				field.NewPath("spec", "images", "spiffeHelper"),
+				"please specify the spiffe-helper image or configure a default image in the operator configuration",
+			)},
+		},
+		{
+			name:       "ServiceMonitors with HTTP encryption",
+			ctrlConfig: encryption,
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					SPIFFE: spiffe,
+					Images: v1alpha1.ImagesSpec{SPIFFEHelper: "ghcr.io/spiffe/spiffe-helper:0.7.0"},
+					Observability: ObservabilitySpec{
+						Metrics: MetricsConfigSpec{CreateServiceMonitors: true},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec", "observability", "metrics", "createServiceMonitors"),
+				true,
+				"cannot create ServiceMonitors if SPIFFE and the featureGates.httpEncryption feature gate are enabled",
+			)},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: tc.ctrlConfig}
+			assert.Equal(t, tc.expected, v.validateSPIFFE(tc.input))
+		})
+	}
+}
+
+func TestValidateRouteCertificates(t *testing.T) {
+	gatewayPath := field.NewPath("spec", "template", "gateway", "ingress", "route")
+	jaegerQueryPath := field.NewPath("spec", "template", "queryFrontend", "jaegerQuery", "ingress", "route")
+	tempoStack := func(gatewayRoute, jaegerQueryRoute RouteSpec) TempoStack {
+		return TempoStack{
+			Spec: TempoStackSpec{
+				Template: TempoTemplateSpec{
+					Gateway: TempoGatewaySpec{
+						Ingress: IngressSpec{Type: IngressTypeRoute, Route: gatewayRoute},
+					},
+					QueryFrontend: TempoQueryFrontendSpec{
+						JaegerQuery: JaegerQuerySpec{
+							Ingress: IngressSpec{Type: IngressTypeRoute, Route: jaegerQueryRoute},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name:  "no custom certificates",
+			input: tempoStack(RouteSpec{Termination: TLSRouteTerminationTypePassthrough}, RouteSpec{Termination: TLSRouteTerminationTypeEdge}),
+		},
+		{
+			name: "valid reencrypt and edge",
+			input: tempoStack(
+				RouteSpec{Termination: TLSRouteTerminationTypeReencrypt, CertificateSecret: "cert", DestinationCAConfigMap: "ca"},
+				RouteSpec{Termination: TLSRouteTerminationTypeEdge, CertificateSecret: "cert"},
+			),
+		},
+		{
+			name:  "certificate with passthrough termination",
+			input: tempoStack(RouteSpec{Termination: TLSRouteTerminationTypePassthrough, CertificateSecret: "cert"}, RouteSpec{}),
+			expected: field.ErrorList{field.Invalid(gatewayPath.Child("certificateSecret"), "cert",
+				"a custom certificate is only supported by the edge and reencrypt termination")},
+		},
+		{
+			name:  "destination CA with edge termination",
+			input: tempoStack(RouteSpec{}, RouteSpec{Termination: TLSRouteTerminationTypeEdge, DestinationCAConfigMap: "ca"}),
+			expected: field.ErrorList{field.Invalid(jaegerQueryPath.Child("destinationCAConfigMap"), "ca",
+				"a destination CA is only supported by the reencrypt termination")},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateRouteCertificates(tc.input))
+		})
+	}
+}
+
+func TestValidateIngressHosts(t *testing.T) {
+	gatewayPath := field.NewPath("spec", "template", "gateway", "ingress")
+	tempoStack := func(name string, ingress IngressSpec) TempoStack {
+		return TempoStack{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "observability"},
+			Spec: TempoStackSpec{
+				Template: TempoTemplateSpec{
+					Gateway: TempoGatewaySpec{
+						Enabled: true,
+						Ingress: ingress,
+					},
+				},
+			},
+		}
+	}
+	other := tempoStack("other", IngressSpec{
+		Type:            IngressTypeIngress,
+		Host:            "tempo.example.com",
+		AdditionalHosts: []IngressHostSpec{{Host: "traces.example.com"}},
+	})
+
+	tt := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name:  "no ingress",
+			input: tempoStack("simplest", IngressSpec{}),
+		},
+		{
+			name: "valid",
+			input: tempoStack("simplest", IngressSpec{
+				Type:            IngressTypeIngress,
+				Host:            "simplest.example.com",
+				AdditionalHosts: []IngressHostSpec{{Host: "simplest.example.org", TLSSecretName: "simplest-tls"}},
+			}),
+		},
+		{
+			name:  "update of the same TempoStack",
+			input: other,
+		},
+		{
+			name: "duplicate host",
+			input: tempoStack("simplest", IngressSpec{
+				Type:            IngressTypeIngress,
+				Host:            "simplest.example.com",
+				AdditionalHosts: []IngressHostSpec{{Host: "simplest.example.com"}},
+			}),
+			expected: field.ErrorList{field.Duplicate(gatewayPath.Child("additionalHosts").Index(0).Child("host"), "simplest.example.com")},
+		},
+		{
+			name: "host used by another TempoStack",
+			input: tempoStack("simplest", IngressSpec{
+				Type:            IngressTypeIngress,
+				Host:            "tempo.example.com",
+				AdditionalHosts: []IngressHostSpec{{Host: "traces.example.com"}},
+			}),
+			expected: field.ErrorList{
+				field.Invalid(gatewayPath.Child("host"), "tempo.example.com",
+					"the host is already used by the TempoStack observability/other"),
+				field.Invalid(gatewayPath.Child("additionalHosts").Index(0).Child("host"), "traces.example.com",
+					"the host is already used by the TempoStack observability/other"),
+			},
+		},
+		{
+			name: "certificate with passthrough Route",
+			input: tempoStack("simplest", IngressSpec{
+				Type:            IngressTypeRoute,
+				Route:           RouteSpec{Termination: TLSRouteTerminationTypePassthrough},
+				AdditionalHosts: []IngressHostSpec{{Host: "simplest.example.org", TLSSecretName: "simplest-tls"}},
+			}),
+			expected: field.ErrorList{field.Invalid(gatewayPath.Child("additionalHosts").Index(0).Child("tlsSecretName"), "simplest-tls",
+				"a custom certificate is only supported by the edge and reencrypt termination")},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{client: &tempoStackListFake{stacks: []TempoStack{other}}}
+			assert.Equal(t, tc.expected, v.validateIngressHosts(context.Background(), tc.input))
+		})
+	}
+}
+
+func TestValidatePorts(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name:  "defaults",
+			input: TempoStack{},
+		},
+		{
+			name: "custom ports",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Ports: &ServerPortsSpec{HTTP: 3201, GRPC: 9096},
+					Template: TempoTemplateSpec{
+						Distributor: TempoDistributorSpec{
+							Receivers: ReceiversSpec{
+								OTLP: &OTLPReceiverSpec{GRPCPort: 4319, HTTPPort: 4320},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "server port conflicts with the memberlist port",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Ports: &ServerPortsSpec{HTTP: 7946},
+				},
+			},
+			expected: field.ErrorList{field.Duplicate(field.NewPath("spec", "ports", "http"), int32(7946))},
+		},
+		{
+			name: "custom memberlist port conflicts with the HTTP server port",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Memberlist: &MemberlistSpec{Port: 3200},
+				},
+			},
+			expected: field.ErrorList{field.Duplicate(field.NewPath("spec", "ports", "http"), int32(3200))},
+		},
+		{
+			name: "OTLP port conflicts with the gRPC server port",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						Distributor: TempoDistributorSpec{
+							Receivers: ReceiversSpec{
+								OTLP: &OTLPReceiverSpec{GRPCPort: 9095},
+							},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Duplicate(
+				field.NewPath("spec", "template", "distributor", "receivers", "otlp", "grpcPort"), int32(9095))},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validatePorts(tc.input))
+		})
+	}
+}
+
+func TestValidateMemberlist(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    *MemberlistSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "valid",
+			input: &MemberlistSpec{
+				InstanceAddrType: MemberlistInstanceAddrPodIP,
+				GossipInterval:   metav1.Duration{Duration: time.Second},
+				ClusterDomain:    "example.org",
+			},
+		},
+		{
+			name:  "negative gossip interval",
+			input: &MemberlistSpec{GossipInterval: metav1.Duration{Duration: -time.Second}},
+			expected: field.ErrorList{field.Invalid(field.NewPath("spec", "memberlist", "gossipInterval"), "-1s",
+				"the duration must be positive")},
+		},
+		{
+			name:  "invalid cluster domain",
+			input: &MemberlistSpec{ClusterDomain: "Example.org"},
+			expected: field.ErrorList{field.Invalid(field.NewPath("spec", "memberlist", "clusterDomain"), "Example.org",
+				validation.IsDNS1123Subdomain("Example.org")[0])},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateMemberlist(TempoStack{Spec: TempoStackSpec{Memberlist: tc.input}}))
+		})
+	}
+}
+
+func TestValidateKVStore(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    *KVStoreSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:  "memberlist",
+			input: &KVStoreSpec{Type: KVStoreMemberlist},
+		},
+		{
+			name:  "etcd",
+			input: &KVStoreSpec{Type: KVStoreEtcd, Etcd: &EtcdSpec{Endpoints: []string{"etcd:2379"}}},
+		},
+		{
+			name:  "consul",
+			input: &KVStoreSpec{Type: KVStoreConsul, Consul: &ConsulSpec{Host: "consul:8500"}},
+		},
+		{
+			name:  "missing etcd settings",
+			input: &KVStoreSpec{Type: KVStoreEtcd},
+			expected: field.ErrorList{field.Required(field.NewPath("spec", "kvStore", "etcd"),
+				"the etcd settings are required for the etcd KV store")},
+		},
+		{
+			name:  "missing Consul settings",
+			input: &KVStoreSpec{Type: KVStoreConsul},
+			expected: field.ErrorList{field.Required(field.NewPath("spec", "kvStore", "consul"),
+				"the Consul settings are required for the consul KV store")},
+		},
+		{
+			name:  "etcd settings with the memberlist",
+			input: &KVStoreSpec{Type: KVStoreMemberlist, Etcd: &EtcdSpec{Endpoints: []string{"etcd:2379"}}},
+			expected: field.ErrorList{field.Invalid(field.NewPath("spec", "kvStore", "etcd"), "",
+				"the etcd settings require the etcd KV store")},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateKVStore(TempoStack{Spec: TempoStackSpec{KVStore: tc.input}}))
+		})
+	}
+}
+
+func TestValidateSharedObjects(t *testing.T) {
+	storage := func(secretNamespace string, caNamespace string) ObjectStorageSpec {
+		return ObjectStorageSpec{
+			Secret: ObjectStorageSecretSpec{Name: "storage", Namespace: secretNamespace},
+			TLS:    &ObjectStorageTLSSpec{CA: "storage-ca", CANamespace: caNamespace},
+		}
+	}
+
+	tt := []struct {
+		name     string
+		input    TempoStackSpec
+		expected field.ErrorList
+	}{
+		{
+			name:  "same namespace",
+			input: TempoStackSpec{Storage: storage("", "team-a")},
+		},
+		{
+			name:  "allow-listed namespace",
+			input: TempoStackSpec{Storage: storage("platform", "platform")},
+		},
+		{
+			name:  "namespace not allow-listed",
+			input: TempoStackSpec{Storage: storage("platform", "team-b")},
+			expected: field.ErrorList{field.Invalid(field.NewPath("spec", "storage", "tls", "caNamespace"), "team-b",
+				"the namespace is not allow-listed in the secretNamespaces setting of the operator")},
+		},
+		{
+			name: "same name from different namespaces",
+			input: TempoStackSpec{
+				Storage: storage("platform", ""),
+				Backup:  &BackupSpec{Secret: ObjectStorageSecretSpec{Name: "storage", Namespace: "observability"}},
+			},
+			expected: field.ErrorList{field.Invalid(field.NewPath("spec", "backup", "secret", "namespace"), "observability",
+				"the Secret storage is already referenced from the namespace platform")},
+		},
+		{
+			name: "tenant secret",
+			input: TempoStackSpec{
+				Tenants: &TenantsSpec{
+					Authentication: []AuthenticationSpec{
+						{TenantName: "dev", OIDC: &OIDCSpec{Secret: &TenantSecretSpec{Name: "dev-oidc", Namespace: "team-b"}}},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(field.NewPath("spec", "tenants", "authentication").Index(0).Child("oidc", "secret", "namespace"),
+				"team-b", "the namespace is not allow-listed in the secretNamespaces setting of the operator")},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: v1alpha1.ProjectConfig{SecretNamespaces: []string{"platform", "observability"}}}
+			tempo := TempoStack{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"}, Spec: tc.input}
+			assert.Equal(t, tc.expected, v.validateSharedObjects(tempo))
+		})
+	}
+}
+
+func TestValidateDistributorService(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name:  "default service type",
+			input: TempoStack{},
+		},
+		{
+			name: "load balancer without gateway",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						Distributor: TempoDistributorSpec{ServiceType: corev1.ServiceTypeLoadBalancer},
+					},
+				},
+			},
+		},
+		{
+			name: "cluster IP with gateway",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						Gateway:     TempoGatewaySpec{Enabled: true},
+						Distributor: TempoDistributorSpec{ServiceType: corev1.ServiceTypeClusterIP},
+					},
+				},
+			},
+		},
+		{
+			name: "node port with gateway",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Template: TempoTemplateSpec{
+						Gateway:     TempoGatewaySpec{Enabled: true},
+						Distributor: TempoDistributorSpec{ServiceType: corev1.ServiceTypeNodePort},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec", "template", "distributor", "serviceType"),
+				corev1.ServiceTypeNodePort,
+				"the distributor cannot be exposed directly if the gateway is enabled, please expose the gateway instead",
+			)},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateDistributorService(tc.input))
+		})
+	}
+}
+
+func TestValidateLimits(t *testing.T) {
+	path := field.NewPath("spec", "limits")
+	negative := -1
+	positive := 100
+
+	tt := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name:  "no limits",
+			input: TempoStack{},
+		},
+		{
+			name: "valid per tenant limits",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					LimitSpec: LimitSpec{
+						Global: RateLimitSpec{Ingestion: IngestionLimitSpec{MaxTracesPerUser: &positive}},
+						PerTenant: map[string]RateLimitSpec{
+							"dev": {Ingestion: IngestionLimitSpec{IngestionRateLimitBytes: &positive}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "negative limits",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					LimitSpec: LimitSpec{
+						Global: RateLimitSpec{Query: QueryLimit{MaxBytesPerTagValues: &negative}},
+						PerTenant: map[string]RateLimitSpec{
+							"prod": {Query: QueryLimit{MaxSearchDuration: metav1.Duration{Duration: -time.Hour}}},
+							"dev":  {Ingestion: IngestionLimitSpec{MaxBytesPerTrace: &negative}},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("global", "query", "maxBytesPerTagValues"), -1, "the limit must not be negative"),
+				field.Invalid(path.Child("perTenant").Key("dev").Child("ingestion", "maxBytesPerTrace"), -1, "the limit must not be negative"),
+				field.Invalid(path.Child("perTenant").Key("prod").Child("query", "maxSearchDuration"), "-1h0m0s", "the duration must not be negative"),
+			},
+		},
+		{
+			name: "limits defined twice",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Authentication: []AuthenticationSpec{
+							{TenantName: "dev", TenantID: "1610b0c3-c509-4592-a256-a1871353dbfa", Limits: &RateLimitSpec{}},
+						},
+					},
+					LimitSpec: LimitSpec{
+						PerTenant: map[string]RateLimitSpec{
+							"dev": {},
+							"":    {},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("perTenant").Key(""), "", "the tenant must not be empty"),
+				field.Duplicate(path.Child("perTenant").Key("dev"), "dev"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateLimits(tc.input))
+		})
+	}
+}
+
+func TestValidateMetricsGenerator(t *testing.T) {
+	path := field.NewPath("spec", "template", "metricsGenerator")
+	tt := []struct {
+		name     string
+		input    TempoMetricsGeneratorSpec
+		expected field.ErrorList
+	}{
+		{
+			name:  "disabled",
+			input: TempoMetricsGeneratorSpec{},
+		},
+		{
+			name: "valid",
+			input: TempoMetricsGeneratorSpec{
+				Enabled:     true,
+				Processors:  []MetricsGeneratorProcessor{MetricsGeneratorProcessorSpanMetrics},
+				RemoteWrite: []RemoteWriteSpec{{URL: "https://prometheus:9091/api/v1/write"}},
+			},
+		},
+		{
+			name: "no processors and invalid remote write URLs",
+			input: TempoMetricsGeneratorSpec{
+				Enabled: true,
+				RemoteWrite: []RemoteWriteSpec{
+					{URL: "prometheus:9090"},
+					{URL: "http://prometheus:9090/api/v1/write"},
+					{URL: "/api/v1/write"},
+				},
+			},
+			expected: field.ErrorList{
+				field.Required(path.Child("processors"), "at least one processor must be enabled"),
+				field.Invalid(path.Child("remoteWrite").Index(0).Child("url"), "prometheus:9090", "must be an absolute http or https URL"),
+				field.Invalid(path.Child("remoteWrite").Index(2).Child("url"), "/api/v1/write", "must be an absolute http or https URL"),
+			},
+		},
+		{
+			name: "valid remote write authentication and OpenShift monitoring",
+			input: TempoMetricsGeneratorSpec{
+				Enabled:    true,
+				Processors: []MetricsGeneratorProcessor{MetricsGeneratorProcessorServiceGraphs},
+				RemoteWrite: []RemoteWriteSpec{
+					{
+						URL:     "https://mimir:8080/api/v1/push",
+						Headers: map[string]string{"X-Scope-OrgID": "dev"},
+						Auth:    &RemoteWriteAuthSpec{Type: RemoteWriteAuthTypeBasic, Secret: "mimir-credentials"},
+						TLS:     &RemoteWriteTLSSpec{CA: "mimir-ca"},
+					},
+					{OpenShiftMonitoring: true},
+				},
+			},
+		},
+		{
+			name: "invalid remote write authentication and OpenShift monitoring",
+			input: TempoMetricsGeneratorSpec{
+				Enabled:    true,
+				Processors: []MetricsGeneratorProcessor{MetricsGeneratorProcessorServiceGraphs},
+				RemoteWrite: []RemoteWriteSpec{
+					{},
+					{URL: "https://mimir:8080/api/v1/push", Auth: &RemoteWriteAuthSpec{Type: RemoteWriteAuthTypeBearer}},
+					{
+						OpenShiftMonitoring: true,
+						URL:                 "https://prometheus:9091/api/v1/write",
+						Auth:                &RemoteWriteAuthSpec{Type: RemoteWriteAuthTypeBearer, Secret: "token"},
+						TLS:                 &RemoteWriteTLSSpec{InsecureSkipVerify: true},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Required(path.Child("remoteWrite").Index(0).Child("url"), "the URL is required if OpenShift monitoring is not enabled"),
+				field.Required(path.Child("remoteWrite").Index(1).Child("auth").Child("secret"), "the credentials secret is required"),
+				field.Invalid(path.Child("remoteWrite").Index(2).Child("url"), "https://prometheus:9091/api/v1/write",
+					"the URL cannot be set if OpenShift monitoring is enabled"),
+				field.Forbidden(path.Child("remoteWrite").Index(2).Child("auth"),
+					"OpenShift monitoring uses the token of the service account, custom authentication is not supported"),
+				field.Forbidden(path.Child("remoteWrite").Index(2).Child("tls"),
+					"OpenShift monitoring uses the service CA bundle, custom TLS settings are not supported"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			tempo := TempoStack{Spec: TempoStackSpec{Template: TempoTemplateSpec{MetricsGenerator: tc.input}}}
+			assert.Equal(t, tc.expected, v.validateMetricsGenerator(tempo))
+		})
+	}
+}
+
+func TestValidateRetention(t *testing.T) {
+	path := field.NewPath("spec", "retention")
+
+	tt := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name:  "no retention",
+			input: TempoStack{},
+		},
+		{
+			name: "valid per tenant retention",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Retention: RetentionSpec{
+						Global: RetentionConfig{Traces: metav1.Duration{Duration: 48 * time.Hour}},
+						PerTenant: map[string]RetentionConfig{
+							"premium": {Traces: metav1.Duration{Duration: 720 * time.Hour}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid retention",
+			input: TempoStack{
+				Spec: TempoStackSpec{
+					Tenants: &TenantsSpec{
+						Authentication: []AuthenticationSpec{
+							{
+								TenantName: "dev",
+								TenantID:   "1610b0c3-c509-4592-a256-a1871353dbfa",
+								Retention:  &RetentionConfig{Traces: metav1.Duration{Duration: time.Hour}},
+							},
+						},
+					},
+					Retention: RetentionSpec{
+						Global: RetentionConfig{Traces: metav1.Duration{Duration: -time.Hour}},
+						PerTenant: map[string]RetentionConfig{
+							"":     {Traces: metav1.Duration{Duration: time.Hour}},
+							"dev":  {Traces: metav1.Duration{Duration: time.Hour}},
+							"prod": {},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("global", "traces"), "-1h0m0s", "the retention must not be negative"),
+				field.Invalid(path.Child("perTenant").Key(""), "", "the tenant must not be empty"),
+				field.Duplicate(path.Child("perTenant").Key("dev"), "dev"),
+				field.Invalid(path.Child("perTenant").Key("prod").Child("traces"), "0s", "the retention must be positive"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateRetention(tc.input))
+		})
+	}
+}
+
+func TestValidateCompactor(t *testing.T) {
+	path := field.NewPath("spec", "template", "compactor")
+	negative := -1
+	zero := 0
+	positive := 100
+
+	tt := []struct {
+		name     string
+		input    TempoCompactorSpec
+		expected field.ErrorList
+	}{
+		{
+			name:  "defaults",
+			input: TempoCompactorSpec{},
+		},
+		{
+			name: "valid",
+			input: TempoCompactorSpec{
+				CompactionWindow:     metav1.Duration{Duration: 30 * time.Minute},
+				MaxBlockBytes:        &positive,
+				RetentionConcurrency: &positive,
+				CompactionCycle:      metav1.Duration{Duration: time.Minute},
+			},
+		},
+		{
+			name: "invalid",
+			input: TempoCompactorSpec{
+				CompactionWindow:     metav1.Duration{Duration: -time.Minute},
+				MaxBlockBytes:        &negative,
+				RetentionConcurrency: &zero,
+				CompactionCycle:      metav1.Duration{Duration: -time.Second},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("compactionWindow"), "-1m0s", "the duration must not be negative"),
+				field.Invalid(path.Child("compactionCycle"), "-1s", "the duration must not be negative"),
+				field.Invalid(path.Child("maxBlockBytes"), -1, "the value must be positive"),
+				field.Invalid(path.Child("retentionConcurrency"), 0, "the value must be positive"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			tempo := TempoStack{Spec: TempoStackSpec{Template: TempoTemplateSpec{Compactor: tc.input}}}
+			assert.Equal(t, tc.expected, v.validateCompactor(tempo))
+		})
+	}
+}
+
+func TestValidateIngester(t *testing.T) {
+	path := field.NewPath("spec", "template", "ingester")
+	negative := -1
+	zero := 0
+	positive := 100
+
+	tt := []struct {
+		name     string
+		input    TempoIngesterSpec
+		expected field.ErrorList
+	}{
+		{
+			name:  "defaults",
+			input: TempoIngesterSpec{},
+		},
+		{
+			name: "valid",
+			input: TempoIngesterSpec{
+				TraceIdlePeriod:      metav1.Duration{Duration: 5 * time.Second},
+				MaxBlockDuration:     metav1.Duration{Duration: 30 * time.Minute},
+				MaxBlockBytes:        &positive,
+				CompleteBlockTimeout: metav1.Duration{Duration: 5 * time.Minute},
+				FlushCheckPeriod:     metav1.Duration{Duration: 15 * time.Second},
+				ConcurrentFlushes:    &positive,
+			},
+		},
+		{
+			name: "invalid",
+			input: TempoIngesterSpec{
+				TraceIdlePeriod:      metav1.Duration{Duration: -time.Second},
+				MaxBlockDuration:     metav1.Duration{Duration: -time.Minute},
+				MaxBlockBytes:        &negative,
+				CompleteBlockTimeout: metav1.Duration{Duration: -time.Minute},
+				FlushCheckPeriod:     metav1.Duration{Duration: -time.Second},
+				ConcurrentFlushes:    &zero,
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("traceIdlePeriod"), "-1s", "the duration must not be negative"),
+				field.Invalid(path.Child("maxBlockDuration"), "-1m0s", "the duration must not be negative"),
+				field.Invalid(path.Child("completeBlockTimeout"), "-1m0s", "the duration must not be negative"),
+				field.Invalid(path.Child("flushCheckPeriod"), "-1s", "the duration must not be negative"),
+				field.Invalid(path.Child("maxBlockBytes"), -1, "the value must be positive"),
+				field.Invalid(path.Child("concurrentFlushes"), 0, "the value must be positive"),
+			},
+		},
+		{
+			name: "valid zone-aware replication",
+			input: TempoIngesterSpec{
+				ZoneAwareReplication: &ZoneAwareReplicationSpec{Zones: []string{"zone-a", "zone-b", "zone-c"}},
+			},
+		},
+		{
+			name: "more zones than the replication factor",
+			input: TempoIngesterSpec{
+				ZoneAwareReplication: &ZoneAwareReplicationSpec{Zones: []string{"zone-a", "zone-b", "zone-c", "zone-d"}},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					path.Child("zoneAwareReplication", "zones"),
+					[]string{"zone-a", "zone-b", "zone-c", "zone-d"},
+					"the replication factor (3) must be greater than or equal to the number of zones",
+				),
+			},
+		},
+		{
+			name: "invalid zone name",
+			input: TempoIngesterSpec{
+				ZoneAwareReplication: &ZoneAwareReplicationSpec{Zones: []string{"zone-a", "Zone_B"}},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					path.Child("zoneAwareReplication", "zones").Index(1),
+					"Zone_B",
+					validation.IsDNS1123Label("Zone_B")[0],
+				),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			tempo := TempoStack{Spec: TempoStackSpec{ReplicationFactor: 3, Template: TempoTemplateSpec{Ingester: tc.input}}}
+			assert.Equal(t, tc.expected, v.validateIngester(tempo))
+		})
+	}
+}
+
+func TestValidateResultsCache(t *testing.T) {
+	path := field.NewPath("spec", "template", "queryFrontend", "resultsCache")
+	memcached := TempoMemcachedSpec{Enabled: true}
+
+	tt := []struct {
+		name         string
+		image        string
+		memcached    TempoMemcachedSpec
+		resultsCache *QueryFrontendResultsCacheSpec
+		expected     field.ErrorList
+	}{
+		{
+			name:  "not configured",
+			image: "docker.io/grafana/tempo:2.2.1",
+		},
+		{
+			name:         "disabled",
+			image:        "docker.io/grafana/tempo:2.2.1",
+			resultsCache: &QueryFrontendResultsCacheSpec{},
+		},
+		{
+			name:         "valid",
+			image:        "docker.io/grafana/tempo:2.4.0",
+			memcached:    memcached,
+			resultsCache: &QueryFrontendResultsCacheSpec{Enabled: true, TTL: metav1.Duration{Duration: time.Hour}},
+		},
+		{
+			name:         "missing cache",
+			image:        "docker.io/grafana/tempo:2.4.0",
+			resultsCache: &QueryFrontendResultsCacheSpec{Enabled: true},
+			expected: field.ErrorList{field.Invalid(path.Child("enabled"), true,
+				"the results cache requires spec.cache or spec.template.memcached")},
+		},
+		{
+			name:         "unsupported Tempo version",
+			image:        "docker.io/grafana/tempo:2.3.1",
+			memcached:    memcached,
+			resultsCache: &QueryFrontendResultsCacheSpec{Enabled: true},
+			expected: field.ErrorList{field.Invalid(path.Child("enabled"), true,
+				"the results cache requires Tempo 2.4.0 or later, the tempo image docker.io/grafana/tempo:2.3.1 uses Tempo 2.3.1")},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			tempo := TempoStack{
+				Spec: TempoStackSpec{
+					Images: v1alpha1.ImagesSpec{Tempo: tc.image},
+					Template: TempoTemplateSpec{
+						Memcached:     tc.memcached,
+						QueryFrontend: TempoQueryFrontendSpec{ResultsCache: tc.resultsCache},
+					},
+				},
+			}
+			assert.Equal(t, tc.expected, v.validateResultsCache(tempo))
+		})
+	}
+}
+
+func TestValidateBlockFormat(t *testing.T) {
+	path := field.NewPath("spec", "storage", "blockFormat")
+
+	tt := []struct {
+		name     string
+		image    string
+		format   BlockFormat
+		expected field.ErrorList
+	}{
+		{
+			name:  "default block format",
+			image: "docker.io/grafana/tempo:2.2.1",
+		},
+		{
+			name:   "supported block format",
+			image:  "docker.io/grafana/tempo:2.2.1",
+			format: BlockFormatVParquet2,
+		},
+		{
+			name:   "older block format",
+			image:  "docker.io/grafana/tempo:2.3.0",
+			format: BlockFormatVParquet,
+		},
+		{
+			name:   "image without version",
+			image:  "registry.local:5000/grafana/tempo@sha256:f29ab7beb6e2f4e9f4b1b7df9c42a1de21ccb17d5e2e1a0d1b57cfb1c6d6a1e7",
+			format: BlockFormatVParquet4,
+		},
+		{
+			name:   "unsupported block format",
+			image:  "docker.io/grafana/tempo:2.2.1",
+			format: BlockFormatVParquet3,
+			expected: field.ErrorList{field.Invalid(path, BlockFormatVParquet3,
+				"the block format requires Tempo 2.3.0 or later, the tempo image docker.io/grafana/tempo:2.2.1 uses Tempo 2.2.1")},
+		},
+		{
+			name:   "unknown block format",
+			image:  "docker.io/grafana/tempo:2.2.1",
+			format: "v2",
+			expected: field.ErrorList{field.NotSupported(path, BlockFormat("v2"), []string{
+				"vParquet", "vParquet2", "vParquet3", "vParquet4",
+			})},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			tempo := TempoStack{Spec: TempoStackSpec{
+				Images:  v1alpha1.ImagesSpec{Tempo: tc.image},
+				Storage: ObjectStorageSpec{BlockFormat: tc.format},
+			}}
+			assert.Equal(t, tc.expected, v.validateBlockFormat(tempo))
+		})
+	}
+}
+
+func TestValidateCache(t *testing.T) {
+	path := field.NewPath("spec", "cache")
+
+	tt := []struct {
+		name     string
+		input    *CacheSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "no cache",
+		},
+		{
+			name: "valid memcached",
+			input: &CacheSpec{
+				Backend:   CacheBackendMemcached,
+				Endpoints: []string{"memcached-0.memcached:11211", "memcached-1.memcached:11211"},
+				Timeout:   metav1.Duration{Duration: 500 * time.Millisecond},
+				TLS:       &CacheTLSSpec{CA: "memcached-ca", CertName: "memcached-client-cert"},
+			},
+		},
+		{
+			name: "valid redis",
+			input: &CacheSpec{
+				Backend:    CacheBackendRedis,
+				Endpoints:  []string{"redis:6379"},
+				AuthSecret: "redis-credentials",
+				TLS:        &CacheTLSSpec{InsecureSkipVerify: true},
+			},
+		},
+		{
+			name: "invalid memcached",
+			input: &CacheSpec{
+				Backend:    CacheBackendMemcached,
+				Endpoints:  []string{"memcached"},
+				Timeout:    metav1.Duration{Duration: -time.Second},
+				AuthSecret: "memcached-credentials",
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("endpoints").Index(0), "memcached", "must be a host:port address"),
+				field.Invalid(path.Child("timeout"), "-1s", "the duration must not be negative"),
+				field.Forbidden(path.Child("authSecret"), "authentication is only supported by redis"),
+			},
+		},
+		{
+			name: "invalid redis",
+			input: &CacheSpec{
+				Backend: CacheBackendRedis,
+				TLS:     &CacheTLSSpec{CA: "redis-ca", CertName: "redis-client-cert", ServerName: "redis.cache.svc"},
+			},
+			expected: field.ErrorList{
+				field.Required(path.Child("endpoints"), "at least one endpoint is required"),
+				field.Forbidden(path.Child("tls", "caName"), "a custom CA is only supported by memcached, redis uses the system CA bundle"),
+				field.Forbidden(path.Child("tls", "certName"), "client certificates are only supported by memcached"),
+				field.Forbidden(path.Child("tls", "serverName"), "a custom server name is only supported by memcached"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			tempo := TempoStack{Spec: TempoStackSpec{Cache: tc.input}}
+			assert.Equal(t, tc.expected, v.validateCache(tempo))
+		})
+	}
+}
+
+func TestValidateCache_Memcached(t *testing.T) {
+	path := field.NewPath("spec", "template", "memcached", "enabled")
+
+	tt := []struct {
+		name     string
+		input    TempoStackSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "valid",
+			input: TempoStackSpec{
+				Images:   v1alpha1.ImagesSpec{Memcached: "memcached:1.6.21"},
+				Template: TempoTemplateSpec{Memcached: TempoMemcachedSpec{Enabled: true}},
+			},
+		},
+		{
+			name: "external cache",
+			input: TempoStackSpec{
+				Images:   v1alpha1.ImagesSpec{Memcached: "memcached:1.6.21"},
+				Cache:    &CacheSpec{Backend: CacheBackendRedis, Endpoints: []string{"redis:6379"}},
+				Template: TempoTemplateSpec{Memcached: TempoMemcachedSpec{Enabled: true}},
+			},
+			expected: field.ErrorList{field.Invalid(path, true,
+				"the memcached cache managed by the operator cannot be used together with an external cache in spec.cache")},
+		},
+		{
+			name: "no image",
+			input: TempoStackSpec{
+				Template: TempoTemplateSpec{Memcached: TempoMemcachedSpec{Enabled: true}},
+			},
+			expected: field.ErrorList{field.Invalid(path, true,
+				"please specify a memcached image in the CR or in the operator configuration")},
+		},
+		{
+			name: "log level",
+			input: TempoStackSpec{
+				Images: v1alpha1.ImagesSpec{Memcached: "memcached:1.6.21"},
+				Template: TempoTemplateSpec{Memcached: TempoMemcachedSpec{
+					TempoComponentSpec: TempoComponentSpec{LogLevel: LogLevelDebug},
+					Enabled:            true,
+				}},
+			},
+			expected: field.ErrorList{field.Forbidden(field.NewPath("spec", "template", "memcached", "logLevel"),
+				"the log level of memcached cannot be configured")},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateCache(TempoStack{Spec: tc.input}))
+		})
+	}
+}
+
+func TestValidateSearch(t *testing.T) {
+	path := field.NewPath("spec", "search")
+
+	tt := []struct {
+		name     string
+		input    SearchSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name: "valid",
+			input: SearchSpec{
+				MaxDuration:  metav1.Duration{Duration: 24 * time.Hour},
+				QueryTimeout: metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+		{
+			name: "negative durations",
+			input: SearchSpec{
+				MaxDuration:  metav1.Duration{Duration: -time.Hour},
+				QueryTimeout: metav1.Duration{Duration: -time.Minute},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("maxDuration"), "-1h0m0s", "the duration must not be negative"),
+				field.Invalid(path.Child("queryTimeout"), "-1m0s", "the duration must not be negative"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			tempo := TempoStack{Spec: TempoStackSpec{SearchSpec: tc.input}}
+			assert.Equal(t, tc.expected, v.validateSearch(tempo))
+		})
+	}
+}
+
+func TestValidateQueryFrontendSearch(t *testing.T) {
+	path := field.NewPath("spec", "template", "queryFrontend", "search")
+	zero := 0
+	positive := 100
+
+	tt := []struct {
+		name     string
+		input    *QueryFrontendSearchSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name: "valid",
+			input: &QueryFrontendSearchSpec{
+				ConcurrentJobs:      &positive,
+				TargetBytesPerJob:   &positive,
+				MaxDuration:         metav1.Duration{Duration: 24 * time.Hour},
+				QueryBackendAfter:   metav1.Duration{Duration: 15 * time.Minute},
+				QueryIngestersUntil: metav1.Duration{Duration: 30 * time.Minute},
+			},
+		},
+		{
+			name: "invalid values",
+			input: &QueryFrontendSearchSpec{
+				ConcurrentJobs:    &zero,
+				MaxDuration:       metav1.Duration{Duration: -time.Hour},
+				QueryBackendAfter: metav1.Duration{Duration: -time.Minute},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("maxDuration"), "-1h0m0s", "the duration must not be negative"),
+				field.Invalid(path.Child("queryBackendAfter"), "-1m0s", "the duration must not be negative"),
+				field.Invalid(path.Child("concurrentJobs"), 0, "the value must be positive"),
+			},
+		},
+		{
+			name: "ingesters queried for a shorter time than the backend is skipped",
+			input: &QueryFrontendSearchSpec{
+				QueryBackendAfter:   metav1.Duration{Duration: time.Hour},
+				QueryIngestersUntil: metav1.Duration{Duration: 30 * time.Minute},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("queryIngestersUntil"), "30m0s",
+					"must not be less than queryBackendAfter, otherwise recent data is neither searched in the ingesters nor in the object storage"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			tempo := TempoStack{Spec: TempoStackSpec{Template: TempoTemplateSpec{QueryFrontend: TempoQueryFrontendSpec{Search: tc.input}}}}
+			assert.Equal(t, tc.expected, v.validateQueryFrontendSearch(tempo))
+		})
+	}
+}
+
+func TestValidateExtraConfig(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    *ExtraConfigSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:  "object",
+			input: &ExtraConfigSpec{Tempo: apiextensionsv1.JSON{Raw: []byte(`{"querier":{"max_concurrent_queries":50}}`)}},
+		},
+		{
+			name:  "not an object",
+			input: &ExtraConfigSpec{Tempo: apiextensionsv1.JSON{Raw: []byte(`["querier"]`)}},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "extraConfig", "tempo"), `["querier"]`, "the extra configuration must be an object"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			tempo := TempoStack{Spec: TempoStackSpec{ExtraConfig: tc.input}}
+			assert.Equal(t, tc.expected, v.validateExtraConfig(tempo))
+		})
+	}
+}
+
+func TestExtraConfigWarnings(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    string
+		expected admission.Warnings
+	}{
+		{
+			name:  "unmanaged settings",
+			input: `{"querier":{"max_concurrent_queries":50},"storage":{"trace":{"pool":{"max_workers":200}}}}`,
+		},
+		{
+			name:  "managed settings",
+			input: `{"server":{"http_listen_port":8080,"log_level":"debug"},"storage":{"trace":{"s3":{"bucket":"other"}}}}`,
+			expected: admission.Warnings{
+				"spec.extraConfig.tempo overrides server.http_listen_port, which is managed by the operator. This can break the TempoStack",
+				"spec.extraConfig.tempo overrides storage.trace.s3.bucket, which is managed by the operator. This can break the TempoStack",
+			},
+		},
+		{
+			name:  "parent of a managed setting",
+			input: `{"ingester":{"lifecycler":"none"}}`,
+			expected: admission.Warnings{
+				"spec.extraConfig.tempo overrides ingester.lifecycler, which is managed by the operator. This can break the TempoStack",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tempo := TempoStack{Spec: TempoStackSpec{ExtraConfig: &ExtraConfigSpec{Tempo: apiextensionsv1.JSON{Raw: []byte(tc.input)}}}}
+			assert.Equal(t, tc.expected, extraConfigWarnings(tempo))
+		})
+	}
+}
+
+func TestValidateRuntimeOverrides(t *testing.T) {
+	msg := "per-tenant overrides cannot be combined with spec.limits.perTenantRuntimeOverridesConfigMap, " +
+		"configure them in the runtime overrides ConfigMap instead"
+
+	tt := []struct {
+		name     string
+		input    TempoStackSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "per tenant limits without runtime overrides",
+			input: TempoStackSpec{
+				LimitSpec: LimitSpec{PerTenant: map[string]RateLimitSpec{"dev": {}}},
+			},
+		},
+		{
+			name: "runtime overrides",
+			input: TempoStackSpec{
+				LimitSpec: LimitSpec{PerTenantRuntimeOverridesConfigMap: "tenant-overrides"},
+			},
+		},
+		{
+			name: "runtime overrides with per tenant overrides",
+			input: TempoStackSpec{
+				LimitSpec: LimitSpec{
+					PerTenantRuntimeOverridesConfigMap: "tenant-overrides",
+					PerTenant:                          map[string]RateLimitSpec{"dev": {}},
+				},
+				Retention: RetentionSpec{
+					PerTenant: map[string]RetentionConfig{"dev": {Traces: metav1.Duration{Duration: time.Hour}}},
+				},
+				Tenants: &TenantsSpec{
+					Authentication: []AuthenticationSpec{
+						{
+							TenantName: "prod",
+							TenantID:   "1610b0c3-c509-4592-a256-a1871353dbfa",
+							Limits:     &RateLimitSpec{},
+							Retention:  &RetentionConfig{Traces: metav1.Duration{Duration: time.Hour}},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "limits", "perTenant"), msg),
+				field.Forbidden(field.NewPath("spec", "retention", "perTenant"), msg),
+				field.Forbidden(field.NewPath("spec", "tenants", "authentication").Index(0).Child("limits"), msg),
+				field.Forbidden(field.NewPath("spec", "tenants", "authentication").Index(0).Child("retention"), msg),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateRuntimeOverrides(TempoStack{Spec: tc.input}))
+		})
+	}
+}
+
+func TestValidateComponentAutoscaling(t *testing.T) {
+	path := field.NewPath("spec", "template")
+	tt := []struct {
+		name     string
+		input    TempoTemplateSpec
+		expected field.ErrorList
+	}{
+		{
+			name:  "autoscaling disabled",
+			input: TempoTemplateSpec{},
+		},
+		{
+			name: "valid autoscaling",
+			input: TempoTemplateSpec{
+				Distributor: TempoDistributorSpec{
+					Autoscaling: &AutoscalingSpec{MinReplicas: pointer.Int32(2), MaxReplicas: 5},
+				},
+				Querier: TempoQuerierSpec{
+					Autoscaling: &AutoscalingSpec{MaxReplicas: 3},
+				},
+			},
+		},
+		{
+			name: "distributor replicas and autoscaling",
+			input: TempoTemplateSpec{
+				Distributor: TempoDistributorSpec{
+					TempoComponentSpec: TempoComponentSpec{Replicas: pointer.Int32(3)},
+					Autoscaling:        &AutoscalingSpec{MaxReplicas: 5},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(
+				path.Child("distributor", "replicas"),
+				int32(3),
+				"cannot set the replicas of the distributor if autoscaling is enabled",
+			)},
+		},
+		{
+			name: "querier minReplicas greater than maxReplicas",
+			input: TempoTemplateSpec{
+				Querier: TempoQuerierSpec{
+					Autoscaling: &AutoscalingSpec{MinReplicas: pointer.Int32(6), MaxReplicas: 5},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(
+				path.Child("querier", "autoscaling", "minReplicas"),
+				int32(6),
+				"must be less than or equal to maxReplicas",
+			)},
+		},
+		{
+			name: "valid KEDA autoscaling",
+			input: TempoTemplateSpec{
+				Distributor: TempoDistributorSpec{
+					Autoscaling: &AutoscalingSpec{
+						MaxReplicas: 5,
+						Keda: &KedaSpec{
+							Triggers: []KedaTriggerSpec{{Type: "prometheus", Metadata: map[string]string{"threshold": "100"}}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "metrics and KEDA",
+			input: TempoTemplateSpec{
+				Querier: TempoQuerierSpec{
+					Autoscaling: &AutoscalingSpec{
+						MaxReplicas: 5,
+						Metrics:     []autoscalingv2.MetricSpec{{Type: autoscalingv2.PodsMetricSourceType}},
+						Keda: &KedaSpec{
+							Triggers: []KedaTriggerSpec{{Type: "prometheus", Metadata: map[string]string{"threshold": "100"}}},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(
+				path.Child("querier", "autoscaling", "metrics"),
+				[]autoscalingv2.MetricSpec{{Type: autoscalingv2.PodsMetricSourceType}},
+				"metrics cannot be used together with KEDA, please define KEDA triggers instead",
+			)},
+		},
+		{
+			name: "KEDA with a CPU utilization target only",
+			input: TempoTemplateSpec{
+				Distributor: TempoDistributorSpec{
+					Autoscaling: &AutoscalingSpec{
+						MaxReplicas:          5,
+						TargetCPUUtilization: pointer.Int32(80),
+						Keda:                 &KedaSpec{},
+					},
+				},
+			},
+		},
+		{
+			name: "KEDA without triggers",
+			input: TempoTemplateSpec{
+				Distributor: TempoDistributorSpec{
+					Autoscaling: &AutoscalingSpec{
+						MaxReplicas: 5,
+						Keda:        &KedaSpec{},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Required(
+				path.Child("distributor", "autoscaling", "keda", "triggers"),
+				"at least one KEDA trigger or a CPU or memory utilization target is required",
+			)},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			tempo := TempoStack{Spec: TempoStackSpec{Template: tc.input}}
+			assert.Equal(t, tc.expected, v.validateComponentAutoscaling(tempo))
+		})
+	}
+}
+
+func TestValidateVerticalPodAutoscaler(t *testing.T) {
+	path := field.NewPath("spec").Child("resources").Child("verticalPodAutoscaler").Child("updateMode")
+	total := &corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+	}
+
+	tt := []struct {
+		name     string
+		input    Resources
+		expected field.ErrorList
+	}{
+		{
+			name:  "no VerticalPodAutoscaler",
+			input: Resources{Total: total},
+		},
+		{
+			name: "recommendations only with total resources",
+			input: Resources{
+				Total:                 total,
+				VerticalPodAutoscaler: &VerticalPodAutoscalerSpec{UpdateMode: VerticalPodAutoscalerUpdateModeOff},
+			},
+		},
+		{
+			name: "auto without total resources",
+			input: Resources{
+				VerticalPodAutoscaler: &VerticalPodAutoscalerSpec{UpdateMode: VerticalPodAutoscalerUpdateModeAuto},
+			},
+		},
+		{
+			name: "initial with resource profile",
+			input: Resources{
+				Profile:               ResourceProfileSmall,
+				VerticalPodAutoscaler: &VerticalPodAutoscalerSpec{UpdateMode: VerticalPodAutoscalerUpdateModeInitial},
+			},
+			expected: field.ErrorList{field.Invalid(
+				path,
+				VerticalPodAutoscalerUpdateModeInitial,
+				"the VerticalPodAutoscaler can only update the resources if spec.resources.total and spec.resources.profile are not set",
+			)},
+		},
+		{
+			name: "auto with total resources",
+			input: Resources{
+				Total:                 total,
+				VerticalPodAutoscaler: &VerticalPodAutoscalerSpec{UpdateMode: VerticalPodAutoscalerUpdateModeAuto},
+			},
+			expected: field.ErrorList{field.Invalid(
+				path,
+				VerticalPodAutoscalerUpdateModeAuto,
+				"the VerticalPodAutoscaler can only update the resources if spec.resources.total and spec.resources.profile are not set",
+			)},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			tempo := TempoStack{Spec: TempoStackSpec{Resources: tc.input}}
+			assert.Equal(t, tc.expected, v.validateVerticalPodAutoscaler(tempo))
+		})
+	}
+}
+
+func TestValidatePodMetadata(t *testing.T) {
+	path := field.NewPath("spec").Child("template")
+
+	tt := []struct {
+		name     string
+		input    TempoTemplateSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "valid labels and annotations",
+			input: TempoTemplateSpec{
+				Ingester: TempoIngesterSpec{
+					TempoComponentSpec: TempoComponentSpec{
+						PodLabels:      map[string]string{"cost-center": "tracing"},
+						PodAnnotations: map[string]string{"sidecar.istio.io/inject": "true"},
+					},
+				},
+			},
+		},
+		{
+			name: "label managed by the operator",
+			input: TempoTemplateSpec{
+				Gateway: TempoGatewaySpec{
+					TempoComponentSpec: TempoComponentSpec{
+						PodLabels: map[string]string{"app.kubernetes.io/component": "custom"},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Forbidden(
+				path.Child("gateway", "component", "podLabels").Key("app.kubernetes.io/component"),
+				"the label is managed by the operator",
+			)},
+		},
+		{
+			name: "invalid label value",
+			input: TempoTemplateSpec{
+				Querier: TempoQuerierSpec{
+					TempoComponentSpec: TempoComponentSpec{
+						PodLabels: map[string]string{"team": "a b"},
+					},
+				},
+			},
+			expected: metav1validation.ValidateLabels(
+				map[string]string{"team": "a b"},
+				path.Child("querier", "podLabels"),
+			),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			tempo := TempoStack{Spec: TempoStackSpec{Template: tc.input}}
+			assert.Equal(t, tc.expected, v.validatePodMetadata(tempo))
+		})
+	}
+}
+
+func TestValidateResources(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    TempoStackSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "resource profile",
+			input: TempoStackSpec{
+				Resources: Resources{Profile: ResourceProfileMedium},
+			},
+		},
+		{
+			name: "resource profile and total resources",
+			input: TempoStackSpec{
+				Resources: Resources{
+					Profile: ResourceProfileMedium,
+					Total: &corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec").Child("resources").Child("profile"),
+				ResourceProfileMedium,
+				"the resource profile cannot be used together with spec.resources.total",
+			)},
+		},
+		{
+			name: "component resources",
+			input: TempoStackSpec{
+				Template: TempoTemplateSpec{
+					Ingester: TempoIngesterSpec{
+						TempoComponentSpec: TempoComponentSpec{
+							Resources: &corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+								Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "component request exceeds limit",
+			input: TempoStackSpec{
+				Template: TempoTemplateSpec{
+					Gateway: TempoGatewaySpec{
+						TempoComponentSpec: TempoComponentSpec{
+							Resources: &corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+								Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+							},
+						},
+					},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(
+				field.NewPath("spec").Child("template").Child("gateway").Child("component").Child("resources").Child("requests").Key("cpu"),
+				"2",
+				"the request must be less than or equal to the limit (1)",
+			)},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateResources(TempoStack{Spec: tc.input}))
+		})
+	}
+}
+
+func TestValidateGrafana(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    GrafanaConfigSpec
+		expected field.ErrorList
+	}{
+		{
+			name:  "datasource disabled",
+			input: GrafanaConfigSpec{},
+		},
+		{
+			name: "datasource with instance selector",
+			input: GrafanaConfigSpec{
+				CreateDatasource: true,
+				InstanceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"dashboards": "grafana"}},
+			},
+		},
+		{
+			name:  "datasource without instance selector",
+			input: GrafanaConfigSpec{CreateDatasource: true},
+			expected: field.ErrorList{field.Required(
+				field.NewPath("spec").Child("observability").Child("grafana").Child("instanceSelector"),
+				"the Grafana instance selector is required to create a datasource",
+			)},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			tempo := TempoStack{Spec: TempoStackSpec{Observability: ObservabilitySpec{Grafana: tc.input}}}
+			assert.Equal(t, tc.expected, v.validateGrafana(tempo))
+		})
+	}
+}
+
+func TestValidateServiceMonitors(t *testing.T) {
+	path := field.NewPath("spec").Child("observability").Child("metrics").Child("serviceMonitors")
+
+	tt := []struct {
+		name     string
+		input    map[string]ServiceMonitorSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "valid",
+			input: map[string]ServiceMonitorSpec{
+				"ingester": {
+					Interval:      "1m",
+					ScrapeTimeout: "30s",
+					HonorLabels:   true,
+					MetricRelabelConfigs: []RelabelConfig{{
+						SourceLabels: []string{"__name__"},
+						Regex:        "tempo_ingester_.*_bucket",
+						Action:       "drop",
+					}},
+				},
+			},
+		},
+		{
+			name:  "unknown component",
+			input: map[string]ServiceMonitorSpec{"memcached": {}},
+			expected: field.ErrorList{
+				field.NotSupported(path.Key("memcached"), "memcached", serviceMonitorComponents),
+			},
+		},
+		{
+			name:  "invalid interval",
+			input: map[string]ServiceMonitorSpec{"querier": {Interval: "m1"}},
+			expected: field.ErrorList{
+				field.Invalid(path.Key("querier").Child("interval"), "m1", "not a valid duration string: \"m1\""),
+			},
+		},
+		{
+			name:  "timeout greater than interval",
+			input: map[string]ServiceMonitorSpec{"querier": {Interval: "30s", ScrapeTimeout: "1m"}},
+			expected: field.ErrorList{
+				field.Invalid(path.Key("querier").Child("scrapeTimeout"), "1m",
+					"the scrape timeout must not be greater than the scrape interval"),
+			},
+		},
+		{
+			name: "invalid regex",
+			input: map[string]ServiceMonitorSpec{
+				"distributor": {MetricRelabelConfigs: []RelabelConfig{{Regex: "("}}},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Key("distributor").Child("metricRelabelConfigs").Index(0).Child("regex"), "(",
+					"error parsing regexp: missing closing ): `(`"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			tempo := TempoStack{Spec: TempoStackSpec{Observability: ObservabilitySpec{
+				Metrics: MetricsConfigSpec{ServiceMonitors: tc.input},
+			}}}
+			assert.Equal(t, tc.expected, v.validateServiceMonitors(tempo))
+		})
+	}
+}
+
+func TestValidateNetworkPolicy(t *testing.T) {
+	path := field.NewPath("spec").Child("networkPolicy")
+	tt := []struct {
+		name     string
+		input    *NetworkPolicySpec
+		expected field.ErrorList
+	}{
+		{
+			name:  "not configured",
+			input: nil,
+		},
+		{
+			name: "disabled with invalid CIDR",
+			input: &NetworkPolicySpec{
+				ObjectStorageCIDRs: []string{"invalid"},
+			},
+		},
+		{
+			name: "valid",
+			input: &NetworkPolicySpec{
+				Enabled:                   true,
+				ReceiverNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tracing": "enabled"}},
+				ObjectStorageCIDRs:        []string{"10.0.0.0/8"},
+			},
+		},
+		{
+			name: "invalid CIDR",
+			input: &NetworkPolicySpec{
+				Enabled:            true,
+				ObjectStorageCIDRs: []string{"10.0.0.0/8", "10.0.0.1"},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("objectStorageCIDRs").Index(1), "10.0.0.1", "invalid CIDR"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			tempo := TempoStack{Spec: TempoStackSpec{NetworkPolicy: tc.input}}
+			assert.Equal(t, tc.expected, v.validateNetworkPolicy(tempo))
+		})
+	}
+}
+
+func TestValidateIngressTLSAndPaths(t *testing.T) {
+	jaegerPath := field.NewPath("spec").Child("template").Child("queryFrontend").Child("jaegerQuery").Child("ingress")
+	gatewayPath := field.NewPath("spec").Child("template").Child("gateway").Child("ingress")
+	tempoStack := func(jaegerIngress IngressSpec, gatewayIngress IngressSpec) TempoStack {
+		return TempoStack{Spec: TempoStackSpec{Template: TempoTemplateSpec{
+			QueryFrontend: TempoQueryFrontendSpec{JaegerQuery: JaegerQuerySpec{Enabled: true, Ingress: jaegerIngress}},
+			Gateway:       TempoGatewaySpec{Enabled: gatewayIngress.Type != "", Ingress: gatewayIngress},
+		}}}
+	}
+
+	tt := []struct {
+		name     string
+		input    TempoStack
+		expected field.ErrorList
+	}{
+		{
+			name:  "Jaeger Query ingress with TLS secret and path prefix",
+			input: tempoStack(IngressSpec{Type: IngressTypeIngress, TLSSecretName: "tls", Path: "/jaeger"}, IngressSpec{}),
+		},
+		{
+			name:  "Jaeger Query route with path prefix",
+			input: tempoStack(IngressSpec{Type: IngressTypeRoute, Path: "/jaeger", Route: RouteSpec{Termination: TLSRouteTerminationTypeEdge}}, IngressSpec{}),
+		},
+		{
+			name:  "TLS secret of a route",
+			input: tempoStack(IngressSpec{Type: IngressTypeRoute, TLSSecretName: "tls"}, IngressSpec{}),
+			expected: field.ErrorList{
+				field.Forbidden(jaegerPath.Child("tlsSecretName"),
+					"the TLS secret is only supported by an Ingress, please use route.certificateSecret for a Route"),
+			},
+		},
+		{
+			name:  "path prefix with passthrough termination",
+			input: tempoStack(IngressSpec{Type: IngressTypeRoute, Path: "/jaeger", Route: RouteSpec{Termination: TLSRouteTerminationTypePassthrough}}, IngressSpec{}),
+			expected: field.ErrorList{
+				field.Invalid(jaegerPath.Child("path"), "/jaeger", "a path prefix is not supported by the passthrough termination"),
+			},
+		},
+		{
+			name:  "path without leading slash",
+			input: tempoStack(IngressSpec{Type: IngressTypeIngress, Path: "jaeger"}, IngressSpec{}),
+			expected: field.ErrorList{
+				field.Invalid(jaegerPath.Child("path"), "jaeger", "the path must start with /"),
+			},
+		},
+		{
+			name:  "path prefix of the gateway",
+			input: tempoStack(IngressSpec{}, IngressSpec{Type: IngressTypeIngress, Path: "/tempo"}),
+			expected: field.ErrorList{
+				field.Forbidden(gatewayPath.Child("path"), "a path prefix is only supported by the Jaeger Query UI"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateIngressTLSAndPaths(tc.input))
+		})
+	}
+}
+
+func TestValidateGRPCServer(t *testing.T) {
+	path := field.NewPath("spec").Child("grpcServer")
+
+	tt := []struct {
+		name     string
+		input    *GRPCServerSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "valid settings",
+			input: &GRPCServerSpec{
+				MaxRecvMsgSizeMiB:     pointer.Int(16),
+				MaxSendMsgSizeMiB:     pointer.Int(16),
+				KeepaliveTime:         &metav1.Duration{Duration: 2 * time.Hour},
+				KeepaliveTimeout:      &metav1.Duration{Duration: 20 * time.Second},
+				MaxConnectionAge:      &metav1.Duration{Duration: 5 * time.Minute},
+				MaxConnectionAgeGrace: &metav1.Duration{Duration: time.Minute},
+			},
+		},
+		{
+			name: "invalid settings",
+			input: &GRPCServerSpec{
+				MaxSendMsgSizeMiB: pointer.Int(0),
+				KeepaliveTimeout:  &metav1.Duration{Duration: -time.Second},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("keepaliveTimeout"), "-1s", "the duration must be positive"),
+				field.Invalid(path.Child("maxSendMsgSizeMiB"), 0, "the value must be positive"),
+			},
+		},
+		{
+			name: "grace period without maximum connection age",
+			input: &GRPCServerSpec{
+				MaxConnectionAgeGrace: &metav1.Duration{Duration: time.Minute},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("maxConnectionAgeGrace"), "1m0s", "the grace period requires maxConnectionAge"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateGRPCServer(TempoStack{Spec: TempoStackSpec{GRPCServer: tc.input}}))
+		})
+	}
+}
+
+func TestValidateServiceMesh(t *testing.T) {
+	path := field.NewPath("spec").Child("serviceMesh").Child("enabled")
+
+	tt := []struct {
+		name     string
+		input    TempoStackSpec
+		expected field.ErrorList
+	}{
+		{
+			name:  "service mesh enabled",
+			input: TempoStackSpec{ServiceMesh: &ServiceMeshSpec{Enabled: true}},
+		},
+		{
+			name: "service mesh disabled with cert-manager",
+			input: TempoStackSpec{
+				ServiceMesh: &ServiceMeshSpec{},
+				CertManager: &CertManagerSpec{IssuerRef: CertManagerIssuerReference{Name: "issuer"}},
+			},
+		},
+		{
+			name: "service mesh with cert-manager and SPIFFE",
+			input: TempoStackSpec{
+				ServiceMesh: &ServiceMeshSpec{Enabled: true},
+				CertManager: &CertManagerSpec{IssuerRef: CertManagerIssuerReference{Name: "issuer"}},
+				SPIFFE:      &SPIFFESpec{},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path, true, "the service mesh cannot be combined with spec.certManager, the traffic is encrypted by the sidecars"),
+				field.Invalid(path, true, "the service mesh cannot be combined with spec.spiffe, the traffic is encrypted by the sidecars"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateServiceMesh(TempoStack{Spec: tc.input}))
+		})
+	}
+}
+
+func TestValidateServiceTopology(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    *ServiceTopologySpec
+		expected field.ErrorList
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:  "topology aware routing",
+			input: &ServiceTopologySpec{TopologyAwareRouting: true, InternalTrafficPolicy: corev1.ServiceInternalTrafficPolicyCluster},
+		},
+		{
+			name:  "local internal traffic policy",
+			input: &ServiceTopologySpec{InternalTrafficPolicy: corev1.ServiceInternalTrafficPolicyLocal},
+		},
+		{
+			name:  "topology aware routing with local internal traffic policy",
+			input: &ServiceTopologySpec{TopologyAwareRouting: true, InternalTrafficPolicy: corev1.ServiceInternalTrafficPolicyLocal},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("serviceTopology").Child("topologyAwareRouting"), true,
+					"topology aware routing cannot be combined with the Local internal traffic policy"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateServiceTopology(TempoStack{Spec: TempoStackSpec{ServiceTopology: tc.input}}))
+		})
+	}
+}
+
+func TestValidateOpenTelemetryCollector(t *testing.T) {
+	path := field.NewPath("spec").Child("openTelemetryCollector")
+	tenants := &TenantsSpec{
+		Mode: ModeOpenShift,
+		Authentication: []AuthenticationSpec{
+			{TenantName: "dev", TenantID: "1610b0c3-c509-4592-a256-a1871353dbfa"},
+		},
+	}
+	gateway := TempoGatewaySpec{Enabled: true}
+
+	tt := []struct {
+		name     string
+		input    TempoStackSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:  "single tenant",
+			input: TempoStackSpec{OpenTelemetryCollector: &OpenTelemetryCollectorSpec{}},
+		},
+		{
+			name: "tenant without multi-tenancy",
+			input: TempoStackSpec{
+				OpenTelemetryCollector: &OpenTelemetryCollectorSpec{Tenant: "dev"},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("tenant"), "dev", "the tenant can only be set if multi-tenancy is enabled"),
+			},
+		},
+		{
+			name: "openshift mode",
+			input: TempoStackSpec{
+				Tenants:                tenants,
+				Template:               TempoTemplateSpec{Gateway: gateway},
+				OpenTelemetryCollector: &OpenTelemetryCollectorSpec{Tenant: "dev"},
+			},
+		},
+		{
+			name: "missing tenant",
+			input: TempoStackSpec{
+				Tenants:                tenants,
+				Template:               TempoTemplateSpec{Gateway: gateway},
+				OpenTelemetryCollector: &OpenTelemetryCollectorSpec{},
+			},
+			expected: field.ErrorList{
+				field.Required(path.Child("tenant"), "the tenant is required if multi-tenancy is enabled"),
+			},
+		},
+		{
+			name: "unknown tenant",
+			input: TempoStackSpec{
+				Tenants:                tenants,
+				Template:               TempoTemplateSpec{Gateway: gateway},
+				OpenTelemetryCollector: &OpenTelemetryCollectorSpec{Tenant: "prod"},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("tenant"), "prod", "the tenant must be configured in spec.tenants.authentication"),
+			},
+		},
+		{
+			name: "static mode",
+			input: TempoStackSpec{
+				Tenants: &TenantsSpec{
+					Mode:           ModeStatic,
+					Authentication: tenants.Authentication,
+				},
+				Template:               TempoTemplateSpec{Gateway: gateway},
+				OpenTelemetryCollector: &OpenTelemetryCollectorSpec{Tenant: "dev"},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("tenants").Child("mode"), ModeStatic,
+					"the OpenTelemetry Collector is not supported in static mode, the collector cannot authenticate with the OIDC provider"),
+			},
+		},
+		{
+			name: "receivers with client certificates",
+			input: TempoStackSpec{
+				Template: TempoTemplateSpec{
+					Distributor: TempoDistributorSpec{
+						TLS: ReceiversTLSSpec{Enabled: true, CA: "ca"},
+					},
+				},
+				OpenTelemetryCollector: &OpenTelemetryCollectorSpec{},
+			},
+			expected: field.ErrorList{
+				field.Forbidden(path, "the OpenTelemetry Collector cannot be combined with receivers which require client certificates"),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateOpenTelemetryCollector(TempoStack{Spec: tc.input}))
+		})
+	}
+}
+
+func TestWebhooksSkipUnwatchedNamespaces(t *testing.T) {
+	ctrlConfig := v1alpha1.ProjectConfig{WatchNamespaces: []string{"team-a"}}
+	tempo := &TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "team-b",
+		},
+	}
+
+	err := NewDefaulter(ctrlConfig).Default(context.Background(), tempo)
+	assert.NoError(t, err)
+	assert.Equal(t, &TempoStack{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "team-b"}}, tempo)
+
+	v := &validator{ctrlConfig: ctrlConfig}
+	warnings, err := v.ValidateCreate(context.Background(), tempo)
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestValidateFIPS(t *testing.T) {
+	ctrlConfig := v1alpha1.ProjectConfig{
+		Gates: v1alpha1.FeatureGates{FIPS: true},
+		DefaultImages: v1alpha1.ImagesSpec{
+			Tempo: "docker.io/grafana/tempo:x.y.z",
+		},
+	}
+
+	tt := []struct {
+		name       string
+		ctrlConfig v1alpha1.ProjectConfig
+		input      TempoStackSpec
+		expected   field.ErrorList
+	}{
+		{
+			name: "fips feature gate disabled",
+			input: TempoStackSpec{
+				TLSProfile: &TLSProfileSpec{Type: v1alpha1.TLSProfileOldType},
+			},
+		},
+		{
+			name:       "default images and modern TLS profile",
+			ctrlConfig: ctrlConfig,
+			input: TempoStackSpec{
+				Images:     v1alpha1.ImagesSpec{Tempo: "docker.io/grafana/tempo:x.y.z"},
+				TLSProfile: &TLSProfileSpec{Type: v1alpha1.TLSProfileModernType},
+			},
+		},
+		{
+			name:       "old TLS profile",
+			ctrlConfig: ctrlConfig,
+			input: TempoStackSpec{
+				TLSProfile: &TLSProfileSpec{Type: v1alpha1.TLSProfileOldType},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("tlsProfile").Child("type"), v1alpha1.TLSProfileOldType,
+					"the Old TLS profile is not supported when the fips feature gate is enabled"),
+			},
+		},
 		{
-			name: "createPrometheusRules and createServiceMonitors enabled and prometheusOperator feature gate set",
-			input: TempoStack{
-				Spec: TempoStackSpec{
-					Observability: ObservabilitySpec{
-						Metrics: MetricsConfigSpec{
-							CreateServiceMonitors: true,
-							CreatePrometheusRules: true,
-						},
-					},
+			name:       "custom TLS profile",
+			ctrlConfig: ctrlConfig,
+			input: TempoStackSpec{
+				TLSProfile: &TLSProfileSpec{
+					Type:          v1alpha1.TLSProfileCustomType,
+					Ciphers:       []string{"ECDHE-RSA-AES128-GCM-SHA256", "ECDHE-RSA-CHACHA20-POLY1305"},
+					MinTLSVersion: "VersionTLS11",
 				},
 			},
-			ctrlConfig: v1alpha1.ProjectConfig{
-				Gates: v1alpha1.FeatureGates{
-					PrometheusOperator: true,
-				},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("tlsProfile").Child("ciphers"), []string{"ECDHE-RSA-CHACHA20-POLY1305"},
+					"the cipher suites are not FIPS approved"),
+				field.Invalid(field.NewPath("spec").Child("tlsProfile").Child("minTLSVersion"), "VersionTLS11",
+					"the minimal TLS version must be VersionTLS12 or VersionTLS13 when the fips feature gate is enabled"),
 			},
-			expected: nil,
 		},
 		{
-			name: "createPrometheusRules enabled but createServiceMonitors not enabled",
-			input: TempoStack{
-				Spec: TempoStackSpec{
-					Observability: ObservabilitySpec{
-						Metrics: MetricsConfigSpec{
-							CreatePrometheusRules: true,
+			name:       "receivers TLS version",
+			ctrlConfig: ctrlConfig,
+			input: TempoStackSpec{
+				Template: TempoTemplateSpec{
+					Distributor: TempoDistributorSpec{
+						TLS: ReceiversTLSSpec{
+							Enabled: true,
+							Zipkin:  &ReceiverTLSOverrideSpec{MinTLSVersion: "VersionTLS10"},
 						},
 					},
 				},
 			},
-			ctrlConfig: v1alpha1.ProjectConfig{
-				Gates: v1alpha1.FeatureGates{
-					PrometheusOperator: true,
-				},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("template").Child("distributor").Child("tls").Child("zipkin").Child("minTLSVersion"), "VersionTLS10",
+					"the minimal TLS version must be VersionTLS12 or VersionTLS13 when the fips feature gate is enabled"),
+			},
+		},
+		{
+			name:       "custom image",
+			ctrlConfig: ctrlConfig,
+			input: TempoStackSpec{
+				Images: v1alpha1.ImagesSpec{Tempo: "docker.io/grafana/tempo:custom"},
 			},
 			expected: field.ErrorList{
-				field.Invalid(
-					metricsBase.Child("createPrometheusRules"),
-					true,
-					"the Prometheus rules alert based on collected metrics, therefore the createServiceMonitors feature must be enabled when enabling the createPrometheusRules feature",
-				),
+				field.Invalid(field.NewPath("spec").Child("images").Child("tempo"), "docker.io/grafana/tempo:custom",
+					"custom container images are not supported when the fips feature gate is enabled"),
 			},
 		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{ctrlConfig: tc.ctrlConfig}
+			assert.Equal(t, tc.expected, v.validateFIPS(TempoStack{Spec: tc.input}))
+		})
+	}
+}
+
+func TestValidateBackup(t *testing.T) {
+	path := field.NewPath("spec").Child("backup")
+	storage := ObjectStorageSpec{
+		Secret: ObjectStorageSecretSpec{Name: "storage", Type: ObjectStorageSecretS3},
+	}
+
+	tt := []struct {
+		name     string
+		input    TempoStackSpec
+		expected field.ErrorList
+	}{
+		{
+			name:  "no backup",
+			input: TempoStackSpec{Storage: storage},
+		},
 		{
-			name: "sampling fraction not a float",
-			input: TempoStack{
-				Spec: TempoStackSpec{
-					Observability: ObservabilitySpec{
-						Tracing: TracingConfigSpec{
-							SamplingFraction: "a",
-						},
-					},
+			name: "valid backup",
+			input: TempoStackSpec{
+				Storage: storage,
+				Backup: &BackupSpec{
+					Schedule: "0 1 * * *",
+					Secret:   ObjectStorageSecretSpec{Name: "backup", Type: ObjectStorageSecretGCS},
 				},
 			},
+		},
+		{
+			name: "missing schedule and secret",
+			input: TempoStackSpec{
+				Storage: storage,
+				Backup:  &BackupSpec{Secret: ObjectStorageSecretSpec{Type: ObjectStorageSecretS3}},
+			},
 			expected: field.ErrorList{
-				field.Invalid(
-					tracingBase.Child("sampling_fraction"),
-					"a",
-					"strconv.ParseFloat: parsing \"a\": invalid syntax",
-				),
+				field.Required(path.Child("schedule"), "the schedule of the backup is required"),
+				field.Required(path.Child("secret").Child("name"), "the secret of the backup bucket is required"),
 			},
 		},
 		{
-			name: "invalid jaeger agent address",
-			input: TempoStack{
-				Spec: TempoStackSpec{
-					Observability: ObservabilitySpec{
-						Tracing: TracingConfigSpec{
-							SamplingFraction:    "0.5",
-							JaegerAgentEndpoint: "--invalid--",
-						},
-					},
+			name: "storage secret",
+			input: TempoStackSpec{
+				Storage: storage,
+				Backup: &BackupSpec{
+					Schedule: "0 1 * * *",
+					Secret:   ObjectStorageSecretSpec{Name: "storage", Type: "swift"},
 				},
 			},
 			expected: field.ErrorList{
-				field.Invalid(
-					tracingBase.Child("jaeger_agent_endpoint"),
-					"--invalid--",
-					"address --invalid--: missing port in address",
-				),
+				field.Invalid(path.Child("secret").Child("name"), "storage", "the backup bucket must be different from the storage bucket"),
+				field.NotSupported(path.Child("secret").Child("type"), ObjectStorageSecretType("swift"), []string{"azure", "gcs", "s3"}),
 			},
 		},
 		{
-			name: "valid configuration",
-			input: TempoStack{
-				Spec: TempoStackSpec{
-					Observability: ObservabilitySpec{
-						Tracing: TracingConfigSpec{
-							SamplingFraction:    "0.5",
-							JaegerAgentEndpoint: "agent:1234",
-						},
-					},
+			name: "secret provider class",
+			input: TempoStackSpec{
+				Storage: ObjectStorageSpec{
+					Secret:              ObjectStorageSecretSpec{Name: "storage", Type: ObjectStorageSecretS3},
+					SecretProviderClass: "aws",
+				},
+				Backup: &BackupSpec{
+					Schedule: "0 1 * * *",
+					Secret:   ObjectStorageSecretSpec{Name: "backup", Type: ObjectStorageSecretS3},
 				},
 			},
+			expected: field.ErrorList{
+				field.Forbidden(path, "the backup is not supported if the storage credentials are provided by a secret provider class"),
+			},
 		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			v := &validator{ctrlConfig: tc.ctrlConfig}
-			assert.Equal(t, tc.expected, v.validateObservability(tc.input))
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateBackup(TempoStack{Spec: tc.input}))
 		})
 	}
 }
 
-func TestValidatorValidate(t *testing.T) {
+func TestValidateStorageMigration(t *testing.T) {
+	path := field.NewPath("spec").Child("storage").Child("migrateFrom")
 
-	gvType := metav1.TypeMeta{
-		APIVersion: "testv1",
-		Kind:       "something",
-	}
 	tt := []struct {
 		name     string
-		input    runtime.Object
-		expected error
+		input    ObjectStorageSpec
+		expected field.ErrorList
 	}{
 		{
-			name:     "not a tempo object",
-			input:    new(corev1.Pod),
-			expected: apierrors.NewBadRequest("expected a TempoStack object but got *v1.Pod"),
+			name:  "no migration",
+			input: ObjectStorageSpec{Secret: ObjectStorageSecretSpec{Name: "storage", Type: ObjectStorageSecretS3}},
 		},
 		{
-			name: "pass all validators",
-			input: &TempoStack{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-obj",
-					Namespace: "abc",
-				},
-				TypeMeta: gvType,
-				Spec: TempoStackSpec{
-					ServiceAccount: naming.DefaultServiceAccountName("test-obj"),
-					Storage: ObjectStorageSpec{
-						Secret: ObjectStorageSecretSpec{
-							Name: "not-found",
-						},
-					},
-					Template: TempoTemplateSpec{
-						Ingester: TempoComponentSpec{
-							Replicas: func(i int32) *int32 { return &i }(1),
-						},
-					},
-				},
+			name: "valid migration",
+			input: ObjectStorageSpec{
+				Secret:      ObjectStorageSecretSpec{Name: "storage", Type: ObjectStorageSecretS3},
+				MigrateFrom: &ObjectStorageSecretSpec{Name: "old-storage", Type: ObjectStorageSecretAzure},
+			},
+		},
+		{
+			name: "storage secret",
+			input: ObjectStorageSpec{
+				Secret:      ObjectStorageSecretSpec{Name: "storage", Type: ObjectStorageSecretS3},
+				MigrateFrom: &ObjectStorageSecretSpec{Name: "storage", Type: "swift"},
+			},
+			expected: field.ErrorList{
+				field.Invalid(path.Child("name"), "storage", "the secret of the previous object storage must be different from the storage secret"),
+				field.NotSupported(path.Child("type"), ObjectStorageSecretType("swift"), []string{"azure", "gcs", "s3"}),
+			},
+		},
+		{
+			name: "secret provider class",
+			input: ObjectStorageSpec{
+				Secret:              ObjectStorageSecretSpec{Name: "storage", Type: ObjectStorageSecretS3},
+				SecretProviderClass: "aws",
+				MigrateFrom:         &ObjectStorageSecretSpec{Type: ObjectStorageSecretS3},
+			},
+			expected: field.ErrorList{
+				field.Required(path.Child("name"), "the secret of the previous object storage is required"),
+				field.Forbidden(path, "the migration is not supported if the storage credentials are provided by a secret provider class"),
 			},
 		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			v := &validator{ctrlConfig: v1alpha1.ProjectConfig{}, client: &k8sFake{}}
-			_, err := v.validate(context.Background(), tc.input)
-			assert.Equal(t, tc.expected, err)
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateStorageMigration(TempoStack{Spec: TempoStackSpec{Storage: tc.input}}))
 		})
 	}
 }
 
-func TestValidateName(t *testing.T) {
-
-	longName := "tgqwkjwqkehkqjwhekjwqhekjhwkjehwkqjehkjqwhekjqwhekjqhwkjehkqwj" +
-		"554678789021123234554678789021123234554678789021123234554678" +
-		"tgqwkjwqkehkqjwhekjwqhekjhwkjehwkqjehkjqwhekjqwhekjqhwkjehkqwj"
+func TestValidateCLIJobs(t *testing.T) {
+	path := field.NewPath("spec").Child("cliJobs")
 
 	tt := []struct {
 		name     string
-		input    TempoStack
+		input    TempoStackSpec
 		expected field.ErrorList
 	}{
 		{
-			name: "all good",
-			input: TempoStack{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-obj",
-					Namespace: "abc",
+			name: "valid jobs",
+			input: TempoStackSpec{
+				CLIJobs: []TempoCLIJobSpec{
+					{Name: "list", Command: TempoCLIListBlocks},
+					{Name: "search", Command: TempoCLISearchBlocks, Attribute: "service.name=frontend"},
 				},
 			},
 		},
 		{
-			name: "too long",
-			input: TempoStack{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      longName,
-					Namespace: "abc",
+			name: "invalid jobs",
+			input: TempoStackSpec{
+				CLIJobs: []TempoCLIJobSpec{
+					{Name: "analyse", Command: TempoCLIAnalyseBlock},
+					{Name: "analyse", Command: TempoCLISearchBlocks, Attribute: "frontend"},
+					{Name: "Compact", Command: "compact"},
 				},
 			},
 			expected: field.ErrorList{
-				field.Invalid(
-					field.NewPath("metadata").Child("name"),
-					longName,
-					fmt.Sprintf("must be no more than %d characters", maxLabelLength),
-				)},
+				field.Required(path.Index(0).Child("blockID"), "the block ID is required by the analyse-block command"),
+				field.Duplicate(path.Index(1).Child("name"), "analyse"),
+				field.Invalid(path.Index(1).Child("attribute"), "frontend", "the attribute must be in the format name=value for the search-blocks command"),
+				field.Invalid(path.Index(2).Child("name"), "Compact",
+					"the name of the Job tempo-simplest-cli-Compact is invalid: a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"),
+				field.NotSupported(path.Index(2).Child("command"), TempoCLICommand("compact"), []string{"list-blocks", "analyse-block", "search-blocks", "gen-index"}),
+			},
+		},
+		{
+			name: "multi-tenancy",
+			input: TempoStackSpec{
+				Tenants: &TenantsSpec{Mode: ModeOpenShift},
+				CLIJobs: []TempoCLIJobSpec{{Name: "index", Command: TempoCLIGenIndex}},
+			},
+			expected: field.ErrorList{
+				field.Required(path.Index(0).Child("tenant"), "the tenant is required if multi-tenancy is enabled"),
+			},
 		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			v := &validator{ctrlConfig: v1alpha1.ProjectConfig{}, client: &k8sFake{}}
-			assert.Equal(t, tc.expected, v.validateStackName(tc.input))
+			v := &validator{}
+			tempo := TempoStack{ObjectMeta: metav1.ObjectMeta{Name: "simplest"}, Spec: tc.input}
+			assert.Equal(t, tc.expected, v.validateCLIJobs(tempo))
 		})
 	}
 }
 
-func TestValidateDeprecatedFields(t *testing.T) {
-	zero := 0
-	one := 1
+func TestValidateTenantPurges(t *testing.T) {
+	path := field.NewPath("spec").Child("tenantPurges")
+	tenantMsg := "it must consist of at most 150 alphanumeric characters or !-_.*'(), and must not be . or .."
 
 	tt := []struct {
 		name     string
-		input    TempoStack
+		input    TempoStackSpec
 		expected field.ErrorList
 	}{
 		{
-			name:  "no deprecated fields",
-			input: TempoStack{},
+			name: "valid purges",
+			input: TempoStackSpec{
+				TenantPurges: []TenantPurgeSpec{
+					{Name: "dev", Tenant: "dev"},
+					{Name: "single-tenant", Tenant: "single-tenant"},
+				},
+			},
 		},
 		{
-			name: "deprecated global maxSearchBytesPerTrace set to 0",
-			input: TempoStack{
-				Spec: TempoStackSpec{
-					LimitSpec: LimitSpec{
-						Global: RateLimitSpec{
-							Query: QueryLimit{
-								MaxSearchBytesPerTrace: &zero,
-							},
-						},
-					},
+			name: "invalid purges",
+			input: TempoStackSpec{
+				TenantPurges: []TenantPurgeSpec{
+					{Name: "dev"},
+					{Name: "dev", Tenant: ".."},
+					{Name: "root", Tenant: "/"},
 				},
 			},
 			expected: field.ErrorList{
-				field.Invalid(
-					field.NewPath("spec", "limits", "global", "query", "maxSearchBytesPerTrace"),
-					&zero,
-					"this field is deprecated and must be unset",
-				),
+				field.Required(path.Index(0).Child("tenant"), "the tenant is required"),
+				field.Duplicate(path.Index(1).Child("name"), "dev"),
+				field.Invalid(path.Index(1).Child("tenant"), "..", tenantMsg),
+				field.Invalid(path.Index(2).Child("tenant"), "/", tenantMsg),
 			},
 		},
 		{
-			name: "deprecated global maxSearchBytesPerTrace set to 1",
-			input: TempoStack{
-				Spec: TempoStackSpec{
-					LimitSpec: LimitSpec{
-						Global: RateLimitSpec{
-							Query: QueryLimit{
-								MaxSearchBytesPerTrace: &one,
-							},
-						},
-					},
-				},
+			name: "secret provider class",
+			input: TempoStackSpec{
+				Storage:      ObjectStorageSpec{SecretProviderClass: "aws"},
+				TenantPurges: []TenantPurgeSpec{{Name: "dev", Tenant: "dev"}},
 			},
 			expected: field.ErrorList{
-				field.Invalid(
-					field.NewPath("spec", "limits", "global", "query", "maxSearchBytesPerTrace"),
-					&one,
-					"this field is deprecated and must be unset",
-				),
+				field.Forbidden(path, "the tenant purges are not supported if the storage credentials are provided by a secret provider class"),
 			},
 		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &validator{}
+			tempo := TempoStack{ObjectMeta: metav1.ObjectMeta{Name: "simplest"}, Spec: tc.input}
+			assert.Equal(t, tc.expected, v.validateTenantPurges(tempo))
+		})
+	}
+}
+
+func TestValidateCanary(t *testing.T) {
+	path := field.NewPath("spec").Child("canary")
+
+	tt := []struct {
+		name     string
+		input    TempoStackSpec
+		expected field.ErrorList
+	}{
 		{
-			name: "deprecated per-tenant maxSearchBytesPerTrace set to 0",
-			input: TempoStack{
-				Spec: TempoStackSpec{
-					LimitSpec: LimitSpec{
-						PerTenant: map[string]RateLimitSpec{
-							"tenant1": {
-								Query: QueryLimit{
-									MaxSearchBytesPerTrace: &zero,
-								},
-							},
-						},
+			name:  "no canary",
+			input: TempoStackSpec{},
+		},
+		{
+			name: "valid canary",
+			input: TempoStackSpec{
+				Tenants: &TenantsSpec{Mode: ModeTrustedHeader},
+				Canary:  &CanarySpec{Tenant: "dev", Interval: metav1.Duration{Duration: time.Minute}},
+			},
+		},
+		{
+			name: "multi-tenancy without tenant",
+			input: TempoStackSpec{
+				Tenants: &TenantsSpec{Mode: ModeTrustedHeader},
+				Canary:  &CanarySpec{Interval: metav1.Duration{Duration: time.Second}},
+			},
+			expected: field.ErrorList{
+				field.Required(path.Child("tenant"), "the tenant is required if multi-tenancy is enabled"),
+				field.Invalid(path.Child("interval"), "1s", "the interval must be at least 10s"),
+			},
+		},
+		{
+			name: "gateway and custom receiver certificate",
+			input: TempoStackSpec{
+				Template: TempoTemplateSpec{
+					Gateway: TempoGatewaySpec{Enabled: true},
+					Distributor: TempoDistributorSpec{
+						TLS: ReceiversTLSSpec{Enabled: true, OTLPHTTP: &ReceiverTLSOverrideSpec{CertName: "receiver-cert"}},
 					},
 				},
+				Canary: &CanarySpec{Tenant: ".."},
 			},
 			expected: field.ErrorList{
-				field.Invalid(
-					field.NewPath("spec.limits.perTenant[tenant1].query.maxSearchBytesPerTrace"),
-					&zero,
-					"this field is deprecated and must be unset",
-				),
+				field.Forbidden(path, "the canary is not supported if the gateway is enabled"),
+				field.Invalid(path.Child("tenant"), "..", "it must consist of at most 150 alphanumeric characters or !-_.*'(), and must not be . or .."),
+				field.Forbidden(path, "the canary is not supported if the OTLP HTTP receiver uses a custom certificate or client CA"),
 			},
 		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			v := &validator{ctrlConfig: v1alpha1.ProjectConfig{}}
-			assert.Equal(t, tc.expected, v.validateDeprecatedFields(tc.input))
+			v := &validator{}
+			assert.Equal(t, tc.expected, v.validateCanary(TempoStack{Spec: tc.input}))
 		})
 	}
 }
-
-type k8sFake struct {
-	client.Client
-}
-
-func (*k8sFake) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
-	return fmt.Errorf("mock: fails always")
-}
@@ -91,7 +91,7 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
-	err = (&TempoStack{}).SetupWebhookWithManager(mgr, v1alpha1.ProjectConfig{})
+	err = (&TempoStack{}).SetupWebhookWithManager(mgr, v1alpha1.ProjectConfig{}, nil)
 	Expect(err).NotTo(HaveOccurred())
 
 	//+kubebuilder:scaffold:webhook
@@ -6,9 +6,11 @@
 package v1alpha1
 
 import (
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/api/autoscaling/v2"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -19,6 +21,26 @@ func (in *AuthenticationSpec) DeepCopyInto(out *AuthenticationSpec) {
 		*out = new(OIDCSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MTLS != nil {
+		in, out := &in.MTLS, &out.MTLS
+		*out = new(MTLSSpec)
+		**out = **in
+	}
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(RateLimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(RetentionConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationSpec.
@@ -48,6 +70,11 @@ func (in *AuthorizationSpec) DeepCopyInto(out *AuthorizationSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(OPAPolicySpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationSpec.
@@ -60,6 +87,162 @@ func (in *AuthorizationSpec) DeepCopy() *AuthorizationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSpec) DeepCopyInto(out *AutoscalingSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilization != nil {
+		in, out := &in.TargetCPUUtilization, &out.TargetCPUUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilization != nil {
+		in, out := &in.TargetMemoryUtilization, &out.TargetMemoryUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2.MetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Keda != nil {
+		in, out := &in.Keda, &out.Keda
+		*out = new(KedaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSpec.
+func (in *AutoscalingSpec) DeepCopy() *AutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSpec) DeepCopyInto(out *BackupSpec) {
+	*out = *in
+	out.Secret = in.Secret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSpec.
+func (in *BackupSpec) DeepCopy() *BackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CacheSpec) DeepCopyInto(out *CacheSpec) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Timeout = in.Timeout
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(CacheTLSSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheSpec.
+func (in *CacheSpec) DeepCopy() *CacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CacheTLSSpec) DeepCopyInto(out *CacheTLSSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheTLSSpec.
+func (in *CacheTLSSpec) DeepCopy() *CacheTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CacheTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanarySpec) DeepCopyInto(out *CanarySpec) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanarySpec.
+func (in *CanarySpec) DeepCopy() *CanarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CanarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerReference) DeepCopyInto(out *CertManagerIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerReference.
+func (in *CertManagerIssuerReference) DeepCopy() *CertManagerIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerSpec) DeepCopyInto(out *CertManagerSpec) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerSpec.
+func (in *CertManagerSpec) DeepCopy() *CertManagerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 	*out = *in
@@ -153,6 +336,21 @@ func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.MetricsGenerator != nil {
+		in, out := &in.MetricsGenerator, &out.MetricsGenerator
+		*out = make(PodStatusMap, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
@@ -165,6 +363,21 @@ func (in *ComponentStatus) DeepCopy() *ComponentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsulSpec) DeepCopyInto(out *ConsulSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulSpec.
+func (in *ConsulSpec) DeepCopy() *ConsulSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsulSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Defaulter) DeepCopyInto(out *Defaulter) {
 	*out = *in
@@ -182,510 +395,1953 @@ func (in *Defaulter) DeepCopy() *Defaulter {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngestionLimitSpec) DeepCopyInto(out *IngestionLimitSpec) {
+func (in *EtcdSpec) DeepCopyInto(out *EtcdSpec) {
 	*out = *in
-	if in.IngestionBurstSizeBytes != nil {
-		in, out := &in.IngestionBurstSizeBytes, &out.IngestionBurstSizeBytes
-		*out = new(int)
-		**out = **in
-	}
-	if in.IngestionRateLimitBytes != nil {
-		in, out := &in.IngestionRateLimitBytes, &out.IngestionRateLimitBytes
-		*out = new(int)
-		**out = **in
-	}
-	if in.MaxBytesPerTrace != nil {
-		in, out := &in.MaxBytesPerTrace, &out.MaxBytesPerTrace
-		*out = new(int)
-		**out = **in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.MaxTracesPerUser != nil {
-		in, out := &in.MaxTracesPerUser, &out.MaxTracesPerUser
-		*out = new(int)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(EtcdTLSSpec)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngestionLimitSpec.
-func (in *IngestionLimitSpec) DeepCopy() *IngestionLimitSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSpec.
+func (in *EtcdSpec) DeepCopy() *EtcdSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IngestionLimitSpec)
+	out := new(EtcdSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
+func (in *EtcdTLSSpec) DeepCopyInto(out *EtcdTLSSpec) {
 	*out = *in
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.IngressClassName != nil {
-		in, out := &in.IngressClassName, &out.IngressClassName
-		*out = new(string)
-		**out = **in
-	}
-	out.Route = in.Route
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSpec.
-func (in *IngressSpec) DeepCopy() *IngressSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdTLSSpec.
+func (in *EtcdTLSSpec) DeepCopy() *EtcdTLSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressSpec)
+	out := new(EtcdTLSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JaegerQueryMonitor) DeepCopyInto(out *JaegerQueryMonitor) {
+func (in *ExtraConfigSpec) DeepCopyInto(out *ExtraConfigSpec) {
 	*out = *in
+	in.Tempo.DeepCopyInto(&out.Tempo)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JaegerQueryMonitor.
-func (in *JaegerQueryMonitor) DeepCopy() *JaegerQueryMonitor {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraConfigSpec.
+func (in *ExtraConfigSpec) DeepCopy() *ExtraConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(JaegerQueryMonitor)
+	out := new(ExtraConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JaegerQuerySpec) DeepCopyInto(out *JaegerQuerySpec) {
+func (in *FeatureGatesSpec) DeepCopyInto(out *FeatureGatesSpec) {
 	*out = *in
-	in.Ingress.DeepCopyInto(&out.Ingress)
-	out.MonitorTab = in.MonitorTab
+	if in.HTTPEncryption != nil {
+		in, out := &in.HTTPEncryption, &out.HTTPEncryption
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GRPCEncryption != nil {
+		in, out := &in.GRPCEncryption, &out.GRPCEncryption
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OpenShiftRoute != nil {
+		in, out := &in.OpenShiftRoute, &out.OpenShiftRoute
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PrometheusOperator != nil {
+		in, out := &in.PrometheusOperator, &out.PrometheusOperator
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JaegerQuerySpec.
-func (in *JaegerQuerySpec) DeepCopy() *JaegerQuerySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureGatesSpec.
+func (in *FeatureGatesSpec) DeepCopy() *FeatureGatesSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(JaegerQuerySpec)
+	out := new(FeatureGatesSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LimitSpec) DeepCopyInto(out *LimitSpec) {
+func (in *GRPCServerSpec) DeepCopyInto(out *GRPCServerSpec) {
 	*out = *in
-	if in.PerTenant != nil {
-		in, out := &in.PerTenant, &out.PerTenant
-		*out = make(map[string]RateLimitSpec, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
+	if in.MaxRecvMsgSizeMiB != nil {
+		in, out := &in.MaxRecvMsgSizeMiB, &out.MaxRecvMsgSizeMiB
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxSendMsgSizeMiB != nil {
+		in, out := &in.MaxSendMsgSizeMiB, &out.MaxSendMsgSizeMiB
+		*out = new(int)
+		**out = **in
+	}
+	if in.KeepaliveTime != nil {
+		in, out := &in.KeepaliveTime, &out.KeepaliveTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.KeepaliveTimeout != nil {
+		in, out := &in.KeepaliveTimeout, &out.KeepaliveTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxConnectionAge != nil {
+		in, out := &in.MaxConnectionAge, &out.MaxConnectionAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxConnectionAgeGrace != nil {
+		in, out := &in.MaxConnectionAgeGrace, &out.MaxConnectionAgeGrace
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	in.Global.DeepCopyInto(&out.Global)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LimitSpec.
-func (in *LimitSpec) DeepCopy() *LimitSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCServerSpec.
+func (in *GRPCServerSpec) DeepCopy() *GRPCServerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LimitSpec)
+	out := new(GRPCServerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricsConfigSpec) DeepCopyInto(out *MetricsConfigSpec) {
+func (in *GatewayAccessReviewCacheSpec) DeepCopyInto(out *GatewayAccessReviewCacheSpec) {
 	*out = *in
+	out.TTL = in.TTL
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsConfigSpec.
-func (in *MetricsConfigSpec) DeepCopy() *MetricsConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayAccessReviewCacheSpec.
+func (in *GatewayAccessReviewCacheSpec) DeepCopy() *GatewayAccessReviewCacheSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricsConfigSpec)
+	out := new(GatewayAccessReviewCacheSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OIDCSpec) DeepCopyInto(out *OIDCSpec) {
+func (in *GatewayAuditLogSpec) DeepCopyInto(out *GatewayAuditLogSpec) {
 	*out = *in
-	if in.Secret != nil {
-		in, out := &in.Secret, &out.Secret
-		*out = new(TenantSecretSpec)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCSpec.
-func (in *OIDCSpec) DeepCopy() *OIDCSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayAuditLogSpec.
+func (in *GatewayAuditLogSpec) DeepCopy() *GatewayAuditLogSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDCSpec)
+	out := new(GatewayAuditLogSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectStorageSecretSpec) DeepCopyInto(out *ObjectStorageSecretSpec) {
+func (in *GatewayRateLimitSpec) DeepCopyInto(out *GatewayRateLimitSpec) {
 	*out = *in
+	out.Window = in.Window
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageSecretSpec.
-func (in *ObjectStorageSecretSpec) DeepCopy() *ObjectStorageSecretSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayRateLimitSpec.
+func (in *GatewayRateLimitSpec) DeepCopy() *GatewayRateLimitSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectStorageSecretSpec)
+	out := new(GatewayRateLimitSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectStorageSpec) DeepCopyInto(out *ObjectStorageSpec) {
+func (in *GrafanaConfigSpec) DeepCopyInto(out *GrafanaConfigSpec) {
 	*out = *in
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(ObjectStorageTLSSpec)
-		**out = **in
+	if in.DashboardsLabels != nil {
+		in, out := &in.DashboardsLabels, &out.DashboardsLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InstanceSelector != nil {
+		in, out := &in.InstanceSelector, &out.InstanceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
-	out.Secret = in.Secret
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageSpec.
-func (in *ObjectStorageSpec) DeepCopy() *ObjectStorageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaConfigSpec.
+func (in *GrafanaConfigSpec) DeepCopy() *GrafanaConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectStorageSpec)
+	out := new(GrafanaConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectStorageTLSSpec) DeepCopyInto(out *ObjectStorageTLSSpec) {
+func (in *IngestionLimitSpec) DeepCopyInto(out *IngestionLimitSpec) {
 	*out = *in
+	if in.IngestionBurstSizeBytes != nil {
+		in, out := &in.IngestionBurstSizeBytes, &out.IngestionBurstSizeBytes
+		*out = new(int)
+		**out = **in
+	}
+	if in.IngestionRateLimitBytes != nil {
+		in, out := &in.IngestionRateLimitBytes, &out.IngestionRateLimitBytes
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxBytesPerTrace != nil {
+		in, out := &in.MaxBytesPerTrace, &out.MaxBytesPerTrace
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxTracesPerUser != nil {
+		in, out := &in.MaxTracesPerUser, &out.MaxTracesPerUser
+		*out = new(int)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageTLSSpec.
-func (in *ObjectStorageTLSSpec) DeepCopy() *ObjectStorageTLSSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngestionLimitSpec.
+func (in *IngestionLimitSpec) DeepCopy() *IngestionLimitSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectStorageTLSSpec)
+	out := new(IngestionLimitSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObservabilitySpec) DeepCopyInto(out *ObservabilitySpec) {
+func (in *IngressHostSpec) DeepCopyInto(out *IngressHostSpec) {
 	*out = *in
-	out.Metrics = in.Metrics
-	out.Tracing = in.Tracing
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObservabilitySpec.
-func (in *ObservabilitySpec) DeepCopy() *ObservabilitySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressHostSpec.
+func (in *IngressHostSpec) DeepCopy() *IngressHostSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObservabilitySpec)
+	out := new(IngressHostSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in PodStatusMap) DeepCopyInto(out *PodStatusMap) {
-	{
-		in := &in
-		*out = make(PodStatusMap, len(*in))
+func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
+	if in.AdditionalHosts != nil {
+		in, out := &in.AdditionalHosts, &out.AdditionalHosts
+		*out = make([]IngressHostSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	out.Route = in.Route
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodStatusMap.
-func (in PodStatusMap) DeepCopy() PodStatusMap {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSpec.
+func (in *IngressSpec) DeepCopy() *IngressSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PodStatusMap)
+	out := new(IngressSpec)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QueryLimit) DeepCopyInto(out *QueryLimit) {
+func (in *JaegerQueryAuthenticationSpec) DeepCopyInto(out *JaegerQueryAuthenticationSpec) {
 	*out = *in
-	if in.MaxBytesPerTagValues != nil {
-		in, out := &in.MaxBytesPerTagValues, &out.MaxBytesPerTagValues
-		*out = new(int)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JaegerQueryAuthenticationSpec.
+func (in *JaegerQueryAuthenticationSpec) DeepCopy() *JaegerQueryAuthenticationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JaegerQueryAuthenticationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JaegerQueryMonitor) DeepCopyInto(out *JaegerQueryMonitor) {
+	*out = *in
+	if in.REDMetricsNamespace != nil {
+		in, out := &in.REDMetricsNamespace, &out.REDMetricsNamespace
+		*out = new(string)
 		**out = **in
 	}
-	if in.MaxSearchBytesPerTrace != nil {
-		in, out := &in.MaxSearchBytesPerTrace, &out.MaxSearchBytesPerTrace
-		*out = new(int)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(JaegerQueryMonitorTLSSpec)
 		**out = **in
 	}
-	out.MaxSearchDuration = in.MaxSearchDuration
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryLimit.
-func (in *QueryLimit) DeepCopy() *QueryLimit {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JaegerQueryMonitor.
+func (in *JaegerQueryMonitor) DeepCopy() *JaegerQueryMonitor {
 	if in == nil {
 		return nil
 	}
-	out := new(QueryLimit)
+	out := new(JaegerQueryMonitor)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RateLimitSpec) DeepCopyInto(out *RateLimitSpec) {
+func (in *JaegerQueryMonitorTLSSpec) DeepCopyInto(out *JaegerQueryMonitorTLSSpec) {
 	*out = *in
-	in.Ingestion.DeepCopyInto(&out.Ingestion)
-	in.Query.DeepCopyInto(&out.Query)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitSpec.
-func (in *RateLimitSpec) DeepCopy() *RateLimitSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JaegerQueryMonitorTLSSpec.
+func (in *JaegerQueryMonitorTLSSpec) DeepCopy() *JaegerQueryMonitorTLSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RateLimitSpec)
+	out := new(JaegerQueryMonitorTLSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Resources) DeepCopyInto(out *Resources) {
+func (in *JaegerQuerySpec) DeepCopyInto(out *JaegerQuerySpec) {
 	*out = *in
-	if in.Total != nil {
-		in, out := &in.Total, &out.Total
-		*out = new(corev1.ResourceRequirements)
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	in.MonitorTab.DeepCopyInto(&out.MonitorTab)
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(JaegerQueryAuthenticationSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JaegerQuerySpec.
+func (in *JaegerQuerySpec) DeepCopy() *JaegerQuerySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JaegerQuerySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JaegerReceiverSpec) DeepCopyInto(out *JaegerReceiverSpec) {
+	*out = *in
+	if in.ThriftHTTP != nil {
+		in, out := &in.ThriftHTTP, &out.ThriftHTTP
+		*out = new(ReceiverProtocolSpec)
+		**out = **in
+	}
+	if in.ThriftBinary != nil {
+		in, out := &in.ThriftBinary, &out.ThriftBinary
+		*out = new(ReceiverProtocolSpec)
+		**out = **in
+	}
+	if in.ThriftCompact != nil {
+		in, out := &in.ThriftCompact, &out.ThriftCompact
+		*out = new(ReceiverProtocolSpec)
+		**out = **in
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(ReceiverProtocolSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JaegerReceiverSpec.
+func (in *JaegerReceiverSpec) DeepCopy() *JaegerReceiverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JaegerReceiverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KVStoreSpec) DeepCopyInto(out *KVStoreSpec) {
+	*out = *in
+	if in.Etcd != nil {
+		in, out := &in.Etcd, &out.Etcd
+		*out = new(EtcdSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Consul != nil {
+		in, out := &in.Consul, &out.Consul
+		*out = new(ConsulSpec)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resources.
-func (in *Resources) DeepCopy() *Resources {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KVStoreSpec.
+func (in *KVStoreSpec) DeepCopy() *KVStoreSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Resources)
+	out := new(KVStoreSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RetentionConfig) DeepCopyInto(out *RetentionConfig) {
+func (in *KafkaReceiverSpec) DeepCopyInto(out *KafkaReceiverSpec) {
 	*out = *in
-	out.Traces = in.Traces
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SASL != nil {
+		in, out := &in.SASL, &out.SASL
+		*out = new(KafkaSASLSpec)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(KafkaTLSSpec)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetentionConfig.
-func (in *RetentionConfig) DeepCopy() *RetentionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaReceiverSpec.
+func (in *KafkaReceiverSpec) DeepCopy() *KafkaReceiverSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RetentionConfig)
+	out := new(KafkaReceiverSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RetentionSpec) DeepCopyInto(out *RetentionSpec) {
+func (in *KafkaSASLSpec) DeepCopyInto(out *KafkaSASLSpec) {
 	*out = *in
-	if in.PerTenant != nil {
-		in, out := &in.PerTenant, &out.PerTenant
-		*out = make(map[string]RetentionConfig, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSASLSpec.
+func (in *KafkaSASLSpec) DeepCopy() *KafkaSASLSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaSASLSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaTLSSpec) DeepCopyInto(out *KafkaTLSSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTLSSpec.
+func (in *KafkaTLSSpec) DeepCopy() *KafkaTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KedaSpec) DeepCopyInto(out *KedaSpec) {
+	*out = *in
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]KedaTriggerSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CooldownPeriod != nil {
+		in, out := &in.CooldownPeriod, &out.CooldownPeriod
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KedaSpec.
+func (in *KedaSpec) DeepCopy() *KedaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KedaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KedaTriggerSpec) DeepCopyInto(out *KedaTriggerSpec) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	out.Global = in.Global
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetentionSpec.
-func (in *RetentionSpec) DeepCopy() *RetentionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KedaTriggerSpec.
+func (in *KedaTriggerSpec) DeepCopy() *KedaTriggerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RetentionSpec)
+	out := new(KedaTriggerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleBindingsSpec) DeepCopyInto(out *RoleBindingsSpec) {
+func (in *LimitSpec) DeepCopyInto(out *LimitSpec) {
 	*out = *in
-	if in.Subjects != nil {
-		in, out := &in.Subjects, &out.Subjects
-		*out = make([]Subject, len(*in))
-		copy(*out, *in)
+	if in.PerTenant != nil {
+		in, out := &in.PerTenant, &out.PerTenant
+		*out = make(map[string]RateLimitSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
 	}
-	if in.Roles != nil {
-		in, out := &in.Roles, &out.Roles
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	in.Global.DeepCopyInto(&out.Global)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LimitSpec.
+func (in *LimitSpec) DeepCopy() *LimitSpec {
+	if in == nil {
+		return nil
 	}
+	out := new(LimitSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleBindingsSpec.
-func (in *RoleBindingsSpec) DeepCopy() *RoleBindingsSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogReceivedSpansSpec) DeepCopyInto(out *LogReceivedSpansSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogReceivedSpansSpec.
+func (in *LogReceivedSpansSpec) DeepCopy() *LogReceivedSpansSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleBindingsSpec)
+	out := new(LogReceivedSpansSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggingConfigSpec) DeepCopyInto(out *LoggingConfigSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingConfigSpec.
+func (in *LoggingConfigSpec) DeepCopy() *LoggingConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggingConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MTLSSpec) DeepCopyInto(out *MTLSSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MTLSSpec.
+func (in *MTLSSpec) DeepCopy() *MTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberlistSpec) DeepCopyInto(out *MemberlistSpec) {
+	*out = *in
+	out.GossipInterval = in.GossipInterval
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(MemberlistTLSSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberlistSpec.
+func (in *MemberlistSpec) DeepCopy() *MemberlistSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberlistSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberlistTLSSpec) DeepCopyInto(out *MemberlistTLSSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberlistTLSSpec.
+func (in *MemberlistTLSSpec) DeepCopy() *MemberlistTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberlistTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsConfigSpec) DeepCopyInto(out *MetricsConfigSpec) {
+	*out = *in
+	if in.ServiceMonitors != nil {
+		in, out := &in.ServiceMonitors, &out.ServiceMonitors
+		*out = make(map[string]ServiceMonitorSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsConfigSpec.
+func (in *MetricsConfigSpec) DeepCopy() *MetricsConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicySpec) DeepCopyInto(out *NetworkPolicySpec) {
+	*out = *in
+	if in.ReceiverNamespaceSelector != nil {
+		in, out := &in.ReceiverNamespaceSelector, &out.ReceiverNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectStorageCIDRs != nil {
+		in, out := &in.ObjectStorageCIDRs, &out.ObjectStorageCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicySpec.
+func (in *NetworkPolicySpec) DeepCopy() *NetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCGroupMappingSpec) DeepCopyInto(out *OIDCGroupMappingSpec) {
+	*out = *in
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]PermissionType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCGroupMappingSpec.
+func (in *OIDCGroupMappingSpec) DeepCopy() *OIDCGroupMappingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCGroupMappingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCSpec) DeepCopyInto(out *OIDCSpec) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(TenantSecretSpec)
+		**out = **in
+	}
+	if in.GroupMappings != nil {
+		in, out := &in.GroupMappings, &out.GroupMappings
+		*out = make([]OIDCGroupMappingSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCSpec.
+func (in *OIDCSpec) DeepCopy() *OIDCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OPAPolicySpec) DeepCopyInto(out *OPAPolicySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPAPolicySpec.
+func (in *OPAPolicySpec) DeepCopy() *OPAPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OPAPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OTLPGRPCSpec) DeepCopyInto(out *OTLPGRPCSpec) {
+	*out = *in
+	if in.MaxRecvMsgSizeMiB != nil {
+		in, out := &in.MaxRecvMsgSizeMiB, &out.MaxRecvMsgSizeMiB
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxConcurrentStreams != nil {
+		in, out := &in.MaxConcurrentStreams, &out.MaxConcurrentStreams
+		*out = new(int)
+		**out = **in
+	}
+	if in.ReadBufferSize != nil {
+		in, out := &in.ReadBufferSize, &out.ReadBufferSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.WriteBufferSize != nil {
+		in, out := &in.WriteBufferSize, &out.WriteBufferSize
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OTLPGRPCSpec.
+func (in *OTLPGRPCSpec) DeepCopy() *OTLPGRPCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OTLPGRPCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OTLPReceiverSpec) DeepCopyInto(out *OTLPReceiverSpec) {
+	*out = *in
+	in.GRPC.DeepCopyInto(&out.GRPC)
+	in.GRPCIngress.DeepCopyInto(&out.GRPCIngress)
+	in.HTTPIngress.DeepCopyInto(&out.HTTPIngress)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OTLPReceiverSpec.
+func (in *OTLPReceiverSpec) DeepCopy() *OTLPReceiverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OTLPReceiverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorageHedgingSpec) DeepCopyInto(out *ObjectStorageHedgingSpec) {
+	*out = *in
+	out.RequestsAt = in.RequestsAt
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageHedgingSpec.
+func (in *ObjectStorageHedgingSpec) DeepCopy() *ObjectStorageHedgingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStorageHedgingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorageS3Spec) DeepCopyInto(out *ObjectStorageS3Spec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageS3Spec.
+func (in *ObjectStorageS3Spec) DeepCopy() *ObjectStorageS3Spec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStorageS3Spec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorageSecretSpec) DeepCopyInto(out *ObjectStorageSecretSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageSecretSpec.
+func (in *ObjectStorageSecretSpec) DeepCopy() *ObjectStorageSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStorageSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorageSpec) DeepCopyInto(out *ObjectStorageSpec) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ObjectStorageTLSSpec)
+		**out = **in
+	}
+	out.Secret = in.Secret
+	if in.MigrateFrom != nil {
+		in, out := &in.MigrateFrom, &out.MigrateFrom
+		*out = new(ObjectStorageSecretSpec)
+		**out = **in
+	}
+	if in.Hedging != nil {
+		in, out := &in.Hedging, &out.Hedging
+		*out = new(ObjectStorageHedgingSpec)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(ObjectStorageS3Spec)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageSpec.
+func (in *ObjectStorageSpec) DeepCopy() *ObjectStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorageTLSSpec) DeepCopyInto(out *ObjectStorageTLSSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageTLSSpec.
+func (in *ObjectStorageTLSSpec) DeepCopy() *ObjectStorageTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStorageTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObservabilitySpec) DeepCopyInto(out *ObservabilitySpec) {
+	*out = *in
+	in.Metrics.DeepCopyInto(&out.Metrics)
+	out.Tracing = in.Tracing
+	out.Logging = in.Logging
+	in.Grafana.DeepCopyInto(&out.Grafana)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObservabilitySpec.
+func (in *ObservabilitySpec) DeepCopy() *ObservabilitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObservabilitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenTelemetryCollectorSpec) DeepCopyInto(out *OpenTelemetryCollectorSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryCollectorSpec.
+func (in *OpenTelemetryCollectorSpec) DeepCopy() *OpenTelemetryCollectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenTelemetryCollectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetSpec) DeepCopyInto(out *PodDisruptionBudgetSpec) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetSpec.
+func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in PodStatusMap) DeepCopyInto(out *PodStatusMap) {
+	{
+		in := &in
+		*out = make(PodStatusMap, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodStatusMap.
+func (in PodStatusMap) DeepCopy() PodStatusMap {
+	if in == nil {
+		return nil
+	}
+	out := new(PodStatusMap)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryFrontendResultsCacheSpec) DeepCopyInto(out *QueryFrontendResultsCacheSpec) {
+	*out = *in
+	out.TTL = in.TTL
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryFrontendResultsCacheSpec.
+func (in *QueryFrontendResultsCacheSpec) DeepCopy() *QueryFrontendResultsCacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryFrontendResultsCacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryFrontendSearchSpec) DeepCopyInto(out *QueryFrontendSearchSpec) {
+	*out = *in
+	if in.ConcurrentJobs != nil {
+		in, out := &in.ConcurrentJobs, &out.ConcurrentJobs
+		*out = new(int)
+		**out = **in
+	}
+	if in.TargetBytesPerJob != nil {
+		in, out := &in.TargetBytesPerJob, &out.TargetBytesPerJob
+		*out = new(int)
+		**out = **in
+	}
+	out.MaxDuration = in.MaxDuration
+	out.QueryBackendAfter = in.QueryBackendAfter
+	out.QueryIngestersUntil = in.QueryIngestersUntil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryFrontendSearchSpec.
+func (in *QueryFrontendSearchSpec) DeepCopy() *QueryFrontendSearchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryFrontendSearchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryLimit) DeepCopyInto(out *QueryLimit) {
+	*out = *in
+	if in.MaxBytesPerTagValues != nil {
+		in, out := &in.MaxBytesPerTagValues, &out.MaxBytesPerTagValues
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxSearchBytesPerTrace != nil {
+		in, out := &in.MaxSearchBytesPerTrace, &out.MaxSearchBytesPerTrace
+		*out = new(int)
+		**out = **in
+	}
+	out.MaxSearchDuration = in.MaxSearchDuration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryLimit.
+func (in *QueryLimit) DeepCopy() *QueryLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACSpec) DeepCopyInto(out *RBACSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACSpec.
+func (in *RBACSpec) DeepCopy() *RBACSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitSpec) DeepCopyInto(out *RateLimitSpec) {
+	*out = *in
+	in.Ingestion.DeepCopyInto(&out.Ingestion)
+	in.Query.DeepCopyInto(&out.Query)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitSpec.
+func (in *RateLimitSpec) DeepCopy() *RateLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReceiverProtocolSpec) DeepCopyInto(out *ReceiverProtocolSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReceiverProtocolSpec.
+func (in *ReceiverProtocolSpec) DeepCopy() *ReceiverProtocolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReceiverProtocolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReceiverTLSOverrideSpec) DeepCopyInto(out *ReceiverTLSOverrideSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReceiverTLSOverrideSpec.
+func (in *ReceiverTLSOverrideSpec) DeepCopy() *ReceiverTLSOverrideSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReceiverTLSOverrideSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReceiversSpec) DeepCopyInto(out *ReceiversSpec) {
+	*out = *in
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(KafkaReceiverSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Zipkin != nil {
+		in, out := &in.Zipkin, &out.Zipkin
+		*out = new(ZipkinReceiverSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Jaeger != nil {
+		in, out := &in.Jaeger, &out.Jaeger
+		*out = new(JaegerReceiverSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OTLP != nil {
+		in, out := &in.OTLP, &out.OTLP
+		*out = new(OTLPReceiverSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReceiversSpec.
+func (in *ReceiversSpec) DeepCopy() *ReceiversSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReceiversSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReceiversTLSSpec) DeepCopyInto(out *ReceiversTLSSpec) {
+	*out = *in
+	if in.OTLPGRPC != nil {
+		in, out := &in.OTLPGRPC, &out.OTLPGRPC
+		*out = new(ReceiverTLSOverrideSpec)
+		**out = **in
+	}
+	if in.OTLPHTTP != nil {
+		in, out := &in.OTLPHTTP, &out.OTLPHTTP
+		*out = new(ReceiverTLSOverrideSpec)
+		**out = **in
+	}
+	if in.Jaeger != nil {
+		in, out := &in.Jaeger, &out.Jaeger
+		*out = new(ReceiverTLSOverrideSpec)
+		**out = **in
+	}
+	if in.Zipkin != nil {
+		in, out := &in.Zipkin, &out.Zipkin
+		*out = new(ReceiverTLSOverrideSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReceiversTLSSpec.
+func (in *ReceiversTLSSpec) DeepCopy() *ReceiversTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReceiversTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RelabelConfig) DeepCopyInto(out *RelabelConfig) {
+	*out = *in
+	if in.SourceLabels != nil {
+		in, out := &in.SourceLabels, &out.SourceLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelabelConfig.
+func (in *RelabelConfig) DeepCopy() *RelabelConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RelabelConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteWriteAuthSpec) DeepCopyInto(out *RemoteWriteAuthSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteWriteAuthSpec.
+func (in *RemoteWriteAuthSpec) DeepCopy() *RemoteWriteAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteWriteAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteWriteSpec) DeepCopyInto(out *RemoteWriteSpec) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(RemoteWriteAuthSpec)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(RemoteWriteTLSSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteWriteSpec.
+func (in *RemoteWriteSpec) DeepCopy() *RemoteWriteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteWriteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteWriteTLSSpec) DeepCopyInto(out *RemoteWriteTLSSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteWriteTLSSpec.
+func (in *RemoteWriteTLSSpec) DeepCopy() *RemoteWriteTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteWriteTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Resources) DeepCopyInto(out *Resources) {
+	*out = *in
+	if in.Total != nil {
+		in, out := &in.Total, &out.Total
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VerticalPodAutoscaler != nil {
+		in, out := &in.VerticalPodAutoscaler, &out.VerticalPodAutoscaler
+		*out = new(VerticalPodAutoscalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resources.
+func (in *Resources) DeepCopy() *Resources {
+	if in == nil {
+		return nil
+	}
+	out := new(Resources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetentionConfig) DeepCopyInto(out *RetentionConfig) {
+	*out = *in
+	out.Traces = in.Traces
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetentionConfig.
+func (in *RetentionConfig) DeepCopy() *RetentionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RetentionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetentionSpec) DeepCopyInto(out *RetentionSpec) {
+	*out = *in
+	if in.PerTenant != nil {
+		in, out := &in.PerTenant, &out.PerTenant
+		*out = make(map[string]RetentionConfig, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.Global = in.Global
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetentionSpec.
+func (in *RetentionSpec) DeepCopy() *RetentionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RetentionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RingStatus) DeepCopyInto(out *RingStatus) {
+	*out = *in
+	if in.UnhealthyMembers != nil {
+		in, out := &in.UnhealthyMembers, &out.UnhealthyMembers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RingStatus.
+func (in *RingStatus) DeepCopy() *RingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleBindingsSpec) DeepCopyInto(out *RoleBindingsSpec) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]Subject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleBindingsSpec.
+func (in *RoleBindingsSpec) DeepCopy() *RoleBindingsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleBindingsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tenants != nil {
+		in, out := &in.Tenants, &out.Tenants
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]PermissionType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSpec.
+func (in *RoleSpec) DeepCopy() *RoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteSpec) DeepCopyInto(out *RouteSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteSpec.
+func (in *RouteSpec) DeepCopy() *RouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SPIFFESpec) DeepCopyInto(out *SPIFFESpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SPIFFESpec.
+func (in *SPIFFESpec) DeepCopy() *SPIFFESpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SPIFFESpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SearchSpec) DeepCopyInto(out *SearchSpec) {
+	*out = *in
+	if in.DefaultResultLimit != nil {
+		in, out := &in.DefaultResultLimit, &out.DefaultResultLimit
+		*out = new(int)
+		**out = **in
+	}
+	out.MaxDuration = in.MaxDuration
+	out.QueryTimeout = in.QueryTimeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchSpec.
+func (in *SearchSpec) DeepCopy() *SearchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SearchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerPortsSpec) DeepCopyInto(out *ServerPortsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerPortsSpec.
+func (in *ServerPortsSpec) DeepCopy() *ServerPortsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerPortsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMeshSpec) DeepCopyInto(out *ServiceMeshSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMeshSpec.
+func (in *ServiceMeshSpec) DeepCopy() *ServiceMeshSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMeshSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMonitorSpec) DeepCopyInto(out *ServiceMonitorSpec) {
+	*out = *in
+	if in.MetricRelabelConfigs != nil {
+		in, out := &in.MetricRelabelConfigs, &out.MetricRelabelConfigs
+		*out = make([]RelabelConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMonitorSpec.
+func (in *ServiceMonitorSpec) DeepCopy() *ServiceMonitorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMonitorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceTopologySpec) DeepCopyInto(out *ServiceTopologySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceTopologySpec.
+func (in *ServiceTopologySpec) DeepCopy() *ServiceTopologySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceTopologySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Subject) DeepCopyInto(out *Subject) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Subject.
+func (in *Subject) DeepCopy() *Subject {
+	if in == nil {
+		return nil
+	}
+	out := new(Subject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSProfileSpec) DeepCopyInto(out *TLSProfileSpec) {
+	*out = *in
+	if in.Ciphers != nil {
+		in, out := &in.Ciphers, &out.Ciphers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSProfileSpec.
+func (in *TLSProfileSpec) DeepCopy() *TLSProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempoCLIJobSpec) DeepCopyInto(out *TempoCLIJobSpec) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoCLIJobSpec.
+func (in *TempoCLIJobSpec) DeepCopy() *TempoCLIJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TempoCLIJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempoCompactorSpec) DeepCopyInto(out *TempoCompactorSpec) {
+	*out = *in
+	in.TempoComponentSpec.DeepCopyInto(&out.TempoComponentSpec)
+	out.CompactionWindow = in.CompactionWindow
+	if in.MaxBlockBytes != nil {
+		in, out := &in.MaxBlockBytes, &out.MaxBlockBytes
+		*out = new(int)
+		**out = **in
+	}
+	if in.RetentionConcurrency != nil {
+		in, out := &in.RetentionConcurrency, &out.RetentionConcurrency
+		*out = new(int)
+		**out = **in
+	}
+	out.CompactionCycle = in.CompactionCycle
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeClaimTemplate != nil {
+		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
+		*out = new(VolumeClaimTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoCompactorSpec.
+func (in *TempoCompactorSpec) DeepCopy() *TempoCompactorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TempoCompactorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempoComponentSpec) DeepCopyInto(out *TempoComponentSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceLabels != nil {
+		in, out := &in.ServiceLabels, &out.ServiceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraContainers != nil {
+		in, out := &in.ExtraContainers, &out.ExtraContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraInitContainers != nil {
+		in, out := &in.ExtraInitContainers, &out.ExtraInitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumes != nil {
+		in, out := &in.ExtraVolumes, &out.ExtraVolumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]v1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoComponentSpec.
+func (in *TempoComponentSpec) DeepCopy() *TempoComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TempoComponentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
+func (in *TempoDistributorSpec) DeepCopyInto(out *TempoDistributorSpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	in.TempoComponentSpec.DeepCopyInto(&out.TempoComponentSpec)
+	in.TLS.DeepCopyInto(&out.TLS)
+	in.Receivers.DeepCopyInto(&out.Receivers)
+	if in.LogReceivedSpans != nil {
+		in, out := &in.LogReceivedSpans, &out.LogReceivedSpans
+		*out = new(LogReceivedSpansSpec)
+		**out = **in
 	}
-	if in.Tenants != nil {
-		in, out := &in.Tenants, &out.Tenants
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Permissions != nil {
-		in, out := &in.Permissions, &out.Permissions
-		*out = make([]PermissionType, len(*in))
-		copy(*out, *in)
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSpec.
-func (in *RoleSpec) DeepCopy() *RoleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoDistributorSpec.
+func (in *TempoDistributorSpec) DeepCopy() *TempoDistributorSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleSpec)
+	out := new(TempoDistributorSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RouteSpec) DeepCopyInto(out *RouteSpec) {
+func (in *TempoGatewaySpec) DeepCopyInto(out *TempoGatewaySpec) {
 	*out = *in
+	in.TempoComponentSpec.DeepCopyInto(&out.TempoComponentSpec)
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimits != nil {
+		in, out := &in.RateLimits, &out.RateLimits
+		*out = make([]GatewayRateLimitSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.AuditLog != nil {
+		in, out := &in.AuditLog, &out.AuditLog
+		*out = new(GatewayAuditLogSpec)
+		**out = **in
+	}
+	if in.AccessReviewCache != nil {
+		in, out := &in.AccessReviewCache, &out.AccessReviewCache
+		*out = new(GatewayAccessReviewCacheSpec)
+		**out = **in
+	}
+	out.RBAC = in.RBAC
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteSpec.
-func (in *RouteSpec) DeepCopy() *RouteSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoGatewaySpec.
+func (in *TempoGatewaySpec) DeepCopy() *TempoGatewaySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RouteSpec)
+	out := new(TempoGatewaySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SearchSpec) DeepCopyInto(out *SearchSpec) {
+func (in *TempoIngesterSpec) DeepCopyInto(out *TempoIngesterSpec) {
 	*out = *in
-	if in.DefaultResultLimit != nil {
-		in, out := &in.DefaultResultLimit, &out.DefaultResultLimit
+	in.TempoComponentSpec.DeepCopyInto(&out.TempoComponentSpec)
+	if in.VolumeClaimTemplate != nil {
+		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
+		*out = new(VolumeClaimTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	out.TraceIdlePeriod = in.TraceIdlePeriod
+	out.MaxBlockDuration = in.MaxBlockDuration
+	if in.MaxBlockBytes != nil {
+		in, out := &in.MaxBlockBytes, &out.MaxBlockBytes
 		*out = new(int)
 		**out = **in
 	}
-	out.MaxDuration = in.MaxDuration
+	out.CompleteBlockTimeout = in.CompleteBlockTimeout
+	out.FlushCheckPeriod = in.FlushCheckPeriod
+	if in.ConcurrentFlushes != nil {
+		in, out := &in.ConcurrentFlushes, &out.ConcurrentFlushes
+		*out = new(int)
+		**out = **in
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ZoneAwareReplication != nil {
+		in, out := &in.ZoneAwareReplication, &out.ZoneAwareReplication
+		*out = new(ZoneAwareReplicationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchSpec.
-func (in *SearchSpec) DeepCopy() *SearchSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoIngesterSpec.
+func (in *TempoIngesterSpec) DeepCopy() *TempoIngesterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SearchSpec)
+	out := new(TempoIngesterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Subject) DeepCopyInto(out *Subject) {
+func (in *TempoMemcachedSpec) DeepCopyInto(out *TempoMemcachedSpec) {
 	*out = *in
+	in.TempoComponentSpec.DeepCopyInto(&out.TempoComponentSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Subject.
-func (in *Subject) DeepCopy() *Subject {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoMemcachedSpec.
+func (in *TempoMemcachedSpec) DeepCopy() *TempoMemcachedSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Subject)
+	out := new(TempoMemcachedSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TempoComponentSpec) DeepCopyInto(out *TempoComponentSpec) {
+func (in *TempoMetricsGeneratorSpec) DeepCopyInto(out *TempoMetricsGeneratorSpec) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
-	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	in.TempoComponentSpec.DeepCopyInto(&out.TempoComponentSpec)
+	if in.Processors != nil {
+		in, out := &in.Processors, &out.Processors
+		*out = make([]MetricsGeneratorProcessor, len(*in))
+		copy(*out, *in)
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
+	if in.RemoteWrite != nil {
+		in, out := &in.RemoteWrite, &out.RemoteWrite
+		*out = make([]RemoteWriteSpec, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoComponentSpec.
-func (in *TempoComponentSpec) DeepCopy() *TempoComponentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoMetricsGeneratorSpec.
+func (in *TempoMetricsGeneratorSpec) DeepCopy() *TempoMetricsGeneratorSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TempoComponentSpec)
+	out := new(TempoMetricsGeneratorSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TempoGatewaySpec) DeepCopyInto(out *TempoGatewaySpec) {
+func (in *TempoQuerierSpec) DeepCopyInto(out *TempoQuerierSpec) {
 	*out = *in
 	in.TempoComponentSpec.DeepCopyInto(&out.TempoComponentSpec)
-	in.Ingress.DeepCopyInto(&out.Ingress)
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoGatewaySpec.
-func (in *TempoGatewaySpec) DeepCopy() *TempoGatewaySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoQuerierSpec.
+func (in *TempoQuerierSpec) DeepCopy() *TempoQuerierSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TempoGatewaySpec)
+	out := new(TempoQuerierSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -695,6 +2351,16 @@ func (in *TempoQueryFrontendSpec) DeepCopyInto(out *TempoQueryFrontendSpec) {
 	*out = *in
 	in.TempoComponentSpec.DeepCopyInto(&out.TempoComponentSpec)
 	in.JaegerQuery.DeepCopyInto(&out.JaegerQuery)
+	if in.Search != nil {
+		in, out := &in.Search, &out.Search
+		*out = new(QueryFrontendSearchSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResultsCache != nil {
+		in, out := &in.ResultsCache, &out.ResultsCache
+		*out = new(QueryFrontendResultsCacheSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoQueryFrontendSpec.
@@ -778,7 +2444,17 @@ func (in *TempoStackSpec) DeepCopyInto(out *TempoStackSpec) {
 	in.Resources.DeepCopyInto(&out.Resources)
 	out.StorageSize = in.StorageSize.DeepCopy()
 	out.Images = in.Images
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
 	in.Storage.DeepCopyInto(&out.Storage)
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(CacheSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Retention.DeepCopyInto(&out.Retention)
 	in.SearchSpec.DeepCopyInto(&out.SearchSpec)
 	in.Template.DeepCopyInto(&out.Template)
@@ -787,7 +2463,94 @@ func (in *TempoStackSpec) DeepCopyInto(out *TempoStackSpec) {
 		*out = new(TenantsSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	out.Observability = in.Observability
+	in.Observability.DeepCopyInto(&out.Observability)
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManagerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSProfile != nil {
+		in, out := &in.TLSProfile, &out.TLSProfile
+		*out = new(TLSProfileSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SPIFFE != nil {
+		in, out := &in.SPIFFE, &out.SPIFFE
+		*out = new(SPIFFESpec)
+		**out = **in
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = new(ServerPortsSpec)
+		**out = **in
+	}
+	if in.GRPCServer != nil {
+		in, out := &in.GRPCServer, &out.GRPCServer
+		*out = new(GRPCServerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Memberlist != nil {
+		in, out := &in.Memberlist, &out.Memberlist
+		*out = new(MemberlistSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KVStore != nil {
+		in, out := &in.KVStore, &out.KVStore
+		*out = new(KVStoreSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraConfig != nil {
+		in, out := &in.ExtraConfig, &out.ExtraConfig
+		*out = new(ExtraConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceMesh != nil {
+		in, out := &in.ServiceMesh, &out.ServiceMesh
+		*out = new(ServiceMeshSpec)
+		**out = **in
+	}
+	if in.ServiceTopology != nil {
+		in, out := &in.ServiceTopology, &out.ServiceTopology
+		*out = new(ServiceTopologySpec)
+		**out = **in
+	}
+	if in.OpenTelemetryCollector != nil {
+		in, out := &in.OpenTelemetryCollector, &out.OpenTelemetryCollector
+		*out = new(OpenTelemetryCollectorSpec)
+		**out = **in
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupSpec)
+		**out = **in
+	}
+	if in.CLIJobs != nil {
+		in, out := &in.CLIJobs, &out.CLIJobs
+		*out = make([]TempoCLIJobSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TenantPurges != nil {
+		in, out := &in.TenantPurges, &out.TenantPurges
+		*out = make([]TenantPurgeSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanarySpec)
+		**out = **in
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = new(FeatureGatesSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoStackSpec.
@@ -803,10 +2566,32 @@ func (in *TempoStackSpec) DeepCopy() *TempoStackSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TempoStackStatus) DeepCopyInto(out *TempoStackStatus) {
 	*out = *in
+	out.Images = in.Images
+	if in.ImageDigests != nil {
+		in, out := &in.ImageDigests, &out.ImageDigests
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	in.Components.DeepCopyInto(&out.Components)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rings != nil {
+		in, out := &in.Rings, &out.Rings
+		*out = make([]RingStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TenantPurges != nil {
+		in, out := &in.TenantPurges, &out.TenantPurges
+		*out = make([]TenantPurgeStatus, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -832,6 +2617,8 @@ func (in *TempoTemplateSpec) DeepCopyInto(out *TempoTemplateSpec) {
 	in.Querier.DeepCopyInto(&out.Querier)
 	in.QueryFrontend.DeepCopyInto(&out.QueryFrontend)
 	in.Gateway.DeepCopyInto(&out.Gateway)
+	in.MetricsGenerator.DeepCopyInto(&out.MetricsGenerator)
+	in.Memcached.DeepCopyInto(&out.Memcached)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempoTemplateSpec.
@@ -844,6 +2631,40 @@ func (in *TempoTemplateSpec) DeepCopy() *TempoTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantPurgeSpec) DeepCopyInto(out *TenantPurgeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantPurgeSpec.
+func (in *TenantPurgeSpec) DeepCopy() *TenantPurgeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantPurgeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantPurgeStatus) DeepCopyInto(out *TenantPurgeStatus) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantPurgeStatus.
+func (in *TenantPurgeStatus) DeepCopy() *TenantPurgeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantPurgeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantSecretSpec) DeepCopyInto(out *TenantSecretSpec) {
 	*out = *in
@@ -874,6 +2695,11 @@ func (in *TenantsSpec) DeepCopyInto(out *TenantsSpec) {
 		*out = new(AuthorizationSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TrustedHeader != nil {
+		in, out := &in.TrustedHeader, &out.TrustedHeader
+		*out = new(TrustedHeaderSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantsSpec.
@@ -900,3 +2726,125 @@ func (in *TracingConfigSpec) DeepCopy() *TracingConfigSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustedHeaderSpec) DeepCopyInto(out *TrustedHeaderSpec) {
+	*out = *in
+	if in.AllowedCIDRs != nil {
+		in, out := &in.AllowedCIDRs, &out.AllowedCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustedHeaderSpec.
+func (in *TrustedHeaderSpec) DeepCopy() *TrustedHeaderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustedHeaderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerticalPodAutoscalerSpec) DeepCopyInto(out *VerticalPodAutoscalerSpec) {
+	*out = *in
+	if in.MinAllowed != nil {
+		in, out := &in.MinAllowed, &out.MinAllowed
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxAllowed != nil {
+		in, out := &in.MaxAllowed, &out.MaxAllowed
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerticalPodAutoscalerSpec.
+func (in *VerticalPodAutoscalerSpec) DeepCopy() *VerticalPodAutoscalerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VerticalPodAutoscalerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeClaimTemplateSpec) DeepCopyInto(out *VolumeClaimTemplateSpec) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeClaimTemplateSpec.
+func (in *VolumeClaimTemplateSpec) DeepCopy() *VolumeClaimTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeClaimTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZipkinReceiverSpec) DeepCopyInto(out *ZipkinReceiverSpec) {
+	*out = *in
+	in.Ingress.DeepCopyInto(&out.Ingress)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZipkinReceiverSpec.
+func (in *ZipkinReceiverSpec) DeepCopy() *ZipkinReceiverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZipkinReceiverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneAwareReplicationSpec) DeepCopyInto(out *ZoneAwareReplicationSpec) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneAwareReplicationSpec.
+func (in *ZoneAwareReplicationSpec) DeepCopy() *ZoneAwareReplicationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneAwareReplicationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
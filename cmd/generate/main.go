@@ -11,10 +11,13 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
@@ -103,7 +106,27 @@ func toYAMLManifest(scheme *runtime.Scheme, objects []client.Object, out io.Writ
 	return nil
 }
 
-func generate(c *cobra.Command, crPath string, outPath string, params manifestutils.Params) error {
+// validate runs the defaulting and validating webhooks for the TempoStack.
+// The resources referenced by the TempoStack are not available offline, therefore
+// the service account is assumed to exist and the storage secret is not validated.
+func validate(ctx context.Context, ctrlConfig configv1alpha1.ProjectConfig, scheme *runtime.Scheme, spec v1alpha1.TempoStack) (admission.Warnings, error) {
+	tempo := spec.DeepCopy()
+	err := v1alpha1.NewDefaulter(ctrlConfig).Default(ctx, tempo)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tempo.Spec.ServiceAccount,
+			Namespace: tempo.Namespace,
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(serviceAccount).Build()
+	return v1alpha1.NewValidator(k8sClient, ctrlConfig, manifestutils.ComponentPods).ValidateCreate(ctx, tempo)
+}
+
+func generate(c *cobra.Command, crPath string, outPath string, namespace string, validateSpec bool, params manifestutils.Params) error {
 	rootCmdConfig := c.Context().Value(cmd.RootConfigKey{}).(cmd.RootConfig)
 	ctrlConfig, options := rootCmdConfig.CtrlConfig, rootCmdConfig.Options
 
@@ -130,8 +153,22 @@ func generate(c *cobra.Command, crPath string, outPath string, params manifestut
 	if err != nil {
 		return fmt.Errorf("error loading spec: %w", err)
 	}
+	if spec.Namespace == "" {
+		spec.Namespace = namespace
+	}
+
+	if validateSpec {
+		warnings, err := validate(c.Context(), ctrlConfig, options.Scheme, spec)
+		if err != nil {
+			return fmt.Errorf("invalid TempoStack: %w", err)
+		}
+		for _, warning := range warnings {
+			fmt.Fprintf(c.ErrOrStderr(), "warning: %s\n", warning)
+		}
+	}
 
 	params.Tempo = spec
+	params.Distribution = ctrlConfig.Distribution
 	objects, err := build(ctrlConfig, params)
 	if err != nil {
 		return fmt.Errorf("error building manifests: %w", err)
@@ -166,6 +203,8 @@ func generate(c *cobra.Command, crPath string, outPath string, params manifestut
 func NewGenerateCommand() *cobra.Command {
 	var crPath string
 	var outPath string
+	var namespace string
+	var validateSpec bool
 	var azureContainer string
 	var gcsBucket string
 	var s3Endpoint string
@@ -175,6 +214,8 @@ func NewGenerateCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "generate",
 		Short: "Generate YAML manifests from a Tempo CR",
+		Long: "Generate the YAML manifests which the operator creates for a Tempo CR, without applying them to a cluster.\n" +
+			"The feature gates and default images of the operator are read from the --config file.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			switch {
 			case azureContainer != "":
@@ -191,11 +232,13 @@ func NewGenerateCommand() *cobra.Command {
 					"bucket":   []byte(s3Bucket),
 				}})
 			}
-			return generate(cmd, crPath, outPath, params)
+			return generate(cmd, crPath, outPath, namespace, validateSpec, params)
 		},
 	}
 	cmd.Flags().StringVar(&crPath, "cr", "/dev/stdin", "Input CR")
 	cmd.Flags().StringVar(&outPath, "output", "/dev/stdout", "File to store the manifests")
+	cmd.Flags().StringVar(&namespace, "namespace", "", "Namespace of the Tempo CR, if it is not set in the CR")
+	cmd.Flags().BoolVar(&validateSpec, "validate", false, "Validate the CR with the checks of the validating webhook")
 	cmd.Flags().StringVar(&azureContainer, "storage.azure.container", "azure", "Azure container(taken from storage secret)")
 	cmd.Flags().StringVar(&gcsBucket, "storage.gcs.bucket", "tempo", "GCS storage bucket (taken from storage secret)")
 	cmd.Flags().StringVar(&s3Endpoint, "storage.s3.endpoint", "http://minio.minio.svc:9000", "S3 storage endpoint (taken from storage secret)")
@@ -136,3 +136,75 @@ metadata:
   name: tempo-simplest-distributor
 `)
 }
+
+func TestGenerateCmdValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cr      string
+		wantErr string
+	}{
+		{
+			name: "valid CR",
+			cr: `
+apiVersion: tempo.grafana.com/v1alpha1
+kind: TempoStack
+metadata:
+  name: simplest
+spec:
+  images:
+    tempo: docker.io/grafana/tempo:x.y.z
+    tempoQuery: docker.io/grafana/tempo-query:x.y.z
+    tempoGateway: quay.io/observatorium/api
+    tempoGatewayOpa: quay.io/observatorium/opa-openshift
+  storage:
+    secret:
+      name: minio-test
+      type: s3
+`,
+		},
+		{
+			name: "invalid CR",
+			cr: `
+apiVersion: tempo.grafana.com/v1alpha1
+kind: TempoStack
+metadata:
+  name: simplest
+spec:
+  images:
+    tempo: docker.io/grafana/tempo:x.y.z
+    tempoQuery: docker.io/grafana/tempo-query:x.y.z
+    tempoGateway: quay.io/observatorium/api
+    tempoGatewayOpa: quay.io/observatorium/opa-openshift
+  storage:
+    secret:
+      name: minio-test
+      type: s3
+  template:
+    gateway:
+      enabled: true
+`,
+			wantErr: "to use the gateway, please enable jaegerQuery",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := cmd.NewRootCommand()
+			c.AddCommand(NewGenerateCommand())
+
+			out := &strings.Builder{}
+			c.SetOut(out)
+			c.SetErr(out)
+			c.SetIn(strings.NewReader(test.cr))
+
+			c.SetArgs([]string{"generate", "--validate", "--namespace", "observability"})
+			_, err := c.ExecuteC()
+			if test.wantErr != "" {
+				require.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, out.String(), "  name: tempo-simplest-distributor\n  namespace: observability\n")
+		})
+	}
+}
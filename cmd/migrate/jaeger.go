@@ -0,0 +1,278 @@
+package migrate
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/yaml"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+var (
+	jaegerGVK    = schema.GroupVersionKind{Group: "jaegertracing.io", Version: "v1", Kind: "Jaeger"}
+	collectorGVK = schema.GroupVersionKind{Group: "opentelemetry.io", Version: "v1alpha1", Kind: "OpenTelemetryCollector"}
+)
+
+// options contains the settings of the migration which cannot be derived from the Jaeger CR.
+type options struct {
+	storageSecret     string
+	storageSecretType v1alpha1.ObjectStorageSecretType
+	openShift         bool
+	collector         bool
+}
+
+// migration contains the resources replacing a Jaeger instance,
+// and the warnings about the settings which could not be migrated.
+type migration struct {
+	tempo     v1alpha1.TempoStack
+	collector *unstructured.Unstructured
+	warnings  []string
+}
+
+// migrate converts a Jaeger CR of the Jaeger Operator to a TempoStack,
+// and optionally to an OpenTelemetry Collector which receives the traces in all protocols of the Jaeger collector.
+func migrate(jaeger *unstructured.Unstructured, opts options) (migration, error) {
+	if jaeger.GroupVersionKind() != jaegerGVK {
+		return migration{}, fmt.Errorf("expected a %s, got %s", jaegerGVK.String(), jaeger.GroupVersionKind().String())
+	}
+
+	m := migration{}
+	m.tempo = v1alpha1.TempoStack{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.GroupVersion.String(),
+			Kind:       "TempoStack",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jaeger.GetName(),
+			Namespace: jaeger.GetNamespace(),
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Name: opts.storageSecret,
+					Type: opts.storageSecretType,
+				},
+			},
+		},
+	}
+	if m.tempo.Spec.Storage.Secret.Name == "" {
+		m.tempo.Spec.Storage.Secret.Name = fmt.Sprintf("%s-storage", jaeger.GetName())
+		m.warn("the object storage secret %s must be created before the TempoStack", m.tempo.Spec.Storage.Secret.Name)
+	}
+
+	if err := m.migrateStrategy(jaeger); err != nil {
+		return migration{}, err
+	}
+	if err := m.migrateStorage(jaeger); err != nil {
+		return migration{}, err
+	}
+	if err := m.migrateQuery(jaeger, opts); err != nil {
+		return migration{}, err
+	}
+
+	for _, field := range []string{"resources", "affinity", "tolerations", "volumes", "volumeMounts", "sampling"} {
+		if _, found, _ := unstructured.NestedFieldNoCopy(jaeger.Object, "spec", field); found {
+			m.warn("spec.%s is not migrated", field)
+		}
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(jaeger.Object, "spec", "agent"); found {
+		m.warn("the Jaeger agents are not migrated, the applications should send the traces to the OpenTelemetry Collector or the distributor directly")
+	}
+
+	if opts.collector {
+		collector, err := collector(m.tempo)
+		if err != nil {
+			return migration{}, err
+		}
+		m.collector = collector
+	}
+
+	return m, nil
+}
+
+func (m *migration) warn(format string, args ...interface{}) {
+	m.warnings = append(m.warnings, fmt.Sprintf(format, args...))
+}
+
+// migrateStrategy migrates the deployment strategy and the replicas of the Jaeger collector and query.
+func (m *migration) migrateStrategy(jaeger *unstructured.Unstructured) error {
+	strategy, _, err := unstructured.NestedString(jaeger.Object, "spec", "strategy")
+	if err != nil {
+		return fmt.Errorf("invalid spec.strategy: %w", err)
+	}
+
+	switch strategy {
+	case "", "allinone", "allInOne":
+		// The TempoStack runs every component with one replica by default.
+		return nil
+	case "production":
+	case "streaming":
+		m.warn("the streaming strategy is not migrated, the TempoStack receives the traces directly from the applications")
+	default:
+		return fmt.Errorf("unknown strategy %s", strategy)
+	}
+
+	collectorReplicas, found, err := unstructured.NestedInt64(jaeger.Object, "spec", "collector", "replicas")
+	if err != nil {
+		return fmt.Errorf("invalid spec.collector.replicas: %w", err)
+	}
+	if found {
+		m.tempo.Spec.Template.Distributor.Replicas = pointer.Int32(int32(collectorReplicas))
+	}
+
+	queryReplicas, found, err := unstructured.NestedInt64(jaeger.Object, "spec", "query", "replicas")
+	if err != nil {
+		return fmt.Errorf("invalid spec.query.replicas: %w", err)
+	}
+	if found {
+		m.tempo.Spec.Template.QueryFrontend.Replicas = pointer.Int32(int32(queryReplicas))
+		m.tempo.Spec.Template.Querier.Replicas = pointer.Int32(int32(queryReplicas))
+	}
+	return nil
+}
+
+// migrateStorage migrates the retention of the traces.
+// The traces stored by Jaeger are not copied to the object storage of the TempoStack.
+func (m *migration) migrateStorage(jaeger *unstructured.Unstructured) error {
+	storageType, _, err := unstructured.NestedString(jaeger.Object, "spec", "storage", "type")
+	if err != nil {
+		return fmt.Errorf("invalid spec.storage.type: %w", err)
+	}
+	if storageType == "" {
+		storageType = "memory"
+	}
+	m.warn("the traces in the %s storage of the Jaeger instance are not migrated", storageType)
+
+	cleanerEnabled, found, err := unstructured.NestedBool(jaeger.Object, "spec", "storage", "esIndexCleaner", "enabled")
+	if err != nil {
+		return fmt.Errorf("invalid spec.storage.esIndexCleaner.enabled: %w", err)
+	}
+	if found && !cleanerEnabled {
+		return nil
+	}
+
+	days, found, err := unstructured.NestedInt64(jaeger.Object, "spec", "storage", "esIndexCleaner", "numberOfDays")
+	if err != nil {
+		return fmt.Errorf("invalid spec.storage.esIndexCleaner.numberOfDays: %w", err)
+	}
+	if found && days > 0 {
+		m.tempo.Spec.Retention.Global.Traces = metav1.Duration{Duration: time.Duration(days) * 24 * time.Hour}
+	}
+	return nil
+}
+
+// migrateQuery migrates the Jaeger Query UI and its Ingress or Route.
+func (m *migration) migrateQuery(jaeger *unstructured.Unstructured, opts options) error {
+	jaegerQuery := &m.tempo.Spec.Template.QueryFrontend.JaegerQuery
+	jaegerQuery.Enabled = true
+
+	enabled, found, err := unstructured.NestedBool(jaeger.Object, "spec", "ingress", "enabled")
+	if err != nil {
+		return fmt.Errorf("invalid spec.ingress.enabled: %w", err)
+	}
+	if found && !enabled {
+		return nil
+	}
+
+	security, _, err := unstructured.NestedString(jaeger.Object, "spec", "ingress", "security")
+	if err != nil {
+		return fmt.Errorf("invalid spec.ingress.security: %w", err)
+	}
+
+	switch {
+	case security == "oauth-proxy":
+		jaegerQuery.Ingress.Type = v1alpha1.IngressTypeRoute
+		jaegerQuery.Authentication = &v1alpha1.JaegerQueryAuthenticationSpec{Enabled: true}
+	case opts.openShift:
+		jaegerQuery.Ingress.Type = v1alpha1.IngressTypeRoute
+	default:
+		jaegerQuery.Ingress.Type = v1alpha1.IngressTypeIngress
+	}
+	if security != "" && security != "none" && security != "oauth-proxy" {
+		m.warn("the %s ingress security is not migrated", security)
+	}
+
+	hosts, _, err := unstructured.NestedStringSlice(jaeger.Object, "spec", "ingress", "hosts")
+	if err != nil {
+		return fmt.Errorf("invalid spec.ingress.hosts: %w", err)
+	}
+	if len(hosts) > 0 {
+		jaegerQuery.Ingress.Host = hosts[0]
+		for _, host := range hosts[1:] {
+			jaegerQuery.Ingress.AdditionalHosts = append(jaegerQuery.Ingress.AdditionalHosts, v1alpha1.IngressHostSpec{Host: host})
+		}
+	}
+
+	annotations, _, err := unstructured.NestedStringMap(jaeger.Object, "spec", "ingress", "annotations")
+	if err != nil {
+		return fmt.Errorf("invalid spec.ingress.annotations: %w", err)
+	}
+	jaegerQuery.Ingress.Annotations = annotations
+	return nil
+}
+
+// collector returns an OpenTelemetry Collector with the same name as the Jaeger instance.
+// The OpenTelemetry Operator names the Service of the collector <name>-collector, like the Jaeger Operator,
+// therefore the applications can keep sending their traces to the same endpoint.
+func collector(tempo v1alpha1.TempoStack) (*unstructured.Unstructured, error) {
+	endpoint := naming.Name(manifestutils.DistributorComponentName, tempo.Name)
+	if tempo.Namespace != "" {
+		endpoint = fmt.Sprintf("%s.%s.svc.cluster.local", endpoint, tempo.Namespace)
+	}
+
+	config, err := yaml.Marshal(map[string]interface{}{
+		"receivers": map[string]interface{}{
+			"jaeger": map[string]interface{}{
+				"protocols": map[string]interface{}{
+					"grpc":           nil,
+					"thrift_binary":  nil,
+					"thrift_compact": nil,
+					"thrift_http":    nil,
+				},
+			},
+			"otlp": map[string]interface{}{
+				"protocols": map[string]interface{}{
+					"grpc": nil,
+					"http": nil,
+				},
+			},
+			"zipkin": nil,
+		},
+		"exporters": map[string]interface{}{
+			"otlp": map[string]interface{}{
+				"endpoint": fmt.Sprintf("%s:%d", endpoint, manifestutils.PortOtlpGrpcServer),
+				"tls": map[string]interface{}{
+					"insecure": true,
+				},
+			},
+		},
+		"service": map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"traces": map[string]interface{}{
+					"receivers": []interface{}{"jaeger", "otlp", "zipkin"},
+					"exporters": []interface{}{"otlp"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling the collector configuration: %w", err)
+	}
+
+	collector := &unstructured.Unstructured{}
+	collector.SetGroupVersionKind(collectorGVK)
+	collector.SetName(tempo.Name)
+	collector.SetNamespace(tempo.Namespace)
+	collector.Object["spec"] = map[string]interface{}{
+		"mode":   "deployment",
+		"config": string(config),
+	}
+	return collector, nil
+}
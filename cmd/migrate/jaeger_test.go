@@ -0,0 +1,108 @@
+package migrate
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/cmd"
+)
+
+func loadTestJaeger(t *testing.T) *unstructured.Unstructured {
+	file, err := os.Open("testdata/jaeger.yaml")
+	require.NoError(t, err)
+	defer file.Close()
+
+	jaeger, err := loadJaeger(file)
+	require.NoError(t, err)
+	return jaeger
+}
+
+func TestMigrate(t *testing.T) {
+	m, err := migrate(loadTestJaeger(t), options{storageSecret: "minio", storageSecretType: v1alpha1.ObjectStorageSecretS3, collector: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "simple-prod", m.tempo.Name)
+	assert.Equal(t, "observability", m.tempo.Namespace)
+	assert.Equal(t, v1alpha1.ObjectStorageSecretSpec{Name: "minio", Type: v1alpha1.ObjectStorageSecretS3}, m.tempo.Spec.Storage.Secret)
+	assert.Equal(t, metav1.Duration{Duration: 7 * 24 * time.Hour}, m.tempo.Spec.Retention.Global.Traces)
+	assert.Equal(t, pointer.Int32(3), m.tempo.Spec.Template.Distributor.Replicas)
+	assert.Equal(t, pointer.Int32(2), m.tempo.Spec.Template.QueryFrontend.Replicas)
+	assert.Equal(t, pointer.Int32(2), m.tempo.Spec.Template.Querier.Replicas)
+
+	jaegerQuery := m.tempo.Spec.Template.QueryFrontend.JaegerQuery
+	assert.True(t, jaegerQuery.Enabled)
+	assert.Equal(t, v1alpha1.IngressTypeRoute, jaegerQuery.Ingress.Type)
+	assert.Equal(t, "jaeger.example.com", jaegerQuery.Ingress.Host)
+	assert.Equal(t, &v1alpha1.JaegerQueryAuthenticationSpec{Enabled: true}, jaegerQuery.Authentication)
+	assert.Equal(t, []string{"the traces in the elasticsearch storage of the Jaeger instance are not migrated"}, m.warnings)
+
+	require.NotNil(t, m.collector)
+	assert.Equal(t, "simple-prod", m.collector.GetName())
+	assert.Equal(t, "observability", m.collector.GetNamespace())
+	config, _, err := unstructured.NestedString(m.collector.Object, "spec", "config")
+	require.NoError(t, err)
+	assert.Contains(t, config, "endpoint: tempo-simple-prod-distributor.observability.svc.cluster.local:4317")
+}
+
+func TestMigrate_AllInOne(t *testing.T) {
+	jaeger := &unstructured.Unstructured{}
+	jaeger.SetGroupVersionKind(jaegerGVK)
+	jaeger.SetName("simplest")
+	jaeger.Object["spec"] = map[string]interface{}{
+		"collector": map[string]interface{}{"replicas": int64(3)},
+		"ingress":   map[string]interface{}{"enabled": false},
+	}
+
+	m, err := migrate(jaeger, options{})
+	require.NoError(t, err)
+	assert.Equal(t, "simplest-storage", m.tempo.Spec.Storage.Secret.Name)
+	assert.Nil(t, m.tempo.Spec.Template.Distributor.Replicas)
+	assert.True(t, m.tempo.Spec.Template.QueryFrontend.JaegerQuery.Enabled)
+	assert.Equal(t, v1alpha1.IngressTypeNone, m.tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress.Type)
+	assert.Nil(t, m.collector)
+	assert.Len(t, m.warnings, 2)
+}
+
+func TestMigrate_InvalidKind(t *testing.T) {
+	tempo := &unstructured.Unstructured{}
+	tempo.SetGroupVersionKind(v1alpha1.GroupVersion.WithKind("TempoStack"))
+
+	_, err := migrate(tempo, options{})
+	require.Error(t, err)
+}
+
+func TestMigrateCmd(t *testing.T) {
+	c := cmd.NewRootCommand()
+	c.AddCommand(NewMigrateCommand())
+
+	out := &strings.Builder{}
+	errOut := &strings.Builder{}
+	c.SetOut(out)
+	c.SetErr(errOut)
+
+	c.SetArgs([]string{"migrate", "--cr", "testdata/jaeger.yaml", "--storage.secret", "minio"})
+	_, err := c.ExecuteC()
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), `
+apiVersion: tempo.grafana.com/v1alpha1
+kind: TempoStack
+metadata:
+  name: simple-prod
+  namespace: observability
+`)
+	assert.Contains(t, out.String(), `
+apiVersion: opentelemetry.io/v1alpha1
+kind: OpenTelemetryCollector
+`)
+	assert.Contains(t, errOut.String(), "warning: the traces in the elasticsearch storage of the Jaeger instance are not migrated")
+}
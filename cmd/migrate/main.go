@@ -0,0 +1,162 @@
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+var log = ctrl.Log.WithName("migrate")
+
+// loadJaeger reads a Jaeger CR in YAML or JSON format.
+// The unstructured JSON decoder is used to decode numbers as integers instead of floats.
+func loadJaeger(r io.Reader) (*unstructured.Unstructured, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonBytes, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	jaeger := &unstructured.Unstructured{}
+	err = jaeger.UnmarshalJSON(jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return jaeger, nil
+}
+
+func toYAMLManifest(objects []interface{}, out io.Writer) error {
+	for _, obj := range objects {
+		fmt.Fprintln(out, "---")
+
+		// Marshal to JSON first, to respect json tags in structs
+		jsonBytes, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+
+		// Unmarshal into a map and remove the empty creationTimestamp and status fields
+		var jsonObj map[string]interface{}
+		err = json.Unmarshal(jsonBytes, &jsonObj)
+		if err != nil {
+			return err
+		}
+		if metadata, ok := jsonObj["metadata"].(map[string]interface{}); ok {
+			delete(metadata, "creationTimestamp")
+		}
+		delete(jsonObj, "status")
+
+		yamlBytes, err := yaml.Marshal(jsonObj)
+		if err != nil {
+			return err
+		}
+
+		_, err = out.Write(yamlBytes)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func run(c *cobra.Command, crPath string, outPath string, opts options) error {
+	var crReader io.Reader
+	if crPath == "/dev/stdin" {
+		log.Info("reading from stdin")
+		crReader = c.InOrStdin()
+	} else {
+		pathCleaned := filepath.Clean(crPath)
+		file, err := os.Open(pathCleaned)
+		if err != nil {
+			return fmt.Errorf("error reading cr: %w", err)
+		}
+
+		crReader = file
+		defer func() {
+			if err := file.Close(); err != nil {
+				log.Error(err, "error closing file", "path", pathCleaned)
+			}
+		}()
+	}
+
+	jaeger, err := loadJaeger(crReader)
+	if err != nil {
+		return fmt.Errorf("error loading cr: %w", err)
+	}
+
+	m, err := migrate(jaeger, opts)
+	if err != nil {
+		return fmt.Errorf("error migrating %s: %w", jaeger.GetName(), err)
+	}
+	for _, warning := range m.warnings {
+		fmt.Fprintf(c.ErrOrStderr(), "warning: %s\n", warning)
+	}
+
+	objects := []interface{}{m.tempo}
+	if m.collector != nil {
+		objects = append(objects, m.collector.Object)
+	}
+
+	var output io.Writer
+	if outPath == "/dev/stdout" {
+		output = c.OutOrStdout()
+	} else {
+		outPathCleaned := filepath.Clean(outPath)
+		outFile, err := os.OpenFile(outPathCleaned, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("error opening output file: %w", err)
+		}
+		output = outFile
+		defer func() {
+			if err := outFile.Close(); err != nil {
+				log.Error(err, "error closing file", "path", outPathCleaned)
+			}
+		}()
+	}
+
+	err = toYAMLManifest(objects, output)
+	if err != nil {
+		return fmt.Errorf("error generating yaml: %w", err)
+	}
+
+	return nil
+}
+
+// NewMigrateCommand returns a new migrate command.
+func NewMigrateCommand() *cobra.Command {
+	var crPath string
+	var outPath string
+	var storageSecretType string
+	opts := options{}
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Generate a TempoStack and an OpenTelemetry Collector from a Jaeger CR of the Jaeger Operator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.storageSecretType = v1alpha1.ObjectStorageSecretType(storageSecretType)
+			return run(cmd, crPath, outPath, opts)
+		},
+	}
+	cmd.Flags().StringVar(&crPath, "cr", "/dev/stdin", "Input Jaeger CR")
+	cmd.Flags().StringVar(&outPath, "output", "/dev/stdout", "File to store the manifests")
+	cmd.Flags().StringVar(&opts.storageSecret, "storage.secret", "", "Name of the object storage secret of the TempoStack (defaults to <name>-storage)")
+	cmd.Flags().StringVar(&storageSecretType, "storage.secret.type", string(v1alpha1.ObjectStorageSecretS3), "Type of the object storage secret (azure, gcs or s3)")
+	cmd.Flags().BoolVar(&opts.openShift, "openshift", false, "Expose the Jaeger Query UI with a Route instead of an Ingress")
+	cmd.Flags().BoolVar(&opts.collector, "otel-collector", true, "Generate an OpenTelemetry Collector which receives the traces in the protocols of the Jaeger collector")
+	return cmd
+}
@@ -3,6 +3,8 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	configv1 "github.com/openshift/api/config/v1"
 	openshiftoperatorv1 "github.com/openshift/api/operator/v1"
@@ -22,6 +24,16 @@ var (
 	scheme = runtime.NewScheme()
 )
 
+// watchNamespaceEnv is the environment variable which overrides the watchNamespaces setting of the ProjectConfig.
+// OLM sets the target namespaces of the OperatorGroup in the olm.targetNamespaces annotation of the operator pod,
+// which is passed to this environment variable.
+const watchNamespaceEnv = "WATCH_NAMESPACE"
+
+// relatedImageEnvPrefix is the prefix of the environment variables which override the default images of the ProjectConfig.
+// In disconnected environments, OLM replaces the images of the RELATED_IMAGE_* environment variables
+// with the images of the mirror registry, pinned to their digests.
+const relatedImageEnvPrefix = "RELATED_IMAGE_"
+
 // RootConfigKey contains the key to RootConfig in the context object.
 type RootConfigKey struct{}
 
@@ -60,6 +72,11 @@ func readConfig(cmd *cobra.Command, configFile string) error {
 		}
 	}
 
+	if watchNamespace, ok := os.LookupEnv(watchNamespaceEnv); ok {
+		ctrlConfig.WatchNamespaces = parseNamespaces(watchNamespace)
+	}
+	applyRelatedImages(&ctrlConfig.DefaultImages)
+
 	err = ctrlConfig.Validate()
 	if err != nil {
 		return fmt.Errorf("controller config validation failed: %w", err)
@@ -69,6 +86,40 @@ func readConfig(cmd *cobra.Command, configFile string) error {
 	return nil
 }
 
+// parseNamespaces parses a comma-separated list of namespaces.
+// An empty list means all namespaces.
+func parseNamespaces(value string) []string {
+	var namespaces []string
+	for _, ns := range strings.Split(value, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
+// applyRelatedImages overrides the default images with the RELATED_IMAGE_* environment variables, if set.
+func applyRelatedImages(images *configv1alpha1.ImagesSpec) {
+	for _, relatedImage := range []struct {
+		name  string
+		image *string
+	}{
+		{name: "TEMPO", image: &images.Tempo},
+		{name: "TEMPO_QUERY", image: &images.TempoQuery},
+		{name: "TEMPO_GATEWAY", image: &images.TempoGateway},
+		{name: "TEMPO_GATEWAY_OPA", image: &images.TempoGatewayOpa},
+		{name: "SPIFFE_HELPER", image: &images.SPIFFEHelper},
+		{name: "OAUTH_PROXY", image: &images.OauthProxy},
+		{name: "MEMCACHED", image: &images.Memcached},
+		{name: "TEMPO_CLI", image: &images.TempoCLI},
+		{name: "BACKUP", image: &images.Backup},
+	} {
+		if image := os.Getenv(relatedImageEnvPrefix + relatedImage.name); image != "" {
+			*relatedImage.image = image
+		}
+	}
+}
+
 // NewRootCommand creates a new cobra root command.
 func NewRootCommand() *cobra.Command {
 	var configFile string
@@ -61,3 +61,37 @@ func TestReadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestReadConfig_WatchNamespace(t *testing.T) {
+	t.Setenv("WATCH_NAMESPACE", "team-a, team-b")
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	err := readConfig(cmd, "testdata/empty.yaml")
+	require.NoError(t, err)
+
+	rootCmdConfig := cmd.Context().Value(RootConfigKey{}).(RootConfig)
+	assert.Equal(t, []string{"team-a", "team-b"}, rootCmdConfig.CtrlConfig.WatchNamespaces)
+}
+
+func TestReadConfig_RelatedImages(t *testing.T) {
+	t.Setenv("RELATED_IMAGE_TEMPO", "registry.example.com/grafana/tempo@sha256:8d6546721a1d106cf8d27f7326ebae7e83c1592aeb7479b8f7ec9d8d700d464f")
+	t.Setenv("RELATED_IMAGE_TEMPO_GATEWAY_OPA", "registry.example.com/observatorium/opa-openshift@sha256:1fbe8e4f4059ee0e7e8ac840aefd2ac3224c51bb038c09f80ebb767600b9378a")
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	err := readConfig(cmd, "testdata/empty.yaml")
+	require.NoError(t, err)
+
+	rootCmdConfig := cmd.Context().Value(RootConfigKey{}).(RootConfig)
+	assert.Equal(t, configv1alpha1.ImagesSpec{
+		Tempo:           "registry.example.com/grafana/tempo@sha256:8d6546721a1d106cf8d27f7326ebae7e83c1592aeb7479b8f7ec9d8d700d464f",
+		TempoGatewayOpa: "registry.example.com/observatorium/opa-openshift@sha256:1fbe8e4f4059ee0e7e8ac840aefd2ac3224c51bb038c09f80ebb767600b9378a",
+	}, rootCmdConfig.CtrlConfig.DefaultImages)
+}
+
+func TestParseNamespaces(t *testing.T) {
+	assert.Nil(t, parseNamespaces(""))
+	assert.Equal(t, []string{"team-a"}, parseNamespaces("team-a"))
+	assert.Equal(t, []string{"team-a", "team-b"}, parseNamespaces("team-a,,team-b,"))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -13,6 +14,8 @@ import (
 
 	"github.com/spf13/cobra"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
@@ -20,11 +23,15 @@ import (
 	tempov1alpha1 "github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/cmd"
 	controllers "github.com/grafana/tempo-operator/controllers/tempo"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/upgrade"
 	"github.com/grafana/tempo-operator/internal/version"
 	//+kubebuilder:scaffold:imports
 )
 
+// operatorNamespaceFile contains the namespace of the operator pod.
+const operatorNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
 func start(c *cobra.Command, args []string) {
 	rootCmdConfig := c.Context().Value(cmd.RootConfigKey{}).(cmd.RootConfig)
 	ctrlConfig, options := rootCmdConfig.CtrlConfig, rootCmdConfig.Options
@@ -32,6 +39,7 @@ func start(c *cobra.Command, args []string) {
 	version := version.Get()
 
 	options.PprofBindAddress, _ = c.Flags().GetString("pprof-addr")
+	configureCacheNamespaces(&options, ctrlConfig)
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
 	if err != nil {
@@ -49,6 +57,7 @@ func start(c *cobra.Command, args []string) {
 		if err = (&controllers.CertRotationReconciler{
 			Client:       mgr.GetClient(),
 			Scheme:       mgr.GetScheme(),
+			Recorder:     mgr.GetEventRecorderFor("certrotation-controller"),
 			FeatureGates: ctrlConfig.Gates,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "certrotation")
@@ -56,6 +65,24 @@ func start(c *cobra.Command, args []string) {
 		}
 	}
 
+	if err = (&controllers.RingStatusReconciler{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		FeatureGates: ctrlConfig.Gates,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ringstatus")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.CanaryReconciler{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		FeatureGates: ctrlConfig.Gates,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "canary")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.TempoStackReconciler{
 		Client:     mgr.GetClient(),
 		Scheme:     mgr.GetScheme(),
@@ -69,7 +96,7 @@ func start(c *cobra.Command, args []string) {
 
 	enableWebhooks := os.Getenv("ENABLE_WEBHOOKS") != "false"
 	if enableWebhooks {
-		if err = (&tempov1alpha1.TempoStack{}).SetupWebhookWithManager(mgr, ctrlConfig); err != nil {
+		if err = (&tempov1alpha1.TempoStack{}).SetupWebhookWithManager(mgr, ctrlConfig, manifestutils.ComponentPods); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "TempoStack")
 			os.Exit(1)
 		}
@@ -98,6 +125,7 @@ func start(c *cobra.Command, args []string) {
 		"default-tempo-image", rootCmdConfig.CtrlConfig.DefaultImages.Tempo,
 		"default-tempo-query-image", rootCmdConfig.CtrlConfig.DefaultImages.TempoQuery,
 		"default-tempo-gateway-image", rootCmdConfig.CtrlConfig.DefaultImages.TempoGateway,
+		"watch-namespaces", ctrlConfig.WatchNamespaces,
 		"go-version", version.GoVersion,
 		"go-arch", runtime.GOARCH,
 		"go-os", runtime.GOOS,
@@ -109,6 +137,38 @@ func start(c *cobra.Command, args []string) {
 	}
 }
 
+// configureCacheNamespaces restricts the cache of the manager to the watched namespaces.
+// The namespace of the operator, the namespace of the CA secret of the built-in cert management and the allow-listed
+// secret namespaces are cached as well, because the operator reads resources in these namespaces,
+// but only the TempoStacks in the watched namespaces are reconciled.
+func configureCacheNamespaces(options *ctrl.Options, ctrlConfig configv1alpha1.ProjectConfig) {
+	if len(ctrlConfig.WatchNamespaces) == 0 {
+		return
+	}
+
+	namespaces := map[string]cache.Config{}
+	tempoStackNamespaces := map[string]cache.Config{}
+	for _, ns := range ctrlConfig.WatchNamespaces {
+		namespaces[ns] = cache.Config{}
+		tempoStackNamespaces[ns] = cache.Config{}
+	}
+	if ns, err := os.ReadFile(operatorNamespaceFile); err == nil {
+		namespaces[strings.TrimSpace(string(ns))] = cache.Config{}
+	}
+	if ca := ctrlConfig.Gates.BuiltInCertManagement.CASecret; ca != nil {
+		namespaces[ca.Namespace] = cache.Config{}
+	}
+	for _, ns := range ctrlConfig.SecretNamespaces {
+		namespaces[ns] = cache.Config{}
+	}
+
+	options.Cache.DefaultNamespaces = namespaces
+	if options.Cache.ByObject == nil {
+		options.Cache.ByObject = map[client.Object]cache.ByObject{}
+	}
+	options.Cache.ByObject[&tempov1alpha1.TempoStack{}] = cache.ByObject{Namespaces: tempoStackNamespaces}
+}
+
 func addDependencies(mgr ctrl.Manager, ctrlConfig configv1alpha1.ProjectConfig, version version.Version) error {
 	err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
 		upgrade := &upgrade.Upgrade{
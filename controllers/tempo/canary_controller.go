@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/canary"
+	"github.com/grafana/tempo-operator/internal/handlers/httpclient"
+	"github.com/grafana/tempo-operator/internal/manifests/servicemesh"
+)
+
+// CanaryReconciler periodically writes a synthetic trace to a TempoStack and reads it back,
+// and reports the result in the PipelineHealthy condition.
+// Unlike the readiness of the pods, the canary detects a broken write or read path,
+// for example an object storage or ingester ring which rejects the traces.
+type CanaryReconciler struct {
+	client.Client
+	Scheme       *runtime.Scheme
+	FeatureGates configv1alpha1.FeatureGates
+}
+
+// Reconcile probes the write and read path of a TempoStack and updates the PipelineHealthy condition.
+func (r *CanaryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx).WithName("canary-reconcile").WithValues("tempo", req.NamespacedName)
+
+	log.V(1).Info("starting reconcile loop")
+	defer log.V(1).Info("finished reconcile loop")
+
+	tempo := v1alpha1.TempoStack{}
+	if err := r.Get(ctx, req.NamespacedName, &tempo); err != nil {
+		if apierrors.IsNotFound(err) {
+			canary.Forget(req.Namespace, req.Name)
+			canary.DeleteMetrics(req.Namespace, req.Name)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if tempo.Spec.ManagementState != v1alpha1.ManagementStateManaged {
+		log.Info("Skipping reconciliation for unmanaged TempoStack resource", "name", req.String())
+		// Stop requeueing for unmanaged TempoStack custom resources
+		return ctrl.Result{}, nil
+	}
+
+	result := ctrl.Result{}
+	var updateCondition func(conditions *[]metav1.Condition)
+	// A hibernated TempoStack has no running pods.
+	if tempo.Spec.Canary == nil || tempo.Spec.Hibernate {
+		canary.Forget(tempo.Namespace, tempo.Name)
+		updateCondition = func(conditions *[]metav1.Condition) {
+			meta.RemoveStatusCondition(conditions, string(v1alpha1.ConditionPipelineHealthy))
+		}
+	} else {
+		condition := canary.Probe(ctx, r.Client, httpclient.ForComponent, time.Now, tempo, servicemesh.FeatureGates(r.FeatureGates, tempo).HTTPEncryption)
+		if condition == nil {
+			return ctrl.Result{RequeueAfter: canary.Interval(tempo)}, nil
+		}
+		updateCondition = func(conditions *[]metav1.Condition) {
+			meta.SetStatusCondition(conditions, *condition)
+		}
+		result.RequeueAfter = canary.Interval(tempo)
+	}
+
+	if err := r.patchConditions(ctx, tempo, updateCondition); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	return result, nil
+}
+
+// patchConditions updates the status conditions of a TempoStack.
+// A merge patch replaces the whole list of conditions, therefore the patch uses an optimistic lock
+// to not overwrite the conditions which the TempoStack reconciler updated in the meantime,
+// and is retried with the latest TempoStack on a conflict.
+func (r *CanaryReconciler) patchConditions(ctx context.Context, tempo v1alpha1.TempoStack, update func(conditions *[]metav1.Condition)) error {
+	refetch := false
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if refetch {
+			if err := r.Get(ctx, client.ObjectKeyFromObject(&tempo), &tempo); err != nil {
+				return err
+			}
+		}
+		refetch = true
+
+		changed := tempo.DeepCopy()
+		update(&changed.Status.Conditions)
+		if reflect.DeepEqual(changed.Status.Conditions, tempo.Status.Conditions) {
+			return nil
+		}
+		return r.Status().Patch(ctx, changed, client.MergeFromWithOptions(&tempo, client.MergeFromWithOptimisticLock{}))
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CanaryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("tempostack-canary").
+		For(&v1alpha1.TempoStack{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
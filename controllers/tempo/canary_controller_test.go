@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestCanaryPatchConditions(t *testing.T) {
+	nsn := types.NamespacedName{Name: "canary-conditions", Namespace: "default"}
+	storageSecret := createSecret(t, nsn)
+	createTempoCR(t, nsn, storageSecret)
+
+	stale := v1alpha1.TempoStack{}
+	err := k8sClient.Get(context.Background(), nsn, &stale)
+	require.NoError(t, err)
+
+	// The TempoStack reconciler updates its conditions after the canary fetched the TempoStack.
+	updated := stale.DeepCopy()
+	meta.SetStatusCondition(&updated.Status.Conditions, metav1.Condition{
+		Type:    string(v1alpha1.ConditionReady),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(v1alpha1.ReasonReady),
+		Message: "All components are operational",
+	})
+	err = k8sClient.Status().Update(context.Background(), updated)
+	require.NoError(t, err)
+
+	reconciler := CanaryReconciler{Client: k8sClient, Scheme: testScheme}
+	err = reconciler.patchConditions(context.Background(), stale, func(conditions *[]metav1.Condition) {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:    string(v1alpha1.ConditionPipelineHealthy),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(v1alpha1.ReasonReady),
+			Message: "The synthetic traces are written and read back successfully",
+		})
+	})
+	require.NoError(t, err)
+
+	// The condition of the canary is added without overwriting the conditions of the TempoStack reconciler.
+	tempo := v1alpha1.TempoStack{}
+	err = k8sClient.Get(context.Background(), nsn, &tempo)
+	require.NoError(t, err)
+	assert.True(t, meta.IsStatusConditionTrue(tempo.Status.Conditions, string(v1alpha1.ConditionReady)))
+	assert.True(t, meta.IsStatusConditionTrue(tempo.Status.Conditions, string(v1alpha1.ConditionPipelineHealthy)))
+}
@@ -7,6 +7,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -23,6 +24,7 @@ import (
 type CertRotationReconciler struct {
 	client.Client
 	Scheme       *runtime.Scheme
+	Recorder     record.EventRecorder
 	FeatureGates configv1alpha1.FeatureGates
 }
 
@@ -80,6 +82,12 @@ func (r *CertRotationReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
+	tempo := v1alpha1.TempoStack{}
+	if err := r.Get(ctx, req.NamespacedName, &tempo); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Event(&tempo, corev1.EventTypeNormal, "CertificateRotation", expired.Error())
+
 	return ctrl.Result{
 		RequeueAfter: checkExpiryAfter,
 	}, nil
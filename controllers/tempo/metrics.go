@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	metricReconcileDuration = promauto.With(metrics.Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "tempostack",
+		Name:      "reconcile_duration_seconds",
+		Help:      "The duration of the reconciliation of a TempoStack instance.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"stack_namespace", "stack_name"})
+
+	metricReconcileErrors = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: "tempostack",
+		Name:      "reconcile_errors_total",
+		Help:      "The number of failed reconciliations of a TempoStack instance.",
+	}, []string{"stack_namespace", "stack_name", "reason"})
+
+	metricManagedObjects = promauto.With(metrics.Registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "tempostack",
+		Name:      "managed_objects",
+		Help:      "The number of objects applied in the last reconciliation of a TempoStack instance.",
+	}, []string{"stack_namespace", "stack_name"})
+)
+
+// deleteMetrics removes the metrics of a deleted TempoStack instance.
+func deleteMetrics(namespace string, name string) {
+	labels := prometheus.Labels{"stack_namespace": namespace, "stack_name": name}
+	metricReconcileDuration.DeletePartialMatch(labels)
+	metricReconcileErrors.DeletePartialMatch(labels)
+	metricManagedObjects.DeletePartialMatch(labels)
+}
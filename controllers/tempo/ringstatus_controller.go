@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/httpclient"
+	"github.com/grafana/tempo-operator/internal/handlers/ring"
+	"github.com/grafana/tempo-operator/internal/manifests/servicemesh"
+)
+
+// ringProbeInterval is the interval at which the hash rings of a TempoStack are probed.
+const ringProbeInterval = time.Minute
+
+// RingStatusReconciler periodically probes the hash rings of the Tempo components
+// and reports unhealthy ring members in the TempoStack status.
+// Deployment and StatefulSet readiness does not show ring-level problems,
+// for example ingesters which left the ring or missed their heartbeat.
+type RingStatusReconciler struct {
+	client.Client
+	Scheme       *runtime.Scheme
+	FeatureGates configv1alpha1.FeatureGates
+}
+
+// Reconcile probes the hash rings of a TempoStack and updates the rings in the status.
+func (r *RingStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx).WithName("ringstatus-reconcile").WithValues("tempo", req.NamespacedName)
+
+	log.V(1).Info("starting reconcile loop")
+	defer log.V(1).Info("finished reconcile loop")
+
+	tempo := v1alpha1.TempoStack{}
+	if err := r.Get(ctx, req.NamespacedName, &tempo); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if tempo.Spec.ManagementState != v1alpha1.ManagementStateManaged {
+		log.Info("Skipping reconciliation for unmanaged TempoStack resource", "name", req.String())
+		// Stop requeueing for unmanaged TempoStack custom resources
+		return ctrl.Result{}, nil
+	}
+
+	// A hibernated TempoStack has no running pods.
+	var rings []v1alpha1.RingStatus
+	if !tempo.Spec.Hibernate {
+		rings = ring.Probe(ctx, r.Client, httpclient.ForComponent, time.Now, tempo, servicemesh.FeatureGates(r.FeatureGates, tempo).HTTPEncryption)
+	}
+
+	if !reflect.DeepEqual(rings, tempo.Status.Rings) {
+		changed := tempo.DeepCopy()
+		changed.Status.Rings = rings
+		if err := r.Status().Patch(ctx, changed, client.MergeFrom(&tempo)); err != nil {
+			if apierrors.IsNotFound(err) {
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{
+		RequeueAfter: ringProbeInterval,
+	}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RingStatusReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("tempostack-ringstatus").
+		For(&v1alpha1.TempoStack{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -8,17 +10,32 @@ import (
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 )
 
+// azureEndpointSuffixes maps the Azure cloud environments to their blob storage endpoint suffixes.
+var azureEndpointSuffixes = map[string]string{
+	"AzureGlobal":       "blob.core.windows.net",
+	"AzureUSGovernment": "blob.core.usgovcloudapi.net",
+	"AzureChinaCloud":   "blob.core.chinacloudapi.cn",
+}
+
+// awsS3Endpoint matches the regional endpoints of AWS S3, e.g. s3.us-east-1.amazonaws.com or s3-us-west-2.amazonaws.com.
+var awsS3Endpoint = regexp.MustCompile(`^s3[.-](?:dualstack\.)?([a-z0-9-]+)\.amazonaws\.com(\.cn)?$`)
+
 // GetAzureParams extracts Azure storage params of a storage secret.
 func GetAzureParams(storageSecret *corev1.Secret) *manifestutils.AzureStorage {
 	return &manifestutils.AzureStorage{
-		Container: string(storageSecret.Data["container"]),
+		Container:      string(storageSecret.Data["container"]),
+		EndpointSuffix: azureEndpointSuffixes[string(storageSecret.Data["environment"])],
 	}
 }
 
 // GetGCSParams extracts GCS params of a storage secret.
 func GetGCSParams(storageSecret *corev1.Secret) *manifestutils.GCS {
+	endpoint := string(storageSecret.Data["endpoint"])
+
 	return &manifestutils.GCS{
-		Bucket: string(storageSecret.Data["bucketname"]),
+		Bucket:   string(storageSecret.Data["bucketname"]),
+		Endpoint: endpoint,
+		Insecure: strings.HasPrefix(endpoint, "http://"),
 	}
 }
 
@@ -35,3 +52,16 @@ func GetS3Params(storageSecret *corev1.Secret) *manifestutils.S3 {
 		Insecure: insecure,
 	}
 }
+
+// DualStackS3Endpoint returns the dual-stack endpoint of the region of an AWS S3 endpoint (without http/https).
+func DualStackS3Endpoint(endpoint string) (string, error) {
+	if endpoint == "s3.amazonaws.com" {
+		return "s3.dualstack.us-east-1.amazonaws.com", nil
+	}
+
+	match := awsS3Endpoint.FindStringSubmatch(endpoint)
+	if match == nil {
+		return "", fmt.Errorf("the dual-stack endpoint requires a regional AWS S3 endpoint, e.g. https://s3.us-east-1.amazonaws.com, got %s", endpoint)
+	}
+	return fmt.Sprintf("s3.dualstack.%s.amazonaws.com%s", match[1], match[2]), nil
+}
@@ -6,6 +6,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetS3ParamsInsecure(t *testing.T) {
@@ -21,6 +22,42 @@ func TestGetS3ParamsInsecure(t *testing.T) {
 	assert.Equal(t, "testbucket", s3.Bucket)
 }
 
+func TestGetS3ParamsDualStack(t *testing.T) {
+	storageSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			"endpoint": []byte("https://s3.dualstack.us-east-1.amazonaws.com"),
+			"bucket":   []byte("testbucket"),
+		},
+	}
+	s3 := GetS3Params(storageSecret)
+	assert.Equal(t, "s3.dualstack.us-east-1.amazonaws.com", s3.Endpoint)
+	assert.False(t, s3.Insecure)
+}
+
+func TestDualStackS3Endpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		expected string
+	}{
+		{endpoint: "s3.amazonaws.com", expected: "s3.dualstack.us-east-1.amazonaws.com"},
+		{endpoint: "s3.eu-central-1.amazonaws.com", expected: "s3.dualstack.eu-central-1.amazonaws.com"},
+		{endpoint: "s3-us-west-2.amazonaws.com", expected: "s3.dualstack.us-west-2.amazonaws.com"},
+		{endpoint: "s3.cn-north-1.amazonaws.com.cn", expected: "s3.dualstack.cn-north-1.amazonaws.com.cn"},
+		{endpoint: "s3.dualstack.us-east-1.amazonaws.com", expected: "s3.dualstack.us-east-1.amazonaws.com"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.endpoint, func(t *testing.T) {
+			endpoint, err := DualStackS3Endpoint(test.endpoint)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, endpoint)
+		})
+	}
+
+	_, err := DualStackS3Endpoint("minio:9000")
+	require.Error(t, err)
+}
+
 func TestGetS3ParamsSecure(t *testing.T) {
 	storageSecret := &corev1.Secret{
 		Data: map[string][]byte{
@@ -33,3 +70,39 @@ func TestGetS3ParamsSecure(t *testing.T) {
 	assert.False(t, s3.Insecure)
 	assert.Equal(t, "testbucket", s3.Bucket)
 }
+
+func TestGetGCSParamsEndpoint(t *testing.T) {
+	storageSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			"bucketname": []byte("testbucket"),
+			"endpoint":   []byte("http://fake-gcs-server:4443/storage/v1/"),
+		},
+	}
+	gcs := GetGCSParams(storageSecret)
+	assert.Equal(t, "http://fake-gcs-server:4443/storage/v1/", gcs.Endpoint)
+	assert.True(t, gcs.Insecure)
+	assert.Equal(t, "testbucket", gcs.Bucket)
+}
+
+func TestGetGCSParamsDefaultEndpoint(t *testing.T) {
+	storageSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			"bucketname": []byte("testbucket"),
+		},
+	}
+	gcs := GetGCSParams(storageSecret)
+	assert.Empty(t, gcs.Endpoint)
+	assert.False(t, gcs.Insecure)
+}
+
+func TestGetAzureParamsEnvironment(t *testing.T) {
+	storageSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			"container":   []byte("testcontainer"),
+			"environment": []byte("AzureChinaCloud"),
+		},
+	}
+	azure := GetAzureParams(storageSecret)
+	assert.Equal(t, "testcontainer", azure.Container)
+	assert.Equal(t, "blob.core.chinacloudapi.cn", azure.EndpointSuffix)
+}
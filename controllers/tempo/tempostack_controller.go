@@ -4,14 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
 	routev1 "github.com/openshift/api/route/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -27,7 +33,10 @@ import (
 	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/certrotation/handlers"
+	"github.com/grafana/tempo-operator/internal/handlers/ingester"
+	"github.com/grafana/tempo-operator/internal/handlers/sharedobjects"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/servicemesh"
 	"github.com/grafana/tempo-operator/internal/status"
 	"github.com/grafana/tempo-operator/internal/upgrade"
 	"github.com/grafana/tempo-operator/internal/version"
@@ -35,6 +44,12 @@ import (
 
 const (
 	storageSecretField = ".spec.storage.secret.name" // nolint #nosec
+	sharedObjectsField = ".spec.sharedObjects"
+
+	eventReasonComponentDegraded = "ComponentDegraded"
+
+	// ingesterFlushPollInterval is the interval to check if the ingesters finished flushing their traces.
+	ingesterFlushPollInterval = 10 * time.Second
 )
 
 // TempoStackReconciler reconciles a TempoStack object.
@@ -47,14 +62,27 @@ type TempoStackReconciler struct {
 }
 
 // +kubebuilder:rbac:groups="",resources=services;configmaps;serviceaccounts;secrets;pods,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups="",resources=resourcequotas;limitranges,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments/finalizers,verbs=update
-// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=cronjobs;jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;networkpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings;clusterroles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=operator.openshift.io,resources=ingresscontrollers,verbs=get;list;watch
 // +kubebuilder:rbac:groups=config.openshift.io,resources=dnses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;prometheusrules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=grafana.integreatly.org,resources=grafanadatasources,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors,verbs=get;list;watch;create;update;patch;delete
+// The operator can only grant the OpenTelemetry Collector the permission to write traces if it holds this permission itself.
+// +kubebuilder:rbac:groups=tempo.grafana.com,resources=*,verbs=create
 
 //+kubebuilder:rbac:groups=tempo.grafana.com,resources=tempostacks,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=tempo.grafana.com,resources=tempostacks/status,verbs=get;update;patch
@@ -78,15 +106,30 @@ func (r *TempoStackReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		// we'll ignore not-found errors, since they can't be fixed by an immediate
 		// requeue (we'll need to wait for a new notification), and we can get them
 		// on deleted requests.
+		deleteMetrics(req.Namespace, req.Name)
+		status.DeleteMetrics(req.Namespace, req.Name)
 		return ctrl.Result{}, nil
 	}
 
+	// The cluster-scoped resources are deleted also for unmanaged TempoStacks, because they are not garbage collected.
+	if !tempo.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.finalize(ctx, log, tempo)
+	}
+	if err := r.addFinalizer(ctx, &tempo); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if tempo.Spec.ManagementState != v1alpha1.ManagementStateManaged {
 		log.Info("Skipping reconciliation for unmanaged TempoStack resource", "name", req.String())
 		// Stop requeueing for unmanaged TempoStack custom resources
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, r.handleUnmanagedStatus(ctx, tempo)
 	}
 
+	start := time.Now()
+	defer func() {
+		metricReconcileDuration.WithLabelValues(tempo.Namespace, tempo.Name).Observe(time.Since(start).Seconds())
+	}()
+
 	// Apply upgrades in case a TempoStack is switched back from Unmanaged to Managed state.
 	// In all other cases, the upgrade process at operator startup will upgrade the TempoStack instance.
 	//
@@ -101,29 +144,60 @@ func (r *TempoStackReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			Log:        ctrl.LoggerFrom(ctx).WithName("tempostack-reconcile-upgrade"),
 		}.TempoStack(ctx, tempo)
 		if err != nil {
-			return r.handleReconcileStatus(ctx, log, tempo, err)
+			return r.handleReconcileStatus(ctx, log, tempo, appliedConfig{}, err)
 		}
 	}
 
-	if r.CtrlConfig.Gates.BuiltInCertManagement.Enabled {
+	// The certificates of TempoStacks using cert-manager are issued by cert-manager,
+	// TempoStacks using SPIFFE fetch their certificates from the SPIFFE Workload API,
+	// and the traffic of TempoStacks inside a service mesh is encrypted by the sidecars.
+	if r.CtrlConfig.Gates.BuiltInCertManagement.Enabled && tempo.Spec.CertManager == nil && tempo.Spec.SPIFFE == nil && !servicemesh.Enabled(tempo) {
 		err := handlers.CreateOrRotateCertificates(ctx, log, req, r.Client, r.Scheme, r.CtrlConfig.Gates)
 		if err != nil {
-			return r.handleReconcileStatus(ctx, log, tempo, fmt.Errorf("built in cert manager error: %w", err))
+			return r.handleReconcileStatus(ctx, log, tempo, appliedConfig{}, fmt.Errorf("built in cert manager error: %w", err))
 		}
 	}
 
-	err := r.createOrUpdate(ctx, log, req, tempo)
+	// The manifests are built from the copies of the Secrets and ConfigMaps referenced from other namespaces.
+	if err := sharedobjects.Copy(ctx, r.Client, r.Scheme, tempo); err != nil {
+		return r.handleReconcileStatus(ctx, log, tempo, appliedConfig{}, err)
+	}
+
+	config, err := r.createOrUpdate(ctx, log, req, tempo)
+	if errors.Is(err, ingester.ErrFlushInProgress) {
+		// The end of a flush does not change any watched resource, therefore poll until the ingesters are flushed.
+		result, err := r.handleReconcileStatus(ctx, log, tempo, appliedConfig{}, err)
+		if err == nil {
+			result.RequeueAfter = ingesterFlushPollInterval
+		}
+		return result, err
+	}
 	if err != nil {
-		return r.handleReconcileStatus(ctx, log, tempo, err)
+		return r.handleReconcileStatus(ctx, log, tempo, appliedConfig{}, err)
 	}
 
 	// Update the components status also in case of no reconciliation errors.
-	return r.handleReconcileStatus(ctx, log, tempo, nil)
+	return r.handleReconcileStatus(ctx, log, tempo, config, nil)
+}
+
+// handleUnmanagedStatus reports the Unmanaged condition, the status of the components is not refreshed
+// because the generated resources might have been changed manually.
+func (r *TempoStackReconciler) handleUnmanagedStatus(ctx context.Context, tempo v1alpha1.TempoStack) error {
+	newStatus := *tempo.Status.DeepCopy()
+	newStatus.Conditions = status.UnmanagedCondition(tempo)
+	if reflect.DeepEqual(newStatus.Conditions, tempo.Status.Conditions) {
+		return nil
+	}
+	return status.Refresh(ctx, r, tempo, &newStatus)
 }
 
 // handleReconcileStatus updates the status of each component and sets an appropriate status condition:
 //
-//   - No error: Only update components status
+//   - No error: Update components status and record the checksum of the applied Tempo configuration,
+//     the applied images, the Tempo version, the generation and the operator version of this reconciliation
+//
+//   - For ingester.ErrFlushInProgress: Set the status condition to Pending with the Reason "IngesterFlushInProgress".
+//     The checksum, images, Tempo version and generation are not updated, because the update is postponed until the flush finished
 //
 //   - For ConfigurationError: Set the status condition to ConfigurationError.
 //     Return a reconcile.TerminalError to indicate that human intervention is required
@@ -131,19 +205,45 @@ func (r *TempoStackReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 //
 //   - For any other error: Set the status condition to Failed,
 //     the Reason to "FailedReconciliation" and the message to the error message.
-func (r *TempoStackReconciler) handleReconcileStatus(ctx context.Context, log logr.Logger, tempo v1alpha1.TempoStack, reconcileError error) (ctrl.Result, error) {
+func (r *TempoStackReconciler) handleReconcileStatus(ctx context.Context, log logr.Logger, tempo v1alpha1.TempoStack, config appliedConfig, reconcileError error) (ctrl.Result, error) {
 	// First refresh components
 	newStatus, rerr := status.GetComponentsStatus(ctx, r, tempo)
 	if rerr != nil {
 		log.Error(rerr, "could not get components status")
 	}
 
+	// Update the overall condition on top of the refreshed component conditions.
+	current := tempo.DeepCopy()
+	if rerr == nil {
+		current.Status.Conditions = newStatus.Conditions
+	}
+
+	if rerr == nil {
+		r.recordDegradedComponents(tempo, newStatus)
+	}
+
 	var configurationError *status.ConfigurationError
 	if reconcileError == nil {
-		// No error.
+		newStatus.ReconciledOperatorVersion = r.Version.OperatorVersion
+		newStatus.ObservedGeneration = tempo.Generation
+		newStatus.ConfigChecksum = config.checksum
+		newStatus.Images = tempo.Spec.Images
+		newStatus.TempoVersion = config.tempoVersion
+	} else if errors.Is(reconcileError, ingester.ErrFlushInProgress) {
+		// The update is postponed until the ingesters flushed their traces, therefore the status keeps
+		// the checksum, images, Tempo version and generation of the previously applied spec.
+		newStatus.Conditions = status.UpdateCondition(*current, metav1.Condition{
+			Type:    string(v1alpha1.ConditionPending),
+			Reason:  string(v1alpha1.ReasonIngesterFlushInProgress),
+			Message: reconcileError.Error(),
+		})
+		reconcileError = nil
 	} else if errors.As(reconcileError, &configurationError) {
+		r.Recorder.Event(&tempo, corev1.EventTypeWarning, string(configurationError.Reason), configurationError.Message)
+		metricReconcileErrors.WithLabelValues(tempo.Namespace, tempo.Name, string(configurationError.Reason)).Inc()
+
 		// Handle configuration error
-		newStatus.Conditions = status.UpdateCondition(tempo, metav1.Condition{
+		newStatus.Conditions = status.UpdateCondition(*current, metav1.Condition{
 			Type:    string(v1alpha1.ConditionConfigurationError),
 			Reason:  string(configurationError.Reason),
 			Message: configurationError.Message,
@@ -154,7 +254,9 @@ func (r *TempoStackReconciler) handleReconcileStatus(ctx context.Context, log lo
 		reconcileError = reconcile.TerminalError(configurationError)
 	} else {
 		// Handle all other errors (e.g. permission errors, etc.)
-		newStatus.Conditions = status.UpdateCondition(tempo, metav1.Condition{
+		r.Recorder.Event(&tempo, corev1.EventTypeWarning, string(v1alpha1.ReasonFailedReconciliation), reconcileError.Error())
+		metricReconcileErrors.WithLabelValues(tempo.Namespace, tempo.Name, string(v1alpha1.ReasonFailedReconciliation)).Inc()
+		newStatus.Conditions = status.UpdateCondition(*current, metav1.Condition{
 			Type:    string(v1alpha1.ConditionFailed),
 			Reason:  string(v1alpha1.ReasonFailedReconciliation),
 			Message: reconcileError.Error(),
@@ -173,6 +275,23 @@ func (r *TempoStackReconciler) handleReconcileStatus(ctx context.Context, log lo
 	return ctrl.Result{}, reconcileError
 }
 
+// recordDegradedComponents records a warning event for every component which became degraded since the last reconciliation.
+func (r *TempoStackReconciler) recordDegradedComponents(tempo v1alpha1.TempoStack, newStatus v1alpha1.TempoStackStatus) {
+	for _, condition := range v1alpha1.AllComponentConditions {
+		current := meta.FindStatusCondition(newStatus.Conditions, string(condition))
+		if current == nil || current.Reason != string(v1alpha1.ReasonFailedComponents) {
+			continue
+		}
+
+		previous := meta.FindStatusCondition(tempo.Status.Conditions, string(condition))
+		if previous != nil && previous.Reason == current.Reason && previous.Message == current.Message {
+			continue
+		}
+
+		r.Recorder.Eventf(&tempo, corev1.EventTypeWarning, eventReasonComponentDegraded, "%s is false: %s", condition, current.Message)
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *TempoStackReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Add an index to the storage secret field in the TempoStack CRD.
@@ -188,6 +307,18 @@ func (r *TempoStackReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if err != nil {
 		return err
 	}
+	// Add an index to the Secrets and ConfigMaps referenced from other namespaces,
+	// to update their copies if the original objects change.
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.TempoStack{}, sharedObjectsField, func(rawObj client.Object) []string {
+		var keys []string
+		for _, ref := range v1alpha1.SharedObjectReferences(*rawObj.(*v1alpha1.TempoStack)) {
+			keys = append(keys, sharedobjects.Key(ref.Kind, ref.Namespace, ref.Name))
+		}
+		return keys
+	})
+	if err != nil {
+		return err
+	}
 
 	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.TempoStack{}).
@@ -198,10 +329,25 @@ func (r *TempoStackReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&networkingv1.Ingress{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&batchv1.CronJob{}).
+		Owns(&batchv1.Job{}).
 		Watches(
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.findTempoStackForStorageSecret),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findTempoStacksForSharedObject(v1alpha1.SharedSecret)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findTempoStacksForSharedObject(v1alpha1.SharedConfigMap)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		)
 
 	if r.CtrlConfig.Gates.PrometheusOperator {
@@ -239,6 +385,31 @@ func (r *TempoStackReconciler) findTempoStackForStorageSecret(ctx context.Contex
 	return requests
 }
 
+// findTempoStacksForSharedObject returns a function which maps a Secret or ConfigMap
+// to the TempoStacks of other namespaces referencing it.
+func (r *TempoStackReconciler) findTempoStacksForSharedObject(kind v1alpha1.SharedObjectKind) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		tempostacks := &v1alpha1.TempoStackList{}
+		listOps := &client.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector(sharedObjectsField, sharedobjects.Key(kind, obj.GetNamespace(), obj.GetName())),
+		}
+		if err := r.List(ctx, tempostacks, listOps); err != nil {
+			return []reconcile.Request{}
+		}
+
+		requests := make([]reconcile.Request, len(tempostacks.Items))
+		for i, item := range tempostacks.Items {
+			requests[i] = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      item.GetName(),
+					Namespace: item.GetNamespace(),
+				},
+			}
+		}
+		return requests
+	}
+}
+
 // GetPodsComponent is used for fetching component pod status and refreshing the status of the CR.
 func (r *TempoStackReconciler) GetPodsComponent(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*corev1.PodList, error) {
 	pods := &corev1.PodList{}
@@ -251,6 +422,18 @@ func (r *TempoStackReconciler) GetPodsComponent(ctx context.Context, componentNa
 	return pods, err
 }
 
+// GetJobsComponent is used for fetching the status of the Jobs of a component, e.g. of the tenant purges.
+func (r *TempoStackReconciler) GetJobsComponent(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*batchv1.JobList, error) {
+	jobs := &batchv1.JobList{}
+
+	opts := []client.ListOption{
+		client.MatchingLabels(manifestutils.ComponentLabels(componentName, stack.Name)),
+		client.InNamespace(stack.Namespace),
+	}
+	err := r.Client.List(ctx, jobs, opts...)
+	return jobs, err
+}
+
 // PatchStatus patches the status field of the CR.
 func (r *TempoStackReconciler) PatchStatus(ctx context.Context, changed, original *v1alpha1.TempoStack) error {
 	statusPatch := client.MergeFrom(original)
@@ -12,7 +12,9 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -21,7 +23,10 @@ import (
 
 	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/ingester"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/status"
+	"github.com/grafana/tempo-operator/internal/tlsprofile"
 	"github.com/grafana/tempo-operator/internal/version"
 )
 
@@ -95,7 +100,7 @@ func TestReconcile(t *testing.T) {
 	reconciler := TempoStackReconciler{
 		Client:   k8sClient,
 		Scheme:   testScheme,
-		Recorder: record.NewFakeRecorder(1),
+		Recorder: record.NewFakeRecorder(100),
 		CtrlConfig: configv1alpha1.ProjectConfig{
 			Gates: configv1alpha1.FeatureGates{
 				TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
@@ -141,7 +146,11 @@ func TestReconcile(t *testing.T) {
 	updatedTempo := v1alpha1.TempoStack{}
 	err = k8sClient.Get(context.Background(), nsn, &updatedTempo)
 	require.NoError(t, err)
-	assert.Equal(t, "0.0.0", updatedTempo.Status.TempoVersion)
+	assert.Equal(t, "1.5.0", updatedTempo.Status.TempoVersion)
+	assert.Equal(t, version.Get().OperatorVersion, updatedTempo.Status.ReconciledOperatorVersion)
+	assert.Equal(t, updatedTempo.Generation, updatedTempo.Status.ObservedGeneration)
+	assert.NotEmpty(t, updatedTempo.Status.ConfigChecksum)
+	assert.Equal(t, updatedTempo.Spec.Images, updatedTempo.Status.Images)
 
 	// test status condition
 	assert.Equal(t, []metav1.Condition{{
@@ -162,10 +171,11 @@ func TestReadyToConfigurationError(t *testing.T) {
 	createTempoCR(t, nsn, storageSecret)
 
 	// Reconcile
+	recorder := record.NewFakeRecorder(100)
 	reconciler := TempoStackReconciler{
 		Client:   k8sClient,
 		Scheme:   testScheme,
-		Recorder: record.NewFakeRecorder(1),
+		Recorder: recorder,
 		CtrlConfig: configv1alpha1.ProjectConfig{
 			Gates: configv1alpha1.FeatureGates{
 				TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
@@ -226,6 +236,10 @@ func TestReadyToConfigurationError(t *testing.T) {
 	}, updatedTempo2.Status.Conditions)
 	assert.Greater(t, updatedTempo2.Status.Conditions[0].LastTransitionTime.UnixNano(), updatedTempo1.Status.Conditions[0].LastTransitionTime.UnixNano())
 	assert.Greater(t, updatedTempo2.Status.Conditions[1].LastTransitionTime.UnixNano(), updatedTempo1.Status.Conditions[0].LastTransitionTime.UnixNano())
+
+	// Verify the configuration error was recorded as event
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Warning InvalidStorageConfig invalid storage secret: \"endpoint\" field of storage secret must be a valid URL", <-recorder.Events)
 }
 
 func TestConfigurationErrorToConfigurationError(t *testing.T) {
@@ -243,7 +257,7 @@ func TestConfigurationErrorToConfigurationError(t *testing.T) {
 	reconciler := TempoStackReconciler{
 		Client:   k8sClient,
 		Scheme:   testScheme,
-		Recorder: record.NewFakeRecorder(1),
+		Recorder: record.NewFakeRecorder(100),
 		CtrlConfig: configv1alpha1.ProjectConfig{
 			Gates: configv1alpha1.FeatureGates{
 				TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
@@ -311,7 +325,7 @@ func TestConfigurationErrorToReady(t *testing.T) {
 	reconciler := TempoStackReconciler{
 		Client:   k8sClient,
 		Scheme:   testScheme,
-		Recorder: record.NewFakeRecorder(1),
+		Recorder: record.NewFakeRecorder(100),
 		CtrlConfig: configv1alpha1.ProjectConfig{
 			Gates: configv1alpha1.FeatureGates{
 				TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
@@ -375,6 +389,69 @@ func TestConfigurationErrorToReady(t *testing.T) {
 	assert.Greater(t, updatedTempo2.Status.Conditions[1].LastTransitionTime.UnixNano(), updatedTempo1.Status.Conditions[0].LastTransitionTime.UnixNano())
 }
 
+func TestReconcileUnmanaged(t *testing.T) {
+	nsn := types.NamespacedName{Name: "reconcile-unmanaged", Namespace: "default"}
+	storageSecret := createSecret(t, nsn)
+	createTempoCR(t, nsn, storageSecret)
+
+	tempo := v1alpha1.TempoStack{}
+	err := k8sClient.Get(context.Background(), nsn, &tempo)
+	require.NoError(t, err)
+	tempo.Spec.ManagementState = v1alpha1.ManagementStateUnmanaged
+	err = k8sClient.Update(context.Background(), &tempo)
+	require.NoError(t, err)
+
+	reconciler := TempoStackReconciler{
+		Client:   k8sClient,
+		Scheme:   testScheme,
+		Recorder: record.NewFakeRecorder(100),
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			Gates: configv1alpha1.FeatureGates{
+				TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
+			},
+		},
+		Version: version.Get(),
+	}
+	req := ctrl.Request{
+		NamespacedName: nsn,
+	}
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// Verify that no objects were created and the Unmanaged condition is reported
+	list := &appsv1.DeploymentList{}
+	err = k8sClient.List(context.Background(), list, client.InNamespace(nsn.Namespace), client.MatchingLabels{
+		"app.kubernetes.io/instance": nsn.Name,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, list.Items)
+
+	updatedTempo := v1alpha1.TempoStack{}
+	err = k8sClient.Get(context.Background(), nsn, &updatedTempo)
+	require.NoError(t, err)
+	require.Len(t, updatedTempo.Status.Conditions, 1)
+	assert.Equal(t, string(v1alpha1.ConditionUnmanaged), updatedTempo.Status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionTrue, updatedTempo.Status.Conditions[0].Status)
+	assert.Equal(t, string(v1alpha1.ReasonUnmanaged), updatedTempo.Status.Conditions[0].Reason)
+
+	// Switch back to Managed
+	updatedTempo.Spec.ManagementState = v1alpha1.ManagementStateManaged
+	err = k8sClient.Update(context.Background(), &updatedTempo)
+	require.NoError(t, err)
+
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	err = k8sClient.Get(context.Background(), nsn, &updatedTempo)
+	require.NoError(t, err)
+	conditions := map[string]metav1.ConditionStatus{}
+	for _, c := range updatedTempo.Status.Conditions {
+		conditions[c.Type] = c.Status
+	}
+	assert.Equal(t, metav1.ConditionFalse, conditions[string(v1alpha1.ConditionUnmanaged)])
+	assert.Equal(t, metav1.ConditionTrue, conditions[string(v1alpha1.ConditionReady)])
+}
+
 func TestReconcileGenericError(t *testing.T) {
 	nsn := types.NamespacedName{Name: "reconcile-errors", Namespace: "default"}
 	storageSecret := createSecret(t, nsn)
@@ -383,7 +460,7 @@ func TestReconcileGenericError(t *testing.T) {
 	reconciler := TempoStackReconciler{
 		Client:   k8sClient,
 		Scheme:   testScheme,
-		Recorder: record.NewFakeRecorder(1),
+		Recorder: record.NewFakeRecorder(100),
 		CtrlConfig: configv1alpha1.ProjectConfig{
 			Gates: configv1alpha1.FeatureGates{
 				TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
@@ -413,6 +490,42 @@ func TestReconcileGenericError(t *testing.T) {
 	assert.Contains(t, updatedTempo.Status.Conditions[0].Message, "error listing routes: no kind is registered for the type v1.RouteList")
 }
 
+func TestReconcileStatusFlushInProgress(t *testing.T) {
+	nsn := types.NamespacedName{Name: "reconcile-flush", Namespace: "default"}
+	storageSecret := createSecret(t, nsn)
+	createTempoCR(t, nsn, storageSecret)
+
+	tempo := v1alpha1.TempoStack{}
+	err := k8sClient.Get(context.Background(), nsn, &tempo)
+	require.NoError(t, err)
+	tempo.Status.ConfigChecksum = "previous"
+	tempo.Status.ObservedGeneration = tempo.Generation - 1
+	err = k8sClient.Status().Update(context.Background(), &tempo)
+	require.NoError(t, err)
+
+	reconciler := TempoStackReconciler{
+		Client:   k8sClient,
+		Scheme:   testScheme,
+		Recorder: record.NewFakeRecorder(100),
+		Version:  version.Get(),
+	}
+	_, err = reconciler.handleReconcileStatus(context.Background(), logr.Discard(), tempo, appliedConfig{}, ingester.ErrFlushInProgress)
+	require.NoError(t, err)
+
+	// The status keeps describing the previously applied spec until the ingesters are flushed.
+	updatedTempo := v1alpha1.TempoStack{}
+	err = k8sClient.Get(context.Background(), nsn, &updatedTempo)
+	require.NoError(t, err)
+	assert.Equal(t, "previous", updatedTempo.Status.ConfigChecksum)
+	assert.Equal(t, tempo.Generation-1, updatedTempo.Status.ObservedGeneration)
+	pending := meta.FindStatusCondition(updatedTempo.Status.Conditions, string(v1alpha1.ConditionPending))
+	require.NotNil(t, pending)
+	assert.Equal(t, metav1.ConditionTrue, pending.Status)
+	assert.Equal(t, string(v1alpha1.ReasonIngesterFlushInProgress), pending.Reason)
+	assert.Equal(t, "waiting for the ingesters to flush their traces", pending.Message)
+	assert.False(t, meta.IsStatusConditionTrue(updatedTempo.Status.Conditions, string(v1alpha1.ConditionReady)))
+}
+
 func TestTLSEnable(t *testing.T) {
 	nsn := types.NamespacedName{Name: "tls-enabled-test", Namespace: "default"}
 	storageSecret := createSecret(t, nsn)
@@ -421,7 +534,7 @@ func TestTLSEnable(t *testing.T) {
 	reconciler := TempoStackReconciler{
 		Client:   k8sClient,
 		Scheme:   testScheme,
-		Recorder: record.NewFakeRecorder(1),
+		Recorder: record.NewFakeRecorder(100),
 		CtrlConfig: configv1alpha1.ProjectConfig{
 			Gates: configv1alpha1.FeatureGates{
 				BuiltInCertManagement: configv1alpha1.BuiltInCertManagement{
@@ -534,7 +647,7 @@ func TestPruneIngress(t *testing.T) {
 	reconciler := TempoStackReconciler{
 		Client:   k8sClient,
 		Scheme:   testScheme,
-		Recorder: record.NewFakeRecorder(1),
+		Recorder: record.NewFakeRecorder(100),
 		CtrlConfig: configv1alpha1.ProjectConfig{
 			Gates: configv1alpha1.FeatureGates{
 				TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
@@ -663,7 +776,7 @@ func TestK8SGatewaySecret(t *testing.T) {
 	reconciler := TempoStackReconciler{
 		Client:   k8sClient,
 		Scheme:   testScheme,
-		Recorder: record.NewFakeRecorder(1),
+		Recorder: record.NewFakeRecorder(100),
 		CtrlConfig: configv1alpha1.ProjectConfig{
 			Gates: configv1alpha1.FeatureGates{
 				BuiltInCertManagement: configv1alpha1.BuiltInCertManagement{
@@ -778,7 +891,7 @@ func TestReconcileManifestsValidateModes(t *testing.T) {
 			require.NoError(t, err)
 			reconciler := TempoStackReconciler{Client: k8sClient, Scheme: testScheme}
 			req := ctrl.Request{NamespacedName: nsn}
-			err = reconciler.createOrUpdate(context.Background(), logr.Discard(), req, *tempo)
+			_, err = reconciler.createOrUpdate(context.Background(), logr.Discard(), req, *tempo)
 			tc.validate(t, err)
 		})
 	}
@@ -794,7 +907,7 @@ func TestUpgrade(t *testing.T) {
 	reconciler := TempoStackReconciler{
 		Client:   k8sClient,
 		Scheme:   testScheme,
-		Recorder: record.NewFakeRecorder(1),
+		Recorder: record.NewFakeRecorder(100),
 		CtrlConfig: configv1alpha1.ProjectConfig{
 			Gates: configv1alpha1.FeatureGates{
 				TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
@@ -823,3 +936,130 @@ func TestUpgrade(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "0.0.1", updatedTempo.Status.OperatorVersion)
 }
+
+func TestTLSProfileGates(t *testing.T) {
+	fg := configv1alpha1.FeatureGates{
+		TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
+		OpenShift: configv1alpha1.OpenShiftFeatureGates{
+			ClusterTLSPolicy: true,
+		},
+	}
+
+	assert.Equal(t, fg, tlsProfileGates(fg, v1alpha1.TempoStack{}))
+
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			TLSProfile: &v1alpha1.TLSProfileSpec{Type: configv1alpha1.TLSProfileModernType},
+		},
+	}
+	assert.Equal(t, configv1alpha1.FeatureGates{
+		TLSProfile: string(configv1alpha1.TLSProfileModernType),
+	}, tlsProfileGates(fg, tempo))
+}
+
+func TestGetCustomTLSProfile(t *testing.T) {
+	r := &TempoStackReconciler{}
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			TLSProfile: &v1alpha1.TLSProfileSpec{
+				Type:          configv1alpha1.TLSProfileCustomType,
+				Ciphers:       []string{"ECDHE-RSA-AES128-GCM-SHA256", "ECDHE-RSA-AES256-GCM-SHA384"},
+				MinTLSVersion: "VersionTLS12",
+			},
+		},
+	}
+
+	profile, err := r.getTLSProfile(context.Background(), logr.Discard(), tempo)
+	require.NoError(t, err)
+	assert.Equal(t, tlsprofile.TLSProfileOptions{
+		Ciphers:       []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+		MinTLSVersion: "VersionTLS12",
+	}, profile)
+}
+
+func TestGetFIPSTLSProfile(t *testing.T) {
+	r := &TempoStackReconciler{
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			Gates: configv1alpha1.FeatureGates{
+				TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
+				FIPS:       true,
+			},
+		},
+	}
+
+	profile, err := r.getTLSProfile(context.Background(), logr.Discard(), v1alpha1.TempoStack{})
+	require.NoError(t, err)
+	assert.Equal(t, "VersionTLS12", profile.MinTLSVersion)
+	assert.NotEmpty(t, profile.Ciphers)
+	assert.Subset(t, []string{
+		"TLS_AES_128_GCM_SHA256",
+		"TLS_AES_256_GCM_SHA384",
+		"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	}, profile.Ciphers)
+}
+
+func TestFinalizer(t *testing.T) {
+	nsn := types.NamespacedName{Name: "finalizer-test", Namespace: "default"}
+	storageSecret := createSecret(t, nsn)
+	createTempoCR(t, nsn, storageSecret)
+
+	reconciler := TempoStackReconciler{
+		Client:   k8sClient,
+		Scheme:   testScheme,
+		Recorder: record.NewFakeRecorder(100),
+		CtrlConfig: configv1alpha1.ProjectConfig{
+			Gates: configv1alpha1.FeatureGates{
+				TLSProfile: string(configv1alpha1.TLSProfileIntermediateType),
+			},
+		},
+		Version: version.Get(),
+	}
+	req := ctrl.Request{
+		NamespacedName: nsn,
+	}
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	tempo := v1alpha1.TempoStack{}
+	err = k8sClient.Get(context.Background(), nsn, &tempo)
+	require.NoError(t, err)
+	assert.Contains(t, tempo.Finalizers, tempoStackFinalizer)
+
+	// cluster-scoped resources of this TempoStack, and of a TempoStack with the same name in another namespace
+	labels := manifestutils.CommonLabels(nsn.Name)
+	clusterRoleBinding := func(name string, namespace string) *rbacv1.ClusterRoleBinding {
+		return &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Subjects:   []rbacv1.Subject{{Kind: "ServiceAccount", Name: "tempo-finalizer-test-gateway", Namespace: namespace}},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: name, APIGroup: "rbac.authorization.k8s.io"},
+		}
+	}
+	for _, obj := range []client.Object{
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "tempo-finalizer-test-gateway", Labels: labels}},
+		clusterRoleBinding("tempo-finalizer-test-gateway", nsn.Namespace),
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "tempo-finalizer-test-other", Labels: labels}},
+		clusterRoleBinding("tempo-finalizer-test-other", "other"),
+	} {
+		err = k8sClient.Create(context.Background(), obj)
+		require.NoError(t, err)
+	}
+
+	err = k8sClient.Delete(context.Background(), &tempo)
+	require.NoError(t, err)
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	err = k8sClient.Get(context.Background(), nsn, &tempo)
+	assert.True(t, apierrors.IsNotFound(err))
+	err = k8sClient.Get(context.Background(), types.NamespacedName{Name: "tempo-finalizer-test-gateway"}, &rbacv1.ClusterRole{})
+	assert.True(t, apierrors.IsNotFound(err))
+	err = k8sClient.Get(context.Background(), types.NamespacedName{Name: "tempo-finalizer-test-gateway"}, &rbacv1.ClusterRoleBinding{})
+	assert.True(t, apierrors.IsNotFound(err))
+	err = k8sClient.Get(context.Background(), types.NamespacedName{Name: "tempo-finalizer-test-other"}, &rbacv1.ClusterRole{})
+	assert.NoError(t, err)
+	err = k8sClient.Get(context.Background(), types.NamespacedName{Name: "tempo-finalizer-test-other"}, &rbacv1.ClusterRoleBinding{})
+	assert.NoError(t, err)
+}
@@ -7,22 +7,44 @@ import (
 	"strings"
 
 	"github.com/go-logr/logr"
+	openshiftconfigv1 "github.com/openshift/api/config/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/certrotation"
 	"github.com/grafana/tempo-operator/internal/handlers/gateway"
+	"github.com/grafana/tempo-operator/internal/handlers/httpclient"
+	"github.com/grafana/tempo-operator/internal/handlers/ingester"
 	"github.com/grafana/tempo-operator/internal/manifests"
+	"github.com/grafana/tempo-operator/internal/manifests/certmanager"
+	"github.com/grafana/tempo-operator/internal/manifests/grafana"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+	"github.com/grafana/tempo-operator/internal/manifests/oauthproxy"
+	"github.com/grafana/tempo-operator/internal/manifests/otelcollector"
+	"github.com/grafana/tempo-operator/internal/manifests/servicemesh"
+	"github.com/grafana/tempo-operator/internal/manifests/vpa"
 	"github.com/grafana/tempo-operator/internal/status"
 	"github.com/grafana/tempo-operator/internal/tlsprofile"
+	"github.com/grafana/tempo-operator/internal/upgrade"
 )
 
 func (r *TempoStackReconciler) getStorageConfig(ctx context.Context, tempo v1alpha1.TempoStack) (manifestutils.StorageParams, error) {
@@ -50,6 +72,12 @@ func (r *TempoStackReconciler) getStorageConfig(ctx context.Context, tempo v1alp
 		params.GCS = GetGCSParams(storageSecret)
 	case v1alpha1.ObjectStorageSecretS3:
 		params.S3 = GetS3Params(storageSecret)
+		if tempo.Spec.Storage.S3 != nil && tempo.Spec.Storage.S3.DualStack {
+			params.S3.Endpoint, err = DualStackS3Endpoint(params.S3.Endpoint)
+			if err != nil {
+				return manifestutils.StorageParams{}, fmt.Errorf("invalid storage secret: %w", err)
+			}
+		}
 	default:
 		return manifestutils.StorageParams{}, fmt.Errorf("storage secret type is not recognized")
 	}
@@ -57,6 +85,136 @@ func (r *TempoStackReconciler) getStorageConfig(ctx context.Context, tempo v1alp
 	return params, nil
 }
 
+// getCertManagerCABundle returns the CA certificate of the certificates issued by cert-manager,
+// or an empty string if cert-manager did not issue the certificates yet.
+func (r *TempoStackReconciler) getCertManagerCABundle(ctx context.Context, tempo v1alpha1.TempoStack) (string, error) {
+	secret := &corev1.Secret{}
+	name := naming.TLSSecretName(manifestutils.DistributorComponentName, tempo.Name)
+	err := r.Get(ctx, types.NamespacedName{Namespace: tempo.Namespace, Name: name}, secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("could not fetch certificate secret: %w", err)
+	}
+
+	return string(secret.Data[certmanager.CAKey]), nil
+}
+
+// getOAuthProxyCookieSecret returns the existing cookie secret of the Jaeger Query UI OAuth proxy.
+func (r *TempoStackReconciler) getOAuthProxyCookieSecret(ctx context.Context, tempo v1alpha1.TempoStack) (string, error) {
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: tempo.Namespace, Name: oauthproxy.SecretName(tempo.Name)}, secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("could not fetch oauth proxy secret: %w", err)
+	}
+
+	return string(secret.Data[oauthproxy.CookieSecretKey]), nil
+}
+
+// getRouteCertificates returns the custom certificates of a Route, read from the Secret and ConfigMap referenced in the RouteSpec.
+func (r *TempoStackReconciler) getRouteCertificates(ctx context.Context, tempo v1alpha1.TempoStack, spec v1alpha1.RouteSpec) (manifestutils.RouteCertificates, error) {
+	certs := manifestutils.RouteCertificates{}
+
+	if spec.CertificateSecret != "" {
+		secret := &corev1.Secret{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: tempo.Namespace, Name: spec.CertificateSecret}, secret)
+		if err != nil {
+			return certs, fmt.Errorf("could not fetch route certificate secret: %w", err)
+		}
+
+		certs.Certificate = string(secret.Data[corev1.TLSCertKey])
+		certs.Key = string(secret.Data[corev1.TLSPrivateKeyKey])
+		certs.CACertificate = string(secret.Data[corev1.ServiceAccountRootCAKey])
+		if certs.Certificate == "" || certs.Key == "" {
+			return certs, fmt.Errorf("route certificate secret %s must contain the %s and %s keys", spec.CertificateSecret, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+		}
+	}
+
+	if spec.DestinationCAConfigMap != "" {
+		configMap := &corev1.ConfigMap{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: tempo.Namespace, Name: spec.DestinationCAConfigMap}, configMap)
+		if err != nil {
+			return certs, fmt.Errorf("could not fetch route destination CA configmap: %w", err)
+		}
+
+		certs.DestinationCACertificate = configMap.Data[certrotation.CAFile]
+		if certs.DestinationCACertificate == "" {
+			return certs, fmt.Errorf("route destination CA configmap %s must contain the %s key", spec.DestinationCAConfigMap, certrotation.CAFile)
+		}
+	}
+
+	return certs, nil
+}
+
+// getRouteHostCertificates returns the certificates of the additional hosts of the Routes, by the name of their TLS secret.
+func (r *TempoStackReconciler) getRouteHostCertificates(ctx context.Context, tempo v1alpha1.TempoStack) (map[string]manifestutils.RouteCertificates, error) {
+	certs := map[string]manifestutils.RouteCertificates{}
+	for _, ingress := range []v1alpha1.IngressSpec{tempo.Spec.Template.Gateway.Ingress, tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress} {
+		if ingress.Type != v1alpha1.IngressTypeRoute {
+			continue
+		}
+
+		for _, host := range ingress.AdditionalHosts {
+			if host.TLSSecretName == "" {
+				continue
+			}
+			if _, ok := certs[host.TLSSecretName]; ok {
+				continue
+			}
+
+			hostCerts, err := r.getRouteCertificates(ctx, tempo, v1alpha1.RouteSpec{CertificateSecret: host.TLSSecretName})
+			if err != nil {
+				return nil, err
+			}
+			certs[host.TLSSecretName] = hostCerts
+		}
+	}
+	return certs, nil
+}
+
+// getTLSProfile returns the TLS settings of the TempoStack.
+// A custom TLS profile of the TempoStack takes precedence over the TLS profile of the operator.
+// With the fips feature gate, the settings are restricted to FIPS approved TLS versions and cipher suites.
+func (r *TempoStackReconciler) getTLSProfile(ctx context.Context, log logr.Logger, tempo v1alpha1.TempoStack) (tlsprofile.TLSProfileOptions, error) {
+	tlsProfile, err := r.getTLSSettings(ctx, log, tempo)
+	if r.CtrlConfig.Gates.FIPS && tlsProfile.MinTLSVersion != "" {
+		tlsProfile = tlsProfile.FIPS()
+	}
+	return tlsProfile, err
+}
+
+func (r *TempoStackReconciler) getTLSSettings(ctx context.Context, log logr.Logger, tempo v1alpha1.TempoStack) (tlsprofile.TLSProfileOptions, error) {
+	if tempo.Spec.TLSProfile != nil && tempo.Spec.TLSProfile.Type == configv1alpha1.TLSProfileCustomType {
+		return tlsprofile.GetTLSSettings(openshiftconfigv1.TLSSecurityProfile{
+			Type: openshiftconfigv1.TLSProfileCustomType,
+			Custom: &openshiftconfigv1.CustomTLSProfile{
+				TLSProfileSpec: openshiftconfigv1.TLSProfileSpec{
+					Ciphers:       tempo.Spec.TLSProfile.Ciphers,
+					MinTLSVersion: openshiftconfigv1.TLSProtocolVersion(tempo.Spec.TLSProfile.MinTLSVersion),
+				},
+			},
+		})
+	}
+
+	return tlsprofile.Get(ctx, tlsProfileGates(r.CtrlConfig.Gates, tempo), r.Client, log)
+}
+
+// tlsProfileGates returns the feature gates used to look up the TLS profile,
+// honoring the TLS profile override of the TempoStack.
+func tlsProfileGates(fg configv1alpha1.FeatureGates, tempo v1alpha1.TempoStack) configv1alpha1.FeatureGates {
+	if tempo.Spec.TLSProfile == nil {
+		return fg
+	}
+
+	fg.TLSProfile = string(tempo.Spec.TLSProfile.Type)
+	fg.OpenShift.ClusterTLSPolicy = false
+	return fg
+}
+
 func isNamespaceScoped(obj client.Object) bool {
 	switch obj.(type) {
 	case *rbacv1.ClusterRole, *rbacv1.ClusterRoleBinding:
@@ -66,17 +224,26 @@ func isNamespaceScoped(obj client.Object) bool {
 	}
 }
 
-func (r *TempoStackReconciler) createOrUpdate(ctx context.Context, log logr.Logger, req ctrl.Request, tempo v1alpha1.TempoStack) error {
+// appliedConfig describes the configuration applied by a reconciliation.
+type appliedConfig struct {
+	// checksum is the checksum of the Tempo configuration.
+	checksum string
+	// tempoVersion is the Tempo version all workloads are configured with,
+	// or the previous version while a new version is rolled out.
+	tempoVersion string
+}
+
+func (r *TempoStackReconciler) createOrUpdate(ctx context.Context, log logr.Logger, req ctrl.Request, tempo v1alpha1.TempoStack) (appliedConfig, error) {
 	storageConfig, err := r.getStorageConfig(ctx, tempo)
 	if err != nil {
-		return &status.ConfigurationError{
+		return appliedConfig{}, &status.ConfigurationError{
 			Reason:  v1alpha1.ReasonInvalidStorageConfig,
 			Message: err.Error(),
 		}
 	}
 
 	if err = v1alpha1.ValidateTenantConfigs(tempo); err != nil {
-		return &status.ConfigurationError{
+		return appliedConfig{}, &status.ConfigurationError{
 			Message: fmt.Sprintf("Invalid tenants configuration: %s", err),
 			Reason:  v1alpha1.ReasonInvalidTenantsConfiguration,
 		}
@@ -85,23 +252,23 @@ func (r *TempoStackReconciler) createOrUpdate(ctx context.Context, log logr.Logg
 	if tempo.Spec.Tenants != nil && tempo.Spec.Tenants.Mode == v1alpha1.ModeOpenShift && r.CtrlConfig.Gates.OpenShift.BaseDomain == "" {
 		domain, err := gateway.GetOpenShiftBaseDomain(ctx, r.Client)
 		if err != nil {
-			return err
+			return appliedConfig{}, err
 		}
 		log.Info("OpenShift base domain set", "openshift-base-domain", domain)
 		r.CtrlConfig.Gates.OpenShift.BaseDomain = domain
 	}
 
-	tlsProfile, err := tlsprofile.Get(ctx, r.CtrlConfig.Gates, r.Client, log)
+	tlsProfile, err := r.getTLSProfile(ctx, log, tempo)
 	if err != nil {
 		switch err {
 		case tlsprofile.ErrGetProfileFromCluster:
 		case tlsprofile.ErrGetInvalidProfile:
-			return &status.ConfigurationError{
+			return appliedConfig{}, &status.ConfigurationError{
 				Message: err.Error(),
 				Reason:  v1alpha1.ReasonCouldNotGetOpenShiftTLSPolicy,
 			}
 		default:
-			return err
+			return appliedConfig{}, err
 		}
 
 	}
@@ -112,14 +279,14 @@ func (r *TempoStackReconciler) createOrUpdate(ctx context.Context, log logr.Logg
 	// the Ingress object should be removed from the cluster.
 	pruneObjects, err := r.findObjectsOwnedByTempoOperator(ctx, tempo)
 	if err != nil {
-		return err
+		return appliedConfig{}, err
 	}
 
 	var tenantSecrets []*manifestutils.GatewayTenantOIDCSecret
 	if tempo.Spec.Tenants != nil && tempo.Spec.Tenants.Mode == v1alpha1.ModeStatic {
 		tenantSecrets, err = gateway.GetOIDCTenantSecrets(ctx, r.Client, tempo)
 		if err != nil {
-			return err
+			return appliedConfig{}, err
 		}
 	}
 
@@ -132,21 +299,118 @@ func (r *TempoStackReconciler) createOrUpdate(ctx context.Context, log logr.Logg
 		}
 	}
 
-	managedObjects, err := manifests.BuildAll(manifestutils.Params{
-		Tempo:               tempo,
-		StorageParams:       storageConfig,
-		Gates:               r.CtrlConfig.Gates,
-		TLSProfile:          tlsProfile,
-		GatewayTenantSecret: tenantSecrets,
-		GatewayTenantsData:  gatewayTenantsData,
-	})
+	var certManagerCABundle string
+	if tempo.Spec.CertManager != nil {
+		certManagerCABundle, err = r.getCertManagerCABundle(ctx, tempo)
+		if err != nil {
+			return appliedConfig{}, err
+		}
+	}
+
+	var oauthProxyCookieSecret string
+	if oauthproxy.Enabled(tempo) {
+		oauthProxyCookieSecret, err = r.getOAuthProxyCookieSecret(ctx, tempo)
+		if err != nil {
+			return appliedConfig{}, err
+		}
+	}
+
+	gatewayRouteCertificates, err := r.getRouteCertificates(ctx, tempo, tempo.Spec.Template.Gateway.Ingress.Route)
+	if err != nil {
+		return appliedConfig{}, &status.ConfigurationError{
+			Message: err.Error(),
+			Reason:  v1alpha1.ReasonMissingRouteCertificate,
+		}
+	}
+
+	jaegerQueryRouteCertificates, err := r.getRouteCertificates(ctx, tempo, tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress.Route)
+	if err != nil {
+		return appliedConfig{}, &status.ConfigurationError{
+			Message: err.Error(),
+			Reason:  v1alpha1.ReasonMissingRouteCertificate,
+		}
+	}
+
+	routeHostCertificates, err := r.getRouteHostCertificates(ctx, tempo)
+	if err != nil {
+		return appliedConfig{}, &status.ConfigurationError{
+			Message: err.Error(),
+			Reason:  v1alpha1.ReasonMissingRouteCertificate,
+		}
+	}
+
+	params := manifestutils.Params{
+		Tempo:                        tempo,
+		StorageParams:                storageConfig,
+		Gates:                        r.CtrlConfig.Gates,
+		Distribution:                 r.CtrlConfig.Distribution,
+		TLSProfile:                   tlsProfile,
+		GatewayTenantSecret:          tenantSecrets,
+		GatewayTenantsData:           gatewayTenantsData,
+		CertManagerCABundle:          certManagerCABundle,
+		OAuthProxyCookieSecret:       oauthProxyCookieSecret,
+		GatewayRouteCertificates:     gatewayRouteCertificates,
+		JaegerQueryRouteCertificates: jaegerQueryRouteCertificates,
+		RouteHostCertificates:        routeHostCertificates,
+	}
+	managedObjects, err := manifests.BuildAll(params)
 	// TODO (pavolloffay) check error type and change return appropriately
 	if err != nil {
-		return fmt.Errorf("error building manifests: %w", err)
+		return appliedConfig{}, fmt.Errorf("error building manifests: %w", err)
 	}
 
+	// The Tempo version is recorded once all workloads are configured with the new version.
+	// Until then, the migration steps between the recorded and the new version apply.
+	rolledOut, err := upgrade.VersionRolledOut(ctx, r.Client, managedObjects)
+	if err != nil {
+		return appliedConfig{}, err
+	}
+	tempoVersion := upgrade.TempoVersion(tempo.Spec.Images.Tempo, r.CtrlConfig.DefaultImages.Tempo, r.Version.TempoVersion)
+	if !rolledOut {
+		if migrated, ok := upgrade.MigrateTempo(tempo, tempo.Status.TempoVersion, tempoVersion); ok {
+			params.Tempo = migrated
+			managedObjects, err = manifests.BuildAll(params)
+			if err != nil {
+				return appliedConfig{}, fmt.Errorf("error building manifests: %w", err)
+			}
+		}
+		tempoVersion = tempo.Status.TempoVersion
+	}
+	configChecksum := manifests.ConfigChecksum(managedObjects)
+
 	errs := []error{}
+	flushing := false
+	// Roll out a new Tempo version component by component, the ingesters are flushed before they are updated.
+	err = upgrade.OrderRollout(ctx, r.Client, httpclient.ForComponent, tempo, servicemesh.FeatureGates(r.CtrlConfig.Gates, tempo).HTTPEncryption, managedObjects)
+	if errors.Is(err, ingester.ErrFlushInProgress) {
+		log.Info("waiting for the ingesters to flush their traces, postponing the update of the affected components")
+		flushing = true
+	} else if err != nil {
+		log.Error(err, "failed to order the rollout of the Tempo version, postponing the update of the affected components")
+		errs = append(errs, err)
+	}
+	if !rolledOut {
+		// The workloads which still run the previous version keep the configuration of the previous version.
+		managedObjects, err = upgrade.StageConfig(ctx, r.Client, tempo, managedObjects)
+		if err != nil {
+			return appliedConfig{}, err
+		}
+	}
+
+	applied := 0
 	for _, obj := range managedObjects {
+		// Flush the ingesters before the StatefulSet is scaled down, otherwise the in-memory traces are lost.
+		if ss, ok := obj.(*appsv1.StatefulSet); ok && ingester.IsIngester(ss) {
+			ss.SetNamespace(req.Namespace)
+			err := ingester.FlushRemovedIngesters(ctx, r.Client, httpclient.ForComponent, tempo, servicemesh.FeatureGates(r.CtrlConfig.Gates, tempo).HTTPEncryption, ss)
+			if errors.Is(err, ingester.ErrFlushInProgress) {
+				log.Info("waiting for the ingesters to flush their traces, postponing the scale-down", "statefulset", ss.Name)
+				flushing = true
+			} else if err != nil {
+				log.Error(err, "failed to flush ingesters, postponing the scale-down", "statefulset", ss.Name)
+				errs = append(errs, err)
+			}
+		}
 		l := log.WithValues(
 			"object_name", obj.GetName(),
 			"object_kind", obj.GetObjectKind(),
@@ -172,13 +436,31 @@ func (r *TempoStackReconciler) createOrUpdate(ctx context.Context, log logr.Logg
 		}
 
 		l.V(1).Info(fmt.Sprintf("resource has been %s", op))
+		applied++
 
 		// This object is still managed by the operator, remove it from the list of objects to prune
 		delete(pruneObjects, obj.GetUID())
 	}
 
+	metricManagedObjects.WithLabelValues(tempo.Namespace, tempo.Name).Set(float64(applied))
 	if len(errs) > 0 {
-		return fmt.Errorf("failed to create objects for TempoStack %s: %w", req.NamespacedName, errors.Join(errs...))
+		return appliedConfig{}, fmt.Errorf("failed to create objects for TempoStack %s: %w", req.NamespacedName, errors.Join(errs...))
+	}
+
+	// Delete the volumes of the removed ingesters, the volumes of a hibernated TempoStack are kept.
+	if !tempo.Spec.Hibernate {
+		for _, obj := range managedObjects {
+			if ss, ok := obj.(*appsv1.StatefulSet); ok && ingester.IsIngester(ss) {
+				if err := ingester.DeleteRemovedVolumes(ctx, r.Client, ss); err != nil {
+					return appliedConfig{}, err
+				}
+			}
+		}
+	}
+
+	if tempo.Spec.CertManager != nil && certManagerCABundle == "" {
+		// Requeue until cert-manager issued the certificates, to create the CA bundle.
+		return appliedConfig{}, fmt.Errorf("waiting for cert-manager to issue the certificates of TempoStack %s", req.NamespacedName)
 	}
 
 	// Prune owned objects in the cluster which are not managed anymore.
@@ -190,17 +472,22 @@ func (r *TempoStackReconciler) createOrUpdate(ctx context.Context, log logr.Logg
 		)
 		l.Info("pruning unmanaged resource")
 
-		err = r.Delete(ctx, obj)
+		// Delete the pods of pruned Jobs as well, Jobs are orphaning their pods by default.
+		err = r.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground))
 		if err != nil {
 			l.Error(err, "failed to delete resource")
 			pruneErrs = append(pruneErrs, err)
 		}
 	}
 	if len(pruneErrs) > 0 {
-		return fmt.Errorf("failed to prune objects of TempoStack %s: %w", req.NamespacedName, errors.Join(pruneErrs...))
+		return appliedConfig{}, fmt.Errorf("failed to prune objects of TempoStack %s: %w", req.NamespacedName, errors.Join(pruneErrs...))
 	}
 
-	return nil
+	config := appliedConfig{checksum: configChecksum, tempoVersion: tempoVersion}
+	if flushing {
+		return config, ingester.ErrFlushInProgress
+	}
+	return config, nil
 }
 
 func (r *TempoStackReconciler) findObjectsOwnedByTempoOperator(ctx context.Context, tempo v1alpha1.TempoStack) (map[types.UID]client.Object, error) {
@@ -222,7 +509,99 @@ func (r *TempoStackReconciler) findObjectsOwnedByTempoOperator(ctx context.Conte
 		ownedObjects[ingressList.Items[i].GetUID()] = &ingressList.Items[i]
 	}
 
-	if r.CtrlConfig.Gates.PrometheusOperator {
+	networkPolicyList := &networkingv1.NetworkPolicyList{}
+	err = r.List(ctx, networkPolicyList, listOps)
+	if err != nil {
+		return nil, fmt.Errorf("error listing network policies: %w", err)
+	}
+	for i := range networkPolicyList.Items {
+		ownedObjects[networkPolicyList.Items[i].GetUID()] = &networkPolicyList.Items[i]
+	}
+
+	// Only the Grafana ConfigMaps and the Tempo configuration of a rollout can be disabled, other ConfigMaps like the CA bundle are managed outside of this reconciliation.
+	configMapList := &corev1.ConfigMapList{}
+	err = r.List(ctx, configMapList, listOps)
+	if err != nil {
+		return nil, fmt.Errorf("error listing configmaps: %w", err)
+	}
+	for i := range configMapList.Items {
+		switch configMapList.Items[i].Name {
+		case naming.Name("grafana-dashboards", tempo.Name), naming.Name("grafana-datasources", tempo.Name), upgrade.RolloutConfigMapName(tempo):
+			ownedObjects[configMapList.Items[i].GetUID()] = &configMapList.Items[i]
+		}
+	}
+
+	// The ingester StatefulSets depend on the zones of the zone-aware replication.
+	statefulSetList := &appsv1.StatefulSetList{}
+	err = r.List(ctx, statefulSetList, listOps)
+	if err != nil {
+		return nil, fmt.Errorf("error listing statefulsets: %w", err)
+	}
+	for i := range statefulSetList.Items {
+		ownedObjects[statefulSetList.Items[i].GetUID()] = &statefulSetList.Items[i]
+	}
+
+	hpaList := &autoscalingv2.HorizontalPodAutoscalerList{}
+	err = r.List(ctx, hpaList, listOps)
+	if err != nil {
+		return nil, fmt.Errorf("error listing horizontal pod autoscalers: %w", err)
+	}
+	for i := range hpaList.Items {
+		ownedObjects[hpaList.Items[i].GetUID()] = &hpaList.Items[i]
+	}
+
+	pdbList := &policyv1.PodDisruptionBudgetList{}
+	err = r.List(ctx, pdbList, listOps)
+	if err != nil {
+		return nil, fmt.Errorf("error listing pod disruption budgets: %w", err)
+	}
+	for i := range pdbList.Items {
+		ownedObjects[pdbList.Items[i].GetUID()] = &pdbList.Items[i]
+	}
+
+	cronJobList := &batchv1.CronJobList{}
+	err = r.List(ctx, cronJobList, listOps)
+	if err != nil {
+		return nil, fmt.Errorf("error listing cron jobs: %w", err)
+	}
+	for i := range cronJobList.Items {
+		ownedObjects[cronJobList.Items[i].GetUID()] = &cronJobList.Items[i]
+	}
+
+	jobList := &batchv1.JobList{}
+	err = r.List(ctx, jobList, listOps)
+	if err != nil {
+		return nil, fmt.Errorf("error listing jobs: %w", err)
+	}
+	for i := range jobList.Items {
+		// The Jobs created by the backup CronJob inherit its labels, but are owned by the CronJob.
+		if !metav1.IsControlledBy(&jobList.Items[i], &tempo) {
+			continue
+		}
+		ownedObjects[jobList.Items[i].GetUID()] = &jobList.Items[i]
+	}
+
+	// KEDA, the VerticalPodAutoscaler, the Grafana Operator and the OpenTelemetry Operator are optional,
+	// skip their resources if the CRD is not installed or the operator is not allowed to list them.
+	for _, gvk := range []schema.GroupVersionKind{manifestutils.ScaledObjectGVK, vpa.VerticalPodAutoscalerGVK, grafana.DatasourceGVK, otelcollector.CollectorGVK} {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		err = r.List(ctx, list, listOps)
+		if meta.IsNoMatchError(err) || apierrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error listing %s: %w", gvk.Kind, err)
+		}
+		for i := range list.Items {
+			ownedObjects[list.Items[i].GetUID()] = &list.Items[i]
+		}
+	}
+
+	// The feature gates can be overridden per TempoStack. The resources are listed if the feature gate is enabled
+	// in the operator configuration or in the TempoStack, to prune them after the feature gate was disabled.
+	gates := servicemesh.FeatureGates(r.CtrlConfig.Gates, tempo)
+	if r.CtrlConfig.Gates.PrometheusOperator || gates.PrometheusOperator {
 		servicemonitorList := &monitoringv1.ServiceMonitorList{}
 		err := r.List(ctx, servicemonitorList, listOps)
 		if err != nil {
@@ -242,7 +621,7 @@ func (r *TempoStackReconciler) findObjectsOwnedByTempoOperator(ctx context.Conte
 		}
 	}
 
-	if r.CtrlConfig.Gates.OpenShift.OpenShiftRoute {
+	if r.CtrlConfig.Gates.OpenShift.OpenShiftRoute || gates.OpenShift.OpenShiftRoute {
 		routesList := &routev1.RouteList{}
 		err := r.List(ctx, routesList, listOps)
 		if err != nil {
@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+// tempoStackFinalizer is set on every TempoStack, to delete the cluster-scoped resources of a TempoStack before it is removed.
+// The garbage collector removes all namespaced resources, because they have an owner reference to the TempoStack,
+// but cluster-scoped resources cannot be owned by a namespaced resource.
+const tempoStackFinalizer = "tempo.grafana.com/finalizer"
+
+// addFinalizer adds the finalizer to a TempoStack, if it is not set yet.
+func (r *TempoStackReconciler) addFinalizer(ctx context.Context, tempo *v1alpha1.TempoStack) error {
+	if controllerutil.ContainsFinalizer(tempo, tempoStackFinalizer) {
+		return nil
+	}
+
+	patch := client.MergeFrom(tempo.DeepCopy())
+	controllerutil.AddFinalizer(tempo, tempoStackFinalizer)
+	if err := r.Patch(ctx, tempo, patch); err != nil {
+		return fmt.Errorf("failed to add finalizer: %w", err)
+	}
+	return nil
+}
+
+// finalize deletes the cluster-scoped resources of a deleted TempoStack and removes the finalizer afterwards.
+func (r *TempoStackReconciler) finalize(ctx context.Context, log logr.Logger, tempo v1alpha1.TempoStack) error {
+	if !controllerutil.ContainsFinalizer(&tempo, tempoStackFinalizer) {
+		return nil
+	}
+
+	log.Info("deleting cluster-scoped resources of the TempoStack")
+	if err := r.deleteClusterScopedObjects(ctx, tempo); err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(tempo.DeepCopy())
+	controllerutil.RemoveFinalizer(&tempo, tempoStackFinalizer)
+	if err := r.Patch(ctx, &tempo, patch); err != nil {
+		return fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+	return nil
+}
+
+// deleteClusterScopedObjects deletes the ClusterRoleBindings of a TempoStack, and the ClusterRoles which are not bound anymore.
+// TempoStacks with the same name in different namespaces have the same labels, therefore only the ClusterRoleBindings
+// with a subject in the namespace of the TempoStack are deleted.
+func (r *TempoStackReconciler) deleteClusterScopedObjects(ctx context.Context, tempo v1alpha1.TempoStack) error {
+	listOps := client.MatchingLabels(manifestutils.CommonLabels(tempo.Name))
+
+	clusterRoleBindings := &rbacv1.ClusterRoleBindingList{}
+	if err := r.List(ctx, clusterRoleBindings, listOps); err != nil {
+		return fmt.Errorf("error listing cluster role bindings: %w", err)
+	}
+
+	errs := []error{}
+	boundRoles := map[string]bool{}
+	for i := range clusterRoleBindings.Items {
+		binding := &clusterRoleBindings.Items[i]
+		if !hasSubjectInNamespace(binding, tempo.Namespace) {
+			boundRoles[binding.RoleRef.Name] = true
+			continue
+		}
+
+		if err := r.Delete(ctx, binding); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete cluster role binding %s: %w", binding.Name, err))
+		}
+	}
+
+	clusterRoles := &rbacv1.ClusterRoleList{}
+	if err := r.List(ctx, clusterRoles, listOps); err != nil {
+		return fmt.Errorf("error listing cluster roles: %w", err)
+	}
+
+	for i := range clusterRoles.Items {
+		role := &clusterRoles.Items[i]
+		if boundRoles[role.Name] {
+			continue
+		}
+
+		if err := r.Delete(ctx, role); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete cluster role %s: %w", role.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func hasSubjectInNamespace(binding *rbacv1.ClusterRoleBinding, namespace string) bool {
+	for _, subject := range binding.Subjects {
+		if subject.Namespace == namespace {
+			return true
+		}
+	}
+	return false
+}
@@ -10,10 +10,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1alpha1 "github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 )
 
-const certRotationRequiredAtKey = "tempo.grafana.com/certRotationRequiredAt"
-
 // AnnotateForRequiredCertRotation adds/updates the `tempo.grafana.com/certRotationRequiredAt` annotation
 // to the named TempoStack if any of the managed client/serving/ca certificates expired. If no TempoStack
 // is found, then skip reconciliation.
@@ -35,10 +34,10 @@ func AnnotateForRequiredCertRotation(ctx context.Context, k client.Client, name,
 		ss.Annotations = make(map[string]string)
 	}
 
-	ss.Annotations[certRotationRequiredAtKey] = time.Now().UTC().Format(time.RFC3339)
+	ss.Annotations[manifestutils.CertRotationRequiredAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
 
 	if err := k.Update(ctx, ss); err != nil {
-		return kverrors.Wrap(err, fmt.Sprintf("failed to update tempo TempoStack `%s` annotation", certRotationRequiredAtKey), "key", key)
+		return kverrors.Wrap(err, fmt.Sprintf("failed to update tempo TempoStack `%s` annotation", manifestutils.CertRotationRequiredAtAnnotation), "key", key)
 	}
 
 	return nil
@@ -30,7 +30,7 @@ func CheckCertExpiry(ctx context.Context, log logr.Logger, req ctrl.Request, k c
 		return kverrors.Wrap(err, "failed to lookup tempostacks", "name", req.String())
 	}
 
-	opts, err := GetOptions(ctx, k, req)
+	opts, err := GetOptions(ctx, k, req, fg.BuiltInCertManagement)
 	if err != nil {
 		return kverrors.Wrap(err, "failed to lookup certificates secrets", "name", req.String())
 	}
@@ -40,6 +40,8 @@ func CheckCertExpiry(ctx context.Context, log logr.Logger, req ctrl.Request, k c
 		return optErr
 	}
 
+	certrotation.RecordExpiration(opts)
+
 	if err := certrotation.SigningCAExpired(opts); err != nil {
 		return err
 	}
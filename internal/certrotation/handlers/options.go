@@ -9,12 +9,13 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/certrotation"
 )
 
 // GetOptions return a certrotation options struct filled with all found client and serving certificate secrets if any found.
 // Return an error only if either the k8s client returns any other error except IsNotFound or if merging options fails.
-func GetOptions(ctx context.Context, k client.Client, req ctrl.Request) (certrotation.Options, error) {
+func GetOptions(ctx context.Context, k client.Client, req ctrl.Request, cfg configv1alpha1.BuiltInCertManagement) (certrotation.Options, error) {
 	name := certrotation.SigningCASecretName(req.Name)
 	ca, err := getSecret(ctx, k, name, req.Namespace)
 	if err != nil {
@@ -23,6 +24,14 @@ func GetOptions(ctx context.Context, k client.Client, req ctrl.Request) (certrot
 		}
 	}
 
+	var externalCA *corev1.Secret
+	if cfg.CASecret != nil {
+		externalCA, err = getSecret(ctx, k, cfg.CASecret.Name, cfg.CASecret.Namespace)
+		if err != nil {
+			return certrotation.Options{}, kverrors.Wrap(err, "failed to get external ca secret", "name", cfg.CASecret.Name, "namespace", cfg.CASecret.Namespace)
+		}
+	}
+
 	name = certrotation.CABundleName(req.Name)
 	bundle, err := getConfigMap(ctx, k, name, req.Namespace)
 	if err != nil {
@@ -40,7 +49,8 @@ func GetOptions(ctx context.Context, k client.Client, req ctrl.Request) (certrot
 		StackName:      req.Name,
 		StackNamespace: req.Namespace,
 		Signer: certrotation.SigningCA{
-			Secret: ca,
+			Secret:   ca,
+			External: externalCA,
 		},
 		CABundle:     bundle,
 		Certificates: certs,
@@ -35,7 +35,7 @@ func CreateOrRotateCertificates(ctx context.Context, log logr.Logger,
 		return kverrors.Wrap(err, "failed to lookup tempostacks", "name", req.String())
 	}
 
-	opts, err := GetOptions(ctx, k, req)
+	opts, err := GetOptions(ctx, k, req, fg.BuiltInCertManagement)
 	if err != nil {
 		return kverrors.Wrap(err, "failed to lookup certificates secrets", "name", req.String())
 	}
@@ -49,6 +49,7 @@ func CreateOrRotateCertificates(ctx context.Context, log logr.Logger,
 
 	if err != nil {
 		ll.Error(err, "failed to build certificate manifests")
+		certrotation.RecordRotationFailure(req.Namespace, req.Name)
 		return kverrors.Wrap(err, "failed to build certificate manifests", "name", req.String())
 	}
 
@@ -93,6 +94,7 @@ func CreateOrRotateCertificates(ctx context.Context, log logr.Logger,
 	}
 
 	if errCount > 0 {
+		certrotation.RecordRotationFailure(req.Namespace, req.Name)
 		return kverrors.New("failed to create or rotate TempoStack certificates", "name", req.String())
 	}
 
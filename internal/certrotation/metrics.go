@@ -0,0 +1,53 @@
+package certrotation
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const signingCACertificateName = "signing-ca"
+
+var (
+	metricCertificateExpiration = promauto.With(metrics.Registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "tempooperator",
+		Name:      "certificate_expiration_timestamp_seconds",
+		Help:      "The expiration timestamp of a certificate managed by the operator.",
+	}, []string{"stack_namespace", "stack_name", "certificate"})
+
+	metricCertificateRotationFailures = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: "tempooperator",
+		Name:      "certificate_rotation_failures_total",
+		Help:      "The number of failed certificate rotations of a TempoStack instance.",
+	}, []string{"stack_namespace", "stack_name"})
+)
+
+// RecordExpiration updates the tempooperator_certificate_expiration_timestamp_seconds metric
+// of the signing CA and all client and serving certificates of a TempoStack.
+func RecordExpiration(opts Options) {
+	recordSecretExpiration(opts.StackNamespace, opts.StackName, signingCACertificateName, opts.Signer.Secret)
+	for name, cert := range opts.Certificates {
+		recordSecretExpiration(opts.StackNamespace, opts.StackName, name, cert.Secret)
+	}
+}
+
+// RecordRotationFailure increments the tempooperator_certificate_rotation_failures_total metric of a TempoStack.
+func RecordRotationFailure(stackNamespace, stackName string) {
+	metricCertificateRotationFailures.WithLabelValues(stackNamespace, stackName).Inc()
+}
+
+func recordSecretExpiration(stackNamespace, stackName, certificate string, secret *corev1.Secret) {
+	if secret == nil {
+		return
+	}
+
+	notAfter, err := time.Parse(time.RFC3339, secret.Annotations[CertificateNotAfterAnnotation])
+	if err != nil {
+		return
+	}
+
+	metricCertificateExpiration.WithLabelValues(stackNamespace, stackName, certificate).Set(float64(notAfter.Unix()))
+}
@@ -0,0 +1,50 @@
+package certrotation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRecordExpiration(t *testing.T) {
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	secret := func(notAfter string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					CertificateNotAfterAnnotation: notAfter,
+				},
+			},
+		}
+	}
+
+	RecordExpiration(Options{
+		StackName:      "metrics",
+		StackNamespace: "ns",
+		Signer: SigningCA{
+			Secret: secret(notAfter.Format(time.RFC3339)),
+		},
+		Certificates: ComponentCertificates{
+			"tempo-metrics-distributor": SelfSignedCertKey{
+				Secret: secret(notAfter.Add(-time.Hour).Format(time.RFC3339)),
+			},
+			"tempo-metrics-ingester": SelfSignedCertKey{
+				Secret: secret("invalid"),
+			},
+		},
+	})
+
+	assert.Equal(t, float64(notAfter.Unix()), testutil.ToFloat64(metricCertificateExpiration.WithLabelValues("ns", "metrics", "signing-ca")))
+	assert.Equal(t, float64(notAfter.Add(-time.Hour).Unix()), testutil.ToFloat64(metricCertificateExpiration.WithLabelValues("ns", "metrics", "tempo-metrics-distributor")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metricCertificateExpiration.WithLabelValues("ns", "metrics", "tempo-metrics-ingester")))
+}
+
+func TestRecordRotationFailure(t *testing.T) {
+	RecordRotationFailure("ns", "failure")
+	RecordRotationFailure("ns", "failure")
+	assert.Equal(t, 2.0, testutil.ToFloat64(metricCertificateRotationFailures.WithLabelValues("ns", "failure")))
+}
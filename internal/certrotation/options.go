@@ -29,9 +29,12 @@ type Options struct {
 // - refresh duration is over
 // - or 80% of validity is over
 // - or the CA is expired.
+//
+// If an external CA is provided, the signing CA is copied from the external CA secret instead.
 type SigningCA struct {
 	RawCA    *crypto.CA
 	Secret   *corev1.Secret
+	External *corev1.Secret
 	Rotation signerRotation
 }
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ViaQ/logerr/v2/kverrors"
 	"github.com/openshift/library-go/pkg/crypto"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,6 +21,11 @@ func SigningCAExpired(opts Options) error {
 		return nil
 	}
 
+	// Skip as the external CA is not rotated by the operator
+	if opts.Signer.External != nil {
+		return nil
+	}
+
 	reason := opts.Signer.Rotation.NeedNewCertificate(opts.Signer.Secret.Annotations, opts.Rotation.CACertRefresh)
 	if reason != "" {
 		return &CertExpiredError{Message: "signing CA certificate expired", Reasons: []string{reason}}
@@ -33,7 +39,11 @@ func buildSigningCASecret(opts *Options) (client.Object, error) {
 	signingCertKeyPairSecret := newSigningCASecret(*opts)
 	opts.Signer.Rotation.Issuer = fmt.Sprintf("%s_%s", signingCertKeyPairSecret.Namespace, signingCertKeyPairSecret.Name)
 
-	if reason := opts.Signer.Rotation.NeedNewCertificate(signingCertKeyPairSecret.Annotations, opts.Rotation.CACertRefresh); reason != "" {
+	if opts.Signer.External != nil {
+		if err := setExternalCertKeyPairSecret(signingCertKeyPairSecret, opts.Signer.External, opts.Signer.Rotation); err != nil {
+			return nil, err
+		}
+	} else if reason := opts.Signer.Rotation.NeedNewCertificate(signingCertKeyPairSecret.Annotations, opts.Rotation.CACertRefresh); reason != "" {
 		if err := setSigningCertKeyPairSecret(signingCertKeyPairSecret, opts.Rotation.CACertValidity, opts.Signer.Rotation); err != nil {
 			return nil, err
 		}
@@ -100,3 +110,29 @@ func setSigningCertKeyPairSecret(s *corev1.Secret, validity time.Duration, caCre
 
 	return nil
 }
+
+// setExternalCertKeyPairSecret copies the signing cert/key pair of an external CA secret into the secret.
+func setExternalCertKeyPairSecret(s *corev1.Secret, external *corev1.Secret, caCreator signerRotation) error {
+	if s.Annotations == nil {
+		s.Annotations = map[string]string{}
+	}
+	if s.Data == nil {
+		s.Data = map[string][]byte{}
+	}
+
+	var (
+		cert = external.Data[corev1.TLSCertKey]
+		key  = external.Data[corev1.TLSPrivateKeyKey]
+	)
+
+	ca, err := crypto.GetCAFromBytes(cert, key)
+	if err != nil {
+		return kverrors.Wrap(err, "invalid external CA secret", "name", external.Name, "namespace", external.Namespace)
+	}
+
+	s.Data[corev1.TLSCertKey] = cert
+	s.Data[corev1.TLSPrivateKeyKey] = key
+	caCreator.SetAnnotations(ca.Config, s.Annotations)
+
+	return nil
+}
@@ -1,9 +1,11 @@
 package certrotation
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
+	"github.com/openshift/library-go/pkg/crypto"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -129,3 +131,47 @@ func TestBuildSigningCASecret_Rotate(t *testing.T) {
 	require.NotEqual(t, string(s.Data[corev1.TLSCertKey]), string(opts.Signer.Secret.Data[corev1.TLSCertKey]))
 	require.NotEqual(t, string(s.Data[corev1.TLSPrivateKeyKey]), string(opts.Signer.Secret.Data[corev1.TLSPrivateKeyKey]))
 }
+
+func TestBuildSigningCASecret_External(t *testing.T) {
+	ca, err := crypto.MakeSelfSignedCAConfigForDuration("external-ca", time.Hour)
+	require.NoError(t, err)
+
+	certBytes := &bytes.Buffer{}
+	keyBytes := &bytes.Buffer{}
+	require.NoError(t, ca.WriteCertConfig(certBytes, keyBytes))
+
+	opts := &Options{
+		StackName:      "dev",
+		StackNamespace: "ns",
+		Signer: SigningCA{
+			Rotation: signerRotation{
+				Clock: time.Now,
+			},
+			External: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "corporate-ca",
+					Namespace: "security",
+				},
+				Data: map[string][]byte{
+					corev1.TLSCertKey:       certBytes.Bytes(),
+					corev1.TLSPrivateKeyKey: keyBytes.Bytes(),
+				},
+			},
+		},
+	}
+
+	obj, err := buildSigningCASecret(opts)
+	require.NoError(t, err)
+	require.NotNil(t, opts.Signer.RawCA)
+
+	s := obj.(*corev1.Secret)
+	require.Equal(t, "tempo-dev-signing-ca", s.Name)
+	require.Equal(t, certBytes.Bytes(), s.Data[corev1.TLSCertKey])
+	require.Equal(t, keyBytes.Bytes(), s.Data[corev1.TLSPrivateKeyKey])
+	require.Equal(t, "external-ca", s.Annotations[CertificateIssuer])
+	require.Contains(t, s.Annotations, CertificateNotAfterAnnotation)
+
+	// The external CA is never rotated by the operator
+	opts.Signer.Secret = s
+	require.NoError(t, SigningCAExpired(*opts))
+}
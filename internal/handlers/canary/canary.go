@@ -0,0 +1,254 @@
+package canary
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/httpclient"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const (
+	// DefaultInterval is the interval between two probes if spec.canary.interval is not set.
+	DefaultInterval = time.Minute
+	requestTimeout  = 10 * time.Second
+	serviceName     = "tempo-operator-canary"
+
+	messageHealthy = "The synthetic traces are written and read back successfully"
+)
+
+var (
+	metricRequests = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: "tempostack",
+		Name:      "canary_requests_total",
+		Help:      "The number of synthetic trace writes and reads of the canary, by result.",
+	}, []string{"stack_namespace", "stack_name", "operation", "result"})
+
+	metricRequestDuration = promauto.With(metrics.Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "tempostack",
+		Name:      "canary_request_duration_seconds",
+		Help:      "The duration of the synthetic trace writes and reads of the canary.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 11),
+	}, []string{"stack_namespace", "stack_name", "operation"})
+)
+
+// written tracks the ID of the last synthetic trace of each TempoStack, which is read back by the next probe.
+var written = &traceTracker{traces: map[types.NamespacedName]string{}}
+
+type traceTracker struct {
+	mu     sync.Mutex
+	traces map[types.NamespacedName]string
+}
+
+// Interval returns the interval between two probes of the canary.
+func Interval(tempo v1alpha1.TempoStack) time.Duration {
+	if tempo.Spec.Canary != nil && tempo.Spec.Canary.Interval.Duration != 0 {
+		return tempo.Spec.Canary.Interval.Duration
+	}
+	return DefaultInterval
+}
+
+// Probe reads the synthetic trace written by the previous probe back from the query-frontend,
+// and writes a new synthetic trace to the distributor.
+// It returns the PipelineHealthy condition, or nil if there is no previous trace to read yet.
+// The HTTP clients to call the distributor and the query-frontend are created by newHTTPClient,
+// the timestamps of the synthetic spans and the request durations are taken from now.
+func Probe(ctx context.Context, k8sClient client.Client, newHTTPClient httpclient.Factory, now func() time.Time, tempo v1alpha1.TempoStack, httpEncryption bool) *metav1.Condition {
+	key := types.NamespacedName{Namespace: tempo.Namespace, Name: tempo.Name}
+	tenant := tempo.Spec.Canary.Tenant
+
+	var condition *metav1.Condition
+	if traceID, found := written.get(key); found {
+		err := observe(now, tempo, "read", func() error {
+			return readTrace(ctx, k8sClient, newHTTPClient, tempo, httpEncryption, tenant, traceID)
+		})
+		if err != nil {
+			condition = unhealthy(v1alpha1.ReasonCanaryReadFailed, fmt.Sprintf("failed to read the synthetic trace %s: %s", traceID, err))
+		} else {
+			condition = &metav1.Condition{
+				Type:    string(v1alpha1.ConditionPipelineHealthy),
+				Status:  metav1.ConditionTrue,
+				Reason:  string(v1alpha1.ReasonReady),
+				Message: messageHealthy,
+			}
+		}
+	}
+
+	traceID, spanID := randomHex(16), randomHex(8)
+	err := observe(now, tempo, "write", func() error {
+		return writeTrace(ctx, k8sClient, newHTTPClient, now, tempo, tenant, traceID, spanID)
+	})
+	if err != nil {
+		written.forget(key)
+		return unhealthy(v1alpha1.ReasonCanaryWriteFailed, fmt.Sprintf("failed to write a synthetic trace: %s", err))
+	}
+	written.set(key, traceID)
+	return condition
+}
+
+// Forget removes the last synthetic trace of a TempoStack, for example if the canary is disabled.
+func Forget(namespace string, name string) {
+	written.forget(types.NamespacedName{Namespace: namespace, Name: name})
+}
+
+// DeleteMetrics removes the canary metrics of a deleted TempoStack instance.
+func DeleteMetrics(namespace string, name string) {
+	labels := prometheus.Labels{"stack_namespace": namespace, "stack_name": name}
+	metricRequests.DeletePartialMatch(labels)
+	metricRequestDuration.DeletePartialMatch(labels)
+}
+
+func unhealthy(reason v1alpha1.ConditionReason, message string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:    string(v1alpha1.ConditionPipelineHealthy),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(reason),
+		Message: message,
+	}
+}
+
+// observe runs a request and records its result and duration in the canary metrics.
+func observe(now func() time.Time, tempo v1alpha1.TempoStack, operation string, request func() error) error {
+	start := now()
+	err := request()
+	metricRequestDuration.WithLabelValues(tempo.Namespace, tempo.Name, operation).Observe(now().Sub(start).Seconds())
+
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	metricRequests.WithLabelValues(tempo.Namespace, tempo.Name, operation, result).Inc()
+	return err
+}
+
+// writeTrace sends a trace with a single span to the OTLP HTTP receiver of the distributor, encoded as OTLP/JSON.
+func writeTrace(ctx context.Context, k8sClient client.Client, newHTTPClient httpclient.Factory, now func() time.Time, tempo v1alpha1.TempoStack, tenant string, traceID string, spanID string) error {
+	tlsEnabled := tempo.Spec.Template.Distributor.TLS.Enabled
+	httpClient, err := newHTTPClient(ctx, k8sClient, tempo, tlsEnabled, manifestutils.DistributorComponentName)
+	if err != nil {
+		return err
+	}
+
+	timestamp := now().UnixNano()
+	body, err := json.Marshal(map[string]any{
+		"resourceSpans": []any{map[string]any{
+			"resource": map[string]any{
+				"attributes": []any{map[string]any{
+					"key":   "service.name",
+					"value": map[string]any{"stringValue": serviceName},
+				}},
+			},
+			"scopeSpans": []any{map[string]any{
+				"spans": []any{map[string]any{
+					"traceId":           traceID,
+					"spanId":            spanID,
+					"name":              "canary",
+					"kind":              1,
+					"startTimeUnixNano": fmt.Sprint(timestamp),
+					"endTimeUnixNano":   fmt.Sprint(timestamp + int64(time.Millisecond)),
+				}},
+			}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, otlpHTTPPort := manifestutils.OTLPReceiverPorts(tempo)
+	url := fmt.Sprintf("%s://%s:%d/v1/traces", scheme(tlsEnabled),
+		naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.DistributorComponentName), otlpHTTPPort)
+	return do(ctx, httpClient, http.MethodPost, url, tenant, body)
+}
+
+// readTrace queries a trace by its ID from the query-frontend.
+func readTrace(ctx context.Context, k8sClient client.Client, newHTTPClient httpclient.Factory, tempo v1alpha1.TempoStack, tlsEnabled bool, tenant string, traceID string) error {
+	httpClient, err := newHTTPClient(ctx, k8sClient, tempo, tlsEnabled, manifestutils.QueryFrontendComponentName)
+	if err != nil {
+		return err
+	}
+
+	httpPort, _ := manifestutils.ServerPorts(tempo)
+	url := fmt.Sprintf("%s://%s:%d/api/traces/%s", scheme(tlsEnabled),
+		naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.QueryFrontendComponentName), httpPort, traceID)
+	return do(ctx, httpClient, http.MethodGet, url, tenant, nil)
+}
+
+func do(ctx context.Context, httpClient *http.Client, method string, url string, tenant string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if tenant != "" {
+		req.Header.Set(manifestutils.TenantHeader, tenant)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("not found")
+	default:
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+}
+
+func scheme(tlsEnabled bool) string {
+	if tlsEnabled {
+		return "https"
+	}
+	return "http"
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	// crypto/rand.Read never returns an error on the supported platforms.
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func (t *traceTracker) get(key types.NamespacedName) (string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	traceID, found := t.traces[key]
+	return traceID, found
+}
+
+func (t *traceTracker) set(key types.NamespacedName, traceID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.traces[key] = traceID
+}
+
+func (t *traceTracker) forget(key types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.traces, key)
+}
@@ -0,0 +1,107 @@
+package canary
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/httpclient/httpclienttest"
+)
+
+func TestProbe(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "project1"},
+		Spec: v1alpha1.TempoStackSpec{
+			Canary: &v1alpha1.CanarySpec{Tenant: "dev"},
+		},
+	}
+	t.Cleanup(func() { Forget("project1", "test") })
+
+	var traceIDs []string
+	readStatus := http.StatusOK
+	newHTTPClient := httpclienttest.NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "dev", r.Header.Get("X-Scope-OrgID"))
+
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "tempo-test-distributor.project1.svc.cluster.local:4318", r.Host)
+			assert.Equal(t, "/v1/traces", r.URL.Path)
+
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			traces := struct {
+				ResourceSpans []struct {
+					ScopeSpans []struct {
+						Spans []struct {
+							TraceID string `json:"traceId"`
+						} `json:"spans"`
+					} `json:"scopeSpans"`
+				} `json:"resourceSpans"`
+			}{}
+			require.NoError(t, json.Unmarshal(body, &traces))
+			traceIDs = append(traceIDs, traces.ResourceSpans[0].ScopeSpans[0].Spans[0].TraceID)
+		case http.MethodGet:
+			assert.Equal(t, "tempo-test-query-frontend.project1.svc.cluster.local:3200", r.Host)
+			assert.Equal(t, "/api/traces/"+traceIDs[len(traceIDs)-1], r.URL.Path)
+			w.WriteHeader(readStatus)
+		}
+	})
+
+	// The first probe only writes a trace.
+	assert.Nil(t, Probe(context.Background(), nil, newHTTPClient, time.Now, tempo, false))
+	require.Len(t, traceIDs, 1)
+	assert.Len(t, traceIDs[0], 32)
+
+	condition := Probe(context.Background(), nil, newHTTPClient, time.Now, tempo, false)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.Condition{
+		Type:    "PipelineHealthy",
+		Status:  metav1.ConditionTrue,
+		Reason:  "Ready",
+		Message: "The synthetic traces are written and read back successfully",
+	}, *condition)
+	require.Len(t, traceIDs, 2)
+	assert.NotEqual(t, traceIDs[0], traceIDs[1])
+
+	readStatus = http.StatusNotFound
+	lastTraceID := traceIDs[1]
+	condition = Probe(context.Background(), nil, newHTTPClient, time.Now, tempo, false)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "CanaryReadFailed", condition.Reason)
+	assert.Equal(t, "failed to read the synthetic trace "+lastTraceID+": not found", condition.Message)
+}
+
+func TestProbe_WriteFailed(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "project1"},
+		Spec: v1alpha1.TempoStackSpec{
+			Canary: &v1alpha1.CanarySpec{},
+		},
+	}
+	t.Cleanup(func() { Forget("project1", "test") })
+
+	newHTTPClient := httpclienttest.NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("X-Scope-OrgID"))
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	condition := Probe(context.Background(), nil, newHTTPClient, time.Now, tempo, false)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "CanaryWriteFailed", condition.Reason)
+	assert.Equal(t, "failed to write a synthetic trace: unexpected status code 503", condition.Message)
+
+	// The next probe does not read the trace which failed to be written.
+	_, found := written.get(types.NamespacedName{Namespace: "project1", Name: "test"})
+	assert.False(t, found)
+}
@@ -0,0 +1,62 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/certrotation"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// Factory creates an HTTP client to call the HTTP API of a Tempo component, e.g. ForComponent.
+type Factory func(ctx context.Context, k8sClient client.Client, tempo v1alpha1.TempoStack, tlsEnabled bool, component string) (*http.Client, error)
+
+// ForComponent returns an HTTP client to call the HTTP API of a Tempo component.
+// If HTTP encryption is enabled, the client authenticates with the certificate of the component
+// and verifies the server certificate with the CA bundle of the TempoStack.
+func ForComponent(ctx context.Context, k8sClient client.Client, tempo v1alpha1.TempoStack, tlsEnabled bool, component string) (*http.Client, error) {
+	if !tlsEnabled {
+		return &http.Client{}, nil
+	}
+
+	secret := &corev1.Secret{}
+	secretName := naming.TLSSecretName(component, tempo.Name)
+	err := k8sClient.Get(ctx, client.ObjectKey{Namespace: tempo.Namespace, Name: secretName}, secret)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch %s certificate secret: %w", component, err)
+	}
+	cert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s certificate: %w", component, err)
+	}
+
+	caBundle := &corev1.ConfigMap{}
+	caBundleName := naming.SigningCABundleName(tempo.Name)
+	err = k8sClient.Get(ctx, client.ObjectKey{Namespace: tempo.Namespace, Name: caBundleName}, caBundle)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch CA bundle: %w", err)
+	}
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM([]byte(caBundle.Data[certrotation.CAFile])) {
+		return nil, fmt.Errorf("CA bundle %s does not contain a valid certificate", caBundleName)
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				Certificates: []tls.Certificate{cert},
+				RootCAs:      rootCAs,
+				// The certificate is issued for the service of the component, pods may be called by their IP address.
+				ServerName: naming.ServiceFqdn(tempo.Namespace, tempo.Name, component),
+				MinVersion: tls.VersionTLS12,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,41 @@
+// Package httpclienttest provides HTTP clients for the tests of the handlers calling the HTTP API of Tempo.
+package httpclienttest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/httpclient"
+)
+
+// redirectTransport sends all requests to the test server.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// NewServer starts a test server with the handler, which is closed at the end of the test.
+// The returned factory creates HTTP clients which send all requests to the test server,
+// while the Host header of the requests keeps the host of the requested URL.
+func NewServer(t *testing.T, handler http.HandlerFunc) httpclient.Factory {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	return func(ctx context.Context, k8sClient client.Client, tempo v1alpha1.TempoStack, tlsEnabled bool, component string) (*http.Client, error) {
+		return &http.Client{Transport: redirectTransport{target: target}}, nil
+	}
+}
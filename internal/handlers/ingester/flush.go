@@ -0,0 +1,112 @@
+package ingester
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/httpclient"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+// ErrFlushInProgress is returned while an ingester is flushing its traces to the object storage.
+// The reconciliation should be requeued to check the result of the flush later.
+var ErrFlushInProgress = errors.New("waiting for the ingesters to flush their traces")
+
+// flushes tracks the shutdown requests of the ingester pods, by pod UID.
+// A flush can take several minutes, therefore it runs in the background instead of blocking the reconciliation.
+var flushes = &flushTracker{requests: map[types.UID]*flushRequest{}}
+
+type flushTracker struct {
+	mu       sync.Mutex
+	requests map[types.UID]*flushRequest
+}
+
+type flushRequest struct {
+	done bool
+	err  error
+}
+
+// flush triggers the flush of an ingester in the background, and annotates the pod once the flush finished successfully.
+// It returns ErrFlushInProgress while the flush is running, and nil if the pod is already flushed.
+func flush(ctx context.Context, k8sClient client.Client, newHTTPClient httpclient.Factory, tempo v1alpha1.TempoStack, tlsEnabled bool, pod *corev1.Pod) error {
+	if pod.Annotations[FlushedAnnotation] == "true" {
+		return nil
+	}
+
+	req, found := flushes.result(pod.UID)
+	if !found {
+		httpClient, err := newHTTPClient(ctx, k8sClient, tempo, tlsEnabled, manifestutils.IngesterComponentName)
+		if err != nil {
+			return err
+		}
+		url, err := shutdownURL(tempo, tlsEnabled, pod)
+		if err != nil {
+			return fmt.Errorf("failed to flush ingester %s: %w", pod.Name, err)
+		}
+
+		flushes.start(pod.UID, func() error {
+			// The request must outlive the reconciliation which triggered it.
+			return shutdown(context.Background(), httpClient, url)
+		})
+		return ErrFlushInProgress
+	}
+	if !req.done {
+		return ErrFlushInProgress
+	}
+
+	// Trigger a new flush in the next reconciliation if the flush failed or the pod cannot be annotated.
+	flushes.forget(pod.UID)
+	if req.err != nil {
+		return fmt.Errorf("failed to flush ingester %s: %w", pod.Name, req.err)
+	}
+
+	patch := client.MergeFrom(pod.DeepCopy())
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[FlushedAnnotation] = "true"
+	if err := k8sClient.Patch(ctx, pod, patch); err != nil {
+		return fmt.Errorf("failed to annotate ingester pod %s: %w", pod.Name, err)
+	}
+	return nil
+}
+
+func (t *flushTracker) start(uid types.UID, fn func() error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	req := &flushRequest{}
+	t.requests[uid] = req
+	go func() {
+		err := fn()
+
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		req.done, req.err = true, err
+	}()
+}
+
+func (t *flushTracker) result(uid types.UID) (flushRequest, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	req, found := t.requests[uid]
+	if !found {
+		return flushRequest{}, false
+	}
+	return *req, true
+}
+
+func (t *flushTracker) forget(uid types.UID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.requests, uid)
+}
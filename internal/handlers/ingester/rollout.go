@@ -0,0 +1,115 @@
+package ingester
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/httpclient"
+)
+
+const tempoContainerName = "tempo"
+
+// RollOutVersion rolls out a new Tempo version to the ingesters of the desired StatefulSet one pod at a time,
+// starting with the highest ordinal. Each ingester flushes its traces to the object storage and leaves the ring
+// before it is restarted with the new version, therefore the new version never replays a WAL of the previous version.
+//
+// The rollout is controlled by the partition of the rolling update of the desired StatefulSet,
+// only the pods with an ordinal greater or equal than the partition are updated.
+// RollOutVersion returns true once all ingesters run the new version and are ready.
+// The HTTP client to call the shutdown endpoint is created by newHTTPClient.
+func RollOutVersion(ctx context.Context, k8sClient client.Client, newHTTPClient httpclient.Factory, tempo v1alpha1.TempoStack, tlsEnabled bool, desired *appsv1.StatefulSet) (bool, error) {
+	existing := &appsv1.StatefulSet{}
+	err := k8sClient.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get ingester statefulset %s: %w", desired.Name, err)
+	}
+
+	image := TempoImage(desired.Spec.Template)
+	if TempoImage(existing.Spec.Template) == image && partition(existing) == 0 {
+		return true, nil
+	}
+
+	for ordinal := replicas(desired) - 1; ordinal >= 0; ordinal-- {
+		pod := &corev1.Pod{}
+		key := client.ObjectKey{Namespace: desired.Namespace, Name: fmt.Sprintf("%s-%d", desired.Name, ordinal)}
+		err := k8sClient.Get(ctx, key, pod)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// The pod is being re-created by the StatefulSet controller.
+				setPartition(desired, ordinal)
+				return false, nil
+			}
+			setPartition(desired, ordinal+1)
+			return false, fmt.Errorf("failed to get ingester pod %s: %w", key.Name, err)
+		}
+
+		if TempoImage(corev1.PodTemplateSpec{Spec: pod.Spec}) == image {
+			if !isPodReady(pod) {
+				// Wait until the updated ingester joined the ring again.
+				setPartition(desired, ordinal)
+				return false, nil
+			}
+			continue
+		}
+
+		// An ingester which is not ready does not receive traces, it can be restarted without a flush.
+		// The flush runs in the background, ErrFlushInProgress is returned until the ingester is flushed.
+		if isPodReady(pod) {
+			if err := flush(ctx, k8sClient, newHTTPClient, tempo, tlsEnabled, pod); err != nil {
+				setPartition(desired, ordinal+1)
+				return false, err
+			}
+		}
+
+		setPartition(desired, ordinal)
+		return false, nil
+	}
+
+	setPartition(desired, 0)
+	return true, nil
+}
+
+// TempoImage returns the image of the Tempo container of a pod template,
+// or an empty string if the pod template has no Tempo container.
+func TempoImage(template corev1.PodTemplateSpec) string {
+	for _, container := range template.Spec.Containers {
+		if container.Name == tempoContainerName {
+			return container.Image
+		}
+	}
+	return ""
+}
+
+func partition(ss *appsv1.StatefulSet) int32 {
+	if ss.Spec.UpdateStrategy.RollingUpdate == nil || ss.Spec.UpdateStrategy.RollingUpdate.Partition == nil {
+		return 0
+	}
+	return *ss.Spec.UpdateStrategy.RollingUpdate.Partition
+}
+
+func setPartition(ss *appsv1.StatefulSet, partition int32) {
+	ss.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition: &partition,
+		},
+	}
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
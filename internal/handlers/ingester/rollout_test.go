@@ -0,0 +1,171 @@
+package ingester
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func statefulSetWithImage(replicas int32, image string) *appsv1.StatefulSet {
+	ss := statefulSet(replicas)
+	ss.Spec.Template.Spec.Containers = []corev1.Container{{Name: "tempo", Image: image}}
+	return ss
+}
+
+func podWithImage(name string, image string, ready bool) *corev1.Pod {
+	p := pod(name)
+	p.Spec.Containers = []corev1.Container{{Name: "tempo", Image: image}}
+	if ready {
+		p.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	}
+	return p
+}
+
+func TestRollOutVersion(t *testing.T) {
+	tests := []struct {
+		name              string
+		existing          *appsv1.StatefulSet
+		existingPartition int32
+		pods              []client.Object
+		expectedDone      bool
+		expectedPartition *int32
+		expectedFlushed   int
+	}{
+		{
+			name:     "same version",
+			existing: statefulSetWithImage(2, "tempo:new"),
+			pods: []client.Object{
+				podWithImage("tempo-test-ingester-0", "tempo:new", true),
+				podWithImage("tempo-test-ingester-1", "tempo:new", true),
+			},
+			expectedDone: true,
+		},
+		{
+			name:     "flush the ingester with the highest ordinal",
+			existing: statefulSetWithImage(3, "tempo:old"),
+			pods: []client.Object{
+				podWithImage("tempo-test-ingester-0", "tempo:old", true),
+				podWithImage("tempo-test-ingester-1", "tempo:old", true),
+				podWithImage("tempo-test-ingester-2", "tempo:old", true),
+			},
+			expectedPartition: pointer.Int32(2),
+			expectedFlushed:   1,
+		},
+		{
+			name:              "wait for the updated ingester",
+			existing:          statefulSetWithImage(3, "tempo:new"),
+			existingPartition: 2,
+			pods: []client.Object{
+				podWithImage("tempo-test-ingester-0", "tempo:old", true),
+				podWithImage("tempo-test-ingester-1", "tempo:old", true),
+				podWithImage("tempo-test-ingester-2", "tempo:new", false),
+			},
+			expectedPartition: pointer.Int32(2),
+		},
+		{
+			name:              "update the next ingester",
+			existing:          statefulSetWithImage(3, "tempo:new"),
+			existingPartition: 2,
+			pods: []client.Object{
+				podWithImage("tempo-test-ingester-0", "tempo:old", true),
+				podWithImage("tempo-test-ingester-1", "tempo:old", true),
+				podWithImage("tempo-test-ingester-2", "tempo:new", true),
+			},
+			expectedPartition: pointer.Int32(1),
+			expectedFlushed:   1,
+		},
+		{
+			name:              "restart an ingester which is not ready without flush",
+			existing:          statefulSetWithImage(2, "tempo:new"),
+			existingPartition: 1,
+			pods: []client.Object{
+				podWithImage("tempo-test-ingester-0", "tempo:old", false),
+				podWithImage("tempo-test-ingester-1", "tempo:new", true),
+			},
+			expectedPartition: pointer.Int32(0),
+		},
+		{
+			name:              "all ingesters updated",
+			existing:          statefulSetWithImage(2, "tempo:new"),
+			existingPartition: 1,
+			pods: []client.Object{
+				podWithImage("tempo-test-ingester-0", "tempo:new", true),
+				podWithImage("tempo-test-ingester-1", "tempo:new", true),
+			},
+			expectedDone:      true,
+			expectedPartition: pointer.Int32(0),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var flushed atomic.Int32
+			newHTTPClient := useServer(t, func(w http.ResponseWriter, r *http.Request) {
+				flushed.Add(1)
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			if test.existingPartition > 0 {
+				setPartition(test.existing, test.existingPartition)
+			}
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(test.existing).WithObjects(test.pods...).Build()
+
+			desired := statefulSetWithImage(replicas(test.existing), "tempo:new")
+			done, err := RollOutVersion(context.Background(), k8sClient, newHTTPClient, v1alpha1.TempoStack{}, false, desired)
+			if test.expectedFlushed > 0 {
+				// The ingester is not restarted until its flush in the background finished.
+				require.ErrorIs(t, err, ErrFlushInProgress)
+				assert.False(t, done)
+				assert.Equal(t, *test.expectedPartition+1, *desired.Spec.UpdateStrategy.RollingUpdate.Partition)
+				waitForFlushes(t)
+
+				desired = statefulSetWithImage(replicas(test.existing), "tempo:new")
+				done, err = RollOutVersion(context.Background(), k8sClient, newHTTPClient, v1alpha1.TempoStack{}, false, desired)
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedDone, done)
+			assert.Equal(t, int32(test.expectedFlushed), flushed.Load())
+			if test.expectedPartition == nil {
+				assert.Nil(t, desired.Spec.UpdateStrategy.RollingUpdate)
+			} else {
+				require.NotNil(t, desired.Spec.UpdateStrategy.RollingUpdate)
+				assert.Equal(t, test.expectedPartition, desired.Spec.UpdateStrategy.RollingUpdate.Partition)
+			}
+		})
+	}
+}
+
+func TestRollOutVersion_FlushFailure(t *testing.T) {
+	newHTTPClient := useServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		statefulSetWithImage(2, "tempo:old"),
+		podWithImage("tempo-test-ingester-0", "tempo:old", true),
+		podWithImage("tempo-test-ingester-1", "tempo:old", true),
+	).Build()
+
+	desired := statefulSetWithImage(2, "tempo:new")
+	_, err := RollOutVersion(context.Background(), k8sClient, newHTTPClient, v1alpha1.TempoStack{}, false, desired)
+	require.ErrorIs(t, err, ErrFlushInProgress)
+	waitForFlushes(t)
+
+	done, err := RollOutVersion(context.Background(), k8sClient, newHTTPClient, v1alpha1.TempoStack{}, false, desired)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrFlushInProgress)
+	assert.False(t, done)
+	assert.Equal(t, pointer.Int32(2), desired.Spec.UpdateStrategy.RollingUpdate.Partition)
+}
@@ -0,0 +1,176 @@
+package ingester
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/httpclient"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+const (
+	// FlushedAnnotation is set on the ingester pods which flushed their traces and left the ring.
+	FlushedAnnotation = "tempo.grafana.com/flushed"
+
+	dataVolumeName = "data"
+	shutdownPath   = "/shutdown"
+	// shutdownTimeout is the maximum time to flush the traces of an ingester.
+	shutdownTimeout = 5 * time.Minute
+)
+
+// IsIngester returns true if the StatefulSet belongs to the ingester component.
+func IsIngester(ss *appsv1.StatefulSet) bool {
+	return ss.Labels["app.kubernetes.io/component"] == manifestutils.IngesterComponentName
+}
+
+// FlushRemovedIngesters flushes the traces of the ingesters which are removed by a scale-down of the desired StatefulSet,
+// by calling the shutdown endpoint of each ingester. The ingester flushes all in-memory traces to the object storage
+// and leaves the ring, before the StatefulSet is scaled down.
+// The flushes run in the background, ErrFlushInProgress is returned until all removed ingesters are flushed.
+// Until then, or if an ingester cannot be flushed, the replicas of the desired StatefulSet are reset to the current replicas,
+// so that no traces are lost, and the scale-down is retried in the next reconciliation.
+// The HTTP client to call the shutdown endpoint is created by newHTTPClient.
+func FlushRemovedIngesters(ctx context.Context, k8sClient client.Client, newHTTPClient httpclient.Factory, tempo v1alpha1.TempoStack, tlsEnabled bool, desired *appsv1.StatefulSet) error {
+	existing := &appsv1.StatefulSet{}
+	err := k8sClient.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get ingester statefulset %s: %w", desired.Name, err)
+	}
+
+	current, target := replicas(existing), replicas(desired)
+	if target >= current {
+		return nil
+	}
+
+	var flushErrs []error
+	for ordinal := target; ordinal < current; ordinal++ {
+		pod := &corev1.Pod{}
+		key := client.ObjectKey{Namespace: desired.Namespace, Name: fmt.Sprintf("%s-%d", desired.Name, ordinal)}
+		err := k8sClient.Get(ctx, key, pod)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			desired.Spec.Replicas = existing.Spec.Replicas
+			return fmt.Errorf("failed to get ingester pod %s: %w", key.Name, err)
+		}
+
+		// Flush all removed ingesters in parallel.
+		if err := flush(ctx, k8sClient, newHTTPClient, tempo, tlsEnabled, pod); err != nil {
+			flushErrs = append(flushErrs, err)
+		}
+	}
+
+	if len(flushErrs) > 0 {
+		desired.Spec.Replicas = existing.Spec.Replicas
+		return joinFlushErrors(flushErrs)
+	}
+	return nil
+}
+
+// joinFlushErrors returns ErrFlushInProgress if all errors are ErrFlushInProgress,
+// otherwise it returns the other errors, which take precedence.
+func joinFlushErrors(errs []error) error {
+	failures := []error{}
+	for _, err := range errs {
+		if !errors.Is(err, ErrFlushInProgress) {
+			failures = append(failures, err)
+		}
+	}
+	if len(failures) == 0 {
+		return ErrFlushInProgress
+	}
+	return errors.Join(failures...)
+}
+
+// DeleteRemovedVolumes deletes the PersistentVolumeClaims of the ingesters which got removed by a scale-down.
+// The traces of these ingesters were flushed to the object storage before the scale-down.
+func DeleteRemovedVolumes(ctx context.Context, k8sClient client.Client, ss *appsv1.StatefulSet) error {
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	err := k8sClient.List(ctx, pvcs, client.InNamespace(ss.Namespace), client.MatchingLabels(ss.Spec.Selector.MatchLabels))
+	if err != nil {
+		return fmt.Errorf("failed to list ingester volumes: %w", err)
+	}
+
+	prefix := fmt.Sprintf("%s-%s-", dataVolumeName, ss.Name)
+	for i := range pvcs.Items {
+		ordinal, ok := volumeOrdinal(pvcs.Items[i].Name, prefix)
+		if !ok || ordinal < replicas(ss) {
+			continue
+		}
+
+		err := k8sClient.Delete(ctx, &pvcs.Items[i])
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete ingester volume %s: %w", pvcs.Items[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// volumeOrdinal returns the ordinal of the pod of a PersistentVolumeClaim created by a StatefulSet.
+func volumeOrdinal(name string, prefix string) (int32, bool) {
+	if !strings.HasPrefix(name, prefix) {
+		return 0, false
+	}
+	ordinal, err := strconv.ParseInt(strings.TrimPrefix(name, prefix), 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(ordinal), true
+}
+
+func replicas(ss *appsv1.StatefulSet) int32 {
+	// A StatefulSet without replicas defaults to one replica.
+	if ss.Spec.Replicas == nil {
+		return 1
+	}
+	return *ss.Spec.Replicas
+}
+
+// shutdownURL returns the URL of the shutdown endpoint of an ingester pod, on the configured HTTP port of Tempo.
+func shutdownURL(tempo v1alpha1.TempoStack, tlsEnabled bool, pod *corev1.Pod) (string, error) {
+	if pod.Status.PodIP == "" {
+		return "", fmt.Errorf("the pod has no IP address")
+	}
+
+	scheme := "http"
+	if tlsEnabled {
+		scheme = "https"
+	}
+	httpPort, _ := manifestutils.ServerPorts(tempo)
+	return fmt.Sprintf("%s://%s:%d%s", scheme, pod.Status.PodIP, httpPort, shutdownPath), nil
+}
+
+func shutdown(ctx context.Context, httpClient *http.Client, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, shutdownTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
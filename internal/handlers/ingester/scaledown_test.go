@@ -0,0 +1,192 @@
+package ingester
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/httpclient"
+	"github.com/grafana/tempo-operator/internal/handlers/httpclient/httpclienttest"
+)
+
+func statefulSet(replicas int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-ingester",
+			Namespace: "project1",
+			Labels:    map[string]string{"app.kubernetes.io/component": "ingester"},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: pointer.Int32(replicas),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app.kubernetes.io/component": "ingester"},
+			},
+		},
+	}
+}
+
+func pod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "project1", UID: types.UID(name)},
+		Status:     corev1.PodStatus{PodIP: "10.0.0.1"},
+	}
+}
+
+// waitForFlushes waits until all flushes running in the background are finished.
+func waitForFlushes(t *testing.T) {
+	require.Eventually(t, func() bool {
+		flushes.mu.Lock()
+		defer flushes.mu.Unlock()
+		for _, req := range flushes.requests {
+			if !req.done {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// useServer returns HTTP clients which send all requests to a test server with the handler,
+// and resets the flushes at the end of the test.
+func useServer(t *testing.T, handler http.HandlerFunc) httpclient.Factory {
+	t.Cleanup(func() {
+		flushes = &flushTracker{requests: map[types.UID]*flushRequest{}}
+	})
+	return httpclienttest.NewServer(t, handler)
+}
+
+func TestFlushRemovedIngesters(t *testing.T) {
+	var flushed atomic.Int32
+	newHTTPClient := useServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, shutdownPath, r.URL.Path)
+		flushed.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	alreadyFlushed := pod("tempo-test-ingester-2")
+	alreadyFlushed.Annotations = map[string]string{FlushedAnnotation: "true"}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		statefulSet(3),
+		pod("tempo-test-ingester-0"),
+		pod("tempo-test-ingester-1"),
+		alreadyFlushed,
+	).Build()
+
+	// The flush runs in the background, the scale-down is postponed until the flush finished.
+	desired := statefulSet(1)
+	err := FlushRemovedIngesters(context.Background(), k8sClient, newHTTPClient, v1alpha1.TempoStack{}, false, desired)
+	require.ErrorIs(t, err, ErrFlushInProgress)
+	assert.Equal(t, pointer.Int32(3), desired.Spec.Replicas)
+	waitForFlushes(t)
+
+	desired = statefulSet(1)
+	err = FlushRemovedIngesters(context.Background(), k8sClient, newHTTPClient, v1alpha1.TempoStack{}, false, desired)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), flushed.Load())
+	assert.Equal(t, pointer.Int32(1), desired.Spec.Replicas)
+
+	p := &corev1.Pod{}
+	err = k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "project1", Name: "tempo-test-ingester-1"}, p)
+	require.NoError(t, err)
+	assert.Equal(t, "true", p.Annotations[FlushedAnnotation])
+
+	err = k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "project1", Name: "tempo-test-ingester-0"}, p)
+	require.NoError(t, err)
+	assert.Empty(t, p.Annotations[FlushedAnnotation])
+}
+
+func TestFlushRemovedIngesters_Failure(t *testing.T) {
+	newHTTPClient := useServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		statefulSet(2),
+		pod("tempo-test-ingester-1"),
+	).Build()
+
+	desired := statefulSet(1)
+	err := FlushRemovedIngesters(context.Background(), k8sClient, newHTTPClient, v1alpha1.TempoStack{}, false, desired)
+	require.ErrorIs(t, err, ErrFlushInProgress)
+	waitForFlushes(t)
+
+	desired = statefulSet(1)
+	err = FlushRemovedIngesters(context.Background(), k8sClient, newHTTPClient, v1alpha1.TempoStack{}, false, desired)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrFlushInProgress)
+	assert.Equal(t, pointer.Int32(2), desired.Spec.Replicas)
+}
+
+func TestShutdownURL(t *testing.T) {
+	tempo := v1alpha1.TempoStack{Spec: v1alpha1.TempoStackSpec{Ports: &v1alpha1.ServerPortsSpec{HTTP: 8080}}}
+	url, err := shutdownURL(tempo, true, pod("tempo-test-ingester-0"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://10.0.0.1:8080/shutdown", url)
+
+	url, err = shutdownURL(v1alpha1.TempoStack{}, false, pod("tempo-test-ingester-0"))
+	require.NoError(t, err)
+	assert.Equal(t, "http://10.0.0.1:3200/shutdown", url)
+}
+
+func TestFlushRemovedIngesters_ScaleUp(t *testing.T) {
+	newHTTPClient := useServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no ingester must be flushed")
+	})
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(statefulSet(1)).Build()
+
+	desired := statefulSet(3)
+	err := FlushRemovedIngesters(context.Background(), k8sClient, newHTTPClient, v1alpha1.TempoStack{}, false, desired)
+	require.NoError(t, err)
+	assert.Equal(t, pointer.Int32(3), desired.Spec.Replicas)
+}
+
+func TestDeleteRemovedVolumes(t *testing.T) {
+	pvc := func(name string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "project1",
+				Labels:    map[string]string{"app.kubernetes.io/component": "ingester"},
+			},
+		}
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		pvc("data-tempo-test-ingester-0"),
+		pvc("data-tempo-test-ingester-1"),
+		pvc("data-tempo-test-ingester-2"),
+	).Build()
+
+	err := DeleteRemovedVolumes(context.Background(), k8sClient, statefulSet(1))
+	require.NoError(t, err)
+
+	tests := map[string]bool{
+		"data-tempo-test-ingester-0": true,
+		"data-tempo-test-ingester-1": false,
+		"data-tempo-test-ingester-2": false,
+	}
+	for name, exists := range tests {
+		err := k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "project1", Name: name}, &corev1.PersistentVolumeClaim{})
+		if exists {
+			assert.NoError(t, err, name)
+		} else {
+			assert.True(t, apierrors.IsNotFound(err), name)
+		}
+	}
+}
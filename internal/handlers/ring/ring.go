@@ -0,0 +1,102 @@
+package ring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/httpclient"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const (
+	// heartbeatTimeout is the duration after which a ring member without heartbeat is considered unhealthy.
+	heartbeatTimeout = time.Minute
+	probeTimeout     = 10 * time.Second
+	activeState      = "ACTIVE"
+)
+
+// ringResponse is the JSON response of the ring status page.
+type ringResponse struct {
+	Shards []struct {
+		ID        string    `json:"id"`
+		State     string    `json:"state"`
+		Timestamp time.Time `json:"timestamp"`
+	} `json:"shards"`
+}
+
+// Probe fetches the ring status pages of the distributor and returns the health of the ingester ring,
+// and of the metrics-generator ring if the metrics-generator is enabled.
+// Errors of individual rings are reported in the ring status.
+// Ring members without a heartbeat in the last minute before now are reported as unhealthy.
+func Probe(ctx context.Context, k8sClient client.Client, newHTTPClient httpclient.Factory, now func() time.Time, tempo v1alpha1.TempoStack, tlsEnabled bool) []v1alpha1.RingStatus {
+	rings := []string{manifestutils.IngesterComponentName}
+	if tempo.Spec.Template.MetricsGenerator.Enabled {
+		rings = append(rings, manifestutils.MetricsGeneratorComponentName)
+	}
+
+	httpClient, err := newHTTPClient(ctx, k8sClient, tempo, tlsEnabled, manifestutils.DistributorComponentName)
+	statuses := make([]v1alpha1.RingStatus, 0, len(rings))
+	for _, name := range rings {
+		if err != nil {
+			statuses = append(statuses, v1alpha1.RingStatus{Name: name, Error: err.Error()})
+			continue
+		}
+		statuses = append(statuses, probeRing(ctx, httpClient, now, tempo, tlsEnabled, name))
+	}
+	return statuses
+}
+
+func probeRing(ctx context.Context, httpClient *http.Client, now func() time.Time, tempo v1alpha1.TempoStack, tlsEnabled bool, name string) v1alpha1.RingStatus {
+	status := v1alpha1.RingStatus{Name: name}
+
+	scheme := "http"
+	if tlsEnabled {
+		scheme = "https"
+	}
+	url := fmt.Sprintf("%s://%s:%d/%s/ring", scheme,
+		naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.DistributorComponentName), manifestutils.PortHTTPServer, name)
+
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	// The ring status page returns JSON instead of HTML if requested.
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		status.Error = fmt.Sprintf("unexpected status code %d", resp.StatusCode)
+		return status
+	}
+
+	ring := ringResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&ring); err != nil {
+		status.Error = fmt.Sprintf("invalid ring status: %s", err)
+		return status
+	}
+
+	status.Members = len(ring.Shards)
+	for _, shard := range ring.Shards {
+		if shard.State != activeState || now().Sub(shard.Timestamp) > heartbeatTimeout {
+			status.UnhealthyMembers = append(status.UnhealthyMembers, shard.ID)
+		}
+	}
+	sort.Strings(status.UnhealthyMembers)
+	return status
+}
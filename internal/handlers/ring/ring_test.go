@@ -0,0 +1,57 @@
+package ring
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/httpclient/httpclienttest"
+)
+
+func TestProbe(t *testing.T) {
+	probeTime := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	now := func() time.Time { return probeTime }
+
+	newHTTPClient := httpclienttest.NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		assert.Equal(t, "tempo-test-distributor.project1.svc.cluster.local:3200", r.Host)
+
+		switch r.URL.Path {
+		case "/ingester/ring":
+			_, _ = w.Write([]byte(`{"shards": [
+				{"id": "tempo-test-ingester-0", "state": "ACTIVE", "timestamp": "2023-10-01T11:59:50Z"},
+				{"id": "tempo-test-ingester-1", "state": "LEAVING", "timestamp": "2023-10-01T11:59:50Z"},
+				{"id": "tempo-test-ingester-2", "state": "ACTIVE", "timestamp": "2023-10-01T11:50:00Z"}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "project1"},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{Enabled: true},
+			},
+		},
+	}
+
+	rings := Probe(context.Background(), nil, newHTTPClient, now, tempo, false)
+	assert.Equal(t, []v1alpha1.RingStatus{
+		{
+			Name:             "ingester",
+			Members:          3,
+			UnhealthyMembers: []string{"tempo-test-ingester-1", "tempo-test-ingester-2"},
+		},
+		{
+			Name:  "metrics-generator",
+			Error: "unexpected status code 404",
+		},
+	}, rings)
+}
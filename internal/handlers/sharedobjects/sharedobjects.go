@@ -0,0 +1,156 @@
+package sharedobjects
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/status"
+)
+
+const (
+	// CopiedFromAnnotation contains the namespace and name of the Secret or ConfigMap a copy was created from.
+	CopiedFromAnnotation = "tempo.grafana.com/copied-from"
+	// copyLabel marks the Secrets and ConfigMaps which are copies of a shared object.
+	copyLabel = "tempo.grafana.com/shared-object-copy"
+)
+
+// Copy copies the Secrets and ConfigMaps which are referenced from other namespaces into the namespace of the TempoStack,
+// keeping their names, and removes the TempoStack from the owners of the copies which are not referenced anymore.
+// A copy can be referenced by several TempoStacks of the namespace, it is garbage collected once it has no owner left.
+func Copy(ctx context.Context, k8sClient client.Client, scheme *runtime.Scheme, tempo v1alpha1.TempoStack) error {
+	referenced := map[string]bool{}
+	for _, ref := range v1alpha1.SharedObjectReferences(tempo) {
+		if err := copyObject(ctx, k8sClient, scheme, tempo, ref); err != nil {
+			return err
+		}
+		referenced[key(ref.Kind, ref.Name)] = true
+	}
+	return releaseCopies(ctx, k8sClient, tempo, referenced)
+}
+
+// Key returns the index key of a shared Secret or ConfigMap.
+func Key(kind v1alpha1.SharedObjectKind, namespace string, name string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+func key(kind v1alpha1.SharedObjectKind, name string) string {
+	return fmt.Sprintf("%s/%s", kind, name)
+}
+
+func newObject(kind v1alpha1.SharedObjectKind) client.Object {
+	if kind == v1alpha1.SharedConfigMap {
+		return &corev1.ConfigMap{}
+	}
+	return &corev1.Secret{}
+}
+
+func copyObject(ctx context.Context, k8sClient client.Client, scheme *runtime.Scheme, tempo v1alpha1.TempoStack, ref v1alpha1.SharedObjectReference) error {
+	source := newObject(ref.Kind)
+	copiedFrom := ref.Namespace + "/" + ref.Name
+	if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, source); err != nil {
+		if apierrors.IsNotFound(err) {
+			return &status.ConfigurationError{
+				Message: fmt.Sprintf("the %s %s referenced in %s does not exist", ref.Kind, copiedFrom, ref.Path),
+				Reason:  v1alpha1.ReasonCouldNotCopySecret,
+			}
+		}
+		return fmt.Errorf("failed to get the %s %s: %w", ref.Kind, copiedFrom, err)
+	}
+
+	target := newObject(ref.Kind)
+	target.SetNamespace(tempo.Namespace)
+	target.SetName(ref.Name)
+	_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, target, func() error {
+		exists := target.GetResourceVersion() != ""
+		if exists && target.GetAnnotations()[CopiedFromAnnotation] != copiedFrom {
+			return &status.ConfigurationError{
+				Message: fmt.Sprintf("the %s %s already exists in the namespace %s and is not a copy of %s",
+					ref.Kind, ref.Name, tempo.Namespace, copiedFrom),
+				Reason: v1alpha1.ReasonCouldNotCopySecret,
+			}
+		}
+
+		target.SetLabels(map[string]string{
+			"app.kubernetes.io/managed-by": "tempo-operator",
+			copyLabel:                      "true",
+		})
+		target.SetAnnotations(map[string]string{CopiedFromAnnotation: copiedFrom})
+		switch s := source.(type) {
+		case *corev1.Secret:
+			t := target.(*corev1.Secret)
+			// The type of a Secret is immutable.
+			if !exists {
+				t.Type = s.Type
+			}
+			t.Data = s.Data
+		case *corev1.ConfigMap:
+			t := target.(*corev1.ConfigMap)
+			t.Data = s.Data
+			t.BinaryData = s.BinaryData
+		}
+		return controllerutil.SetOwnerReference(&tempo, target, scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy the %s %s: %w", ref.Kind, copiedFrom, err)
+	}
+	return nil
+}
+
+// releaseCopies removes the TempoStack from the owners of the copies it does not reference anymore,
+// and deletes the copies without owners.
+func releaseCopies(ctx context.Context, k8sClient client.Client, tempo v1alpha1.TempoStack, referenced map[string]bool) error {
+	listOps := []client.ListOption{client.InNamespace(tempo.Namespace), client.MatchingLabels{copyLabel: "true"}}
+
+	secrets := &corev1.SecretList{}
+	if err := k8sClient.List(ctx, secrets, listOps...); err != nil {
+		return fmt.Errorf("failed to list the copied secrets: %w", err)
+	}
+	configMaps := &corev1.ConfigMapList{}
+	if err := k8sClient.List(ctx, configMaps, listOps...); err != nil {
+		return fmt.Errorf("failed to list the copied config maps: %w", err)
+	}
+
+	var copies []client.Object
+	for i := range secrets.Items {
+		if !referenced[key(v1alpha1.SharedSecret, secrets.Items[i].Name)] {
+			copies = append(copies, &secrets.Items[i])
+		}
+	}
+	for i := range configMaps.Items {
+		if !referenced[key(v1alpha1.SharedConfigMap, configMaps.Items[i].Name)] {
+			copies = append(copies, &configMaps.Items[i])
+		}
+	}
+
+	for _, obj := range copies {
+		var owners []metav1.OwnerReference
+		for _, owner := range obj.GetOwnerReferences() {
+			if owner.UID != tempo.UID {
+				owners = append(owners, owner)
+			}
+		}
+		if len(owners) == len(obj.GetOwnerReferences()) {
+			continue
+		}
+
+		var err error
+		if len(owners) == 0 {
+			err = k8sClient.Delete(ctx, obj)
+		} else {
+			obj.SetOwnerReferences(owners)
+			err = k8sClient.Update(ctx, obj)
+		}
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to release the copy %s: %w", obj.GetName(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package sharedobjects
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/status"
+)
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+func tempoStack(name string, uid string) v1alpha1.TempoStack {
+	return v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", UID: types.UID(uid)},
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{Name: "storage", Namespace: "platform", Type: v1alpha1.ObjectStorageSecretS3},
+				TLS:    &v1alpha1.ObjectStorageTLSSpec{CA: "storage-ca", CANamespace: "platform"},
+			},
+		},
+	}
+}
+
+func TestCopy(t *testing.T) {
+	scheme := testScheme(t)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "storage", Namespace: "platform"},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       map[string][]byte{"bucket": []byte("traces")},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "storage-ca", Namespace: "platform"},
+			Data:       map[string]string{"service-ca.crt": "ca"},
+		},
+	).Build()
+
+	simplest, other := tempoStack("simplest", "uid-1"), tempoStack("other", "uid-2")
+	require.NoError(t, Copy(context.Background(), k8sClient, scheme, simplest))
+	require.NoError(t, Copy(context.Background(), k8sClient, scheme, other))
+
+	secret := &corev1.Secret{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "team-a", Name: "storage"}, secret))
+	assert.Equal(t, map[string][]byte{"bucket": []byte("traces")}, secret.Data)
+	assert.Equal(t, corev1.SecretTypeOpaque, secret.Type)
+	assert.Equal(t, "platform/storage", secret.Annotations[CopiedFromAnnotation])
+	assert.Len(t, secret.OwnerReferences, 2)
+
+	configMap := &corev1.ConfigMap{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "team-a", Name: "storage-ca"}, configMap))
+	assert.Equal(t, map[string]string{"service-ca.crt": "ca"}, configMap.Data)
+	assert.Equal(t, "platform/storage-ca", configMap.Annotations[CopiedFromAnnotation])
+
+	// The copies are released if the references are removed.
+	simplest.Spec.Storage.TLS = nil
+	require.NoError(t, Copy(context.Background(), k8sClient, scheme, simplest))
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "team-a", Name: "storage-ca"}, configMap))
+	require.Len(t, configMap.OwnerReferences, 1)
+	assert.Equal(t, "other", configMap.OwnerReferences[0].Name)
+
+	other.Spec.Storage.TLS = nil
+	require.NoError(t, Copy(context.Background(), k8sClient, scheme, other))
+	err := k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "team-a", Name: "storage-ca"}, configMap)
+	assert.True(t, apierrors.IsNotFound(err))
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "team-a", Name: "storage"}, secret))
+}
+
+func TestCopy_Errors(t *testing.T) {
+	scheme := testScheme(t)
+	tempo := tempoStack("simplest", "uid-1")
+	tempo.Spec.Storage.TLS = nil
+
+	// The original secret does not exist.
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	err := Copy(context.Background(), k8sClient, scheme, tempo)
+	var configErr *status.ConfigurationError
+	require.True(t, errors.As(err, &configErr))
+	assert.Equal(t, "the Secret platform/storage referenced in spec.storage.secret.namespace does not exist", configErr.Message)
+
+	// A secret with the same name, which is not a copy, is not overwritten.
+	k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "storage", Namespace: "platform"}},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "storage", Namespace: "team-a"},
+			Data:       map[string][]byte{"bucket": []byte("local")},
+		},
+	).Build()
+	err = Copy(context.Background(), k8sClient, scheme, tempo)
+	require.True(t, errors.As(err, &configErr))
+	assert.Equal(t, "the Secret storage already exists in the namespace team-a and is not a copy of platform/storage", configErr.Message)
+
+	secret := &corev1.Secret{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "team-a", Name: "storage"}, secret))
+	assert.Equal(t, map[string][]byte{"bucket": []byte("local")}, secret.Data)
+}
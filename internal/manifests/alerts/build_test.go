@@ -17,7 +17,7 @@ func TestBuildRules(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, rulesSpec.Groups, 2)
 	assert.Equal(t, "tempo_alerts_test_default", rulesSpec.Groups[0].Name)
-	assert.Len(t, rulesSpec.Groups[0].Rules, 14)
+	assert.Len(t, rulesSpec.Groups[0].Rules, 16)
 
 	assert.Equal(t, "tempo_rules_test_default", rulesSpec.Groups[1].Name)
 	assert.Len(t, rulesSpec.Groups[1].Rules, 6)
@@ -3,8 +3,10 @@ package alerts
 import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
 )
 
@@ -45,9 +47,10 @@ func newPrometheusRule(stackName, namespace string) (*monitoringv1.PrometheusRul
 
 		ObjectMeta: metav1.ObjectMeta{
 			Name: naming.PrometheusRuleName(stackName),
-			Labels: map[string]string{
+			// The common labels are required to prune the PrometheusRule once it is disabled.
+			Labels: labels.Merge(manifestutils.CommonLabels(stackName), map[string]string{
 				"openshift.io/prometheus-rule-evaluation-scope": "leaf-prometheus",
-			},
+			}),
 		},
 		Spec: *spec,
 	}, nil
@@ -16,4 +16,6 @@ func TestBuildPrometheusRule(t *testing.T) {
 	rules := objects[0].(*monitoringv1.PrometheusRule)
 
 	assert.Equal(t, "tempo-test-prometheus-rule", rules.Name)
+	assert.Equal(t, "tempo-test", rules.Labels["app.kubernetes.io/instance"])
+	assert.Equal(t, "leaf-prometheus", rules.Labels["openshift.io/prometheus-rule-evaluation-scope"])
 }
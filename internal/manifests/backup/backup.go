@@ -0,0 +1,129 @@
+package backup
+
+import (
+	"github.com/ViaQ/logerr/v2/kverrors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const (
+	componentName = "backup"
+
+	// rclone reads the configuration of the remotes from the RCLONE_CONFIG_<REMOTE>_<OPTION> environment variables.
+	sourceRemote      = "SRC"
+	destinationRemote = "DST"
+	storageRemote     = "STORAGE"
+)
+
+// BuildBackup creates a CronJob which synchronizes the backup bucket with the object storage of the TempoStack.
+// The whole bucket is copied with rclone, i.e. the blocks, their meta.json files and the tenant indexes.
+func BuildBackup(params manifestutils.Params) (*batchv1.CronJob, error) {
+	tempo := params.Tempo
+	spec := tempo.Spec.Backup
+
+	srcEnv, err := remoteEnvVars(sourceRemote, tempo.Spec.Storage.Secret)
+	if err != nil {
+		return nil, err
+	}
+	dstEnv, err := remoteEnvVars(destinationRemote, spec.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := manifestutils.ComponentLabels(componentName, tempo.Name)
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(componentName, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule: spec.Schedule,
+			Suspend:  pointer.Bool(spec.Suspend),
+			// A sync must not run concurrently with another sync to the same backup bucket.
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: pointer.Int32(1),
+			FailedJobsHistoryLimit:     pointer.Int32(3),
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: batchv1.JobSpec{
+					BackoffLimit: pointer.Int32(2),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: labels,
+						},
+						Spec: corev1.PodSpec{
+							ServiceAccountName: tempo.Spec.ServiceAccount,
+							RestartPolicy:      corev1.RestartPolicyNever,
+							Containers: []corev1.Container{
+								{
+									Name:  "rclone",
+									Image: tempo.Spec.Images.Backup,
+									Args: []string{
+										"sync",
+										"--checksum",
+										sourceRemote + ":$(" + sourceRemote + "_BUCKET)",
+										destinationRemote + ":$(" + destinationRemote + "_BUCKET)",
+									},
+									Env: append(srcEnv, dstEnv...),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// remoteEnvVars returns the environment variables which configure a rclone remote,
+// and the <remote>_BUCKET variable containing the bucket (or container) name.
+func remoteEnvVars(remote string, secret v1alpha1.ObjectStorageSecretSpec) ([]corev1.EnvVar, error) {
+	fromSecret := func(name string, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+					Key:                  key,
+				},
+			},
+		}
+	}
+	prefix := "RCLONE_CONFIG_" + remote + "_"
+
+	switch secret.Type {
+	case v1alpha1.ObjectStorageSecretS3:
+		return []corev1.EnvVar{
+			{Name: prefix + "TYPE", Value: "s3"},
+			{Name: prefix + "PROVIDER", Value: "Other"},
+			fromSecret(prefix+"ENDPOINT", "endpoint"),
+			fromSecret(prefix+"ACCESS_KEY_ID", "access_key_id"),
+			fromSecret(prefix+"SECRET_ACCESS_KEY", "access_key_secret"),
+			fromSecret(remote+"_BUCKET", "bucket"),
+		}, nil
+	case v1alpha1.ObjectStorageSecretAzure:
+		return []corev1.EnvVar{
+			{Name: prefix + "TYPE", Value: "azureblob"},
+			fromSecret(prefix+"ACCOUNT", "account_name"),
+			fromSecret(prefix+"KEY", "account_key"),
+			fromSecret(remote+"_BUCKET", "container"),
+		}, nil
+	case v1alpha1.ObjectStorageSecretGCS:
+		return []corev1.EnvVar{
+			{Name: prefix + "TYPE", Value: "google cloud storage"},
+			fromSecret(prefix+"SERVICE_ACCOUNT_CREDENTIALS", "key.json"),
+			fromSecret(remote+"_BUCKET", "bucketname"),
+		}, nil
+	default:
+		return nil, kverrors.New("unsupported object storage type", "type", secret.Type)
+	}
+}
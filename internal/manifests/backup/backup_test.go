@@ -0,0 +1,112 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func secretEnv(name string, secret string, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+func TestBuildBackup(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			ServiceAccount: "tempo-simplest",
+			Images:         configv1alpha1.ImagesSpec{Backup: "docker.io/rclone/rclone:1.64.0"},
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{Name: "storage", Type: v1alpha1.ObjectStorageSecretS3},
+			},
+			Backup: &v1alpha1.BackupSpec{
+				Schedule: "0 1 * * *",
+				Secret:   v1alpha1.ObjectStorageSecretSpec{Name: "backup", Type: v1alpha1.ObjectStorageSecretGCS},
+			},
+		},
+	}
+
+	cronJob, err := BuildBackup(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+
+	assert.Equal(t, "tempo-simplest-backup", cronJob.Name)
+	assert.Equal(t, "observability", cronJob.Namespace)
+	assert.Equal(t, "backup", cronJob.Labels["app.kubernetes.io/component"])
+	assert.Equal(t, "0 1 * * *", cronJob.Spec.Schedule)
+	assert.False(t, *cronJob.Spec.Suspend)
+	assert.Equal(t, batchv1.ForbidConcurrent, cronJob.Spec.ConcurrencyPolicy)
+
+	pod := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	assert.Equal(t, "tempo-simplest", pod.ServiceAccountName)
+	assert.Equal(t, corev1.RestartPolicyNever, pod.RestartPolicy)
+	require.Len(t, pod.Containers, 1)
+	assert.Equal(t, "docker.io/rclone/rclone:1.64.0", pod.Containers[0].Image)
+	assert.Equal(t, []string{"sync", "--checksum", "SRC:$(SRC_BUCKET)", "DST:$(DST_BUCKET)"}, pod.Containers[0].Args)
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "RCLONE_CONFIG_SRC_TYPE", Value: "s3"},
+		{Name: "RCLONE_CONFIG_SRC_PROVIDER", Value: "Other"},
+		secretEnv("RCLONE_CONFIG_SRC_ENDPOINT", "storage", "endpoint"),
+		secretEnv("RCLONE_CONFIG_SRC_ACCESS_KEY_ID", "storage", "access_key_id"),
+		secretEnv("RCLONE_CONFIG_SRC_SECRET_ACCESS_KEY", "storage", "access_key_secret"),
+		secretEnv("SRC_BUCKET", "storage", "bucket"),
+		{Name: "RCLONE_CONFIG_DST_TYPE", Value: "google cloud storage"},
+		secretEnv("RCLONE_CONFIG_DST_SERVICE_ACCOUNT_CREDENTIALS", "backup", "key.json"),
+		secretEnv("DST_BUCKET", "backup", "bucketname"),
+	}, pod.Containers[0].Env)
+}
+
+func TestRemoteEnvVars(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  v1alpha1.ObjectStorageSecretSpec
+		want    []corev1.EnvVar
+		wantErr bool
+	}{
+		{
+			name:   "azure",
+			secret: v1alpha1.ObjectStorageSecretSpec{Name: "backup", Type: v1alpha1.ObjectStorageSecretAzure},
+			want: []corev1.EnvVar{
+				{Name: "RCLONE_CONFIG_DST_TYPE", Value: "azureblob"},
+				secretEnv("RCLONE_CONFIG_DST_ACCOUNT", "backup", "account_name"),
+				secretEnv("RCLONE_CONFIG_DST_KEY", "backup", "account_key"),
+				secretEnv("DST_BUCKET", "backup", "container"),
+			},
+		},
+		{
+			name:    "unknown type",
+			secret:  v1alpha1.ObjectStorageSecretSpec{Name: "backup", Type: "swift"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env, err := remoteEnvVars(destinationRemote, test.secret)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, env)
+		})
+	}
+}
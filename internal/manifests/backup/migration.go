@@ -0,0 +1,85 @@
+package backup
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const migrationComponentName = "migrate"
+
+// BuildMigration creates a Job which copies the blocks of the previous object storage to the object storage of the TempoStack.
+//
+// Tempo discovers the blocks by their meta.json files, therefore the data of the blocks is copied first
+// and the meta.json files afterwards, so that Tempo does not read partially copied blocks.
+// The tenant indexes are not copied, they would hide the blocks of the new object storage until the compactor
+// rebuilds them.
+func BuildMigration(params manifestutils.Params) (*batchv1.Job, error) {
+	tempo := params.Tempo
+
+	srcEnv, err := remoteEnvVars(sourceRemote, *tempo.Spec.Storage.MigrateFrom)
+	if err != nil {
+		return nil, err
+	}
+	dstEnv, err := remoteEnvVars(destinationRemote, tempo.Spec.Storage.Secret)
+	if err != nil {
+		return nil, err
+	}
+	env := append(srcEnv, dstEnv...)
+	remotes := []string{
+		sourceRemote + ":$(" + sourceRemote + "_BUCKET)",
+		destinationRemote + ":$(" + destinationRemote + "_BUCKET)",
+	}
+
+	labels := manifestutils.ComponentLabels(migrationComponentName, tempo.Name)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(migrationComponentName, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: pointer.Int32(2),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: tempo.Spec.ServiceAccount,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					InitContainers: []corev1.Container{
+						{
+							Name:  "copy-blocks",
+							Image: tempo.Spec.Images.Backup,
+							Args: append([]string{
+								"copy",
+								"--checksum",
+								"--exclude=meta.json",
+								"--exclude=meta.compacted.json",
+								"--exclude=index.json.gz",
+							}, remotes...),
+							Env: env,
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:  "copy-metadata",
+							Image: tempo.Spec.Images.Backup,
+							Args: append([]string{
+								"copy",
+								"--checksum",
+								"--include=meta.json",
+								"--include=meta.compacted.json",
+							}, remotes...),
+							Env: env,
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,71 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildMigration(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			ServiceAccount: "tempo-simplest",
+			Images:         configv1alpha1.ImagesSpec{Backup: "docker.io/rclone/rclone:1.64.0"},
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret:      v1alpha1.ObjectStorageSecretSpec{Name: "storage", Type: v1alpha1.ObjectStorageSecretS3},
+				MigrateFrom: &v1alpha1.ObjectStorageSecretSpec{Name: "old-storage", Type: v1alpha1.ObjectStorageSecretAzure},
+			},
+		},
+	}
+
+	job, err := BuildMigration(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+
+	assert.Equal(t, "tempo-simplest-migrate", job.Name)
+	assert.Equal(t, "observability", job.Namespace)
+	assert.Equal(t, "migrate", job.Labels["app.kubernetes.io/component"])
+
+	pod := job.Spec.Template.Spec
+	assert.Equal(t, "tempo-simplest", pod.ServiceAccountName)
+	assert.Equal(t, corev1.RestartPolicyNever, pod.RestartPolicy)
+	require.Len(t, pod.InitContainers, 1)
+	require.Len(t, pod.Containers, 1)
+
+	// The blocks are copied before their meta.json files, which make them visible to Tempo.
+	assert.Equal(t, "docker.io/rclone/rclone:1.64.0", pod.InitContainers[0].Image)
+	assert.Equal(t, []string{
+		"copy", "--checksum", "--exclude=meta.json", "--exclude=meta.compacted.json", "--exclude=index.json.gz",
+		"SRC:$(SRC_BUCKET)", "DST:$(DST_BUCKET)",
+	}, pod.InitContainers[0].Args)
+	assert.Equal(t, "docker.io/rclone/rclone:1.64.0", pod.Containers[0].Image)
+	assert.Equal(t, []string{
+		"copy", "--checksum", "--include=meta.json", "--include=meta.compacted.json",
+		"SRC:$(SRC_BUCKET)", "DST:$(DST_BUCKET)",
+	}, pod.Containers[0].Args)
+
+	env := []corev1.EnvVar{
+		{Name: "RCLONE_CONFIG_SRC_TYPE", Value: "azureblob"},
+		secretEnv("RCLONE_CONFIG_SRC_ACCOUNT", "old-storage", "account_name"),
+		secretEnv("RCLONE_CONFIG_SRC_KEY", "old-storage", "account_key"),
+		secretEnv("SRC_BUCKET", "old-storage", "container"),
+		{Name: "RCLONE_CONFIG_DST_TYPE", Value: "s3"},
+		{Name: "RCLONE_CONFIG_DST_PROVIDER", Value: "Other"},
+		secretEnv("RCLONE_CONFIG_DST_ENDPOINT", "storage", "endpoint"),
+		secretEnv("RCLONE_CONFIG_DST_ACCESS_KEY_ID", "storage", "access_key_id"),
+		secretEnv("RCLONE_CONFIG_DST_SECRET_ACCESS_KEY", "storage", "access_key_secret"),
+		secretEnv("DST_BUCKET", "storage", "bucket"),
+	}
+	assert.Equal(t, env, pod.InitContainers[0].Env)
+	assert.Equal(t, env, pod.Containers[0].Env)
+}
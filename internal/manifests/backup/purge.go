@@ -0,0 +1,74 @@
+package backup
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// TenantPurgeJobName returns the name of the Job of a tenant purge.
+func TenantPurgeJobName(tempo v1alpha1.TempoStack, spec v1alpha1.TenantPurgeSpec) string {
+	return naming.Name(manifestutils.TenantPurgeComponentName+"-"+spec.Name, tempo.Name)
+}
+
+// BuildTenantPurges creates a Job for each tenant purge of the TempoStack.
+// Tempo stores the blocks, their meta.json files and the index of a tenant below the tenant ID prefix,
+// therefore the Job removes this prefix from the bucket.
+func BuildTenantPurges(params manifestutils.Params) ([]client.Object, error) {
+	tempo := params.Tempo
+	if len(tempo.Spec.TenantPurges) == 0 {
+		return nil, nil
+	}
+
+	env, err := remoteEnvVars(storageRemote, tempo.Spec.Storage.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	var objs []client.Object
+	for _, spec := range tempo.Spec.TenantPurges {
+		objs = append(objs, buildTenantPurge(tempo, spec, env))
+	}
+	return objs, nil
+}
+
+func buildTenantPurge(tempo v1alpha1.TempoStack, spec v1alpha1.TenantPurgeSpec, env []corev1.EnvVar) *batchv1.Job {
+	labels := manifestutils.ComponentLabels(manifestutils.TenantPurgeComponentName, tempo.Name)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TenantPurgeJobName(tempo, spec),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: pointer.Int32(2),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: tempo.Spec.ServiceAccount,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:  "rclone",
+							Image: tempo.Spec.Images.Backup,
+							// The webhook rejects tenant IDs which are not a single path segment.
+							Args: []string{
+								"purge",
+								storageRemote + ":$(" + storageRemote + "_BUCKET)/" + spec.Tenant,
+							},
+							Env: env,
+						},
+					},
+				},
+			},
+		},
+	}
+}
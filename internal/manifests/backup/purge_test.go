@@ -0,0 +1,62 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildTenantPurges(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			ServiceAccount: "tempo-simplest",
+			Images:         configv1alpha1.ImagesSpec{Backup: "docker.io/rclone/rclone:1.64.0"},
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{Name: "storage", Type: v1alpha1.ObjectStorageSecretGCS},
+			},
+			TenantPurges: []v1alpha1.TenantPurgeSpec{
+				{Name: "dev", Tenant: "dev"},
+				{Name: "prod", Tenant: "prod"},
+			},
+		},
+	}
+
+	objs, err := BuildTenantPurges(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+
+	job := objs[1].(*batchv1.Job)
+	assert.Equal(t, "tempo-simplest-purge-prod", job.Name)
+	assert.Equal(t, "observability", job.Namespace)
+	assert.Equal(t, "purge", job.Labels["app.kubernetes.io/component"])
+
+	pod := job.Spec.Template.Spec
+	assert.Equal(t, "tempo-simplest", pod.ServiceAccountName)
+	assert.Equal(t, corev1.RestartPolicyNever, pod.RestartPolicy)
+	require.Len(t, pod.Containers, 1)
+	assert.Equal(t, "docker.io/rclone/rclone:1.64.0", pod.Containers[0].Image)
+	assert.Equal(t, []string{"purge", "STORAGE:$(STORAGE_BUCKET)/prod"}, pod.Containers[0].Args)
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "RCLONE_CONFIG_STORAGE_TYPE", Value: "google cloud storage"},
+		secretEnv("RCLONE_CONFIG_STORAGE_SERVICE_ACCOUNT_CREDENTIALS", "storage", "key.json"),
+		secretEnv("STORAGE_BUCKET", "storage", "bucketname"),
+	}, pod.Containers[0].Env)
+}
+
+func TestBuildTenantPurges_None(t *testing.T) {
+	objs, err := BuildTenantPurges(manifestutils.Params{Tempo: v1alpha1.TempoStack{}})
+	require.NoError(t, err)
+	assert.Empty(t, objs)
+}
@@ -0,0 +1,123 @@
+package certmanager
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const (
+	defaultIssuerGroup = "cert-manager.io"
+	// CAKey is the key of the CA certificate in the secrets issued by cert-manager.
+	CAKey = "ca.crt"
+)
+
+// CertificateGVK is the GroupVersionKind of the cert-manager Certificate resource.
+var CertificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+// components contains all components which require a serving and client certificate.
+var components = []string{
+	manifestutils.DistributorComponentName,
+	manifestutils.IngesterComponentName,
+	manifestutils.QuerierComponentName,
+	manifestutils.QueryFrontendComponentName,
+	manifestutils.CompactorComponentName,
+	manifestutils.GatewayComponentName,
+}
+
+// BuildCertificates creates the cert-manager Certificates of all Tempo components and,
+// once cert-manager issued the certificates, the CA bundle ConfigMap.
+// The certificates are stored in the same secrets as the certificates of the built-in cert management.
+func BuildCertificates(params manifestutils.Params) []client.Object {
+	tempo := params.Tempo
+	objs := make([]client.Object, 0, len(components)+1)
+	for _, component := range components {
+		objs = append(objs, certificate(tempo, component))
+	}
+	if tempo.Spec.Template.MetricsGenerator.Enabled {
+		objs = append(objs, certificate(tempo, manifestutils.MetricsGeneratorComponentName))
+	}
+
+	if params.CertManagerCABundle != "" {
+		objs = append(objs, caBundle(tempo, params.CertManagerCABundle))
+	}
+	return objs
+}
+
+func certificate(tempo v1alpha1.TempoStack, component string) *unstructured.Unstructured {
+	issuerRef := tempo.Spec.CertManager.IssuerRef
+	kind := issuerRef.Kind
+	if kind == "" {
+		kind = v1alpha1.CertManagerIssuer
+	}
+	group := issuerRef.Group
+	if group == "" {
+		group = defaultIssuerGroup
+	}
+
+	serviceName := naming.Name(component, tempo.Name)
+	labels := manifestutils.ComponentLabels(component, tempo.Name)
+
+	cert := &unstructured.Unstructured{}
+	cert.SetGroupVersionKind(CertificateGVK)
+	cert.SetName(serviceName)
+	cert.SetNamespace(tempo.Namespace)
+	cert.SetLabels(labels)
+	spec := map[string]interface{}{
+		"secretName": naming.TLSSecretName(component, tempo.Name),
+		"secretTemplate": map[string]interface{}{
+			"labels": toInterfaceMap(labels),
+		},
+		"dnsNames": []interface{}{
+			fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, tempo.Namespace),
+			fmt.Sprintf("%s.%s.svc", serviceName, tempo.Namespace),
+		},
+		"usages": []interface{}{
+			"server auth",
+			"client auth",
+		},
+		"issuerRef": map[string]interface{}{
+			"name":  issuerRef.Name,
+			"kind":  string(kind),
+			"group": group,
+		},
+	}
+	if duration := tempo.Spec.CertManager.Duration; duration != nil {
+		spec["duration"] = duration.Duration.String()
+	}
+	if renewBefore := tempo.Spec.CertManager.RenewBefore; renewBefore != nil {
+		spec["renewBefore"] = renewBefore.Duration.String()
+	}
+
+	cert.Object["spec"] = spec
+	return cert
+}
+
+func caBundle(tempo v1alpha1.TempoStack, ca string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.SigningCABundleName(tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    manifestutils.CommonLabels(tempo.Name),
+		},
+		Data: map[string]string{
+			"service-ca.crt": ca,
+		},
+	}
+}
+
+func toInterfaceMap(m map[string]string) map[string]interface{} {
+	res := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		res[k] = v
+	}
+	return res
+}
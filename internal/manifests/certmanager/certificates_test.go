@@ -0,0 +1,101 @@
+package certmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildCertificates(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			CertManager: &v1alpha1.CertManagerSpec{
+				IssuerRef: v1alpha1.CertManagerIssuerReference{
+					Name: "ca-issuer",
+					Kind: v1alpha1.CertManagerClusterIssuer,
+				},
+			},
+		},
+	}
+
+	objects := BuildCertificates(manifestutils.Params{Tempo: tempo})
+	require.Len(t, objects, 6)
+
+	cert := objects[0].(*unstructured.Unstructured)
+	assert.Equal(t, CertificateGVK, cert.GroupVersionKind())
+	assert.Equal(t, "tempo-test-distributor", cert.GetName())
+	assert.Equal(t, map[string]interface{}{
+		"secretName": "tempo-test-distributor-mtls",
+		"secretTemplate": map[string]interface{}{
+			"labels": map[string]interface{}{
+				"app.kubernetes.io/name":       "tempo",
+				"app.kubernetes.io/instance":   "test",
+				"app.kubernetes.io/managed-by": "tempo-operator",
+				"app.kubernetes.io/component":  "distributor",
+			},
+		},
+		"dnsNames": []interface{}{
+			"tempo-test-distributor.project1.svc.cluster.local",
+			"tempo-test-distributor.project1.svc",
+		},
+		"usages": []interface{}{
+			"server auth",
+			"client auth",
+		},
+		"issuerRef": map[string]interface{}{
+			"name":  "ca-issuer",
+			"kind":  "ClusterIssuer",
+			"group": "cert-manager.io",
+		},
+	}, cert.Object["spec"])
+
+	objects = BuildCertificates(manifestutils.Params{Tempo: tempo, CertManagerCABundle: "ca"})
+	require.Len(t, objects, 7)
+	assert.Equal(t, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-ca-bundle",
+			Namespace: "project1",
+			Labels:    manifestutils.CommonLabels("test"),
+		},
+		Data: map[string]string{
+			"service-ca.crt": "ca",
+		},
+	}, objects[6])
+}
+
+func TestBuildCertificates_Duration(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			CertManager: &v1alpha1.CertManagerSpec{
+				IssuerRef: v1alpha1.CertManagerIssuerReference{
+					Name: "vault-issuer",
+				},
+				Duration:    &metav1.Duration{Duration: 24 * time.Hour},
+				RenewBefore: &metav1.Duration{Duration: 8 * time.Hour},
+			},
+		},
+	}
+
+	objects := BuildCertificates(manifestutils.Params{Tempo: tempo})
+	require.Len(t, objects, 6)
+
+	spec := objects[0].(*unstructured.Unstructured).Object["spec"].(map[string]interface{})
+	assert.Equal(t, "24h0m0s", spec["duration"])
+	assert.Equal(t, "8h0m0s", spec["renewBefore"])
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/memberlist"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
+	"github.com/grafana/tempo-operator/internal/manifests/spiffe"
 )
 
 // BuildCompactor creates distributor objects.
@@ -26,7 +27,15 @@ func BuildCompactor(params manifestutils.Params) ([]client.Object, error) {
 	}
 	gates := params.Gates
 	tempo := params.Tempo
-	if gates.HTTPEncryption || gates.GRPCEncryption {
+	manifestutils.ConfigureRuntimeOverrides(tempo, &d.Spec.Template.Spec)
+	manifestutils.ConfigureMemberlist(tempo, &d.Spec.Template.Spec)
+	manifestutils.ConfigureKVStore(tempo, &d.Spec.Template.Spec)
+
+	if (gates.HTTPEncryption || gates.GRPCEncryption) && tempo.Spec.SPIFFE != nil {
+		if err := spiffe.ConfigurePodSpec(tempo, &d.Spec.Template.Spec); err != nil {
+			return nil, err
+		}
+	} else if gates.HTTPEncryption || gates.GRPCEncryption {
 		caBundleName := naming.SigningCABundleName(tempo.Name)
 		if err := manifestutils.ConfigureServiceCA(&d.Spec.Template.Spec, caBundleName); err != nil {
 			return nil, err
@@ -37,13 +46,18 @@ func BuildCompactor(params manifestutils.Params) ([]client.Object, error) {
 		}
 	}
 
-	return []client.Object{d, service(tempo)}, nil
+	objs := []client.Object{d, service(tempo)}
+	if manifestutils.MultipleReplicas(d.Spec.Replicas, nil) {
+		objs = append(objs, manifestutils.PodDisruptionBudget(tempo, manifestutils.CompactorComponentName, tempo.Spec.Template.Compactor.PodDisruptionBudget))
+	}
+	return objs, nil
 }
 
 func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 	tempo := params.Tempo
+	httpPort, _ := manifestutils.ServerPorts(tempo)
 	labels := manifestutils.ComponentLabels(manifestutils.CompactorComponentName, tempo.Name)
-	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum)
+	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum, params.Tempo.Annotations[manifestutils.CertRotationRequiredAtAnnotation])
 	cfg := tempo.Spec.Template.Compactor
 
 	d := &v1.Deployment{
@@ -56,18 +70,22 @@ func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 			Labels:    labels,
 		},
 		Spec: v1.DeploymentSpec{
+			Replicas: cfg.Replicas,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      k8slabels.Merge(labels, memberlist.GossipSelector),
-					Annotations: annotations,
+					Labels:      manifestutils.PodLabels(cfg.PodLabels, k8slabels.Merge(labels, memberlist.GossipSelector)),
+					Annotations: manifestutils.PodAnnotations(cfg.PodAnnotations, annotations),
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: tempo.Spec.ServiceAccount,
-					NodeSelector:       cfg.NodeSelector,
-					Tolerations:        cfg.Tolerations,
+					ServiceAccountName:        tempo.Spec.ServiceAccount,
+					NodeSelector:              cfg.NodeSelector,
+					Tolerations:               cfg.Tolerations,
+					Affinity:                  cfg.Affinity.DeepCopy(),
+					TopologySpreadConstraints: manifestutils.TopologySpreadConstraints(cfg.TopologySpreadConstraints, labels),
+					PriorityClassName:         cfg.PriorityClassName,
 					Containers: []corev1.Container{
 						{
 							Name:  "tempo",
@@ -75,17 +93,17 @@ func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 							Args: []string{
 								"-target=compactor",
 								"-config.file=/conf/tempo.yaml",
-								"-log.level=info",
+								manifestutils.LogLevelArg(tempo, cfg.TempoComponentSpec),
 							},
 							Ports: []corev1.ContainerPort{
 								{
 									Name:          manifestutils.HttpPortName,
-									ContainerPort: manifestutils.PortHTTPServer,
+									ContainerPort: httpPort,
 									Protocol:      corev1.ProtocolTCP,
 								},
 								{
 									Name:          manifestutils.HttpMemberlistPortName,
-									ContainerPort: manifestutils.PortMemberlist,
+									ContainerPort: manifestutils.MemberlistPort(tempo),
 									Protocol:      corev1.ProtocolTCP,
 								},
 							},
@@ -128,6 +146,10 @@ func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 		},
 	}
 
+	if cfg.VolumeClaimTemplate != nil {
+		configureVolumeClaimTemplate(tempo, cfg.VolumeClaimTemplate, &d.Spec.Template.Spec)
+	}
+
 	err := manifestutils.ConfigureStorage(tempo, &d.Spec.Template.Spec)
 	if err != nil {
 		return nil, err
@@ -135,13 +157,34 @@ func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 	return d, nil
 }
 
+// configureVolumeClaimTemplate replaces the emptyDir tmp volume with a generic ephemeral volume,
+// which is provisioned per pod using the volume claim template of the compactor.
+func configureVolumeClaimTemplate(tempo v1alpha1.TempoStack, tpl *v1alpha1.VolumeClaimTemplateSpec, pod *corev1.PodSpec) {
+	for i := range pod.Volumes {
+		if pod.Volumes[i].Name != manifestutils.TmpStorageVolumeName {
+			continue
+		}
+		pod.Volumes[i].VolumeSource = corev1.VolumeSource{
+			Ephemeral: &corev1.EphemeralVolumeSource{
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: tpl.Annotations,
+					},
+					Spec: manifestutils.PersistentVolumeClaimSpec(tempo, tpl),
+				},
+			},
+		}
+	}
+}
+
 func service(tempo v1alpha1.TempoStack) *corev1.Service {
 	labels := manifestutils.ComponentLabels(manifestutils.CompactorComponentName, tempo.Name)
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      naming.Name(manifestutils.CompactorComponentName, tempo.Name),
-			Namespace: tempo.Namespace,
-			Labels:    labels,
+			Name:        naming.Name(manifestutils.CompactorComponentName, tempo.Name),
+			Namespace:   tempo.Namespace,
+			Labels:      manifestutils.ServiceLabels(tempo.Spec.Template.Compactor.ServiceLabels, labels),
+			Annotations: manifestutils.ServiceAnnotations(tempo.Spec.Template.Compactor.ServiceAnnotations, nil),
 		},
 		Spec: corev1.ServiceSpec{
 			Ports: []corev1.ServicePort{
@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
@@ -29,11 +30,13 @@ func TestBuildCompactor(t *testing.T) {
 			},
 			ServiceAccount: "tempo-test-serviceaccount",
 			Template: v1alpha1.TempoTemplateSpec{
-				Compactor: v1alpha1.TempoComponentSpec{
-					NodeSelector: map[string]string{"a": "b"},
-					Tolerations: []corev1.Toleration{
-						{
-							Key: "c",
+				Compactor: v1alpha1.TempoCompactorSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						NodeSelector: map[string]string{"a": "b"},
+						Tolerations: []corev1.Toleration{
+							{
+								Key: "c",
+							},
 						},
 					},
 				},
@@ -51,7 +54,7 @@ func TestBuildCompactor(t *testing.T) {
 	require.NoError(t, err)
 
 	labels := manifestutils.ComponentLabels("compactor", "test")
-	annotations := manifestutils.CommonAnnotations("")
+	annotations := manifestutils.CommonAnnotations("", "")
 	assert.Equal(t, 2, len(objects))
 
 	assert.Equal(t, &corev1.Service{
@@ -184,3 +187,76 @@ func TestBuildCompactor(t *testing.T) {
 		},
 	}, objects[0])
 }
+
+func TestBuildCompactorVolumeClaimTemplate(t *testing.T) {
+	storageClassName := "fast"
+	size := resource.MustParse("20Gi")
+	objects, err := BuildCompactor(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			StorageSize: resource.MustParse("10Gi"),
+			Template: v1alpha1.TempoTemplateSpec{
+				Compactor: v1alpha1.TempoCompactorSpec{
+					VolumeClaimTemplate: &v1alpha1.VolumeClaimTemplateSpec{
+						StorageClassName: &storageClassName,
+						Size:             &size,
+						Annotations:      map[string]string{"a": "b"},
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	filesystem := corev1.PersistentVolumeFilesystem
+	d := objects[0].(*v1.Deployment)
+	assert.Contains(t, d.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: manifestutils.TmpStorageVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Ephemeral: &corev1.EphemeralVolumeSource{
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{"a": "b"},
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: size,
+							},
+						},
+						StorageClassName: &storageClassName,
+						VolumeMode:       &filesystem,
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestBuildCompactor_Replicas(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+	}
+	objects, err := BuildCompactor(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	assert.Nil(t, objects[0].(*v1.Deployment).Spec.Replicas)
+
+	maxUnavailable := intstr.FromString("50%")
+	tempo.Spec.Template.Compactor = v1alpha1.TempoCompactorSpec{
+		TempoComponentSpec:  v1alpha1.TempoComponentSpec{Replicas: pointer.Int32(3)},
+		PodDisruptionBudget: &v1alpha1.PodDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable},
+	}
+	objects, err = BuildCompactor(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+	require.Len(t, objects, 3)
+	assert.Equal(t, pointer.Int32(3), objects[0].(*v1.Deployment).Spec.Replicas)
+	assert.Equal(t, manifestutils.PodDisruptionBudget(tempo, manifestutils.CompactorComponentName, tempo.Spec.Template.Compactor.PodDisruptionBudget), objects[2])
+}
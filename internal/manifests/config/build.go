@@ -3,19 +3,25 @@ package config
 import (
 	"bytes"
 	"embed"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"io"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
+	"github.com/grafana/tempo-operator/internal/tlsprofile"
 )
 
 var (
 	//go:embed tempo-config.yaml
 	tempoConfigYAMLTmplFile embed.FS
-	tempoConfigYAMLTmpl     = template.Must(template.ParseFS(tempoConfigYAMLTmplFile, "tempo-config.yaml"))
+	tempoConfigYAMLTmpl     = newTempoConfigTemplate()
 
 	//go:embed tempo-overrides.yaml
 	tempoTenantsOverridesYAMLTmplFile embed.FS
@@ -26,6 +32,49 @@ var (
 	tempoQueryYAMLTmpl     = template.Must(template.ParseFS(tempoQueryYAMLTmplFile, "tempo-query.yaml"))
 )
 
+// yamlString renders a user-provided string as a double-quoted YAML scalar.
+// The result bypasses the HTML escaping of the template, and cannot break out of the YAML value.
+func yamlString(s string) template.HTML {
+	// A JSON string is a valid double-quoted YAML scalar.
+	quoted, _ := json.Marshal(s)
+	return template.HTML(quoted) // #nosec G203 -- the string is escaped as a YAML scalar
+}
+
+// newTempoConfigTemplate parses the template of the Tempo configuration.
+// The include function renders a named template to a string, which allows to reuse
+// a block at different indentation levels, e.g. {{ include "kvstore" .KVStore | nindent 4 }}.
+func newTempoConfigTemplate() *template.Template {
+	tmpl := template.New("tempo-config.yaml")
+	tmpl.Funcs(template.FuncMap{
+		"yamlString": yamlString,
+		"nindent":    nindent,
+		"include": func(name string, data interface{}) (template.HTML, error) {
+			var buf bytes.Buffer
+			if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+				return "", err
+			}
+			// The included template is escaped already.
+			return template.HTML(buf.String()), nil // #nosec G203
+		},
+	})
+	return template.Must(tmpl.ParseFS(tempoConfigYAMLTmplFile, "tempo-config.yaml"))
+}
+
+// nindent starts a new line and indents every line of the rendered template by the given number of spaces.
+func nindent(spaces int, s template.HTML) template.HTML {
+	pad := strings.Repeat(" ", spaces)
+	return template.HTML("\n" + pad + strings.ReplaceAll(string(s), "\n", "\n"+pad)) // #nosec G203
+}
+
+// defaultHTTPServerTimeout is the read and write timeout of the HTTP servers of the Tempo components.
+const defaultHTTPServerTimeout = 3 * time.Minute
+
+const (
+	mebibyte = 1024 * 1024
+	// defaultGRPCMaxMsgSize is the maximum size of a message received or sent by the gRPC servers of the Tempo components.
+	defaultGRPCMaxMsgSize = 4 * mebibyte
+)
+
 func fromRateLimitSpecToRateLimitOptions(spec v1alpha1.RateLimitSpec) rateLimitsOptions {
 	return rateLimitsOptions{
 		IngestionRateLimitBytes: spec.Ingestion.IngestionRateLimitBytes,
@@ -65,16 +114,36 @@ func buildConfiguration(params manifestutils.Params) ([]byte, error) {
 		}
 	}
 
+	receiverTLS := receiverTLSOptions{}
+	if tempo.Spec.Template.Distributor.TLS.Enabled && !tempo.Spec.Template.Gateway.Enabled {
+		receiverTLS, err = buildReceiverTLSConfig(params)
+		if err != nil {
+			return []byte{}, err
+		}
+	}
+
+	httpPort, grpcPort := manifestutils.ServerPorts(tempo)
+	otlpGRPCPort, otlpHTTPPort := manifestutils.OTLPReceiverPorts(tempo)
+
+	cache := buildCacheOptions(tempo, params.TLSProfile)
 	opts := options{
-		StorageType:     string(tempo.Spec.Storage.Secret.Type),
-		StorageParams:   params.StorageParams,
-		GlobalRetention: tempo.Spec.Retention.Global.Traces.Duration.String(),
-		MemberList: []string{
-			naming.Name("gossip-ring", tempo.Name),
-		},
-		QueryFrontendDiscovery: fmt.Sprintf("%s:%d", naming.Name("query-frontend-discovery", tempo.Name), manifestutils.PortGRPCServer),
+		StorageType:            string(tempo.Spec.Storage.Secret.Type),
+		StorageParams:          params.StorageParams,
+		StorageHedging:         fromHedgingSpecToOptions(tempo.Spec.Storage.Hedging),
+		BlockFormat:            string(tempo.Spec.Storage.BlockFormat),
+		Cache:                  cache,
+		ResultsCache:           buildResultsCacheOptions(tempo, cache),
+		GlobalRetention:        tempo.Spec.Retention.Global.Traces.Duration.String(),
+		Compaction:             buildCompactionOptions(tempo.Spec.Template.Compactor),
+		Ingester:               buildIngesterOptions(tempo.Spec.Template.Ingester),
+		Memberlist:             buildMemberlistOptions(tempo, params.TLSProfile),
+		KVStore:                buildKVStoreOptions(tempo, params.TLSProfile),
+		QueryFrontendDiscovery: fmt.Sprintf("%s:%d", naming.Name("query-frontend-discovery", tempo.Name), grpcPort),
+		QueryFrontendRetries:   tempo.Spec.Storage.MaxRetries,
 		GlobalRateLimits:       fromRateLimitSpecToRateLimitOptions(tempo.Spec.LimitSpec.Global),
-		Search:                 fromSearchSpecToOptions(tempo.Spec.SearchSpec),
+		Search:                 fromSearchSpecToOptions(tempo.Spec.SearchSpec, tempo.Spec.Template.QueryFrontend.Search),
+		HTTPServerTimeout:      httpServerTimeout(tempo.Spec.SearchSpec.QueryTimeout.Duration),
+		LogFormat:              logFormat(tempo.Spec.Observability.Logging.Format),
 		ReplicationFactor:      tempo.Spec.ReplicationFactor,
 		Multitenancy:           tempo.Spec.Tenants != nil,
 		Gateway:                tempo.Spec.Template.Gateway.Enabled,
@@ -82,26 +151,445 @@ func buildConfiguration(params manifestutils.Params) ([]byte, error) {
 			GRPCEncryption: params.Gates.GRPCEncryption,
 			HTTPEncryption: params.Gates.HTTPEncryption,
 		},
-		TLS: tlsopts,
+		TLS:           tlsopts,
+		ReceiverTLS:   receiverTLS,
+		KafkaReceiver: buildKafkaReceiverOptions(tempo.Spec.Template.Distributor.Receivers.Kafka),
+		OTLPReceiver: otlpReceiverOptions{
+			GRPCPort: otlpGRPCPort,
+			HTTPPort: otlpHTTPPort,
+			GRPC:     buildOTLPGRPCOptions(tempo),
+		},
+		LogReceivedSpans: buildLogReceivedSpansOptions(tempo),
+		MetricsGenerator: buildMetricsGeneratorOptions(tempo),
+		ServerPorts: serverPortsOptions{
+			HTTP: httpPort,
+			GRPC: grpcPort,
+		},
+		GRPCServer: buildGRPCServerOptions(tempo.Spec.GRPCServer),
+	}
+
+	if enabled, port := manifestutils.ZipkinReceiver(tempo); enabled {
+		opts.ZipkinReceiver = &zipkinReceiverOptions{Port: port}
 	}
+	opts.JaegerReceiver = buildJaegerReceiverOptions(tempo)
 
-	if isTenantOverridesConfigRequired(tempo.Spec.LimitSpec) {
+	if tempo.Spec.LimitSpec.PerTenantRuntimeOverridesConfigMap != "" {
+		opts.TenantRateLimitsPath = manifestutils.RuntimeOverridesFile()
+	} else if isTenantOverridesConfigRequired(tempo) {
 		opts.TenantRateLimitsPath = tenantOverridesMountPath
 	}
 
-	return renderTemplate(opts)
+	cfg, err := renderTemplate(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if tempo.Spec.ExtraConfig != nil && len(tempo.Spec.ExtraConfig.Tempo.Raw) > 0 {
+		return mergeExtraConfig(cfg, tempo.Spec.ExtraConfig.Tempo.Raw)
+	}
+	return cfg, nil
+}
+
+func buildMemberlistOptions(tempo v1alpha1.TempoStack, tlsProfile tlsprofile.TLSProfileOptions) memberlistOptions {
+	gossipRing := naming.Name("gossip-ring", tempo.Name)
+	spec := tempo.Spec.Memberlist
+	if spec == nil {
+		return memberlistOptions{JoinMembers: []string{gossipRing}}
+	}
+
+	clusterDomain := "cluster.local"
+	if spec.ClusterDomain != "" {
+		clusterDomain = spec.ClusterDomain
+		gossipRing = fmt.Sprintf("%s.%s.svc.%s", gossipRing, tempo.Namespace, clusterDomain)
+	}
+	opts := memberlistOptions{
+		JoinMembers: []string{gossipRing},
+		BindPort:    spec.Port,
+	}
+	if spec.GossipInterval.Duration > 0 {
+		opts.GossipInterval = spec.GossipInterval.Duration.String()
+	}
+	if spec.InstanceAddrType == v1alpha1.MemberlistInstanceAddrPodIP {
+		opts.InstanceAddr = fmt.Sprintf("${%s}", manifestutils.PodIPEnv)
+	}
+	if spec.TLS != nil {
+		opts.TLS = &memberlistTLSOptions{
+			CertFile:      fmt.Sprintf("%s/tls.crt", manifestutils.MemberlistTLSDir()),
+			KeyFile:       fmt.Sprintf("%s/tls.key", manifestutils.MemberlistTLSDir()),
+			ServerName:    spec.TLS.ServerName,
+			MinTLSVersion: tlsProfile.MinTLSVersion,
+			Ciphers:       tlsProfile.TLSCipherSuites(),
+		}
+		if spec.TLS.CA != "" {
+			opts.TLS.CAFile = fmt.Sprintf("%s/service-ca.crt", manifestutils.MemberlistCABundleDir())
+		}
+		if opts.TLS.ServerName == "" {
+			opts.TLS.ServerName = fmt.Sprintf("%s.%s.svc.%s", naming.Name("gossip-ring", tempo.Name), tempo.Namespace, clusterDomain)
+		}
+	}
+	return opts
+}
+
+func buildKVStoreOptions(tempo v1alpha1.TempoStack, tlsProfile tlsprofile.TLSProfileOptions) kvStoreOptions {
+	store := manifestutils.KVStore(tempo)
+	if store == v1alpha1.KVStoreMemberlist {
+		return kvStoreOptions{Store: string(store)}
+	}
+
+	// The etcd cluster or Consul can be shared by several TempoStacks.
+	opts := kvStoreOptions{
+		Store:  string(store),
+		Prefix: fmt.Sprintf("tempo/%s/%s/", tempo.Namespace, tempo.Name),
+	}
+	if etcd := tempo.Spec.KVStore.Etcd; store == v1alpha1.KVStoreEtcd && etcd != nil {
+		opts.Etcd = &etcdOptions{Endpoints: etcd.Endpoints}
+		if etcd.AuthSecret != "" {
+			opts.Etcd.Username = fmt.Sprintf("${%s}", manifestutils.EtcdUsernameEnv)
+			opts.Etcd.Password = fmt.Sprintf("${%s}", manifestutils.EtcdPasswordEnv)
+		}
+		if etcd.TLS != nil {
+			opts.Etcd.TLS = &etcdTLSOptions{
+				ServerName:    etcd.TLS.ServerName,
+				MinTLSVersion: tlsProfile.MinTLSVersion,
+				Ciphers:       tlsProfile.TLSCipherSuites(),
+			}
+			if etcd.TLS.CA != "" {
+				opts.Etcd.TLS.CAFile = fmt.Sprintf("%s/service-ca.crt", manifestutils.EtcdCABundleDir())
+			}
+			if etcd.TLS.CertName != "" {
+				opts.Etcd.TLS.CertFile = fmt.Sprintf("%s/tls.crt", manifestutils.EtcdTLSDir())
+				opts.Etcd.TLS.KeyFile = fmt.Sprintf("%s/tls.key", manifestutils.EtcdTLSDir())
+			}
+		}
+	}
+	if consul := tempo.Spec.KVStore.Consul; store == v1alpha1.KVStoreConsul && consul != nil {
+		opts.Consul = &consulOptions{Host: consul.Host}
+		if consul.AuthSecret != "" {
+			opts.Consul.ACLToken = fmt.Sprintf("${%s}", manifestutils.ConsulACLTokenEnv)
+		}
+	}
+	return opts
+}
+
+// buildResultsCacheOptions returns the results cache of the query-frontend, which uses the cache of the TempoStack.
+func buildResultsCacheOptions(tempo v1alpha1.TempoStack, cache *cacheOptions) *cacheOptions {
+	spec := tempo.Spec.Template.QueryFrontend.ResultsCache
+	if spec == nil || !spec.Enabled || cache == nil {
+		return nil
+	}
+
+	resultsCache := *cache
+	if spec.TTL.Duration > 0 {
+		resultsCache.Expiration = spec.TTL.Duration.String()
+	}
+	return &resultsCache
+}
+
+func buildCacheOptions(tempo v1alpha1.TempoStack, tlsProfile tlsprofile.TLSProfileOptions) *cacheOptions {
+	if tempo.Spec.Template.Memcached.Enabled {
+		return &cacheOptions{
+			Backend: string(v1alpha1.CacheBackendMemcached),
+			Host:    naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.MemcachedComponentName),
+			Service: manifestutils.MemcachedPortName,
+		}
+	}
+
+	spec := tempo.Spec.Cache
+	if spec == nil {
+		return nil
+	}
+
+	opts := &cacheOptions{
+		Backend:   string(spec.Backend),
+		Endpoints: strings.Join(spec.Endpoints, ","),
+	}
+	if spec.Timeout.Duration > 0 {
+		opts.Timeout = spec.Timeout.Duration.String()
+	}
+	if spec.AuthSecret != "" {
+		opts.Password = fmt.Sprintf("${%s}", manifestutils.CachePasswordEnv)
+	}
+	if spec.TLS != nil {
+		opts.TLS = &cacheTLSOptions{
+			ServerName:         spec.TLS.ServerName,
+			InsecureSkipVerify: spec.TLS.InsecureSkipVerify,
+			MinTLSVersion:      tlsProfile.MinTLSVersion,
+			Ciphers:            tlsProfile.TLSCipherSuites(),
+		}
+		if spec.TLS.CA != "" {
+			opts.TLS.CAFile = fmt.Sprintf("%s/service-ca.crt", manifestutils.CacheCABundleDir())
+		}
+		if spec.TLS.CertName != "" {
+			opts.TLS.CertFile = fmt.Sprintf("%s/tls.crt", manifestutils.CacheTLSDir())
+			opts.TLS.KeyFile = fmt.Sprintf("%s/tls.key", manifestutils.CacheTLSDir())
+		}
+	}
+	return opts
+}
+
+func buildCompactionOptions(spec v1alpha1.TempoCompactorSpec) compactionOptions {
+	opts := compactionOptions{
+		MaxBlockBytes:        spec.MaxBlockBytes,
+		RetentionConcurrency: spec.RetentionConcurrency,
+	}
+	if spec.CompactionWindow.Duration > 0 {
+		opts.CompactionWindow = spec.CompactionWindow.Duration.String()
+	}
+	if spec.CompactionCycle.Duration > 0 {
+		opts.CompactionCycle = spec.CompactionCycle.Duration.String()
+	}
+	return opts
+}
+
+func buildIngesterOptions(spec v1alpha1.TempoIngesterSpec) ingesterOptions {
+	opts := ingesterOptions{
+		MaxBlockDuration:     "10m",
+		MaxBlockBytes:        spec.MaxBlockBytes,
+		ConcurrentFlushes:    spec.ConcurrentFlushes,
+		ZoneAwareReplication: spec.ZoneAwareReplication != nil,
+	}
+	if spec.TraceIdlePeriod.Duration > 0 {
+		opts.TraceIdlePeriod = spec.TraceIdlePeriod.Duration.String()
+	}
+	if spec.MaxBlockDuration.Duration > 0 {
+		opts.MaxBlockDuration = spec.MaxBlockDuration.Duration.String()
+	}
+	if spec.CompleteBlockTimeout.Duration > 0 {
+		opts.CompleteBlockTimeout = spec.CompleteBlockTimeout.Duration.String()
+	}
+	if spec.FlushCheckPeriod.Duration > 0 {
+		opts.FlushCheckPeriod = spec.FlushCheckPeriod.Duration.String()
+	}
+	return opts
+}
+
+func buildMetricsGeneratorOptions(tempo v1alpha1.TempoStack) *metricsGeneratorOptions {
+	generator := tempo.Spec.Template.MetricsGenerator
+	if !generator.Enabled {
+		return nil
+	}
+
+	opts := &metricsGeneratorOptions{}
+	for _, processor := range generator.Processors {
+		opts.Processors = append(opts.Processors, string(processor))
+	}
+	for i, remoteWrite := range generator.RemoteWrite {
+		opts.RemoteWrite = append(opts.RemoteWrite, buildRemoteWriteOptions(i, remoteWrite))
+	}
+	return opts
+}
+
+func buildRemoteWriteOptions(index int, spec v1alpha1.RemoteWriteSpec) remoteWriteOptions {
+	if spec.OpenShiftMonitoring {
+		return remoteWriteOptions{
+			URL:             manifestutils.OpenShiftMonitoringRemoteWriteURL,
+			Headers:         spec.Headers,
+			BearerTokenFile: manifestutils.BearerTokenFile,
+			TLS: &remoteWriteTLSOptions{
+				CAFile: manifestutils.ServiceAccountServiceCAFile,
+			},
+		}
+	}
+
+	opts := remoteWriteOptions{
+		URL:     spec.URL,
+		Headers: spec.Headers,
+	}
+	if spec.Auth != nil {
+		switch spec.Auth.Type {
+		case v1alpha1.RemoteWriteAuthTypeBasic:
+			opts.BasicAuth = &remoteWriteBasicAuthOptions{
+				Username: fmt.Sprintf("${%s}", manifestutils.RemoteWriteCredentialEnv(index, "username")),
+				Password: fmt.Sprintf("${%s}", manifestutils.RemoteWriteCredentialEnv(index, "password")),
+			}
+		case v1alpha1.RemoteWriteAuthTypeBearer:
+			opts.BearerToken = fmt.Sprintf("${%s}", manifestutils.RemoteWriteCredentialEnv(index, "token"))
+		}
+	}
+	if spec.TLS != nil {
+		opts.TLS = &remoteWriteTLSOptions{
+			InsecureSkipVerify: spec.TLS.InsecureSkipVerify,
+		}
+		if spec.TLS.CA != "" {
+			opts.TLS.CAFile = fmt.Sprintf("%s/service-ca.crt", manifestutils.RemoteWriteCABundleDir(index))
+		}
+		if spec.TLS.CertName != "" {
+			opts.TLS.CertFile = fmt.Sprintf("%s/tls.crt", manifestutils.RemoteWriteTLSDir(index))
+			opts.TLS.KeyFile = fmt.Sprintf("%s/tls.key", manifestutils.RemoteWriteTLSDir(index))
+		}
+	}
+	return opts
+}
+
+func buildLogReceivedSpansOptions(tempo v1alpha1.TempoStack) *logReceivedSpansOptions {
+	logReceivedSpans := tempo.Spec.Template.Distributor.LogReceivedSpans
+	if logReceivedSpans == nil || !logReceivedSpans.Enabled {
+		return nil
+	}
+
+	return &logReceivedSpansOptions{
+		IncludeAllAttributes: logReceivedSpans.IncludeAllAttributes,
+		FilterByStatusError:  logReceivedSpans.FilterByStatusError,
+	}
 }
 
-func isTenantOverridesConfigRequired(limitSpec v1alpha1.LimitSpec) bool {
-	return len(limitSpec.PerTenant) > 0
+func buildOTLPGRPCOptions(tempo v1alpha1.TempoStack) otlpGRPCOptions {
+	otlp := tempo.Spec.Template.Distributor.Receivers.OTLP
+	if otlp == nil {
+		return otlpGRPCOptions{}
+	}
+
+	return otlpGRPCOptions{
+		MaxRecvMsgSizeMiB:    otlp.GRPC.MaxRecvMsgSizeMiB,
+		MaxConcurrentStreams: otlp.GRPC.MaxConcurrentStreams,
+		ReadBufferSize:       otlp.GRPC.ReadBufferSize,
+		WriteBufferSize:      otlp.GRPC.WriteBufferSize,
+	}
+}
+
+func buildGRPCServerOptions(spec *v1alpha1.GRPCServerSpec) grpcServerOptions {
+	opts := grpcServerOptions{
+		MaxRecvMsgSize: defaultGRPCMaxMsgSize,
+		MaxSendMsgSize: defaultGRPCMaxMsgSize,
+	}
+	if spec == nil {
+		return opts
+	}
+
+	if spec.MaxRecvMsgSizeMiB != nil {
+		opts.MaxRecvMsgSize = *spec.MaxRecvMsgSizeMiB * mebibyte
+		opts.ClientMsgSizes = true
+	}
+	if spec.MaxSendMsgSizeMiB != nil {
+		opts.MaxSendMsgSize = *spec.MaxSendMsgSizeMiB * mebibyte
+		opts.ClientMsgSizes = true
+	}
+	durations := []struct {
+		value  *metav1.Duration
+		option *string
+	}{
+		{spec.KeepaliveTime, &opts.KeepaliveTime},
+		{spec.KeepaliveTimeout, &opts.KeepaliveTimeout},
+		{spec.MaxConnectionAge, &opts.MaxConnectionAge},
+		{spec.MaxConnectionAgeGrace, &opts.MaxConnectionAgeGrace},
+	}
+	for _, d := range durations {
+		if d.value != nil {
+			*d.option = d.value.Duration.String()
+		}
+	}
+	return opts
+}
+
+func buildJaegerReceiverOptions(tempo v1alpha1.TempoStack) *jaegerReceiverOptions {
+	protocols := manifestutils.JaegerReceiverProtocols(tempo)
+	if len(protocols) == 0 {
+		return nil
+	}
+
+	opts := &jaegerReceiverOptions{}
+	for _, protocol := range protocols {
+		switch protocol.Name {
+		case "thrift_http":
+			opts.ThriftHTTP = protocol.Port
+		case "thrift_binary":
+			opts.ThriftBinary = protocol.Port
+		case "thrift_compact":
+			opts.ThriftCompact = protocol.Port
+		case "grpc":
+			opts.GRPC = protocol.Port
+		}
+	}
+	return opts
+}
+
+func buildKafkaReceiverOptions(spec *v1alpha1.KafkaReceiverSpec) *kafkaReceiverOptions {
+	if spec == nil {
+		return nil
+	}
+
+	opts := &kafkaReceiverOptions{
+		Brokers:         spec.Brokers,
+		Topic:           spec.Topic,
+		Encoding:        string(spec.Encoding),
+		GroupID:         spec.GroupID,
+		ProtocolVersion: spec.ProtocolVersion,
+	}
+	if spec.SASL != nil {
+		opts.SASLMechanism = string(spec.SASL.Mechanism)
+	}
+	if spec.TLS != nil {
+		opts.TLS = &kafkaTLSOptions{
+			InsecureSkipVerify: spec.TLS.InsecureSkipVerify,
+		}
+		if spec.TLS.CA != "" {
+			opts.TLS.CAFile = fmt.Sprintf("%s/service-ca.crt", manifestutils.KafkaCABundleDir())
+		}
+	}
+	return opts
+}
+
+func isTenantOverridesConfigRequired(tempo v1alpha1.TempoStack) bool {
+	if len(tempo.Spec.LimitSpec.PerTenant) > 0 || len(tempo.Spec.Retention.PerTenant) > 0 {
+		return true
+	}
+	if tempo.Spec.Tenants == nil {
+		return false
+	}
+	for _, tenant := range tempo.Spec.Tenants.Authentication {
+		if tenant.Limits != nil || tenant.Retention != nil {
+			return true
+		}
+	}
+	return false
 }
 
 func buildTenantOverrides(tempo v1alpha1.TempoStack) ([]byte, error) {
+	overrides := fromRateLimitSpecToRateLimitOptionsMap(tempo.Spec.LimitSpec.PerTenant)
+	setBlockRetention := func(tenant string, retention v1alpha1.RetentionConfig) {
+		override, ok := overrides[tenant]
+		if !ok {
+			override = fromRateLimitSpecToRateLimitOptions(v1alpha1.RateLimitSpec{})
+		}
+		override.BlockRetention = retention.Traces.Duration.String()
+		overrides[tenant] = override
+	}
+
+	var tenants []v1alpha1.AuthenticationSpec
+	if tempo.Spec.Tenants != nil {
+		tenants = tempo.Spec.Tenants.Authentication
+	}
+
+	// The limits and retention of a tenant in spec.tenants.authentication take precedence
+	// over spec.limits.perTenant and spec.retention.perTenant.
+	for _, tenant := range tenants {
+		if tenant.Limits != nil {
+			overrides[tenantOverridesKey(tempo, tenant)] = fromRateLimitSpecToRateLimitOptions(*tenant.Limits)
+		}
+	}
+	for tenant, retention := range tempo.Spec.Retention.PerTenant {
+		setBlockRetention(tenant, retention)
+	}
+	for _, tenant := range tenants {
+		if tenant.Retention != nil {
+			setBlockRetention(tenantOverridesKey(tempo, tenant), *tenant.Retention)
+		}
+	}
+
 	return renderTenantOverridesTemplate(tenantOptions{
-		RateLimits: fromRateLimitSpecToRateLimitOptionsMap(tempo.Spec.LimitSpec.PerTenant),
+		RateLimits: overrides,
 	})
 }
 
+// tenantOverridesKey returns the ID of a tenant in the X-Scope-OrgID header received by Tempo.
+// The gateway sends the ID of the tenant, without the gateway the clients send the name of the tenant.
+func tenantOverridesKey(tempo v1alpha1.TempoStack, tenant v1alpha1.AuthenticationSpec) string {
+	if tempo.Spec.Template.Gateway.Enabled {
+		return tenant.TenantID
+	}
+	return tenant.TenantName
+}
+
 func buildTLSConfig(params manifestutils.Params) (tlsOptions, error) {
 	tempo := params.Tempo
 	minTLSShort, err := params.TLSProfile.MinVersionShort()
@@ -115,27 +603,97 @@ func buildTLSConfig(params manifestutils.Params) (tlsOptions, error) {
 			Certificate: fmt.Sprintf("%s/tls.crt", manifestutils.TempoServerTLSDir()),
 		},
 		ServerNames: serverNames{
-			QueryFrontend: naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.QueryFrontendComponentName),
-			Ingester:      naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.IngesterComponentName),
+			QueryFrontend:    naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.QueryFrontendComponentName),
+			Ingester:         naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.IngesterComponentName),
+			MetricsGenerator: naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.MetricsGeneratorComponentName),
 		},
 		Profile: tlsProfileOptions{
 			MinTLSVersion:      params.TLSProfile.MinTLSVersion,
 			Ciphers:            params.TLSProfile.TLSCipherSuites(),
 			MinTLSVersionShort: minTLSShort,
 		},
+		// Certificates issued by the OpenShift service-ca operator cannot be used for client authentication.
+		ClientAuth: !params.Gates.OpenShift.ServingCertsAllServices,
 	}, nil
 
 }
 
+func buildReceiverTLSConfig(params manifestutils.Params) (receiverTLSOptions, error) {
+	receiversTLS := params.Tempo.Spec.Template.Distributor.TLS
+	minTLSVersion := params.TLSProfile
+	if receiversTLS.MinTLSVersion != "" {
+		minTLSVersion = tlsprofile.TLSProfileOptions{MinTLSVersion: receiversTLS.MinTLSVersion}
+	}
+	minTLSShort, err := minTLSVersion.MinVersionShort()
+	if err != nil {
+		return receiverTLSOptions{}, err
+	}
+	settings := receiverTLSSettings{
+		Paths: tlsFilePaths{
+			Key:         fmt.Sprintf("%s/tls.key", manifestutils.ReceiverTLSDir()),
+			Certificate: fmt.Sprintf("%s/tls.crt", manifestutils.ReceiverTLSDir()),
+		},
+		MinTLSVersionShort: minTLSShort,
+	}
+	if receiversTLS.CA != "" {
+		settings.Paths.CA = fmt.Sprintf("%s/service-ca.crt", manifestutils.ReceiverCABundleDir())
+	}
+
+	opts := receiverTLSOptions{
+		Enabled:  true,
+		OTLPGRPC: settings,
+		OTLPHTTP: settings,
+		Jaeger:   settings,
+		Zipkin:   settings,
+	}
+	for _, override := range manifestutils.ReceiverTLSOverrides(receiversTLS) {
+		overridden, err := overrideReceiverTLSSettings(settings, override)
+		if err != nil {
+			return receiverTLSOptions{}, err
+		}
+
+		switch override.Receiver {
+		case "otlp-grpc":
+			opts.OTLPGRPC = overridden
+		case "otlp-http":
+			opts.OTLPHTTP = overridden
+		case "jaeger":
+			opts.Jaeger = overridden
+		case "zipkin":
+			opts.Zipkin = overridden
+		}
+	}
+	return opts, nil
+}
+
+func overrideReceiverTLSSettings(settings receiverTLSSettings, override manifestutils.ReceiverTLSOverride) (receiverTLSSettings, error) {
+	if override.Spec.CertName != "" {
+		settings.Paths.Key = fmt.Sprintf("%s/tls.key", manifestutils.ReceiverOverrideTLSDir(override.Receiver))
+		settings.Paths.Certificate = fmt.Sprintf("%s/tls.crt", manifestutils.ReceiverOverrideTLSDir(override.Receiver))
+	}
+	if override.Spec.CA != "" {
+		settings.Paths.CA = fmt.Sprintf("%s/service-ca.crt", manifestutils.ReceiverOverrideCABundleDir(override.Receiver))
+	}
+	if override.Spec.MinTLSVersion != "" {
+		minTLSShort, err := tlsprofile.TLSProfileOptions{MinTLSVersion: override.Spec.MinTLSVersion}.MinVersionShort()
+		if err != nil {
+			return receiverTLSSettings{}, err
+		}
+		settings.MinTLSVersionShort = minTLSShort
+	}
+	return settings, nil
+}
+
 func buildTempoQueryConfig(params manifestutils.Params) ([]byte, error) {
 	tlsopts, err := buildTLSConfig(params)
 	if err != nil {
 		return []byte{}, err
 	}
 
+	httpPort, _ := manifestutils.ServerPorts(params.Tempo)
 	return renderTempoQueryTemplate(tempoQueryOptions{
 		TLS:      tlsopts,
-		HTTPPort: manifestutils.PortHTTPServer,
+		HTTPPort: httpPort,
 		Gates: featureGates{
 			GRPCEncryption: params.Gates.GRPCEncryption,
 			HTTPEncryption: params.Gates.HTTPEncryption,
@@ -175,7 +733,7 @@ func renderTenantOverridesTemplate(opts tenantOptions) ([]byte, error) {
 	return cfg, nil
 }
 
-func fromSearchSpecToOptions(spec v1alpha1.SearchSpec) searchOptions {
+func fromSearchSpecToOptions(spec v1alpha1.SearchSpec, frontend *v1alpha1.QueryFrontendSearchSpec) searchOptions {
 
 	options := searchOptions{
 		// Those are recommended defaults taken from: https://grafana.com/docs/tempo/latest/operations/backend_search/
@@ -193,9 +751,59 @@ func fromSearchSpecToOptions(spec v1alpha1.SearchSpec) searchOptions {
 		options.DefaultResultLimit = *spec.DefaultResultLimit
 	}
 
+	if spec.QueryTimeout.Duration > 0 {
+		options.QueryTimeout = spec.QueryTimeout.Duration.String()
+	}
+
+	if frontend != nil {
+		if frontend.ConcurrentJobs != nil {
+			options.ConcurrentJobs = *frontend.ConcurrentJobs
+		}
+		if frontend.TargetBytesPerJob != nil {
+			options.TargetBytesPerJob = *frontend.TargetBytesPerJob
+		}
+		if frontend.MaxDuration.Duration > 0 {
+			options.MaxDuration = frontend.MaxDuration.Duration.String()
+		}
+		if frontend.QueryBackendAfter.Duration > 0 {
+			options.QueryBackendAfter = frontend.QueryBackendAfter.Duration.String()
+		}
+		if frontend.QueryIngestersUntil.Duration > 0 {
+			options.QueryIngestersUntil = frontend.QueryIngestersUntil.Duration.String()
+		}
+	}
+
 	return options
 }
 
+// logFormat returns the log format of the Tempo components.
+func logFormat(format v1alpha1.LogFormat) string {
+	if format == "" {
+		return string(v1alpha1.LogFormatLogfmt)
+	}
+	return string(format)
+}
+
+// httpServerTimeout returns the read and write timeout of the HTTP servers, or an empty string if the default applies.
+// The timeout must exceed the query timeout, otherwise long running queries fail with a 504 status code.
+func httpServerTimeout(queryTimeout time.Duration) string {
+	if queryTimeout+time.Minute <= defaultHTTPServerTimeout {
+		return ""
+	}
+	return (queryTimeout + time.Minute).String()
+}
+
+func fromHedgingSpecToOptions(spec *v1alpha1.ObjectStorageHedgingSpec) hedgingOptions {
+	if spec == nil || spec.RequestsAt.Duration == 0 {
+		return hedgingOptions{}
+	}
+
+	return hedgingOptions{
+		RequestsAt:   spec.RequestsAt.Duration.String(),
+		RequestsUpTo: spec.RequestsUpTo,
+	}
+}
+
 func renderTempoQueryTemplate(opts tempoQueryOptions) ([]byte, error) {
 	// Build tempo query config yaml
 	w := bytes.NewBuffer(nil)
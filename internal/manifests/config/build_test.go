@@ -6,7 +6,9 @@ import (
 
 	openshiftconfigv1 "github.com/openshift/api/config/v1"
 	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
@@ -40,6 +42,7 @@ distributor:
         grpc:
           endpoint: 0.0.0.0:14250
     zipkin:
+      endpoint: 0.0.0.0:9411
     otlp:
       protocols:
         grpc:
@@ -73,6 +76,7 @@ server:
   grpc_server_max_recv_msg_size: 4194304
   grpc_server_max_send_msg_size: 4194304
   http_listen_port: 3200
+  grpc_listen_port: 9095
   http_server_read_timeout: 3m
   http_server_write_timeout: 3m
   log_format: logfmt
@@ -161,6 +165,7 @@ distributor:
         grpc:
           endpoint: 0.0.0.0:14250
     zipkin:
+      endpoint: 0.0.0.0:9411
     otlp:
       protocols:
         grpc:
@@ -194,6 +199,7 @@ server:
   grpc_server_max_recv_msg_size: 4194304
   grpc_server_max_send_msg_size: 4194304
   http_listen_port: 3200
+  grpc_listen_port: 9095
   http_server_read_timeout: 3m
   http_server_write_timeout: 3m
   log_format: logfmt
@@ -248,6 +254,7 @@ distributor:
         grpc:
           endpoint: 0.0.0.0:14250
     zipkin:
+      endpoint: 0.0.0.0:9411
     otlp:
       protocols:
         grpc:
@@ -283,6 +290,7 @@ server:
   grpc_server_max_recv_msg_size: 4194304
   grpc_server_max_send_msg_size: 4194304
   http_listen_port: 3200
+  grpc_listen_port: 9095
   http_server_read_timeout: 3m
   http_server_write_timeout: 3m
   log_format: logfmt
@@ -336,6 +344,7 @@ distributor:
         grpc:
           endpoint: 0.0.0.0:14250
     zipkin:
+      endpoint: 0.0.0.0:9411
     otlp:
       protocols:
         grpc:
@@ -371,6 +380,7 @@ server:
   grpc_server_max_recv_msg_size: 4194304
   grpc_server_max_send_msg_size: 4194304
   http_listen_port: 3200
+  grpc_listen_port: 9095
   http_server_read_timeout: 3m
   http_server_write_timeout: 3m
   log_format: logfmt
@@ -425,6 +435,7 @@ distributor:
         grpc:
           endpoint: 0.0.0.0:14250
     zipkin:
+      endpoint: 0.0.0.0:9411
     otlp:
       protocols:
         grpc:
@@ -460,6 +471,7 @@ server:
   grpc_server_max_recv_msg_size: 4194304
   grpc_server_max_send_msg_size: 4194304
   http_listen_port: 3200
+  grpc_listen_port: 9095
   http_server_read_timeout: 3m
   http_server_write_timeout: 3m
   log_format: logfmt
@@ -514,6 +526,7 @@ distributor:
         grpc:
           endpoint: 0.0.0.0:14250
     zipkin:
+      endpoint: 0.0.0.0:9411
     otlp:
       protocols:
         grpc:
@@ -549,6 +562,7 @@ server:
   grpc_server_max_recv_msg_size: 4194304
   grpc_server_max_send_msg_size: 4194304
   http_listen_port: 3200
+  grpc_listen_port: 9095
   http_server_read_timeout: 3m
   http_server_write_timeout: 3m
   log_format: logfmt
@@ -603,6 +617,7 @@ distributor:
         grpc:
           endpoint: 0.0.0.0:14250
     zipkin:
+      endpoint: 0.0.0.0:9411
     otlp:
       protocols:
         grpc:
@@ -638,6 +653,7 @@ server:
   grpc_server_max_recv_msg_size: 4194304
   grpc_server_max_send_msg_size: 4194304
   http_listen_port: 3200
+  grpc_listen_port: 9095
   http_server_read_timeout: 3m
   http_server_write_timeout: 3m
   log_format: logfmt
@@ -692,6 +708,7 @@ distributor:
         grpc:
           endpoint: 0.0.0.0:14250
     zipkin:
+      endpoint: 0.0.0.0:9411
     otlp:
       protocols:
         grpc:
@@ -727,6 +744,7 @@ server:
   grpc_server_max_recv_msg_size: 4194304
   grpc_server_max_send_msg_size: 4194304
   http_listen_port: 3200
+  grpc_listen_port: 9095
   http_server_read_timeout: 3m
   http_server_write_timeout: 3m
   log_format: logfmt
@@ -788,6 +806,7 @@ distributor:
         grpc:
           endpoint: 0.0.0.0:14250
     zipkin:
+      endpoint: 0.0.0.0:9411
     otlp:
       protocols:
         grpc:
@@ -828,6 +847,7 @@ server:
   grpc_server_max_recv_msg_size: 4194304
   grpc_server_max_send_msg_size: 4194304
   http_listen_port: 3200
+  grpc_listen_port: 9095
   http_server_read_timeout: 3m
   http_server_write_timeout: 3m
   log_format: logfmt
@@ -892,6 +912,7 @@ distributor:
         grpc:
           endpoint: 0.0.0.0:14250
     zipkin:
+      endpoint: 0.0.0.0:9411
     otlp:
       protocols:
         grpc:
@@ -927,6 +948,7 @@ server:
   grpc_server_max_recv_msg_size: 4194304
   grpc_server_max_send_msg_size: 4194304
   http_listen_port: 3200
+  grpc_listen_port: 9095
   http_server_read_timeout: 3m
   http_server_write_timeout: 3m
   log_format: logfmt
@@ -1020,6 +1042,125 @@ overrides:
 	require.YAMLEq(t, expectedCfg, string(cfg))
 }
 
+func TestBuildTenantsOverrides_Tenants(t *testing.T) {
+	expectedCfg := `
+---
+overrides:
+  "abcd1":
+    ingestion_burst_size_bytes: 200
+    block_retention: 24h0m0s
+  "abcd2":
+    block_retention: 12h0m0s
+  "mytenant":
+    ingestion_burst_size_bytes: 100
+`
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			LimitSpec: v1alpha1.LimitSpec{
+				PerTenant: map[string]v1alpha1.RateLimitSpec{
+					"mytenant": {
+						Ingestion: v1alpha1.IngestionLimitSpec{
+							IngestionBurstSizeBytes: intToPointer(100),
+						},
+					},
+					"abcd1": {
+						Ingestion: v1alpha1.IngestionLimitSpec{
+							IngestionBurstSizeBytes: intToPointer(100),
+						},
+					},
+				},
+			},
+			Tenants: &v1alpha1.TenantsSpec{
+				Mode: v1alpha1.ModeStatic,
+				Authentication: []v1alpha1.AuthenticationSpec{
+					{
+						TenantName: "dev",
+						TenantID:   "abcd1",
+						Limits: &v1alpha1.RateLimitSpec{
+							Ingestion: v1alpha1.IngestionLimitSpec{
+								IngestionBurstSizeBytes: intToPointer(200),
+							},
+						},
+						Retention: &v1alpha1.RetentionConfig{Traces: metav1.Duration{Duration: 24 * time.Hour}},
+					},
+					{
+						TenantName: "prod",
+						TenantID:   "abcd2",
+						Retention:  &v1alpha1.RetentionConfig{Traces: metav1.Duration{Duration: 12 * time.Hour}},
+					},
+				},
+			},
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway: v1alpha1.TempoGatewaySpec{Enabled: true},
+			},
+		},
+	}
+	require.True(t, isTenantOverridesConfigRequired(tempo))
+	cfg, err := buildTenantOverrides(tempo)
+	require.NoError(t, err)
+	require.YAMLEq(t, expectedCfg, string(cfg))
+}
+
+func TestBuildTenantsOverrides_Retention(t *testing.T) {
+	expectedCfg := `
+---
+overrides:
+  "dev":
+    ingestion_burst_size_bytes: 200
+    block_retention: 24h0m0s
+  "premium":
+    ingestion_burst_size_bytes: 100
+    block_retention: 720h0m0s
+  "standard":
+    block_retention: 168h0m0s
+`
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			LimitSpec: v1alpha1.LimitSpec{
+				PerTenant: map[string]v1alpha1.RateLimitSpec{
+					"premium": {
+						Ingestion: v1alpha1.IngestionLimitSpec{
+							IngestionBurstSizeBytes: intToPointer(100),
+						},
+					},
+				},
+			},
+			Retention: v1alpha1.RetentionSpec{
+				Global: v1alpha1.RetentionConfig{Traces: metav1.Duration{Duration: 48 * time.Hour}},
+				PerTenant: map[string]v1alpha1.RetentionConfig{
+					"premium":  {Traces: metav1.Duration{Duration: 720 * time.Hour}},
+					"standard": {Traces: metav1.Duration{Duration: 168 * time.Hour}},
+				},
+			},
+			Tenants: &v1alpha1.TenantsSpec{
+				Mode: v1alpha1.ModeStatic,
+				Authentication: []v1alpha1.AuthenticationSpec{
+					{
+						TenantName: "dev",
+						TenantID:   "abcd1",
+						Limits: &v1alpha1.RateLimitSpec{
+							Ingestion: v1alpha1.IngestionLimitSpec{
+								IngestionBurstSizeBytes: intToPointer(200),
+							},
+						},
+						Retention: &v1alpha1.RetentionConfig{Traces: metav1.Duration{Duration: 24 * time.Hour}},
+					},
+				},
+			},
+		},
+	}
+	require.True(t, isTenantOverridesConfigRequired(tempo))
+	cfg, err := buildTenantOverrides(tempo)
+	require.NoError(t, err)
+	require.YAMLEq(t, expectedCfg, string(cfg))
+}
+
 func TestBuildConfiguration_SearchConfig(t *testing.T) {
 	defaultResultLimit := 20
 	testCases := []struct {
@@ -1053,6 +1194,3175 @@ distributor:
         grpc:
           endpoint: 0.0.0.0:14250
     zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: gcs
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    gcs:
+      bucket_name: test-bucket
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    default_result_limit: 20
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := buildConfiguration(manifestutils.Params{
+				Tempo: v1alpha1.TempoStack{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test",
+					},
+					Spec: v1alpha1.TempoStackSpec{
+						Storage: v1alpha1.ObjectStorageSpec{
+							Secret: v1alpha1.ObjectStorageSecretSpec{
+								Type: v1alpha1.ObjectStorageSecretGCS,
+							},
+						},
+						ReplicationFactor: 1,
+						SearchSpec:        tc.spec,
+					},
+				},
+				StorageParams: manifestutils.StorageParams{
+					GCS: &manifestutils.GCS{
+						Bucket: "test-bucket",
+					},
+				},
+				TLSProfile: tlsprofile.TLSProfileOptions{
+					MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+				},
+			})
+			require.NoError(t, err)
+			require.YAMLEq(t, tc.expect, string(cfg))
+		})
+	}
+}
+
+func TestBuildConfiguration_ReplicationFactor(t *testing.T) {
+
+	replcationFactor := 10
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 10
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: replcationFactor,
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+		TLSProfile: tlsprofile.TLSProfileOptions{
+			MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_StorageHedging(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+      hedge_requests_at: 1s
+      hedge_requests_up_to: 3
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+					Hedging: &v1alpha1.ObjectStorageHedgingSpec{
+						RequestsAt:   metav1.Duration{Duration: time.Second},
+						RequestsUpTo: 3,
+					},
+				},
+				ReplicationFactor: 1,
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+		TLSProfile: tlsprofile.TLSProfileOptions{
+			MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_MaxRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries *int
+	}{
+		{name: "default"},
+		{name: "disabled", maxRetries: intToPointer(0)},
+		{name: "custom", maxRetries: intToPointer(5)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg, err := buildConfiguration(manifestutils.Params{
+				Tempo: v1alpha1.TempoStack{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test",
+					},
+					Spec: v1alpha1.TempoStackSpec{
+						Storage: v1alpha1.ObjectStorageSpec{
+							Secret: v1alpha1.ObjectStorageSecretSpec{
+								Type: v1alpha1.ObjectStorageSecretS3,
+							},
+							MaxRetries: test.maxRetries,
+						},
+						ReplicationFactor: 1,
+					},
+				},
+				StorageParams: manifestutils.StorageParams{
+					S3: &manifestutils.S3{
+						Endpoint: "s3.dualstack.us-east-1.amazonaws.com",
+						Bucket:   "tempo",
+					},
+				},
+			})
+			require.NoError(t, err)
+
+			parsed := struct {
+				QueryFrontend struct {
+					MaxRetries *int `json:"max_retries"`
+				} `json:"query_frontend"`
+			}{}
+			require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+			require.Equal(t, test.maxRetries, parsed.QueryFrontend.MaxRetries)
+		})
+	}
+}
+
+func TestBuildConfiguration_ReceiversTLS(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+          tls:
+            client_ca_file: /var/run/ca/receiver/service-ca.crt
+            cert_file: /var/run/tls/receiver/tls.crt
+            key_file: /var/run/tls/receiver/tls.key
+            min_version: 1.3
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+          tls:
+            client_ca_file: /var/run/ca/receiver/service-ca.crt
+            cert_file: /var/run/tls/receiver/tls.crt
+            key_file: /var/run/tls/receiver/tls.key
+            min_version: 1.3
+    zipkin:
+      endpoint: 0.0.0.0:9411
+      tls:
+        client_ca_file: /var/run/ca/receiver/service-ca.crt
+        cert_file: /var/run/tls/receiver/tls.crt
+        key_file: /var/run/tls/receiver/tls.key
+        min_version: 1.3
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+          tls:
+            client_ca_file: /var/run/ca/receiver/service-ca.crt
+            cert_file: /var/run/tls/receiver/tls.crt
+            key_file: /var/run/tls/receiver/tls.key
+            min_version: 1.3
+        http:
+          endpoint: "0.0.0.0:4318"
+          tls:
+            client_ca_file: /var/run/ca/receiver/service-ca.crt
+            cert_file: /var/run/tls/receiver/tls.crt
+            key_file: /var/run/tls/receiver/tls.key
+            min_version: 1.3
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Template: v1alpha1.TempoTemplateSpec{
+					Distributor: v1alpha1.TempoDistributorSpec{
+						TLS: v1alpha1.ReceiversTLSSpec{
+							Enabled: true,
+							CA:      "client-ca",
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+		TLSProfile: tlsprofile.TLSProfileOptions{
+			MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_ReceiversTLSOverrides(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+          tls:
+            client_ca_file: /var/run/ca/receiver-jaeger/service-ca.crt
+            cert_file: /var/run/tls/receiver/tls.crt
+            key_file: /var/run/tls/receiver/tls.key
+            min_version: 1.3
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+          tls:
+            client_ca_file: /var/run/ca/receiver-jaeger/service-ca.crt
+            cert_file: /var/run/tls/receiver/tls.crt
+            key_file: /var/run/tls/receiver/tls.key
+            min_version: 1.3
+    zipkin:
+      endpoint: 0.0.0.0:9411
+      tls:
+        client_ca_file: /var/run/ca/receiver/service-ca.crt
+        cert_file: /var/run/tls/receiver/tls.crt
+        key_file: /var/run/tls/receiver/tls.key
+        min_version: 1.3
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+          tls:
+            client_ca_file: /var/run/ca/receiver/service-ca.crt
+            cert_file: /var/run/tls/receiver/tls.crt
+            key_file: /var/run/tls/receiver/tls.key
+            min_version: 1.3
+        http:
+          endpoint: "0.0.0.0:4318"
+          tls:
+            client_ca_file: /var/run/ca/receiver/service-ca.crt
+            cert_file: /var/run/tls/receiver-otlp-http/tls.crt
+            key_file: /var/run/tls/receiver-otlp-http/tls.key
+            min_version: 1.2
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Template: v1alpha1.TempoTemplateSpec{
+					Distributor: v1alpha1.TempoDistributorSpec{
+						TLS: v1alpha1.ReceiversTLSSpec{
+							Enabled: true,
+							CA:      "client-ca",
+							OTLPHTTP: &v1alpha1.ReceiverTLSOverrideSpec{
+								CertName:      "otlp-http-cert",
+								MinTLSVersion: string(openshiftconfigv1.VersionTLS12),
+							},
+							Jaeger: &v1alpha1.ReceiverTLSOverrideSpec{
+								CA: "jaeger-ca",
+							},
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+		TLSProfile: tlsprofile.TLSProfileOptions{
+			MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_KafkaReceiver(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+    kafka:
+      brokers:
+      - kafka-0:9093
+      - kafka-1:9093
+      topic: spans
+      encoding: otlp_proto
+      group_id: tempo
+      protocol_version: 2.0.0
+      auth:
+        sasl:
+          username: ${KAFKA_SASL_USERNAME}
+          password: ${KAFKA_SASL_PASSWORD}
+          mechanism: SCRAM-SHA-512
+        tls:
+          insecure: false
+          ca_file: /var/run/ca/kafka/service-ca.crt
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Template: v1alpha1.TempoTemplateSpec{
+					Distributor: v1alpha1.TempoDistributorSpec{
+						Receivers: v1alpha1.ReceiversSpec{
+							Kafka: &v1alpha1.KafkaReceiverSpec{
+								Brokers:         []string{"kafka-0:9093", "kafka-1:9093"},
+								Topic:           "spans",
+								Encoding:        "otlp_proto",
+								GroupID:         "tempo",
+								ProtocolVersion: "2.0.0",
+								SASL: &v1alpha1.KafkaSASLSpec{
+									Secret:    "kafka-credentials",
+									Mechanism: v1alpha1.KafkaSASLMechanismSCRAMSHA512,
+								},
+								TLS: &v1alpha1.KafkaTLSSpec{
+									CA: "kafka-ca",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_KafkaReceiverEscaping(t *testing.T) {
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Template: v1alpha1.TempoTemplateSpec{
+					Distributor: v1alpha1.TempoDistributorSpec{
+						Receivers: v1alpha1.ReceiversSpec{
+							Kafka: &v1alpha1.KafkaReceiverSpec{
+								Brokers: []string{"kafka-0:9093\n  injected: true"},
+								Topic:   "spans&traces+'v1'",
+								GroupID: "tempo: {}",
+							},
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	parsed := struct {
+		Distributor struct {
+			Receivers struct {
+				Kafka map[string]interface{} `json:"kafka"`
+			} `json:"receivers"`
+		} `json:"distributor"`
+	}{}
+	require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+	require.Equal(t, map[string]interface{}{
+		"brokers":  []interface{}{"kafka-0:9093\n  injected: true"},
+		"topic":    "spans&traces+'v1'",
+		"group_id": "tempo: {}",
+	}, parsed.Distributor.Receivers.Kafka)
+}
+
+func TestBuildConfiguration_JaegerReceiver(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14269
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Template: v1alpha1.TempoTemplateSpec{
+					Distributor: v1alpha1.TempoDistributorSpec{
+						Receivers: v1alpha1.ReceiversSpec{
+							Jaeger: &v1alpha1.JaegerReceiverSpec{
+								ThriftHTTP:    &v1alpha1.ReceiverProtocolSpec{Enabled: true, Port: 14269},
+								ThriftBinary:  &v1alpha1.ReceiverProtocolSpec{Enabled: false},
+								ThriftCompact: &v1alpha1.ReceiverProtocolSpec{Enabled: false},
+							},
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_OTLPGRPCSettings(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+          max_recv_msg_size_mib: 16
+          max_concurrent_streams: 100
+          write_buffer_size: 524288
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Template: v1alpha1.TempoTemplateSpec{
+					Distributor: v1alpha1.TempoDistributorSpec{
+						Receivers: v1alpha1.ReceiversSpec{
+							OTLP: &v1alpha1.OTLPReceiverSpec{
+								GRPC: v1alpha1.OTLPGRPCSpec{
+									MaxRecvMsgSizeMiB:    intToPointer(16),
+									MaxConcurrentStreams: intToPointer(100),
+									WriteBufferSize:      intToPointer(512 * 1024),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_GRPCServer(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester_client:
+  grpc_client_config:
+    max_recv_msg_size: 16777216
+    max_send_msg_size: 67108864
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+    grpc_client_config:
+      max_recv_msg_size: 16777216
+      max_send_msg_size: 67108864
+server:
+  grpc_server_max_recv_msg_size: 16777216
+  grpc_server_max_send_msg_size: 67108864
+  grpc_server_keepalive_time: 2h0m0s
+  grpc_server_keepalive_timeout: 20s
+  grpc_server_max_connection_age: 5m0s
+  grpc_server_max_connection_age_grace: 1m0s
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				GRPCServer: &v1alpha1.GRPCServerSpec{
+					MaxRecvMsgSizeMiB:     intToPointer(16),
+					MaxSendMsgSizeMiB:     intToPointer(64),
+					KeepaliveTime:         &metav1.Duration{Duration: 2 * time.Hour},
+					KeepaliveTimeout:      &metav1.Duration{Duration: 20 * time.Second},
+					MaxConnectionAge:      &metav1.Duration{Duration: 5 * time.Minute},
+					MaxConnectionAgeGrace: &metav1.Duration{Duration: time.Minute},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_LogReceivedSpans(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  log_received_spans:
+    enabled: true
+    include_all_attributes: true
+    filter_by_status_error: false
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Template: v1alpha1.TempoTemplateSpec{
+					Distributor: v1alpha1.TempoDistributorSpec{
+						LogReceivedSpans: &v1alpha1.LogReceivedSpansSpec{
+							Enabled:              true,
+							IncludeAllAttributes: true,
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_CompactorTuning(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+    compaction_window: 30m0s
+    max_block_bytes: 53687091200
+    retention_concurrency: 5
+    compaction_cycle: 1m0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Template: v1alpha1.TempoTemplateSpec{
+					Compactor: v1alpha1.TempoCompactorSpec{
+						CompactionWindow:     metav1.Duration{Duration: 30 * time.Minute},
+						MaxBlockBytes:        intToPointer(50 * 1024 * 1024 * 1024),
+						RetentionConcurrency: intToPointer(5),
+						CompactionCycle:      metav1.Duration{Duration: time.Minute},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_IngesterTuning(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  trace_idle_period: 5s
+  max_block_duration: 30m0s
+  max_block_bytes: 1073741824
+  complete_block_timeout: 5m0s
+  flush_check_period: 15s
+  concurrent_flushes: 8
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Template: v1alpha1.TempoTemplateSpec{
+					Ingester: v1alpha1.TempoIngesterSpec{
+						TraceIdlePeriod:      metav1.Duration{Duration: 5 * time.Second},
+						MaxBlockDuration:     metav1.Duration{Duration: 30 * time.Minute},
+						MaxBlockBytes:        intToPointer(1024 * 1024 * 1024),
+						CompleteBlockTimeout: metav1.Duration{Duration: 5 * time.Minute},
+						FlushCheckPeriod:     metav1.Duration{Duration: 15 * time.Second},
+						ConcurrentFlushes:    intToPointer(8),
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_ZoneAwareReplication(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 3
+      zone_awareness_enabled: true
+    availability_zone: ${INGESTER_AVAILABILITY_ZONE}
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 3,
+				Template: v1alpha1.TempoTemplateSpec{
+					Ingester: v1alpha1.TempoIngesterSpec{
+						ZoneAwareReplication: &v1alpha1.ZoneAwareReplicationSpec{
+							Zones: []string{"zone-a", "zone-b", "zone-c"},
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_BlockFormat(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+    block:
+      version: vParquet3
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+					BlockFormat: v1alpha1.BlockFormatVParquet3,
+				},
+				ReplicationFactor: 1,
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_CacheMemcached(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: memcached
+    memcached:
+      addresses: memcached-0.memcached:11211,memcached-1.memcached:11211
+      timeout: 500ms
+      tls_enabled: true
+      tls_ca_path: /var/run/ca/cache/service-ca.crt
+      tls_cert_path: /var/run/tls/cache/tls.crt
+      tls_key_path: /var/run/tls/cache/tls.key
+      tls_server_name: "memcached.cache.svc"
+      tls_min_version: VersionTLS13
+      tls_insecure_skip_verify: false
+    search:
+      cache_control:
+        footer: true
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Cache: &v1alpha1.CacheSpec{
+					Backend:   v1alpha1.CacheBackendMemcached,
+					Endpoints: []string{"memcached-0.memcached:11211", "memcached-1.memcached:11211"},
+					Timeout:   metav1.Duration{Duration: 500 * time.Millisecond},
+					TLS:       &v1alpha1.CacheTLSSpec{CA: "memcached-ca", CertName: "memcached-client-cert", ServerName: "memcached.cache.svc"},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+		TLSProfile: tlsprofile.TLSProfileOptions{
+			MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_Memberlist(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+    instance_addr: ${POD_IP}
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+    instance_addr: ${POD_IP}
+ingester:
+  lifecycler:
+    address: ${POD_IP}
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring.observability.svc.example.org
+  bind_port: 7947
+  advertise_addr: ${POD_IP}
+  gossip_interval: 1s
+  tls_enabled: true
+  tls_cert_path: /var/run/tls/memberlist/tls.crt
+  tls_key_path: /var/run/tls/memberlist/tls.key
+  tls_ca_path: /var/run/ca/memberlist/service-ca.crt
+  tls_server_name: tempo-test-gossip-ring.observability.svc.example.org
+  tls_min_version: VersionTLS13
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "observability",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Memberlist: &v1alpha1.MemberlistSpec{
+					InstanceAddrType: v1alpha1.MemberlistInstanceAddrPodIP,
+					Port:             7947,
+					GossipInterval:   metav1.Duration{Duration: time.Second},
+					ClusterDomain:    "example.org",
+					TLS:              &v1alpha1.MemberlistTLSSpec{CertName: "memberlist-cert", CA: "memberlist-ca"},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+		TLSProfile: tlsprofile.TLSProfileOptions{
+			MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_KVStoreEtcd(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: etcd
+      prefix: "tempo/observability/test/"
+      etcd:
+        endpoints:
+        - "etcd-0.etcd:2379"
+        - "etcd-1.etcd:2379"
+        username: ${ETCD_USERNAME}
+        password: ${ETCD_PASSWORD}
+        tls_enabled: true
+        tls_ca_path: /var/run/ca/etcd/service-ca.crt
+        tls_cert_path: /var/run/tls/etcd/tls.crt
+        tls_key_path: /var/run/tls/etcd/tls.key
+        tls_server_name: "etcd.etcd.svc"
+        tls_min_version: VersionTLS13
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: etcd
+      prefix: "tempo/observability/test/"
+      etcd:
+        endpoints:
+        - "etcd-0.etcd:2379"
+        - "etcd-1.etcd:2379"
+        username: ${ETCD_USERNAME}
+        password: ${ETCD_PASSWORD}
+        tls_enabled: true
+        tls_ca_path: /var/run/ca/etcd/service-ca.crt
+        tls_cert_path: /var/run/tls/etcd/tls.crt
+        tls_key_path: /var/run/tls/etcd/tls.key
+        tls_server_name: "etcd.etcd.svc"
+        tls_min_version: VersionTLS13
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: etcd
+        prefix: "tempo/observability/test/"
+        etcd:
+          endpoints:
+          - "etcd-0.etcd:2379"
+          - "etcd-1.etcd:2379"
+          username: ${ETCD_USERNAME}
+          password: ${ETCD_PASSWORD}
+          tls_enabled: true
+          tls_ca_path: /var/run/ca/etcd/service-ca.crt
+          tls_cert_path: /var/run/tls/etcd/tls.crt
+          tls_key_path: /var/run/tls/etcd/tls.key
+          tls_server_name: "etcd.etcd.svc"
+          tls_min_version: VersionTLS13
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "observability",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				KVStore: &v1alpha1.KVStoreSpec{
+					Type: v1alpha1.KVStoreEtcd,
+					Etcd: &v1alpha1.EtcdSpec{
+						Endpoints:  []string{"etcd-0.etcd:2379", "etcd-1.etcd:2379"},
+						AuthSecret: "etcd-credentials",
+						TLS:        &v1alpha1.EtcdTLSSpec{CA: "etcd-ca", CertName: "etcd-client-cert", ServerName: "etcd.etcd.svc"},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+		TLSProfile: tlsprofile.TLSProfileOptions{
+			MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_KVStoreConsul(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: consul
+      prefix: "tempo/observability/test/"
+      consul:
+        host: "consul.consul.svc:8500"
+        acl_token: ${CONSUL_ACL_TOKEN}
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: consul
+      prefix: "tempo/observability/test/"
+      consul:
+        host: "consul.consul.svc:8500"
+        acl_token: ${CONSUL_ACL_TOKEN}
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: consul
+        prefix: "tempo/observability/test/"
+        consul:
+          host: "consul.consul.svc:8500"
+          acl_token: ${CONSUL_ACL_TOKEN}
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "observability",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				KVStore: &v1alpha1.KVStoreSpec{
+					Type:   v1alpha1.KVStoreConsul,
+					Consul: &v1alpha1.ConsulSpec{Host: "consul.consul.svc:8500", AuthSecret: "consul-token"},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+		TLSProfile: tlsprofile.TLSProfileOptions{
+			MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_CacheRedis(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: redis
+    redis:
+      endpoint: redis:6379
+      password: ${CACHE_PASSWORD}
+      tls_enabled: true
+      tls_insecure_skip_verify: true
+    search:
+      cache_control:
+        footer: true
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Cache: &v1alpha1.CacheSpec{
+					Backend:    v1alpha1.CacheBackendRedis,
+					Endpoints:  []string{"redis:6379"},
+					AuthSecret: "redis-credentials",
+					TLS:        &v1alpha1.CacheTLSSpec{InsecureSkipVerify: true},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_CacheManagedMemcached(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: memcached
+    memcached:
+      host: tempo-test-memcached.project1.svc.cluster.local
+      service: memcached
+      consistent_hash: true
+    search:
+      cache_control:
+        footer: true
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Template: v1alpha1.TempoTemplateSpec{
+					Memcached: v1alpha1.TempoMemcachedSpec{Enabled: true},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_ResultsCache(t *testing.T) {
+	expect := `
+---
+cache:
+  caches:
+  - roles:
+    - bloom
+    - parquet-footer
+    memcached:
+      host: tempo-test-memcached.project1.svc.cluster.local
+      service: memcached
+      consistent_hash: true
+  - roles:
+    - frontend-search
+    memcached:
+      host: tempo-test-memcached.project1.svc.cluster.local
+      service: memcached
+      consistent_hash: true
+      expiration: 10m0s
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    search:
+      cache_control:
+        footer: true
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Template: v1alpha1.TempoTemplateSpec{
+					Memcached: v1alpha1.TempoMemcachedSpec{Enabled: true},
+					QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+						ResultsCache: &v1alpha1.QueryFrontendResultsCacheSpec{
+							Enabled: true,
+							TTL:     metav1.Duration{Duration: 10 * time.Minute},
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_ResultsCacheTempo24(t *testing.T) {
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Images: configv1alpha1.ImagesSpec{
+					Tempo: "docker.io/grafana/tempo:2.4.0",
+				},
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Template: v1alpha1.TempoTemplateSpec{
+					Memcached: v1alpha1.TempoMemcachedSpec{Enabled: true},
+					QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+						ResultsCache: &v1alpha1.QueryFrontendResultsCacheSpec{
+							Enabled: true,
+							TTL:     metav1.Duration{Duration: time.Hour},
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// Tempo 2.4 configures all caches in the top-level cache block and rejects the
+	// legacy cache settings of the trace storage if both are set.
+	parsed := struct {
+		Cache struct {
+			Caches []struct {
+				Roles     []string               `json:"roles"`
+				Memcached map[string]interface{} `json:"memcached"`
+			} `json:"caches"`
+		} `json:"cache"`
+		Storage struct {
+			Trace map[string]interface{} `json:"trace"`
+		} `json:"storage"`
+	}{}
+	require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+	require.Len(t, parsed.Cache.Caches, 2)
+	require.Equal(t, []string{"bloom", "parquet-footer"}, parsed.Cache.Caches[0].Roles)
+	require.Equal(t, []string{"frontend-search"}, parsed.Cache.Caches[1].Roles)
+	require.Equal(t, "1h0m0s", parsed.Cache.Caches[1].Memcached["expiration"])
+	require.NotContains(t, parsed.Storage.Trace, "cache")
+	require.NotContains(t, parsed.Storage.Trace, "memcached")
+}
+
+func TestBuildConfiguration_QueryFrontendSearch(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 500
+    max_duration: 12h0m0s
+    target_bytes_per_job: 52428800
+    query_backend_after: 15m0s
+    query_ingesters_until: 30m0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				SearchSpec: v1alpha1.SearchSpec{
+					MaxDuration: metav1.Duration{Duration: time.Hour},
+				},
+				Template: v1alpha1.TempoTemplateSpec{
+					QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+						Search: &v1alpha1.QueryFrontendSearchSpec{
+							ConcurrentJobs:      intToPointer(500),
+							TargetBytesPerJob:   intToPointer(52428800),
+							MaxDuration:         metav1.Duration{Duration: 12 * time.Hour},
+							QueryBackendAfter:   metav1.Duration{Duration: 15 * time.Minute},
+							QueryIngestersUntil: metav1.Duration{Duration: 30 * time.Minute},
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_QueryTimeout(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    query_timeout: 5m0s
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  trace_by_id:
+    query_timeout: 5m0s
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 6m0s
+  http_server_write_timeout: 6m0s
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 1h0m0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				SearchSpec: v1alpha1.SearchSpec{
+					MaxDuration:  metav1.Duration{Duration: time.Hour},
+					QueryTimeout: metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_ExtraConfig(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 50
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+  log_level: debug
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				ExtraConfig: &v1alpha1.ExtraConfigSpec{
+					Tempo: apiextensionsv1.JSON{
+						Raw: []byte(`{"querier":{"max_concurrent_queries":50},"server":{"log_level":"debug"}}`),
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_LogFormat(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+multitenancy_enabled: false
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: json
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Observability: v1alpha1.ObservabilitySpec{
+					Logging: v1alpha1.LoggingConfigSpec{Format: v1alpha1.LogFormatJSON},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_RuntimeOverrides(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
     otlp:
       protocols:
         grpc:
@@ -1075,6 +4385,8 @@ memberlist:
   join_members:
     - tempo-test-gossip-ring
 multitenancy_enabled: false
+overrides:
+  per_tenant_override_config: /runtime-overrides/overrides.yaml
 querier:
   max_concurrent_queries: 20
   search:
@@ -1086,66 +4398,185 @@ server:
   grpc_server_max_recv_msg_size: 4194304
   grpc_server_max_send_msg_size: 4194304
   http_listen_port: 3200
+  grpc_listen_port: 9095
   http_server_read_timeout: 3m
   http_server_write_timeout: 3m
   log_format: logfmt
 storage:
   trace:
-    backend: gcs
+    backend: azure
     blocklist_poll: 5m
     cache: none
     local:
       path: /var/tempo/traces
-    gcs:
-      bucket_name: test-bucket
+    azure:
+      container_name: "container-test"
     wal:
       path: /var/tempo/wal
 usage_report:
   reporting_enabled: false
 query_frontend:
   search:
-    default_result_limit: 20
     concurrent_jobs: 2000
     max_duration: 0s
-      `,
-		},
-	}
+      `
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			cfg, err := buildConfiguration(manifestutils.Params{
-				Tempo: v1alpha1.TempoStack{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "test",
-					},
-					Spec: v1alpha1.TempoStackSpec{
-						Storage: v1alpha1.ObjectStorageSpec{
-							Secret: v1alpha1.ObjectStorageSecretSpec{
-								Type: v1alpha1.ObjectStorageSecretGCS,
-							},
-						},
-						ReplicationFactor: 1,
-						SearchSpec:        tc.spec,
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
 					},
 				},
-				StorageParams: manifestutils.StorageParams{
-					GCS: &manifestutils.GCS{
-						Bucket: "test-bucket",
+				ReplicationFactor: 1,
+				LimitSpec: v1alpha1.LimitSpec{
+					PerTenantRuntimeOverridesConfigMap: "tenant-overrides",
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
+}
+
+func TestBuildConfiguration_MetricsGenerator(t *testing.T) {
+	expect := `
+---
+compactor:
+  compaction:
+    block_retention: 0s
+  ring:
+    kvstore:
+      store: memberlist
+distributor:
+  receivers:
+    jaeger:
+      protocols:
+        thrift_http:
+          endpoint: 0.0.0.0:14268
+        thrift_binary:
+          endpoint: 0.0.0.0:6832
+        thrift_compact:
+          endpoint: 0.0.0.0:6831
+        grpc:
+          endpoint: 0.0.0.0:14250
+    zipkin:
+      endpoint: 0.0.0.0:9411
+    otlp:
+      protocols:
+        grpc:
+          endpoint: "0.0.0.0:4317"
+        http:
+          endpoint: "0.0.0.0:4318"
+  ring:
+    kvstore:
+      store: memberlist
+ingester:
+  lifecycler:
+    ring:
+      kvstore:
+        store: memberlist
+      replication_factor: 1
+    tokens_file_path: /var/tempo/tokens.json
+  max_block_duration: 10m
+memberlist:
+  abort_if_cluster_join_fails: false
+  join_members:
+    - tempo-test-gossip-ring
+metrics_generator:
+  ring:
+    kvstore:
+      store: memberlist
+  storage:
+    path: /var/tempo/generator/wal
+    remote_write:
+      - url: http://prometheus:9090/api/v1/write
+multitenancy_enabled: false
+overrides:
+  metrics_generator_processors:
+    - service-graphs
+    - span-metrics
+querier:
+  max_concurrent_queries: 20
+  search:
+    external_hedge_requests_at: 8s
+    external_hedge_requests_up_to: 2
+  frontend_worker:
+    frontend_address: "tempo-test-query-frontend-discovery:9095"
+server:
+  grpc_server_max_recv_msg_size: 4194304
+  grpc_server_max_send_msg_size: 4194304
+  http_listen_port: 3200
+  grpc_listen_port: 9095
+  http_server_read_timeout: 3m
+  http_server_write_timeout: 3m
+  log_format: logfmt
+storage:
+  trace:
+    backend: azure
+    blocklist_poll: 5m
+    cache: none
+    local:
+      path: /var/tempo/traces
+    azure:
+      container_name: "container-test"
+    wal:
+      path: /var/tempo/wal
+usage_report:
+  reporting_enabled: false
+query_frontend:
+  search:
+    concurrent_jobs: 2000
+    max_duration: 0s
+      `
+
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
 					},
 				},
-				TLSProfile: tlsprofile.TLSProfileOptions{
-					MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+				ReplicationFactor: 1,
+				Template: v1alpha1.TempoTemplateSpec{
+					MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+						Enabled: true,
+						Processors: []v1alpha1.MetricsGeneratorProcessor{
+							v1alpha1.MetricsGeneratorProcessorServiceGraphs,
+							v1alpha1.MetricsGeneratorProcessorSpanMetrics,
+						},
+						RemoteWrite: []v1alpha1.RemoteWriteSpec{
+							{URL: "http://prometheus:9090/api/v1/write"},
+						},
+					},
 				},
-			})
-			require.NoError(t, err)
-			require.YAMLEq(t, tc.expect, string(cfg))
-		})
-	}
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.YAMLEq(t, expect, string(cfg))
 }
 
-func TestBuildConfiguration_ReplicationFactor(t *testing.T) {
-
-	replcationFactor := 10
+func TestBuildConfiguration_MetricsGeneratorRemoteWrite(t *testing.T) {
 	expect := `
 ---
 compactor:
@@ -1167,6 +4598,7 @@ distributor:
         grpc:
           endpoint: 0.0.0.0:14250
     zipkin:
+      endpoint: 0.0.0.0:9411
     otlp:
       protocols:
         grpc:
@@ -1181,14 +4613,45 @@ ingester:
     ring:
       kvstore:
         store: memberlist
-      replication_factor: 10
+      replication_factor: 1
     tokens_file_path: /var/tempo/tokens.json
   max_block_duration: 10m
 memberlist:
   abort_if_cluster_join_fails: false
   join_members:
     - tempo-test-gossip-ring
+metrics_generator:
+  ring:
+    kvstore:
+      store: memberlist
+  storage:
+    path: /var/tempo/generator/wal
+    remote_write:
+      - url: https://mimir:8080/api/v1/push
+        headers:
+          X-Scope-OrgID: dev
+        basic_auth:
+          username: ${REMOTE_WRITE_0_USERNAME}
+          password: ${REMOTE_WRITE_0_PASSWORD}
+        tls_config:
+          ca_file: /var/run/ca/remote-write-0/service-ca.crt
+          cert_file: /var/run/tls/remote-write-0/tls.crt
+          key_file: /var/run/tls/remote-write-0/tls.key
+          insecure_skip_verify: false
+      - url: https://prometheus:9090/api/v1/write
+        authorization:
+          credentials: ${REMOTE_WRITE_1_TOKEN}
+      - url: https://prometheus-k8s.openshift-monitoring.svc:9091/api/v1/write
+        authorization:
+          credentials_file: /var/run/secrets/kubernetes.io/serviceaccount/token
+        tls_config:
+          ca_file: /var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt
+          insecure_skip_verify: false
 multitenancy_enabled: false
+overrides:
+  metrics_generator_processors:
+    - service-graphs
+    - span-metrics
 querier:
   max_concurrent_queries: 20
   search:
@@ -1200,6 +4663,7 @@ server:
   grpc_server_max_recv_msg_size: 4194304
   grpc_server_max_send_msg_size: 4194304
   http_listen_port: 3200
+  grpc_listen_port: 9095
   http_server_read_timeout: 3m
   http_server_write_timeout: 3m
   log_format: logfmt
@@ -1233,7 +4697,29 @@ query_frontend:
 						Type: v1alpha1.ObjectStorageSecretAzure,
 					},
 				},
-				ReplicationFactor: replcationFactor,
+				ReplicationFactor: 1,
+				Template: v1alpha1.TempoTemplateSpec{
+					MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+						Enabled: true,
+						Processors: []v1alpha1.MetricsGeneratorProcessor{
+							v1alpha1.MetricsGeneratorProcessorServiceGraphs,
+							v1alpha1.MetricsGeneratorProcessorSpanMetrics,
+						},
+						RemoteWrite: []v1alpha1.RemoteWriteSpec{
+							{
+								URL:     "https://mimir:8080/api/v1/push",
+								Headers: map[string]string{"X-Scope-OrgID": "dev"},
+								Auth:    &v1alpha1.RemoteWriteAuthSpec{Type: v1alpha1.RemoteWriteAuthTypeBasic, Secret: "mimir-credentials"},
+								TLS:     &v1alpha1.RemoteWriteTLSSpec{CA: "mimir-ca", CertName: "mimir-client-cert"},
+							},
+							{
+								URL:  "https://prometheus:9090/api/v1/write",
+								Auth: &v1alpha1.RemoteWriteAuthSpec{Type: v1alpha1.RemoteWriteAuthTypeBearer, Secret: "prometheus-token"},
+							},
+							{OpenShiftMonitoring: true},
+						},
+					},
+				},
 			},
 		},
 		StorageParams: manifestutils.StorageParams{
@@ -1241,14 +4727,61 @@ query_frontend:
 				Container: "container-test",
 			},
 		},
-		TLSProfile: tlsprofile.TLSProfileOptions{
-			MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
-		},
 	})
 	require.NoError(t, err)
 	require.YAMLEq(t, expect, string(cfg))
 }
 
+func TestBuildConfiguration_MetricsGeneratorRemoteWriteEscaping(t *testing.T) {
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretAzure,
+					},
+				},
+				ReplicationFactor: 1,
+				Template: v1alpha1.TempoTemplateSpec{
+					MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+						Enabled: true,
+						RemoteWrite: []v1alpha1.RemoteWriteSpec{
+							{
+								URL:     "https://mimir:8080/api/v1/push?tenant=dev&token=a+b",
+								Headers: map[string]string{"X-Scope-OrgID": "it's: {dev}\n  injected: true"},
+							},
+						},
+					},
+				},
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			AzureStorage: &manifestutils.AzureStorage{
+				Container: "container-test",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	parsed := struct {
+		MetricsGenerator struct {
+			Storage struct {
+				RemoteWrite []map[string]interface{} `json:"remote_write"`
+			} `json:"storage"`
+		} `json:"metrics_generator"`
+	}{}
+	require.NoError(t, yaml.Unmarshal(cfg, &parsed))
+	require.Equal(t, []map[string]interface{}{
+		{
+			"url":     "https://mimir:8080/api/v1/push?tenant=dev&token=a+b",
+			"headers": map[string]interface{}{"X-Scope-OrgID": "it's: {dev}\n  injected: true"},
+		},
+	}, parsed.MetricsGenerator.Storage.RemoteWrite)
+}
+
 func TestBuildConfiguration_Multitenancy(t *testing.T) {
 	expCfg := `
 ---
@@ -1271,6 +4804,7 @@ distributor:
         grpc:
           endpoint: 0.0.0.0:14250
     zipkin:
+      endpoint: 0.0.0.0:9411
     otlp:
       protocols:
         grpc:
@@ -1304,6 +4838,7 @@ server:
   grpc_server_max_recv_msg_size: 4194304
   grpc_server_max_send_msg_size: 4194304
   http_listen_port: 3200
+  grpc_listen_port: 9095
   http_server_read_timeout: 3m
   http_server_write_timeout: 3m
   log_format: logfmt
@@ -1384,6 +4919,7 @@ distributor:
         grpc:
           endpoint: 0.0.0.0:14250
     zipkin:
+      endpoint: 0.0.0.0:9411
     otlp:
       protocols:
         grpc:
@@ -1433,6 +4969,7 @@ server:
   grpc_server_max_recv_msg_size: 4194304
   grpc_server_max_send_msg_size: 4194304
   http_listen_port: 3200
+  grpc_listen_port: 9095
   http_server_read_timeout: 3m
   http_server_write_timeout: 3m
   log_format: logfmt
@@ -1585,6 +5122,7 @@ server:
   grpc_server_max_recv_msg_size: 4194304
   grpc_server_max_send_msg_size: 4194304
   http_listen_port: 3200
+  grpc_listen_port: 9095
   http_server_read_timeout: 3m
   http_server_write_timeout: 3m
   log_format: logfmt
@@ -1677,3 +5215,44 @@ ingester_client:
 	require.NoError(t, err)
 	require.YAMLEq(t, expCfg, string(cfg))
 }
+
+func TestBuildConfigurationTLS_ServingCertsAllServices(t *testing.T) {
+	cfg, err := buildConfiguration(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "nstest",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Storage: v1alpha1.ObjectStorageSpec{
+					Secret: v1alpha1.ObjectStorageSecretSpec{
+						Type: v1alpha1.ObjectStorageSecretS3,
+					},
+				},
+				ReplicationFactor: 1,
+			},
+		},
+		StorageParams: manifestutils.StorageParams{
+			S3: &manifestutils.S3{
+				Endpoint: "minio:9000",
+				Bucket:   "tempo",
+			},
+		},
+		TLSProfile: tlsprofile.TLSProfileOptions{
+			MinTLSVersion: "VersionTLS12",
+		},
+		Gates: configv1alpha1.FeatureGates{
+			HTTPEncryption: true,
+			GRPCEncryption: true,
+			OpenShift: configv1alpha1.OpenShiftFeatureGates{
+				ServingCertsAllServices: true,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Contains(t, string(cfg), "grpc_tls_config:")
+	require.Contains(t, string(cfg), "http_tls_config:")
+	require.Contains(t, string(cfg), "tls_ca_path: /var/run/ca/service-ca.crt")
+	require.NotContains(t, string(cfg), "client_auth_type")
+	require.NotContains(t, string(cfg), "client_ca_file")
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/imdario/mergo"
+	"sigs.k8s.io/yaml"
+)
+
+// mergeExtraConfig deep-merges the extra configuration of the TempoStack CR into the rendered configuration.
+// Values of the extra configuration take precedence, lists are replaced instead of appended.
+func mergeExtraConfig(cfg []byte, extraConfig []byte) ([]byte, error) {
+	rendered := map[string]interface{}{}
+	if err := yaml.Unmarshal(cfg, &rendered); err != nil {
+		return nil, fmt.Errorf("failed to parse the generated configuration: %w", err)
+	}
+
+	extra := map[string]interface{}{}
+	if err := json.Unmarshal(extraConfig, &extra); err != nil {
+		return nil, fmt.Errorf("failed to parse the extra configuration: %w", err)
+	}
+
+	if err := mergo.Merge(&rendered, extra, mergo.WithOverride); err != nil {
+		return nil, fmt.Errorf("failed to merge the extra configuration: %w", err)
+	}
+
+	return yaml.Marshal(rendered)
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeExtraConfig(t *testing.T) {
+	cfg := `
+server:
+  http_listen_port: 3200
+  log_format: logfmt
+memberlist:
+  join_members:
+  - tempo-test-gossip-ring
+querier:
+  max_concurrent_queries: 20
+`
+
+	tests := []struct {
+		name        string
+		extraConfig string
+		expected    string
+	}{
+		{
+			name:        "add settings",
+			extraConfig: `{"server":{"log_level":"debug"},"storage":{"trace":{"pool":{"max_workers":200}}}}`,
+			expected: `
+server:
+  http_listen_port: 3200
+  log_format: logfmt
+  log_level: debug
+memberlist:
+  join_members:
+  - tempo-test-gossip-ring
+querier:
+  max_concurrent_queries: 20
+storage:
+  trace:
+    pool:
+      max_workers: 200
+`,
+		},
+		{
+			name:        "override settings",
+			extraConfig: `{"querier":{"max_concurrent_queries":50},"memberlist":{"join_members":["a","b"]}}`,
+			expected: `
+server:
+  http_listen_port: 3200
+  log_format: logfmt
+memberlist:
+  join_members:
+  - a
+  - b
+querier:
+  max_concurrent_queries: 50
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			merged, err := mergeExtraConfig([]byte(cfg), []byte(tc.extraConfig))
+			require.NoError(t, err)
+			require.YAMLEq(t, tc.expected, string(merged))
+		})
+	}
+}
+
+func TestMergeExtraConfigInvalid(t *testing.T) {
+	_, err := mergeExtraConfig([]byte("server: {}"), []byte(`["server"]`))
+	require.Error(t, err)
+}
@@ -6,13 +6,32 @@ import "github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 type options struct {
 	StorageType            string
 	GlobalRetention        string
+	Compaction             compactionOptions
+	Ingester               ingesterOptions
 	QueryFrontendDiscovery string
+	QueryFrontendRetries   *int
 	StorageParams          manifestutils.StorageParams
+	StorageHedging         hedgingOptions
+	BlockFormat            string
+	Cache                  *cacheOptions
+	ResultsCache           *cacheOptions
 	GlobalRateLimits       rateLimitsOptions
 	TenantRateLimitsPath   string
 	TLS                    tlsOptions
-	MemberList             []string
+	ReceiverTLS            receiverTLSOptions
+	KafkaReceiver          *kafkaReceiverOptions
+	ZipkinReceiver         *zipkinReceiverOptions
+	JaegerReceiver         *jaegerReceiverOptions
+	LogReceivedSpans       *logReceivedSpansOptions
+	MetricsGenerator       *metricsGeneratorOptions
+	OTLPReceiver           otlpReceiverOptions
+	ServerPorts            serverPortsOptions
+	GRPCServer             grpcServerOptions
+	Memberlist             memberlistOptions
+	KVStore                kvStoreOptions
 	Search                 searchOptions
+	HTTPServerTimeout      string
+	LogFormat              string
 	ReplicationFactor      int
 	Multitenancy           bool
 	Gateway                bool
@@ -22,7 +41,7 @@ type options struct {
 type tempoQueryOptions struct {
 	Gates        featureGates
 	TLS          tlsOptions
-	HTTPPort     int
+	HTTPPort     int32
 	TenantHeader string
 	Gateway      bool
 }
@@ -43,10 +62,20 @@ type rateLimitsOptions struct {
 	MaxTracesPerUser        *int
 	MaxBytesPerTagValues    *int
 	MaxSearchDuration       string
+	// BlockRetention is only supported in the tenant overrides.
+	BlockRetention string
+}
+
+type hedgingOptions struct {
+	RequestsAt   string
+	RequestsUpTo int
 }
 
 type searchOptions struct {
 	MaxDuration               string
+	QueryBackendAfter         string
+	QueryIngestersUntil       string
+	TargetBytesPerJob         int
 	QueryTimeout              string
 	ExternalHedgeRequestsAt   string
 	ExternalHedgeRequestsUpTo int
@@ -62,6 +91,8 @@ type tlsOptions struct {
 	Paths       tlsFilePaths
 	ServerNames serverNames
 	Profile     tlsProfileOptions
+	// ClientAuth requires and verifies client certificates on the internal servers.
+	ClientAuth bool
 }
 
 // TLSProfileSpec is the desired behavior of a TLSProfileType.
@@ -76,6 +107,211 @@ type tlsProfileOptions struct {
 	MinTLSVersionShort string
 }
 
+type receiverTLSOptions struct {
+	Enabled  bool
+	OTLPGRPC receiverTLSSettings
+	OTLPHTTP receiverTLSSettings
+	Jaeger   receiverTLSSettings
+	Zipkin   receiverTLSSettings
+}
+
+type receiverTLSSettings struct {
+	Paths              tlsFilePaths
+	MinTLSVersionShort string
+}
+
+type serverPortsOptions struct {
+	HTTP int32
+	GRPC int32
+}
+
+// grpcServerOptions contains the settings of the gRPC servers, unset durations are empty.
+type grpcServerOptions struct {
+	MaxRecvMsgSize        int
+	MaxSendMsgSize        int
+	KeepaliveTime         string
+	KeepaliveTimeout      string
+	MaxConnectionAge      string
+	MaxConnectionAgeGrace string
+	// ClientMsgSizes is true if the message sizes of the gRPC clients of the query path are aligned with the server.
+	ClientMsgSizes bool
+}
+
+type otlpReceiverOptions struct {
+	GRPCPort int32
+	HTTPPort int32
+	GRPC     otlpGRPCOptions
+}
+
+// cacheOptions contains the settings of the external cache, it is nil if no cache is configured.
+type cacheOptions struct {
+	Backend   string
+	Endpoints string
+	// Host and Service are used to discover the instances of the memcached cache managed by the operator with DNS SRV lookups.
+	Host    string
+	Service string
+	Timeout string
+	// Expiration is only set for the results cache of the query-frontend.
+	Expiration string
+	// Password is a reference to the environment variable holding the password.
+	Password string
+	TLS      *cacheTLSOptions
+}
+
+type cacheTLSOptions struct {
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	ServerName         string
+	InsecureSkipVerify bool
+	// MinTLSVersion and Ciphers are set from the TLS profile of the operator.
+	MinTLSVersion string
+	Ciphers       string
+}
+
+// memberlistOptions contains the settings of the memberlist, empty values use the Tempo defaults.
+type memberlistOptions struct {
+	JoinMembers    []string
+	BindPort       int32
+	GossipInterval string
+	// InstanceAddr is the address advertised to the memberlist and registered in the hash rings.
+	InstanceAddr string
+	TLS          *memberlistTLSOptions
+}
+
+type memberlistTLSOptions struct {
+	CertFile   string
+	KeyFile    string
+	CAFile     string
+	ServerName string
+	// MinTLSVersion and Ciphers are set from the TLS profile of the operator.
+	MinTLSVersion string
+	Ciphers       string
+}
+
+// kvStoreOptions contains the settings of the key-value store of the hash rings.
+type kvStoreOptions struct {
+	Store  string
+	Prefix string
+	Etcd   *etcdOptions
+	Consul *consulOptions
+}
+
+type etcdOptions struct {
+	Endpoints []string
+	// Username and Password are references to the environment variables holding the credentials.
+	Username string
+	Password string
+	TLS      *etcdTLSOptions
+}
+
+type etcdTLSOptions struct {
+	CAFile     string
+	CertFile   string
+	KeyFile    string
+	ServerName string
+	// MinTLSVersion and Ciphers are set from the TLS profile of the operator.
+	MinTLSVersion string
+	Ciphers       string
+}
+
+type consulOptions struct {
+	Host string
+	// ACLToken is a reference to the environment variable holding the token.
+	ACLToken string
+}
+
+// compactionOptions contains the tuning parameters of the compactor, empty values use the Tempo defaults.
+type compactionOptions struct {
+	CompactionWindow     string
+	MaxBlockBytes        *int
+	RetentionConcurrency *int
+	CompactionCycle      string
+}
+
+// ingesterOptions contains the tuning parameters of the ingester, empty values use the Tempo defaults.
+type ingesterOptions struct {
+	TraceIdlePeriod      string
+	MaxBlockDuration     string
+	MaxBlockBytes        *int
+	CompleteBlockTimeout string
+	FlushCheckPeriod     string
+	ConcurrentFlushes    *int
+	ZoneAwareReplication bool
+}
+
+// metricsGeneratorOptions contains the settings of the metrics-generator, it is nil if the metrics-generator is disabled.
+type metricsGeneratorOptions struct {
+	Processors  []string
+	RemoteWrite []remoteWriteOptions
+}
+
+// remoteWriteOptions contains the settings of a remote write endpoint of the metrics-generator.
+type remoteWriteOptions struct {
+	URL     string
+	Headers map[string]string
+	// BasicAuth contains references to the environment variables holding the username and password.
+	BasicAuth *remoteWriteBasicAuthOptions
+	// BearerToken is a reference to the environment variable holding the token.
+	BearerToken     string
+	BearerTokenFile string
+	TLS             *remoteWriteTLSOptions
+}
+
+type remoteWriteBasicAuthOptions struct {
+	Username string
+	Password string
+}
+
+type remoteWriteTLSOptions struct {
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	InsecureSkipVerify bool
+}
+
+// logReceivedSpansOptions contains the settings of the logging of received spans, it is nil if the logging is disabled.
+type logReceivedSpansOptions struct {
+	IncludeAllAttributes bool
+	FilterByStatusError  bool
+}
+
+// otlpGRPCOptions contains the server settings of the OTLP gRPC protocol, unset settings are nil.
+type otlpGRPCOptions struct {
+	MaxRecvMsgSizeMiB    *int
+	MaxConcurrentStreams *int
+	ReadBufferSize       *int
+	WriteBufferSize      *int
+}
+
+// jaegerReceiverOptions contains the ports of the enabled Jaeger protocols, the port of a disabled protocol is zero.
+type jaegerReceiverOptions struct {
+	ThriftHTTP    int32
+	ThriftBinary  int32
+	ThriftCompact int32
+	GRPC          int32
+}
+
+type zipkinReceiverOptions struct {
+	Port int32
+}
+
+type kafkaReceiverOptions struct {
+	Brokers         []string
+	Topic           string
+	Encoding        string
+	GroupID         string
+	ProtocolVersion string
+	// SASLMechanism is empty if SASL authentication is disabled.
+	SASLMechanism string
+	TLS           *kafkaTLSOptions
+}
+
+type kafkaTLSOptions struct {
+	CAFile             string
+	InsecureSkipVerify bool
+}
+
 type tlsFilePaths struct {
 	CA          string
 	Certificate string
@@ -83,8 +319,9 @@ type tlsFilePaths struct {
 }
 
 type serverNames struct {
-	Compactor     string
-	Ingester      string
-	QueryFrontend string
-	Querier       string
+	Compactor        string
+	Ingester         string
+	QueryFrontend    string
+	Querier          string
+	MetricsGenerator string
 }
@@ -0,0 +1,53 @@
+package containerenv
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+// ConfigurePods adds the environment variables of the component templates to the containers
+// of the Deployments and StatefulSets of the components.
+// Environment variables which are already set by the operator are not overwritten.
+// It must be called before the extra containers are added to the pods.
+func ConfigurePods(tempo v1alpha1.TempoStack, objs []client.Object) {
+	for _, obj := range objs {
+		var pod *corev1.PodSpec
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			pod = &o.Spec.Template.Spec
+		case *appsv1.StatefulSet:
+			pod = &o.Spec.Template.Spec
+		default:
+			continue
+		}
+
+		spec := manifestutils.ComponentSpec(tempo, obj.GetLabels()["app.kubernetes.io/component"])
+		if spec == nil || (len(spec.Env) == 0 && len(spec.EnvFrom) == 0) {
+			continue
+		}
+
+		for i := range pod.Containers {
+			configureContainer(&pod.Containers[i], spec.Env, spec.EnvFrom)
+		}
+	}
+}
+
+func configureContainer(container *corev1.Container, env []corev1.EnvVar, envFrom []corev1.EnvFromSource) {
+	existing := map[string]bool{}
+	for _, e := range container.Env {
+		existing[e.Name] = true
+	}
+	for _, e := range env {
+		if existing[e.Name] {
+			continue
+		}
+		container.Env = append(container.Env, *e.DeepCopy())
+	}
+	for _, e := range envFrom {
+		container.EnvFrom = append(container.EnvFrom, *e.DeepCopy())
+	}
+}
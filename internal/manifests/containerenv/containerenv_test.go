@@ -0,0 +1,85 @@
+package containerenv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestConfigurePods(t *testing.T) {
+	envFrom := corev1.EnvFromSource{
+		SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "proxy"}},
+	}
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Querier: v1alpha1.TempoQuerierSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						Env: []corev1.EnvVar{
+							{Name: "HTTPS_PROXY", Value: "http://proxy:3128"},
+							{Name: "GOMEMLIMIT", Value: "1"},
+						},
+						EnvFrom: []corev1.EnvFromSource{envFrom},
+					},
+				},
+			},
+		},
+	}
+
+	ingester := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: manifestutils.ComponentLabels(manifestutils.IngesterComponentName, "test"),
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "tempo"}},
+				},
+			},
+		},
+	}
+	querier := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: manifestutils.ComponentLabels(manifestutils.QuerierComponentName, "test"),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "tempo", Env: []corev1.EnvVar{{Name: "GOMEMLIMIT", Value: "900"}}},
+						{Name: "tempo-query"},
+					},
+				},
+			},
+		},
+	}
+
+	ConfigurePods(tempo, []client.Object{ingester, querier, &corev1.ConfigMap{}})
+
+	assert.Equal(t, []corev1.Container{{Name: "tempo"}}, ingester.Spec.Template.Spec.Containers)
+	assert.Equal(t, []corev1.Container{
+		{
+			Name: "tempo",
+			Env: []corev1.EnvVar{
+				{Name: "GOMEMLIMIT", Value: "900"},
+				{Name: "HTTPS_PROXY", Value: "http://proxy:3128"},
+			},
+			EnvFrom: []corev1.EnvFromSource{envFrom},
+		},
+		{
+			Name: "tempo-query",
+			Env: []corev1.EnvVar{
+				{Name: "HTTPS_PROXY", Value: "http://proxy:3128"},
+				{Name: "GOMEMLIMIT", Value: "1"},
+			},
+			EnvFrom: []corev1.EnvFromSource{envFrom},
+		},
+	}, querier.Spec.Template.Spec.Containers)
+}
@@ -1,8 +1,12 @@
 package distributor
 
 import (
+	"fmt"
+
+	routev1 "github.com/openshift/api/route/v1"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -12,6 +16,23 @@ import (
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/memberlist"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
+	"github.com/grafana/tempo-operator/internal/manifests/spiffe"
+)
+
+const (
+	receiverTLSVolumeName = "receiver-tls"
+	receiverCAVolumeName  = "receiver-ca"
+	kafkaCAVolumeName     = "kafka-ca"
+	zipkinIngressName     = "distributor-zipkin"
+	otlpGRPCIngressName   = "distributor-otlp-grpc"
+	otlpHTTPIngressName   = "distributor-otlp-http"
+
+	// backendProtocolAnnotation instructs the ingress-nginx controller to proxy gRPC requests to the backend.
+	backendProtocolAnnotation = "nginx.ingress.kubernetes.io/backend-protocol"
+
+	// kafkaSASLUsernameEnv and kafkaSASLPasswordEnv are referenced by the Kafka receiver in the Tempo configuration.
+	kafkaSASLUsernameEnv = "KAFKA_SASL_USERNAME"
+	kafkaSASLPasswordEnv = "KAFKA_SASL_PASSWORD"
 )
 
 // BuildDistributor creates distributor objects.
@@ -24,7 +45,15 @@ func BuildDistributor(params manifestutils.Params) ([]client.Object, error) {
 	}
 	gates := params.Gates
 	tempo := params.Tempo
-	if gates.HTTPEncryption || gates.GRPCEncryption {
+	manifestutils.ConfigureRuntimeOverrides(tempo, &dep.Spec.Template.Spec)
+	manifestutils.ConfigureMemberlist(tempo, &dep.Spec.Template.Spec)
+	manifestutils.ConfigureKVStore(tempo, &dep.Spec.Template.Spec)
+
+	if (gates.HTTPEncryption || gates.GRPCEncryption) && tempo.Spec.SPIFFE != nil {
+		if err := spiffe.ConfigurePodSpec(tempo, &dep.Spec.Template.Spec); err != nil {
+			return nil, err
+		}
+	} else if gates.HTTPEncryption || gates.GRPCEncryption {
 		caBundleName := naming.SigningCABundleName(tempo.Name)
 		if err := manifestutils.ConfigureServiceCA(&dep.Spec.Template.Spec, caBundleName); err != nil {
 			return nil, err
@@ -35,66 +64,229 @@ func BuildDistributor(params manifestutils.Params) ([]client.Object, error) {
 		}
 	}
 
-	return []client.Object{dep, service(tempo)}, nil
+	if tempo.Spec.Template.Distributor.TLS.Enabled && !tempo.Spec.Template.Gateway.Enabled {
+		configureReceiversTLS(tempo, &dep.Spec.Template.Spec)
+	}
+
+	if kafka := tempo.Spec.Template.Distributor.Receivers.Kafka; kafka != nil {
+		configureKafkaReceiver(*kafka, &dep.Spec.Template.Spec)
+	}
+
+	objs := []client.Object{dep, service(tempo)}
+	if autoscaling := tempo.Spec.Template.Distributor.Autoscaling; autoscaling != nil {
+		objs = append(objs, manifestutils.Autoscaler(tempo, manifestutils.DistributorComponentName, *autoscaling))
+	}
+	if manifestutils.MultipleReplicas(dep.Spec.Replicas, tempo.Spec.Template.Distributor.Autoscaling) {
+		objs = append(objs, manifestutils.PodDisruptionBudget(tempo, manifestutils.DistributorComponentName, tempo.Spec.Template.Distributor.PodDisruptionBudget))
+	}
+
+	type exposedReceiver struct {
+		name     string
+		portName string
+		spec     v1alpha1.IngressSpec
+	}
+	var receivers []exposedReceiver
+	if enabled, _ := manifestutils.ZipkinReceiver(tempo); enabled {
+		if zipkin := tempo.Spec.Template.Distributor.Receivers.Zipkin; zipkin != nil {
+			receivers = append(receivers, exposedReceiver{zipkinIngressName, manifestutils.PortZipkinName, zipkin.Ingress})
+		}
+	}
+	if otlp := tempo.Spec.Template.Distributor.Receivers.OTLP; otlp != nil && !tempo.Spec.Template.Gateway.Enabled {
+		grpcIngress := otlp.GRPCIngress
+		if grpcIngress.Type == v1alpha1.IngressTypeIngress {
+			grpcIngress.Annotations = grpcIngressAnnotations(tempo, grpcIngress.Annotations)
+		}
+		receivers = append(receivers,
+			exposedReceiver{otlpGRPCIngressName, manifestutils.OtlpGrpcPortName, grpcIngress},
+			exposedReceiver{otlpHTTPIngressName, manifestutils.PortOtlpHttpName, otlp.HTTPIngress},
+		)
+	}
+
+	for _, receiver := range receivers {
+		switch receiver.spec.Type {
+		case v1alpha1.IngressTypeIngress:
+			objs = append(objs, ingress(tempo, receiver.name, receiver.portName, receiver.spec))
+		case v1alpha1.IngressTypeRoute:
+			routeObj, err := route(tempo, receiver.name, receiver.portName, receiver.spec)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, routeObj)
+		}
+	}
+
+	return objs, nil
+}
+
+// configureReceiversTLS mounts the serving certificate and the client CA bundle of the receivers into the tempo container,
+// as well as the certificates and CA bundles which override them for single receivers.
+func configureReceiversTLS(tempo v1alpha1.TempoStack, podSpec *corev1.PodSpec) {
+	secretName := tempo.Spec.Template.Distributor.TLS.CertName
+	if secretName == "" {
+		secretName = naming.TLSSecretName(manifestutils.DistributorComponentName, tempo.Name)
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: receiverTLSVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	})
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      receiverTLSVolumeName,
+		MountPath: manifestutils.ReceiverTLSDir(),
+		ReadOnly:  true,
+	})
+
+	if caName := tempo.Spec.Template.Distributor.TLS.CA; caName != "" {
+		mountReceiverCABundle(podSpec, receiverCAVolumeName, caName, manifestutils.ReceiverCABundleDir())
+	}
+
+	for _, override := range manifestutils.ReceiverTLSOverrides(tempo.Spec.Template.Distributor.TLS) {
+		if override.Spec.CertName != "" {
+			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+				Name: fmt.Sprintf("%s-%s", receiverTLSVolumeName, override.Receiver),
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: override.Spec.CertName,
+					},
+				},
+			})
+			podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+				Name:      fmt.Sprintf("%s-%s", receiverTLSVolumeName, override.Receiver),
+				MountPath: manifestutils.ReceiverOverrideTLSDir(override.Receiver),
+				ReadOnly:  true,
+			})
+		}
+		if override.Spec.CA != "" {
+			mountReceiverCABundle(podSpec, fmt.Sprintf("%s-%s", receiverCAVolumeName, override.Receiver),
+				override.Spec.CA, manifestutils.ReceiverOverrideCABundleDir(override.Receiver))
+		}
+	}
+}
+
+func mountReceiverCABundle(podSpec *corev1.PodSpec, volumeName string, caName string, mountPath string) {
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: caName,
+				},
+			},
+		},
+	})
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      volumeName,
+		MountPath: mountPath,
+		ReadOnly:  true,
+	})
+}
+
+// configureKafkaReceiver exposes the SASL credentials to the tempo container and mounts the CA bundle of the Kafka brokers.
+func configureKafkaReceiver(kafka v1alpha1.KafkaReceiverSpec, podSpec *corev1.PodSpec) {
+	container := &podSpec.Containers[0]
+
+	if kafka.SASL != nil {
+		container.Args = append(container.Args, "-config.expand-env=true")
+		container.Env = append(container.Env,
+			corev1.EnvVar{
+				Name: kafkaSASLUsernameEnv,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: kafka.SASL.Secret},
+						Key:                  "username",
+					},
+				},
+			},
+			corev1.EnvVar{
+				Name: kafkaSASLPasswordEnv,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: kafka.SASL.Secret},
+						Key:                  "password",
+					},
+				},
+			},
+		)
+	}
+
+	if kafka.TLS == nil || kafka.TLS.CA == "" {
+		return
+	}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: kafkaCAVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: kafka.TLS.CA,
+				},
+			},
+		},
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      kafkaCAVolumeName,
+		MountPath: manifestutils.KafkaCABundleDir(),
+		ReadOnly:  true,
+	})
 }
 
 func deployment(params manifestutils.Params) *v1.Deployment {
 	tempo := params.Tempo
+	httpPort, _ := manifestutils.ServerPorts(tempo)
+	otlpGRPCPort, otlpHTTPPort := manifestutils.OTLPReceiverPorts(tempo)
 	labels := manifestutils.ComponentLabels(manifestutils.DistributorComponentName, tempo.Name)
-	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum)
+	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum, params.Tempo.Annotations[manifestutils.CertRotationRequiredAtAnnotation])
 	cfg := tempo.Spec.Template.Distributor
 
 	containerPorts := []corev1.ContainerPort{
 		{
 			Name:          manifestutils.OtlpGrpcPortName,
-			ContainerPort: manifestutils.PortOtlpGrpcServer,
+			ContainerPort: otlpGRPCPort,
 			Protocol:      corev1.ProtocolTCP,
 		},
 		{
 			Name:          manifestutils.HttpPortName,
-			ContainerPort: manifestutils.PortHTTPServer,
+			ContainerPort: httpPort,
 			Protocol:      corev1.ProtocolTCP,
 		},
 		{
 			Name:          manifestutils.HttpMemberlistPortName,
-			ContainerPort: manifestutils.PortMemberlist,
+			ContainerPort: manifestutils.MemberlistPort(tempo),
 			Protocol:      corev1.ProtocolTCP,
 		},
 	}
 
 	if !tempo.Spec.Template.Gateway.Enabled {
-		containerPorts = append(containerPorts, []corev1.ContainerPort{
-			{
-				Name:          manifestutils.PortOtlpHttpName,
-				ContainerPort: manifestutils.PortOtlpHttp,
-				Protocol:      corev1.ProtocolTCP,
-			},
-			{
-				Name:          manifestutils.PortJaegerThriftHTTPName,
-				ContainerPort: manifestutils.PortJaegerThriftHTTP,
-				Protocol:      corev1.ProtocolTCP,
-			},
-			{
-				Name:          manifestutils.PortJaegerThriftCompactName,
-				ContainerPort: manifestutils.PortJaegerThriftCompact,
-				Protocol:      corev1.ProtocolUDP,
-			},
-			{
-				Name:          manifestutils.PortJaegerThriftBinaryName,
-				ContainerPort: manifestutils.PortJaegerThriftBinary,
-				Protocol:      corev1.ProtocolUDP,
-			},
-			{
-				Name:          manifestutils.PortJaegerGrpcName,
-				ContainerPort: manifestutils.PortJaegerGrpc,
-				Protocol:      corev1.ProtocolTCP,
-			},
-			{
-				Name:          manifestutils.PortZipkinName,
-				ContainerPort: manifestutils.PortZipkin,
-				Protocol:      corev1.ProtocolTCP,
-			},
-		}...)
+		containerPorts = append(containerPorts, corev1.ContainerPort{
+			Name:          manifestutils.PortOtlpHttpName,
+			ContainerPort: otlpHTTPPort,
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
+
+	for _, jaegerProtocol := range manifestutils.JaegerReceiverProtocols(tempo) {
+		containerPorts = append(containerPorts, corev1.ContainerPort{
+			Name:          jaegerProtocol.PortName,
+			ContainerPort: jaegerProtocol.Port,
+			Protocol:      jaegerProtocol.Protocol,
+		})
+	}
+
+	if enabled, port := manifestutils.ZipkinReceiver(tempo); enabled {
+		containerPorts = append(containerPorts, corev1.ContainerPort{
+			Name:          manifestutils.PortZipkinName,
+			ContainerPort: port,
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
+
+	// The replicas of an autoscaled distributor are managed by the HorizontalPodAutoscaler.
+	replicas := cfg.Replicas
+	if cfg.Autoscaling != nil {
+		replicas = nil
 	}
 
 	return &v1.Deployment{
@@ -107,21 +299,23 @@ func deployment(params manifestutils.Params) *v1.Deployment {
 			Labels:    labels,
 		},
 		Spec: v1.DeploymentSpec{
-			Replicas: tempo.Spec.Template.Distributor.Replicas,
+			Replicas: replicas,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
 			Template: corev1.PodTemplateSpec{
 
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      k8slabels.Merge(labels, memberlist.GossipSelector),
-					Annotations: annotations,
+					Labels:      manifestutils.PodLabels(cfg.PodLabels, k8slabels.Merge(labels, memberlist.GossipSelector)),
+					Annotations: manifestutils.PodAnnotations(cfg.PodAnnotations, annotations),
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: tempo.Spec.ServiceAccount,
-					NodeSelector:       cfg.NodeSelector,
-					Tolerations:        cfg.Tolerations,
-					Affinity:           manifestutils.DefaultAffinity(labels),
+					ServiceAccountName:        tempo.Spec.ServiceAccount,
+					NodeSelector:              cfg.NodeSelector,
+					Tolerations:               cfg.Tolerations,
+					TopologySpreadConstraints: manifestutils.TopologySpreadConstraints(cfg.TopologySpreadConstraints, labels),
+					PriorityClassName:         cfg.PriorityClassName,
+					Affinity:                  manifestutils.Affinity(cfg.Affinity, labels),
 					Containers: []corev1.Container{
 						{
 							Name:  "tempo",
@@ -129,7 +323,7 @@ func deployment(params manifestutils.Params) *v1.Deployment {
 							Args: []string{
 								"-target=distributor",
 								"-config.file=/conf/tempo.yaml",
-								"-log.level=info",
+								manifestutils.LogLevelArg(tempo, cfg.TempoComponentSpec),
 							},
 							Ports:          containerPorts,
 							ReadinessProbe: manifestutils.TempoReadinessProbe(params.Gates.HTTPEncryption),
@@ -174,12 +368,13 @@ func deployment(params manifestutils.Params) *v1.Deployment {
 
 func service(tempo v1alpha1.TempoStack) *corev1.Service {
 	labels := manifestutils.ComponentLabels(manifestutils.DistributorComponentName, tempo.Name)
+	otlpGRPCPort, otlpHTTPPort := manifestutils.OTLPReceiverPorts(tempo)
 
 	servicePorts := []corev1.ServicePort{
 		{
 			Name:       manifestutils.OtlpGrpcPortName,
 			Protocol:   corev1.ProtocolTCP,
-			Port:       manifestutils.PortOtlpGrpcServer,
+			Port:       otlpGRPCPort,
 			TargetPort: intstr.FromString(manifestutils.OtlpGrpcPortName),
 		},
 		{
@@ -191,55 +386,121 @@ func service(tempo v1alpha1.TempoStack) *corev1.Service {
 	}
 
 	if !tempo.Spec.Template.Gateway.Enabled {
-		servicePorts = append(servicePorts, []corev1.ServicePort{
-			{
-				Name:       manifestutils.PortOtlpHttpName,
-				Port:       manifestutils.PortOtlpHttp,
-				TargetPort: intstr.FromString(manifestutils.PortOtlpHttpName),
-				Protocol:   corev1.ProtocolTCP,
-			},
-			{
-				Name:       manifestutils.PortJaegerThriftHTTPName,
-				Port:       manifestutils.PortJaegerThriftHTTP,
-				TargetPort: intstr.FromString(manifestutils.PortJaegerThriftHTTPName),
-				Protocol:   corev1.ProtocolTCP,
-			},
-			{
-				Name:       manifestutils.PortJaegerThriftCompactName,
-				Port:       manifestutils.PortJaegerThriftCompact,
-				TargetPort: intstr.FromString(manifestutils.PortJaegerThriftCompactName),
-				Protocol:   corev1.ProtocolUDP,
-			},
-			{
-				Name:       manifestutils.PortJaegerThriftBinaryName,
-				Port:       manifestutils.PortJaegerThriftBinary,
-				TargetPort: intstr.FromString(manifestutils.PortJaegerThriftBinaryName),
-				Protocol:   corev1.ProtocolUDP,
-			},
-			{
-				Name:       manifestutils.PortJaegerGrpcName,
-				Port:       manifestutils.PortJaegerGrpc,
-				TargetPort: intstr.FromString(manifestutils.PortJaegerGrpcName),
-				Protocol:   corev1.ProtocolTCP,
-			},
-			{
-				Name:       manifestutils.PortZipkinName,
-				Port:       manifestutils.PortZipkin,
-				TargetPort: intstr.FromString(manifestutils.PortZipkinName),
-				Protocol:   corev1.ProtocolTCP,
-			},
-		}...)
+		servicePorts = append(servicePorts, corev1.ServicePort{
+			Name:       manifestutils.PortOtlpHttpName,
+			Port:       otlpHTTPPort,
+			TargetPort: intstr.FromString(manifestutils.PortOtlpHttpName),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+
+	for _, jaegerProtocol := range manifestutils.JaegerReceiverProtocols(tempo) {
+		servicePorts = append(servicePorts, corev1.ServicePort{
+			Name:       jaegerProtocol.PortName,
+			Port:       jaegerProtocol.Port,
+			TargetPort: intstr.FromString(jaegerProtocol.PortName),
+			Protocol:   jaegerProtocol.Protocol,
+		})
+	}
+
+	if enabled, port := manifestutils.ZipkinReceiver(tempo); enabled {
+		servicePorts = append(servicePorts, corev1.ServicePort{
+			Name:       manifestutils.PortZipkinName,
+			Port:       port,
+			TargetPort: intstr.FromString(manifestutils.PortZipkinName),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+
+	serviceType := tempo.Spec.Template.Distributor.ServiceType
+	if serviceType == "" {
+		serviceType = corev1.ServiceTypeClusterIP
 	}
 
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      naming.Name(manifestutils.DistributorComponentName, tempo.Name),
-			Namespace: tempo.Namespace,
-			Labels:    labels,
+			Name:        naming.Name(manifestutils.DistributorComponentName, tempo.Name),
+			Namespace:   tempo.Namespace,
+			Labels:      manifestutils.ServiceLabels(tempo.Spec.Template.Distributor.ServiceLabels, labels),
+			Annotations: manifestutils.ServiceAnnotations(tempo.Spec.Template.Distributor.ServiceAnnotations, nil),
 		},
 		Spec: corev1.ServiceSpec{
+			Type:     serviceType,
 			Ports:    servicePorts,
 			Selector: labels,
 		},
 	}
 }
+
+// grpcIngressAnnotations returns the annotations of the Ingress of the OTLP gRPC receiver.
+// The backend protocol is set to gRPC, unless it is specified explicitly.
+func grpcIngressAnnotations(tempo v1alpha1.TempoStack, annotations map[string]string) map[string]string {
+	merged := make(map[string]string, len(annotations)+1)
+	merged[backendProtocolAnnotation] = "GRPC"
+	if tempo.Spec.Template.Distributor.TLS.Enabled {
+		merged[backendProtocolAnnotation] = "GRPCS"
+	}
+	for key, value := range annotations {
+		merged[key] = value
+	}
+	return merged
+}
+
+func ingress(tempo v1alpha1.TempoStack, name string, portName string, spec v1alpha1.IngressSpec) *networkingv1.Ingress {
+	distributorName := naming.Name(manifestutils.DistributorComponentName, tempo.Name)
+	labels := manifestutils.ComponentLabels(manifestutils.DistributorComponentName, tempo.Name)
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.Name(name, tempo.Name),
+			Namespace:   tempo.Namespace,
+			Labels:      manifestutils.IngressLabels(spec.Labels, labels),
+			Annotations: spec.Annotations,
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: spec.IngressClassName,
+		},
+	}
+
+	backend := networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: distributorName,
+			Port: networkingv1.ServiceBackendPort{
+				Name: portName,
+			},
+		},
+	}
+
+	manifestutils.ConfigureIngressHosts(ingress, spec, backend)
+
+	return ingress
+}
+
+func route(tempo v1alpha1.TempoStack, name string, portName string, spec v1alpha1.IngressSpec) (*routev1.Route, error) {
+	labels := manifestutils.ComponentLabels(manifestutils.DistributorComponentName, tempo.Name)
+
+	tlsCfg, err := manifestutils.RouteTLSConfig(spec.Route, manifestutils.RouteCertificates{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.Name(name, tempo.Name),
+			Namespace:   tempo.Namespace,
+			Labels:      manifestutils.IngressLabels(spec.Labels, labels),
+			Annotations: spec.Annotations,
+		},
+		Spec: routev1.RouteSpec{
+			Host: spec.Host,
+			To: routev1.RouteTargetReference{
+				Kind: "Service",
+				Name: naming.Name(manifestutils.DistributorComponentName, tempo.Name),
+			},
+			Port: &routev1.RoutePort{
+				TargetPort: intstr.FromString(portName),
+			},
+			TLS: tlsCfg,
+		},
+	}, nil
+}
@@ -3,10 +3,12 @@ package distributor
 import (
 	"testing"
 
+	routev1 "github.com/openshift/api/route/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
@@ -198,12 +200,14 @@ func TestBuildDistributor(t *testing.T) {
 					},
 					ServiceAccount: "tempo-test-serviceaccount",
 					Template: v1alpha1.TempoTemplateSpec{
-						Distributor: v1alpha1.TempoComponentSpec{
-							Replicas:     pointer.Int32(1),
-							NodeSelector: map[string]string{"a": "b"},
-							Tolerations: []corev1.Toleration{
-								{
-									Key: "c",
+						Distributor: v1alpha1.TempoDistributorSpec{
+							TempoComponentSpec: v1alpha1.TempoComponentSpec{
+								Replicas:     pointer.Int32(1),
+								NodeSelector: map[string]string{"a": "b"},
+								Tolerations: []corev1.Toleration{
+									{
+										Key: "c",
+									},
 								},
 							},
 						},
@@ -224,7 +228,7 @@ func TestBuildDistributor(t *testing.T) {
 			require.NoError(t, err)
 
 			labels := manifestutils.ComponentLabels("distributor", "test")
-			annotations := manifestutils.CommonAnnotations("")
+			annotations := manifestutils.CommonAnnotations("", "")
 			assert.Equal(t, 2, len(objects))
 			assert.Equal(t, &v1.Deployment{
 				TypeMeta: metav1.TypeMeta{
@@ -321,6 +325,7 @@ func TestBuildDistributor(t *testing.T) {
 					Labels:    labels,
 				},
 				Spec: corev1.ServiceSpec{
+					Type:     corev1.ServiceTypeClusterIP,
 					Ports:    ts.expectedServicePorts,
 					Selector: labels,
 				},
@@ -328,3 +333,526 @@ func TestBuildDistributor(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildDistributor_ReceiversTLS(t *testing.T) {
+	tests := []struct {
+		name               string
+		certName           string
+		expectedSecretName string
+	}{
+		{
+			name:               "operator managed certificate",
+			expectedSecretName: "tempo-test-distributor-mtls",
+		},
+		{
+			name:               "custom certificate",
+			certName:           "receiver-cert",
+			expectedSecretName: "receiver-cert",
+		},
+	}
+
+	for _, ts := range tests {
+		t.Run(ts.name, func(t *testing.T) {
+			objects, err := BuildDistributor(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "project1",
+				},
+				Spec: v1alpha1.TempoStackSpec{
+					Template: v1alpha1.TempoTemplateSpec{
+						Distributor: v1alpha1.TempoDistributorSpec{
+							TLS: v1alpha1.ReceiversTLSSpec{
+								Enabled:  true,
+								CertName: ts.certName,
+							},
+						},
+					},
+				},
+			}})
+			require.NoError(t, err)
+
+			dep := objects[0].(*v1.Deployment)
+			assert.Contains(t, dep.Spec.Template.Spec.Volumes, corev1.Volume{
+				Name: "receiver-tls",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: ts.expectedSecretName,
+					},
+				},
+			})
+			assert.Contains(t, dep.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+				Name:      "receiver-tls",
+				MountPath: "/var/run/tls/receiver",
+				ReadOnly:  true,
+			})
+		})
+	}
+}
+
+func TestBuildDistributor_ReceiversClientCA(t *testing.T) {
+	objects, err := BuildDistributor(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Distributor: v1alpha1.TempoDistributorSpec{
+					TLS: v1alpha1.ReceiversTLSSpec{
+						Enabled: true,
+						CA:      "client-ca",
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	dep := objects[0].(*v1.Deployment)
+	assert.Contains(t, dep.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "receiver-ca",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "client-ca",
+				},
+			},
+		},
+	})
+	assert.Contains(t, dep.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "receiver-ca",
+		MountPath: "/var/run/ca/receiver",
+		ReadOnly:  true,
+	})
+}
+
+func TestBuildDistributor_KafkaReceiver(t *testing.T) {
+	objects, err := BuildDistributor(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Distributor: v1alpha1.TempoDistributorSpec{
+					Receivers: v1alpha1.ReceiversSpec{
+						Kafka: &v1alpha1.KafkaReceiverSpec{
+							Brokers: []string{"kafka:9093"},
+							SASL: &v1alpha1.KafkaSASLSpec{
+								Secret:    "kafka-credentials",
+								Mechanism: v1alpha1.KafkaSASLMechanismPlain,
+							},
+							TLS: &v1alpha1.KafkaTLSSpec{
+								CA: "kafka-ca",
+							},
+						},
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	dep := objects[0].(*v1.Deployment)
+	container := dep.Spec.Template.Spec.Containers[0]
+	assert.Contains(t, container.Args, "-config.expand-env=true")
+	assert.Equal(t, []corev1.EnvVar{
+		{
+			Name: "KAFKA_SASL_USERNAME",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "kafka-credentials"},
+					Key:                  "username",
+				},
+			},
+		},
+		{
+			Name: "KAFKA_SASL_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "kafka-credentials"},
+					Key:                  "password",
+				},
+			},
+		},
+	}, container.Env)
+	assert.Contains(t, dep.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "kafka-ca",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "kafka-ca",
+				},
+			},
+		},
+	})
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name:      "kafka-ca",
+		MountPath: "/var/run/ca/kafka",
+		ReadOnly:  true,
+	})
+}
+
+func TestBuildDistributor_ZipkinReceiver(t *testing.T) {
+	tempoStack := func(zipkin *v1alpha1.ZipkinReceiverSpec) v1alpha1.TempoStack {
+		return v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Template: v1alpha1.TempoTemplateSpec{
+					Distributor: v1alpha1.TempoDistributorSpec{
+						Receivers: v1alpha1.ReceiversSpec{Zipkin: zipkin},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		objects, err := BuildDistributor(manifestutils.Params{Tempo: tempoStack(&v1alpha1.ZipkinReceiverSpec{Enabled: false})})
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+
+		dep := objects[0].(*v1.Deployment)
+		for _, port := range dep.Spec.Template.Spec.Containers[0].Ports {
+			assert.NotEqual(t, manifestutils.PortZipkinName, port.Name)
+		}
+		svc := objects[1].(*corev1.Service)
+		for _, port := range svc.Spec.Ports {
+			assert.NotEqual(t, manifestutils.PortZipkinName, port.Name)
+		}
+	})
+
+	t.Run("custom port with ingress", func(t *testing.T) {
+		objects, err := BuildDistributor(manifestutils.Params{Tempo: tempoStack(&v1alpha1.ZipkinReceiverSpec{
+			Enabled: true,
+			Port:    9412,
+			Ingress: v1alpha1.IngressSpec{
+				Type: v1alpha1.IngressTypeIngress,
+				Host: "zipkin.example.com",
+			},
+		})})
+		require.NoError(t, err)
+		require.Len(t, objects, 3)
+
+		dep := objects[0].(*v1.Deployment)
+		assert.Contains(t, dep.Spec.Template.Spec.Containers[0].Ports, corev1.ContainerPort{
+			Name:          manifestutils.PortZipkinName,
+			ContainerPort: 9412,
+			Protocol:      corev1.ProtocolTCP,
+		})
+		svc := objects[1].(*corev1.Service)
+		assert.Contains(t, svc.Spec.Ports, corev1.ServicePort{
+			Name:       manifestutils.PortZipkinName,
+			Port:       9412,
+			TargetPort: intstr.FromString(manifestutils.PortZipkinName),
+			Protocol:   corev1.ProtocolTCP,
+		})
+
+		pathType := networkingv1.PathTypePrefix
+		ingress := objects[2].(*networkingv1.Ingress)
+		assert.Equal(t, "tempo-test-distributor-zipkin", ingress.Name)
+		assert.Equal(t, []networkingv1.IngressRule{
+			{
+				Host: "zipkin.example.com",
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{
+							{
+								Path:     "/",
+								PathType: &pathType,
+								Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{
+										Name: "tempo-test-distributor",
+										Port: networkingv1.ServiceBackendPort{
+											Name: manifestutils.PortZipkinName,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, ingress.Spec.Rules)
+	})
+
+	t.Run("route", func(t *testing.T) {
+		objects, err := BuildDistributor(manifestutils.Params{Tempo: tempoStack(&v1alpha1.ZipkinReceiverSpec{
+			Enabled: true,
+			Ingress: v1alpha1.IngressSpec{
+				Type: v1alpha1.IngressTypeRoute,
+				Route: v1alpha1.RouteSpec{
+					Termination: v1alpha1.TLSRouteTerminationTypeEdge,
+				},
+			},
+		})})
+		require.NoError(t, err)
+		require.Len(t, objects, 3)
+
+		route := objects[2].(*routev1.Route)
+		assert.Equal(t, "tempo-test-distributor-zipkin", route.Name)
+		assert.Equal(t, routev1.RouteSpec{
+			To: routev1.RouteTargetReference{
+				Kind: "Service",
+				Name: "tempo-test-distributor",
+			},
+			Port: &routev1.RoutePort{
+				TargetPort: intstr.FromString(manifestutils.PortZipkinName),
+			},
+			TLS: &routev1.TLSConfig{
+				Termination: routev1.TLSTerminationEdge,
+			},
+		}, route.Spec)
+	})
+}
+
+func TestBuildDistributor_CustomPorts(t *testing.T) {
+	objects, err := BuildDistributor(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Ports: &v1alpha1.ServerPortsSpec{HTTP: 3201},
+			Template: v1alpha1.TempoTemplateSpec{
+				Distributor: v1alpha1.TempoDistributorSpec{
+					Receivers: v1alpha1.ReceiversSpec{
+						OTLP: &v1alpha1.OTLPReceiverSpec{GRPCPort: 4319, HTTPPort: 4320},
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	dep := objects[0].(*v1.Deployment)
+	ports := dep.Spec.Template.Spec.Containers[0].Ports
+	assert.Contains(t, ports, corev1.ContainerPort{Name: manifestutils.OtlpGrpcPortName, ContainerPort: 4319, Protocol: corev1.ProtocolTCP})
+	assert.Contains(t, ports, corev1.ContainerPort{Name: manifestutils.PortOtlpHttpName, ContainerPort: 4320, Protocol: corev1.ProtocolTCP})
+	assert.Contains(t, ports, corev1.ContainerPort{Name: manifestutils.HttpPortName, ContainerPort: 3201, Protocol: corev1.ProtocolTCP})
+
+	svc := objects[1].(*corev1.Service)
+	assert.Contains(t, svc.Spec.Ports, corev1.ServicePort{
+		Name:       manifestutils.OtlpGrpcPortName,
+		Protocol:   corev1.ProtocolTCP,
+		Port:       4319,
+		TargetPort: intstr.FromString(manifestutils.OtlpGrpcPortName),
+	})
+	// The Service keeps the default port of the HTTP server.
+	assert.Contains(t, svc.Spec.Ports, corev1.ServicePort{
+		Name:       manifestutils.HttpPortName,
+		Protocol:   corev1.ProtocolTCP,
+		Port:       manifestutils.PortHTTPServer,
+		TargetPort: intstr.FromString(manifestutils.HttpPortName),
+	})
+}
+
+func TestBuildDistributor_ReceiversTLSOverrides(t *testing.T) {
+	objects, err := BuildDistributor(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Distributor: v1alpha1.TempoDistributorSpec{
+					TLS: v1alpha1.ReceiversTLSSpec{
+						Enabled:  true,
+						OTLPGRPC: &v1alpha1.ReceiverTLSOverrideSpec{CertName: "otlp-grpc-cert", CA: "otlp-grpc-ca"},
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	dep := objects[0].(*v1.Deployment)
+	assert.Contains(t, dep.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "receiver-tls-otlp-grpc",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "otlp-grpc-cert",
+			},
+		},
+	})
+	assert.Contains(t, dep.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "receiver-ca-otlp-grpc",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "otlp-grpc-ca",
+				},
+			},
+		},
+	})
+	assert.Contains(t, dep.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "receiver-tls-otlp-grpc",
+		MountPath: "/var/run/tls/receiver-otlp-grpc",
+		ReadOnly:  true,
+	})
+	assert.Contains(t, dep.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "receiver-ca-otlp-grpc",
+		MountPath: "/var/run/ca/receiver-otlp-grpc",
+		ReadOnly:  true,
+	})
+}
+
+func TestBuildDistributor_ServiceType(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Distributor: v1alpha1.TempoDistributorSpec{
+					ServiceType: corev1.ServiceTypeLoadBalancer,
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						ServiceAnnotations: map[string]string{
+							"service.beta.kubernetes.io/aws-load-balancer-type": "nlb",
+						},
+					},
+				},
+			},
+		},
+	}
+	objects, err := BuildDistributor(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+
+	svc := objects[1].(*corev1.Service)
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, svc.Spec.Type)
+	assert.Equal(t, map[string]string{
+		"service.beta.kubernetes.io/aws-load-balancer-type": "nlb",
+	}, svc.Annotations)
+
+	// The annotations of the Service must not share the map of the TempoStack spec.
+	svc.Annotations["key"] = "value"
+	assert.Len(t, tempo.Spec.Template.Distributor.ServiceAnnotations, 1)
+}
+
+func TestBuildDistributor_Autoscaling(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Distributor: v1alpha1.TempoDistributorSpec{
+					Autoscaling: &v1alpha1.AutoscalingSpec{
+						MinReplicas: pointer.Int32(2),
+						MaxReplicas: 5,
+					},
+				},
+			},
+		},
+	}
+	objects, err := BuildDistributor(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+
+	dep := objects[0].(*v1.Deployment)
+	assert.Nil(t, dep.Spec.Replicas)
+	assert.Equal(t, manifestutils.HorizontalPodAutoscaler(tempo, manifestutils.DistributorComponentName, *tempo.Spec.Template.Distributor.Autoscaling), objects[2])
+}
+
+func TestBuildDistributor_OTLPIngresses(t *testing.T) {
+	tempoStack := func(otlp *v1alpha1.OTLPReceiverSpec, tlsEnabled bool) v1alpha1.TempoStack {
+		return v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Template: v1alpha1.TempoTemplateSpec{
+					Distributor: v1alpha1.TempoDistributorSpec{
+						TLS:       v1alpha1.ReceiversTLSSpec{Enabled: tlsEnabled},
+						Receivers: v1alpha1.ReceiversSpec{OTLP: otlp},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("ingress", func(t *testing.T) {
+		objects, err := BuildDistributor(manifestutils.Params{Tempo: tempoStack(&v1alpha1.OTLPReceiverSpec{
+			GRPCIngress: v1alpha1.IngressSpec{
+				Type: v1alpha1.IngressTypeIngress,
+				Host: "otlp-grpc.example.com",
+			},
+			HTTPIngress: v1alpha1.IngressSpec{
+				Type:        v1alpha1.IngressTypeIngress,
+				Host:        "otlp-http.example.com",
+				Annotations: map[string]string{"key": "value"},
+			},
+		}, false)})
+		require.NoError(t, err)
+		require.Len(t, objects, 4)
+
+		grpcIngress := objects[2].(*networkingv1.Ingress)
+		assert.Equal(t, "tempo-test-distributor-otlp-grpc", grpcIngress.Name)
+		assert.Equal(t, map[string]string{backendProtocolAnnotation: "GRPC"}, grpcIngress.Annotations)
+		assert.Equal(t, manifestutils.OtlpGrpcPortName, grpcIngress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name)
+
+		httpIngress := objects[3].(*networkingv1.Ingress)
+		assert.Equal(t, "tempo-test-distributor-otlp-http", httpIngress.Name)
+		assert.Equal(t, map[string]string{"key": "value"}, httpIngress.Annotations)
+		assert.Equal(t, manifestutils.PortOtlpHttpName, httpIngress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name)
+	})
+
+	t.Run("gRPC ingress with TLS and custom backend protocol", func(t *testing.T) {
+		objects, err := BuildDistributor(manifestutils.Params{Tempo: tempoStack(&v1alpha1.OTLPReceiverSpec{
+			GRPCIngress: v1alpha1.IngressSpec{
+				Type:        v1alpha1.IngressTypeIngress,
+				Annotations: map[string]string{"key": "value"},
+			},
+		}, true)})
+		require.NoError(t, err)
+		require.Len(t, objects, 3)
+
+		grpcIngress := objects[2].(*networkingv1.Ingress)
+		assert.Equal(t, map[string]string{backendProtocolAnnotation: "GRPCS", "key": "value"}, grpcIngress.Annotations)
+
+		objects, err = BuildDistributor(manifestutils.Params{Tempo: tempoStack(&v1alpha1.OTLPReceiverSpec{
+			GRPCIngress: v1alpha1.IngressSpec{
+				Type:        v1alpha1.IngressTypeIngress,
+				Annotations: map[string]string{backendProtocolAnnotation: "HTTP"},
+			},
+		}, true)})
+		require.NoError(t, err)
+		grpcIngress = objects[2].(*networkingv1.Ingress)
+		assert.Equal(t, map[string]string{backendProtocolAnnotation: "HTTP"}, grpcIngress.Annotations)
+	})
+
+	t.Run("route", func(t *testing.T) {
+		objects, err := BuildDistributor(manifestutils.Params{Tempo: tempoStack(&v1alpha1.OTLPReceiverSpec{
+			GRPCIngress: v1alpha1.IngressSpec{
+				Type: v1alpha1.IngressTypeRoute,
+				Host: "otlp-grpc.example.com",
+				Route: v1alpha1.RouteSpec{
+					Termination: v1alpha1.TLSRouteTerminationTypePassthrough,
+				},
+			},
+		}, true)})
+		require.NoError(t, err)
+		require.Len(t, objects, 3)
+
+		route := objects[2].(*routev1.Route)
+		assert.Equal(t, "tempo-test-distributor-otlp-grpc", route.Name)
+		assert.Equal(t, routev1.RouteSpec{
+			Host: "otlp-grpc.example.com",
+			To: routev1.RouteTargetReference{
+				Kind: "Service",
+				Name: "tempo-test-distributor",
+			},
+			Port: &routev1.RoutePort{
+				TargetPort: intstr.FromString(manifestutils.OtlpGrpcPortName),
+			},
+			TLS: &routev1.TLSConfig{
+				Termination: routev1.TLSTerminationPassthrough,
+			},
+		}, route.Spec)
+	})
+}
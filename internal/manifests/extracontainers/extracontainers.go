@@ -0,0 +1,41 @@
+package extracontainers
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+// ConfigurePods adds the extra containers, init containers and volumes of the component templates
+// to the pods of the Deployments and StatefulSets of the components.
+func ConfigurePods(tempo v1alpha1.TempoStack, objs []client.Object) {
+	for _, obj := range objs {
+		var pod *corev1.PodSpec
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			pod = &o.Spec.Template.Spec
+		case *appsv1.StatefulSet:
+			pod = &o.Spec.Template.Spec
+		default:
+			continue
+		}
+
+		spec := manifestutils.ComponentSpec(tempo, obj.GetLabels()["app.kubernetes.io/component"])
+		if spec == nil {
+			continue
+		}
+
+		for _, container := range spec.ExtraContainers {
+			pod.Containers = append(pod.Containers, *container.DeepCopy())
+		}
+		for _, container := range spec.ExtraInitContainers {
+			pod.InitContainers = append(pod.InitContainers, *container.DeepCopy())
+		}
+		for _, volume := range spec.ExtraVolumes {
+			pod.Volumes = append(pod.Volumes, *volume.DeepCopy())
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package extracontainers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestConfigurePods(t *testing.T) {
+	sidecar := corev1.Container{Name: "log-shipper", Image: "fluent-bit"}
+	initContainer := corev1.Container{Name: "fetch-secrets", Image: "vault"}
+	volume := corev1.Volume{Name: "logs", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}
+
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoIngesterSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						ExtraContainers:     []corev1.Container{sidecar},
+						ExtraInitContainers: []corev1.Container{initContainer},
+						ExtraVolumes:        []corev1.Volume{volume},
+					},
+				},
+			},
+		},
+	}
+
+	ingester := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: manifestutils.ComponentLabels(manifestutils.IngesterComponentName, "test"),
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "tempo"}},
+				},
+			},
+		},
+	}
+	querier := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: manifestutils.ComponentLabels(manifestutils.QuerierComponentName, "test"),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "tempo"}},
+				},
+			},
+		},
+	}
+
+	ConfigurePods(tempo, []client.Object{ingester, querier})
+
+	assert.Equal(t, []corev1.Container{{Name: "tempo"}, sidecar}, ingester.Spec.Template.Spec.Containers)
+	assert.Equal(t, []corev1.Container{initContainer}, ingester.Spec.Template.Spec.InitContainers)
+	assert.Equal(t, []corev1.Volume{volume}, ingester.Spec.Template.Spec.Volumes)
+	assert.Equal(t, []corev1.Container{{Name: "tempo"}}, querier.Spec.Template.Spec.Containers)
+	assert.Nil(t, querier.Spec.Template.Spec.InitContainers)
+}
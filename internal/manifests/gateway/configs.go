@@ -5,7 +5,9 @@ import (
 	"embed"
 	"fmt"
 	"math/rand"
+	"path"
 	"text/template"
+	"time"
 
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
@@ -58,6 +60,12 @@ func newOptions(tempo v1alpha1.TempoStack, baseDomain string, oidcSecrets []*man
 			TenantID:              tenantAuth.TenantID,
 			OpenShiftCookieSecret: cookieSecret,
 			OIDC:                  tenantAuth.OIDC,
+			Audiences:             tenantAuth.Audiences,
+			RateLimits:            rateLimits(tempo, tenantAuth.TenantName),
+		}
+
+		if tenantAuth.MTLS != nil {
+			auth.MTLSCAPath = mtlsCAPath(tenantAuth.TenantName)
 		}
 
 		oidcTenantSecret := getOIDCSecret(tenantAuth.TenantName, oidcSecrets)
@@ -72,6 +80,14 @@ func newOptions(tempo v1alpha1.TempoStack, baseDomain string, oidcSecrets []*man
 		auths = append(auths, auth)
 	}
 
+	var policy *opaPolicy
+	if p := customPolicy(tempo); p != nil {
+		policy = &opaPolicy{
+			Query: p.Query,
+			Path:  path.Join(tempoGatewayMountDir, "opa", p.Key),
+		}
+	}
+
 	return options{
 		Namespace:  tempo.Namespace,
 		Name:       tempo.Name,
@@ -79,11 +95,90 @@ func newOptions(tempo v1alpha1.TempoStack, baseDomain string, oidcSecrets []*man
 		Tenants: &tenants{
 			Mode:           tempo.Spec.Tenants.Mode,
 			Authentication: auths,
-			Authorization:  tempo.Spec.Tenants.Authorization,
+			Authorization:  authorization(tempo.Spec.Tenants),
+			Policy:         policy,
 		},
 	}
 }
 
+// authorization returns the roles and role bindings of the static mode,
+// including the roles and role bindings generated from the OIDC group mappings.
+func authorization(tenants *v1alpha1.TenantsSpec) *v1alpha1.AuthorizationSpec {
+	if tenants.Mode != v1alpha1.ModeStatic {
+		return tenants.Authorization
+	}
+
+	authz := &v1alpha1.AuthorizationSpec{}
+	if tenants.Authorization != nil {
+		authz = tenants.Authorization.DeepCopy()
+	}
+
+	generated := false
+	for _, auth := range tenants.Authentication {
+		if auth.OIDC == nil {
+			continue
+		}
+		for _, mapping := range auth.OIDC.GroupMappings {
+			name := fmt.Sprintf("oidc-group-%s-%s", auth.TenantName, mapping.Group)
+			authz.Roles = append(authz.Roles, v1alpha1.RoleSpec{
+				Name:        name,
+				Resources:   []string{"traces"},
+				Tenants:     []string{auth.TenantName},
+				Permissions: mapping.Permissions,
+			})
+			authz.RoleBindings = append(authz.RoleBindings, v1alpha1.RoleBindingsSpec{
+				Name:  name,
+				Roles: []string{name},
+				Subjects: []v1alpha1.Subject{
+					{
+						Name: mapping.Group,
+						Kind: v1alpha1.Group,
+					},
+				},
+			})
+			generated = true
+		}
+	}
+
+	if !generated {
+		return tenants.Authorization
+	}
+	return authz
+}
+
+// rateLimits returns the rate limits of a tenant.
+func rateLimits(tempo v1alpha1.TempoStack, tenantName string) []rateLimit {
+	var limits []rateLimit
+	for _, spec := range tempo.Spec.Template.Gateway.RateLimits {
+		if spec.Tenant != tenantName {
+			continue
+		}
+
+		limit := rateLimit{
+			Endpoint: spec.Endpoint,
+			Limit:    spec.Limit,
+			Window:   spec.Window.Duration.String(),
+		}
+		if limit.Endpoint == "" {
+			limit.Endpoint = ".*"
+		}
+		if spec.Window.Duration == 0 {
+			limit.Window = defaultRateLimitWindow.String()
+		}
+		limits = append(limits, limit)
+	}
+	return limits
+}
+
+// customPolicy returns the custom Rego policy of the static mode, or nil if the built-in policy is used.
+func customPolicy(tempo v1alpha1.TempoStack) *v1alpha1.OPAPolicySpec {
+	tenants := tempo.Spec.Tenants
+	if tenants == nil || tenants.Mode != v1alpha1.ModeStatic || tenants.Authorization == nil {
+		return nil
+	}
+	return tenants.Authorization.Policy
+}
+
 func getTenantData(tenantName string, tenantsData []*manifestutils.GatewayTenantsData) *manifestutils.GatewayTenantsData {
 	for _, d := range tenantsData {
 		if d.TenantName == tenantName {
@@ -115,6 +210,13 @@ type tenants struct {
 
 	Authentication []authentication
 	Authorization  *v1alpha1.AuthorizationSpec
+	Policy         *opaPolicy
+}
+
+// opaPolicy configures the in-process OPA authorizer of the gateway to evaluate a custom Rego policy.
+type opaPolicy struct {
+	Query string
+	Path  string
 }
 
 type authentication struct {
@@ -125,6 +227,18 @@ type authentication struct {
 	OpenShiftCookieSecret string
 	OIDC                  *v1alpha1.OIDCSpec
 	OIDCSecret            oidcSecret
+	// MTLSCAPath is the path of the CA bundle which verifies the client certificates of the tenant.
+	MTLSCAPath string
+	// Audiences are the audiences of the service account tokens accepted for the tenant.
+	Audiences  []string
+	RateLimits []rateLimit
+}
+
+// rateLimit limits the number of HTTP requests of a tenant to an endpoint in a time window.
+type rateLimit struct {
+	Endpoint string
+	Limit    int32
+	Window   string
 }
 
 // secret for clientID, clientSecret and issuerCAPath for tenant's authentication.
@@ -135,6 +249,8 @@ type oidcSecret struct {
 }
 
 var (
+	defaultRateLimitWindow = time.Second
+
 	cookieSecretLength       = 32
 	cookieSecretAllowedRunes = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
 )
@@ -3,6 +3,7 @@ package gateway
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -139,6 +140,93 @@ func TestTenantsTemplate(t *testing.T) {
 			expected: `tenants:
 - name: dev
   id: abcd1`,
+		},
+		{
+			name: "rate limits",
+			opts: options{
+				Namespace: "default",
+				Name:      "foo",
+				Tenants: &tenants{
+					Mode: v1alpha1.ModeStatic,
+					Authentication: []authentication{
+						{
+							TenantName: "dev",
+							TenantID:   "abcd1",
+							RateLimits: []rateLimit{
+								{
+									Endpoint: ".*",
+									Limit:    100,
+									Window:   "1s",
+								},
+								{
+									Endpoint: "/api/traces/v1/dev/api/search",
+									Limit:    10,
+									Window:   "1m0s",
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: `tenants:
+- name: dev
+  id: abcd1
+  rateLimits:
+  - endpoint: ".*"
+    limit: 100
+    window: 1s
+  - endpoint: "/api/traces/v1/dev/api/search"
+    limit: 10
+    window: 1m0s`,
+		},
+		{
+			name: "mTLS",
+			opts: options{
+				Namespace: "default",
+				Name:      "foo",
+				Tenants: &tenants{
+					Mode: v1alpha1.ModeStatic,
+					Authentication: []authentication{
+						{
+							TenantName: "dev",
+							TenantID:   "abcd1",
+							MTLSCAPath: "/etc/tempo-gateway/mtls/dev/service-ca.crt",
+						},
+					},
+				},
+			},
+			expected: `tenants:
+- name: dev
+  id: abcd1
+  mTLS:
+    caPath: /etc/tempo-gateway/mtls/dev/service-ca.crt`,
+		},
+		{
+			name: "custom policy",
+			opts: options{
+				Namespace: "default",
+				Name:      "foo",
+				Tenants: &tenants{
+					Mode: v1alpha1.ModeStatic,
+					Authentication: []authentication{
+						{
+							TenantName: "dev",
+							TenantID:   "abcd1",
+						},
+					},
+					Policy: &opaPolicy{
+						Query: "data.tempostack.allow",
+						Path:  "/etc/tempo-gateway/opa/policy.rego",
+					},
+				},
+			},
+			expected: `tenants:
+- name: dev
+  id: abcd1
+  opa:
+    query: data.tempostack.allow
+    paths:
+    - /etc/tempo-gateway/opa/policy.rego`,
 		},
 		{
 			name: "with oidc",
@@ -250,6 +338,38 @@ func TestTenantsTemplate(t *testing.T) {
     serviceAccount: tempo-foo-gateway
     redirectURL: https://tempo-foo-gateway-default.apps-crc.testing/openshift/prod/callback
     cookieSecret: random2
+  opa:
+    url: http://localhost:8082/v1/data/tempostack/allow
+    withAccessToken: true`,
+		},
+		{
+			name: "openshift with token audiences",
+			opts: options{
+				Namespace:  "default",
+				Name:       "foo",
+				BaseDomain: "apps-crc.testing",
+				Tenants: &tenants{
+					Mode: v1alpha1.ModeOpenShift,
+					Authentication: []authentication{
+						{
+							TenantName:            "dev",
+							TenantID:              "abcd1",
+							OpenShiftCookieSecret: "random",
+							Audiences:             []string{"tempo-gateway", "https://kubernetes.default.svc"},
+						},
+					},
+				},
+			},
+			expected: `tenants:
+- name: dev
+  id: abcd1
+  openshift:
+    serviceAccount: tempo-foo-gateway
+    redirectURL: https://tempo-foo-gateway-default.apps-crc.testing/openshift/dev/callback
+    cookieSecret: random
+    audiences:
+    - tempo-gateway
+    - https://kubernetes.default.svc
   opa:
     url: http://localhost:8082/v1/data/tempostack/allow
     withAccessToken: true`,
@@ -327,3 +447,75 @@ func TestNewOptions(t *testing.T) {
 		},
 	}, opts)
 }
+
+func TestAuthorizationGroupMappings(t *testing.T) {
+	staticRole := v1alpha1.RoleSpec{
+		Name:        "read-write",
+		Resources:   []string{"traces"},
+		Tenants:     []string{"dev"},
+		Permissions: []v1alpha1.PermissionType{v1alpha1.Read, v1alpha1.Write},
+	}
+	tenants := &v1alpha1.TenantsSpec{
+		Mode: v1alpha1.ModeStatic,
+		Authentication: []v1alpha1.AuthenticationSpec{
+			{
+				TenantName: "dev",
+				TenantID:   "abcd1",
+				OIDC: &v1alpha1.OIDCSpec{
+					GroupClaim: "groups",
+					GroupMappings: []v1alpha1.OIDCGroupMappingSpec{
+						{
+							Group:       "dev-readers",
+							Permissions: []v1alpha1.PermissionType{v1alpha1.Read},
+						},
+					},
+				},
+			},
+		},
+		Authorization: &v1alpha1.AuthorizationSpec{
+			Roles: []v1alpha1.RoleSpec{staticRole},
+		},
+	}
+
+	authz := authorization(tenants)
+	assert.Equal(t, []v1alpha1.RoleSpec{
+		staticRole,
+		{
+			Name:        "oidc-group-dev-dev-readers",
+			Resources:   []string{"traces"},
+			Tenants:     []string{"dev"},
+			Permissions: []v1alpha1.PermissionType{v1alpha1.Read},
+		},
+	}, authz.Roles)
+	assert.Equal(t, []v1alpha1.RoleBindingsSpec{
+		{
+			Name:     "oidc-group-dev-dev-readers",
+			Roles:    []string{"oidc-group-dev-dev-readers"},
+			Subjects: []v1alpha1.Subject{{Name: "dev-readers", Kind: v1alpha1.Group}},
+		},
+	}, authz.RoleBindings)
+	// the TempoStack must not be modified
+	assert.Len(t, tenants.Authorization.Roles, 1)
+
+	tenants.Authentication[0].OIDC.GroupMappings = nil
+	assert.Same(t, tenants.Authorization, authorization(tenants))
+}
+
+func TestRateLimits(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway: v1alpha1.TempoGatewaySpec{
+					RateLimits: []v1alpha1.GatewayRateLimitSpec{
+						{Tenant: "dev", Limit: 100},
+						{Tenant: "prod", Endpoint: "/api/traces/v1/prod/.*", Limit: 10, Window: metav1.Duration{Duration: time.Minute}},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []rateLimit{{Endpoint: ".*", Limit: 100, Window: "1s"}}, rateLimits(tempo, "dev"))
+	assert.Equal(t, []rateLimit{{Endpoint: "/api/traces/v1/prod/.*", Limit: 10, Window: "1m0s"}}, rateLimits(tempo, "prod"))
+	assert.Empty(t, rateLimits(tempo, "test"))
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
+	"github.com/grafana/tempo-operator/internal/manifests/spiffe"
 )
 
 const (
@@ -54,7 +55,15 @@ func BuildGateway(params manifestutils.Params) ([]client.Object, error) {
 
 	dep := deployment(params, rbacCfgHash, tenantsCfgHash)
 
-	if params.Gates.HTTPEncryption || params.Gates.GRPCEncryption {
+	if policy := customPolicy(params.Tempo); policy != nil {
+		configureCustomPolicy(&dep.Spec.Template.Spec, policy)
+	}
+
+	if (params.Gates.HTTPEncryption || params.Gates.GRPCEncryption) && params.Tempo.Spec.SPIFFE != nil {
+		if err := spiffe.ConfigurePodSpec(params.Tempo, &dep.Spec.Template.Spec); err != nil {
+			return nil, err
+		}
+	} else if params.Gates.HTTPEncryption || params.Gates.GRPCEncryption {
 		caBundleName := naming.SigningCABundleName(params.Tempo.Name)
 		if err := manifestutils.ConfigureServiceCA(&dep.Spec.Template.Spec, caBundleName); err != nil {
 			return nil, err
@@ -65,12 +74,17 @@ func BuildGateway(params manifestutils.Params) ([]client.Object, error) {
 		}
 	}
 
+	if params.Tempo.Spec.Tenants.Mode == v1alpha1.ModeStatic && params.Gates.HTTPEncryption {
+		configureMTLS(params.Tempo, &dep.Spec.Template.Spec)
+	}
+
 	if params.Tempo.Spec.Tenants.Mode == v1alpha1.ModeOpenShift {
 		dep = patchOCPServiceAccount(params.Tempo, dep)
 		dep, err = patchOCPOPAContainer(params.Tempo, dep)
 		if err != nil {
 			return nil, err
 		}
+		dep = patchOCPQueryRBAC(params.Tempo, dep)
 
 		objs = append(objs, []client.Object{
 			clusterRole(params.Tempo),
@@ -80,21 +94,31 @@ func BuildGateway(params manifestutils.Params) ([]client.Object, error) {
 		}...)
 
 		if params.Gates.OpenShift.ServingCertsService {
-			dep, err = patchOCPServingCerts(params.Tempo, dep)
+			dep, err = patchOCPServingCerts(params.Tempo, dep, servingCertsSecretName(params))
 			if err != nil {
 				return nil, err
 			}
 		}
 	}
 
+	if autoscaling := params.Tempo.Spec.Template.Gateway.Autoscaling; autoscaling != nil {
+		objs = append(objs, manifestutils.Autoscaler(params.Tempo, manifestutils.GatewayComponentName, *autoscaling))
+	}
+	if gateway := params.Tempo.Spec.Template.Gateway; manifestutils.MultipleReplicas(dep.Spec.Replicas, gateway.Autoscaling) {
+		objs = append(objs, manifestutils.PodDisruptionBudget(params.Tempo, manifestutils.GatewayComponentName, gateway.PodDisruptionBudget))
+	}
+
 	if params.Tempo.Spec.Template.Gateway.Ingress.Type == v1alpha1.IngressTypeIngress {
 		objs = append(objs, ingress(params.Tempo))
 	} else if params.Tempo.Spec.Template.Gateway.Ingress.Type == v1alpha1.IngressTypeRoute {
-		routeObj, err := route(params.Tempo)
+		routeObj, err := route(params)
 		if err != nil {
 			return nil, err
 		}
 		objs = append(objs, routeObj)
+		for _, additionalRoute := range manifestutils.AdditionalRoutes(routeObj, params.Tempo.Spec.Template.Gateway.Ingress, params.RouteHostCertificates) {
+			objs = append(objs, additionalRoute)
+		}
 	}
 
 	dep.Spec.Template, err = patchTracing(params.Tempo, dep.Spec.Template)
@@ -106,6 +130,20 @@ func BuildGateway(params manifestutils.Params) ([]client.Object, error) {
 	return objs, nil
 }
 
+// logArgs returns the log arguments of the gateway containers.
+// The audit logs are the debug logs of the request logging and authorization middlewares in JSON format.
+func logArgs(tempo v1alpha1.TempoStack, level string) []string {
+	if tempo.Spec.Template.Gateway.AuditLog != nil {
+		return []string{"--log.level=debug", "--log.format=json"}
+	}
+
+	args := []string{fmt.Sprintf("--log.level=%s", manifestutils.LogLevel(tempo, tempo.Spec.Template.Gateway.TempoComponentSpec, level))}
+	if tempo.Spec.Observability.Logging.Format != "" {
+		args = append(args, fmt.Sprintf("--log.format=%s", tempo.Spec.Observability.Logging.Format))
+	}
+	return args
+}
+
 func httpScheme(tls bool) string {
 	if tls {
 		return "https"
@@ -115,8 +153,9 @@ func httpScheme(tls bool) string {
 
 func deployment(params manifestutils.Params, rbacCfgHash string, tenantsCfgHash string) *appsv1.Deployment {
 	tempo := params.Tempo
+	otlpGRPCPort, _ := manifestutils.OTLPReceiverPorts(tempo)
 	labels := manifestutils.ComponentLabels(manifestutils.GatewayComponentName, tempo.Name)
-	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum)
+	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum, params.Tempo.Annotations[manifestutils.CertRotationRequiredAtAnnotation])
 	annotations["tempo.grafana.com/rbacConfig.hash"] = rbacCfgHash
 	annotations["tempo.grafana.com/tenantsConfig.hash"] = tenantsCfgHash
 
@@ -135,6 +174,12 @@ func deployment(params manifestutils.Params, rbacCfgHash string, tenantsCfgHash
 		}
 	}
 
+	// The replicas of an autoscaled gateway are managed by the HorizontalPodAutoscaler.
+	replicas := cfg.Replicas
+	if cfg.Autoscaling != nil {
+		replicas = nil
+	}
+
 	dep := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: appsv1.SchemeGroupVersion.String(),
@@ -145,18 +190,22 @@ func deployment(params manifestutils.Params, rbacCfgHash string, tenantsCfgHash
 			Labels:    labels,
 		},
 		Spec: appsv1.DeploymentSpec{
+			Replicas: replicas,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      labels,
-					Annotations: annotations,
+					Labels:      manifestutils.PodLabels(cfg.PodLabels, labels),
+					Annotations: manifestutils.PodAnnotations(cfg.PodAnnotations, annotations),
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: tempo.Spec.ServiceAccount,
-					NodeSelector:       cfg.NodeSelector,
-					Tolerations:        cfg.Tolerations,
+					ServiceAccountName:        tempo.Spec.ServiceAccount,
+					Affinity:                  manifestutils.Affinity(cfg.Affinity, labels),
+					NodeSelector:              cfg.NodeSelector,
+					Tolerations:               cfg.Tolerations,
+					TopologySpreadConstraints: manifestutils.TopologySpreadConstraints(cfg.TopologySpreadConstraints, labels),
+					PriorityClassName:         cfg.PriorityClassName,
 					Containers: []corev1.Container{
 						{
 							Name:  "tempo-gateway",
@@ -165,14 +214,13 @@ func deployment(params manifestutils.Params, rbacCfgHash string, tenantsCfgHash
 								fmt.Sprintf("--traces.tenant-header=%s", manifestutils.TenantHeader),
 								fmt.Sprintf("--web.listen=0.0.0.0:%d", portPublic),
 								fmt.Sprintf("--web.internal.listen=0.0.0.0:%d", portInternal),
-								fmt.Sprintf("--traces.write.endpoint=%s:4317", naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.DistributorComponentName)),
+								fmt.Sprintf("--traces.write.endpoint=%s:%d", naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.DistributorComponentName), otlpGRPCPort),
 								fmt.Sprintf("--traces.read.endpoint=%s://%s:16686", httpScheme(params.Gates.HTTPEncryption),
 									naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.QueryFrontendComponentName)),
 								fmt.Sprintf("--grpc.listen=0.0.0.0:%d", portGRPC),
 								fmt.Sprintf("--rbac.config=%s", path.Join(tempoGatewayMountDir, "cm", tempoGatewayRbacFileName)),
 								fmt.Sprintf("--tenants.config=%s", path.Join(tempoGatewayMountDir, "secret", manifestutils.GatewayTenantFileName)),
-								"--log.level=info",
-							}, tlsArgs...),
+							}, append(logArgs(tempo, "info"), tlsArgs...)...),
 							Ports: []corev1.ContainerPort{
 								{
 									Name:          "grpc-public",
@@ -271,6 +319,30 @@ func deployment(params manifestutils.Params, rbacCfgHash string, tenantsCfgHash
 	return dep
 }
 
+// configureCustomPolicy mounts the custom Rego policy into the gateway container.
+func configureCustomPolicy(pod *corev1.PodSpec, policy *v1alpha1.OPAPolicySpec) {
+	items := []corev1.KeyToPath{{Key: policy.Key, Path: policy.Key}}
+	volume := corev1.Volume{Name: "opa-policy"}
+	if policy.Secret != "" {
+		volume.VolumeSource.Secret = &corev1.SecretVolumeSource{
+			SecretName: policy.Secret,
+			Items:      items,
+		}
+	} else {
+		volume.VolumeSource.ConfigMap = &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: policy.ConfigMap},
+			Items:                items,
+		}
+	}
+
+	pod.Volumes = append(pod.Volumes, volume)
+	pod.Containers[0].VolumeMounts = append(pod.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "opa-policy",
+		ReadOnly:  true,
+		MountPath: path.Join(tempoGatewayMountDir, "opa"),
+	})
+}
+
 func patchTracing(tempo v1alpha1.TempoStack, pod corev1.PodTemplateSpec) (corev1.PodTemplateSpec, error) {
 	if tempo.Spec.Observability.Tracing.SamplingFraction == "" {
 		return pod, nil
@@ -300,6 +372,16 @@ func patchTracing(tempo v1alpha1.TempoStack, pod corev1.PodTemplateSpec) (corev1
 	})
 }
 
+// servingCertsSecretName returns the name of the secret holding the serving certificate of the gateway
+// issued by the OpenShift service-ca operator. If serving certificates are enabled for all services,
+// the certificate is shared with the internal TLS configuration of the gateway.
+func servingCertsSecretName(params manifestutils.Params) string {
+	if params.Gates.OpenShift.ServingCertsAllServices {
+		return naming.TLSSecretName(manifestutils.GatewayComponentName, params.Tempo.Name)
+	}
+	return naming.Name("gateway-tls", params.Tempo.Name)
+}
+
 func service(tempo v1alpha1.TempoStack, ocpServingCerts bool) *corev1.Service {
 	labels := manifestutils.ComponentLabels(manifestutils.GatewayComponentName, tempo.Name)
 	annotations := map[string]string{}
@@ -310,8 +392,8 @@ func service(tempo v1alpha1.TempoStack, ocpServingCerts bool) *corev1.Service {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        naming.Name(manifestutils.GatewayComponentName, tempo.Name),
 			Namespace:   tempo.Namespace,
-			Labels:      labels,
-			Annotations: annotations,
+			Labels:      manifestutils.ServiceLabels(tempo.Spec.Template.Gateway.ServiceLabels, labels),
+			Annotations: manifestutils.ServiceAnnotations(tempo.Spec.Template.Gateway.ServiceAnnotations, annotations),
 		},
 		Spec: corev1.ServiceSpec{
 			Ports: []corev1.ServicePort{
@@ -388,7 +470,7 @@ func ingress(tempo v1alpha1.TempoStack) *networkingv1.Ingress {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        ingressName,
 			Namespace:   tempo.Namespace,
-			Labels:      labels,
+			Labels:      manifestutils.IngressLabels(tempo.Spec.Template.Gateway.Ingress.Labels, labels),
 			Annotations: tempo.Spec.Template.Gateway.Ingress.Annotations,
 		},
 		Spec: networkingv1.IngressSpec{
@@ -405,26 +487,6 @@ func ingress(tempo v1alpha1.TempoStack) *networkingv1.Ingress {
 		},
 	}
 
-	if tempo.Spec.Template.Gateway.Ingress.Host == "" {
-		ingress.Spec.DefaultBackend = &backend
-	} else {
-		pathType := networkingv1.PathTypePrefix
-		ingress.Spec.Rules = []networkingv1.IngressRule{
-			{
-				Host: tempo.Spec.Template.Gateway.Ingress.Host,
-				IngressRuleValue: networkingv1.IngressRuleValue{
-					HTTP: &networkingv1.HTTPIngressRuleValue{
-						Paths: []networkingv1.HTTPIngressPath{
-							{
-								Path:     "/",
-								PathType: &pathType,
-								Backend:  backend,
-							},
-						},
-					},
-				},
-			},
-		}
-	}
+	manifestutils.ConfigureIngressHosts(ingress, tempo.Spec.Template.Gateway.Ingress, backend)
 	return ingress
 }
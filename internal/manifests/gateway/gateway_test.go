@@ -473,6 +473,123 @@ func TestTLSParameters(t *testing.T) {
 	assert.Equal(t, corev1.URISchemeHTTP, dep.Spec.Template.Spec.Containers[0].ReadinessProbe.HTTPGet.Scheme)
 }
 
+func TestBuildGateway_CustomPolicy(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Tenants: &v1alpha1.TenantsSpec{
+				Mode: v1alpha1.ModeStatic,
+				Authentication: []v1alpha1.AuthenticationSpec{
+					{
+						TenantName: "dev",
+						TenantID:   "abcd1",
+					},
+				},
+				Authorization: &v1alpha1.AuthorizationSpec{
+					Policy: &v1alpha1.OPAPolicySpec{
+						ConfigMap: "my-policy",
+						Key:       "tenancy.rego",
+						Query:     "data.tenancy.allow",
+					},
+				},
+			},
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway: v1alpha1.TempoGatewaySpec{
+					Enabled: true,
+				},
+			},
+		},
+	}
+
+	objects, err := BuildGateway(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+
+	secret := getObjectByTypeAndName(objects, "tempo-simplest-gateway", reflect.TypeOf(&corev1.Secret{}))
+	require.NotNil(t, secret)
+	assert.Equal(t, `tenants:
+- name: dev
+  id: abcd1
+  opa:
+    query: data.tenancy.allow
+    paths:
+    - /etc/tempo-gateway/opa/tenancy.rego`, string(secret.(*corev1.Secret).Data[manifestutils.GatewayTenantFileName]))
+
+	obj := getObjectByTypeAndName(objects, "tempo-simplest-gateway", reflect.TypeOf(&appsv1.Deployment{}))
+	require.NotNil(t, obj)
+	dep := obj.(*appsv1.Deployment)
+	assert.Contains(t, dep.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "opa-policy",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "my-policy"},
+				Items:                []corev1.KeyToPath{{Key: "tenancy.rego", Path: "tenancy.rego"}},
+			},
+		},
+	})
+	assert.Contains(t, dep.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "opa-policy",
+		ReadOnly:  true,
+		MountPath: "/etc/tempo-gateway/opa",
+	})
+}
+
+func TestBuildGateway_AuditLog(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Tenants: &v1alpha1.TenantsSpec{
+				Mode: v1alpha1.ModeOpenShift,
+				Authentication: []v1alpha1.AuthenticationSpec{
+					{
+						TenantName: "dev",
+						TenantID:   "abcd1",
+					},
+				},
+			},
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway: v1alpha1.TempoGatewaySpec{
+					Enabled:  true,
+					AuditLog: &v1alpha1.GatewayAuditLogSpec{Output: v1alpha1.GatewayAuditLogOutputStdout},
+				},
+			},
+		},
+	}
+
+	objects, err := BuildGateway(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+
+	obj := getObjectByTypeAndName(objects, "tempo-simplest-gateway", reflect.TypeOf(&appsv1.Deployment{}))
+	require.NotNil(t, obj)
+	dep := obj.(*appsv1.Deployment)
+	require.Len(t, dep.Spec.Template.Spec.Containers, 2)
+	for _, container := range dep.Spec.Template.Spec.Containers {
+		assert.Contains(t, container.Args, "--log.level=debug")
+		assert.Contains(t, container.Args, "--log.format=json")
+		assert.NotContains(t, container.Args, "--log.level=info")
+		assert.NotContains(t, container.Args, "--log.level=warn")
+	}
+}
+
+func TestLogArgs(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Observability: v1alpha1.ObservabilitySpec{
+				Logging: v1alpha1.LoggingConfigSpec{Format: v1alpha1.LogFormatJSON},
+			},
+		},
+	}
+	assert.Equal(t, []string{"--log.level=info", "--log.format=json"}, logArgs(tempo, "info"))
+
+	tempo.Spec.Template.Gateway.LogLevel = v1alpha1.LogLevelDebug
+	assert.Equal(t, []string{"--log.level=debug", "--log.format=json"}, logArgs(tempo, "warn"))
+}
+
 func TestIngress(t *testing.T) {
 	objects, err := BuildGateway(manifestutils.Params{Tempo: v1alpha1.TempoStack{
 		ObjectMeta: metav1.ObjectMeta{
@@ -635,3 +752,144 @@ func TestRoute(t *testing.T) {
 		},
 	}, objects[3].(*routev1.Route))
 }
+
+func TestRoute_Customization(t *testing.T) {
+	objects, err := BuildGateway(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway: v1alpha1.TempoGatewaySpec{
+					Enabled: true,
+					Ingress: v1alpha1.IngressSpec{
+						Type:        v1alpha1.IngressTypeRoute,
+						Host:        "tempo.example.com",
+						Annotations: map[string]string{"haproxy.router.openshift.io/timeout": "5m"},
+						Labels: map[string]string{
+							"router":                      "external",
+							"app.kubernetes.io/component": "custom",
+						},
+						Route: v1alpha1.RouteSpec{
+							Termination:       v1alpha1.TLSRouteTerminationTypeReencrypt,
+							CertificateSecret: "tempo-route-cert",
+						},
+					},
+				},
+			},
+			Tenants: &v1alpha1.TenantsSpec{
+				Mode: "static",
+				Authorization: &v1alpha1.AuthorizationSpec{
+					Roles: []v1alpha1.RoleSpec{{
+						Name:        "read-write",
+						Resources:   []string{"traces"},
+						Tenants:     []string{"test-oidc"},
+						Permissions: []v1alpha1.PermissionType{v1alpha1.Write, v1alpha1.Read},
+					}},
+				},
+			},
+		},
+	}})
+
+	require.NoError(t, err)
+	route := getObjectByTypeAndName(objects, "tempo-test-gateway", reflect.TypeOf(&routev1.Route{}))
+	require.NotNil(t, route)
+
+	expectedLabels := manifestutils.ComponentLabels("gateway", "test")
+	expectedLabels["router"] = "external"
+	assert.Equal(t, map[string]string(expectedLabels), route.GetLabels())
+	assert.Equal(t, map[string]string{"haproxy.router.openshift.io/timeout": "5m"}, route.GetAnnotations())
+	assert.Equal(t, "tempo.example.com", route.(*routev1.Route).Spec.Host)
+	assert.Equal(t, routev1.TLSTerminationReencrypt, route.(*routev1.Route).Spec.TLS.Termination)
+}
+
+func TestService_Metadata(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway: v1alpha1.TempoGatewaySpec{
+					Enabled: true,
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						ServiceLabels: map[string]string{"mesh": "enabled"},
+						ServiceAnnotations: map[string]string{
+							"service.beta.kubernetes.io/aws-load-balancer-internal": "true",
+							"service.beta.openshift.io/serving-cert-secret-name":    "custom",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	svc := service(tempo, true)
+	expectedLabels := manifestutils.ComponentLabels(manifestutils.GatewayComponentName, "test")
+	assert.Equal(t, map[string]string(expectedLabels), svc.Spec.Selector)
+	expectedLabels["mesh"] = "enabled"
+	assert.Equal(t, map[string]string(expectedLabels), svc.Labels)
+	assert.Equal(t, map[string]string{
+		"service.beta.kubernetes.io/aws-load-balancer-internal": "true",
+		"service.beta.openshift.io/serving-cert-secret-name":    "tempo-test-gateway-tls",
+	}, svc.Annotations)
+}
+
+func TestBuildGateway_MTLS(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Tenants: &v1alpha1.TenantsSpec{
+				Mode: v1alpha1.ModeStatic,
+				Authentication: []v1alpha1.AuthenticationSpec{
+					{
+						TenantName: "dev",
+						TenantID:   "abcd1",
+						MTLS:       &v1alpha1.MTLSSpec{CA: "dev-ca"},
+					},
+				},
+				Authorization: &v1alpha1.AuthorizationSpec{},
+			},
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway: v1alpha1.TempoGatewaySpec{
+					Enabled: true,
+				},
+			},
+		},
+	}
+
+	objects, err := BuildGateway(manifestutils.Params{
+		Tempo: tempo,
+		Gates: configv1alpha1.FeatureGates{HTTPEncryption: true},
+	})
+	require.NoError(t, err)
+
+	secret := getObjectByTypeAndName(objects, "tempo-simplest-gateway", reflect.TypeOf(&corev1.Secret{}))
+	require.NotNil(t, secret)
+	assert.Contains(t, string(secret.(*corev1.Secret).Data[manifestutils.GatewayTenantFileName]), `  mTLS:
+    caPath: /etc/tempo-gateway/mtls/dev/service-ca.crt`)
+
+	obj := getObjectByTypeAndName(objects, "tempo-simplest-gateway", reflect.TypeOf(&appsv1.Deployment{}))
+	require.NotNil(t, obj)
+	dep := obj.(*appsv1.Deployment)
+	assert.Contains(t, dep.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "mtls-ca-0",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "dev-ca"},
+			},
+		},
+	})
+	assert.Contains(t, dep.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "mtls-ca-0",
+		ReadOnly:  true,
+		MountPath: "/etc/tempo-gateway/mtls/dev",
+	})
+	assert.Contains(t, dep.Spec.Template.Spec.Containers[0].Args, "--tls.server.cert-file=/var/run/tls/server/tls.crt")
+	assert.Contains(t, dep.Spec.Template.Spec.Containers[0].Args, "--web.healthchecks.url=https://localhost:8080")
+}
@@ -0,0 +1,56 @@
+package gateway
+
+import (
+	"fmt"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// mtlsCAPath returns the path of the CA bundle which verifies the client certificates of a tenant.
+func mtlsCAPath(tenantName string) string {
+	return path.Join(tempoGatewayMountDir, "mtls", tenantName, "service-ca.crt")
+}
+
+// configureMTLS mounts the CA bundles of the tenants with client certificate authentication
+// and enables TLS on the public server of the gateway, using the certificate of the built-in cert management.
+func configureMTLS(tempo v1alpha1.TempoStack, pod *corev1.PodSpec) {
+	configured := false
+	for i, auth := range tempo.Spec.Tenants.Authentication {
+		if auth.MTLS == nil {
+			continue
+		}
+
+		volumeName := fmt.Sprintf("mtls-ca-%d", i)
+		pod.Volumes = append(pod.Volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: auth.MTLS.CA},
+				},
+			},
+		})
+		pod.Containers[0].VolumeMounts = append(pod.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			ReadOnly:  true,
+			MountPath: path.Dir(mtlsCAPath(auth.TenantName)),
+		})
+		configured = true
+	}
+
+	if !configured {
+		return
+	}
+
+	pod.Containers[0].Args = append(pod.Containers[0].Args,
+		fmt.Sprintf("--tls.server.cert-file=%s/tls.crt", manifestutils.TempoServerTLSDir()),
+		fmt.Sprintf("--tls.server.key-file=%s/tls.key", manifestutils.TempoServerTLSDir()),
+		fmt.Sprintf("--tls.healthchecks.server-ca-file=%s/service-ca.crt", manifestutils.CABundleDir),
+		fmt.Sprintf("--tls.healthchecks.server-name=%s", naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.GatewayComponentName)),
+		"--web.healthchecks.url=https://localhost:8080",
+	)
+}
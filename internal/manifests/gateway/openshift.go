@@ -3,6 +3,7 @@ package gateway
 import (
 	"fmt"
 	"path"
+	"time"
 
 	"github.com/imdario/mergo"
 	routev1 "github.com/openshift/api/route/v1"
@@ -20,6 +21,13 @@ import (
 const (
 	gatewayOPAHTTPPort     = 8082
 	gatewayOPAInternalPort = 8083
+
+	memcachedPort                  = 11211
+	defaultAccessReviewCacheTTL    = time.Minute
+	defaultAccessReviewCacheSizeMB = 64
+
+	// queryRBACMatcher is the resource attribute, by which the query results are filtered.
+	queryRBACMatcher = "k8s.namespace.name"
 )
 
 func serviceAccount(tempo v1alpha1.TempoStack) *corev1.ServiceAccount {
@@ -43,23 +51,33 @@ func serviceAccount(tempo v1alpha1.TempoStack) *corev1.ServiceAccount {
 }
 
 func clusterRole(tempo v1alpha1.TempoStack) *rbacv1.ClusterRole {
+	rules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{"authentication.k8s.io"},
+			Resources: []string{"tokenreviews"},
+			Verbs:     []string{"create"},
+		},
+		{
+			APIGroups: []string{"authorization.k8s.io"},
+			Resources: []string{"subjectaccessreviews"},
+			Verbs:     []string{"create"},
+		},
+	}
+	if tempo.Spec.Template.Gateway.RBAC.Enabled {
+		// The OPA lists the namespaces to check in which namespaces the user is allowed to get the traces.
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{""},
+			Resources: []string{"namespaces"},
+			Verbs:     []string{"get", "list"},
+		})
+	}
+
 	return &rbacv1.ClusterRole{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   naming.Name(manifestutils.GatewayComponentName, tempo.Name),
 			Labels: manifestutils.ComponentLabels(manifestutils.GatewayComponentName, tempo.Name),
 		},
-		Rules: []rbacv1.PolicyRule{
-			{
-				APIGroups: []string{"authentication.k8s.io"},
-				Resources: []string{"tokenreviews"},
-				Verbs:     []string{"create"},
-			},
-			{
-				APIGroups: []string{"authorization.k8s.io"},
-				Resources: []string{"subjectaccessreviews"},
-				Verbs:     []string{"create"},
-			},
-		},
+		Rules: rules,
 	}
 }
 
@@ -84,28 +102,21 @@ func clusterRoleBinding(tempo v1alpha1.TempoStack) *rbacv1.ClusterRoleBinding {
 	}
 }
 
-func route(tempo v1alpha1.TempoStack) (*routev1.Route, error) {
+func route(params manifestutils.Params) (*routev1.Route, error) {
+	tempo := params.Tempo
 	labels := manifestutils.ComponentLabels(manifestutils.GatewayComponentName, tempo.Name)
 
-	var tlsCfg *routev1.TLSConfig
-	switch tempo.Spec.Template.Gateway.Ingress.Route.Termination {
-	case v1alpha1.TLSRouteTerminationTypeInsecure:
-		// NOTE: insecure, no tls cfg.
-	case v1alpha1.TLSRouteTerminationTypeEdge:
-		tlsCfg = &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge}
-	case v1alpha1.TLSRouteTerminationTypePassthrough:
-		tlsCfg = &routev1.TLSConfig{Termination: routev1.TLSTerminationPassthrough}
-	case v1alpha1.TLSRouteTerminationTypeReencrypt:
-		tlsCfg = &routev1.TLSConfig{Termination: routev1.TLSTerminationReencrypt}
-	default: // NOTE: if unsupported, end here.
-		return nil, fmt.Errorf("unsupported tls termination specified for route")
+	tlsCfg, err := manifestutils.RouteTLSConfig(tempo.Spec.Template.Gateway.Ingress.Route, params.GatewayRouteCertificates)
+	if err != nil {
+		return nil, err
 	}
 
 	return &routev1.Route{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      naming.Name(manifestutils.GatewayComponentName, tempo.Name),
-			Namespace: tempo.Namespace,
-			Labels:    labels,
+			Name:        naming.Name(manifestutils.GatewayComponentName, tempo.Name),
+			Namespace:   tempo.Namespace,
+			Labels:      manifestutils.IngressLabels(tempo.Spec.Template.Gateway.Ingress.Labels, labels),
+			Annotations: tempo.Spec.Template.Gateway.Ingress.Annotations,
 		},
 		Spec: routev1.RouteSpec{
 			Host: tempo.Spec.Template.Gateway.Ingress.Host,
@@ -132,7 +143,7 @@ func configMapCABundle(tempo v1alpha1.TempoStack) *corev1.ConfigMap {
 	}
 }
 
-func patchOCPServingCerts(tempo v1alpha1.TempoStack, dep *v1.Deployment) (*v1.Deployment, error) {
+func patchOCPServingCerts(tempo v1alpha1.TempoStack, dep *v1.Deployment, secretName string) (*v1.Deployment, error) {
 	container := corev1.Container{
 		VolumeMounts: []corev1.VolumeMount{
 			{
@@ -166,7 +177,7 @@ func patchOCPServingCerts(tempo v1alpha1.TempoStack, dep *v1.Deployment) (*v1.De
 				Name: "serving-certs",
 				VolumeSource: corev1.VolumeSource{
 					Secret: &corev1.SecretVolumeSource{
-						SecretName: naming.Name("gateway-tls", tempo.Name),
+						SecretName: secretName,
 					},
 				},
 			},
@@ -198,6 +209,9 @@ func patchOCPOPAContainer(tempo v1alpha1.TempoStack, dep *v1.Deployment) (*v1.De
 	pod := corev1.PodSpec{
 		Containers: []corev1.Container{opaContainer(tempo)},
 	}
+	if cache := tempo.Spec.Template.Gateway.AccessReviewCache; cache != nil {
+		pod.Containers = append(pod.Containers, memcachedContainer(tempo, cache))
+	}
 	err := mergo.Merge(&dep.Spec.Template.Spec, pod, mergo.WithAppendSlice)
 	if err != nil {
 		return nil, err
@@ -205,18 +219,38 @@ func patchOCPOPAContainer(tempo v1alpha1.TempoStack, dep *v1.Deployment) (*v1.De
 	return dep, err
 }
 
+// patchOCPQueryRBAC enables the filtering of the query results by the matchers returned by the OPA.
+func patchOCPQueryRBAC(tempo v1alpha1.TempoStack, dep *v1.Deployment) *v1.Deployment {
+	if !tempo.Spec.Template.Gateway.RBAC.Enabled {
+		return dep
+	}
+
+	container := &dep.Spec.Template.Spec.Containers[0]
+	container.Args = append(container.Args, "--traces.query-rbac=true")
+	return dep
+}
+
 func opaContainer(tempo v1alpha1.TempoStack) corev1.Container {
-	var args = []string{
-		"--log.level=warn",
+	var args = append(logArgs(tempo, "warn"),
 		"--opa.admin-groups=system:cluster-admins,cluster-admin,dedicated-admin",
 		fmt.Sprintf("--web.listen=:%d", gatewayOPAHTTPPort),
 		fmt.Sprintf("--web.internal.listen=:%d", gatewayOPAInternalPort),
 		fmt.Sprintf("--web.healthchecks.url=http://localhost:%d", gatewayOPAHTTPPort),
 		fmt.Sprintf("--opa.package=%s", "tempostack"),
-	}
+	)
 	for _, t := range tempo.Spec.Tenants.Authentication {
 		args = append(args, fmt.Sprintf(`--openshift.mappings=%s=%s`, t.TenantName, "tempo.grafana.com"))
 	}
+	if tempo.Spec.Template.Gateway.RBAC.Enabled {
+		// The OPA returns the namespaces of the user as matchers, which the gateway injects into the queries.
+		args = append(args, fmt.Sprintf("--opa.matcher=%s", queryRBACMatcher))
+	}
+	if cache := tempo.Spec.Template.Gateway.AccessReviewCache; cache != nil {
+		args = append(args,
+			fmt.Sprintf("--memcached=localhost:%d", memcachedPort),
+			fmt.Sprintf("--memcached.expire=%d", accessReviewCacheTTLSeconds(cache)),
+		)
+	}
 
 	return corev1.Container{
 		Name:  "opa",
@@ -260,3 +294,38 @@ func opaContainer(tempo v1alpha1.TempoStack) corev1.Container {
 		},
 	}
 }
+
+// accessReviewCacheTTLSeconds returns the expiration of the cached access reviews in seconds.
+func accessReviewCacheTTLSeconds(cache *v1alpha1.GatewayAccessReviewCacheSpec) int {
+	ttl := cache.TTL.Duration
+	if ttl == 0 {
+		ttl = defaultAccessReviewCacheTTL
+	}
+	return int(ttl.Seconds())
+}
+
+// memcachedContainer returns the memcached sidecar container, which caches the access reviews of the opa-openshift container.
+func memcachedContainer(tempo v1alpha1.TempoStack, cache *v1alpha1.GatewayAccessReviewCacheSpec) corev1.Container {
+	sizeMB := cache.SizeMB
+	if sizeMB == 0 {
+		sizeMB = defaultAccessReviewCacheSizeMB
+	}
+
+	return corev1.Container{
+		Name:  "memcached",
+		Image: tempo.Spec.Images.Memcached,
+		Args: []string{
+			"-l", "127.0.0.1",
+			"-p", fmt.Sprintf("%d", memcachedPort),
+			"-m", fmt.Sprintf("%d", sizeMB),
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "memcached",
+				ContainerPort: memcachedPort,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		SecurityContext: manifestutils.TempoContainerSecurityContext(),
+	}
+}
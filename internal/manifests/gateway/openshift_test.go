@@ -3,13 +3,16 @@ package gateway
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
 )
@@ -120,7 +123,82 @@ func TestPatchOCPServingCerts(t *testing.T) {
 			MountPath: "/etc/tempo-gateway/cabundle",
 		})
 
-	got, err := patchOCPServingCerts(tempo, dep)
+	got, err := patchOCPServingCerts(tempo, dep, naming.Name("gateway-tls", tempo.Name))
 	require.NoError(t, err)
 	assert.Equal(t, expected, got)
 }
+
+func TestPatchOCPOPAContainer_AccessReviewCache(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Images: configv1alpha1.ImagesSpec{
+				Memcached: "docker.io/library/memcached:1.6.21-alpine",
+			},
+			Tenants: &v1alpha1.TenantsSpec{
+				Mode: v1alpha1.ModeOpenShift,
+				Authentication: []v1alpha1.AuthenticationSpec{
+					{
+						TenantName: "dev",
+						TenantID:   "abcd1",
+					},
+				},
+			},
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway: v1alpha1.TempoGatewaySpec{
+					AccessReviewCache: &v1alpha1.GatewayAccessReviewCacheSpec{
+						TTL: metav1.Duration{Duration: 5 * time.Minute},
+					},
+				},
+			},
+		},
+	}
+	dep, err := patchOCPOPAContainer(tempo, &appsv1.Deployment{})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(dep.Spec.Template.Spec.Containers))
+	assert.Contains(t, dep.Spec.Template.Spec.Containers[0].Args, "--memcached=localhost:11211")
+	assert.Contains(t, dep.Spec.Template.Spec.Containers[0].Args, "--memcached.expire=300")
+
+	memcached := dep.Spec.Template.Spec.Containers[1]
+	assert.Equal(t, "memcached", memcached.Name)
+	assert.Equal(t, "docker.io/library/memcached:1.6.21-alpine", memcached.Image)
+	assert.Equal(t, []string{"-l", "127.0.0.1", "-p", "11211", "-m", "64"}, memcached.Args)
+}
+
+func TestQueryRBAC(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Tenants: &v1alpha1.TenantsSpec{
+				Mode: v1alpha1.ModeOpenShift,
+			},
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway: v1alpha1.TempoGatewaySpec{
+					Enabled: true,
+					RBAC:    v1alpha1.RBACSpec{Enabled: true},
+				},
+			},
+		},
+	}
+	dep := &appsv1.Deployment{}
+	dep.Spec.Template.Spec.Containers = []corev1.Container{{Name: "tempo-gateway"}}
+
+	dep, err := patchOCPOPAContainer(tempo, dep)
+	require.NoError(t, err)
+	dep = patchOCPQueryRBAC(tempo, dep)
+	require.Equal(t, 2, len(dep.Spec.Template.Spec.Containers))
+	assert.Equal(t, []string{"--traces.query-rbac=true"}, dep.Spec.Template.Spec.Containers[0].Args)
+	assert.Contains(t, dep.Spec.Template.Spec.Containers[1].Args, "--opa.matcher=k8s.namespace.name")
+
+	assert.Contains(t, clusterRole(tempo).Rules, rbacv1.PolicyRule{
+		APIGroups: []string{""},
+		Resources: []string{"namespaces"},
+		Verbs:     []string{"get", "list"},
+	})
+}
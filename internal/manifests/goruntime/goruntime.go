@@ -0,0 +1,69 @@
+package goruntime
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+const (
+	// tempoContainerName is the name of the Tempo container in the pods of all Tempo components.
+	tempoContainerName = "tempo"
+
+	// memoryLimitPercentage is the share of the memory limit of a container which is used as soft memory limit
+	// of the Go runtime. The remainder is left for memory which is not managed by the Go runtime.
+	memoryLimitPercentage = 90
+)
+
+// ConfigureContainers sets the GOMEMLIMIT and GOMAXPROCS environment variables of the Tempo containers
+// according to their resource limits, so that the Go runtime collects garbage before the container is OOM killed
+// and does not start more threads than CPU cores are available.
+func ConfigureContainers(tempo v1alpha1.TempoStack, objs []client.Object) {
+	if tempo.Spec.Resources.DisableGoRuntimeTuning {
+		return
+	}
+
+	for _, obj := range objs {
+		var pod *corev1.PodSpec
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			pod = &o.Spec.Template.Spec
+		case *appsv1.StatefulSet:
+			pod = &o.Spec.Template.Spec
+		default:
+			continue
+		}
+
+		for i := range pod.Containers {
+			container := &pod.Containers[i]
+			if container.Name != tempoContainerName {
+				continue
+			}
+			container.Env = append(container.Env, envVars(container.Resources.Limits)...)
+		}
+	}
+}
+
+// envVars returns the environment variables of the Go runtime for the given resource limits.
+func envVars(limits corev1.ResourceList) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	if memory, ok := limits[corev1.ResourceMemory]; ok && !memory.IsZero() {
+		env = append(env, corev1.EnvVar{
+			Name:  "GOMEMLIMIT",
+			Value: fmt.Sprintf("%d", memory.Value()*memoryLimitPercentage/100),
+		})
+	}
+	if cpu, ok := limits[corev1.ResourceCPU]; ok && !cpu.IsZero() {
+		// GOMAXPROCS must be a positive integer, fractional CPU limits are rounded up.
+		procs := (cpu.MilliValue() + 999) / 1000
+		env = append(env, corev1.EnvVar{
+			Name:  "GOMAXPROCS",
+			Value: fmt.Sprintf("%d", procs),
+		})
+	}
+	return env
+}
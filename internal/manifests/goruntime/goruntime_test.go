@@ -0,0 +1,90 @@
+package goruntime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func deployment(limits corev1.ResourceList) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:      "tempo",
+							Resources: corev1.ResourceRequirements{Limits: limits},
+						},
+						{
+							Name:      "tempo-query",
+							Resources: corev1.ResourceRequirements{Limits: limits},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestConfigureContainers(t *testing.T) {
+	tests := []struct {
+		name     string
+		limits   corev1.ResourceList
+		disabled bool
+		expected []corev1.EnvVar
+	}{
+		{
+			name: "no limits",
+		},
+		{
+			name: "memory and cpu limits",
+			limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+				corev1.ResourceCPU:    resource.MustParse("1500m"),
+			},
+			expected: []corev1.EnvVar{
+				{Name: "GOMEMLIMIT", Value: "966367641"},
+				{Name: "GOMAXPROCS", Value: "2"},
+			},
+		},
+		{
+			name: "small cpu limit",
+			limits: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("100m"),
+			},
+			expected: []corev1.EnvVar{
+				{Name: "GOMAXPROCS", Value: "1"},
+			},
+		},
+		{
+			name: "disabled",
+			limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+				corev1.ResourceCPU:    resource.MustParse("2"),
+			},
+			disabled: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					Resources: v1alpha1.Resources{DisableGoRuntimeTuning: test.disabled},
+				},
+			}
+			dep := deployment(test.limits)
+			ConfigureContainers(tempo, []client.Object{dep, &corev1.Service{}})
+
+			assert.Equal(t, test.expected, dep.Spec.Template.Spec.Containers[0].Env)
+			assert.Empty(t, dep.Spec.Template.Spec.Containers[1].Env)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package grafana
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// DashboardLabel instructs the Grafana dashboard sidecar to load the dashboards of a ConfigMap.
+const DashboardLabel = "grafana_dashboard"
+
+var (
+	//go:embed dashboards/*.json
+	dashboardsFS embed.FS
+
+	dashboardsTmpl = template.Must(template.New("").Delims("[[", "]]").ParseFS(dashboardsFS, "dashboards/*.json"))
+)
+
+type dashboardOptions struct {
+	Name      string
+	Namespace string
+}
+
+// BuildDashboards creates a ConfigMap with the operational Grafana dashboards of the Tempo components.
+func BuildDashboards(tempo v1alpha1.TempoStack) ([]client.Object, error) {
+	if !tempo.Spec.Observability.Grafana.CreateDashboards {
+		return nil, nil
+	}
+
+	entries, err := dashboardsFS.ReadDir("dashboards")
+	if err != nil {
+		return nil, err
+	}
+
+	opts := dashboardOptions{Name: tempo.Name, Namespace: tempo.Namespace}
+	data := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		w := bytes.NewBuffer(nil)
+		err := dashboardsTmpl.ExecuteTemplate(w, entry.Name(), opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create grafana dashboard %s, err: %w", entry.Name(), err)
+		}
+		data[entry.Name()] = w.String()
+	}
+
+	dashboardsLabels := tempo.Spec.Observability.Grafana.DashboardsLabels
+	if len(dashboardsLabels) == 0 {
+		dashboardsLabels = map[string]string{DashboardLabel: "1"}
+	}
+
+	return []client.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      naming.Name("grafana-dashboards", tempo.Name),
+				Namespace: tempo.Namespace,
+				// The common labels take precedence, they are required to prune the ConfigMap once it is disabled.
+				Labels: labels.Merge(dashboardsLabels, manifestutils.CommonLabels(tempo.Name)),
+			},
+			Data: data,
+		},
+	}, nil
+}
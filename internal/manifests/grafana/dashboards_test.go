@@ -0,0 +1,70 @@
+package grafana
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestBuildDashboards(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+	}
+
+	objs, err := BuildDashboards(tempo)
+	require.NoError(t, err)
+	assert.Empty(t, objs)
+
+	tempo.Spec.Observability.Grafana.CreateDashboards = true
+	objs, err = BuildDashboards(tempo)
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+
+	cm := objs[0].(*corev1.ConfigMap)
+	assert.Equal(t, "tempo-test-grafana-dashboards", cm.Name)
+	assert.Equal(t, "project1", cm.Namespace)
+	assert.Equal(t, "1", cm.Labels[DashboardLabel])
+	assert.Equal(t, "test", cm.Labels["app.kubernetes.io/instance"])
+	require.Len(t, cm.Data, 3)
+
+	for name, content := range cm.Data {
+		dashboard := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(content), &dashboard), name)
+		assert.Contains(t, dashboard["title"], "project1 / test")
+		assert.NotContains(t, content, "[[")
+	}
+}
+
+func TestBuildDashboards_CustomLabels(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Observability: v1alpha1.ObservabilitySpec{
+				Grafana: v1alpha1.GrafanaConfigSpec{
+					CreateDashboards: true,
+					DashboardsLabels: map[string]string{"dashboards": "tempo"},
+				},
+			},
+		},
+	}
+
+	objs, err := BuildDashboards(tempo)
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+
+	cm := objs[0].(*corev1.ConfigMap)
+	assert.Equal(t, "tempo", cm.Labels["dashboards"])
+	assert.NotContains(t, cm.Labels, DashboardLabel)
+}
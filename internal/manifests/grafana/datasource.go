@@ -0,0 +1,116 @@
+package grafana
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const (
+	// DatasourceLabel instructs the Grafana datasource sidecar to load the datasources of a ConfigMap.
+	DatasourceLabel = "grafana_datasource"
+	// DatasourcesKey is the key of the datasources provisioning file in the ConfigMap.
+	DatasourcesKey = "tempo-datasources.yaml"
+
+	gatewayPort = 8080
+)
+
+type datasourcesConfig struct {
+	APIVersion  int          `json:"apiVersion"`
+	Datasources []datasource `json:"datasources"`
+}
+
+type datasource struct {
+	Name           string                 `json:"name"`
+	UID            string                 `json:"uid"`
+	Type           string                 `json:"type"`
+	Access         string                 `json:"access"`
+	URL            string                 `json:"url"`
+	JSONData       map[string]interface{} `json:"jsonData,omitempty"`
+	SecureJSONData map[string]string      `json:"secureJsonData,omitempty"`
+}
+
+// BuildTenantDatasources creates a ConfigMap with the Grafana datasources of all tenants
+// which have the grafanaDatasource option enabled.
+func BuildTenantDatasources(params manifestutils.Params) ([]client.Object, error) {
+	tempo := params.Tempo
+	if tempo.Spec.Tenants == nil {
+		return nil, nil
+	}
+
+	var datasources []datasource
+	for _, tenant := range tempo.Spec.Tenants.Authentication {
+		if tenant.GrafanaDatasource {
+			datasources = append(datasources, tenantDatasource(params, tenant))
+		}
+	}
+	if len(datasources) == 0 {
+		return nil, nil
+	}
+
+	cfg, err := yaml.Marshal(datasourcesConfig{APIVersion: 1, Datasources: datasources})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create grafana datasources, err: %w", err)
+	}
+
+	labels := manifestutils.CommonLabels(tempo.Name)
+	labels[DatasourceLabel] = "1"
+	return []client.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      naming.Name("grafana-datasources", tempo.Name),
+				Namespace: tempo.Namespace,
+				Labels:    labels,
+			},
+			Data: map[string]string{
+				DatasourcesKey: string(cfg),
+			},
+		},
+	}, nil
+}
+
+func tenantDatasource(params manifestutils.Params, tenant v1alpha1.AuthenticationSpec) datasource {
+	tempo := params.Tempo
+	ds := datasource{
+		Name:   fmt.Sprintf("Tempo %s/%s (%s)", tempo.Namespace, tempo.Name, tenant.TenantName),
+		UID:    fmt.Sprintf("%s-%s", naming.Name("", tempo.Name), tenant.TenantName),
+		Type:   "tempo",
+		Access: "proxy",
+	}
+
+	if tempo.Spec.Template.Gateway.Enabled {
+		// The gateway authenticates the requests, therefore Grafana forwards the token of the user.
+		ds.URL = fmt.Sprintf("%s://%s:%d/api/traces/v1/%s/tempo", gatewayScheme(params),
+			naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.GatewayComponentName), gatewayPort, tenant.TenantName)
+		ds.JSONData = map[string]interface{}{"oauthPassThru": true}
+		return ds
+	}
+
+	ds.URL = fmt.Sprintf("http://%s:%d", naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.QueryFrontendComponentName), manifestutils.PortHTTPServer)
+	ds.JSONData = map[string]interface{}{"httpHeaderName1": manifestutils.TenantHeader}
+	ds.SecureJSONData = map[string]string{"httpHeaderValue1": tenant.TenantName}
+	return ds
+}
+
+// gatewayScheme returns the scheme of the public server of the gateway.
+func gatewayScheme(params manifestutils.Params) string {
+	tenants := params.Tempo.Spec.Tenants
+	if tenants.Mode == v1alpha1.ModeOpenShift && params.Gates.OpenShift.ServingCertsService {
+		return "https"
+	}
+	if tenants.Mode == v1alpha1.ModeStatic && params.Gates.HTTPEncryption {
+		for _, auth := range tenants.Authentication {
+			if auth.MTLS != nil {
+				return "https"
+			}
+		}
+	}
+	return "http"
+}
@@ -0,0 +1,105 @@
+package grafana
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildTenantDatasources(t *testing.T) {
+	tempo := func(gateway bool) v1alpha1.TempoStack {
+		return v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "simplest",
+				Namespace: "observability",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Tenants: &v1alpha1.TenantsSpec{
+					Mode: v1alpha1.ModeOpenShift,
+					Authentication: []v1alpha1.AuthenticationSpec{
+						{TenantName: "dev", TenantID: "abcd1", GrafanaDatasource: true},
+						{TenantName: "prod", TenantID: "abcd2"},
+					},
+				},
+				Template: v1alpha1.TempoTemplateSpec{
+					Gateway: v1alpha1.TempoGatewaySpec{Enabled: gateway},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		params   manifestutils.Params
+		expected string
+	}{
+		{
+			name: "gateway",
+			params: manifestutils.Params{
+				Tempo: tempo(true),
+				Gates: configv1alpha1.FeatureGates{
+					OpenShift: configv1alpha1.OpenShiftFeatureGates{ServingCertsService: true},
+				},
+			},
+			expected: `apiVersion: 1
+datasources:
+- access: proxy
+  jsonData:
+    oauthPassThru: true
+  name: Tempo observability/simplest (dev)
+  type: tempo
+  uid: tempo-simplest-dev
+  url: https://tempo-simplest-gateway.observability.svc.cluster.local:8080/api/traces/v1/dev/tempo
+`,
+		},
+		{
+			name:   "query-frontend",
+			params: manifestutils.Params{Tempo: tempo(false)},
+			expected: `apiVersion: 1
+datasources:
+- access: proxy
+  jsonData:
+    httpHeaderName1: x-scope-orgid
+  name: Tempo observability/simplest (dev)
+  secureJsonData:
+    httpHeaderValue1: dev
+  type: tempo
+  uid: tempo-simplest-dev
+  url: http://tempo-simplest-query-frontend.observability.svc.cluster.local:3200
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			objs, err := BuildTenantDatasources(test.params)
+			require.NoError(t, err)
+			require.Len(t, objs, 1)
+
+			cm := objs[0].(*corev1.ConfigMap)
+			assert.Equal(t, "tempo-simplest-grafana-datasources", cm.Name)
+			assert.Equal(t, "1", cm.Labels[DatasourceLabel])
+			assert.Equal(t, test.expected, cm.Data[DatasourcesKey])
+		})
+	}
+}
+
+func TestBuildTenantDatasources_Disabled(t *testing.T) {
+	objs, err := BuildTenantDatasources(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Tenants: &v1alpha1.TenantsSpec{
+				Mode:           v1alpha1.ModeStatic,
+				Authentication: []v1alpha1.AuthenticationSpec{{TenantName: "dev", TenantID: "abcd1"}},
+			},
+		},
+	}})
+	require.NoError(t, err)
+	assert.Empty(t, objs)
+}
@@ -0,0 +1,83 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// DatasourceGVK is the GroupVersionKind of the GrafanaDatasource resource of the Grafana Operator.
+var DatasourceGVK = schema.GroupVersionKind{Group: "grafana.integreatly.org", Version: "v1beta1", Kind: "GrafanaDatasource"}
+
+// BuildOperatorDatasources creates GrafanaDatasources of the Grafana Operator for the TempoStack,
+// one datasource per tenant if multi-tenancy is enabled.
+func BuildOperatorDatasources(params manifestutils.Params) ([]client.Object, error) {
+	tempo := params.Tempo
+	grafana := tempo.Spec.Observability.Grafana
+	if !grafana.CreateDatasource {
+		return nil, nil
+	}
+
+	instanceSelector := map[string]interface{}{}
+	if grafana.InstanceSelector != nil {
+		var err error
+		instanceSelector, err = runtime.DefaultUnstructuredConverter.ToUnstructured(grafana.InstanceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert grafana instance selector, err: %w", err)
+		}
+	}
+
+	if tempo.Spec.Tenants == nil {
+		ds := datasource{
+			Name:   fmt.Sprintf("Tempo %s/%s", tempo.Namespace, tempo.Name),
+			UID:    naming.Name("", tempo.Name),
+			Type:   "tempo",
+			Access: "proxy",
+			URL:    fmt.Sprintf("http://%s:%d", naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.QueryFrontendComponentName), manifestutils.PortHTTPServer),
+		}
+		obj, err := operatorDatasource(params, naming.Name("", tempo.Name), instanceSelector, ds)
+		if err != nil {
+			return nil, err
+		}
+		return []client.Object{obj}, nil
+	}
+
+	var objs []client.Object
+	for _, tenant := range tempo.Spec.Tenants.Authentication {
+		obj, err := operatorDatasource(params, naming.Name(tenant.TenantName, tempo.Name), instanceSelector, tenantDatasource(params, tenant))
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func operatorDatasource(params manifestutils.Params, name string, instanceSelector map[string]interface{}, ds datasource) (*unstructured.Unstructured, error) {
+	raw, err := json.Marshal(ds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create grafana datasource, err: %w", err)
+	}
+	spec := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		return nil, fmt.Errorf("failed to create grafana datasource, err: %w", err)
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(DatasourceGVK)
+	obj.SetName(name)
+	obj.SetNamespace(params.Tempo.Namespace)
+	obj.SetLabels(manifestutils.CommonLabels(params.Tempo.Name))
+	obj.Object["spec"] = map[string]interface{}{
+		"instanceSelector": instanceSelector,
+		"datasource":       spec,
+	}
+	return obj, nil
+}
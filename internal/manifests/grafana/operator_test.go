@@ -0,0 +1,90 @@
+package grafana
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildOperatorDatasources(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+	}
+
+	objs, err := BuildOperatorDatasources(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+	assert.Empty(t, objs)
+
+	tempo.Spec.Observability.Grafana = v1alpha1.GrafanaConfigSpec{
+		CreateDatasource: true,
+		InstanceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"dashboards": "grafana"}},
+	}
+	objs, err = BuildOperatorDatasources(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+
+	obj := objs[0].(*unstructured.Unstructured)
+	assert.Equal(t, DatasourceGVK, obj.GroupVersionKind())
+	assert.Equal(t, "tempo-simplest", obj.GetName())
+	assert.Equal(t, "observability", obj.GetNamespace())
+	assert.Equal(t, "simplest", obj.GetLabels()["app.kubernetes.io/instance"])
+	assert.Equal(t, map[string]interface{}{
+		"instanceSelector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{"dashboards": "grafana"},
+		},
+		"datasource": map[string]interface{}{
+			"name":   "Tempo observability/simplest",
+			"uid":    "tempo-simplest",
+			"type":   "tempo",
+			"access": "proxy",
+			"url":    "http://tempo-simplest-query-frontend.observability.svc.cluster.local:3200",
+		},
+	}, obj.Object["spec"])
+}
+
+func TestBuildOperatorDatasources_Tenants(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Tenants: &v1alpha1.TenantsSpec{
+				Mode: v1alpha1.ModeOpenShift,
+				Authentication: []v1alpha1.AuthenticationSpec{
+					{TenantName: "dev", TenantID: "abcd1"},
+					{TenantName: "prod", TenantID: "abcd2"},
+				},
+			},
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway: v1alpha1.TempoGatewaySpec{Enabled: true},
+			},
+			Observability: v1alpha1.ObservabilitySpec{
+				Grafana: v1alpha1.GrafanaConfigSpec{
+					CreateDatasource: true,
+					InstanceSelector: &metav1.LabelSelector{},
+				},
+			},
+		},
+	}
+
+	objs, err := BuildOperatorDatasources(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+
+	assert.Equal(t, "tempo-simplest-dev", objs[0].GetName())
+	assert.Equal(t, "tempo-simplest-prod", objs[1].GetName())
+
+	url, _, err := unstructured.NestedString(objs[1].(*unstructured.Unstructured).Object, "spec", "datasource", "url")
+	require.NoError(t, err)
+	assert.Equal(t, "http://tempo-simplest-gateway.observability.svc.cluster.local:8080/api/traces/v1/prod/tempo", url)
+}
@@ -0,0 +1,40 @@
+package hibernation
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+// Configure scales all Deployments and StatefulSets to zero replicas if the TempoStack is hibernated.
+// The autoscalers and PodDisruptionBudgets are removed, because they would scale up the components again
+// or block the eviction of the remaining pods. PersistentVolumeClaims and the configuration are kept.
+func Configure(tempo v1alpha1.TempoStack, objs []client.Object) []client.Object {
+	if !tempo.Spec.Hibernate {
+		return objs
+	}
+
+	res := make([]client.Object, 0, len(objs))
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			o.Spec.Replicas = pointer.Int32(0)
+		case *appsv1.StatefulSet:
+			o.Spec.Replicas = pointer.Int32(0)
+		case *autoscalingv2.HorizontalPodAutoscaler, *policyv1.PodDisruptionBudget:
+			continue
+		case *unstructured.Unstructured:
+			if o.GroupVersionKind() == manifestutils.ScaledObjectGVK {
+				continue
+			}
+		}
+		res = append(res, obj)
+	}
+	return res
+}
@@ -0,0 +1,53 @@
+package hibernation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func objects() []client.Object {
+	scaledObject := &unstructured.Unstructured{}
+	scaledObject.SetGroupVersionKind(manifestutils.ScaledObjectGVK)
+
+	return []client.Object{
+		&appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: pointer.Int32(2)}},
+		&appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Replicas: pointer.Int32(3)}},
+		&autoscalingv2.HorizontalPodAutoscaler{},
+		&policyv1.PodDisruptionBudget{},
+		scaledObject,
+		&corev1.Service{},
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	t.Run("not hibernated", func(t *testing.T) {
+		objs := objects()
+		assert.Equal(t, objects(), Configure(v1alpha1.TempoStack{}, objs))
+	})
+
+	t.Run("hibernated", func(t *testing.T) {
+		tempo := v1alpha1.TempoStack{
+			Spec: v1alpha1.TempoStackSpec{
+				Hibernate: true,
+			},
+		}
+
+		objs := Configure(tempo, objects())
+		require.Len(t, objs, 3)
+		assert.Equal(t, pointer.Int32(0), objs[0].(*appsv1.Deployment).Spec.Replicas)
+		assert.Equal(t, pointer.Int32(0), objs[1].(*appsv1.StatefulSet).Spec.Replicas)
+		assert.IsType(t, &corev1.Service{}, objs[2])
+	})
+}
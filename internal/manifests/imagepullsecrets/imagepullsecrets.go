@@ -0,0 +1,35 @@
+package imagepullsecrets
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+// ConfigurePods adds the image pull secrets of the TempoStack to the pods of the Deployments, StatefulSets, CronJobs and Jobs.
+func ConfigurePods(tempo v1alpha1.TempoStack, objs []client.Object) {
+	if len(tempo.Spec.ImagePullSecrets) == 0 {
+		return
+	}
+
+	for _, obj := range objs {
+		var pod *corev1.PodSpec
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			pod = &o.Spec.Template.Spec
+		case *appsv1.StatefulSet:
+			pod = &o.Spec.Template.Spec
+		case *batchv1.CronJob:
+			pod = &o.Spec.JobTemplate.Spec.Template.Spec
+		case *batchv1.Job:
+			pod = &o.Spec.Template.Spec
+		default:
+			continue
+		}
+
+		pod.ImagePullSecrets = append(pod.ImagePullSecrets, tempo.Spec.ImagePullSecrets...)
+	}
+}
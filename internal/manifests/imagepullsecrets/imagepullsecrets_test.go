@@ -0,0 +1,32 @@
+package imagepullsecrets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestConfigurePods(t *testing.T) {
+	ingester := &appsv1.StatefulSet{}
+	querier := &appsv1.Deployment{}
+	configMap := &corev1.ConfigMap{}
+	objs := []client.Object{ingester, querier, configMap}
+
+	ConfigurePods(v1alpha1.TempoStack{}, objs)
+	assert.Nil(t, ingester.Spec.Template.Spec.ImagePullSecrets)
+	assert.Nil(t, querier.Spec.Template.Spec.ImagePullSecrets)
+
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-credentials"}},
+		},
+	}
+	ConfigurePods(tempo, objs)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "registry-credentials"}}, ingester.Spec.Template.Spec.ImagePullSecrets)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "registry-credentials"}}, querier.Spec.Template.Spec.ImagePullSecrets)
+}
@@ -1,6 +1,8 @@
 package ingester
 
 import (
+	"fmt"
+
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -12,10 +14,18 @@ import (
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/memberlist"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
+	"github.com/grafana/tempo-operator/internal/manifests/spiffe"
 )
 
 const (
 	dataVolumeName = "data"
+
+	// zoneLabel is the label of the ingester pods of a zone, if zone-aware replication is enabled.
+	zoneLabel = "tempo.grafana.com/zone"
+	// availabilityZoneEnv is referenced by the ingester lifecycler in the Tempo configuration.
+	availabilityZoneEnv = "INGESTER_AVAILABILITY_ZONE"
+	// defaultZoneTopologyKey is the node label of the availability zone.
+	defaultZoneTopologyKey = "topology.kubernetes.io/zone"
 )
 
 // BuildIngester creates distributor objects.
@@ -29,7 +39,15 @@ func BuildIngester(params manifestutils.Params) ([]client.Object, error) {
 	gates := params.Gates
 	tempo := params.Tempo
 
-	if gates.HTTPEncryption || gates.GRPCEncryption {
+	manifestutils.ConfigureRuntimeOverrides(tempo, &ss.Spec.Template.Spec)
+	manifestutils.ConfigureMemberlist(tempo, &ss.Spec.Template.Spec)
+	manifestutils.ConfigureKVStore(tempo, &ss.Spec.Template.Spec)
+
+	if (gates.HTTPEncryption || gates.GRPCEncryption) && tempo.Spec.SPIFFE != nil {
+		if err := spiffe.ConfigurePodSpec(tempo, &ss.Spec.Template.Spec); err != nil {
+			return nil, err
+		}
+	} else if gates.HTTPEncryption || gates.GRPCEncryption {
 		caBundleName := naming.SigningCABundleName(tempo.Name)
 		if err := manifestutils.ConfigureServiceCA(&ss.Spec.Template.Spec, caBundleName); err != nil {
 			return nil, err
@@ -41,14 +59,53 @@ func BuildIngester(params manifestutils.Params) ([]client.Object, error) {
 		}
 	}
 
-	return []client.Object{ss, service(tempo)}, nil
+	zoneAware := tempo.Spec.Template.Ingester.ZoneAwareReplication
+	var objs []client.Object
+	if zoneAware != nil {
+		for _, zone := range zoneAware.Zones {
+			objs = append(objs, zonalStatefulSet(ss, zone, zoneAware.TopologyKey))
+		}
+	} else {
+		objs = append(objs, ss)
+	}
+
+	objs = append(objs, service(tempo))
+	if zoneAware != nil || manifestutils.MultipleReplicas(ss.Spec.Replicas, nil) {
+		objs = append(objs, manifestutils.PodDisruptionBudget(tempo, manifestutils.IngesterComponentName, tempo.Spec.Template.Ingester.PodDisruptionBudget))
+	}
+	return objs, nil
+}
+
+// zonalStatefulSet creates the ingester StatefulSet of an availability zone.
+// The pods are scheduled on the nodes of the zone and register with the zone in the ring.
+func zonalStatefulSet(ss *v1.StatefulSet, zone string, topologyKey string) *v1.StatefulSet {
+	if topologyKey == "" {
+		topologyKey = defaultZoneTopologyKey
+	}
+
+	zonal := ss.DeepCopy()
+	zonal.Name = fmt.Sprintf("%s-%s", ss.Name, zone)
+	zonal.Labels = k8slabels.Merge(ss.Labels, k8slabels.Set{zoneLabel: zone})
+	zonal.Spec.Selector.MatchLabels = k8slabels.Merge(ss.Spec.Selector.MatchLabels, k8slabels.Set{zoneLabel: zone})
+	zonal.Spec.Template.Labels = k8slabels.Merge(ss.Spec.Template.Labels, k8slabels.Set{zoneLabel: zone})
+
+	pod := &zonal.Spec.Template.Spec
+	pod.NodeSelector = k8slabels.Merge(pod.NodeSelector, k8slabels.Set{topologyKey: zone})
+
+	container := &pod.Containers[0]
+	container.Args = append(container.Args, "-config.expand-env=true")
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  availabilityZoneEnv,
+		Value: zone,
+	})
+	return zonal
 }
 
 func statefulSet(params manifestutils.Params) (*v1.StatefulSet, error) {
 	tempo := params.Tempo
+	httpPort, grpcPort := manifestutils.ServerPorts(tempo)
 	labels := manifestutils.ComponentLabels(manifestutils.IngesterComponentName, tempo.Name)
-	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum)
-	filesystem := corev1.PersistentVolumeFilesystem
+	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum, params.Tempo.Annotations[manifestutils.CertRotationRequiredAtAnnotation])
 	cfg := tempo.Spec.Template.Ingester
 
 	ss := &v1.StatefulSet{
@@ -72,14 +129,16 @@ func statefulSet(params manifestutils.Params) (*v1.StatefulSet, error) {
 
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      k8slabels.Merge(labels, memberlist.GossipSelector),
-					Annotations: annotations,
+					Labels:      manifestutils.PodLabels(cfg.PodLabels, k8slabels.Merge(labels, memberlist.GossipSelector)),
+					Annotations: manifestutils.PodAnnotations(cfg.PodAnnotations, annotations),
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: tempo.Spec.ServiceAccount,
-					NodeSelector:       cfg.NodeSelector,
-					Tolerations:        cfg.Tolerations,
-					Affinity:           manifestutils.DefaultAffinity(labels),
+					ServiceAccountName:        tempo.Spec.ServiceAccount,
+					NodeSelector:              cfg.NodeSelector,
+					Tolerations:               cfg.Tolerations,
+					TopologySpreadConstraints: manifestutils.TopologySpreadConstraints(cfg.TopologySpreadConstraints, labels),
+					PriorityClassName:         cfg.PriorityClassName,
+					Affinity:                  manifestutils.Affinity(cfg.Affinity, labels),
 					Containers: []corev1.Container{
 						{
 							Name:  "tempo",
@@ -87,7 +146,7 @@ func statefulSet(params manifestutils.Params) (*v1.StatefulSet, error) {
 							Args: []string{
 								"-target=ingester",
 								"-config.file=/conf/tempo.yaml",
-								"-log.level=info",
+								manifestutils.LogLevelArg(tempo, cfg.TempoComponentSpec),
 							},
 							VolumeMounts: []corev1.VolumeMount{
 								{
@@ -103,17 +162,17 @@ func statefulSet(params manifestutils.Params) (*v1.StatefulSet, error) {
 							Ports: []corev1.ContainerPort{
 								{
 									Name:          manifestutils.HttpMemberlistPortName,
-									ContainerPort: manifestutils.PortMemberlist,
+									ContainerPort: manifestutils.MemberlistPort(tempo),
 									Protocol:      corev1.ProtocolTCP,
 								},
 								{
 									Name:          manifestutils.HttpPortName,
-									ContainerPort: manifestutils.PortHTTPServer,
+									ContainerPort: httpPort,
 									Protocol:      corev1.ProtocolTCP,
 								},
 								{
 									Name:          manifestutils.GrpcPortName,
-									ContainerPort: manifestutils.PortGRPCServer,
+									ContainerPort: grpcPort,
 									Protocol:      corev1.ProtocolTCP,
 								},
 							},
@@ -137,21 +196,7 @@ func statefulSet(params manifestutils.Params) (*v1.StatefulSet, error) {
 				},
 			},
 			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: dataVolumeName,
-					},
-					Spec: corev1.PersistentVolumeClaimSpec{
-						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
-						Resources: corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceStorage: tempo.Spec.StorageSize,
-							},
-						},
-						StorageClassName: tempo.Spec.StorageClassName,
-						VolumeMode:       &filesystem,
-					},
-				},
+				manifestutils.PersistentVolumeClaim(tempo, dataVolumeName, cfg.VolumeClaimTemplate),
 			},
 		},
 	}
@@ -173,9 +218,10 @@ func service(tempo v1alpha1.TempoStack) *corev1.Service {
 	labels := manifestutils.ComponentLabels(manifestutils.IngesterComponentName, tempo.Name)
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      naming.Name(manifestutils.IngesterComponentName, tempo.Name),
-			Namespace: tempo.Namespace,
-			Labels:    labels,
+			Name:        naming.Name(manifestutils.IngesterComponentName, tempo.Name),
+			Namespace:   tempo.Namespace,
+			Labels:      manifestutils.ServiceLabels(tempo.Spec.Template.Ingester.ServiceLabels, labels),
+			Annotations: manifestutils.ServiceAnnotations(tempo.Spec.Template.Ingester.ServiceAnnotations, nil),
 		},
 		Spec: corev1.ServiceSpec{
 			Ports: []corev1.ServicePort{
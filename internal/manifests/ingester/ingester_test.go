@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
@@ -39,11 +40,13 @@ func TestBuildIngester(t *testing.T) {
 			StorageSize:      resource.MustParse("10Gi"),
 			StorageClassName: &storageClassName,
 			Template: v1alpha1.TempoTemplateSpec{
-				Ingester: v1alpha1.TempoComponentSpec{
-					NodeSelector: map[string]string{"a": "b"},
-					Tolerations: []corev1.Toleration{
-						{
-							Key: "c",
+				Ingester: v1alpha1.TempoIngesterSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						NodeSelector: map[string]string{"a": "b"},
+						Tolerations: []corev1.Toleration{
+							{
+								Key: "c",
+							},
 						},
 					},
 				},
@@ -61,7 +64,7 @@ func TestBuildIngester(t *testing.T) {
 	require.NoError(t, err)
 
 	labels := manifestutils.ComponentLabels("ingester", "test")
-	annotations := manifestutils.CommonAnnotations("")
+	annotations := manifestutils.CommonAnnotations("", "")
 	assert.Equal(t, 2, len(objects))
 	assert.Equal(t, &v1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -232,3 +235,220 @@ func TestBuildIngester(t *testing.T) {
 		},
 	}, objects[1])
 }
+
+func TestBuildIngesterVolumeClaimTemplate(t *testing.T) {
+	defaultStorageClassName := "default"
+	storageClassName := "fast"
+	filesystem := corev1.PersistentVolumeFilesystem
+	objects, err := BuildIngester(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			StorageSize:      resource.MustParse("10Gi"),
+			StorageClassName: &defaultStorageClassName,
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoIngesterSpec{
+					VolumeClaimTemplate: &v1alpha1.VolumeClaimTemplateSpec{
+						StorageClassName: &storageClassName,
+						AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOncePod},
+						Annotations:      map[string]string{"a": "b"},
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	ss := objects[0].(*v1.StatefulSet)
+	assert.Equal(t, []corev1.PersistentVolumeClaim{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        dataVolumeName,
+				Annotations: map[string]string{"a": "b"},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOncePod},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("10Gi"),
+					},
+				},
+				StorageClassName: &storageClassName,
+				VolumeMode:       &filesystem,
+			},
+		},
+	}, ss.Spec.VolumeClaimTemplates)
+}
+
+func TestBuildIngesterTopologySpreadConstraints(t *testing.T) {
+	objects, err := BuildIngester(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoIngesterSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+							{
+								MaxSkew:           1,
+								TopologyKey:       "topology.kubernetes.io/zone",
+								WhenUnsatisfiable: corev1.DoNotSchedule,
+							},
+						},
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	ss := objects[0].(*v1.StatefulSet)
+	assert.Equal(t, []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: manifestutils.ComponentLabels(manifestutils.IngesterComponentName, "test"),
+			},
+		},
+	}, ss.Spec.Template.Spec.TopologySpreadConstraints)
+}
+
+func TestBuildIngesterPriorityClassName(t *testing.T) {
+	objects, err := BuildIngester(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoIngesterSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						PriorityClassName: "tracing-critical",
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	ss := objects[0].(*v1.StatefulSet)
+	assert.Equal(t, "tracing-critical", ss.Spec.Template.Spec.PriorityClassName)
+}
+
+func TestBuildIngesterScheduling(t *testing.T) {
+	affinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      "node.kubernetes.io/instance-type",
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{"storage-optimized"},
+					}},
+				}},
+			},
+		},
+	}
+	tolerations := []corev1.Toleration{{
+		Key:      "dedicated",
+		Operator: corev1.TolerationOpEqual,
+		Value:    "tracing",
+		Effect:   corev1.TaintEffectNoSchedule,
+	}}
+
+	objects, err := BuildIngester(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoIngesterSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						NodeSelector: map[string]string{"storage": "ssd"},
+						Tolerations:  tolerations,
+						Affinity:     affinity,
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	ss := objects[0].(*v1.StatefulSet)
+	assert.Equal(t, map[string]string{"storage": "ssd"}, ss.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, tolerations, ss.Spec.Template.Spec.Tolerations)
+	assert.Equal(t, affinity, ss.Spec.Template.Spec.Affinity)
+}
+
+func TestBuildIngesterPodDisruptionBudget(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoIngesterSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						Replicas: pointer.Int32(3),
+					},
+				},
+			},
+		},
+	}
+	objects, err := BuildIngester(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+
+	require.Len(t, objects, 3)
+	assert.Equal(t, manifestutils.PodDisruptionBudget(tempo, manifestutils.IngesterComponentName, nil), objects[2])
+}
+
+func TestBuildIngesterZoneAwareReplication(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			ReplicationFactor: 2,
+			Template: v1alpha1.TempoTemplateSpec{
+				Ingester: v1alpha1.TempoIngesterSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						NodeSelector: map[string]string{"a": "b"},
+					},
+					ZoneAwareReplication: &v1alpha1.ZoneAwareReplicationSpec{
+						Zones: []string{"zone-a", "zone-b"},
+					},
+				},
+			},
+		},
+	}
+	objects, err := BuildIngester(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+	require.Len(t, objects, 4)
+
+	labels := manifestutils.ComponentLabels(manifestutils.IngesterComponentName, "test")
+	for i, zone := range []string{"zone-a", "zone-b"} {
+		ss := objects[i].(*v1.StatefulSet)
+		zoneLabels := k8slabels.Merge(labels, k8slabels.Set{"tempo.grafana.com/zone": zone})
+		assert.Equal(t, "tempo-test-ingester-"+zone, ss.Name)
+		assert.Equal(t, map[string]string(zoneLabels), ss.Spec.Selector.MatchLabels)
+		assert.Equal(t, zone, ss.Spec.Template.Labels["tempo.grafana.com/zone"])
+		assert.Equal(t, map[string]string{"a": "b", "topology.kubernetes.io/zone": zone}, ss.Spec.Template.Spec.NodeSelector)
+
+		container := ss.Spec.Template.Spec.Containers[0]
+		assert.Contains(t, container.Args, "-config.expand-env=true")
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "INGESTER_AVAILABILITY_ZONE", Value: zone})
+	}
+
+	// The Service and the PodDisruptionBudget select the ingesters of all zones.
+	assert.Equal(t, map[string]string(labels), objects[2].(*corev1.Service).Spec.Selector)
+	assert.Equal(t, manifestutils.PodDisruptionBudget(tempo, manifestutils.IngesterComponentName, nil), objects[3])
+}
@@ -1,25 +1,48 @@
 package manifests
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/grafana/tempo-operator/internal/manifests/alerts"
+	"github.com/grafana/tempo-operator/internal/manifests/backup"
+	"github.com/grafana/tempo-operator/internal/manifests/certmanager"
 	"github.com/grafana/tempo-operator/internal/manifests/compactor"
 	"github.com/grafana/tempo-operator/internal/manifests/config"
+	"github.com/grafana/tempo-operator/internal/manifests/containerenv"
 	"github.com/grafana/tempo-operator/internal/manifests/distributor"
+	"github.com/grafana/tempo-operator/internal/manifests/extracontainers"
 	"github.com/grafana/tempo-operator/internal/manifests/gateway"
+	"github.com/grafana/tempo-operator/internal/manifests/goruntime"
+	"github.com/grafana/tempo-operator/internal/manifests/grafana"
+	"github.com/grafana/tempo-operator/internal/manifests/hibernation"
+	"github.com/grafana/tempo-operator/internal/manifests/imagepullsecrets"
 	"github.com/grafana/tempo-operator/internal/manifests/ingester"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/memberlist"
+	"github.com/grafana/tempo-operator/internal/manifests/memcached"
+	"github.com/grafana/tempo-operator/internal/manifests/metricsgenerator"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
+	"github.com/grafana/tempo-operator/internal/manifests/networkpolicy"
+	"github.com/grafana/tempo-operator/internal/manifests/oauthproxy"
+	"github.com/grafana/tempo-operator/internal/manifests/otelcollector"
 	"github.com/grafana/tempo-operator/internal/manifests/querier"
 	"github.com/grafana/tempo-operator/internal/manifests/queryfrontend"
+	"github.com/grafana/tempo-operator/internal/manifests/securitycontext"
 	"github.com/grafana/tempo-operator/internal/manifests/serviceaccount"
+	"github.com/grafana/tempo-operator/internal/manifests/servicemesh"
 	"github.com/grafana/tempo-operator/internal/manifests/servicemonitor"
+	"github.com/grafana/tempo-operator/internal/manifests/servicetopology"
+	"github.com/grafana/tempo-operator/internal/manifests/servingcerts"
+	"github.com/grafana/tempo-operator/internal/manifests/spiffe"
+	"github.com/grafana/tempo-operator/internal/manifests/tempocli"
+	"github.com/grafana/tempo-operator/internal/manifests/vpa"
 )
 
 // BuildAll creates objects for Tempo deployment.
 func BuildAll(params manifestutils.Params) ([]client.Object, error) {
+	params.Gates = servicemesh.FeatureGates(params.Gates, params.Tempo)
+
 	configMaps, configChecksum, err := config.BuildConfigMap(params)
 	if err != nil {
 		return nil, err
@@ -53,7 +76,11 @@ func BuildAll(params manifestutils.Params) ([]client.Object, error) {
 	var manifests []client.Object
 	manifests = append(manifests, configMaps)
 	if params.Tempo.Spec.ServiceAccount == naming.DefaultServiceAccountName(params.Tempo.Name) {
-		manifests = append(manifests, serviceaccount.BuildDefaultServiceAccount(params.Tempo))
+		sa := serviceaccount.BuildDefaultServiceAccount(params.Tempo)
+		if oauthproxy.Enabled(params.Tempo) {
+			oauthproxy.PatchServiceAccount(params.Tempo, sa)
+		}
+		manifests = append(manifests, sa)
 	}
 	manifests = append(manifests, distributorObjs...)
 	manifests = append(manifests, ingesterObjs...)
@@ -62,6 +89,18 @@ func BuildAll(params manifestutils.Params) ([]client.Object, error) {
 	manifests = append(manifests, querierObjs...)
 	manifests = append(manifests, compactorObjs...)
 
+	if params.Tempo.Spec.Template.MetricsGenerator.Enabled {
+		metricsGeneratorObjs, err := metricsgenerator.BuildMetricsGenerator(params)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, metricsGeneratorObjs...)
+	}
+
+	if params.Tempo.Spec.Template.Memcached.Enabled {
+		manifests = append(manifests, memcached.BuildMemcached(params)...)
+	}
+
 	if params.Tempo.Spec.Template.Gateway.Enabled {
 		gw, err := gateway.BuildGateway(params)
 		if err != nil {
@@ -70,6 +109,86 @@ func BuildAll(params manifestutils.Params) ([]client.Object, error) {
 		manifests = append(manifests, gw...)
 	}
 
+	if params.Tempo.Spec.Backup != nil {
+		cronJob, err := backup.BuildBackup(params)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, cronJob)
+	}
+
+	if params.Tempo.Spec.Storage.MigrateFrom != nil {
+		job, err := backup.BuildMigration(params)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, job)
+	}
+
+	purges, err := backup.BuildTenantPurges(params)
+	if err != nil {
+		return nil, err
+	}
+	manifests = append(manifests, purges...)
+
+	cliJobs, err := tempocli.BuildJobs(params)
+	if err != nil {
+		return nil, err
+	}
+	manifests = append(manifests, cliJobs...)
+
+	goruntime.ConfigureContainers(params.Tempo, manifests)
+	securitycontext.ConfigurePods(params, manifests)
+	containerenv.ConfigurePods(params.Tempo, manifests)
+	extracontainers.ConfigurePods(params.Tempo, manifests)
+	imagepullsecrets.ConfigurePods(params.Tempo, manifests)
+	servicemesh.ConfigurePods(params.Tempo, manifests)
+	servicetopology.ConfigureServices(params.Tempo, manifests)
+	manifests = append(manifests, vpa.BuildVerticalPodAutoscalers(params.Tempo, manifests)...)
+	manifests = hibernation.Configure(params.Tempo, manifests)
+
+	if networkpolicy.Enabled(params.Tempo) {
+		manifests = append(manifests, networkpolicy.BuildComponentPolicies(params.Tempo)...)
+	} else {
+		manifests = append(manifests, networkpolicy.BuildTrustedHeaderPolicies(params.Tempo)...)
+	}
+
+	datasources, err := grafana.BuildTenantDatasources(params)
+	if err != nil {
+		return nil, err
+	}
+	manifests = append(manifests, datasources...)
+
+	operatorDatasources, err := grafana.BuildOperatorDatasources(params)
+	if err != nil {
+		return nil, err
+	}
+	manifests = append(manifests, operatorDatasources...)
+
+	collector, err := otelcollector.BuildCollector(params)
+	if err != nil {
+		return nil, err
+	}
+	manifests = append(manifests, collector...)
+
+	dashboards, err := grafana.BuildDashboards(params.Tempo)
+	if err != nil {
+		return nil, err
+	}
+	manifests = append(manifests, dashboards...)
+
+	if params.Tempo.Spec.CertManager != nil {
+		manifests = append(manifests, certmanager.BuildCertificates(params)...)
+	}
+
+	if params.Tempo.Spec.SPIFFE != nil && (params.Gates.HTTPEncryption || params.Gates.GRPCEncryption) {
+		manifests = append(manifests, spiffe.BuildConfigMap(params.Tempo))
+	}
+
+	if params.Gates.OpenShift.ServingCertsAllServices {
+		manifests = servingcerts.ConfigureServices(params.Tempo, manifests)
+	}
+
 	if params.Tempo.Spec.Observability.Metrics.CreateServiceMonitors {
 		manifests = append(manifests, servicemonitor.BuildServiceMonitors(params)...)
 	}
@@ -84,3 +203,23 @@ func BuildAll(params manifestutils.Params) ([]client.Object, error) {
 
 	return manifests, nil
 }
+
+// ConfigChecksum returns the checksum of the Tempo configuration, as annotated on the pods of the objects created by BuildAll.
+func ConfigChecksum(objects []client.Object) string {
+	for _, obj := range objects {
+		var annotations map[string]string
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			annotations = o.Spec.Template.Annotations
+		case *appsv1.StatefulSet:
+			annotations = o.Spec.Template.Annotations
+		default:
+			continue
+		}
+
+		if checksum, ok := annotations[manifestutils.ConfigChecksumAnnotation]; ok {
+			return checksum
+		}
+	}
+	return ""
+}
@@ -5,7 +5,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
@@ -81,3 +84,20 @@ func TestBuildAll(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, objects, 17)
 }
+
+func TestConfigChecksum(t *testing.T) {
+	objects := []client.Object{
+		&corev1.ConfigMap{},
+		&appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: manifestutils.CommonAnnotations("abc", ""),
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, "abc", ConfigChecksum(objects))
+	assert.Equal(t, "", ConfigChecksum(objects[:1]))
+}
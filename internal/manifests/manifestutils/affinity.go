@@ -35,3 +35,31 @@ func DefaultAffinity(labels labels.Set) *corev1.Affinity {
 		},
 	}
 }
+
+// Affinity returns the affinity of a component.
+// The default affinity is used if the component does not define an affinity.
+func Affinity(affinity *corev1.Affinity, labels labels.Set) *corev1.Affinity {
+	if affinity == nil {
+		return DefaultAffinity(labels)
+	}
+	return affinity.DeepCopy()
+}
+
+// TopologySpreadConstraints returns the topology spread constraints of a component.
+// Constraints without a label selector are applied to the pods of the component.
+func TopologySpreadConstraints(constraints []corev1.TopologySpreadConstraint, labels labels.Set) []corev1.TopologySpreadConstraint {
+	if len(constraints) == 0 {
+		return nil
+	}
+
+	res := make([]corev1.TopologySpreadConstraint, len(constraints))
+	for i, constraint := range constraints {
+		res[i] = *constraint.DeepCopy()
+		if res[i].LabelSelector == nil {
+			res[i].LabelSelector = &metav1.LabelSelector{
+				MatchLabels: labels,
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,87 @@
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAffinity(t *testing.T) {
+	labels := ComponentLabels(IngesterComponentName, "test")
+	assert.Equal(t, DefaultAffinity(labels), Affinity(nil, labels))
+
+	custom := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      "node.kubernetes.io/instance-type",
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{"storage-optimized"},
+					}},
+				}},
+			},
+		},
+	}
+	assert.Equal(t, custom, Affinity(custom, labels))
+}
+
+func TestTopologySpreadConstraints(t *testing.T) {
+	labels := ComponentLabels(IngesterComponentName, "test")
+	custom := &metav1.LabelSelector{MatchLabels: map[string]string{"a": "b"}}
+
+	tests := []struct {
+		name     string
+		input    []corev1.TopologySpreadConstraint
+		expected []corev1.TopologySpreadConstraint
+	}{
+		{
+			name: "no constraints",
+		},
+		{
+			name: "label selector defaults to the component labels",
+			input: []corev1.TopologySpreadConstraint{
+				{
+					MaxSkew:           1,
+					TopologyKey:       "topology.kubernetes.io/zone",
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+				},
+			},
+			expected: []corev1.TopologySpreadConstraint{
+				{
+					MaxSkew:           1,
+					TopologyKey:       "topology.kubernetes.io/zone",
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+					LabelSelector:     &metav1.LabelSelector{MatchLabels: labels},
+				},
+			},
+		},
+		{
+			name: "custom label selector",
+			input: []corev1.TopologySpreadConstraint{
+				{
+					MaxSkew:           2,
+					TopologyKey:       "kubernetes.io/hostname",
+					WhenUnsatisfiable: corev1.ScheduleAnyway,
+					LabelSelector:     custom,
+				},
+			},
+			expected: []corev1.TopologySpreadConstraint{
+				{
+					MaxSkew:           2,
+					TopologyKey:       "kubernetes.io/hostname",
+					WhenUnsatisfiable: corev1.ScheduleAnyway,
+					LabelSelector:     custom,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, TopologySpreadConstraints(test.input, labels))
+		})
+	}
+}
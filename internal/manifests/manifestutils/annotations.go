@@ -1,8 +1,48 @@
 package manifestutils
 
+// CertRotationRequiredAtAnnotation is set on a TempoStack when its certificates need to be rotated.
+const CertRotationRequiredAtAnnotation = "tempo.grafana.com/certRotationRequiredAt"
+
+// ConfigChecksumAnnotation is set on the pods of all components to the checksum of the Tempo configuration.
+const ConfigChecksumAnnotation = "tempo.grafana.com/config.hash"
+
 // CommonAnnotations returns common annotations for each pod created by the operator.
-func CommonAnnotations(configChecksum string) map[string]string {
-	return map[string]string{
-		"tempo.grafana.com/config.hash": configChecksum,
+// The certRotationRequiredAt annotation triggers a rolling restart of the pods after
+// the certificates got rotated, so that all components pick up the new certificates.
+func CommonAnnotations(configChecksum string, certRotationRequiredAt string) map[string]string {
+	annotations := map[string]string{
+		ConfigChecksumAnnotation: configChecksum,
+	}
+	if certRotationRequiredAt != "" {
+		annotations[CertRotationRequiredAtAnnotation] = certRotationRequiredAt
+	}
+	return annotations
+}
+
+// PodAnnotations returns the annotations of the pods of a component.
+// The user-defined pod annotations cannot overwrite the annotations managed by the operator.
+func PodAnnotations(podAnnotations map[string]string, annotations map[string]string) map[string]string {
+	return mergeAnnotations(podAnnotations, annotations)
+}
+
+// ServiceAnnotations returns the annotations of the Services of a component.
+// The user-defined service annotations cannot overwrite the annotations managed by the operator.
+// The result never shares the map of the user-defined annotations, because annotations are added to it later on.
+func ServiceAnnotations(serviceAnnotations map[string]string, annotations map[string]string) map[string]string {
+	return mergeAnnotations(serviceAnnotations, annotations)
+}
+
+func mergeAnnotations(userAnnotations map[string]string, annotations map[string]string) map[string]string {
+	if len(userAnnotations) == 0 {
+		return annotations
+	}
+
+	res := make(map[string]string, len(userAnnotations)+len(annotations))
+	for k, v := range userAnnotations {
+		res[k] = v
+	}
+	for k, v := range annotations {
+		res[k] = v
 	}
+	return res
 }
@@ -0,0 +1,153 @@
+package manifestutils
+
+import (
+	"strconv"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const defaultTargetCPUUtilization = 80
+
+// ScaledObjectGVK is the GroupVersionKind of the KEDA ScaledObject resource.
+var ScaledObjectGVK = schema.GroupVersionKind{Group: "keda.sh", Version: "v1alpha1", Kind: "ScaledObject"}
+
+// Autoscaler creates the object which scales the Deployment of the given component,
+// a KEDA ScaledObject if KEDA is configured and a HorizontalPodAutoscaler otherwise.
+func Autoscaler(tempo v1alpha1.TempoStack, component string, autoscaling v1alpha1.AutoscalingSpec) client.Object {
+	if autoscaling.Keda != nil {
+		return ScaledObject(tempo, component, autoscaling)
+	}
+	return HorizontalPodAutoscaler(tempo, component, autoscaling)
+}
+
+// HorizontalPodAutoscaler creates a HorizontalPodAutoscaler which scales the Deployment of the given component.
+// The autoscaler scales on the average CPU utilization (80% by default) unless other metrics are configured.
+func HorizontalPodAutoscaler(tempo v1alpha1.TempoStack, component string, autoscaling v1alpha1.AutoscalingSpec) *autoscalingv2.HorizontalPodAutoscaler {
+	name := naming.Name(component, tempo.Name)
+
+	var metrics []autoscalingv2.MetricSpec
+	if autoscaling.TargetMemoryUtilization != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceMemory, *autoscaling.TargetMemoryUtilization))
+	}
+	if autoscaling.TargetCPUUtilization != nil || (len(metrics) == 0 && len(autoscaling.Metrics) == 0) {
+		target := pointer.Int32Deref(autoscaling.TargetCPUUtilization, defaultTargetCPUUtilization)
+		metrics = append([]autoscalingv2.MetricSpec{resourceMetric(corev1.ResourceCPU, target)}, metrics...)
+	}
+	metrics = append(metrics, autoscaling.Metrics...)
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: autoscalingv2.SchemeGroupVersion.String(),
+			Kind:       "HorizontalPodAutoscaler",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: tempo.Namespace,
+			Labels:    ComponentLabels(component, tempo.Name),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       name,
+			},
+			MinReplicas: autoscaling.MinReplicas,
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
+}
+
+func resourceMetric(resource corev1.ResourceName, utilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: resource,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: pointer.Int32(utilization),
+			},
+		},
+	}
+}
+
+// ScaledObject creates a KEDA ScaledObject which scales the Deployment of the given component.
+// KEDA manages the HorizontalPodAutoscaler of the Deployment. The CPU and memory utilization targets
+// are added as cpu and memory triggers.
+func ScaledObject(tempo v1alpha1.TempoStack, component string, autoscaling v1alpha1.AutoscalingSpec) *unstructured.Unstructured {
+	name := naming.Name(component, tempo.Name)
+	keda := autoscaling.Keda
+
+	var triggers []interface{}
+	if autoscaling.TargetCPUUtilization != nil {
+		triggers = append(triggers, resourceTrigger(corev1.ResourceCPU, *autoscaling.TargetCPUUtilization))
+	}
+	if autoscaling.TargetMemoryUtilization != nil {
+		triggers = append(triggers, resourceTrigger(corev1.ResourceMemory, *autoscaling.TargetMemoryUtilization))
+	}
+	for _, trigger := range keda.Triggers {
+		metadata := make(map[string]interface{}, len(trigger.Metadata))
+		for k, v := range trigger.Metadata {
+			metadata[k] = v
+		}
+		t := map[string]interface{}{
+			"type":     trigger.Type,
+			"metadata": metadata,
+		}
+		if trigger.Name != "" {
+			t["name"] = trigger.Name
+		}
+		if trigger.AuthenticationRef != "" {
+			t["authenticationRef"] = map[string]interface{}{
+				"name": trigger.AuthenticationRef,
+			}
+		}
+		triggers = append(triggers, t)
+	}
+
+	spec := map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"name":       name,
+		},
+		"maxReplicaCount": int64(autoscaling.MaxReplicas),
+		"triggers":        triggers,
+	}
+	if autoscaling.MinReplicas != nil {
+		spec["minReplicaCount"] = int64(*autoscaling.MinReplicas)
+	}
+	if keda.PollingInterval != nil {
+		spec["pollingInterval"] = int64(*keda.PollingInterval)
+	}
+	if keda.CooldownPeriod != nil {
+		spec["cooldownPeriod"] = int64(*keda.CooldownPeriod)
+	}
+
+	scaledObject := &unstructured.Unstructured{}
+	scaledObject.SetGroupVersionKind(ScaledObjectGVK)
+	scaledObject.SetName(name)
+	scaledObject.SetNamespace(tempo.Namespace)
+	scaledObject.SetLabels(ComponentLabels(component, tempo.Name))
+	scaledObject.Object["spec"] = spec
+	return scaledObject
+}
+
+func resourceTrigger(resource corev1.ResourceName, utilization int32) map[string]interface{} {
+	return map[string]interface{}{
+		"type":       string(resource),
+		"metricType": string(autoscalingv2.UtilizationMetricType),
+		"metadata": map[string]interface{}{
+			"value": strconv.Itoa(int(utilization)),
+		},
+	}
+}
@@ -0,0 +1,160 @@
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestHorizontalPodAutoscaler(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+	}
+	autoscaling := v1alpha1.AutoscalingSpec{
+		MinReplicas: pointer.Int32(2),
+		MaxReplicas: 5,
+	}
+
+	hpa := HorizontalPodAutoscaler(tempo, GatewayComponentName, autoscaling)
+	assert.Equal(t, "tempo-simplest-gateway", hpa.Name)
+	assert.Equal(t, autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       "tempo-simplest-gateway",
+	}, hpa.Spec.ScaleTargetRef)
+	assert.Equal(t, pointer.Int32(2), hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+	assert.Equal(t, []autoscalingv2.MetricSpec{
+		resourceMetric(corev1.ResourceCPU, 80),
+	}, hpa.Spec.Metrics)
+
+	autoscaling.TargetMemoryUtilization = pointer.Int32(70)
+	hpa = HorizontalPodAutoscaler(tempo, GatewayComponentName, autoscaling)
+	assert.Equal(t, []autoscalingv2.MetricSpec{
+		resourceMetric(corev1.ResourceMemory, 70),
+	}, hpa.Spec.Metrics)
+
+	autoscaling.TargetCPUUtilization = pointer.Int32(60)
+	hpa = HorizontalPodAutoscaler(tempo, GatewayComponentName, autoscaling)
+	assert.Equal(t, []autoscalingv2.MetricSpec{
+		resourceMetric(corev1.ResourceCPU, 60),
+		resourceMetric(corev1.ResourceMemory, 70),
+	}, hpa.Spec.Metrics)
+}
+
+func TestHorizontalPodAutoscaler_CustomMetrics(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+	}
+	averageValue := resource.MustParse("10k")
+	spansMetric := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: "tempo_distributor_spans_received_per_second"},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: &averageValue,
+			},
+		},
+	}
+	autoscaling := v1alpha1.AutoscalingSpec{
+		MaxReplicas: 10,
+		Metrics:     []autoscalingv2.MetricSpec{spansMetric},
+	}
+
+	hpa := HorizontalPodAutoscaler(tempo, DistributorComponentName, autoscaling)
+	assert.Equal(t, "tempo-simplest-distributor", hpa.Name)
+	assert.Equal(t, "tempo-simplest-distributor", hpa.Spec.ScaleTargetRef.Name)
+	assert.Equal(t, []autoscalingv2.MetricSpec{spansMetric}, hpa.Spec.Metrics)
+
+	autoscaling.TargetCPUUtilization = pointer.Int32(75)
+	hpa = HorizontalPodAutoscaler(tempo, DistributorComponentName, autoscaling)
+	assert.Equal(t, []autoscalingv2.MetricSpec{
+		resourceMetric(corev1.ResourceCPU, 75),
+		spansMetric,
+	}, hpa.Spec.Metrics)
+}
+
+func TestScaledObject(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+	}
+	autoscaling := v1alpha1.AutoscalingSpec{
+		MinReplicas:          pointer.Int32(2),
+		MaxReplicas:          10,
+		TargetCPUUtilization: pointer.Int32(80),
+		Keda: &v1alpha1.KedaSpec{
+			Triggers: []v1alpha1.KedaTriggerSpec{
+				{
+					Type: "prometheus",
+					Name: "queue-length",
+					Metadata: map[string]string{
+						"serverAddress": "http://prometheus:9090",
+						"query":         "sum(tempo_distributor_queue_length)",
+						"threshold":     "100",
+					},
+					AuthenticationRef: "prometheus-auth",
+				},
+			},
+			PollingInterval: pointer.Int32(15),
+			CooldownPeriod:  pointer.Int32(120),
+		},
+	}
+
+	obj := Autoscaler(tempo, DistributorComponentName, autoscaling)
+	scaledObject, ok := obj.(*unstructured.Unstructured)
+	require.True(t, ok)
+	assert.Equal(t, ScaledObjectGVK, scaledObject.GroupVersionKind())
+	assert.Equal(t, "tempo-simplest-distributor", scaledObject.GetName())
+	assert.Equal(t, "observability", scaledObject.GetNamespace())
+	assert.Equal(t, map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"name":       "tempo-simplest-distributor",
+		},
+		"minReplicaCount": int64(2),
+		"maxReplicaCount": int64(10),
+		"pollingInterval": int64(15),
+		"cooldownPeriod":  int64(120),
+		"triggers": []interface{}{
+			map[string]interface{}{
+				"type":       "cpu",
+				"metricType": "Utilization",
+				"metadata": map[string]interface{}{
+					"value": "80",
+				},
+			},
+			map[string]interface{}{
+				"type": "prometheus",
+				"name": "queue-length",
+				"metadata": map[string]interface{}{
+					"serverAddress": "http://prometheus:9090",
+					"query":         "sum(tempo_distributor_queue_length)",
+					"threshold":     "100",
+				},
+				"authenticationRef": map[string]interface{}{
+					"name": "prometheus-auth",
+				},
+			},
+		},
+	}, scaledObject.Object["spec"])
+}
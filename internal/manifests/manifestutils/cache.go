@@ -0,0 +1,85 @@
+package manifestutils
+
+import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+const (
+	// CachePasswordEnv is referenced by the redis cache in the Tempo configuration.
+	CachePasswordEnv = "CACHE_PASSWORD"
+
+	cacheCAVolumeName  = "cache-ca-bundle"
+	cacheTLSVolumeName = "cache-tls"
+)
+
+// CacheCABundleDir returns the path where the CA bundle to verify the certificate of the cache is mounted.
+func CacheCABundleDir() string {
+	return path.Join(CABundleDir, "cache")
+}
+
+// CacheTLSDir returns the path where the client certificate for the cache is mounted.
+func CacheTLSDir() string {
+	return path.Join(TLSDir, "cache")
+}
+
+// configureCache exposes the password of the cache to the tempo container and mounts the CA bundle and client certificate.
+func configureCache(tempo v1alpha1.TempoStack, pod *corev1.PodSpec) {
+	cache := tempo.Spec.Cache
+	if cache == nil {
+		return
+	}
+	container := &pod.Containers[0]
+
+	if cache.AuthSecret != "" {
+		container.Args = append(container.Args, "-config.expand-env=true")
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: CachePasswordEnv,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: cache.AuthSecret},
+					Key:                  "password",
+				},
+			},
+		})
+	}
+
+	if cache.TLS == nil {
+		return
+	}
+	if cache.TLS.CA != "" {
+		pod.Volumes = append(pod.Volumes, corev1.Volume{
+			Name: cacheCAVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: cache.TLS.CA,
+					},
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      cacheCAVolumeName,
+			MountPath: CacheCABundleDir(),
+			ReadOnly:  true,
+		})
+	}
+	if cache.TLS.CertName != "" {
+		pod.Volumes = append(pod.Volumes, corev1.Volume{
+			Name: cacheTLSVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: cache.TLS.CertName,
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      cacheTLSVolumeName,
+			MountPath: CacheTLSDir(),
+			ReadOnly:  true,
+		})
+	}
+}
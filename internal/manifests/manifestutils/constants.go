@@ -6,6 +6,10 @@ const (
 	// nolint #nosec
 	// BearerTokenFile declares the path for bearer token file for service monitors.
 	BearerTokenFile string = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	// ServiceAccountServiceCAFile declares the path of the OpenShift service CA bundle injected into every pod.
+	ServiceAccountServiceCAFile string = "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"
+	// OpenShiftMonitoringRemoteWriteURL declares the remote write endpoint of the OpenShift in-cluster monitoring Prometheus.
+	OpenShiftMonitoringRemoteWriteURL string = "https://prometheus-k8s.openshift-monitoring.svc:9091/api/v1/write"
 
 	// ConfigVolumeName declares the name of the volume containing the tempo configuration.
 	ConfigVolumeName = "tempo-conf"
@@ -19,6 +23,12 @@ const (
 	// TmpStoragePath declares the path of temporary storage for tempo.
 	TmpStoragePath = "/var/tempo"
 
+	// CSISecretsVolumeName declares the name of the volume containing the object storage credentials of the Secrets Store CSI driver.
+	CSISecretsVolumeName = "storage-csi-secrets"
+	// nolint #nosec
+	// CSISecretsDirectory declares the mount path of the object storage credentials of the Secrets Store CSI driver.
+	CSISecretsDirectory = "/etc/storage/csi-secrets"
+
 	// HttpPortName declares the name of the tempo http port.
 	HttpPortName = "http"
 	// PortHTTPServer declares the port number of the tempo http port.
@@ -75,6 +85,11 @@ const (
 	// PortMemberlist declares the port number of the tempo memberlist port.
 	PortMemberlist = 7946
 
+	// MemcachedPortName declares the name of the memcached port.
+	MemcachedPortName = "memcached"
+	// PortMemcached declares the port number of the memcached port.
+	PortMemcached = 11211
+
 	// CompactorComponentName declares the internal name of the compactor component.
 	CompactorComponentName = "compactor"
 	// QuerierComponentName declares the internal name of the querier component.
@@ -87,6 +102,12 @@ const (
 	IngesterComponentName = "ingester"
 	// GatewayComponentName declares the internal name of the gateway component.
 	GatewayComponentName = "gateway"
+	// MetricsGeneratorComponentName declares the internal name of the metrics-generator component.
+	MetricsGeneratorComponentName = "metrics-generator"
+	// MemcachedComponentName declares the internal name of the memcached component managed by the operator.
+	MemcachedComponentName = "memcached"
+	// TenantPurgeComponentName declares the internal name of the tenant purge Jobs.
+	TenantPurgeComponentName = "purge"
 	// TenantHeader is the header name that contains tenant name.
 	TenantHeader = "x-scope-orgid"
 )
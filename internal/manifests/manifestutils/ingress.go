@@ -0,0 +1,94 @@
+package manifestutils
+
+import (
+	"fmt"
+	"strings"
+
+	routev1 "github.com/openshift/api/route/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// ConfigureIngressHosts adds a rule for the host and every additional host of the IngressSpec to an Ingress,
+// and the TLS configuration of the hosts with a TLS secret.
+// If neither a host nor a path prefix is specified, all requests are forwarded to the backend.
+func ConfigureIngressHosts(ingress *networkingv1.Ingress, spec v1alpha1.IngressSpec, backend networkingv1.IngressBackend) {
+	var hosts []string
+	if spec.Host != "" {
+		hosts = append(hosts, spec.Host)
+	}
+	if spec.TLSSecretName != "" {
+		tls := networkingv1.IngressTLS{SecretName: spec.TLSSecretName}
+		if spec.Host != "" {
+			tls.Hosts = []string{spec.Host}
+		}
+		ingress.Spec.TLS = append(ingress.Spec.TLS, tls)
+	}
+	for _, additionalHost := range spec.AdditionalHosts {
+		hosts = append(hosts, additionalHost.Host)
+		if additionalHost.TLSSecretName != "" {
+			ingress.Spec.TLS = append(ingress.Spec.TLS, networkingv1.IngressTLS{
+				Hosts:      []string{additionalHost.Host},
+				SecretName: additionalHost.TLSSecretName,
+			})
+		}
+	}
+
+	path := IngressPath(spec)
+	if len(hosts) == 0 {
+		if path == "/" {
+			ingress.Spec.DefaultBackend = &backend
+			return
+		}
+		// A rule without a host matches the requests of all hosts.
+		hosts = []string{""}
+	}
+
+	pathType := networkingv1.PathTypePrefix
+	for _, host := range hosts {
+		ingress.Spec.Rules = append(ingress.Spec.Rules, networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{
+							Path:     path,
+							PathType: &pathType,
+							Backend:  backend,
+						},
+					},
+				},
+			},
+		})
+	}
+}
+
+// IngressPath returns the path prefix of an IngressSpec without a trailing slash, or / if no path prefix is set.
+func IngressPath(spec v1alpha1.IngressSpec) string {
+	path := strings.TrimSuffix(spec.Path, "/")
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// AdditionalRoutes returns a copy of a Route for every additional host of the IngressSpec.
+// The certificate of an additional host is looked up by the name of its TLS secret in hostCertificates.
+func AdditionalRoutes(route *routev1.Route, spec v1alpha1.IngressSpec, hostCertificates map[string]RouteCertificates) []*routev1.Route {
+	routes := make([]*routev1.Route, 0, len(spec.AdditionalHosts))
+	for i, additionalHost := range spec.AdditionalHosts {
+		r := route.DeepCopy()
+		r.Name = naming.DNSName(fmt.Sprintf("%s-%d", route.Name, i+1))
+		r.Spec.Host = additionalHost.Host
+
+		if certs, ok := hostCertificates[additionalHost.TLSSecretName]; ok && additionalHost.TLSSecretName != "" && r.Spec.TLS != nil {
+			r.Spec.TLS.Certificate = certs.Certificate
+			r.Spec.TLS.Key = certs.Key
+			r.Spec.TLS.CACertificate = certs.CACertificate
+		}
+		routes = append(routes, r)
+	}
+	return routes
+}
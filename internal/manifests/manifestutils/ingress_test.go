@@ -0,0 +1,118 @@
+package manifestutils
+
+import (
+	"testing"
+
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestConfigureIngressHosts(t *testing.T) {
+	backend := networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: "tempo-simplest-gateway",
+			Port: networkingv1.ServiceBackendPort{Name: "public"},
+		},
+	}
+	pathType := networkingv1.PathTypePrefix
+	rule := func(host string) networkingv1.IngressRule {
+		return networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{Path: "/", PathType: &pathType, Backend: backend}},
+				},
+			},
+		}
+	}
+
+	t.Run("no host", func(t *testing.T) {
+		ingress := &networkingv1.Ingress{}
+		ConfigureIngressHosts(ingress, v1alpha1.IngressSpec{}, backend)
+		assert.Equal(t, &backend, ingress.Spec.DefaultBackend)
+		assert.Empty(t, ingress.Spec.Rules)
+	})
+
+	t.Run("additional hosts", func(t *testing.T) {
+		ingress := &networkingv1.Ingress{}
+		ConfigureIngressHosts(ingress, v1alpha1.IngressSpec{
+			Host: "tempo.example.com",
+			AdditionalHosts: []v1alpha1.IngressHostSpec{
+				{Host: "traces.example.com", TLSSecretName: "traces-tls"},
+				{Host: "traces.example.org"},
+			},
+		}, backend)
+		assert.Nil(t, ingress.Spec.DefaultBackend)
+		assert.Equal(t, []networkingv1.IngressRule{
+			rule("tempo.example.com"),
+			rule("traces.example.com"),
+			rule("traces.example.org"),
+		}, ingress.Spec.Rules)
+		assert.Equal(t, []networkingv1.IngressTLS{
+			{Hosts: []string{"traces.example.com"}, SecretName: "traces-tls"},
+		}, ingress.Spec.TLS)
+	})
+
+	t.Run("TLS secret and path prefix", func(t *testing.T) {
+		ingress := &networkingv1.Ingress{}
+		ConfigureIngressHosts(ingress, v1alpha1.IngressSpec{
+			Host:          "tempo.example.com",
+			TLSSecretName: "tempo-tls",
+			Path:          "/jaeger/",
+		}, backend)
+		prefixed := rule("tempo.example.com")
+		prefixed.HTTP.Paths[0].Path = "/jaeger"
+		assert.Equal(t, []networkingv1.IngressRule{prefixed}, ingress.Spec.Rules)
+		assert.Equal(t, []networkingv1.IngressTLS{
+			{Hosts: []string{"tempo.example.com"}, SecretName: "tempo-tls"},
+		}, ingress.Spec.TLS)
+	})
+
+	t.Run("path prefix without host", func(t *testing.T) {
+		ingress := &networkingv1.Ingress{}
+		ConfigureIngressHosts(ingress, v1alpha1.IngressSpec{Path: "/jaeger"}, backend)
+		prefixed := rule("")
+		prefixed.HTTP.Paths[0].Path = "/jaeger"
+		assert.Nil(t, ingress.Spec.DefaultBackend)
+		assert.Equal(t, []networkingv1.IngressRule{prefixed}, ingress.Spec.Rules)
+	})
+}
+
+func TestIngressPath(t *testing.T) {
+	assert.Equal(t, "/", IngressPath(v1alpha1.IngressSpec{}))
+	assert.Equal(t, "/", IngressPath(v1alpha1.IngressSpec{Path: "/"}))
+	assert.Equal(t, "/jaeger", IngressPath(v1alpha1.IngressSpec{Path: "/jaeger/"}))
+}
+
+func TestAdditionalRoutes(t *testing.T) {
+	route := &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{Name: "tempo-simplest-gateway"},
+		Spec: routev1.RouteSpec{
+			Host: "tempo.example.com",
+			TLS:  &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge},
+		},
+	}
+
+	routes := AdditionalRoutes(route, v1alpha1.IngressSpec{
+		AdditionalHosts: []v1alpha1.IngressHostSpec{
+			{Host: "traces.example.com", TLSSecretName: "traces-tls"},
+			{Host: "traces.example.org"},
+		},
+	}, map[string]RouteCertificates{
+		"traces-tls": {Certificate: "cert", Key: "key"},
+	})
+
+	require.Len(t, routes, 2)
+	assert.Equal(t, "tempo-simplest-gateway-1", routes[0].Name)
+	assert.Equal(t, "traces.example.com", routes[0].Spec.Host)
+	assert.Equal(t, &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge, Certificate: "cert", Key: "key"}, routes[0].Spec.TLS)
+	assert.Equal(t, "tempo-simplest-gateway-2", routes[1].Name)
+	assert.Equal(t, "traces.example.org", routes[1].Spec.Host)
+	assert.Equal(t, &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge}, routes[1].Spec.TLS)
+	assert.Equal(t, "tempo.example.com", route.Spec.Host)
+}
@@ -0,0 +1,114 @@
+package manifestutils
+
+import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+const (
+	// EtcdUsernameEnv and EtcdPasswordEnv are referenced by the etcd KV store in the Tempo configuration.
+	EtcdUsernameEnv = "ETCD_USERNAME"
+	EtcdPasswordEnv = "ETCD_PASSWORD"
+	// ConsulACLTokenEnv is referenced by the Consul KV store in the Tempo configuration.
+	ConsulACLTokenEnv = "CONSUL_ACL_TOKEN"
+
+	etcdCAVolumeName  = "etcd-ca-bundle"
+	etcdTLSVolumeName = "etcd-tls"
+)
+
+// EtcdCABundleDir returns the path where the CA bundle to verify the certificate of etcd is mounted.
+func EtcdCABundleDir() string {
+	return path.Join(CABundleDir, "etcd")
+}
+
+// EtcdTLSDir returns the path where the client certificate for etcd is mounted.
+func EtcdTLSDir() string {
+	return path.Join(TLSDir, "etcd")
+}
+
+// KVStore returns the type of the key-value store of the hash rings.
+func KVStore(tempo v1alpha1.TempoStack) v1alpha1.KVStoreType {
+	if tempo.Spec.KVStore == nil || tempo.Spec.KVStore.Type == "" {
+		return v1alpha1.KVStoreMemberlist
+	}
+	return tempo.Spec.KVStore.Type
+}
+
+// ConfigureKVStore exposes the credentials of the key-value store to the tempo container
+// and mounts the CA bundle and client certificate of etcd.
+func ConfigureKVStore(tempo v1alpha1.TempoStack, pod *corev1.PodSpec) {
+	container := &pod.Containers[0]
+	fromSecret := func(name string, secret string, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+					Key:                  key,
+				},
+			},
+		}
+	}
+
+	switch KVStore(tempo) {
+	case v1alpha1.KVStoreConsul:
+		consul := tempo.Spec.KVStore.Consul
+		if consul == nil || consul.AuthSecret == "" {
+			return
+		}
+		container.Args = append(container.Args, "-config.expand-env=true")
+		container.Env = append(container.Env, fromSecret(ConsulACLTokenEnv, consul.AuthSecret, "token"))
+
+	case v1alpha1.KVStoreEtcd:
+		etcd := tempo.Spec.KVStore.Etcd
+		if etcd == nil {
+			return
+		}
+		if etcd.AuthSecret != "" {
+			container.Args = append(container.Args, "-config.expand-env=true")
+			container.Env = append(container.Env,
+				fromSecret(EtcdUsernameEnv, etcd.AuthSecret, "username"),
+				fromSecret(EtcdPasswordEnv, etcd.AuthSecret, "password"),
+			)
+		}
+
+		if etcd.TLS == nil {
+			return
+		}
+		if etcd.TLS.CA != "" {
+			pod.Volumes = append(pod.Volumes, corev1.Volume{
+				Name: etcdCAVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: etcd.TLS.CA,
+						},
+					},
+				},
+			})
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      etcdCAVolumeName,
+				MountPath: EtcdCABundleDir(),
+				ReadOnly:  true,
+			})
+		}
+		if etcd.TLS.CertName != "" {
+			pod.Volumes = append(pod.Volumes, corev1.Volume{
+				Name: etcdTLSVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: etcd.TLS.CertName,
+					},
+				},
+			})
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      etcdTLSVolumeName,
+				MountPath: EtcdTLSDir(),
+				ReadOnly:  true,
+			})
+		}
+	}
+}
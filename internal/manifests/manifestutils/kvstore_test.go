@@ -0,0 +1,83 @@
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestConfigureKVStore_Memberlist(t *testing.T) {
+	pod := corev1.PodSpec{Containers: []corev1.Container{{Name: "tempo"}}}
+	ConfigureKVStore(v1alpha1.TempoStack{}, &pod)
+	assert.Equal(t, v1alpha1.KVStoreMemberlist, KVStore(v1alpha1.TempoStack{}))
+	assert.Empty(t, pod.Volumes)
+	assert.Empty(t, pod.Containers[0].Env)
+}
+
+func TestConfigureKVStore_Etcd(t *testing.T) {
+	pod := corev1.PodSpec{Containers: []corev1.Container{{Name: "tempo"}}}
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			KVStore: &v1alpha1.KVStoreSpec{
+				Type: v1alpha1.KVStoreEtcd,
+				Etcd: &v1alpha1.EtcdSpec{
+					Endpoints:  []string{"etcd:2379"},
+					AuthSecret: "etcd-credentials",
+					TLS:        &v1alpha1.EtcdTLSSpec{CA: "etcd-ca", CertName: "etcd-client-cert"},
+				},
+			},
+		},
+	}
+	ConfigureKVStore(tempo, &pod)
+
+	secretEnv := func(name string, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "etcd-credentials"},
+					Key:                  key,
+				},
+			},
+		}
+	}
+	assert.Equal(t, []string{"-config.expand-env=true"}, pod.Containers[0].Args)
+	assert.Equal(t, []corev1.EnvVar{
+		secretEnv("ETCD_USERNAME", "username"),
+		secretEnv("ETCD_PASSWORD", "password"),
+	}, pod.Containers[0].Env)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "etcd-ca-bundle", MountPath: "/var/run/ca/etcd", ReadOnly: true},
+		{Name: "etcd-tls", MountPath: "/var/run/tls/etcd", ReadOnly: true},
+	}, pod.Containers[0].VolumeMounts)
+	assert.Len(t, pod.Volumes, 2)
+}
+
+func TestConfigureKVStore_Consul(t *testing.T) {
+	pod := corev1.PodSpec{Containers: []corev1.Container{{Name: "tempo"}}}
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			KVStore: &v1alpha1.KVStoreSpec{
+				Type:   v1alpha1.KVStoreConsul,
+				Consul: &v1alpha1.ConsulSpec{Host: "consul:8500", AuthSecret: "consul-token"},
+			},
+		},
+	}
+	ConfigureKVStore(tempo, &pod)
+
+	assert.Equal(t, []corev1.EnvVar{
+		{
+			Name: "CONSUL_ACL_TOKEN",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "consul-token"},
+					Key:                  "token",
+				},
+			},
+		},
+	}, pod.Containers[0].Env)
+	assert.Empty(t, pod.Volumes)
+}
@@ -11,6 +11,24 @@ func ComponentLabels(component, instanceName string) labels.Set {
 	})
 }
 
+// IngressLabels returns the labels of an Ingress or Route of a component.
+// The user-defined labels cannot overwrite the labels managed by the operator.
+func IngressLabels(ingressLabels map[string]string, componentLabels labels.Set) labels.Set {
+	return labels.Merge(ingressLabels, componentLabels)
+}
+
+// ServiceLabels returns the labels of the Services of a component.
+// The user-defined service labels cannot overwrite the labels managed by the operator.
+func ServiceLabels(serviceLabels map[string]string, componentLabels labels.Set) labels.Set {
+	return labels.Merge(serviceLabels, componentLabels)
+}
+
+// PodLabels returns the labels of the pods of a component.
+// The user-defined pod labels cannot overwrite the labels managed by the operator, e.g. the selector labels.
+func PodLabels(podLabels map[string]string, componentLabels labels.Set) labels.Set {
+	return labels.Merge(podLabels, componentLabels)
+}
+
 // CommonLabels returns common labels for each object created by the operator.
 func CommonLabels(instanceName string) map[string]string {
 	return map[string]string{
@@ -0,0 +1,24 @@
+package manifestutils
+
+import (
+	"fmt"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+// LogLevel returns the log level of a component.
+// The log level of the component takes precedence over the default log level of the TempoStack.
+func LogLevel(tempo v1alpha1.TempoStack, component v1alpha1.TempoComponentSpec, defaultLevel string) string {
+	if component.LogLevel != "" {
+		return string(component.LogLevel)
+	}
+	if tempo.Spec.Observability.Logging.Level != "" {
+		return string(tempo.Spec.Observability.Logging.Level)
+	}
+	return defaultLevel
+}
+
+// LogLevelArg returns the log level argument of a Tempo component.
+func LogLevelArg(tempo v1alpha1.TempoStack, component v1alpha1.TempoComponentSpec) string {
+	return fmt.Sprintf("-log.level=%s", LogLevel(tempo, component, string(v1alpha1.LogLevelInfo)))
+}
@@ -0,0 +1,48 @@
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestLogLevelArg(t *testing.T) {
+	tests := []struct {
+		name      string
+		global    v1alpha1.LogLevel
+		component v1alpha1.LogLevel
+		expected  string
+	}{
+		{
+			name:     "default",
+			expected: "-log.level=info",
+		},
+		{
+			name:     "global log level",
+			global:   v1alpha1.LogLevelWarn,
+			expected: "-log.level=warn",
+		},
+		{
+			name:      "component log level",
+			global:    v1alpha1.LogLevelWarn,
+			component: v1alpha1.LogLevelDebug,
+			expected:  "-log.level=debug",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					Observability: v1alpha1.ObservabilitySpec{
+						Logging: v1alpha1.LoggingConfigSpec{Level: test.global},
+					},
+				},
+			}
+			component := v1alpha1.TempoComponentSpec{LogLevel: test.component}
+			assert.Equal(t, test.expected, LogLevelArg(tempo, component))
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package manifestutils
+
+import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+const (
+	// PodIPEnv is referenced by the memberlist and ring configuration if the pod IP is advertised.
+	PodIPEnv = "POD_IP"
+
+	memberlistCAVolumeName  = "memberlist-ca-bundle"
+	memberlistTLSVolumeName = "memberlist-tls"
+)
+
+// MemberlistCABundleDir returns the path where the CA bundle to verify the certificate of the members is mounted.
+func MemberlistCABundleDir() string {
+	return path.Join(CABundleDir, "memberlist")
+}
+
+// MemberlistTLSDir returns the path where the certificate of the members is mounted.
+func MemberlistTLSDir() string {
+	return path.Join(TLSDir, "memberlist")
+}
+
+// ConfigureMemberlist exposes the pod IP to the tempo container and mounts the CA bundle and certificate of the memberlist.
+func ConfigureMemberlist(tempo v1alpha1.TempoStack, pod *corev1.PodSpec) {
+	memberlist := tempo.Spec.Memberlist
+	if memberlist == nil {
+		return
+	}
+	container := &pod.Containers[0]
+
+	if memberlist.InstanceAddrType == v1alpha1.MemberlistInstanceAddrPodIP {
+		container.Args = append(container.Args, "-config.expand-env=true")
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: PodIPEnv,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "status.podIP",
+				},
+			},
+		})
+	}
+
+	if memberlist.TLS == nil {
+		return
+	}
+	pod.Volumes = append(pod.Volumes, corev1.Volume{
+		Name: memberlistTLSVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: memberlist.TLS.CertName,
+			},
+		},
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      memberlistTLSVolumeName,
+		MountPath: MemberlistTLSDir(),
+		ReadOnly:  true,
+	})
+	if memberlist.TLS.CA != "" {
+		pod.Volumes = append(pod.Volumes, corev1.Volume{
+			Name: memberlistCAVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: memberlist.TLS.CA,
+					},
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      memberlistCAVolumeName,
+			MountPath: MemberlistCABundleDir(),
+			ReadOnly:  true,
+		})
+	}
+}
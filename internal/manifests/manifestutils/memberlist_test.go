@@ -0,0 +1,56 @@
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestConfigureMemberlist(t *testing.T) {
+	pod := corev1.PodSpec{Containers: []corev1.Container{{Name: "tempo"}}}
+	ConfigureMemberlist(v1alpha1.TempoStack{}, &pod)
+	assert.Empty(t, pod.Volumes)
+	assert.Empty(t, pod.Containers[0].Env)
+
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Memberlist: &v1alpha1.MemberlistSpec{
+				InstanceAddrType: v1alpha1.MemberlistInstanceAddrPodIP,
+				TLS:              &v1alpha1.MemberlistTLSSpec{CertName: "memberlist-cert", CA: "memberlist-ca"},
+			},
+		},
+	}
+	ConfigureMemberlist(tempo, &pod)
+	assert.Equal(t, []string{"-config.expand-env=true"}, pod.Containers[0].Args)
+	assert.Equal(t, []corev1.EnvVar{
+		{
+			Name: "POD_IP",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"},
+			},
+		},
+	}, pod.Containers[0].Env)
+	assert.Equal(t, []corev1.Volume{
+		{
+			Name: "memberlist-tls",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: "memberlist-cert"},
+			},
+		},
+		{
+			Name: "memberlist-ca-bundle",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "memberlist-ca"},
+				},
+			},
+		},
+	}, pod.Volumes)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "memberlist-tls", MountPath: "/var/run/tls/memberlist", ReadOnly: true},
+		{Name: "memberlist-ca-bundle", MountPath: "/var/run/ca/memberlist", ReadOnly: true},
+	}, pod.Containers[0].VolumeMounts)
+}
@@ -0,0 +1,49 @@
+package manifestutils
+
+import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+const (
+	// RuntimeOverridesDir is the directory where the user-managed runtime overrides ConfigMap is mounted.
+	RuntimeOverridesDir = "/runtime-overrides"
+	// RuntimeOverridesKey is the key of the runtime overrides in the user-managed ConfigMap.
+	RuntimeOverridesKey = "overrides.yaml"
+
+	runtimeOverridesVolumeName = "runtime-overrides"
+)
+
+// RuntimeOverridesFile returns the path of the user-managed runtime overrides file.
+func RuntimeOverridesFile() string {
+	return path.Join(RuntimeOverridesDir, RuntimeOverridesKey)
+}
+
+// ConfigureRuntimeOverrides mounts the user-managed runtime overrides ConfigMap into the tempo container.
+// The ConfigMap is mounted as directory (without subPath), therefore the kubelet updates the file in place
+// and Tempo reloads it without restarting the pod.
+func ConfigureRuntimeOverrides(tempo v1alpha1.TempoStack, pod *corev1.PodSpec) {
+	configMap := tempo.Spec.LimitSpec.PerTenantRuntimeOverridesConfigMap
+	if configMap == "" {
+		return
+	}
+
+	pod.Volumes = append(pod.Volumes, corev1.Volume{
+		Name: runtimeOverridesVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: configMap,
+				},
+			},
+		},
+	})
+	pod.Containers[0].VolumeMounts = append(pod.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      runtimeOverridesVolumeName,
+		MountPath: RuntimeOverridesDir,
+		ReadOnly:  true,
+	})
+}
@@ -0,0 +1,42 @@
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestConfigureRuntimeOverrides(t *testing.T) {
+	pod := corev1.PodSpec{Containers: []corev1.Container{{Name: "tempo"}}}
+	ConfigureRuntimeOverrides(v1alpha1.TempoStack{}, &pod)
+	assert.Empty(t, pod.Volumes)
+	assert.Empty(t, pod.Containers[0].VolumeMounts)
+
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			LimitSpec: v1alpha1.LimitSpec{PerTenantRuntimeOverridesConfigMap: "tenant-overrides"},
+		},
+	}
+	ConfigureRuntimeOverrides(tempo, &pod)
+	assert.Equal(t, []corev1.Volume{
+		{
+			Name: "runtime-overrides",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "tenant-overrides"},
+				},
+			},
+		},
+	}, pod.Volumes)
+	assert.Equal(t, []corev1.VolumeMount{
+		{
+			Name:      "runtime-overrides",
+			MountPath: "/runtime-overrides",
+			ReadOnly:  true,
+		},
+	}, pod.Containers[0].VolumeMounts)
+	assert.Equal(t, "/runtime-overrides/overrides.yaml", RuntimeOverridesFile())
+}
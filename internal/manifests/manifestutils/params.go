@@ -15,6 +15,18 @@ type Params struct {
 	TLSProfile          tlsprofile.TLSProfileOptions
 	GatewayTenantSecret []*GatewayTenantOIDCSecret
 	GatewayTenantsData  []*GatewayTenantsData
+	// CertManagerCABundle contains the CA certificate issued by cert-manager, if cert-manager is enabled.
+	CertManagerCABundle string
+	// OAuthProxyCookieSecret contains the existing cookie secret of the Jaeger Query UI OAuth proxy.
+	OAuthProxyCookieSecret string
+	// GatewayRouteCertificates contains the custom certificates of the gateway Route.
+	GatewayRouteCertificates RouteCertificates
+	// JaegerQueryRouteCertificates contains the custom certificates of the Jaeger Query UI Route.
+	JaegerQueryRouteCertificates RouteCertificates
+	// RouteHostCertificates contains the certificates of the additional hosts of the Routes, by the name of their TLS secret.
+	RouteHostCertificates map[string]RouteCertificates
+	// Distribution is the distribution of the operator, e.g. community or openshift.
+	Distribution string
 }
 
 // StorageParams holds storage configuration.
@@ -29,12 +41,18 @@ type AzureStorage struct {
 	Container   string
 	AccountName string
 	AccountKey  string
+	// EndpointSuffix of the blob storage, e.g. for the Azure Government or Azure China clouds.
+	// Defaults to the Azure public cloud if empty.
+	EndpointSuffix string
 }
 
 // GCS for Google Cloud Storage.
 type GCS struct {
 	Bucket  string
 	KeyJson string
+	// Endpoint is an optional custom endpoint URL, e.g. of a GCS emulator.
+	Endpoint string
+	Insecure bool
 }
 
 // S3 holds S3 configuration.
@@ -0,0 +1,48 @@
+package manifestutils
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// MultipleReplicas returns true if a component runs more than one replica,
+// either statically or with an autoscaler.
+func MultipleReplicas(replicas *int32, autoscaling *v1alpha1.AutoscalingSpec) bool {
+	if autoscaling != nil {
+		return autoscaling.MaxReplicas > 1
+	}
+	return pointer.Int32Deref(replicas, 1) > 1
+}
+
+// PodDisruptionBudget creates a PodDisruptionBudget for the pods of the given component.
+// By default, one pod of the component can be unavailable during voluntary disruptions.
+func PodDisruptionBudget(tempo v1alpha1.TempoStack, component string, spec *v1alpha1.PodDisruptionBudgetSpec) *policyv1.PodDisruptionBudget {
+	labels := ComponentLabels(component, tempo.Name)
+	maxUnavailable := intstr.FromInt(1)
+	if spec != nil && spec.MaxUnavailable != nil {
+		maxUnavailable = *spec.MaxUnavailable
+	}
+
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: policyv1.SchemeGroupVersion.String(),
+			Kind:       "PodDisruptionBudget",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(component, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestMultipleReplicas(t *testing.T) {
+	tests := []struct {
+		name        string
+		replicas    *int32
+		autoscaling *v1alpha1.AutoscalingSpec
+		expected    bool
+	}{
+		{
+			name: "default replicas",
+		},
+		{
+			name:     "single replica",
+			replicas: pointer.Int32(1),
+		},
+		{
+			name:     "multiple replicas",
+			replicas: pointer.Int32(3),
+			expected: true,
+		},
+		{
+			name:        "autoscaling",
+			autoscaling: &v1alpha1.AutoscalingSpec{MaxReplicas: 3},
+			expected:    true,
+		},
+		{
+			name:        "autoscaling to a single replica",
+			autoscaling: &v1alpha1.AutoscalingSpec{MaxReplicas: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, MultipleReplicas(test.replicas, test.autoscaling))
+		})
+	}
+}
+
+func TestPodDisruptionBudget(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+	}
+	labels := ComponentLabels(IngesterComponentName, "simplest")
+
+	pdb := PodDisruptionBudget(tempo, IngesterComponentName, nil)
+	maxUnavailable := intstr.FromInt(1)
+	assert.Equal(t, "tempo-simplest-ingester", pdb.Name)
+	assert.Equal(t, "observability", pdb.Namespace)
+	assert.Equal(t, policyv1.PodDisruptionBudgetSpec{
+		MaxUnavailable: &maxUnavailable,
+		Selector:       &metav1.LabelSelector{MatchLabels: labels},
+	}, pdb.Spec)
+
+	percentage := intstr.FromString("25%")
+	pdb = PodDisruptionBudget(tempo, IngesterComponentName, &v1alpha1.PodDisruptionBudgetSpec{MaxUnavailable: &percentage})
+	assert.Equal(t, &percentage, pdb.Spec.MaxUnavailable)
+}
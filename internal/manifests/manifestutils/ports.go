@@ -0,0 +1,25 @@
+package manifestutils
+
+import "github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+
+// ServerPorts returns the ports of the HTTP and gRPC servers of the Tempo components.
+func ServerPorts(tempo v1alpha1.TempoStack) (int32, int32) {
+	httpPort, grpcPort := int32(PortHTTPServer), int32(PortGRPCServer)
+	if ports := tempo.Spec.Ports; ports != nil {
+		if ports.HTTP != 0 {
+			httpPort = ports.HTTP
+		}
+		if ports.GRPC != 0 {
+			grpcPort = ports.GRPC
+		}
+	}
+	return httpPort, grpcPort
+}
+
+// MemberlistPort returns the port of the memberlist of the Tempo components.
+func MemberlistPort(tempo v1alpha1.TempoStack) int32 {
+	if memberlist := tempo.Spec.Memberlist; memberlist != nil && memberlist.Port != 0 {
+		return memberlist.Port
+	}
+	return PortMemberlist
+}
@@ -0,0 +1,32 @@
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestServerPorts(t *testing.T) {
+	httpPort, grpcPort := ServerPorts(v1alpha1.TempoStack{})
+	assert.Equal(t, int32(3200), httpPort)
+	assert.Equal(t, int32(9095), grpcPort)
+
+	httpPort, grpcPort = ServerPorts(v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Ports: &v1alpha1.ServerPortsSpec{GRPC: 9096},
+		},
+	})
+	assert.Equal(t, int32(3200), httpPort)
+	assert.Equal(t, int32(9096), grpcPort)
+}
+
+func TestMemberlistPort(t *testing.T) {
+	assert.Equal(t, int32(7946), MemberlistPort(v1alpha1.TempoStack{}))
+	assert.Equal(t, int32(7947), MemberlistPort(v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Memberlist: &v1alpha1.MemberlistSpec{Port: 7947},
+		},
+	}))
+}
@@ -0,0 +1,115 @@
+package manifestutils
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+// JaegerReceiverProtocol is an enabled protocol of the Jaeger receiver.
+type JaegerReceiverProtocol struct {
+	// Name is the name of the protocol in the Tempo configuration.
+	Name     string
+	PortName string
+	Port     int32
+	Protocol corev1.Protocol
+}
+
+// ReceiverTLSOverride is the TLS override of a single receiver.
+type ReceiverTLSOverride struct {
+	// Receiver is the name of the receiver, it is used in the names of the volumes and in the mount paths.
+	Receiver string
+	Spec     v1alpha1.ReceiverTLSOverrideSpec
+}
+
+// ReceiverTLSOverrides returns the TLS overrides of the receivers of the distributor.
+func ReceiverTLSOverrides(spec v1alpha1.ReceiversTLSSpec) []ReceiverTLSOverride {
+	candidates := []struct {
+		receiver string
+		spec     *v1alpha1.ReceiverTLSOverrideSpec
+	}{
+		{"otlp-grpc", spec.OTLPGRPC},
+		{"otlp-http", spec.OTLPHTTP},
+		{"jaeger", spec.Jaeger},
+		{"zipkin", spec.Zipkin},
+	}
+
+	var overrides []ReceiverTLSOverride
+	for _, candidate := range candidates {
+		if candidate.spec != nil {
+			overrides = append(overrides, ReceiverTLSOverride{Receiver: candidate.receiver, Spec: *candidate.spec})
+		}
+	}
+	return overrides
+}
+
+// OTLPReceiverPorts returns the ports of the gRPC and HTTP protocols of the OTLP receiver of the distributor.
+func OTLPReceiverPorts(tempo v1alpha1.TempoStack) (int32, int32) {
+	grpcPort, httpPort := int32(PortOtlpGrpcServer), int32(PortOtlpHttp)
+	if otlp := tempo.Spec.Template.Distributor.Receivers.OTLP; otlp != nil {
+		if otlp.GRPCPort != 0 {
+			grpcPort = otlp.GRPCPort
+		}
+		if otlp.HTTPPort != 0 {
+			httpPort = otlp.HTTPPort
+		}
+	}
+	return grpcPort, httpPort
+}
+
+// ZipkinReceiver returns if the Zipkin receiver of the distributor is enabled, and its port.
+// The Zipkin receiver is enabled on the default port if it is not configured and the gateway is disabled.
+func ZipkinReceiver(tempo v1alpha1.TempoStack) (bool, int32) {
+	if tempo.Spec.Template.Gateway.Enabled {
+		return false, 0
+	}
+
+	zipkin := tempo.Spec.Template.Distributor.Receivers.Zipkin
+	if zipkin == nil {
+		return true, PortZipkin
+	}
+	if !zipkin.Enabled {
+		return false, 0
+	}
+	if zipkin.Port == 0 {
+		return true, PortZipkin
+	}
+	return true, zipkin.Port
+}
+
+// JaegerReceiverProtocols returns the enabled protocols of the Jaeger receiver of the distributor.
+// A protocol which is not configured is enabled on its default port if the gateway is disabled.
+func JaegerReceiverProtocols(tempo v1alpha1.TempoStack) []JaegerReceiverProtocol {
+	if tempo.Spec.Template.Gateway.Enabled {
+		return nil
+	}
+
+	jaeger := tempo.Spec.Template.Distributor.Receivers.Jaeger
+	if jaeger == nil {
+		jaeger = &v1alpha1.JaegerReceiverSpec{}
+	}
+
+	candidates := []struct {
+		spec     *v1alpha1.ReceiverProtocolSpec
+		protocol JaegerReceiverProtocol
+	}{
+		{jaeger.ThriftHTTP, JaegerReceiverProtocol{"thrift_http", PortJaegerThriftHTTPName, PortJaegerThriftHTTP, corev1.ProtocolTCP}},
+		{jaeger.ThriftCompact, JaegerReceiverProtocol{"thrift_compact", PortJaegerThriftCompactName, PortJaegerThriftCompact, corev1.ProtocolUDP}},
+		{jaeger.ThriftBinary, JaegerReceiverProtocol{"thrift_binary", PortJaegerThriftBinaryName, PortJaegerThriftBinary, corev1.ProtocolUDP}},
+		{jaeger.GRPC, JaegerReceiverProtocol{"grpc", PortJaegerGrpcName, PortJaegerGrpc, corev1.ProtocolTCP}},
+	}
+
+	var protocols []JaegerReceiverProtocol
+	for _, candidate := range candidates {
+		if candidate.spec != nil {
+			if !candidate.spec.Enabled {
+				continue
+			}
+			if candidate.spec.Port != 0 {
+				candidate.protocol.Port = candidate.spec.Port
+			}
+		}
+		protocols = append(protocols, candidate.protocol)
+	}
+	return protocols
+}
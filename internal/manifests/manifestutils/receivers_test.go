@@ -0,0 +1,116 @@
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestZipkinReceiver(t *testing.T) {
+	tempoStack := func(gateway bool, zipkin *v1alpha1.ZipkinReceiverSpec) v1alpha1.TempoStack {
+		return v1alpha1.TempoStack{
+			Spec: v1alpha1.TempoStackSpec{
+				Template: v1alpha1.TempoTemplateSpec{
+					Gateway: v1alpha1.TempoGatewaySpec{Enabled: gateway},
+					Distributor: v1alpha1.TempoDistributorSpec{
+						Receivers: v1alpha1.ReceiversSpec{Zipkin: zipkin},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		tempo           v1alpha1.TempoStack
+		expectedEnabled bool
+		expectedPort    int32
+	}{
+		{
+			name:            "not configured",
+			tempo:           tempoStack(false, nil),
+			expectedEnabled: true,
+			expectedPort:    9411,
+		},
+		{
+			name:  "not configured with gateway",
+			tempo: tempoStack(true, nil),
+		},
+		{
+			name:  "disabled",
+			tempo: tempoStack(false, &v1alpha1.ZipkinReceiverSpec{Enabled: false}),
+		},
+		{
+			name:            "custom port",
+			tempo:           tempoStack(false, &v1alpha1.ZipkinReceiverSpec{Enabled: true, Port: 9412}),
+			expectedEnabled: true,
+			expectedPort:    9412,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			enabled, port := ZipkinReceiver(test.tempo)
+			assert.Equal(t, test.expectedEnabled, enabled)
+			assert.Equal(t, test.expectedPort, port)
+		})
+	}
+}
+
+func TestJaegerReceiverProtocols(t *testing.T) {
+	allProtocols := []JaegerReceiverProtocol{
+		{Name: "thrift_http", PortName: "thrift-http", Port: 14268, Protocol: corev1.ProtocolTCP},
+		{Name: "thrift_compact", PortName: "thrift-compact", Port: 6831, Protocol: corev1.ProtocolUDP},
+		{Name: "thrift_binary", PortName: "thrift-binary", Port: 6832, Protocol: corev1.ProtocolUDP},
+		{Name: "grpc", PortName: "jaeger-grpc", Port: 14250, Protocol: corev1.ProtocolTCP},
+	}
+	tempoStack := func(gateway bool, jaeger *v1alpha1.JaegerReceiverSpec) v1alpha1.TempoStack {
+		return v1alpha1.TempoStack{
+			Spec: v1alpha1.TempoStackSpec{
+				Template: v1alpha1.TempoTemplateSpec{
+					Gateway: v1alpha1.TempoGatewaySpec{Enabled: gateway},
+					Distributor: v1alpha1.TempoDistributorSpec{
+						Receivers: v1alpha1.ReceiversSpec{Jaeger: jaeger},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		tempo    v1alpha1.TempoStack
+		expected []JaegerReceiverProtocol
+	}{
+		{
+			name:     "not configured",
+			tempo:    tempoStack(false, nil),
+			expected: allProtocols,
+		},
+		{
+			name:  "not configured with gateway",
+			tempo: tempoStack(true, nil),
+		},
+		{
+			name: "UDP protocols disabled and custom gRPC port",
+			tempo: tempoStack(false, &v1alpha1.JaegerReceiverSpec{
+				ThriftCompact: &v1alpha1.ReceiverProtocolSpec{Enabled: false},
+				ThriftBinary:  &v1alpha1.ReceiverProtocolSpec{Enabled: false},
+				GRPC:          &v1alpha1.ReceiverProtocolSpec{Enabled: true, Port: 14251},
+			}),
+			expected: []JaegerReceiverProtocol{
+				{Name: "thrift_http", PortName: "thrift-http", Port: 14268, Protocol: corev1.ProtocolTCP},
+				{Name: "grpc", PortName: "jaeger-grpc", Port: 14251, Protocol: corev1.ProtocolTCP},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, JaegerReceiverProtocols(test.tempo))
+		})
+	}
+}
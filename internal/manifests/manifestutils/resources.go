@@ -3,6 +3,7 @@ package manifestutils
 import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
 
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
 )
@@ -22,6 +23,20 @@ var (
 		"querier":        {cpu: 0.1, memory: 0.15},
 		"query-frontend": {cpu: 0.09, memory: 0.05},
 	}
+	resourceProfiles = map[v1alpha1.ResourceProfile]corev1.ResourceList{
+		v1alpha1.ResourceProfileSmall: {
+			corev1.ResourceCPU:    resource.MustParse("2"),
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		},
+		v1alpha1.ResourceProfileMedium: {
+			corev1.ResourceCPU:    resource.MustParse("6"),
+			corev1.ResourceMemory: resource.MustParse("16Gi"),
+		},
+		v1alpha1.ResourceProfileLarge: {
+			corev1.ResourceCPU:    resource.MustParse("12"),
+			corev1.ResourceMemory: resource.MustParse("48Gi"),
+		},
+	}
 	resourcesMapWithGateway = map[string]componentResource{
 		"distributor":    {cpu: 0.26, memory: 0.11},
 		"ingester":       {cpu: 0.36, memory: 0.49},
@@ -33,7 +48,12 @@ var (
 )
 
 // Resources calculates the resource requirements of a specific component.
+// The resources of the component template take precedence over the resources computed
+// from the total resources or the resource profile.
 func Resources(tempo v1alpha1.TempoStack, component string) corev1.ResourceRequirements {
+	if spec := ComponentSpec(tempo, component); spec != nil && spec.Resources != nil {
+		return *spec.Resources.DeepCopy()
+	}
 
 	resourcesMap := resourcesMapNoGateway
 	if tempo.Spec.Template.Gateway.Enabled {
@@ -41,11 +61,12 @@ func Resources(tempo v1alpha1.TempoStack, component string) corev1.ResourceRequi
 	}
 
 	componentResources, ok := resourcesMap[component]
-	if tempo.Spec.Resources.Total == nil || !ok {
+	total := totalResources(tempo)
+	if total == nil || !ok {
 		return corev1.ResourceRequirements{}
 	}
 	resources := corev1.ResourceRequirements{}
-	totalCpu, ok := tempo.Spec.Resources.Total.Limits[corev1.ResourceCPU]
+	totalCpu, ok := total[corev1.ResourceCPU]
 	if ok {
 		totalCpuInt := totalCpu.MilliValue()
 		cpu := float32(totalCpuInt) * componentResources.cpu
@@ -58,7 +79,7 @@ func Resources(tempo v1alpha1.TempoStack, component string) corev1.ResourceRequi
 		}
 	}
 
-	totalMemory, ok := tempo.Spec.Resources.Total.Limits[corev1.ResourceMemory]
+	totalMemory, ok := total[corev1.ResourceMemory]
 	if ok {
 		if resources.Limits == nil {
 			resources.Limits = corev1.ResourceList{}
@@ -73,3 +94,88 @@ func Resources(tempo v1alpha1.TempoStack, component string) corev1.ResourceRequi
 	}
 	return resources
 }
+
+// totalResources returns the total resource limits of the TempoStack, either configured in
+// spec.resources.total or defined by the resource profile.
+func totalResources(tempo v1alpha1.TempoStack) corev1.ResourceList {
+	if tempo.Spec.Resources.Total != nil {
+		return tempo.Spec.Resources.Total.Limits
+	}
+	return resourceProfiles[tempo.Spec.Resources.Profile]
+}
+
+// ComponentPods returns the number of pods and the resources of the Tempo containers of each component.
+// Autoscaled components are accounted with their minimum number of replicas.
+// It is used by the webhook to validate the resources of a TempoStack against the ResourceQuotas
+// and LimitRanges of its namespace.
+func ComponentPods(tempo v1alpha1.TempoStack) []v1alpha1.ComponentPods {
+	tpl := tempo.Spec.Template
+	replicas := func(replicas *int32, autoscaling *v1alpha1.AutoscalingSpec) int32 {
+		if autoscaling != nil {
+			return pointer.Int32Deref(autoscaling.MinReplicas, 1)
+		}
+		return pointer.Int32Deref(replicas, 1)
+	}
+	containers := func(component string, n int) []corev1.ResourceRequirements {
+		resources := make([]corev1.ResourceRequirements, n)
+		for i := range resources {
+			resources[i] = Resources(tempo, component)
+		}
+		return resources
+	}
+
+	ingesterReplicas := pointer.Int32Deref(tpl.Ingester.Replicas, 1)
+	if zoneAware := tpl.Ingester.ZoneAwareReplication; zoneAware != nil {
+		ingesterReplicas *= int32(len(zoneAware.Zones))
+	}
+	queryFrontendContainers := 1
+	if tpl.QueryFrontend.JaegerQuery.Enabled {
+		queryFrontendContainers = 2
+	}
+
+	// The querier and the query-frontend run a single replica unless they are autoscaled.
+	pods := []v1alpha1.ComponentPods{
+		{Component: DistributorComponentName, Replicas: replicas(tpl.Distributor.Replicas, tpl.Distributor.Autoscaling)},
+		{Component: IngesterComponentName, Replicas: ingesterReplicas},
+		{Component: CompactorComponentName, Replicas: pointer.Int32Deref(tpl.Compactor.Replicas, 1)},
+		{Component: QuerierComponentName, Replicas: replicas(nil, tpl.Querier.Autoscaling)},
+		{Component: QueryFrontendComponentName, Replicas: 1, Containers: containers(QueryFrontendComponentName, queryFrontendContainers)},
+	}
+	if tpl.Gateway.Enabled {
+		pods = append(pods, v1alpha1.ComponentPods{Component: GatewayComponentName, Replicas: replicas(tpl.Gateway.Replicas, tpl.Gateway.Autoscaling)})
+	}
+	if tpl.MetricsGenerator.Enabled {
+		pods = append(pods, v1alpha1.ComponentPods{Component: MetricsGeneratorComponentName, Replicas: pointer.Int32Deref(tpl.MetricsGenerator.Replicas, 1)})
+	}
+	for i := range pods {
+		if pods[i].Containers == nil {
+			pods[i].Containers = containers(pods[i].Component, 1)
+		}
+		pods[i].PriorityClassName = ComponentSpec(tempo, pods[i].Component).PriorityClassName
+	}
+	return pods
+}
+
+// ComponentSpec returns the template of a component, or nil for unknown components.
+func ComponentSpec(tempo v1alpha1.TempoStack, component string) *v1alpha1.TempoComponentSpec {
+	switch component {
+	case DistributorComponentName:
+		return &tempo.Spec.Template.Distributor.TempoComponentSpec
+	case IngesterComponentName:
+		return &tempo.Spec.Template.Ingester.TempoComponentSpec
+	case CompactorComponentName:
+		return &tempo.Spec.Template.Compactor.TempoComponentSpec
+	case QuerierComponentName:
+		return &tempo.Spec.Template.Querier.TempoComponentSpec
+	case QueryFrontendComponentName:
+		return &tempo.Spec.Template.QueryFrontend.TempoComponentSpec
+	case GatewayComponentName:
+		return &tempo.Spec.Template.Gateway.TempoComponentSpec
+	case MetricsGeneratorComponentName:
+		return &tempo.Spec.Template.MetricsGenerator.TempoComponentSpec
+	case MemcachedComponentName:
+		return &tempo.Spec.Template.Memcached.TempoComponentSpec
+	default:
+		return nil
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
 
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
 )
@@ -100,6 +101,57 @@ func TestResources(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "resource profile",
+			tempo: v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					Resources: v1alpha1.Resources{
+						Profile: v1alpha1.ResourceProfileSmall,
+					},
+				},
+			},
+			resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    *resource.NewMilliQuantity(540, resource.BinarySI),
+					corev1.ResourceMemory: *resource.NewQuantity(515396064, resource.BinarySI),
+				},
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    *resource.NewMilliQuantity(162, resource.BinarySI),
+					corev1.ResourceMemory: *resource.NewQuantity(154618832, resource.BinarySI),
+				},
+			},
+		},
+		{
+			name: "component resources take precedence",
+			tempo: v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					Template: v1alpha1.TempoTemplateSpec{
+						Distributor: v1alpha1.TempoDistributorSpec{
+							TempoComponentSpec: v1alpha1.TempoComponentSpec{
+								Resources: &corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceCPU: resource.MustParse("500m"),
+									},
+								},
+							},
+						},
+					},
+					Resources: v1alpha1.Resources{
+						Total: &corev1.ResourceRequirements{
+							Limits: map[corev1.ResourceName]resource.Quantity{
+								corev1.ResourceMemory: resource.MustParse("2Gi"),
+								corev1.ResourceCPU:    resource.MustParse("1000m"),
+							},
+						},
+					},
+				},
+			},
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("500m"),
+				},
+			},
+		},
 		{
 			name: "missing cpu resources",
 			tempo: v1alpha1.TempoStack{
@@ -155,3 +207,44 @@ func TestResources(t *testing.T) {
 		})
 	}
 }
+
+func TestComponentPods(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Distributor: v1alpha1.TempoDistributorSpec{
+					Autoscaling: &v1alpha1.AutoscalingSpec{MinReplicas: pointer.Int32(2)},
+				},
+				Ingester: v1alpha1.TempoIngesterSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{Replicas: pointer.Int32(2), PriorityClassName: "high"},
+				},
+				QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+					JaegerQuery: v1alpha1.JaegerQuerySpec{Enabled: true},
+				},
+				Gateway: v1alpha1.TempoGatewaySpec{Enabled: true},
+			},
+		},
+	}
+
+	pods := ComponentPods(tempo)
+	replicas := map[string]int32{}
+	containers := map[string]int{}
+	priorityClasses := map[string]string{}
+	for _, pod := range pods {
+		replicas[pod.Component] = pod.Replicas
+		containers[pod.Component] = len(pod.Containers)
+		priorityClasses[pod.Component] = pod.PriorityClassName
+	}
+	assert.Equal(t, map[string]int32{
+		DistributorComponentName:   2,
+		IngesterComponentName:      2,
+		CompactorComponentName:     1,
+		QuerierComponentName:       1,
+		QueryFrontendComponentName: 1,
+		GatewayComponentName:       1,
+	}, replicas)
+	assert.Equal(t, 2, containers[QueryFrontendComponentName])
+	assert.Equal(t, 1, containers[IngesterComponentName])
+	assert.Equal(t, "high", priorityClasses[IngesterComponentName])
+	assert.Empty(t, priorityClasses[DistributorComponentName])
+}
@@ -0,0 +1,45 @@
+package manifestutils
+
+import (
+	"fmt"
+
+	routev1 "github.com/openshift/api/route/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+// RouteCertificates holds the certificates of a Route, read from the Secret and ConfigMap
+// referenced in the RouteSpec.
+type RouteCertificates struct {
+	Certificate              string
+	Key                      string
+	CACertificate            string
+	DestinationCACertificate string
+}
+
+// RouteTLSConfig returns the TLS configuration of a Route.
+func RouteTLSConfig(spec v1alpha1.RouteSpec, certs RouteCertificates) (*routev1.TLSConfig, error) {
+	var tlsCfg *routev1.TLSConfig
+	switch spec.Termination {
+	case v1alpha1.TLSRouteTerminationTypeInsecure:
+		// NOTE: insecure, no tls cfg.
+		return nil, nil
+	case v1alpha1.TLSRouteTerminationTypeEdge:
+		tlsCfg = &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge}
+	case v1alpha1.TLSRouteTerminationTypePassthrough:
+		// NOTE: the router does not terminate TLS, therefore no certificates can be configured.
+		return &routev1.TLSConfig{Termination: routev1.TLSTerminationPassthrough}, nil
+	case v1alpha1.TLSRouteTerminationTypeReencrypt:
+		tlsCfg = &routev1.TLSConfig{
+			Termination:              routev1.TLSTerminationReencrypt,
+			DestinationCACertificate: certs.DestinationCACertificate,
+		}
+	default: // NOTE: if unsupported, end here.
+		return nil, fmt.Errorf("unsupported tls termination specified for route")
+	}
+
+	tlsCfg.Certificate = certs.Certificate
+	tlsCfg.Key = certs.Key
+	tlsCfg.CACertificate = certs.CACertificate
+	return tlsCfg, nil
+}
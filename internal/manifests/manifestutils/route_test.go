@@ -0,0 +1,68 @@
+package manifestutils
+
+import (
+	"testing"
+
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestRouteTLSConfig(t *testing.T) {
+	certs := RouteCertificates{
+		Certificate:              "cert",
+		Key:                      "key",
+		CACertificate:            "ca",
+		DestinationCACertificate: "destination-ca",
+	}
+
+	tests := []struct {
+		name        string
+		termination v1alpha1.TLSRouteTerminationType
+		expected    *routev1.TLSConfig
+	}{
+		{
+			name:        "insecure",
+			termination: v1alpha1.TLSRouteTerminationTypeInsecure,
+		},
+		{
+			name:        "edge",
+			termination: v1alpha1.TLSRouteTerminationTypeEdge,
+			expected: &routev1.TLSConfig{
+				Termination:   routev1.TLSTerminationEdge,
+				Certificate:   "cert",
+				Key:           "key",
+				CACertificate: "ca",
+			},
+		},
+		{
+			name:        "passthrough",
+			termination: v1alpha1.TLSRouteTerminationTypePassthrough,
+			expected:    &routev1.TLSConfig{Termination: routev1.TLSTerminationPassthrough},
+		},
+		{
+			name:        "reencrypt",
+			termination: v1alpha1.TLSRouteTerminationTypeReencrypt,
+			expected: &routev1.TLSConfig{
+				Termination:              routev1.TLSTerminationReencrypt,
+				Certificate:              "cert",
+				Key:                      "key",
+				CACertificate:            "ca",
+				DestinationCACertificate: "destination-ca",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tlsCfg, err := RouteTLSConfig(v1alpha1.RouteSpec{Termination: test.termination}, certs)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, tlsCfg)
+		})
+	}
+
+	_, err := RouteTLSConfig(v1alpha1.RouteSpec{Termination: "invalid"}, certs)
+	assert.Error(t, err)
+}
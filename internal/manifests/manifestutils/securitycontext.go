@@ -5,6 +5,12 @@ import (
 	"k8s.io/utils/pointer"
 )
 
+// OpenShiftDistribution is the name of the OpenShift distribution of the operator.
+const OpenShiftDistribution = "openshift"
+
+// tempoUserID is the user and group ID of the pods outside of OpenShift.
+const tempoUserID = 10001
+
 // TempoContainerSecurityContext returns the default container security context.
 func TempoContainerSecurityContext() *corev1.SecurityContext {
 	return &corev1.SecurityContext{
@@ -15,3 +21,21 @@ func TempoContainerSecurityContext() *corev1.SecurityContext {
 		ReadOnlyRootFilesystem: pointer.Bool(true),
 	}
 }
+
+// TempoPodSecurityContext returns the default pod security context,
+// which complies with the restricted Pod Security Standard together with the default container security context.
+// On OpenShift, the user and group IDs are assigned by the restricted security context constraint.
+func TempoPodSecurityContext(distribution string) *corev1.PodSecurityContext {
+	securityContext := &corev1.PodSecurityContext{
+		RunAsNonRoot: pointer.Bool(true),
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+	if distribution != OpenShiftDistribution {
+		securityContext.RunAsUser = pointer.Int64(tempoUserID)
+		securityContext.RunAsGroup = pointer.Int64(tempoUserID)
+		securityContext.FSGroup = pointer.Int64(tempoUserID)
+	}
+	return securityContext
+}
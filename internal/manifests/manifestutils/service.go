@@ -1,7 +1,9 @@
 package manifestutils
 
 import (
+	"fmt"
 	"path"
+	"strings"
 
 	"github.com/ViaQ/logerr/v2/kverrors"
 	"github.com/imdario/mergo"
@@ -15,6 +17,56 @@ func TempoServerTLSDir() string {
 	return path.Join(TLSDir, "server")
 }
 
+// ReceiverTLSDir returns the path where the serving certificate of the distributor receivers is mounted.
+func ReceiverTLSDir() string {
+	return path.Join(TLSDir, "receiver")
+}
+
+// ReceiverCABundleDir returns the path where the CA bundle to verify client certificates of the receivers is mounted.
+func ReceiverCABundleDir() string {
+	return path.Join(CABundleDir, "receiver")
+}
+
+// ReceiverOverrideTLSDir returns the path where the serving certificate of a single receiver is mounted.
+func ReceiverOverrideTLSDir(receiver string) string {
+	return path.Join(TLSDir, "receiver-"+receiver)
+}
+
+// ReceiverOverrideCABundleDir returns the path where the CA bundle to verify client certificates of a single receiver is mounted.
+func ReceiverOverrideCABundleDir(receiver string) string {
+	return path.Join(CABundleDir, "receiver-"+receiver)
+}
+
+// KafkaCABundleDir returns the path where the CA bundle to verify the certificates of the Kafka brokers is mounted.
+func KafkaCABundleDir() string {
+	return path.Join(CABundleDir, "kafka")
+}
+
+// RemoteWriteCABundleDir returns the path where the CA bundle to verify the certificate of a remote write endpoint is mounted.
+func RemoteWriteCABundleDir(index int) string {
+	return path.Join(CABundleDir, fmt.Sprintf("remote-write-%d", index))
+}
+
+// RemoteWriteTLSDir returns the path where the client certificate of a remote write endpoint is mounted.
+func RemoteWriteTLSDir(index int) string {
+	return path.Join(TLSDir, fmt.Sprintf("remote-write-%d", index))
+}
+
+// PrometheusCABundleDir returns the path where the CA bundle to verify the certificate of the Prometheus queried by the Jaeger UI is mounted.
+func PrometheusCABundleDir() string {
+	return path.Join(CABundleDir, "prometheus")
+}
+
+// PrometheusTLSDir returns the path where the client certificate for the Prometheus queried by the Jaeger UI is mounted.
+func PrometheusTLSDir() string {
+	return path.Join(TLSDir, "prometheus")
+}
+
+// RemoteWriteCredentialEnv returns the name of the environment variable holding a credential of a remote write endpoint.
+func RemoteWriteCredentialEnv(index int, key string) string {
+	return fmt.Sprintf("REMOTE_WRITE_%d_%s", index, strings.ToUpper(key))
+}
+
 // ConfigureServiceCA modify the PodSpec adding the volumes and volumeMounts to the specified containers.
 func ConfigureServiceCA(podSpec *corev1.PodSpec, caBundleName string, containers ...int) error {
 	secretVolumeSpec := corev1.PodSpec{
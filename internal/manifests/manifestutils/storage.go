@@ -132,8 +132,72 @@ func configureS3Storage(tempo *v1alpha1.TempoStack, pod *corev1.PodSpec) error {
 	return nil
 }
 
-// ConfigureStorage configures storage.
+// configureSecretProviderClass mounts the object storage credentials provided by the Secrets Store CSI driver.
+func configureSecretProviderClass(tempo *v1alpha1.TempoStack, pod *corev1.PodSpec) error {
+	var envVars []corev1.EnvVar
+	switch tempo.Spec.Storage.Secret.Type {
+	case v1alpha1.ObjectStorageSecretGCS:
+		envVars = []corev1.EnvVar{
+			{
+				Name:  "GOOGLE_APPLICATION_CREDENTIALS",
+				Value: path.Join(CSISecretsDirectory, "key.json"),
+			},
+		}
+	case v1alpha1.ObjectStorageSecretS3:
+		envVars = []corev1.EnvVar{
+			{
+				Name:  "AWS_SHARED_CREDENTIALS_FILE",
+				Value: path.Join(CSISecretsDirectory, "credentials"),
+			},
+		}
+	default:
+		return kverrors.New("secret provider classes are not supported by the storage type", "type", tempo.Spec.Storage.Secret.Type)
+	}
+
+	readOnly := true
+	var volumes []corev1.Volume = []corev1.Volume{
+		{
+			Name: CSISecretsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				CSI: &corev1.CSIVolumeSource{
+					Driver:   "secrets-store.csi.k8s.io",
+					ReadOnly: &readOnly,
+					VolumeAttributes: map[string]string{
+						"secretProviderClass": tempo.Spec.Storage.SecretProviderClass,
+					},
+				},
+			},
+		},
+	}
+
+	var volumeMounts []corev1.VolumeMount = []corev1.VolumeMount{
+		{
+			Name:      CSISecretsVolumeName,
+			ReadOnly:  true,
+			MountPath: CSISecretsDirectory,
+		},
+	}
+
+	ingesterContainer := pod.Containers[0].DeepCopy()
+	ingesterContainer.Env = append(ingesterContainer.Env, envVars...)
+	ingesterContainer.VolumeMounts = append(ingesterContainer.VolumeMounts, volumeMounts...)
+
+	pod.Volumes = append(pod.Volumes, volumes...)
+
+	if err := mergo.Merge(&pod.Containers[0], ingesterContainer, mergo.WithOverride); err != nil {
+		return kverrors.Wrap(err, "failed to merge ingester container spec")
+	}
+	return nil
+}
+
+// ConfigureStorage configures storage and the cache of the storage.
 func ConfigureStorage(tempo v1alpha1.TempoStack, pod *corev1.PodSpec) error {
+	configureCache(tempo, pod)
+
+	if tempo.Spec.Storage.SecretProviderClass != "" {
+		return configureSecretProviderClass(&tempo, pod)
+	}
+
 	if tempo.Spec.Storage.Secret.Name != "" {
 		var configure func(*v1alpha1.TempoStack, *corev1.PodSpec) error
 		switch tempo.Spec.Storage.Secret.Type {
@@ -149,3 +213,41 @@ func ConfigureStorage(tempo v1alpha1.TempoStack, pod *corev1.PodSpec) error {
 	}
 	return nil
 }
+
+// ConfigureStorageCredentials configures the object storage credentials with environment variables and volumes only,
+// for containers which do not accept the storage flags of Tempo, e.g. tempo-cli.
+func ConfigureStorageCredentials(tempo v1alpha1.TempoStack, pod *corev1.PodSpec) error {
+	if tempo.Spec.Storage.SecretProviderClass != "" {
+		return configureSecretProviderClass(&tempo, pod)
+	}
+
+	fromSecret := func(name string, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					Key: key,
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: tempo.Spec.Storage.Secret.Name,
+					},
+				},
+			},
+		}
+	}
+
+	switch tempo.Spec.Storage.Secret.Type {
+	case v1alpha1.ObjectStorageSecretAzure:
+		pod.Containers[0].Env = append(pod.Containers[0].Env,
+			fromSecret("AZURE_STORAGE_ACCOUNT", "account_name"),
+			fromSecret("AZURE_STORAGE_KEY", "account_key"),
+		)
+	case v1alpha1.ObjectStorageSecretGCS:
+		return configureGCS(&tempo, pod)
+	case v1alpha1.ObjectStorageSecretS3:
+		pod.Containers[0].Env = append(pod.Containers[0].Env,
+			fromSecret("AWS_ACCESS_KEY_ID", "access_key_id"),
+			fromSecret("AWS_SECRET_ACCESS_KEY", "access_key_secret"),
+		)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
@@ -186,3 +187,137 @@ func TestConfigureStorage(t *testing.T) {
 	}
 
 }
+
+func TestConfigureStorageSecretProviderClass(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Name: "test",
+					Type: v1alpha1.ObjectStorageSecretS3,
+				},
+				SecretProviderClass: "tempo-storage",
+			},
+		},
+	}
+	pod := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "ingester",
+			},
+		},
+	}
+
+	assert.NoError(t, ConfigureStorage(tempo, &pod))
+	assert.Equal(t, []corev1.EnvVar{
+		{
+			Name:  "AWS_SHARED_CREDENTIALS_FILE",
+			Value: "/etc/storage/csi-secrets/credentials",
+		},
+	}, pod.Containers[0].Env)
+	assert.Empty(t, pod.Containers[0].Args)
+	assert.Equal(t, []corev1.VolumeMount{
+		{
+			Name:      CSISecretsVolumeName,
+			ReadOnly:  true,
+			MountPath: CSISecretsDirectory,
+		},
+	}, pod.Containers[0].VolumeMounts)
+	require.Len(t, pod.Volumes, 1)
+	assert.Equal(t, "secrets-store.csi.k8s.io", pod.Volumes[0].CSI.Driver)
+	assert.Equal(t, map[string]string{"secretProviderClass": "tempo-storage"}, pod.Volumes[0].CSI.VolumeAttributes)
+
+	tempo.Spec.Storage.Secret.Type = v1alpha1.ObjectStorageSecretAzure
+	assert.Error(t, ConfigureStorage(tempo, &pod))
+}
+
+func TestConfigureStorageCache(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{
+					Name: "test",
+					Type: v1alpha1.ObjectStorageSecretS3,
+				},
+			},
+			Cache: &v1alpha1.CacheSpec{
+				Backend:    v1alpha1.CacheBackendRedis,
+				Endpoints:  []string{"redis.cache.svc:6379"},
+				AuthSecret: "redis-credentials",
+				TLS:        &v1alpha1.CacheTLSSpec{CA: "cache-ca", CertName: "cache-client-cert"},
+			},
+		},
+	}
+	pod := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "querier",
+			},
+		},
+	}
+
+	require.NoError(t, ConfigureStorage(tempo, &pod))
+	container := pod.Containers[0]
+	assert.Contains(t, container.Args, "-config.expand-env=true")
+	assert.Contains(t, container.Env, corev1.EnvVar{
+		Name: "CACHE_PASSWORD",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "redis-credentials"},
+				Key:                  "password",
+			},
+		},
+	})
+	assert.NoError(t, findEnvVar("S3_SECRET_KEY", &container.Env))
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name:      "cache-ca-bundle",
+		MountPath: "/var/run/ca/cache",
+		ReadOnly:  true,
+	})
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name:      "cache-tls",
+		MountPath: "/var/run/tls/cache",
+		ReadOnly:  true,
+	})
+	assert.Contains(t, pod.Volumes, corev1.Volume{
+		Name: "cache-tls",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: "cache-client-cert"},
+		},
+	})
+}
+
+func TestConfigureStorageCredentials(t *testing.T) {
+	tests := []struct {
+		storageType  v1alpha1.ObjectStorageSecretType
+		expectedEnv  []string
+		volumeMounts int
+	}{
+		{storageType: v1alpha1.ObjectStorageSecretAzure, expectedEnv: []string{"AZURE_STORAGE_ACCOUNT", "AZURE_STORAGE_KEY"}},
+		{storageType: v1alpha1.ObjectStorageSecretGCS, expectedEnv: []string{"GOOGLE_APPLICATION_CREDENTIALS"}, volumeMounts: 1},
+		{storageType: v1alpha1.ObjectStorageSecretS3, expectedEnv: []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY"}},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.storageType), func(t *testing.T) {
+			tempo := v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					Storage: v1alpha1.ObjectStorageSpec{
+						Secret: v1alpha1.ObjectStorageSecretSpec{Name: "test", Type: test.storageType},
+					},
+				},
+			}
+			pod := corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "tempo-cli"}},
+			}
+
+			require.NoError(t, ConfigureStorageCredentials(tempo, &pod))
+			assert.Empty(t, pod.Containers[0].Args)
+			assert.Len(t, pod.Containers[0].Env, len(test.expectedEnv))
+			for _, name := range test.expectedEnv {
+				assert.NoError(t, findEnvVar(name, &pod.Containers[0].Env))
+			}
+			assert.Len(t, pod.Containers[0].VolumeMounts, test.volumeMounts)
+		})
+	}
+}
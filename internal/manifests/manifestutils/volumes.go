@@ -0,0 +1,53 @@
+package manifestutils
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+// PersistentVolumeClaimSpec returns the PVC spec of a component.
+// Fields which are not set in the volume claim template of the component
+// default to the storage class and storage size of the TempoStack.
+func PersistentVolumeClaimSpec(tempo v1alpha1.TempoStack, tpl *v1alpha1.VolumeClaimTemplateSpec) corev1.PersistentVolumeClaimSpec {
+	filesystem := corev1.PersistentVolumeFilesystem
+	spec := corev1.PersistentVolumeClaimSpec{
+		AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceStorage: tempo.Spec.StorageSize,
+			},
+		},
+		StorageClassName: tempo.Spec.StorageClassName,
+		VolumeMode:       &filesystem,
+	}
+
+	if tpl == nil {
+		return spec
+	}
+	if len(tpl.AccessModes) > 0 {
+		spec.AccessModes = tpl.AccessModes
+	}
+	if tpl.Size != nil {
+		spec.Resources.Requests[corev1.ResourceStorage] = *tpl.Size
+	}
+	if tpl.StorageClassName != nil {
+		spec.StorageClassName = tpl.StorageClassName
+	}
+	return spec
+}
+
+// PersistentVolumeClaim returns a PVC with the given name for the volume claim template of a component.
+func PersistentVolumeClaim(tempo v1alpha1.TempoStack, name string, tpl *v1alpha1.VolumeClaimTemplateSpec) corev1.PersistentVolumeClaim {
+	pvc := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: PersistentVolumeClaimSpec(tempo, tpl),
+	}
+	if tpl != nil {
+		pvc.Annotations = tpl.Annotations
+	}
+	return pvc
+}
@@ -0,0 +1,141 @@
+package memcached
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const (
+	defaultMemoryLimitMB  = 1024
+	defaultMaxConnections = 1024
+
+	// memoryOverheadMB is the memory requested in addition to the item memory for connections and internal data structures.
+	memoryOverheadMB = 64
+)
+
+// BuildMemcached creates the objects of the memcached cache managed by the operator.
+func BuildMemcached(params manifestutils.Params) []client.Object {
+	return []client.Object{statefulSet(params.Tempo), service(params.Tempo)}
+}
+
+// memoryLimitMB returns the memory in megabytes used for the items of each memcached instance.
+func memoryLimitMB(spec v1alpha1.TempoMemcachedSpec) int {
+	if spec.MemoryLimitMB == 0 {
+		return defaultMemoryLimitMB
+	}
+	return spec.MemoryLimitMB
+}
+
+func maxConnections(spec v1alpha1.TempoMemcachedSpec) int {
+	if spec.MaxConnections == 0 {
+		return defaultMaxConnections
+	}
+	return spec.MaxConnections
+}
+
+func statefulSet(tempo v1alpha1.TempoStack) *v1.StatefulSet {
+	labels := manifestutils.ComponentLabels(manifestutils.MemcachedComponentName, tempo.Name)
+	cfg := tempo.Spec.Template.Memcached
+	memory := resource.MustParse(fmt.Sprintf("%dMi", memoryLimitMB(cfg)+memoryOverheadMB))
+
+	return &v1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(manifestutils.MemcachedComponentName, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: v1.StatefulSetSpec{
+			Replicas: cfg.Replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			ServiceName:         naming.Name(manifestutils.MemcachedComponentName, tempo.Name),
+			PodManagementPolicy: v1.ParallelPodManagement,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      manifestutils.PodLabels(cfg.PodLabels, labels),
+					Annotations: manifestutils.PodAnnotations(cfg.PodAnnotations, nil),
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName:        tempo.Spec.ServiceAccount,
+					NodeSelector:              cfg.NodeSelector,
+					Tolerations:               cfg.Tolerations,
+					TopologySpreadConstraints: manifestutils.TopologySpreadConstraints(cfg.TopologySpreadConstraints, labels),
+					PriorityClassName:         cfg.PriorityClassName,
+					Affinity:                  manifestutils.Affinity(cfg.Affinity, labels),
+					Containers: []corev1.Container{
+						{
+							Name:  "memcached",
+							Image: tempo.Spec.Images.Memcached,
+							Args: []string{
+								"-p", fmt.Sprintf("%d", manifestutils.PortMemcached),
+								"-m", fmt.Sprintf("%d", memoryLimitMB(cfg)),
+								"-c", fmt.Sprintf("%d", maxConnections(cfg)),
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          manifestutils.MemcachedPortName,
+									ContainerPort: manifestutils.PortMemcached,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{
+										Port: intstr.FromString(manifestutils.MemcachedPortName),
+									},
+								},
+								InitialDelaySeconds: 5,
+								TimeoutSeconds:      1,
+							},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceMemory: memory,
+								},
+								Limits: corev1.ResourceList{
+									corev1.ResourceMemory: memory,
+								},
+							},
+							SecurityContext: manifestutils.TempoContainerSecurityContext(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// service returns a headless service, the memcached clients of Tempo discover the instances with DNS SRV lookups.
+func service(tempo v1alpha1.TempoStack) *corev1.Service {
+	labels := manifestutils.ComponentLabels(manifestutils.MemcachedComponentName, tempo.Name)
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.Name(manifestutils.MemcachedComponentName, tempo.Name),
+			Namespace:   tempo.Namespace,
+			Labels:      manifestutils.ServiceLabels(tempo.Spec.Template.Memcached.ServiceLabels, labels),
+			Annotations: manifestutils.ServiceAnnotations(tempo.Spec.Template.Memcached.ServiceAnnotations, nil),
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       manifestutils.MemcachedPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       manifestutils.PortMemcached,
+					TargetPort: intstr.FromString(manifestutils.MemcachedPortName),
+				},
+			},
+			Selector: labels,
+		},
+	}
+}
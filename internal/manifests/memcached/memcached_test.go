@@ -0,0 +1,94 @@
+package memcached
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildMemcached(t *testing.T) {
+	objects := BuildMemcached(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Images: configv1alpha1.ImagesSpec{
+				Memcached: "docker.io/library/memcached:1.6.21",
+			},
+			ServiceAccount: "tempo-test-serviceaccount",
+			Template: v1alpha1.TempoTemplateSpec{
+				Memcached: v1alpha1.TempoMemcachedSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						Replicas:     pointer.Int32(3),
+						NodeSelector: map[string]string{"a": "b"},
+					},
+					Enabled:        true,
+					MemoryLimitMB:  2048,
+					MaxConnections: 4096,
+				},
+			},
+		},
+	}})
+	require.Len(t, objects, 2)
+
+	labels := manifestutils.ComponentLabels(manifestutils.MemcachedComponentName, "test")
+	assert.Equal(t, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-memcached",
+			Namespace: "project1",
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "memcached",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       11211,
+					TargetPort: intstr.FromString("memcached"),
+				},
+			},
+			Selector: labels,
+		},
+	}, objects[1])
+
+	ss := objects[0].(*v1.StatefulSet)
+	assert.Equal(t, "tempo-test-memcached", ss.Name)
+	assert.Equal(t, "tempo-test-memcached", ss.Spec.ServiceName)
+	assert.Equal(t, pointer.Int32(3), ss.Spec.Replicas)
+	assert.Equal(t, map[string]string{"a": "b"}, ss.Spec.Template.Spec.NodeSelector)
+
+	container := ss.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "docker.io/library/memcached:1.6.21", container.Image)
+	assert.Equal(t, []string{"-p", "11211", "-m", "2048", "-c", "4096"}, container.Args)
+	assert.Equal(t, resource.MustParse("2112Mi"), container.Resources.Limits[corev1.ResourceMemory])
+}
+
+func TestBuildMemcached_Defaults(t *testing.T) {
+	objects := BuildMemcached(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Memcached: v1alpha1.TempoMemcachedSpec{Enabled: true},
+			},
+		},
+	}})
+
+	container := objects[0].(*v1.StatefulSet).Spec.Template.Spec.Containers[0]
+	assert.Equal(t, []string{"-p", "11211", "-m", "1024", "-c", "1024"}, container.Args)
+	assert.Equal(t, resource.MustParse("1088Mi"), container.Resources.Requests[corev1.ResourceMemory])
+}
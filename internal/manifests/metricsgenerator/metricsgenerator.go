@@ -0,0 +1,255 @@
+package metricsgenerator
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/memberlist"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+	"github.com/grafana/tempo-operator/internal/manifests/spiffe"
+)
+
+// BuildMetricsGenerator creates metrics-generator objects.
+func BuildMetricsGenerator(params manifestutils.Params) ([]client.Object, error) {
+	d := deployment(params)
+	var err error
+	d.Spec.Template, err = manifestutils.PatchTracingJaegerEnv(params.Tempo, d.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+	gates := params.Gates
+	tempo := params.Tempo
+	configureRemoteWrite(tempo.Spec.Template.MetricsGenerator.RemoteWrite, &d.Spec.Template.Spec)
+
+	manifestutils.ConfigureRuntimeOverrides(tempo, &d.Spec.Template.Spec)
+	manifestutils.ConfigureMemberlist(tempo, &d.Spec.Template.Spec)
+	manifestutils.ConfigureKVStore(tempo, &d.Spec.Template.Spec)
+
+	if (gates.HTTPEncryption || gates.GRPCEncryption) && tempo.Spec.SPIFFE != nil {
+		if err := spiffe.ConfigurePodSpec(tempo, &d.Spec.Template.Spec); err != nil {
+			return nil, err
+		}
+	} else if gates.HTTPEncryption || gates.GRPCEncryption {
+		caBundleName := naming.SigningCABundleName(tempo.Name)
+		if err := manifestutils.ConfigureServiceCA(&d.Spec.Template.Spec, caBundleName); err != nil {
+			return nil, err
+		}
+
+		if err := manifestutils.ConfigureServicePKI(tempo.Name, manifestutils.MetricsGeneratorComponentName, &d.Spec.Template.Spec); err != nil {
+			return nil, err
+		}
+	}
+
+	return []client.Object{d, service(tempo)}, nil
+}
+
+// configureRemoteWrite exposes the credentials of the remote write endpoints to the tempo container
+// and mounts their CA bundles and client certificates.
+func configureRemoteWrite(remoteWrites []v1alpha1.RemoteWriteSpec, podSpec *corev1.PodSpec) {
+	container := &podSpec.Containers[0]
+	expandEnv := false
+
+	for i, remoteWrite := range remoteWrites {
+		if remoteWrite.OpenShiftMonitoring {
+			continue
+		}
+
+		if auth := remoteWrite.Auth; auth != nil {
+			keys := []string{"token"}
+			if auth.Type == v1alpha1.RemoteWriteAuthTypeBasic {
+				keys = []string{"username", "password"}
+			}
+			for _, key := range keys {
+				container.Env = append(container.Env, corev1.EnvVar{
+					Name: manifestutils.RemoteWriteCredentialEnv(i, key),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: auth.Secret},
+							Key:                  key,
+						},
+					},
+				})
+			}
+			expandEnv = true
+		}
+
+		if remoteWrite.TLS == nil {
+			continue
+		}
+		if remoteWrite.TLS.CA != "" {
+			volumeName := fmt.Sprintf("remote-write-%d-ca", i)
+			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: remoteWrite.TLS.CA,
+						},
+					},
+				},
+			})
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: manifestutils.RemoteWriteCABundleDir(i),
+				ReadOnly:  true,
+			})
+		}
+		if remoteWrite.TLS.CertName != "" {
+			volumeName := fmt.Sprintf("remote-write-%d-tls", i)
+			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: remoteWrite.TLS.CertName,
+					},
+				},
+			})
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: manifestutils.RemoteWriteTLSDir(i),
+				ReadOnly:  true,
+			})
+		}
+	}
+
+	if expandEnv {
+		container.Args = append(container.Args, "-config.expand-env=true")
+	}
+}
+
+func deployment(params manifestutils.Params) *v1.Deployment {
+	tempo := params.Tempo
+	httpPort, grpcPort := manifestutils.ServerPorts(tempo)
+	labels := manifestutils.ComponentLabels(manifestutils.MetricsGeneratorComponentName, tempo.Name)
+	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum, params.Tempo.Annotations[manifestutils.CertRotationRequiredAtAnnotation])
+	cfg := tempo.Spec.Template.MetricsGenerator
+
+	return &v1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(manifestutils.MetricsGeneratorComponentName, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: v1.DeploymentSpec{
+			Replicas: cfg.Replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      manifestutils.PodLabels(cfg.PodLabels, k8slabels.Merge(labels, memberlist.GossipSelector)),
+					Annotations: manifestutils.PodAnnotations(cfg.PodAnnotations, annotations),
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName:        tempo.Spec.ServiceAccount,
+					NodeSelector:              cfg.NodeSelector,
+					Tolerations:               cfg.Tolerations,
+					TopologySpreadConstraints: manifestutils.TopologySpreadConstraints(cfg.TopologySpreadConstraints, labels),
+					PriorityClassName:         cfg.PriorityClassName,
+					Affinity:                  manifestutils.Affinity(cfg.Affinity, labels),
+					Containers: []corev1.Container{
+						{
+							Name:  "tempo",
+							Image: tempo.Spec.Images.Tempo,
+							Args: []string{
+								"-target=metrics-generator",
+								"-config.file=/conf/tempo.yaml",
+								manifestutils.LogLevelArg(tempo, cfg.TempoComponentSpec),
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          manifestutils.HttpPortName,
+									ContainerPort: httpPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+								{
+									Name:          manifestutils.GrpcPortName,
+									ContainerPort: grpcPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+								{
+									Name:          manifestutils.HttpMemberlistPortName,
+									ContainerPort: manifestutils.MemberlistPort(tempo),
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							ReadinessProbe: manifestutils.TempoReadinessProbe(params.Gates.HTTPEncryption),
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      manifestutils.ConfigVolumeName,
+									MountPath: "/conf",
+									ReadOnly:  true,
+								},
+								{
+									Name:      manifestutils.TmpStorageVolumeName,
+									MountPath: manifestutils.TmpStoragePath,
+								},
+							},
+							Resources:       manifestutils.Resources(tempo, manifestutils.MetricsGeneratorComponentName),
+							SecurityContext: manifestutils.TempoContainerSecurityContext(),
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: manifestutils.ConfigVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: naming.Name("", tempo.Name),
+									},
+								},
+							},
+						},
+						{
+							// The write-ahead log of the generated metrics is only buffered until it is sent to the remote write endpoints.
+							Name: manifestutils.TmpStorageVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func service(tempo v1alpha1.TempoStack) *corev1.Service {
+	labels := manifestutils.ComponentLabels(manifestutils.MetricsGeneratorComponentName, tempo.Name)
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.Name(manifestutils.MetricsGeneratorComponentName, tempo.Name),
+			Namespace:   tempo.Namespace,
+			Labels:      manifestutils.ServiceLabels(tempo.Spec.Template.MetricsGenerator.ServiceLabels, labels),
+			Annotations: manifestutils.ServiceAnnotations(tempo.Spec.Template.MetricsGenerator.ServiceAnnotations, nil),
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       manifestutils.HttpPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       manifestutils.PortHTTPServer,
+					TargetPort: intstr.FromString(manifestutils.HttpPortName),
+				},
+				{
+					Name:       manifestutils.GrpcPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       manifestutils.PortGRPCServer,
+					TargetPort: intstr.FromString(manifestutils.GrpcPortName),
+				},
+			},
+			Selector: labels,
+		},
+	}
+}
@@ -0,0 +1,198 @@
+package metricsgenerator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/memberlist"
+)
+
+func TestBuildMetricsGenerator(t *testing.T) {
+	objects, err := BuildMetricsGenerator(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Images: configv1alpha1.ImagesSpec{
+				Tempo: "docker.io/grafana/tempo:2.2.1",
+			},
+			ServiceAccount: "tempo-test-serviceaccount",
+			Template: v1alpha1.TempoTemplateSpec{
+				MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						Replicas:     pointer.Int32(2),
+						NodeSelector: map[string]string{"a": "b"},
+					},
+					Enabled:    true,
+					Processors: []v1alpha1.MetricsGeneratorProcessor{v1alpha1.MetricsGeneratorProcessorSpanMetrics},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	labels := manifestutils.ComponentLabels(manifestutils.MetricsGeneratorComponentName, "test")
+	assert.Equal(t, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-metrics-generator",
+			Namespace: "project1",
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       manifestutils.HttpPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       manifestutils.PortHTTPServer,
+					TargetPort: intstr.FromString(manifestutils.HttpPortName),
+				},
+				{
+					Name:       manifestutils.GrpcPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       manifestutils.PortGRPCServer,
+					TargetPort: intstr.FromString(manifestutils.GrpcPortName),
+				},
+			},
+			Selector: labels,
+		},
+	}, objects[1])
+
+	dep := objects[0].(*v1.Deployment)
+	assert.Equal(t, "tempo-test-metrics-generator", dep.Name)
+	assert.Equal(t, pointer.Int32(2), dep.Spec.Replicas)
+	assert.Equal(t, k8slabels.Merge(labels, memberlist.GossipSelector), k8slabels.Set(dep.Spec.Template.Labels))
+	assert.Equal(t, map[string]string{"a": "b"}, dep.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, "tempo-test-serviceaccount", dep.Spec.Template.Spec.ServiceAccountName)
+
+	container := dep.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "docker.io/grafana/tempo:2.2.1", container.Image)
+	assert.Equal(t, []string{
+		"-target=metrics-generator",
+		"-config.file=/conf/tempo.yaml",
+		"-log.level=info",
+	}, container.Args)
+	assert.Equal(t, []corev1.ContainerPort{
+		{Name: manifestutils.HttpPortName, ContainerPort: manifestutils.PortHTTPServer, Protocol: corev1.ProtocolTCP},
+		{Name: manifestutils.GrpcPortName, ContainerPort: manifestutils.PortGRPCServer, Protocol: corev1.ProtocolTCP},
+		{Name: manifestutils.HttpMemberlistPortName, ContainerPort: manifestutils.PortMemberlist, Protocol: corev1.ProtocolTCP},
+	}, container.Ports)
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name:      manifestutils.TmpStorageVolumeName,
+		MountPath: manifestutils.TmpStoragePath,
+	})
+}
+
+func TestBuildMetricsGenerator_ServicePKI(t *testing.T) {
+	objects, err := BuildMetricsGenerator(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Template: v1alpha1.TempoTemplateSpec{
+					MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{Enabled: true},
+				},
+			},
+		},
+		Gates: configv1alpha1.FeatureGates{
+			GRPCEncryption: true,
+		},
+	})
+	require.NoError(t, err)
+
+	dep := objects[0].(*v1.Deployment)
+	var volumeNames []string
+	for _, volume := range dep.Spec.Template.Spec.Volumes {
+		volumeNames = append(volumeNames, volume.Name)
+	}
+	assert.Contains(t, volumeNames, "tempo-test-ca-bundle")
+	assert.Contains(t, volumeNames, "tempo-test-metrics-generator-mtls")
+}
+
+func TestBuildMetricsGenerator_RemoteWrite(t *testing.T) {
+	objects, err := BuildMetricsGenerator(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+					Enabled:    true,
+					Processors: []v1alpha1.MetricsGeneratorProcessor{v1alpha1.MetricsGeneratorProcessorSpanMetrics},
+					RemoteWrite: []v1alpha1.RemoteWriteSpec{
+						{OpenShiftMonitoring: true},
+						{
+							URL:  "https://mimir:8080/api/v1/push",
+							Auth: &v1alpha1.RemoteWriteAuthSpec{Type: v1alpha1.RemoteWriteAuthTypeBasic, Secret: "mimir-credentials"},
+							TLS:  &v1alpha1.RemoteWriteTLSSpec{CA: "mimir-ca", CertName: "mimir-client-cert"},
+						},
+						{
+							URL:  "https://prometheus:9090/api/v1/write",
+							Auth: &v1alpha1.RemoteWriteAuthSpec{Type: v1alpha1.RemoteWriteAuthTypeBearer, Secret: "prometheus-token"},
+						},
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	dep := objects[0].(*v1.Deployment)
+	container := dep.Spec.Template.Spec.Containers[0]
+	assert.Contains(t, container.Args, "-config.expand-env=true")
+
+	secretEnv := func(name, secret, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+					Key:                  key,
+				},
+			},
+		}
+	}
+	assert.Contains(t, container.Env, secretEnv("REMOTE_WRITE_1_USERNAME", "mimir-credentials", "username"))
+	assert.Contains(t, container.Env, secretEnv("REMOTE_WRITE_1_PASSWORD", "mimir-credentials", "password"))
+	assert.Contains(t, container.Env, secretEnv("REMOTE_WRITE_2_TOKEN", "prometheus-token", "token"))
+
+	assert.Contains(t, dep.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "remote-write-1-ca",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "mimir-ca"},
+			},
+		},
+	})
+	assert.Contains(t, dep.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "remote-write-1-tls",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: "mimir-client-cert"},
+		},
+	})
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name:      "remote-write-1-ca",
+		MountPath: "/var/run/ca/remote-write-1",
+		ReadOnly:  true,
+	})
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name:      "remote-write-1-tls",
+		MountPath: "/var/run/tls/remote-write-1",
+		ReadOnly:  true,
+	})
+}
@@ -8,11 +8,17 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/grafana/tempo-operator/internal/manifests/servingcerts"
 )
 
 // MutateFuncFor returns a mutate function based on the
@@ -23,7 +29,12 @@ import (
 // - Deployment
 // - StatefulSet
 // - ServiceMonitor
-// - Secret.
+// - Secret
+// - HorizontalPodAutoscaler
+// - PodDisruptionBudget
+// - CronJob
+// - Job (metadata only)
+// - Unstructured (spec only).
 func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 	return func() error {
 		existingAnnotations := existing.GetAnnotations()
@@ -98,6 +109,11 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantIng := desired.(*networkingv1.Ingress)
 			mutateIngress(ing, wantIng)
 
+		case *networkingv1.NetworkPolicy:
+			np := existing.(*networkingv1.NetworkPolicy)
+			wantNp := desired.(*networkingv1.NetworkPolicy)
+			mutateNetworkPolicy(np, wantNp)
+
 		case *routev1.Route:
 			rt := existing.(*routev1.Route)
 			wantRt := desired.(*routev1.Route)
@@ -113,6 +129,31 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantPr := desired.(*corev1.Secret)
 			mutateSecret(pr, wantPr)
 
+		case *autoscalingv2.HorizontalPodAutoscaler:
+			hpa := existing.(*autoscalingv2.HorizontalPodAutoscaler)
+			wantHpa := desired.(*autoscalingv2.HorizontalPodAutoscaler)
+			mutateHorizontalPodAutoscaler(hpa, wantHpa)
+
+		case *policyv1.PodDisruptionBudget:
+			pdb := existing.(*policyv1.PodDisruptionBudget)
+			wantPdb := desired.(*policyv1.PodDisruptionBudget)
+			mutatePodDisruptionBudget(pdb, wantPdb)
+
+		case *batchv1.CronJob:
+			cj := existing.(*batchv1.CronJob)
+			wantCj := desired.(*batchv1.CronJob)
+			if err := mutateCronJob(cj, wantCj); err != nil {
+				return err
+			}
+
+		case *batchv1.Job:
+			// The pod template of a Job is immutable, and a Job runs only once.
+
+		case *unstructured.Unstructured:
+			u := existing.(*unstructured.Unstructured)
+			wantU := desired.(*unstructured.Unstructured)
+			mutateUnstructured(u, wantU)
+
 		default:
 			t := reflect.TypeOf(existing).String()
 			return kverrors.New("missing mutate implementation for resource type", "type", t)
@@ -135,7 +176,16 @@ func mutateSecret(existing, desired *corev1.Secret) {
 	existing.Data = desired.Data
 }
 
+func mutateUnstructured(existing, desired *unstructured.Unstructured) {
+	existing.Object["spec"] = desired.Object["spec"]
+}
+
 func mutateConfigMap(existing, desired *corev1.ConfigMap) {
+	// The data of CA bundle ConfigMaps is injected by the OpenShift service-ca operator.
+	if desired.Annotations[servingcerts.InjectCABundleAnnotation] == "true" {
+		return
+	}
+
 	existing.BinaryData = desired.BinaryData
 	existing.Data = desired.Data
 }
@@ -183,6 +233,11 @@ func mutateIngress(existing, desired *networkingv1.Ingress) {
 	existing.Spec.TLS = desired.Spec.TLS
 }
 
+func mutateNetworkPolicy(existing, desired *networkingv1.NetworkPolicy) {
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
 func mutateRoute(existing, desired *routev1.Route) {
 	existing.Annotations = desired.Annotations
 	existing.Labels = desired.Labels
@@ -196,7 +251,18 @@ func mutatePrometheusRule(existing, desired *monitoringv1.PrometheusRule) {
 }
 
 func mutateService(existing, desired *corev1.Service) error {
+	// Keep the service type assigned by the API server if no type is desired.
+	if desired.Spec.Type != "" {
+		existing.Spec.Type = desired.Spec.Type
+	}
 	existing.Spec.Ports = desired.Spec.Ports
+	// Reset the internal traffic policy to the default of the API server if no policy is desired,
+	// otherwise a Local policy is kept after it got removed from the TempoStack.
+	internalTrafficPolicy := corev1.ServiceInternalTrafficPolicyCluster
+	if desired.Spec.InternalTrafficPolicy != nil {
+		internalTrafficPolicy = *desired.Spec.InternalTrafficPolicy
+	}
+	existing.Spec.InternalTrafficPolicy = &internalTrafficPolicy
 	if err := mergeWithOverride(&existing.Spec.Selector, desired.Spec.Selector); err != nil {
 		return err
 	}
@@ -209,7 +275,12 @@ func mutateDeployment(existing, desired *appsv1.Deployment) error {
 	if existing.CreationTimestamp.IsZero() {
 		existing.Spec.Selector = desired.Spec.Selector
 	}
-	existing.Spec.Replicas = desired.Spec.Replicas
+	// Keep the replicas of deployments without desired replicas, e.g. deployments scaled by a HorizontalPodAutoscaler.
+	// Deployments scaled to zero by the hibernation are reset to the default replicas,
+	// because the HorizontalPodAutoscaler does not scale up deployments with zero replicas.
+	if desired.Spec.Replicas != nil || (existing.Spec.Replicas != nil && *existing.Spec.Replicas == 0) {
+		existing.Spec.Replicas = desired.Spec.Replicas
+	}
 	if err := mergeWithOverride(&existing.Spec.Template, desired.Spec.Template); err != nil {
 		return err
 	}
@@ -219,18 +290,38 @@ func mutateDeployment(existing, desired *appsv1.Deployment) error {
 	return nil
 }
 
+func mutateHorizontalPodAutoscaler(existing, desired *autoscalingv2.HorizontalPodAutoscaler) {
+	existing.Spec = desired.Spec
+}
+
+func mutatePodDisruptionBudget(existing, desired *policyv1.PodDisruptionBudget) {
+	existing.Spec = desired.Spec
+}
+
+func mutateCronJob(existing, desired *batchv1.CronJob) error {
+	existing.Spec.Schedule = desired.Spec.Schedule
+	existing.Spec.Suspend = desired.Spec.Suspend
+	existing.Spec.ConcurrencyPolicy = desired.Spec.ConcurrencyPolicy
+	existing.Spec.SuccessfulJobsHistoryLimit = desired.Spec.SuccessfulJobsHistoryLimit
+	existing.Spec.FailedJobsHistoryLimit = desired.Spec.FailedJobsHistoryLimit
+	return mergeWithOverride(&existing.Spec.JobTemplate, desired.Spec.JobTemplate)
+}
+
 func mutateStatefulSet(existing, desired *appsv1.StatefulSet) error {
-	// StatefulSet selector is immutable so we set this value only if
-	// a new object is going to be created
+	// StatefulSet selector and volume claim templates are immutable so we set these values only if
+	// a new object is going to be created. Changes of the volume claim templates are rejected by the webhook.
 	if existing.CreationTimestamp.IsZero() {
 		existing.Spec.Selector = desired.Spec.Selector
+		existing.Spec.VolumeClaimTemplates = desired.Spec.VolumeClaimTemplates
 	}
 	existing.Spec.PodManagementPolicy = desired.Spec.PodManagementPolicy
 	existing.Spec.Replicas = desired.Spec.Replicas
-	for i := range existing.Spec.VolumeClaimTemplates {
-		existing.Spec.VolumeClaimTemplates[i].TypeMeta = desired.Spec.VolumeClaimTemplates[i].TypeMeta
-		existing.Spec.VolumeClaimTemplates[i].ObjectMeta = desired.Spec.VolumeClaimTemplates[i].ObjectMeta
-		existing.Spec.VolumeClaimTemplates[i].Spec = desired.Spec.VolumeClaimTemplates[i].Spec
+	// The partition of the rolling update is only set while a new Tempo version is rolled out to the ingesters.
+	if desired.Spec.UpdateStrategy.RollingUpdate != nil {
+		existing.Spec.UpdateStrategy = desired.Spec.UpdateStrategy
+	} else if existing.Spec.UpdateStrategy.RollingUpdate != nil && existing.Spec.UpdateStrategy.RollingUpdate.Partition != nil {
+		partition := int32(0)
+		existing.Spec.UpdateStrategy.RollingUpdate.Partition = &partition
 	}
 	if err := mergeWithOverride(&existing.Spec.Template, desired.Spec.Template); err != nil {
 		return err
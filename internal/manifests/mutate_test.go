@@ -7,10 +7,13 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 
@@ -81,6 +84,25 @@ func TestGetMutateFunc_MutateConfigMap(t *testing.T) {
 	require.Equal(t, got.Data, want.Data)
 }
 
+func TestGetMutateFunc_MutateInjectedCABundle(t *testing.T) {
+	got := &corev1.ConfigMap{
+		Data: map[string]string{"service-ca.crt": "ca"},
+	}
+
+	want := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"service.beta.openshift.io/inject-cabundle": "true"},
+		},
+	}
+
+	f := manifests.MutateFuncFor(got, want)
+	err := f()
+	require.NoError(t, err)
+
+	require.Equal(t, want.Annotations, got.Annotations)
+	require.Equal(t, map[string]string{"service-ca.crt": "ca"}, got.Data)
+}
+
 func TestGetMutateFunc_MutateSecert(t *testing.T) {
 	got := &corev1.Secret{
 		Data: map[string][]byte{},
@@ -100,6 +122,25 @@ func TestGetMutateFunc_MutateSecert(t *testing.T) {
 	require.Equal(t, got.Data, want.Data)
 }
 
+func TestGetMutateFunc_MutateUnstructured(t *testing.T) {
+	got := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec":   map[string]interface{}{"secretName": "old"},
+		"status": map[string]interface{}{"ready": true},
+	}}
+
+	want := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"secretName": "new"},
+	}}
+
+	f := manifests.MutateFuncFor(got, want)
+	err := f()
+	require.NoError(t, err)
+
+	// Ensure partial mutation applied
+	require.Equal(t, want.Object["spec"], got.Object["spec"])
+	require.Equal(t, map[string]interface{}{"ready": true}, got.Object["status"])
+}
+
 func TestGetMutateFunc_MutateServiceSpec(t *testing.T) {
 	got := &corev1.Service{
 		Spec: corev1.ServiceSpec{
@@ -149,6 +190,47 @@ func TestGetMutateFunc_MutateServiceSpec(t *testing.T) {
 	require.Exactly(t, got.Spec.ClusterIPs, []string{"8.8.8.8"})
 }
 
+func TestGetMutateFunc_MutateServiceType(t *testing.T) {
+	got := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+		},
+	}
+
+	want := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeLoadBalancer,
+		},
+	}
+
+	f := manifests.MutateFuncFor(got, want)
+	err := f()
+	require.NoError(t, err)
+	require.Equal(t, corev1.ServiceTypeLoadBalancer, got.Spec.Type)
+
+	// Ensure the type is not reset if no type is desired
+	f = manifests.MutateFuncFor(got, &corev1.Service{})
+	err = f()
+	require.NoError(t, err)
+	require.Equal(t, corev1.ServiceTypeLoadBalancer, got.Spec.Type)
+}
+
+func TestGetMutateFunc_MutateServiceInternalTrafficPolicy(t *testing.T) {
+	local := corev1.ServiceInternalTrafficPolicyLocal
+	got := &corev1.Service{}
+
+	f := manifests.MutateFuncFor(got, &corev1.Service{Spec: corev1.ServiceSpec{InternalTrafficPolicy: &local}})
+	err := f()
+	require.NoError(t, err)
+	require.Equal(t, corev1.ServiceInternalTrafficPolicyLocal, *got.Spec.InternalTrafficPolicy)
+
+	// Ensure the policy is reset to the default if no policy is desired
+	f = manifests.MutateFuncFor(got, &corev1.Service{})
+	err = f()
+	require.NoError(t, err)
+	require.Equal(t, corev1.ServiceInternalTrafficPolicyCluster, *got.Spec.InternalTrafficPolicy)
+}
+
 func TestGetMutateFunc_MutateServiceAccountObjectMeta(t *testing.T) {
 	type test struct {
 		got  *corev1.ServiceAccount
@@ -638,6 +720,34 @@ func TestGeMutateFunc_MutateDeploymentSpec(t *testing.T) {
 	}
 }
 
+func TestGeMutateFunc_MutateDeploymentKeepsReplicas(t *testing.T) {
+	got := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(3),
+		},
+	}
+	want := &appsv1.Deployment{}
+
+	f := manifests.MutateFuncFor(got, want)
+	err := f()
+	require.NoError(t, err)
+	require.Equal(t, pointer.Int32(3), got.Spec.Replicas)
+}
+
+func TestGeMutateFunc_MutateDeploymentResetsHibernatedReplicas(t *testing.T) {
+	got := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(0),
+		},
+	}
+	want := &appsv1.Deployment{}
+
+	f := manifests.MutateFuncFor(got, want)
+	err := f()
+	require.NoError(t, err)
+	require.Nil(t, got.Spec.Replicas)
+}
+
 func TestGeMutateFunc_MutateStatefulSetSpec(t *testing.T) {
 	one := int32(1)
 	two := int32(2)
@@ -785,14 +895,15 @@ func TestGeMutateFunc_MutateStatefulSetSpec(t *testing.T) {
 			// Ensure conditional mutation applied
 			if tst.got.CreationTimestamp.IsZero() {
 				require.Equal(t, tst.got.Spec.Selector, tst.want.Spec.Selector)
+				require.Equal(t, tst.got.Spec.VolumeClaimTemplates, tst.want.Spec.VolumeClaimTemplates)
 			} else {
 				require.NotEqual(t, tst.got.Spec.Selector, tst.want.Spec.Selector)
+				require.NotEqual(t, tst.got.Spec.VolumeClaimTemplates, tst.want.Spec.VolumeClaimTemplates)
 			}
 
 			// Ensure partial mutation applied
 			require.Equal(t, tst.got.Spec.Replicas, tst.want.Spec.Replicas)
 			require.Equal(t, tst.got.Spec.Template, tst.want.Spec.Template)
-			require.Equal(t, tst.got.Spec.VolumeClaimTemplates, tst.got.Spec.VolumeClaimTemplates)
 		})
 	}
 }
@@ -1080,3 +1191,115 @@ func TestGetMutateFunc_MutateRoute(t *testing.T) {
 	require.Exactly(t, got.Annotations, want.Annotations)
 	require.Exactly(t, got.Spec, want.Spec)
 }
+
+func TestGetMutateFunc_MutateHorizontalPodAutoscaler(t *testing.T) {
+	got := &autoscalingv2.HorizontalPodAutoscaler{
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			MaxReplicas: 3,
+		},
+	}
+	want := &autoscalingv2.HorizontalPodAutoscaler{
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "tempo-simplest-gateway",
+			},
+			MinReplicas: pointer.Int32(2),
+			MaxReplicas: 5,
+		},
+	}
+
+	f := manifests.MutateFuncFor(got, want)
+	err := f()
+	require.NoError(t, err)
+	require.Exactly(t, got.Spec, want.Spec)
+}
+
+func TestGetMutateFunc_MutatePodDisruptionBudget(t *testing.T) {
+	maxUnavailable := intstr.FromInt(1)
+	got := &policyv1.PodDisruptionBudget{}
+	want := &policyv1.PodDisruptionBudget{
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"test": "test"},
+			},
+		},
+	}
+
+	f := manifests.MutateFuncFor(got, want)
+	err := f()
+	require.NoError(t, err)
+	require.Exactly(t, got.Spec, want.Spec)
+}
+
+func TestGetMutateFunc_MutateNetworkPolicy(t *testing.T) {
+	got := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"test": "test"},
+		},
+	}
+	want := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"test": "test", "other": "label"},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{From: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}}}},
+			},
+		},
+	}
+
+	f := manifests.MutateFuncFor(got, want)
+	err := f()
+	require.NoError(t, err)
+	require.Exactly(t, got.Labels, want.Labels)
+	require.Exactly(t, got.Spec, want.Spec)
+}
+
+func TestGetMutateFunc_MutateStatefulSetPartition(t *testing.T) {
+	partition := func(p int32) appsv1.StatefulSetUpdateStrategy {
+		return appsv1.StatefulSetUpdateStrategy{
+			Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &p},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		existing appsv1.StatefulSetUpdateStrategy
+		desired  appsv1.StatefulSetUpdateStrategy
+		expected appsv1.StatefulSetUpdateStrategy
+	}{
+		{
+			name:     "set partition",
+			existing: partition(0),
+			desired:  partition(2),
+			expected: partition(2),
+		},
+		{
+			name:     "reset partition",
+			existing: partition(2),
+			expected: partition(0),
+		},
+		{
+			name: "no partition",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+				Spec:       appsv1.StatefulSetSpec{UpdateStrategy: test.existing},
+			}
+			want := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{UpdateStrategy: test.desired}}
+
+			f := manifests.MutateFuncFor(got, want)
+			require.NoError(t, f())
+			require.Equal(t, test.expected, got.Spec.UpdateStrategy)
+		})
+	}
+}
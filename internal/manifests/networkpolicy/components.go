@@ -0,0 +1,236 @@
+package networkpolicy
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+	"github.com/grafana/tempo-operator/internal/manifests/oauthproxy"
+)
+
+// receiverPorts are the named container ports of the receivers of the distributor.
+var receiverPorts = []struct {
+	name     string
+	protocol corev1.Protocol
+}{
+	{manifestutils.OtlpGrpcPortName, corev1.ProtocolTCP},
+	{manifestutils.PortOtlpHttpName, corev1.ProtocolTCP},
+	{manifestutils.PortJaegerThriftHTTPName, corev1.ProtocolTCP},
+	{manifestutils.PortJaegerGrpcName, corev1.ProtocolTCP},
+	{manifestutils.PortZipkinName, corev1.ProtocolTCP},
+	{manifestutils.PortJaegerThriftCompactName, corev1.ProtocolUDP},
+	{manifestutils.PortJaegerThriftBinaryName, corev1.ProtocolUDP},
+}
+
+// dnsPorts are the ports of the cluster DNS. The DNS pods of OpenShift listen on port 5353.
+var dnsPorts = []int{53, 5353}
+
+// apiPorts are the ports of the Kubernetes API server and of the OIDC/OAuth providers.
+var apiPorts = []int{443, 6443}
+
+// Enabled returns true if NetworkPolicies should be created for all components of the TempoStack.
+func Enabled(tempo v1alpha1.TempoStack) bool {
+	return tempo.Spec.NetworkPolicy != nil && tempo.Spec.NetworkPolicy.Enabled
+}
+
+// BuildComponentPolicies creates a NetworkPolicy for every component of the TempoStack, which allows
+// only the traffic between the components, the ingestion of spans, the queries, the scraping of the metrics,
+// DNS and the egress traffic to the object storage.
+//
+// In the trustedHeader tenancy mode, the ingress traffic from outside the TempoStack is additionally
+// restricted to the allowed CIDRs, therefore these policies replace the policies of BuildTrustedHeaderPolicies.
+func BuildComponentPolicies(tempo v1alpha1.TempoStack) []client.Object {
+	gatewayEnabled := tempo.Spec.Template.Gateway.Enabled
+	storageEgress := objectStorageEgress(tempo)
+
+	distributor := newPolicy(tempo, manifestutils.DistributorComponentName)
+	if !gatewayEnabled {
+		distributor.Spec.Ingress = append(distributor.Spec.Ingress, receiverIngress(tempo))
+	}
+	if tempo.Spec.Template.Distributor.Receivers.Kafka != nil {
+		distributor.Spec.Egress = append(distributor.Spec.Egress, networkingv1.NetworkPolicyEgressRule{})
+	}
+
+	ingester := newPolicy(tempo, manifestutils.IngesterComponentName)
+	ingester.Spec.Egress = append(ingester.Spec.Egress, storageEgress)
+
+	querier := newPolicy(tempo, manifestutils.QuerierComponentName)
+	querier.Spec.Egress = append(querier.Spec.Egress, storageEgress)
+
+	compactor := newPolicy(tempo, manifestutils.CompactorComponentName)
+	compactor.Spec.Egress = append(compactor.Spec.Egress, storageEgress)
+
+	queryFrontend := newPolicy(tempo, manifestutils.QueryFrontendComponentName)
+	if !gatewayEnabled {
+		queryFrontend.Spec.Ingress = append(queryFrontend.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+			From: trustedHeaderPeers(tempo),
+		})
+	}
+	if tempo.Spec.Template.QueryFrontend.JaegerQuery.Enabled && tempo.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab.Enabled {
+		// The monitor tab queries the span RED metrics from Prometheus.
+		queryFrontend.Spec.Egress = append(queryFrontend.Spec.Egress, networkingv1.NetworkPolicyEgressRule{})
+	} else if oauthproxy.Enabled(tempo) {
+		queryFrontend.Spec.Egress = append(queryFrontend.Spec.Egress, networkingv1.NetworkPolicyEgressRule{
+			Ports: tcpPorts(apiPorts),
+		})
+	}
+
+	if manifestutils.KVStore(tempo) != v1alpha1.KVStoreMemberlist {
+		// The address of the etcd cluster or Consul is unknown, therefore all destinations need to be allowed.
+		for _, policy := range []*networkingv1.NetworkPolicy{distributor, ingester, querier, compactor, queryFrontend} {
+			policy.Spec.Egress = append(policy.Spec.Egress, networkingv1.NetworkPolicyEgressRule{})
+		}
+	}
+
+	policies := []client.Object{distributor, ingester, querier, compactor, queryFrontend}
+
+	if tempo.Spec.Template.MetricsGenerator.Enabled {
+		metricsGenerator := newPolicy(tempo, manifestutils.MetricsGeneratorComponentName)
+		// The metrics-generator sends the metrics to the remote write endpoints.
+		metricsGenerator.Spec.Egress = append(metricsGenerator.Spec.Egress, networkingv1.NetworkPolicyEgressRule{})
+		policies = append(policies, metricsGenerator)
+	}
+
+	if gatewayEnabled {
+		gateway := newPolicy(tempo, manifestutils.GatewayComponentName)
+		gateway.Spec.Ingress = append(gateway.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+			From: trustedHeaderPeers(tempo),
+		})
+		gateway.Spec.Egress = append(gateway.Spec.Egress, networkingv1.NetworkPolicyEgressRule{
+			Ports: tcpPorts(apiPorts),
+		})
+		policies = append(policies, gateway)
+	}
+
+	if tempo.Spec.Template.Memcached.Enabled {
+		policies = append(policies, newPolicy(tempo, manifestutils.MemcachedComponentName))
+	}
+
+	return policies
+}
+
+// newPolicy creates a NetworkPolicy which allows the traffic between the components of the TempoStack,
+// the scraping of the metrics and the DNS lookups.
+func newPolicy(tempo v1alpha1.TempoStack, component string) *networkingv1.NetworkPolicy {
+	labels := manifestutils.ComponentLabels(component, tempo.Name)
+	stackPeers := []networkingv1.NetworkPolicyPeer{
+		{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: manifestutils.CommonLabels(tempo.Name),
+			},
+		},
+	}
+
+	ingress := []networkingv1.NetworkPolicyIngressRule{
+		{From: stackPeers},
+	}
+	if component != manifestutils.MemcachedComponentName {
+		// Allow the scraping of the metrics and the requests of the operator (e.g. flushing the ingesters)
+		// from all namespaces of the cluster.
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+			From: []networkingv1.NetworkPolicyPeer{
+				{NamespaceSelector: &metav1.LabelSelector{}},
+			},
+			Ports: []networkingv1.NetworkPolicyPort{
+				namedPort(manifestutils.HttpPortName, corev1.ProtocolTCP),
+			},
+		})
+	}
+
+	dns := networkingv1.NetworkPolicyEgressRule{}
+	for _, port := range dnsPorts {
+		dns.Ports = append(dns.Ports, numberedPort(port, corev1.ProtocolUDP), numberedPort(port, corev1.ProtocolTCP))
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(component, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+			Ingress:     ingress,
+			Egress: []networkingv1.NetworkPolicyEgressRule{
+				{To: stackPeers},
+				dns,
+			},
+		},
+	}
+}
+
+// receiverIngress allows the ingestion of spans from the namespaces selected by the receiver namespace selector,
+// or from everywhere if no selector is set.
+func receiverIngress(tempo v1alpha1.TempoStack) networkingv1.NetworkPolicyIngressRule {
+	rule := networkingv1.NetworkPolicyIngressRule{}
+	for _, port := range receiverPorts {
+		rule.Ports = append(rule.Ports, namedPort(port.name, port.protocol))
+	}
+
+	if selector := tempo.Spec.NetworkPolicy.ReceiverNamespaceSelector; selector != nil {
+		rule.From = append(rule.From, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: selector.DeepCopy(),
+		})
+	}
+	rule.From = append(rule.From, trustedHeaderPeers(tempo)...)
+	return rule
+}
+
+// trustedHeaderPeers returns the allowed CIDRs of the trustedHeader tenancy mode.
+// An empty list allows the traffic from everywhere.
+func trustedHeaderPeers(tempo v1alpha1.TempoStack) []networkingv1.NetworkPolicyPeer {
+	tenants := tempo.Spec.Tenants
+	if tenants == nil || tenants.Mode != v1alpha1.ModeTrustedHeader || tenants.TrustedHeader == nil {
+		return nil
+	}
+
+	var peers []networkingv1.NetworkPolicyPeer
+	for _, cidr := range tenants.TrustedHeader.AllowedCIDRs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+		})
+	}
+	return peers
+}
+
+// objectStorageEgress allows the egress traffic to the object storage and to an external cache.
+func objectStorageEgress(tempo v1alpha1.TempoStack) networkingv1.NetworkPolicyEgressRule {
+	rule := networkingv1.NetworkPolicyEgressRule{}
+	// The address of an external cache is unknown, therefore all destinations need to be allowed.
+	if tempo.Spec.Cache != nil {
+		return rule
+	}
+
+	for _, cidr := range tempo.Spec.NetworkPolicy.ObjectStorageCIDRs {
+		rule.To = append(rule.To, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+		})
+	}
+	return rule
+}
+
+func namedPort(name string, protocol corev1.Protocol) networkingv1.NetworkPolicyPort {
+	port := intstr.FromString(name)
+	return networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &port}
+}
+
+func numberedPort(number int, protocol corev1.Protocol) networkingv1.NetworkPolicyPort {
+	port := intstr.FromInt(number)
+	return networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &port}
+}
+
+func tcpPorts(numbers []int) []networkingv1.NetworkPolicyPort {
+	ports := make([]networkingv1.NetworkPolicyPort, 0, len(numbers))
+	for _, number := range numbers {
+		ports = append(ports, numberedPort(number, corev1.ProtocolTCP))
+	}
+	return ports
+}
@@ -0,0 +1,143 @@
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func policiesByName(t *testing.T, tempo v1alpha1.TempoStack) map[string]*networkingv1.NetworkPolicy {
+	policies := map[string]*networkingv1.NetworkPolicy{}
+	for _, obj := range BuildComponentPolicies(tempo) {
+		policy, ok := obj.(*networkingv1.NetworkPolicy)
+		require.True(t, ok)
+		policies[policy.Name] = policy
+	}
+	return policies
+}
+
+func TestBuildComponentPolicies(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			NetworkPolicy: &v1alpha1.NetworkPolicySpec{
+				Enabled:                   true,
+				ReceiverNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tracing": "enabled"}},
+				ObjectStorageCIDRs:        []string{"10.0.0.0/8"},
+			},
+		},
+	}
+	require.True(t, Enabled(tempo))
+
+	policies := policiesByName(t, tempo)
+	assert.Len(t, policies, 5)
+	for _, name := range []string{"tempo-test-distributor", "tempo-test-ingester", "tempo-test-querier", "tempo-test-compactor", "tempo-test-query-frontend"} {
+		require.Contains(t, policies, name)
+		policy := policies[name]
+		assert.Equal(t, "project1", policy.Namespace)
+		assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, policy.Spec.PolicyTypes)
+
+		// traffic between the components
+		assert.Equal(t, manifestutils.CommonLabels("test"), policy.Spec.Ingress[0].From[0].PodSelector.MatchLabels)
+		assert.Equal(t, manifestutils.CommonLabels("test"), policy.Spec.Egress[0].To[0].PodSelector.MatchLabels)
+		// DNS
+		assert.Len(t, policy.Spec.Egress[1].Ports, 4)
+	}
+
+	distributor := policies["tempo-test-distributor"]
+	require.Len(t, distributor.Spec.Ingress, 3)
+	receivers := distributor.Spec.Ingress[2]
+	assert.Len(t, receivers.Ports, len(receiverPorts))
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{
+		{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tracing": "enabled"}}},
+	}, receivers.From)
+	assert.Len(t, distributor.Spec.Egress, 2)
+
+	ingester := policies["tempo-test-ingester"]
+	require.Len(t, ingester.Spec.Egress, 3)
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{
+		{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}},
+	}, ingester.Spec.Egress[2].To)
+
+	queryFrontend := policies["tempo-test-query-frontend"]
+	require.Len(t, queryFrontend.Spec.Ingress, 3)
+	assert.Empty(t, queryFrontend.Spec.Ingress[2].From)
+	assert.Empty(t, queryFrontend.Spec.Ingress[2].Ports)
+}
+
+func TestBuildComponentPolicies_Gateway(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			NetworkPolicy: &v1alpha1.NetworkPolicySpec{Enabled: true},
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway:          v1alpha1.TempoGatewaySpec{Enabled: true},
+				MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{Enabled: true},
+			},
+			Tenants: &v1alpha1.TenantsSpec{
+				Mode: v1alpha1.ModeTrustedHeader,
+				TrustedHeader: &v1alpha1.TrustedHeaderSpec{
+					AllowedCIDRs: []string{"192.168.0.0/16"},
+				},
+			},
+		},
+	}
+
+	policies := policiesByName(t, tempo)
+	assert.Len(t, policies, 7)
+
+	// the gateway receives the spans and the queries
+	assert.Len(t, policies["tempo-test-distributor"].Spec.Ingress, 2)
+	assert.Len(t, policies["tempo-test-query-frontend"].Spec.Ingress, 2)
+
+	gateway := policies["tempo-test-gateway"]
+	require.Len(t, gateway.Spec.Ingress, 3)
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{
+		{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.0/16"}},
+	}, gateway.Spec.Ingress[2].From)
+	require.Len(t, gateway.Spec.Egress, 3)
+	assert.Len(t, gateway.Spec.Egress[2].Ports, 2)
+
+	metricsGenerator := policies["tempo-test-metrics-generator"]
+	require.Len(t, metricsGenerator.Spec.Egress, 3)
+	assert.Equal(t, networkingv1.NetworkPolicyEgressRule{}, metricsGenerator.Spec.Egress[2])
+}
+
+func TestBuildComponentPolicies_KVStore(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			NetworkPolicy: &v1alpha1.NetworkPolicySpec{Enabled: true},
+			KVStore: &v1alpha1.KVStoreSpec{
+				Type: v1alpha1.KVStoreEtcd,
+				Etcd: &v1alpha1.EtcdSpec{Endpoints: []string{"etcd.etcd.svc:2379"}},
+			},
+		},
+	}
+
+	policies := policiesByName(t, tempo)
+	for _, name := range []string{"tempo-test-distributor", "tempo-test-ingester", "tempo-test-querier", "tempo-test-compactor", "tempo-test-query-frontend"} {
+		require.Contains(t, policies, name)
+		egress := policies[name].Spec.Egress
+		assert.Equal(t, networkingv1.NetworkPolicyEgressRule{}, egress[len(egress)-1])
+	}
+}
+
+func TestBuildComponentPolicies_Disabled(t *testing.T) {
+	assert.False(t, Enabled(v1alpha1.TempoStack{}))
+	assert.False(t, Enabled(v1alpha1.TempoStack{Spec: v1alpha1.TempoStackSpec{NetworkPolicy: &v1alpha1.NetworkPolicySpec{}}}))
+}
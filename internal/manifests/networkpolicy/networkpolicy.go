@@ -0,0 +1,59 @@
+package networkpolicy
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// BuildTrustedHeaderPolicies creates NetworkPolicy objects, which restrict the ingress traffic of the
+// components accepting the tenant header to the allowed CIDRs of the trustedHeader mode.
+func BuildTrustedHeaderPolicies(tempo v1alpha1.TempoStack) []client.Object {
+	tenants := tempo.Spec.Tenants
+	if tenants == nil || tenants.Mode != v1alpha1.ModeTrustedHeader ||
+		tenants.TrustedHeader == nil || len(tenants.TrustedHeader.AllowedCIDRs) == 0 {
+		return nil
+	}
+
+	return []client.Object{
+		buildNetworkPolicy(tempo, manifestutils.DistributorComponentName),
+		buildNetworkPolicy(tempo, manifestutils.QueryFrontendComponentName),
+	}
+}
+
+func buildNetworkPolicy(tempo v1alpha1.TempoStack, component string) *networkingv1.NetworkPolicy {
+	peers := []networkingv1.NetworkPolicyPeer{
+		{
+			// Allow the traffic between the components of the TempoStack.
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: manifestutils.CommonLabels(tempo.Name),
+			},
+		},
+	}
+	for _, cidr := range tempo.Spec.Tenants.TrustedHeader.AllowedCIDRs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+		})
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(component, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    manifestutils.ComponentLabels(component, tempo.Name),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: manifestutils.ComponentLabels(component, tempo.Name),
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{From: peers},
+			},
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildTrustedHeaderPolicies(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Tenants: &v1alpha1.TenantsSpec{
+				Mode: v1alpha1.ModeTrustedHeader,
+				TrustedHeader: &v1alpha1.TrustedHeaderSpec{
+					AllowedCIDRs: []string{"10.0.0.0/8"},
+				},
+			},
+		},
+	}
+
+	objs := BuildTrustedHeaderPolicies(tempo)
+	require.Len(t, objs, 2)
+
+	labels := manifestutils.ComponentLabels(manifestutils.DistributorComponentName, "test")
+	assert.Equal(t, &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-distributor",
+			Namespace: "project1",
+			Labels:    labels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: manifestutils.CommonLabels("test"),
+							},
+						},
+						{
+							IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"},
+						},
+					},
+				},
+			},
+		},
+	}, objs[0])
+	assert.Equal(t, "tempo-test-query-frontend", objs[1].GetName())
+}
+
+func TestBuildTrustedHeaderPolicies_NoCIDRs(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{
+			Tenants: &v1alpha1.TenantsSpec{
+				Mode:          v1alpha1.ModeTrustedHeader,
+				TrustedHeader: &v1alpha1.TrustedHeaderSpec{RequireClientCertificate: true},
+			},
+		},
+	}
+	assert.Empty(t, BuildTrustedHeaderPolicies(tempo))
+}
@@ -0,0 +1,142 @@
+package oauthproxy
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const (
+	// PortName is the name of the port of the OAuth proxy.
+	PortName = "oauth-proxy"
+	port     = 8080
+
+	// CookieSecretKey is the key of the cookie secret in the OAuth proxy Secret.
+	CookieSecretKey = "session_secret"
+
+	containerName   = "oauth-proxy"
+	secretMountPath = "/var/run/secrets/oauth-proxy"
+	secretVolume    = "oauth-proxy-cookie"
+
+	redirectReferenceAnnotation = "serviceaccounts.openshift.io/oauth-redirectreference.primary"
+)
+
+// Enabled returns true if the Jaeger Query UI is protected by the OAuth proxy.
+func Enabled(tempo v1alpha1.TempoStack) bool {
+	jaegerQuery := tempo.Spec.Template.QueryFrontend.JaegerQuery
+	return jaegerQuery.Enabled && jaegerQuery.Authentication != nil && jaegerQuery.Authentication.Enabled
+}
+
+// SecretName returns the name of the Secret holding the cookie secret of the OAuth proxy.
+func SecretName(tempoName string) string {
+	return naming.Name("query-frontend-oauth-proxy", tempoName)
+}
+
+// BuildSecret creates the Secret holding the cookie secret of the OAuth proxy.
+// The existing cookie secret is reused to keep the sessions of the users valid.
+func BuildSecret(params manifestutils.Params) (*corev1.Secret, error) {
+	cookieSecret := params.OAuthProxyCookieSecret
+	if cookieSecret == "" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("failed to generate the OAuth proxy cookie secret: %w", err)
+		}
+		cookieSecret = base64.StdEncoding.EncodeToString(b)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SecretName(params.Tempo.Name),
+			Namespace: params.Tempo.Namespace,
+			Labels:    manifestutils.ComponentLabels(manifestutils.QueryFrontendComponentName, params.Tempo.Name),
+		},
+		Data: map[string][]byte{
+			CookieSecretKey: []byte(cookieSecret),
+		},
+	}, nil
+}
+
+// PatchPodSpec adds the OAuth proxy sidecar container, which forwards the authorized requests to the Jaeger Query UI.
+func PatchPodSpec(tempo v1alpha1.TempoStack, pod *corev1.PodSpec, upstreamPort int) {
+	sar := tempo.Spec.Template.QueryFrontend.JaegerQuery.Authentication.SAR
+	if sar == "" {
+		sar = fmt.Sprintf(`{"namespace": "%s", "resource": "pods", "verb": "get"}`, tempo.Namespace)
+	}
+
+	pod.Containers = append(pod.Containers, corev1.Container{
+		Name:  containerName,
+		Image: tempo.Spec.Images.OauthProxy,
+		Args: []string{
+			"--provider=openshift",
+			fmt.Sprintf("--http-address=0.0.0.0:%d", port),
+			"--https-address=",
+			fmt.Sprintf("--upstream=http://localhost:%d", upstreamPort),
+			fmt.Sprintf("--openshift-service-account=%s", tempo.Spec.ServiceAccount),
+			fmt.Sprintf("--openshift-sar=%s", sar),
+			fmt.Sprintf("--cookie-secret-file=%s", path.Join(secretMountPath, CookieSecretKey)),
+			"--cookie-secure=true",
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          PortName,
+				ContainerPort: port,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/oauth/healthz",
+					Port: intstr.FromInt(port),
+				},
+			},
+			InitialDelaySeconds: 5,
+			PeriodSeconds:       10,
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      secretVolume,
+				ReadOnly:  true,
+				MountPath: secretMountPath,
+			},
+		},
+		SecurityContext: manifestutils.TempoContainerSecurityContext(),
+	})
+	pod.Volumes = append(pod.Volumes, corev1.Volume{
+		Name: secretVolume,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: SecretName(tempo.Name),
+			},
+		},
+	})
+}
+
+// ServicePort returns the service port of the OAuth proxy.
+func ServicePort() corev1.ServicePort {
+	return corev1.ServicePort{
+		Name:       PortName,
+		Port:       port,
+		TargetPort: intstr.FromString(PortName),
+	}
+}
+
+// PatchServiceAccount registers the Route of the Jaeger Query UI as OAuth redirect URI of the service account.
+func PatchServiceAccount(tempo v1alpha1.TempoStack, sa *corev1.ServiceAccount) {
+	if sa.Annotations == nil {
+		sa.Annotations = map[string]string{}
+	}
+	sa.Annotations[redirectReferenceAnnotation] = fmt.Sprintf(
+		`{"kind":"OAuthRedirectReference","apiVersion":"v1","reference":{"kind":"Route","name":"%s"}}`,
+		naming.Name(manifestutils.QueryFrontendComponentName, tempo.Name),
+	)
+}
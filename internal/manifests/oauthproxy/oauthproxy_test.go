@@ -0,0 +1,111 @@
+package oauthproxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func testTempoStack(sar string) v1alpha1.TempoStack {
+	return v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			ServiceAccount: "tempo-test",
+			Images: configv1alpha1.ImagesSpec{
+				OauthProxy: "quay.io/openshift/origin-oauth-proxy:4.14",
+			},
+			Template: v1alpha1.TempoTemplateSpec{
+				QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+					JaegerQuery: v1alpha1.JaegerQuerySpec{
+						Enabled: true,
+						Authentication: &v1alpha1.JaegerQueryAuthenticationSpec{
+							Enabled: true,
+							SAR:     sar,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	assert.True(t, Enabled(testTempoStack("")))
+	assert.False(t, Enabled(v1alpha1.TempoStack{}))
+
+	tempo := testTempoStack("")
+	tempo.Spec.Template.QueryFrontend.JaegerQuery.Enabled = false
+	assert.False(t, Enabled(tempo))
+}
+
+func TestPatchPodSpec(t *testing.T) {
+	tests := []struct {
+		name        string
+		sar         string
+		expectedSAR string
+	}{
+		{
+			name:        "default SAR",
+			expectedSAR: `--openshift-sar={"namespace": "project1", "resource": "pods", "verb": "get"}`,
+		},
+		{
+			name:        "custom SAR",
+			sar:         `{"namespace": "project1", "resource": "services", "verb": "list"}`,
+			expectedSAR: `--openshift-sar={"namespace": "project1", "resource": "services", "verb": "list"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := corev1.PodSpec{Containers: []corev1.Container{{Name: "tempo"}}}
+			PatchPodSpec(testTempoStack(test.sar), &pod, 16686)
+
+			require.Len(t, pod.Containers, 2)
+			proxy := pod.Containers[1]
+			assert.Equal(t, "quay.io/openshift/origin-oauth-proxy:4.14", proxy.Image)
+			assert.Contains(t, proxy.Args, "--upstream=http://localhost:16686")
+			assert.Contains(t, proxy.Args, "--openshift-service-account=tempo-test")
+			assert.Contains(t, proxy.Args, test.expectedSAR)
+			assert.Contains(t, proxy.Args, "--cookie-secret-file=/var/run/secrets/oauth-proxy/session_secret")
+			assert.Equal(t, []corev1.Volume{
+				{
+					Name: "oauth-proxy-cookie",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: "tempo-test-query-frontend-oauth-proxy",
+						},
+					},
+				},
+			}, pod.Volumes)
+		})
+	}
+}
+
+func TestBuildSecret(t *testing.T) {
+	secret, err := BuildSecret(manifestutils.Params{Tempo: testTempoStack("")})
+	require.NoError(t, err)
+	assert.Equal(t, "tempo-test-query-frontend-oauth-proxy", secret.Name)
+	assert.Len(t, secret.Data[CookieSecretKey], 24)
+
+	secret, err = BuildSecret(manifestutils.Params{Tempo: testTempoStack(""), OAuthProxyCookieSecret: "existing"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("existing"), secret.Data[CookieSecretKey])
+}
+
+func TestPatchServiceAccount(t *testing.T) {
+	sa := &corev1.ServiceAccount{}
+	PatchServiceAccount(testTempoStack(""), sa)
+	assert.Equal(t, map[string]string{
+		"serviceaccounts.openshift.io/oauth-redirectreference.primary": `{"kind":"OAuthRedirectReference","apiVersion":"v1","reference":{"kind":"Route","name":"tempo-test-query-frontend"}}`,
+	}, sa.Annotations)
+}
@@ -21,5 +21,5 @@ func TestPrometheusRule(t *testing.T) {
 			"openshift.io/prometheus-rule-evaluation-scope": "leaf-prometheus",
 		}),
 	}, prometheusrule.ObjectMeta)
-	assert.Len(t, prometheusrule.Spec.Groups[0].Rules, 5)
+	assert.Len(t, prometheusrule.Spec.Groups[0].Rules, 7)
 }
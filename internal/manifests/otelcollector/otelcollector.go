@@ -0,0 +1,180 @@
+package otelcollector
+
+import (
+	"fmt"
+	"path"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const (
+	componentName = "otel"
+
+	gatewayGRPCPort = 8090
+	tokenFile       = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	caVolumeName    = "tempo-ca"
+	caDir           = "/var/run/tls/tempo-ca"
+)
+
+// CollectorGVK is the GroupVersionKind of the OpenTelemetryCollector resource of the OpenTelemetry Operator.
+var CollectorGVK = schema.GroupVersionKind{Group: "opentelemetry.io", Version: "v1alpha1", Kind: "OpenTelemetryCollector"}
+
+// BuildCollector creates an OpenTelemetryCollector of the OpenTelemetry Operator, which receives OTLP traces
+// and exports them to the gateway or the distributor of the TempoStack.
+// In openshift mode, the service account of the collector is granted the permission to write the traces of the tenant.
+func BuildCollector(params manifestutils.Params) ([]client.Object, error) {
+	tempo := params.Tempo
+	spec := tempo.Spec.OpenTelemetryCollector
+	if spec == nil {
+		return nil, nil
+	}
+
+	exporter := map[string]interface{}{}
+	if spec.Tenant != "" {
+		exporter["headers"] = map[string]interface{}{manifestutils.TenantHeader: spec.Tenant}
+	}
+	cfg := map[string]interface{}{
+		"receivers": map[string]interface{}{
+			"otlp": map[string]interface{}{
+				"protocols": map[string]interface{}{
+					"grpc": nil,
+					"http": nil,
+				},
+			},
+		},
+		"processors": map[string]interface{}{
+			"batch": nil,
+		},
+		"exporters": map[string]interface{}{
+			"otlp": exporter,
+		},
+	}
+	service := map[string]interface{}{
+		"pipelines": map[string]interface{}{
+			"traces": map[string]interface{}{
+				"receivers":  []interface{}{"otlp"},
+				"processors": []interface{}{"batch"},
+				"exporters":  []interface{}{"otlp"},
+			},
+		},
+	}
+	collectorSpec := map[string]interface{}{
+		"mode": "deployment",
+	}
+
+	var objs []client.Object
+	openShiftMode := tempo.Spec.Tenants != nil && tempo.Spec.Tenants.Mode == v1alpha1.ModeOpenShift
+	if tempo.Spec.Template.Gateway.Enabled {
+		exporter["endpoint"] = fmt.Sprintf("%s:%d", naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.GatewayComponentName), gatewayGRPCPort)
+		exporter["tls"] = map[string]interface{}{"insecure": true}
+
+		if openShiftMode {
+			// The gateway authenticates the collector with the token of its service account.
+			cfg["extensions"] = map[string]interface{}{
+				"bearertokenauth": map[string]interface{}{"filename": tokenFile},
+			}
+			service["extensions"] = []interface{}{"bearertokenauth"}
+			exporter["auth"] = map[string]interface{}{"authenticator": "bearertokenauth"}
+			if params.Gates.OpenShift.ServingCertsService {
+				exporter["tls"] = map[string]interface{}{"ca_file": manifestutils.ServiceAccountServiceCAFile}
+			}
+
+			objs = append(objs, clusterRole(tempo), clusterRoleBinding(tempo))
+		}
+	} else {
+		grpcPort, _ := manifestutils.OTLPReceiverPorts(tempo)
+		exporter["endpoint"] = fmt.Sprintf("%s:%d", naming.ServiceFqdn(tempo.Namespace, tempo.Name, manifestutils.DistributorComponentName), grpcPort)
+		exporter["tls"] = map[string]interface{}{"insecure": true}
+
+		if tempo.Spec.Template.Distributor.TLS.Enabled {
+			caName := spec.CA
+			if caName == "" {
+				caName = naming.SigningCABundleName(tempo.Name)
+			}
+			exporter["tls"] = map[string]interface{}{"ca_file": path.Join(caDir, "service-ca.crt")}
+			collectorSpec["volumes"] = []interface{}{
+				map[string]interface{}{
+					"name":      caVolumeName,
+					"configMap": map[string]interface{}{"name": caName},
+				},
+			}
+			collectorSpec["volumeMounts"] = []interface{}{
+				map[string]interface{}{
+					"name":      caVolumeName,
+					"mountPath": caDir,
+					"readOnly":  true,
+				},
+			}
+		}
+	}
+
+	cfg["service"] = service
+	config, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the OpenTelemetry Collector configuration, err: %w", err)
+	}
+	collectorSpec["config"] = string(config)
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(CollectorGVK)
+	obj.SetName(naming.Name(componentName, tempo.Name))
+	obj.SetNamespace(tempo.Namespace)
+	obj.SetLabels(manifestutils.ComponentLabels(componentName, tempo.Name))
+	obj.Object["spec"] = collectorSpec
+
+	return append([]client.Object{obj}, objs...), nil
+}
+
+// serviceAccountName returns the name of the service account which the OpenTelemetry Operator creates for the collector.
+func serviceAccountName(tempo v1alpha1.TempoStack) string {
+	return fmt.Sprintf("%s-collector", naming.Name(componentName, tempo.Name))
+}
+
+// clusterRole grants the permission to write the traces of the tenant of the collector.
+// The gateway sends the tenant name as resource and traces as resource name in the SubjectAccessReview.
+func clusterRole(tempo v1alpha1.TempoStack) *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   naming.Name(componentName, tempo.Name),
+			Labels: manifestutils.ComponentLabels(componentName, tempo.Name),
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{v1alpha1.GroupVersion.Group},
+				Resources:     []string{tempo.Spec.OpenTelemetryCollector.Tenant},
+				ResourceNames: []string{"traces"},
+				Verbs:         []string{"create"},
+			},
+		},
+	}
+}
+
+func clusterRoleBinding(tempo v1alpha1.TempoStack) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   naming.Name(componentName, tempo.Name),
+			Labels: manifestutils.ComponentLabels(componentName, tempo.Name),
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      serviceAccountName(tempo),
+				Namespace: tempo.Namespace,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			Kind:     "ClusterRole",
+			Name:     naming.Name(componentName, tempo.Name),
+			APIGroup: "rbac.authorization.k8s.io",
+		},
+	}
+}
@@ -0,0 +1,161 @@
+package otelcollector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func collectorConfig(t *testing.T, obj *unstructured.Unstructured) map[string]interface{} {
+	config, _, err := unstructured.NestedString(obj.Object, "spec", "config")
+	require.NoError(t, err)
+
+	cfg := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(config), &cfg))
+	return cfg
+}
+
+func TestBuildCollector(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+	}
+
+	objs, err := BuildCollector(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+	assert.Empty(t, objs)
+
+	tempo.Spec.OpenTelemetryCollector = &v1alpha1.OpenTelemetryCollectorSpec{}
+	objs, err = BuildCollector(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+
+	obj := objs[0].(*unstructured.Unstructured)
+	assert.Equal(t, CollectorGVK, obj.GroupVersionKind())
+	assert.Equal(t, "tempo-simplest-otel", obj.GetName())
+	assert.Equal(t, "observability", obj.GetNamespace())
+	assert.Equal(t, "simplest", obj.GetLabels()["app.kubernetes.io/instance"])
+	assert.Equal(t, map[string]interface{}{
+		"otlp": map[string]interface{}{
+			"endpoint": "tempo-simplest-distributor.observability.svc.cluster.local:4317",
+			"tls":      map[string]interface{}{"insecure": true},
+		},
+	}, collectorConfig(t, obj)["exporters"])
+}
+
+func TestBuildCollector_ReceiversTLS(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       v1alpha1.OpenTelemetryCollectorSpec
+		expectedCA string
+	}{
+		{
+			name:       "operator CA",
+			expectedCA: "tempo-simplest-ca-bundle",
+		},
+		{
+			name:       "custom CA",
+			spec:       v1alpha1.OpenTelemetryCollectorSpec{CA: "custom-ca"},
+			expectedCA: "custom-ca",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := v1alpha1.TempoStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "simplest",
+					Namespace: "observability",
+				},
+				Spec: v1alpha1.TempoStackSpec{
+					OpenTelemetryCollector: &test.spec,
+				},
+			}
+			tempo.Spec.Template.Distributor.TLS.Enabled = true
+
+			objs, err := BuildCollector(manifestutils.Params{Tempo: tempo})
+			require.NoError(t, err)
+			require.Len(t, objs, 1)
+
+			obj := objs[0].(*unstructured.Unstructured)
+			assert.Equal(t, map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"endpoint": "tempo-simplest-distributor.observability.svc.cluster.local:4317",
+					"tls":      map[string]interface{}{"ca_file": "/var/run/tls/tempo-ca/service-ca.crt"},
+				},
+			}, collectorConfig(t, obj)["exporters"])
+			assert.Equal(t, []interface{}{
+				map[string]interface{}{
+					"name":      "tempo-ca",
+					"configMap": map[string]interface{}{"name": test.expectedCA},
+				},
+			}, obj.Object["spec"].(map[string]interface{})["volumes"])
+		})
+	}
+}
+
+func TestBuildCollector_OpenShift(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Tenants: &v1alpha1.TenantsSpec{
+				Mode: v1alpha1.ModeOpenShift,
+				Authentication: []v1alpha1.AuthenticationSpec{
+					{TenantName: "dev", TenantID: "1610b0c3-c509-4592-a256-a1871353dbfa"},
+				},
+			},
+			OpenTelemetryCollector: &v1alpha1.OpenTelemetryCollectorSpec{Tenant: "dev"},
+		},
+	}
+	tempo.Spec.Template.Gateway.Enabled = true
+
+	objs, err := BuildCollector(manifestutils.Params{
+		Tempo: tempo,
+		Gates: configv1alpha1.FeatureGates{
+			OpenShift: configv1alpha1.OpenShiftFeatureGates{ServingCertsService: true},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, objs, 3)
+
+	cfg := collectorConfig(t, objs[0].(*unstructured.Unstructured))
+	assert.Equal(t, map[string]interface{}{
+		"otlp": map[string]interface{}{
+			"endpoint": "tempo-simplest-gateway.observability.svc.cluster.local:8090",
+			"tls":      map[string]interface{}{"ca_file": "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"},
+			"auth":     map[string]interface{}{"authenticator": "bearertokenauth"},
+			"headers":  map[string]interface{}{"x-scope-orgid": "dev"},
+		},
+	}, cfg["exporters"])
+	assert.Equal(t, []interface{}{"bearertokenauth"}, cfg["service"].(map[string]interface{})["extensions"])
+
+	clusterRole := objs[1].(*rbacv1.ClusterRole)
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{
+			APIGroups:     []string{"tempo.grafana.com"},
+			Resources:     []string{"dev"},
+			ResourceNames: []string{"traces"},
+			Verbs:         []string{"create"},
+		},
+	}, clusterRole.Rules)
+
+	clusterRoleBinding := objs[2].(*rbacv1.ClusterRoleBinding)
+	assert.Equal(t, []rbacv1.Subject{
+		{Kind: "ServiceAccount", Name: "tempo-simplest-otel-collector", Namespace: "observability"},
+	}, clusterRoleBinding.Subjects)
+	assert.Equal(t, clusterRole.Name, clusterRoleBinding.RoleRef.Name)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/memberlist"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
+	"github.com/grafana/tempo-operator/internal/manifests/spiffe"
 )
 
 // BuildQuerier creates querier objects.
@@ -29,7 +30,15 @@ func BuildQuerier(params manifestutils.Params) ([]client.Object, error) {
 	gates := params.Gates
 	tempo := params.Tempo
 
-	if gates.HTTPEncryption || gates.GRPCEncryption {
+	manifestutils.ConfigureRuntimeOverrides(tempo, &d.Spec.Template.Spec)
+	manifestutils.ConfigureMemberlist(tempo, &d.Spec.Template.Spec)
+	manifestutils.ConfigureKVStore(tempo, &d.Spec.Template.Spec)
+
+	if (gates.HTTPEncryption || gates.GRPCEncryption) && tempo.Spec.SPIFFE != nil {
+		if err := spiffe.ConfigurePodSpec(tempo, &d.Spec.Template.Spec); err != nil {
+			return nil, err
+		}
+	} else if gates.HTTPEncryption || gates.GRPCEncryption {
 		caBundleName := naming.SigningCABundleName(tempo.Name)
 		if err := manifestutils.ConfigureServiceCA(&d.Spec.Template.Spec, caBundleName); err != nil {
 			return nil, err
@@ -40,14 +49,22 @@ func BuildQuerier(params manifestutils.Params) ([]client.Object, error) {
 		}
 	}
 
-	return []client.Object{d, service(tempo)}, nil
+	objs := []client.Object{d, service(tempo)}
+	if autoscaling := tempo.Spec.Template.Querier.Autoscaling; autoscaling != nil {
+		objs = append(objs, manifestutils.Autoscaler(tempo, manifestutils.QuerierComponentName, *autoscaling))
+	}
+	if manifestutils.MultipleReplicas(d.Spec.Replicas, tempo.Spec.Template.Querier.Autoscaling) {
+		objs = append(objs, manifestutils.PodDisruptionBudget(tempo, manifestutils.QuerierComponentName, tempo.Spec.Template.Querier.PodDisruptionBudget))
+	}
+	return objs, nil
 }
 
 func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 	tempo := params.Tempo
+	httpPort, _ := manifestutils.ServerPorts(tempo)
 	labels := manifestutils.ComponentLabels(manifestutils.QuerierComponentName, tempo.Name)
-	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum)
-	cfg := tempo.Spec.Template.Querier
+	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum, params.Tempo.Annotations[manifestutils.CertRotationRequiredAtAnnotation])
+	cfg := tempo.Spec.Template.Querier.TempoComponentSpec
 
 	d := &v1.Deployment{
 		TypeMeta: metav1.TypeMeta{
@@ -64,14 +81,16 @@ func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      k8slabels.Merge(labels, memberlist.GossipSelector),
-					Annotations: annotations,
+					Labels:      manifestutils.PodLabels(cfg.PodLabels, k8slabels.Merge(labels, memberlist.GossipSelector)),
+					Annotations: manifestutils.PodAnnotations(cfg.PodAnnotations, annotations),
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: tempo.Spec.ServiceAccount,
-					NodeSelector:       cfg.NodeSelector,
-					Tolerations:        cfg.Tolerations,
-					Affinity:           manifestutils.DefaultAffinity(labels),
+					ServiceAccountName:        tempo.Spec.ServiceAccount,
+					NodeSelector:              cfg.NodeSelector,
+					Tolerations:               cfg.Tolerations,
+					TopologySpreadConstraints: manifestutils.TopologySpreadConstraints(cfg.TopologySpreadConstraints, labels),
+					PriorityClassName:         cfg.PriorityClassName,
+					Affinity:                  manifestutils.Affinity(cfg.Affinity, labels),
 					Containers: []corev1.Container{
 						{
 							Name:  "tempo",
@@ -79,17 +98,17 @@ func deployment(params manifestutils.Params) (*v1.Deployment, error) {
 							Args: []string{
 								"-target=querier",
 								"-config.file=/conf/tempo.yaml",
-								"-log.level=info",
+								manifestutils.LogLevelArg(tempo, cfg),
 							},
 							Ports: []corev1.ContainerPort{
 								{
 									Name:          manifestutils.HttpPortName,
-									ContainerPort: manifestutils.PortHTTPServer,
+									ContainerPort: httpPort,
 									Protocol:      corev1.ProtocolTCP,
 								},
 								{
 									Name:          manifestutils.HttpMemberlistPortName,
-									ContainerPort: manifestutils.PortMemberlist,
+									ContainerPort: manifestutils.MemberlistPort(tempo),
 									Protocol:      corev1.ProtocolTCP,
 								},
 							},
@@ -143,9 +162,10 @@ func service(tempo v1alpha1.TempoStack) *corev1.Service {
 	labels := manifestutils.ComponentLabels(manifestutils.QuerierComponentName, tempo.Name)
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      naming.Name(manifestutils.QuerierComponentName, tempo.Name),
-			Namespace: tempo.Namespace,
-			Labels:    labels,
+			Name:        naming.Name(manifestutils.QuerierComponentName, tempo.Name),
+			Namespace:   tempo.Namespace,
+			Labels:      manifestutils.ServiceLabels(tempo.Spec.Template.Querier.ServiceLabels, labels),
+			Annotations: manifestutils.ServiceAnnotations(tempo.Spec.Template.Querier.ServiceAnnotations, nil),
 		},
 		Spec: corev1.ServiceSpec{
 			Ports: []corev1.ServicePort{
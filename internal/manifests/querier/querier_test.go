@@ -29,11 +29,13 @@ func TestBuildQuerier(t *testing.T) {
 			},
 			ServiceAccount: "tempo-test-serviceaccount",
 			Template: v1alpha1.TempoTemplateSpec{
-				Querier: v1alpha1.TempoComponentSpec{
-					NodeSelector: map[string]string{"a": "b"},
-					Tolerations: []corev1.Toleration{
-						{
-							Key: "c",
+				Querier: v1alpha1.TempoQuerierSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						NodeSelector: map[string]string{"a": "b"},
+						Tolerations: []corev1.Toleration{
+							{
+								Key: "c",
+							},
 						},
 					},
 				},
@@ -51,7 +53,7 @@ func TestBuildQuerier(t *testing.T) {
 	require.NoError(t, err)
 
 	labels := manifestutils.ComponentLabels("querier", "test")
-	annotations := manifestutils.CommonAnnotations("")
+	annotations := manifestutils.CommonAnnotations("", "")
 	assert.Equal(t, 2, len(objects))
 
 	assert.Equal(t, &corev1.Service{
@@ -191,3 +193,138 @@ func TestBuildQuerier(t *testing.T) {
 		},
 	}, objects[0])
 }
+
+func TestBuildQuerier_CertRotation(t *testing.T) {
+	objects, err := BuildQuerier(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+			Annotations: map[string]string{
+				"tempo.grafana.com/certRotationRequiredAt": "2023-10-01T00:00:00Z",
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	deployment := objects[0].(*v1.Deployment)
+	assert.Equal(t, "2023-10-01T00:00:00Z", deployment.Spec.Template.Annotations["tempo.grafana.com/certRotationRequiredAt"])
+}
+
+func TestBuildQuerier_LogLevel(t *testing.T) {
+	objects, err := BuildQuerier(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Observability: v1alpha1.ObservabilitySpec{
+				Logging: v1alpha1.LoggingConfigSpec{Level: v1alpha1.LogLevelWarn},
+			},
+			Template: v1alpha1.TempoTemplateSpec{
+				Querier: v1alpha1.TempoQuerierSpec{
+					TempoComponentSpec: v1alpha1.TempoComponentSpec{
+						LogLevel: v1alpha1.LogLevelDebug,
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	deployment := objects[0].(*v1.Deployment)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Args, "-log.level=debug")
+	assert.NotContains(t, deployment.Spec.Template.Spec.Containers[0].Args, "-log.level=warn")
+}
+
+func TestBuildQuerier_Autoscaling(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Querier: v1alpha1.TempoQuerierSpec{
+					Autoscaling: &v1alpha1.AutoscalingSpec{MaxReplicas: 3},
+				},
+			},
+		},
+	}
+	objects, err := BuildQuerier(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+
+	require.Len(t, objects, 4)
+	assert.Nil(t, objects[0].(*v1.Deployment).Spec.Replicas)
+	assert.Equal(t, manifestutils.HorizontalPodAutoscaler(tempo, manifestutils.QuerierComponentName, *tempo.Spec.Template.Querier.Autoscaling), objects[2])
+	assert.Equal(t, manifestutils.PodDisruptionBudget(tempo, manifestutils.QuerierComponentName, nil), objects[3])
+}
+
+func TestBuildQuerier_PodMetadata(t *testing.T) {
+	objects, err := BuildQuerier(manifestutils.Params{
+		ConfigChecksum: "abc",
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Template: v1alpha1.TempoTemplateSpec{
+					Querier: v1alpha1.TempoQuerierSpec{
+						TempoComponentSpec: v1alpha1.TempoComponentSpec{
+							PodLabels: map[string]string{
+								"cost-center":                 "tracing",
+								"app.kubernetes.io/component": "custom",
+							},
+							PodAnnotations: map[string]string{
+								"sidecar.istio.io/inject":       "true",
+								"tempo.grafana.com/config.hash": "custom",
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	d := objects[0].(*v1.Deployment)
+	assert.Equal(t, "tracing", d.Spec.Template.Labels["cost-center"])
+	assert.Equal(t, manifestutils.QuerierComponentName, d.Spec.Template.Labels["app.kubernetes.io/component"])
+	assert.Equal(t, map[string]string{
+		"sidecar.istio.io/inject":       "true",
+		"tempo.grafana.com/config.hash": "abc",
+	}, d.Spec.Template.Annotations)
+}
+
+func TestBuildQuerier_ServiceMetadata(t *testing.T) {
+	objects, err := BuildQuerier(manifestutils.Params{
+		Tempo: v1alpha1.TempoStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "project1",
+			},
+			Spec: v1alpha1.TempoStackSpec{
+				Template: v1alpha1.TempoTemplateSpec{
+					Querier: v1alpha1.TempoQuerierSpec{
+						TempoComponentSpec: v1alpha1.TempoComponentSpec{
+							ServiceLabels: map[string]string{
+								"cost-center":                 "tracing",
+								"app.kubernetes.io/component": "custom",
+							},
+							ServiceAnnotations: map[string]string{
+								"service.kubernetes.io/topology-mode": "Auto",
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	svc := objects[1].(*corev1.Service)
+	assert.Equal(t, "tracing", svc.Labels["cost-center"])
+	assert.Equal(t, manifestutils.QuerierComponentName, svc.Labels["app.kubernetes.io/component"])
+	assert.Equal(t, map[string]string{"service.kubernetes.io/topology-mode": "Auto"}, svc.Annotations)
+	assert.Equal(t, map[string]string(manifestutils.ComponentLabels(manifestutils.QuerierComponentName, "test")), svc.Spec.Selector)
+}
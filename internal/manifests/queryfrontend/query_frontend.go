@@ -19,6 +19,8 @@ import (
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 	"github.com/grafana/tempo-operator/internal/manifests/memberlist"
 	"github.com/grafana/tempo-operator/internal/manifests/naming"
+	"github.com/grafana/tempo-operator/internal/manifests/oauthproxy"
+	"github.com/grafana/tempo-operator/internal/manifests/spiffe"
 )
 
 const (
@@ -32,6 +34,14 @@ const (
 	portJaegerMetrics     = 16687
 
 	thanosQuerierOpenShiftMonitoring = "https://thanos-querier.openshift-monitoring.svc.cluster.local:9091"
+
+	// metricsGeneratorSpanMetricsNamespace is the prefix of the span RED metrics of the metrics-generator.
+	metricsGeneratorSpanMetricsNamespace = "traces_spanmetrics"
+
+	prometheusTokenVolumeName = "prometheus-token"
+	prometheusCAVolumeName    = "prometheus-ca"
+	prometheusTLSVolumeName   = "prometheus-tls"
+	prometheusTokenDir        = "/var/run/tempo-query/prometheus-token"
 )
 
 // BuildQueryFrontend creates the query-frontend objects.
@@ -50,7 +60,15 @@ func BuildQueryFrontend(params manifestutils.Params) ([]client.Object, error) {
 	gates := params.Gates
 	tempo := params.Tempo
 
-	if gates.HTTPEncryption || gates.GRPCEncryption {
+	manifestutils.ConfigureRuntimeOverrides(tempo, &d.Spec.Template.Spec)
+	manifestutils.ConfigureMemberlist(tempo, &d.Spec.Template.Spec)
+	manifestutils.ConfigureKVStore(tempo, &d.Spec.Template.Spec)
+
+	if (gates.HTTPEncryption || gates.GRPCEncryption) && tempo.Spec.SPIFFE != nil {
+		if err := spiffe.ConfigurePodSpec(tempo, &d.Spec.Template.Spec, 0, 1); err != nil {
+			return nil, err
+		}
+	} else if gates.HTTPEncryption || gates.GRPCEncryption {
 		caBundleName := naming.SigningCABundleName(tempo.Name)
 		if err := manifestutils.ConfigureServiceCA(&d.Spec.Template.Spec, caBundleName, 0, 1); err != nil {
 			return nil, err
@@ -62,6 +80,15 @@ func BuildQueryFrontend(params manifestutils.Params) ([]client.Object, error) {
 		}
 	}
 
+	if oauthproxy.Enabled(tempo) {
+		oauthproxy.PatchPodSpec(tempo, &d.Spec.Template.Spec, portJaegerUI)
+		secret, err := oauthproxy.BuildSecret(params)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, secret)
+	}
+
 	manifests = append(manifests, d)
 
 	svcs := services(tempo)
@@ -75,16 +102,19 @@ func BuildQueryFrontend(params manifestutils.Params) ([]client.Object, error) {
 		case v1alpha1.IngressTypeIngress:
 			manifests = append(manifests, ingress(tempo))
 		case v1alpha1.IngressTypeRoute:
-			routeObj, err := route(tempo)
+			routeObj, err := route(params)
 			if err != nil {
 				return nil, err
 			}
 			manifests = append(manifests, routeObj)
+			for _, additionalRoute := range manifestutils.AdditionalRoutes(routeObj, tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress, params.RouteHostCertificates) {
+				manifests = append(manifests, additionalRoute)
+			}
 		}
 	}
 
 	if tempo.Spec.Template.QueryFrontend.JaegerQuery.Enabled && tempo.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab.Enabled &&
-		tempo.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab.PrometheusEndpoint == thanosQuerierOpenShiftMonitoring {
+		monitorTabPrometheusEndpoint(tempo) == thanosQuerierOpenShiftMonitoring {
 		clusterRoleBinding := openShiftMonitoringClusterRoleBinding(tempo)
 		manifests = append(manifests, &clusterRoleBinding)
 	}
@@ -94,8 +124,9 @@ func BuildQueryFrontend(params manifestutils.Params) ([]client.Object, error) {
 
 func deployment(params manifestutils.Params) (*appsv1.Deployment, error) {
 	tempo := params.Tempo
+	httpPort, grpcPort := manifestutils.ServerPorts(tempo)
 	labels := manifestutils.ComponentLabels(manifestutils.QueryFrontendComponentName, tempo.Name)
-	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum)
+	annotations := manifestutils.CommonAnnotations(params.ConfigChecksum, params.Tempo.Annotations[manifestutils.CertRotationRequiredAtAnnotation])
 	cfg := tempo.Spec.Template.QueryFrontend
 
 	d := &appsv1.Deployment{
@@ -113,14 +144,16 @@ func deployment(params manifestutils.Params) (*appsv1.Deployment, error) {
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      k8slabels.Merge(labels, memberlist.GossipSelector),
-					Annotations: annotations,
+					Labels:      manifestutils.PodLabels(cfg.PodLabels, k8slabels.Merge(labels, memberlist.GossipSelector)),
+					Annotations: manifestutils.PodAnnotations(cfg.PodAnnotations, annotations),
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: tempo.Spec.ServiceAccount,
-					NodeSelector:       cfg.NodeSelector,
-					Tolerations:        cfg.Tolerations,
-					Affinity:           manifestutils.DefaultAffinity(labels),
+					ServiceAccountName:        tempo.Spec.ServiceAccount,
+					NodeSelector:              cfg.NodeSelector,
+					Tolerations:               cfg.Tolerations,
+					TopologySpreadConstraints: manifestutils.TopologySpreadConstraints(cfg.TopologySpreadConstraints, labels),
+					PriorityClassName:         cfg.PriorityClassName,
+					Affinity:                  manifestutils.Affinity(cfg.Affinity, labels),
 					Containers: []corev1.Container{
 						{
 							Name:  "tempo",
@@ -129,17 +162,17 @@ func deployment(params manifestutils.Params) (*appsv1.Deployment, error) {
 								"-target=query-frontend",
 								"-config.file=/conf/tempo-query-frontend.yaml",
 								"-mem-ballast-size-mbs=1024",
-								"-log.level=info",
+								manifestutils.LogLevelArg(tempo, cfg.TempoComponentSpec),
 							},
 							Ports: []corev1.ContainerPort{
 								{
 									Name:          manifestutils.HttpPortName,
-									ContainerPort: manifestutils.PortHTTPServer,
+									ContainerPort: httpPort,
 									Protocol:      corev1.ProtocolTCP,
 								},
 								{
 									Name:          manifestutils.GrpcPortName,
-									ContainerPort: manifestutils.PortGRPCServer,
+									ContainerPort: grpcPort,
 									Protocol:      corev1.ProtocolTCP,
 								},
 							},
@@ -187,7 +220,7 @@ func deployment(params manifestutils.Params) (*appsv1.Deployment, error) {
 			Name:  "tempo-query",
 			Image: tempo.Spec.Images.TempoQuery,
 			Args: []string{
-				"--query.base-path=/",
+				fmt.Sprintf("--query.base-path=%s", manifestutils.IngressPath(tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress)),
 				"--grpc-storage-plugin.configuration-file=/conf/tempo-query.yaml",
 				"--query.bearer-token-propagation=true",
 			},
@@ -255,11 +288,12 @@ func deployment(params manifestutils.Params) (*appsv1.Deployment, error) {
 		}
 
 		if tempo.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab.Enabled {
-			c, err := enableMonitoringTab(tempo, jaegerQueryContainer)
+			c, volumes, err := enableMonitoringTab(tempo, jaegerQueryContainer)
 			if err != nil {
 				return nil, fmt.Errorf("failed to configure monitor tab in tempo-query container: %w", err)
 			}
 			jaegerQueryContainer = c
+			d.Spec.Template.Spec.Volumes = append(d.Spec.Template.Spec.Volumes, volumes...)
 		}
 
 		d.Spec.Template.Spec.Containers = append(d.Spec.Template.Spec.Containers, jaegerQueryContainer)
@@ -273,8 +307,10 @@ func deployment(params manifestutils.Params) (*appsv1.Deployment, error) {
 	return d, nil
 }
 
-func enableMonitoringTab(tempo v1alpha1.TempoStack, jaegerQueryContainer corev1.Container) (corev1.Container, error) {
+func enableMonitoringTab(tempo v1alpha1.TempoStack, jaegerQueryContainer corev1.Container) (corev1.Container, []corev1.Volume, error) {
 	// TODO (pavolloffay) disable/enable monitoring tab https://github.com/grafana/tempo-operator/issues/464
+	monitorTab := tempo.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab
+	prometheusEndpoint := monitorTabPrometheusEndpoint(tempo)
 	container := corev1.Container{
 		Env: []corev1.EnvVar{
 			{
@@ -283,19 +319,37 @@ func enableMonitoringTab(tempo v1alpha1.TempoStack, jaegerQueryContainer corev1.
 			},
 			{
 				Name:  "PROMETHEUS_SERVER_URL",
-				Value: tempo.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab.PrometheusEndpoint,
+				Value: prometheusEndpoint,
 			},
 		},
-		Args: []string{
+	}
+
+	redMetricsNamespace := ""
+	if spanMetricsFromMetricsGenerator(tempo) {
+		// The metrics-generator exposes traces_spanmetrics_calls_total and traces_spanmetrics_latency (in seconds).
+		redMetricsNamespace = metricsGeneratorSpanMetricsNamespace
+		container.Args = append(container.Args,
+			"--prometheus.query.duration-unit=s",
+			"--prometheus.query.normalize-calls=true",
+		)
+	} else {
+		container.Args = append(container.Args,
 			"--prometheus.query.support-spanmetrics-connector",
 			// Just a note that normalization needs to be enabled for < 0.80.0 OTEL collector versions
 			// However, we do not intend to support them.
 			// --prometheus.query.normalize-calls
 			// --prometheus.query.normalize-duration
-		},
+		)
+	}
+	if monitorTab.REDMetricsNamespace != nil {
+		redMetricsNamespace = *monitorTab.REDMetricsNamespace
 	}
+	if redMetricsNamespace != "" {
+		container.Args = append(container.Args, fmt.Sprintf("--prometheus.query.namespace=%s", redMetricsNamespace))
+	}
+
+	var volumes []corev1.Volume
 	// If the endpoint matches Prometheus on OpenShift, configure TLS and token based auth
-	prometheusEndpoint := strings.TrimSpace(tempo.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab.PrometheusEndpoint)
 	if prometheusEndpoint == thanosQuerierOpenShiftMonitoring {
 		container.Args = append(container.Args,
 			"--prometheus.tls.enabled=true",
@@ -304,13 +358,118 @@ func enableMonitoringTab(tempo v1alpha1.TempoStack, jaegerQueryContainer corev1.
 			"--prometheus.token-file=/var/run/secrets/kubernetes.io/serviceaccount/token",
 			"--prometheus.token-override-from-context=false",
 			"--prometheus.tls.ca=/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt")
+	} else {
+		volumes = configurePrometheusClient(monitorTab, &container)
 	}
 
 	err := mergo.Merge(&jaegerQueryContainer, container, mergo.WithAppendSlice)
 	if err != nil {
-		return corev1.Container{}, err
+		return corev1.Container{}, nil, err
 	}
-	return jaegerQueryContainer, nil
+	return jaegerQueryContainer, volumes, nil
+}
+
+// configurePrometheusClient mounts the bearer token, CA bundle and client certificate
+// of the Prometheus endpoint and configures the tempo-query container to use them.
+func configurePrometheusClient(monitorTab v1alpha1.JaegerQueryMonitor, container *corev1.Container) []corev1.Volume {
+	var volumes []corev1.Volume
+
+	if monitorTab.BearerTokenSecret != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: prometheusTokenVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: monitorTab.BearerTokenSecret,
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      prometheusTokenVolumeName,
+			MountPath: prometheusTokenDir,
+			ReadOnly:  true,
+		})
+		container.Args = append(container.Args,
+			fmt.Sprintf("--prometheus.token-file=%s/token", prometheusTokenDir),
+			"--prometheus.token-override-from-context=false",
+		)
+	}
+
+	tlsSpec := monitorTab.TLS
+	if tlsSpec == nil {
+		return volumes
+	}
+
+	container.Args = append(container.Args, "--prometheus.tls.enabled=true")
+	if tlsSpec.CA != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: prometheusCAVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: tlsSpec.CA,
+					},
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      prometheusCAVolumeName,
+			MountPath: manifestutils.PrometheusCABundleDir(),
+			ReadOnly:  true,
+		})
+		container.Args = append(container.Args, fmt.Sprintf("--prometheus.tls.ca=%s/service-ca.crt", manifestutils.PrometheusCABundleDir()))
+	}
+	if tlsSpec.CertName != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: prometheusTLSVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: tlsSpec.CertName,
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      prometheusTLSVolumeName,
+			MountPath: manifestutils.PrometheusTLSDir(),
+			ReadOnly:  true,
+		})
+		container.Args = append(container.Args,
+			fmt.Sprintf("--prometheus.tls.cert=%s/tls.crt", manifestutils.PrometheusTLSDir()),
+			fmt.Sprintf("--prometheus.tls.key=%s/tls.key", manifestutils.PrometheusTLSDir()),
+		)
+	}
+	if tlsSpec.InsecureSkipVerify {
+		container.Args = append(container.Args, "--prometheus.tls.skip-host-verify=true")
+	}
+	return volumes
+}
+
+// monitorTabPrometheusEndpoint returns the Prometheus endpoint queried by the monitoring tab.
+// It defaults to the Thanos Querier of the OpenShift monitoring stack
+// if the metrics-generator sends its metrics to the OpenShift in-cluster monitoring stack.
+func monitorTabPrometheusEndpoint(tempo v1alpha1.TempoStack) string {
+	endpoint := strings.TrimSpace(tempo.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab.PrometheusEndpoint)
+	if endpoint != "" || !tempo.Spec.Template.MetricsGenerator.Enabled {
+		return endpoint
+	}
+	for _, remoteWrite := range tempo.Spec.Template.MetricsGenerator.RemoteWrite {
+		if remoteWrite.OpenShiftMonitoring {
+			return thanosQuerierOpenShiftMonitoring
+		}
+	}
+	return ""
+}
+
+// spanMetricsFromMetricsGenerator returns true if the span RED metrics are generated by the metrics-generator.
+func spanMetricsFromMetricsGenerator(tempo v1alpha1.TempoStack) bool {
+	if !tempo.Spec.Template.MetricsGenerator.Enabled {
+		return false
+	}
+	for _, processor := range tempo.Spec.Template.MetricsGenerator.Processors {
+		if processor == v1alpha1.MetricsGeneratorProcessorSpanMetrics {
+			return true
+		}
+	}
+	return false
 }
 
 func openShiftMonitoringClusterRoleBinding(tempo v1alpha1.TempoStack) rbacv1.ClusterRoleBinding {
@@ -337,11 +496,13 @@ func openShiftMonitoringClusterRoleBinding(tempo v1alpha1.TempoStack) rbacv1.Clu
 
 func services(tempo v1alpha1.TempoStack) []*corev1.Service {
 	labels := manifestutils.ComponentLabels(manifestutils.QueryFrontendComponentName, tempo.Name)
+	cfg := tempo.Spec.Template.QueryFrontend.TempoComponentSpec
 	frontEndService := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      naming.Name(manifestutils.QueryFrontendComponentName, tempo.Name),
-			Namespace: tempo.Namespace,
-			Labels:    labels,
+			Name:        naming.Name(manifestutils.QueryFrontendComponentName, tempo.Name),
+			Namespace:   tempo.Namespace,
+			Labels:      manifestutils.ServiceLabels(cfg.ServiceLabels, labels),
+			Annotations: manifestutils.ServiceAnnotations(cfg.ServiceAnnotations, nil),
 		},
 		Spec: corev1.ServiceSpec{
 			Ports: []corev1.ServicePort{
@@ -364,9 +525,10 @@ func services(tempo v1alpha1.TempoStack) []*corev1.Service {
 	queryFrontendDiscoveryName := manifestutils.QueryFrontendComponentName + "-discovery"
 	frontEndDiscoveryService := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      naming.Name(queryFrontendDiscoveryName, tempo.Name),
-			Namespace: tempo.Namespace,
-			Labels:    manifestutils.ComponentLabels(queryFrontendDiscoveryName, tempo.Name),
+			Name:        naming.Name(queryFrontendDiscoveryName, tempo.Name),
+			Namespace:   tempo.Namespace,
+			Labels:      manifestutils.ServiceLabels(cfg.ServiceLabels, manifestutils.ComponentLabels(queryFrontendDiscoveryName, tempo.Name)),
+			Annotations: manifestutils.ServiceAnnotations(cfg.ServiceAnnotations, nil),
 		},
 		Spec: corev1.ServiceSpec{
 			ClusterIP: "None",
@@ -420,6 +582,10 @@ func services(tempo v1alpha1.TempoStack) []*corev1.Service {
 
 		frontEndService.Spec.Ports = append(frontEndService.Spec.Ports, jaegerPorts...)
 		frontEndDiscoveryService.Spec.Ports = append(frontEndDiscoveryService.Spec.Ports, jaegerPorts...)
+
+		if oauthproxy.Enabled(tempo) {
+			frontEndService.Spec.Ports = append(frontEndService.Spec.Ports, oauthproxy.ServicePort())
+		}
 	}
 
 	return []*corev1.Service{frontEndService, frontEndDiscoveryService}
@@ -433,7 +599,7 @@ func ingress(tempo v1alpha1.TempoStack) *networkingv1.Ingress {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        queryFrontendName,
 			Namespace:   tempo.Namespace,
-			Labels:      labels,
+			Labels:      manifestutils.IngressLabels(tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress.Labels, labels),
 			Annotations: tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress.Annotations,
 		},
 		Spec: networkingv1.IngressSpec{
@@ -450,64 +616,48 @@ func ingress(tempo v1alpha1.TempoStack) *networkingv1.Ingress {
 		},
 	}
 
-	if tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress.Host == "" {
-		ingress.Spec.DefaultBackend = &backend
-	} else {
-		pathType := networkingv1.PathTypePrefix
-		ingress.Spec.Rules = []networkingv1.IngressRule{
-			{
-				Host: tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress.Host,
-				IngressRuleValue: networkingv1.IngressRuleValue{
-					HTTP: &networkingv1.HTTPIngressRuleValue{
-						Paths: []networkingv1.HTTPIngressPath{
-							{
-								Path:     "/",
-								PathType: &pathType,
-								Backend:  backend,
-							},
-						},
-					},
-				},
-			},
-		}
-	}
+	manifestutils.ConfigureIngressHosts(ingress, tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress, backend)
 
 	return ingress
 }
 
-func route(tempo v1alpha1.TempoStack) (*routev1.Route, error) {
+func route(params manifestutils.Params) (*routev1.Route, error) {
+	tempo := params.Tempo
 	queryFrontendName := naming.Name(manifestutils.QueryFrontendComponentName, tempo.Name)
 	labels := manifestutils.ComponentLabels(manifestutils.QueryFrontendComponentName, tempo.Name)
 
-	var tlsCfg *routev1.TLSConfig
-	switch tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress.Route.Termination {
-	case v1alpha1.TLSRouteTerminationTypeInsecure:
-		// NOTE: insecure, no tls cfg.
-	case v1alpha1.TLSRouteTerminationTypeEdge:
-		tlsCfg = &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge}
-	case v1alpha1.TLSRouteTerminationTypePassthrough:
-		tlsCfg = &routev1.TLSConfig{Termination: routev1.TLSTerminationPassthrough}
-	case v1alpha1.TLSRouteTerminationTypeReencrypt:
-		tlsCfg = &routev1.TLSConfig{Termination: routev1.TLSTerminationReencrypt}
-	default: // NOTE: if unsupported, end here.
-		return nil, fmt.Errorf("unsupported tls termination specified for route")
+	tlsCfg, err := manifestutils.RouteTLSConfig(tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress.Route, params.JaegerQueryRouteCertificates)
+	if err != nil {
+		return nil, err
+	}
+
+	var routePath string
+	if path := manifestutils.IngressPath(tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress); path != "/" {
+		routePath = path
+	}
+
+	// The OAuth proxy forwards the authorized requests to the Jaeger Query UI.
+	targetPort := jaegerUIPortName
+	if oauthproxy.Enabled(tempo) {
+		targetPort = oauthproxy.PortName
 	}
 
 	return &routev1.Route{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        queryFrontendName,
 			Namespace:   tempo.Namespace,
-			Labels:      labels,
+			Labels:      manifestutils.IngressLabels(tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress.Labels, labels),
 			Annotations: tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress.Annotations,
 		},
 		Spec: routev1.RouteSpec{
 			Host: tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress.Host,
+			Path: routePath,
 			To: routev1.RouteTargetReference{
 				Kind: "Service",
 				Name: queryFrontendName,
 			},
 			Port: &routev1.RoutePort{
-				TargetPort: intstr.FromString(jaegerUIPortName),
+				TargetPort: intstr.FromString(targetPort),
 			},
 			TLS: tlsCfg,
 		},
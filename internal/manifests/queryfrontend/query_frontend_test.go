@@ -14,6 +14,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
@@ -118,7 +119,7 @@ func getExpectedFrontendDiscoveryService(withJaeger bool) *corev1.Service {
 
 func getExpectedDeployment(withJaeger bool) *v1.Deployment {
 	labels := manifestutils.ComponentLabels("query-frontend", "test")
-	annotations := manifestutils.CommonAnnotations("")
+	annotations := manifestutils.CommonAnnotations("", "")
 
 	expectedDeployment := &v1.Deployment{
 		TypeMeta: metav1.TypeMeta{
@@ -480,6 +481,91 @@ func TestQueryFrontendJaegerRoute(t *testing.T) {
 	}, objects[3].(*routev1.Route))
 }
 
+func TestQueryFrontendJaegerRouteMetadata(t *testing.T) {
+	objects, err := BuildQueryFrontend(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+					JaegerQuery: v1alpha1.JaegerQuerySpec{
+						Enabled: true,
+						Ingress: v1alpha1.IngressSpec{
+							Type:        v1alpha1.IngressTypeRoute,
+							Host:        "jaeger.example.com",
+							Annotations: map[string]string{"haproxy.router.openshift.io/timeout": "5m"},
+							Labels: map[string]string{
+								"router":                 "external",
+								"app.kubernetes.io/name": "custom",
+							},
+							Route: v1alpha1.RouteSpec{
+								Termination: v1alpha1.TLSRouteTerminationTypeEdge,
+							},
+						},
+					},
+				},
+			},
+		},
+	}})
+
+	require.NoError(t, err)
+	require.Equal(t, 4, len(objects))
+	route := objects[3].(*routev1.Route)
+
+	expectedLabels := manifestutils.ComponentLabels("query-frontend", "test")
+	expectedLabels["router"] = "external"
+	assert.Equal(t, map[string]string(expectedLabels), route.Labels)
+	assert.Equal(t, map[string]string{"haproxy.router.openshift.io/timeout": "5m"}, route.Annotations)
+	assert.Equal(t, "jaeger.example.com", route.Spec.Host)
+}
+
+func TestQueryFrontendJaegerPathPrefix(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+					JaegerQuery: v1alpha1.JaegerQuerySpec{
+						Enabled: true,
+						Ingress: v1alpha1.IngressSpec{
+							Type:          v1alpha1.IngressTypeIngress,
+							Host:          "tempo.example.com",
+							TLSSecretName: "tempo-tls",
+							Path:          "/jaeger",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	objects, err := BuildQueryFrontend(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+	require.Equal(t, 4, len(objects))
+
+	dep := objects[0].(*v1.Deployment)
+	assert.Contains(t, dep.Spec.Template.Spec.Containers[1].Args, "--query.base-path=/jaeger")
+
+	ing := objects[3].(*networkingv1.Ingress)
+	assert.Equal(t, "/jaeger", ing.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.Equal(t, []networkingv1.IngressTLS{{Hosts: []string{"tempo.example.com"}, SecretName: "tempo-tls"}}, ing.Spec.TLS)
+
+	tempo.Spec.Template.QueryFrontend.JaegerQuery.Ingress = v1alpha1.IngressSpec{
+		Type:  v1alpha1.IngressTypeRoute,
+		Path:  "/jaeger/",
+		Route: v1alpha1.RouteSpec{Termination: v1alpha1.TLSRouteTerminationTypeEdge},
+	}
+	objects, err = BuildQueryFrontend(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+	require.Equal(t, 4, len(objects))
+	assert.Equal(t, "/jaeger", objects[3].(*routev1.Route).Spec.Path)
+}
+
 func TestQueryFrontendJaegerTLS(t *testing.T) {
 	objects, err := BuildQueryFrontend(manifestutils.Params{
 		Gates: configv1alpha1.FeatureGates{
@@ -524,10 +610,11 @@ func TestQueryFrontendJaegerTLS(t *testing.T) {
 
 func TestBuildQueryFrontendWithJaegerMonitorTab(t *testing.T) {
 	tests := []struct {
-		name  string
-		tempo v1alpha1.TempoStack
-		args  []string
-		env   []corev1.EnvVar
+		name    string
+		tempo   v1alpha1.TempoStack
+		args    []string
+		env     []corev1.EnvVar
+		volumes []string
 	}{
 		{
 			name: "disabled",
@@ -591,6 +678,61 @@ func TestBuildQueryFrontendWithJaegerMonitorTab(t *testing.T) {
 			args: []string{"--query.base-path=/", "--grpc-storage-plugin.configuration-file=/conf/tempo-query.yaml", "--query.bearer-token-propagation=true", "--prometheus.query.support-spanmetrics-connector", "--prometheus.tls.enabled=true", "--prometheus.token-file=/var/run/secrets/kubernetes.io/serviceaccount/token", "--prometheus.token-override-from-context=false", "--prometheus.tls.ca=/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"},
 			env:  []corev1.EnvVar{{Name: "METRICS_STORAGE_TYPE", Value: "prometheus"}, {Name: "PROMETHEUS_SERVER_URL", Value: "https://thanos-querier.openshift-monitoring.svc.cluster.local:9091"}},
 		},
+		{
+			name: "metrics-generator with OpenShift monitoring",
+			tempo: v1alpha1.TempoStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "simplest",
+				},
+				Spec: v1alpha1.TempoStackSpec{
+					Template: v1alpha1.TempoTemplateSpec{
+						QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+							JaegerQuery: v1alpha1.JaegerQuerySpec{
+								Enabled: true,
+								MonitorTab: v1alpha1.JaegerQueryMonitor{
+									Enabled: true,
+								},
+							},
+						},
+						MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{
+							Enabled:     true,
+							Processors:  []v1alpha1.MetricsGeneratorProcessor{v1alpha1.MetricsGeneratorProcessorSpanMetrics},
+							RemoteWrite: []v1alpha1.RemoteWriteSpec{{OpenShiftMonitoring: true}},
+						},
+					},
+				},
+			},
+			args: []string{"--query.base-path=/", "--grpc-storage-plugin.configuration-file=/conf/tempo-query.yaml", "--query.bearer-token-propagation=true", "--prometheus.query.duration-unit=s", "--prometheus.query.normalize-calls=true", "--prometheus.query.namespace=traces_spanmetrics", "--prometheus.tls.enabled=true", "--prometheus.token-file=/var/run/secrets/kubernetes.io/serviceaccount/token", "--prometheus.token-override-from-context=false", "--prometheus.tls.ca=/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"},
+			env:  []corev1.EnvVar{{Name: "METRICS_STORAGE_TYPE", Value: "prometheus"}, {Name: "PROMETHEUS_SERVER_URL", Value: "https://thanos-querier.openshift-monitoring.svc.cluster.local:9091"}},
+		},
+		{
+			name: "custom prometheus with auth and TLS",
+			tempo: v1alpha1.TempoStack{
+				Spec: v1alpha1.TempoStackSpec{
+					Template: v1alpha1.TempoTemplateSpec{
+						QueryFrontend: v1alpha1.TempoQueryFrontendSpec{
+							JaegerQuery: v1alpha1.JaegerQuerySpec{
+								Enabled: true,
+								MonitorTab: v1alpha1.JaegerQueryMonitor{
+									Enabled:             true,
+									PrometheusEndpoint:  "https://prometheus:9091",
+									REDMetricsNamespace: pointer.String("custom"),
+									BearerTokenSecret:   "prometheus-token",
+									TLS: &v1alpha1.JaegerQueryMonitorTLSSpec{
+										CA:                 "prometheus-ca",
+										CertName:           "prometheus-client",
+										InsecureSkipVerify: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			args:    []string{"--query.base-path=/", "--grpc-storage-plugin.configuration-file=/conf/tempo-query.yaml", "--query.bearer-token-propagation=true", "--prometheus.query.support-spanmetrics-connector", "--prometheus.query.namespace=custom", "--prometheus.token-file=/var/run/tempo-query/prometheus-token/token", "--prometheus.token-override-from-context=false", "--prometheus.tls.enabled=true", "--prometheus.tls.ca=/var/run/ca/prometheus/service-ca.crt", "--prometheus.tls.cert=/var/run/tls/prometheus/tls.crt", "--prometheus.tls.key=/var/run/tls/prometheus/tls.key", "--prometheus.tls.skip-host-verify=true"},
+			env:     []corev1.EnvVar{{Name: "METRICS_STORAGE_TYPE", Value: "prometheus"}, {Name: "PROMETHEUS_SERVER_URL", Value: "https://prometheus:9091"}},
+			volumes: []string{"prometheus-token", "prometheus-ca", "prometheus-tls"},
+		},
 	}
 
 	for _, test := range tests {
@@ -602,8 +744,17 @@ func TestBuildQueryFrontendWithJaegerMonitorTab(t *testing.T) {
 
 			assert.Equal(t, test.args, dep.Spec.Template.Spec.Containers[1].Args)
 			assert.Equal(t, test.env, dep.Spec.Template.Spec.Containers[1].Env)
+			var volumes, volumeMounts []string
+			for _, volume := range dep.Spec.Template.Spec.Volumes {
+				volumes = append(volumes, volume.Name)
+			}
+			for _, volumeMount := range dep.Spec.Template.Spec.Containers[1].VolumeMounts {
+				volumeMounts = append(volumeMounts, volumeMount.Name)
+			}
+			assert.Subset(t, volumes, test.volumes)
+			assert.Subset(t, volumeMounts, test.volumes)
 
-			if test.tempo.Spec.Template.QueryFrontend.JaegerQuery.MonitorTab.PrometheusEndpoint == thanosQuerierOpenShiftMonitoring {
+			if monitorTabPrometheusEndpoint(test.tempo) == thanosQuerierOpenShiftMonitoring {
 				objects, err := BuildQueryFrontend(manifestutils.Params{
 					Tempo: test.tempo,
 				})
@@ -0,0 +1,52 @@
+package securitycontext
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+// ConfigurePods sets the security context of the pods of the Deployments, StatefulSets, CronJobs and Jobs and of their containers,
+// and applies the security context overrides of the component templates.
+// It must be called before the extra containers are added to the pods.
+func ConfigurePods(params manifestutils.Params, objs []client.Object) {
+	for _, obj := range objs {
+		var pod *corev1.PodSpec
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			pod = &o.Spec.Template.Spec
+		case *appsv1.StatefulSet:
+			pod = &o.Spec.Template.Spec
+		case *batchv1.CronJob:
+			pod = &o.Spec.JobTemplate.Spec.Template.Spec
+		case *batchv1.Job:
+			pod = &o.Spec.Template.Spec
+		default:
+			continue
+		}
+
+		pod.SecurityContext = manifestutils.TempoPodSecurityContext(params.Distribution)
+		for i := range pod.Containers {
+			if pod.Containers[i].SecurityContext == nil {
+				pod.Containers[i].SecurityContext = manifestutils.TempoContainerSecurityContext()
+			}
+		}
+
+		spec := manifestutils.ComponentSpec(params.Tempo, obj.GetLabels()["app.kubernetes.io/component"])
+		if spec == nil {
+			continue
+		}
+
+		if spec.PodSecurityContext != nil {
+			pod.SecurityContext = spec.PodSecurityContext.DeepCopy()
+		}
+		if spec.SecurityContext != nil {
+			for i := range pod.Containers {
+				pod.Containers[i].SecurityContext = spec.SecurityContext.DeepCopy()
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package securitycontext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestConfigurePods(t *testing.T) {
+	podSecurityContext := &corev1.PodSecurityContext{RunAsUser: pointer.Int64(2000)}
+	securityContext := &corev1.SecurityContext{Privileged: pointer.Bool(false)}
+
+	tests := []struct {
+		name                       string
+		distribution               string
+		component                  v1alpha1.TempoComponentSpec
+		expectedPodSecurityContext *corev1.PodSecurityContext
+		expectedSecurityContext    *corev1.SecurityContext
+	}{
+		{
+			name: "community",
+			expectedPodSecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot:   pointer.Bool(true),
+				RunAsUser:      pointer.Int64(10001),
+				RunAsGroup:     pointer.Int64(10001),
+				FSGroup:        pointer.Int64(10001),
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+			expectedSecurityContext: manifestutils.TempoContainerSecurityContext(),
+		},
+		{
+			name:         "openshift",
+			distribution: manifestutils.OpenShiftDistribution,
+			expectedPodSecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot:   pointer.Bool(true),
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+			expectedSecurityContext: manifestutils.TempoContainerSecurityContext(),
+		},
+		{
+			name: "overrides",
+			component: v1alpha1.TempoComponentSpec{
+				PodSecurityContext: podSecurityContext,
+				SecurityContext:    securityContext,
+			},
+			expectedPodSecurityContext: podSecurityContext,
+			expectedSecurityContext:    securityContext,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := v1alpha1.TempoStack{}
+			tempo.Spec.Template.Querier.TempoComponentSpec = test.component
+
+			querier := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: manifestutils.ComponentLabels(manifestutils.QuerierComponentName, "test"),
+				},
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{Name: "tempo", SecurityContext: manifestutils.TempoContainerSecurityContext()},
+								{Name: "tempo-query"},
+							},
+						},
+					},
+				},
+			}
+
+			ConfigurePods(manifestutils.Params{Tempo: tempo, Distribution: test.distribution}, []client.Object{querier, &corev1.ConfigMap{}})
+			assert.Equal(t, test.expectedPodSecurityContext, querier.Spec.Template.Spec.SecurityContext)
+			assert.Equal(t, test.expectedSecurityContext, querier.Spec.Template.Spec.Containers[0].SecurityContext)
+			assert.Equal(t, test.expectedSecurityContext, querier.Spec.Template.Spec.Containers[1].SecurityContext)
+		})
+	}
+}
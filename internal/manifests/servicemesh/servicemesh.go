@@ -0,0 +1,79 @@
+package servicemesh
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+const (
+	injectAnnotation               = "sidecar.istio.io/inject"
+	rewriteProbesAnnotation        = "sidecar.istio.io/rewriteAppHTTPProbers"
+	excludeInboundPortsAnnotation  = "traffic.sidecar.istio.io/excludeInboundPorts"
+	excludeOutboundPortsAnnotation = "traffic.sidecar.istio.io/excludeOutboundPorts"
+	proxyConfigAnnotation          = "proxy.istio.io/config"
+
+	// holdApplicationUntilProxyStarts delays the start of the Tempo containers until the sidecar is ready,
+	// otherwise the first connections to the object storage and the other components fail.
+	holdApplicationUntilProxyStarts = `{"holdApplicationUntilProxyStarts": true}`
+)
+
+// Enabled returns true if the TempoStack runs inside a service mesh.
+func Enabled(tempo v1alpha1.TempoStack) bool {
+	return tempo.Spec.ServiceMesh != nil && tempo.Spec.ServiceMesh.Enabled
+}
+
+// FeatureGates returns the feature gates of a TempoStack, including the overrides in the spec of the TempoStack.
+// The TLS encryption between the components is disabled inside a service mesh,
+// because the sidecars encrypt the traffic with mTLS.
+func FeatureGates(fg configv1alpha1.FeatureGates, tempo v1alpha1.TempoStack) configv1alpha1.FeatureGates {
+	fg = v1alpha1.EffectiveFeatureGates(fg, tempo)
+	if !Enabled(tempo) {
+		return fg
+	}
+
+	fg.HTTPEncryption = false
+	fg.GRPCEncryption = false
+	return fg
+}
+
+// ConfigurePods adds the annotations for the sidecar injection to the pods of the Deployments and StatefulSets.
+//
+// The memberlist and gRPC ports bypass the sidecar, because the components connect to each other by pod IP
+// on these ports (gossip, ring clients and the long-lived streams between the queriers and the query-frontend).
+// The HTTP probes are rewritten by the sidecar, so that they keep working with mTLS.
+func ConfigurePods(tempo v1alpha1.TempoStack, objs []client.Object) {
+	if !Enabled(tempo) {
+		return
+	}
+
+	_, grpcPort := manifestutils.ServerPorts(tempo)
+	excludedPorts := fmt.Sprintf("%d,%d", manifestutils.MemberlistPort(tempo), grpcPort)
+
+	for _, obj := range objs {
+		var template *corev1.PodTemplateSpec
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			template = &o.Spec.Template
+		case *appsv1.StatefulSet:
+			template = &o.Spec.Template
+		default:
+			continue
+		}
+
+		if template.Annotations == nil {
+			template.Annotations = map[string]string{}
+		}
+		template.Annotations[injectAnnotation] = "true"
+		template.Annotations[rewriteProbesAnnotation] = "true"
+		template.Annotations[excludeInboundPortsAnnotation] = excludedPorts
+		template.Annotations[excludeOutboundPortsAnnotation] = excludedPorts
+		template.Annotations[proxyConfigAnnotation] = holdApplicationUntilProxyStarts
+	}
+}
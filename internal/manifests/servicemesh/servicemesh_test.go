@@ -0,0 +1,83 @@
+package servicemesh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestFeatureGates(t *testing.T) {
+	gates := configv1alpha1.FeatureGates{HTTPEncryption: true, GRPCEncryption: true, PrometheusOperator: true}
+
+	assert.Equal(t, gates, FeatureGates(gates, v1alpha1.TempoStack{}))
+	assert.Equal(t, configv1alpha1.FeatureGates{PrometheusOperator: true}, FeatureGates(gates, v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{ServiceMesh: &v1alpha1.ServiceMeshSpec{Enabled: true}},
+	}))
+	assert.Equal(t, configv1alpha1.FeatureGates{GRPCEncryption: true, PrometheusOperator: true}, FeatureGates(gates, v1alpha1.TempoStack{
+		Spec: v1alpha1.TempoStackSpec{FeatureGates: &v1alpha1.FeatureGatesSpec{HTTPEncryption: pointer.Bool(false)}},
+	}))
+}
+
+func TestConfigurePods(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     v1alpha1.TempoStackSpec
+		expected map[string]string
+	}{
+		{
+			name:     "disabled",
+			expected: map[string]string{"tempo.grafana.com/config.hash": "abc"},
+		},
+		{
+			name: "enabled",
+			spec: v1alpha1.TempoStackSpec{ServiceMesh: &v1alpha1.ServiceMeshSpec{Enabled: true}},
+			expected: map[string]string{
+				"tempo.grafana.com/config.hash":                 "abc",
+				"sidecar.istio.io/inject":                       "true",
+				"sidecar.istio.io/rewriteAppHTTPProbers":        "true",
+				"traffic.sidecar.istio.io/excludeInboundPorts":  "7946,9095",
+				"traffic.sidecar.istio.io/excludeOutboundPorts": "7946,9095",
+				"proxy.istio.io/config":                         `{"holdApplicationUntilProxyStarts": true}`,
+			},
+		},
+		{
+			name: "custom gRPC port",
+			spec: v1alpha1.TempoStackSpec{
+				ServiceMesh: &v1alpha1.ServiceMeshSpec{Enabled: true},
+				Ports:       &v1alpha1.ServerPortsSpec{GRPC: 9096},
+			},
+			expected: map[string]string{
+				"tempo.grafana.com/config.hash":                 "abc",
+				"sidecar.istio.io/inject":                       "true",
+				"sidecar.istio.io/rewriteAppHTTPProbers":        "true",
+				"traffic.sidecar.istio.io/excludeInboundPorts":  "7946,9096",
+				"traffic.sidecar.istio.io/excludeOutboundPorts": "7946,9096",
+				"proxy.istio.io/config":                         `{"holdApplicationUntilProxyStarts": true}`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			template := corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"tempo.grafana.com/config.hash": "abc"},
+				},
+			}
+			deployment := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: *template.DeepCopy()}}
+			statefulSet := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Template: *template.DeepCopy()}}
+
+			ConfigurePods(v1alpha1.TempoStack{Spec: test.spec}, []client.Object{deployment, statefulSet, &corev1.Service{}})
+			assert.Equal(t, test.expected, deployment.Spec.Template.Annotations)
+			assert.Equal(t, test.expected, statefulSet.Spec.Template.Annotations)
+		})
+	}
+}
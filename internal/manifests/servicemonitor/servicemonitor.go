@@ -29,6 +29,10 @@ func BuildServiceMonitors(params manifestutils.Params) []client.Object {
 		monitors = append(monitors, buildServiceMonitor(params, manifestutils.GatewayComponentName, gateway.InternalPortName))
 	}
 
+	if params.Tempo.Spec.Template.MetricsGenerator.Enabled {
+		monitors = append(monitors, buildServiceMonitor(params, manifestutils.MetricsGeneratorComponentName, manifestutils.HttpPortName))
+	}
+
 	return monitors
 }
 
@@ -71,6 +75,24 @@ func buildServiceMonitor(params manifestutils.Params, component string, port str
 		}
 	}
 
+	cfg := tempo.Spec.Observability.Metrics.ServiceMonitors[component]
+	var metricRelabelConfigs []*monitoringv1.RelabelConfig
+	for _, relabel := range cfg.MetricRelabelConfigs {
+		sourceLabels := make([]monitoringv1.LabelName, 0, len(relabel.SourceLabels))
+		for _, label := range relabel.SourceLabels {
+			sourceLabels = append(sourceLabels, monitoringv1.LabelName(label))
+		}
+		metricRelabelConfigs = append(metricRelabelConfigs, &monitoringv1.RelabelConfig{
+			SourceLabels: sourceLabels,
+			Separator:    relabel.Separator,
+			TargetLabel:  relabel.TargetLabel,
+			Regex:        relabel.Regex,
+			Modulus:      relabel.Modulus,
+			Replacement:  relabel.Replacement,
+			Action:       string(relabel.Action),
+		})
+	}
+
 	return &monitoringv1.ServiceMonitor{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: tempo.Namespace,
@@ -79,10 +101,14 @@ func buildServiceMonitor(params manifestutils.Params, component string, port str
 		},
 		Spec: monitoringv1.ServiceMonitorSpec{
 			Endpoints: []monitoringv1.Endpoint{{
-				Scheme:    scheme,
-				Port:      port,
-				Path:      "/metrics",
-				TLSConfig: tlsConfig,
+				Scheme:               scheme,
+				Port:                 port,
+				Path:                 "/metrics",
+				TLSConfig:            tlsConfig,
+				Interval:             monitoringv1.Duration(cfg.Interval),
+				ScrapeTimeout:        monitoringv1.Duration(cfg.ScrapeTimeout),
+				HonorLabels:          cfg.HonorLabels,
+				MetricRelabelConfigs: metricRelabelConfigs,
 				// Custom relabel configs to be compatible with predefined Tempo dashboards:
 				// https://grafana.com/docs/tempo/latest/operations/monitoring/#dashboards
 				RelabelConfigs: []*monitoringv1.RelabelConfig{
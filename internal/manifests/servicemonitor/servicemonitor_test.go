@@ -268,3 +268,46 @@ func TestBuildGatewayServiceMonitorsTLS(t *testing.T) {
 		},
 	}, objects[5])
 }
+
+func TestBuildServiceMonitorsScrapeConfig(t *testing.T) {
+	objects := BuildServiceMonitors(manifestutils.Params{Tempo: v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Observability: v1alpha1.ObservabilitySpec{
+				Metrics: v1alpha1.MetricsConfigSpec{
+					ServiceMonitors: map[string]v1alpha1.ServiceMonitorSpec{
+						"ingester": {
+							Interval:      "1m",
+							ScrapeTimeout: "30s",
+							HonorLabels:   true,
+							MetricRelabelConfigs: []v1alpha1.RelabelConfig{{
+								SourceLabels: []string{"__name__"},
+								Regex:        "tempo_ingester_.*_bucket",
+								Action:       "drop",
+							}},
+						},
+					},
+				},
+			},
+		},
+	}})
+
+	assert.Len(t, objects, 5)
+
+	compactor := objects[0].(*monitoringv1.ServiceMonitor).Spec.Endpoints[0]
+	assert.Empty(t, compactor.Interval)
+	assert.Empty(t, compactor.MetricRelabelConfigs)
+
+	ingester := objects[2].(*monitoringv1.ServiceMonitor).Spec.Endpoints[0]
+	assert.Equal(t, monitoringv1.Duration("1m"), ingester.Interval)
+	assert.Equal(t, monitoringv1.Duration("30s"), ingester.ScrapeTimeout)
+	assert.True(t, ingester.HonorLabels)
+	assert.Equal(t, []*monitoringv1.RelabelConfig{{
+		SourceLabels: []monitoringv1.LabelName{"__name__"},
+		Regex:        "tempo_ingester_.*_bucket",
+		Action:       "drop",
+	}}, ingester.MetricRelabelConfigs)
+}
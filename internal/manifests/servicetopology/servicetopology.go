@@ -0,0 +1,57 @@
+package servicetopology
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+const (
+	// topologyModeAnnotation enables the topology aware routing of a Service since Kubernetes 1.27.
+	topologyModeAnnotation = "service.kubernetes.io/topology-mode"
+	// topologyAwareHintsAnnotation enables the topology aware hints of a Service before Kubernetes 1.27.
+	topologyAwareHintsAnnotation = "service.kubernetes.io/topology-aware-hints"
+)
+
+// ConfigureServices configures the topology aware routing and the internal traffic policy
+// of the internal Services of the components.
+func ConfigureServices(tempo v1alpha1.TempoStack, objs []client.Object) {
+	topology := tempo.Spec.ServiceTopology
+	if topology == nil {
+		return
+	}
+
+	for _, obj := range objs {
+		svc, ok := obj.(*corev1.Service)
+		if !ok || !isInternal(svc) {
+			continue
+		}
+
+		if topology.TopologyAwareRouting {
+			if svc.Annotations == nil {
+				svc.Annotations = map[string]string{}
+			}
+			svc.Annotations[topologyModeAnnotation] = "Auto"
+			svc.Annotations[topologyAwareHintsAnnotation] = "auto"
+		}
+		if topology.InternalTrafficPolicy != "" {
+			policy := topology.InternalTrafficPolicy
+			svc.Spec.InternalTrafficPolicy = &policy
+		}
+	}
+}
+
+// isInternal returns true for the ClusterIP Services of the components, except the gateway,
+// which receives the traffic from outside of the TempoStack.
+// Headless Services are skipped, because the clients resolve the pod IPs directly.
+func isInternal(svc *corev1.Service) bool {
+	if svc.Spec.Type != "" && svc.Spec.Type != corev1.ServiceTypeClusterIP {
+		return false
+	}
+	if svc.Spec.ClusterIP == corev1.ClusterIPNone {
+		return false
+	}
+	return svc.Labels["app.kubernetes.io/component"] != manifestutils.GatewayComponentName
+}
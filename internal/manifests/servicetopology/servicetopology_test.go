@@ -0,0 +1,69 @@
+package servicetopology
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func newService(component string, spec corev1.ServiceSpec) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   component,
+			Labels: manifestutils.ComponentLabels(component, "test"),
+		},
+		Spec: spec,
+	}
+}
+
+func TestConfigureServices(t *testing.T) {
+	local := corev1.ServiceInternalTrafficPolicyLocal
+	tests := []struct {
+		name                string
+		topology            *v1alpha1.ServiceTopologySpec
+		expectedAnnotations map[string]string
+		expectedPolicy      *corev1.ServiceInternalTrafficPolicyType
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:     "topology aware routing",
+			topology: &v1alpha1.ServiceTopologySpec{TopologyAwareRouting: true},
+			expectedAnnotations: map[string]string{
+				"service.kubernetes.io/topology-mode":        "Auto",
+				"service.kubernetes.io/topology-aware-hints": "auto",
+			},
+		},
+		{
+			name:           "local internal traffic policy",
+			topology:       &v1alpha1.ServiceTopologySpec{InternalTrafficPolicy: corev1.ServiceInternalTrafficPolicyLocal},
+			expectedPolicy: &local,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			querier := newService(manifestutils.QuerierComponentName, corev1.ServiceSpec{})
+			distributor := newService(manifestutils.DistributorComponentName, corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer})
+			gateway := newService(manifestutils.GatewayComponentName, corev1.ServiceSpec{})
+			memcached := newService(manifestutils.MemcachedComponentName, corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone})
+
+			tempo := v1alpha1.TempoStack{Spec: v1alpha1.TempoStackSpec{ServiceTopology: test.topology}}
+			ConfigureServices(tempo, []client.Object{querier, distributor, gateway, memcached})
+
+			assert.Equal(t, test.expectedAnnotations, querier.Annotations)
+			assert.Equal(t, test.expectedPolicy, querier.Spec.InternalTrafficPolicy)
+			for _, svc := range []*corev1.Service{distributor, gateway, memcached} {
+				assert.Nil(t, svc.Annotations, svc.Name)
+				assert.Nil(t, svc.Spec.InternalTrafficPolicy, svc.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package servingcerts
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const (
+	// ServingCertSecretNameAnnotation instructs the OpenShift service-ca operator to issue a serving certificate for a service.
+	ServingCertSecretNameAnnotation = "service.beta.openshift.io/serving-cert-secret-name"
+	// InjectCABundleAnnotation instructs the OpenShift service-ca operator to inject the service-ca bundle into a ConfigMap.
+	InjectCABundleAnnotation = "service.beta.openshift.io/inject-cabundle"
+)
+
+// components contains all components which require a serving certificate.
+var components = []string{
+	manifestutils.DistributorComponentName,
+	manifestutils.IngesterComponentName,
+	manifestutils.QuerierComponentName,
+	manifestutils.QueryFrontendComponentName,
+	manifestutils.CompactorComponentName,
+	manifestutils.GatewayComponentName,
+	manifestutils.MetricsGeneratorComponentName,
+}
+
+// ConfigureServices annotates the services of all Tempo components, so that the OpenShift service-ca operator
+// issues their certificates, and appends the ConfigMap which receives the service-ca bundle.
+// The certificates are stored in the same secrets as the certificates of the built-in cert management.
+func ConfigureServices(tempo v1alpha1.TempoStack, objs []client.Object) []client.Object {
+	secretNames := make(map[string]string, len(components))
+	for _, component := range components {
+		secretNames[naming.Name(component, tempo.Name)] = naming.TLSSecretName(component, tempo.Name)
+	}
+
+	for _, obj := range objs {
+		svc, ok := obj.(*corev1.Service)
+		if !ok {
+			continue
+		}
+
+		secretName, ok := secretNames[svc.Name]
+		if !ok {
+			continue
+		}
+
+		if svc.Annotations == nil {
+			svc.Annotations = map[string]string{}
+		}
+		svc.Annotations[ServingCertSecretNameAnnotation] = secretName
+	}
+
+	return append(objs, caBundle(tempo))
+}
+
+func caBundle(tempo v1alpha1.TempoStack) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.SigningCABundleName(tempo.Name),
+			Namespace:   tempo.Namespace,
+			Labels:      manifestutils.CommonLabels(tempo.Name),
+			Annotations: map[string]string{InjectCABundleAnnotation: "true"},
+		},
+	}
+}
@@ -0,0 +1,51 @@
+package servingcerts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestConfigureServices(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "project1",
+		},
+	}
+	objs := []client.Object{
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "tempo-test-distributor"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{
+			Name:        "tempo-test-gateway",
+			Annotations: map[string]string{ServingCertSecretNameAnnotation: "tempo-test-gateway-tls"},
+		}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "tempo-test-gossip-ring"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "tempo-test"}},
+	}
+
+	objs = ConfigureServices(tempo, objs)
+	require.Len(t, objs, 5)
+
+	assert.Equal(t, map[string]string{
+		ServingCertSecretNameAnnotation: "tempo-test-distributor-mtls",
+	}, objs[0].GetAnnotations())
+	assert.Equal(t, map[string]string{
+		ServingCertSecretNameAnnotation: "tempo-test-gateway-mtls",
+	}, objs[1].GetAnnotations())
+	assert.Empty(t, objs[2].GetAnnotations())
+	assert.Equal(t, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "tempo-test-ca-bundle",
+			Namespace:   "project1",
+			Labels:      manifestutils.CommonLabels("test"),
+			Annotations: map[string]string{InjectCABundleAnnotation: "true"},
+		},
+	}, objs[4])
+}
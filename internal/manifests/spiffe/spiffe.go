@@ -0,0 +1,149 @@
+package spiffe
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	"github.com/imdario/mergo"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const (
+	helperConfigFile    = "helper.conf"
+	workloadAPIVolume   = "spiffe-workload-api"
+	workloadAPIDir      = "/spiffe-workload-api"
+	certsVolume         = "spiffe-certs"
+	certsDir            = "/var/run/spiffe/certs"
+	helperConfigVolume  = "spiffe-helper-config"
+	helperConfigDir     = "/etc/spiffe-helper"
+	helperContainerName = "spiffe-helper"
+)
+
+// ConfigMapName returns the name of the ConfigMap containing the spiffe-helper configuration.
+func ConfigMapName(tempoStackName string) string {
+	return naming.Name("spiffe-helper", tempoStackName)
+}
+
+// BuildConfigMap creates the spiffe-helper configuration shared by all Tempo components.
+// The helper stores the SVID and the trust bundle with the same file names as the certificates
+// of the built-in cert management, therefore the Tempo configuration does not change.
+func BuildConfigMap(tempo v1alpha1.TempoStack) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName(tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    manifestutils.CommonLabels(tempo.Name),
+		},
+		Data: map[string]string{
+			helperConfigFile: fmt.Sprintf(`agent_address = "%s"
+cmd = ""
+cmd_args = ""
+cert_dir = "%s"
+svid_file_name = "tls.crt"
+svid_key_file_name = "tls.key"
+svid_bundle_file_name = "service-ca.crt"
+`, path.Join(workloadAPIDir, tempo.Spec.SPIFFE.AgentSocketName), certsDir),
+		},
+	}
+}
+
+// ConfigurePodSpec adds the spiffe-helper sidecar to the PodSpec and mounts the SVIDs
+// into the certificate and CA bundle directories of the specified containers.
+func ConfigurePodSpec(tempo v1alpha1.TempoStack, podSpec *corev1.PodSpec, containers ...int) error {
+	readOnly := true
+	pod := corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{
+				Name: workloadAPIVolume,
+				VolumeSource: corev1.VolumeSource{
+					CSI: &corev1.CSIVolumeSource{
+						Driver:   tempo.Spec.SPIFFE.CSIDriver,
+						ReadOnly: &readOnly,
+					},
+				},
+			},
+			{
+				Name: certsVolume,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			},
+			{
+				Name: helperConfigVolume,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: ConfigMapName(tempo.Name),
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := mergo.Merge(podSpec, pod, mergo.WithAppendSlice); err != nil {
+		return kverrors.Wrap(err, "failed to merge volumes")
+	}
+
+	container := corev1.Container{
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      certsVolume,
+				ReadOnly:  true,
+				MountPath: manifestutils.TempoServerTLSDir(),
+			},
+			{
+				Name:      certsVolume,
+				ReadOnly:  true,
+				MountPath: manifestutils.CABundleDir,
+			},
+		},
+	}
+	if len(containers) == 0 {
+		containers = []int{0}
+	}
+	for _, i := range containers {
+		if i >= len(podSpec.Containers) {
+			continue
+		}
+		if err := mergo.Merge(&podSpec.Containers[i], container, mergo.WithAppendSlice); err != nil {
+			return kverrors.Wrap(err, "failed to merge container")
+		}
+	}
+
+	podSpec.Containers = append(podSpec.Containers, helperContainer(tempo))
+	return nil
+}
+
+func helperContainer(tempo v1alpha1.TempoStack) corev1.Container {
+	return corev1.Container{
+		Name:  helperContainerName,
+		Image: tempo.Spec.Images.SPIFFEHelper,
+		Args: []string{
+			"-config",
+			path.Join(helperConfigDir, helperConfigFile),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      workloadAPIVolume,
+				ReadOnly:  true,
+				MountPath: workloadAPIDir,
+			},
+			{
+				Name:      certsVolume,
+				MountPath: certsDir,
+			},
+			{
+				Name:      helperConfigVolume,
+				ReadOnly:  true,
+				MountPath: helperConfigDir,
+			},
+		},
+		SecurityContext: manifestutils.TempoContainerSecurityContext(),
+	}
+}
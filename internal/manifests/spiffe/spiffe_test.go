@@ -0,0 +1,105 @@
+package spiffe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+var tempo = v1alpha1.TempoStack{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "project1",
+	},
+	Spec: v1alpha1.TempoStackSpec{
+		Images: configv1alpha1.ImagesSpec{
+			SPIFFEHelper: "ghcr.io/spiffe/spiffe-helper:0.7.0",
+		},
+		SPIFFE: &v1alpha1.SPIFFESpec{
+			CSIDriver:       "csi.spiffe.io",
+			AgentSocketName: "spire-agent.sock",
+		},
+	},
+}
+
+func TestBuildConfigMap(t *testing.T) {
+	cm := BuildConfigMap(tempo)
+	assert.Equal(t, "tempo-test-spiffe-helper", cm.Name)
+	assert.Equal(t, "project1", cm.Namespace)
+	assert.Equal(t, `agent_address = "/spiffe-workload-api/spire-agent.sock"
+cmd = ""
+cmd_args = ""
+cert_dir = "/var/run/spiffe/certs"
+svid_file_name = "tls.crt"
+svid_key_file_name = "tls.key"
+svid_bundle_file_name = "service-ca.crt"
+`, cm.Data["helper.conf"])
+}
+
+func TestConfigurePodSpec(t *testing.T) {
+	podSpec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{Name: "tempo"},
+			{Name: "tempo-query"},
+		},
+	}
+
+	err := ConfigurePodSpec(tempo, &podSpec, 0, 1)
+	require.NoError(t, err)
+
+	readOnly := true
+	assert.Equal(t, []corev1.Volume{
+		{
+			Name: "spiffe-workload-api",
+			VolumeSource: corev1.VolumeSource{
+				CSI: &corev1.CSIVolumeSource{
+					Driver:   "csi.spiffe.io",
+					ReadOnly: &readOnly,
+				},
+			},
+		},
+		{
+			Name: "spiffe-certs",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		{
+			Name: "spiffe-helper-config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "tempo-test-spiffe-helper",
+					},
+				},
+			},
+		},
+	}, podSpec.Volumes)
+
+	require.Len(t, podSpec.Containers, 3)
+	certMounts := []corev1.VolumeMount{
+		{
+			Name:      "spiffe-certs",
+			ReadOnly:  true,
+			MountPath: "/var/run/tls/server",
+		},
+		{
+			Name:      "spiffe-certs",
+			ReadOnly:  true,
+			MountPath: "/var/run/ca",
+		},
+	}
+	assert.Equal(t, certMounts, podSpec.Containers[0].VolumeMounts)
+	assert.Equal(t, certMounts, podSpec.Containers[1].VolumeMounts)
+
+	helper := podSpec.Containers[2]
+	assert.Equal(t, "spiffe-helper", helper.Name)
+	assert.Equal(t, "ghcr.io/spiffe/spiffe-helper:0.7.0", helper.Image)
+	assert.Equal(t, []string{"-config", "/etc/spiffe-helper/helper.conf"}, helper.Args)
+}
@@ -0,0 +1,123 @@
+package tempocli
+
+import (
+	"strings"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+const (
+	componentName = "cli"
+
+	// singleTenantID is the tenant ID of Tempo if multi-tenancy is disabled.
+	singleTenantID = "single-tenant"
+)
+
+// BuildJobs creates a Job for each tempo-cli job of the TempoStack.
+// tempo-cli reads the storage configuration from the Tempo configuration file,
+// and the credentials from the environment variables or the files of the storage secret.
+func BuildJobs(params manifestutils.Params) ([]client.Object, error) {
+	var objs []client.Object
+	for _, spec := range params.Tempo.Spec.CLIJobs {
+		job, err := buildJob(params.Tempo, spec)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, job)
+	}
+	return objs, nil
+}
+
+func buildJob(tempo v1alpha1.TempoStack, spec v1alpha1.TempoCLIJobSpec) (*batchv1.Job, error) {
+	args, err := commandArgs(tempo, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := manifestutils.ComponentLabels(componentName, tempo.Name)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.Name(componentName+"-"+spec.Name, tempo.Name),
+			Namespace: tempo.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: pointer.Int32(1),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: tempo.Spec.ServiceAccount,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:  "tempo-cli",
+							Image: tempo.Spec.Images.TempoCLI,
+							Args:  args,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      manifestutils.ConfigVolumeName,
+									MountPath: "/conf",
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: manifestutils.ConfigVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: naming.Name("", tempo.Name),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := manifestutils.ConfigureStorageCredentials(tempo, &job.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// commandArgs returns the arguments of tempo-cli.
+func commandArgs(tempo v1alpha1.TempoStack, spec v1alpha1.TempoCLIJobSpec) ([]string, error) {
+	tenant := spec.Tenant
+	if tenant == "" && tempo.Spec.Tenants == nil {
+		tenant = singleTenantID
+	}
+
+	var args []string
+	switch spec.Command {
+	case v1alpha1.TempoCLIListBlocks:
+		args = []string{"list", "blocks", tenant}
+	case v1alpha1.TempoCLIAnalyseBlock:
+		args = []string{"analyse", "block", tenant, spec.BlockID}
+	case v1alpha1.TempoCLISearchBlocks:
+		name, value, _ := strings.Cut(spec.Attribute, "=")
+		args = []string{"search", "blocks", name, value, tenant}
+	case v1alpha1.TempoCLIGenIndex:
+		args = []string{"gen", "index", tenant}
+	default:
+		return nil, kverrors.New("unsupported tempo-cli command", "command", spec.Command)
+	}
+
+	args = append(args, "--config-file=/conf/tempo.yaml")
+	return append(args, spec.Args...), nil
+}
@@ -0,0 +1,88 @@
+package tempocli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func TestBuildJobs(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "simplest",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			ServiceAccount: "tempo-simplest",
+			Images:         configv1alpha1.ImagesSpec{TempoCLI: "docker.io/grafana/tempo-cli:2.2.1"},
+			Storage: v1alpha1.ObjectStorageSpec{
+				Secret: v1alpha1.ObjectStorageSecretSpec{Name: "storage", Type: v1alpha1.ObjectStorageSecretS3},
+			},
+			CLIJobs: []v1alpha1.TempoCLIJobSpec{
+				{Name: "list", Command: v1alpha1.TempoCLIListBlocks, Args: []string{"--include-compacted"}},
+			},
+		},
+	}
+
+	objs, err := BuildJobs(manifestutils.Params{Tempo: tempo})
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+
+	job := objs[0].(*batchv1.Job)
+	assert.Equal(t, "tempo-simplest-cli-list", job.Name)
+	assert.Equal(t, "observability", job.Namespace)
+	assert.Equal(t, "cli", job.Labels["app.kubernetes.io/component"])
+
+	pod := job.Spec.Template.Spec
+	assert.Equal(t, "tempo-simplest", pod.ServiceAccountName)
+	require.Len(t, pod.Containers, 1)
+	assert.Equal(t, "docker.io/grafana/tempo-cli:2.2.1", pod.Containers[0].Image)
+	assert.Equal(t, []string{"list", "blocks", "single-tenant", "--config-file=/conf/tempo.yaml", "--include-compacted"}, pod.Containers[0].Args)
+	assert.Equal(t, "tempo-simplest", pod.Volumes[0].ConfigMap.Name)
+	assert.Len(t, pod.Containers[0].Env, 2)
+}
+
+func TestCommandArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenants  *v1alpha1.TenantsSpec
+		spec     v1alpha1.TempoCLIJobSpec
+		expected []string
+	}{
+		{
+			name:     "analyse block",
+			tenants:  &v1alpha1.TenantsSpec{Mode: v1alpha1.ModeOpenShift},
+			spec:     v1alpha1.TempoCLIJobSpec{Command: v1alpha1.TempoCLIAnalyseBlock, Tenant: "dev", BlockID: "0d8d5e0b-5f4e-4c9a-9b2e-3c4e5f6a7b8c"},
+			expected: []string{"analyse", "block", "dev", "0d8d5e0b-5f4e-4c9a-9b2e-3c4e5f6a7b8c", "--config-file=/conf/tempo.yaml"},
+		},
+		{
+			name:     "search blocks",
+			spec:     v1alpha1.TempoCLIJobSpec{Command: v1alpha1.TempoCLISearchBlocks, Attribute: "service.name=frontend"},
+			expected: []string{"search", "blocks", "service.name", "frontend", "single-tenant", "--config-file=/conf/tempo.yaml"},
+		},
+		{
+			name:     "gen index",
+			spec:     v1alpha1.TempoCLIJobSpec{Command: v1alpha1.TempoCLIGenIndex, Tenant: "prod"},
+			expected: []string{"gen", "index", "prod", "--config-file=/conf/tempo.yaml"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := v1alpha1.TempoStack{Spec: v1alpha1.TempoStackSpec{Tenants: test.tenants}}
+			args, err := commandArgs(tempo, test.spec)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, args)
+		})
+	}
+
+	_, err := commandArgs(v1alpha1.TempoStack{}, v1alpha1.TempoCLIJobSpec{Command: "compact"})
+	require.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package vpa
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+// VerticalPodAutoscalerGVK is the GroupVersionKind of the VerticalPodAutoscaler resource.
+var VerticalPodAutoscalerGVK = schema.GroupVersionKind{Group: "autoscaling.k8s.io", Version: "v1", Kind: "VerticalPodAutoscaler"}
+
+// BuildVerticalPodAutoscalers creates a VerticalPodAutoscaler for each Deployment and StatefulSet of the given objects.
+func BuildVerticalPodAutoscalers(tempo v1alpha1.TempoStack, objs []client.Object) []client.Object {
+	spec := tempo.Spec.Resources.VerticalPodAutoscaler
+	if spec == nil {
+		return nil
+	}
+
+	var vpas []client.Object
+	for _, obj := range objs {
+		var kind string
+		switch obj.(type) {
+		case *appsv1.Deployment:
+			kind = "Deployment"
+		case *appsv1.StatefulSet:
+			kind = "StatefulSet"
+		default:
+			continue
+		}
+		vpas = append(vpas, verticalPodAutoscaler(*spec, obj, kind))
+	}
+	return vpas
+}
+
+func verticalPodAutoscaler(spec v1alpha1.VerticalPodAutoscalerSpec, target client.Object, kind string) *unstructured.Unstructured {
+	updateMode := spec.UpdateMode
+	if updateMode == "" {
+		updateMode = v1alpha1.VerticalPodAutoscalerUpdateModeOff
+	}
+
+	vpaSpec := map[string]interface{}{
+		"targetRef": map[string]interface{}{
+			"apiVersion": appsv1.SchemeGroupVersion.String(),
+			"kind":       kind,
+			"name":       target.GetName(),
+		},
+		"updatePolicy": map[string]interface{}{
+			"updateMode": string(updateMode),
+		},
+	}
+	if len(spec.MinAllowed) > 0 || len(spec.MaxAllowed) > 0 {
+		policy := map[string]interface{}{
+			"containerName": "*",
+		}
+		if len(spec.MinAllowed) > 0 {
+			policy["minAllowed"] = resourceList(spec.MinAllowed)
+		}
+		if len(spec.MaxAllowed) > 0 {
+			policy["maxAllowed"] = resourceList(spec.MaxAllowed)
+		}
+		vpaSpec["resourcePolicy"] = map[string]interface{}{
+			"containerPolicies": []interface{}{policy},
+		}
+	}
+
+	labels := make(map[string]string, len(target.GetLabels()))
+	for k, v := range target.GetLabels() {
+		labels[k] = v
+	}
+
+	vpa := &unstructured.Unstructured{}
+	vpa.SetGroupVersionKind(VerticalPodAutoscalerGVK)
+	vpa.SetName(target.GetName())
+	vpa.SetNamespace(target.GetNamespace())
+	vpa.SetLabels(labels)
+	vpa.Object["spec"] = vpaSpec
+	return vpa
+}
+
+func resourceList(resources corev1.ResourceList) map[string]interface{} {
+	res := make(map[string]interface{}, len(resources))
+	for name, quantity := range resources {
+		res[string(name)] = quantity.String()
+	}
+	return res
+}
@@ -0,0 +1,110 @@
+package vpa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestBuildVerticalPodAutoscalers(t *testing.T) {
+	objs := []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-distributor",
+			Namespace: "nsx",
+			Labels:    map[string]string{"app.kubernetes.io/component": "distributor"},
+		}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-ingester",
+			Namespace: "nsx",
+			Labels:    map[string]string{"app.kubernetes.io/component": "ingester"},
+		}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-test-distributor",
+			Namespace: "nsx",
+		}},
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		tempo := v1alpha1.TempoStack{}
+		assert.Nil(t, BuildVerticalPodAutoscalers(tempo, objs))
+	})
+
+	t.Run("recommendations only", func(t *testing.T) {
+		tempo := v1alpha1.TempoStack{
+			Spec: v1alpha1.TempoStackSpec{
+				Resources: v1alpha1.Resources{
+					VerticalPodAutoscaler: &v1alpha1.VerticalPodAutoscalerSpec{},
+				},
+			},
+		}
+
+		vpas := BuildVerticalPodAutoscalers(tempo, objs)
+		require.Len(t, vpas, 2)
+
+		vpa := vpas[0].(*unstructured.Unstructured)
+		assert.Equal(t, VerticalPodAutoscalerGVK, vpa.GroupVersionKind())
+		assert.Equal(t, "tempo-test-distributor", vpa.GetName())
+		assert.Equal(t, "nsx", vpa.GetNamespace())
+		assert.Equal(t, map[string]string{"app.kubernetes.io/component": "distributor"}, vpa.GetLabels())
+		assert.Equal(t, map[string]interface{}{
+			"targetRef": map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"name":       "tempo-test-distributor",
+			},
+			"updatePolicy": map[string]interface{}{
+				"updateMode": "Off",
+			},
+		}, vpa.Object["spec"])
+
+		vpa = vpas[1].(*unstructured.Unstructured)
+		assert.Equal(t, "tempo-test-ingester", vpa.GetName())
+		kind, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "kind")
+		assert.Equal(t, "StatefulSet", kind)
+	})
+
+	t.Run("auto with resource bounds", func(t *testing.T) {
+		tempo := v1alpha1.TempoStack{
+			Spec: v1alpha1.TempoStackSpec{
+				Resources: v1alpha1.Resources{
+					VerticalPodAutoscaler: &v1alpha1.VerticalPodAutoscalerSpec{
+						UpdateMode: v1alpha1.VerticalPodAutoscalerUpdateModeAuto,
+						MinAllowed: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("100m"),
+						},
+						MaxAllowed: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("2"),
+							corev1.ResourceMemory: resource.MustParse("4Gi"),
+						},
+					},
+				},
+			},
+		}
+
+		vpas := BuildVerticalPodAutoscalers(tempo, objs[:1])
+		require.Len(t, vpas, 1)
+
+		vpa := vpas[0].(*unstructured.Unstructured)
+		assert.Equal(t, map[string]interface{}{
+			"updateMode": "Auto",
+		}, vpa.Object["spec"].(map[string]interface{})["updatePolicy"])
+		assert.Equal(t, map[string]interface{}{
+			"containerPolicies": []interface{}{
+				map[string]interface{}{
+					"containerName": "*",
+					"minAllowed":    map[string]interface{}{"cpu": "100m"},
+					"maxAllowed":    map[string]interface{}{"cpu": "2", "memory": "4Gi"},
+				},
+			},
+		}, vpa.Object["spec"].(map[string]interface{})["resourcePolicy"])
+	})
+}
@@ -3,6 +3,7 @@ package status
 import (
 	"context"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
@@ -11,5 +12,6 @@ import (
 // StatusClient defines a interface for fetching status information.
 type StatusClient interface {
 	GetPodsComponent(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*corev1.PodList, error)
+	GetJobsComponent(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*batchv1.JobList, error)
 	PatchStatus(ctx context.Context, changed, original *v1alpha1.TempoStack) error
 }
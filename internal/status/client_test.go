@@ -3,6 +3,7 @@ package status
 import (
 	"context"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
@@ -10,6 +11,7 @@ import (
 
 type statusClientStub struct {
 	GetPodsComponentStub func(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*corev1.PodList, error)
+	GetJobsComponentStub func(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*batchv1.JobList, error)
 	UpdateStatusStub     func(ctx context.Context, s v1alpha1.TempoStack) error
 	PatchStatusStub      func(ctx context.Context, changed, original *v1alpha1.TempoStack) error
 }
@@ -18,6 +20,10 @@ func (scs *statusClientStub) GetPodsComponent(ctx context.Context, componentName
 	return scs.GetPodsComponentStub(ctx, componentName, stack)
 }
 
+func (scs *statusClientStub) GetJobsComponent(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*batchv1.JobList, error) {
+	return scs.GetJobsComponentStub(ctx, componentName, stack)
+}
+
 func (scs *statusClientStub) PatchStatus(ctx context.Context, changed, original *v1alpha1.TempoStack) error {
 	return scs.PatchStatusStub(ctx, changed, original)
 }
@@ -2,93 +2,222 @@ package status
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/ViaQ/logerr/v2/kverrors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
 	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
 )
 
-// SetComponentsStatus updates the pod status map component.
-func componentsStatus(ctx context.Context, c StatusClient, s v1alpha1.TempoStack) (v1alpha1.ComponentStatus, error) {
+// componentReadiness summarizes the readiness of the pods of a component.
+type componentReadiness struct {
+	ready     int
+	total     int
+	failed    bool
+	lastError string
+}
 
-	var err error
+// componentConditions maps the components to their status condition.
+var componentConditions = []struct {
+	component string
+	condition v1alpha1.ConditionStatus
+}{
+	{manifestutils.CompactorComponentName, v1alpha1.ConditionCompactorReady},
+	{manifestutils.DistributorComponentName, v1alpha1.ConditionDistributorReady},
+	{manifestutils.IngesterComponentName, v1alpha1.ConditionIngesterReady},
+	{manifestutils.QuerierComponentName, v1alpha1.ConditionQuerierReady},
+	{manifestutils.QueryFrontendComponentName, v1alpha1.ConditionQueryFrontendReady},
+	{manifestutils.GatewayComponentName, v1alpha1.ConditionGatewayReady},
+	{manifestutils.MetricsGeneratorComponentName, v1alpha1.ConditionMetricsGeneratorReady},
+}
+
+// SetComponentsStatus updates the pod status map component.
+func componentsStatus(ctx context.Context, c StatusClient, s v1alpha1.TempoStack, digests map[string]string) (v1alpha1.ComponentStatus, map[string]componentReadiness, error) {
 	components := v1alpha1.ComponentStatus{}
-	components.Compactor, err = appendPodStatus(ctx, c, manifestutils.CompactorComponentName, s)
-	if err != nil {
-		return v1alpha1.ComponentStatus{}, kverrors.Wrap(err, "failed lookup TempoStack component pods status", "name", manifestutils.CompactorComponentName)
+	readiness := map[string]componentReadiness{}
+	targets := []struct {
+		component string
+		status    *v1alpha1.PodStatusMap
+		enabled   bool
+	}{
+		{manifestutils.CompactorComponentName, &components.Compactor, true},
+		{manifestutils.QuerierComponentName, &components.Querier, true},
+		{manifestutils.DistributorComponentName, &components.Distributor, true},
+		{manifestutils.QueryFrontendComponentName, &components.QueryFrontend, true},
+		{manifestutils.IngesterComponentName, &components.Ingester, true},
+		{manifestutils.GatewayComponentName, &components.Gateway, true},
+		{manifestutils.MetricsGeneratorComponentName, &components.MetricsGenerator, s.Spec.Template.MetricsGenerator.Enabled},
 	}
 
-	components.Querier, err = appendPodStatus(ctx, c, manifestutils.QuerierComponentName, s)
-	if err != nil {
-		return v1alpha1.ComponentStatus{}, kverrors.Wrap(err, "failed lookup TempoStack component pods status", "name", manifestutils.QuerierComponentName)
+	for _, target := range targets {
+		if !target.enabled {
+			continue
+		}
+
+		psm, r, err := appendPodStatus(ctx, c, target.component, s, digests)
+		if err != nil {
+			return v1alpha1.ComponentStatus{}, nil, kverrors.Wrap(err, "failed lookup TempoStack component pods status", "name", target.component)
+		}
+		*target.status = psm
+		readiness[target.component] = r
 	}
 
-	components.Distributor, err = appendPodStatus(ctx, c, manifestutils.DistributorComponentName, s)
+	return components, readiness, nil
+}
+
+func appendPodStatus(ctx context.Context, c StatusClient, componentName string, stack v1alpha1.TempoStack, digests map[string]string) (v1alpha1.PodStatusMap, componentReadiness, error) {
+	psm := v1alpha1.PodStatusMap{}
+	r := componentReadiness{}
+	pods, err := c.GetPodsComponent(ctx, componentName, stack)
+
 	if err != nil {
-		return v1alpha1.ComponentStatus{}, kverrors.Wrap(err, "failed lookup TempoStack component pods status", "name", manifestutils.DistributorComponentName)
+		return nil, r, kverrors.Wrap(err, "failed to list pods for TempoStack component", "name", stack, "component", componentName)
 	}
 
-	components.QueryFrontend, err = appendPodStatus(ctx, c, manifestutils.QueryFrontendComponentName, s)
-	if err != nil {
-		return v1alpha1.ComponentStatus{}, kverrors.Wrap(err, "failed lookup TempoStack component pods status", "name", manifestutils.QueryFrontendComponentName)
+	for _, pod := range pods.Items {
+		phase := pod.Status.Phase
+		psm[phase] = append(psm[phase], pod.Name)
+
+		r.total++
+		if isPodReady(pod) {
+			r.ready++
+		}
+		if phase == corev1.PodFailed || phase == corev1.PodUnknown {
+			r.failed = true
+		}
+		if r.lastError == "" {
+			r.lastError = podError(pod)
+		}
+		addImageDigests(pod, digests)
 	}
+	return psm, r, nil
+}
 
-	components.Ingester, err = appendPodStatus(ctx, c, manifestutils.IngesterComponentName, s)
-	if err != nil {
-		return v1alpha1.ComponentStatus{}, kverrors.Wrap(err, "failed lookup TempoStack component pods status", "name", manifestutils.IngesterComponentName)
+// addImageDigests adds the image digests of the running containers of a pod, by their image.
+func addImageDigests(pod corev1.Pod, digests map[string]string) {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Image == "" || !strings.Contains(cs.ImageID, "@") {
+			continue
+		}
+		// The Docker runtime prefixes the image ID with docker-pullable://
+		digests[cs.Image] = strings.TrimPrefix(cs.ImageID, "docker-pullable://")
+	}
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
 	}
+	return false
+}
 
-	components.Gateway, err = appendPodStatus(ctx, c, manifestutils.GatewayComponentName, s)
-	if err != nil {
-		return v1alpha1.ComponentStatus{}, kverrors.Wrap(err, "failed lookup TempoStack component pods status", "name", manifestutils.GatewayComponentName)
+// podError returns a description of the error of a pod, or an empty string if the pod has no error.
+func podError(pod corev1.Pod) string {
+	if pod.Status.Phase == corev1.PodFailed {
+		reason := strings.TrimSpace(pod.Status.Reason + " " + pod.Status.Message)
+		if reason == "" {
+			return fmt.Sprintf("pod %s failed", pod.Name)
+		}
+		return fmt.Sprintf("pod %s failed: %s", pod.Name, reason)
 	}
 
-	return components, nil
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		waiting := cs.State.Waiting
+		if waiting == nil || waiting.Reason == "" || waiting.Reason == "ContainerCreating" || waiting.Reason == "PodInitializing" {
+			continue
+		}
+		return strings.TrimSpace(fmt.Sprintf("pod %s, container %s: %s %s", pod.Name, cs.Name, waiting.Reason, waiting.Message))
+	}
+	return ""
 }
 
-func appendPodStatus(ctx context.Context, c StatusClient, componentName string, stack v1alpha1.TempoStack) (v1alpha1.PodStatusMap, error) {
-	psm := v1alpha1.PodStatusMap{}
-	pods, err := c.GetPodsComponent(ctx, componentName, stack)
+// componentCondition returns the status condition of a component.
+func componentCondition(condition v1alpha1.ConditionStatus, r componentReadiness) metav1.Condition {
+	cond := metav1.Condition{
+		Type:    string(condition),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(v1alpha1.ReasonPendingComponents),
+		Message: fmt.Sprintf("%d/%d pods ready", r.ready, r.total),
+	}
 
-	if err != nil {
-		return nil, kverrors.Wrap(err, "failed to list pods for TempoStack component", "name", stack, "component", componentName)
+	if r.failed || r.lastError != "" {
+		cond.Reason = string(v1alpha1.ReasonFailedComponents)
+	} else if r.total > 0 && r.ready == r.total {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = string(v1alpha1.ReasonReady)
 	}
 
-	for _, pod := range pods.Items {
-		phase := pod.Status.Phase
-		psm[phase] = append(psm[phase], pod.Name)
+	if r.lastError != "" {
+		cond.Message = fmt.Sprintf("%s, last error: %s", cond.Message, r.lastError)
+	}
+	return cond
+}
+
+// updateComponentConditions sets the status conditions of all enabled components
+// and removes the conditions of disabled components and of components without pods (e.g. before the first rollout).
+func updateComponentConditions(conditions []metav1.Condition, tempo v1alpha1.TempoStack, readiness map[string]componentReadiness) []metav1.Condition {
+	// The conditions may share the backing array with the conditions of the TempoStack.
+	conditions = append([]metav1.Condition{}, conditions...)
+	for _, c := range componentConditions {
+		r, ok := readiness[c.component]
+		if !ok || r.total == 0 || (c.component == manifestutils.GatewayComponentName && !tempo.Spec.Template.Gateway.Enabled) {
+			meta.RemoveStatusCondition(&conditions, string(c.condition))
+			continue
+		}
+		meta.SetStatusCondition(&conditions, componentCondition(c.condition, r))
 	}
-	return psm, nil
+	return conditions
 }
 
 // GetComponentsStatus executes an aggregate update of the TempoStack Status struct, i.e.
 // - It recreates the Status.Components pod status map per component.
 // - It sets the appropriate Status.Condition to true that matches the pod status maps.
+// - It sets a status condition per component with the number of ready pods and the last error.
 func GetComponentsStatus(ctx context.Context, k StatusClient, s v1alpha1.TempoStack) (v1alpha1.TempoStackStatus, error) {
 
-	cs, err := componentsStatus(ctx, k, s)
+	digests := map[string]string{}
+	cs, readiness, err := componentsStatus(ctx, k, s, digests)
 	if err != nil {
 		return v1alpha1.TempoStackStatus{}, err
 	}
 	s.Status.Components = cs
+	s.Status.ImageDigests = nil
+	if len(digests) > 0 {
+		s.Status.ImageDigests = digests
+	}
+
+	purges, err := tenantPurgesStatus(ctx, k, s)
+	if err != nil {
+		return v1alpha1.TempoStackStatus{}, err
+	}
+	s.Status.TenantPurges = purges
 
 	// Check for failed pods first
 	failed := len(cs.Compactor[corev1.PodFailed]) +
 		len(cs.Distributor[corev1.PodFailed]) +
 		len(cs.Ingester[corev1.PodFailed]) +
 		len(cs.Querier[corev1.PodFailed]) +
-		len(cs.QueryFrontend[corev1.PodFailed])
+		len(cs.QueryFrontend[corev1.PodFailed]) +
+		len(cs.MetricsGenerator[corev1.PodFailed])
 
 	unknown := len(cs.Compactor[corev1.PodUnknown]) +
 		len(cs.Distributor[corev1.PodUnknown]) +
 		len(cs.Ingester[corev1.PodUnknown]) +
 		len(cs.Querier[corev1.PodUnknown]) +
-		len(cs.QueryFrontend[corev1.PodUnknown])
+		len(cs.QueryFrontend[corev1.PodUnknown]) +
+		len(cs.MetricsGenerator[corev1.PodUnknown])
 
 	if failed != 0 || unknown != 0 {
-		s.Status.Conditions = FailedCondition(s)
+		s.Status.Conditions = updateComponentConditions(FailedCondition(s), s, readiness)
 		return s.Status, nil
 	}
 
@@ -97,13 +226,14 @@ func GetComponentsStatus(ctx context.Context, k StatusClient, s v1alpha1.TempoSt
 		len(cs.Distributor[corev1.PodPending]) +
 		len(cs.Ingester[corev1.PodPending]) +
 		len(cs.Querier[corev1.PodPending]) +
-		len(cs.QueryFrontend[corev1.PodPending])
+		len(cs.QueryFrontend[corev1.PodPending]) +
+		len(cs.MetricsGenerator[corev1.PodPending])
 
 	if pending != 0 {
-		s.Status.Conditions = PendingCondition(s)
+		s.Status.Conditions = updateComponentConditions(PendingCondition(s), s, readiness)
 		return s.Status, nil
 
 	}
-	s.Status.Conditions = ReadyCondition(s)
+	s.Status.Conditions = updateComponentConditions(ReadyCondition(s), s, readiness)
 	return s.Status, nil
 }
@@ -126,7 +126,10 @@ func TestSetComponentsStatus_WhenSomePodPending(t *testing.T) {
 		LastTransitionTime: now,
 		Status:             metav1.ConditionTrue,
 	})
-	components.Conditions[0].LastTransitionTime = now
+	expected.Conditions = append(expected.Conditions, expectedComponentConditions(now, metav1.ConditionFalse, v1alpha1.ReasonPendingComponents, "0/2 pods ready")...)
+	for i := range components.Conditions {
+		components.Conditions[i].LastTransitionTime = now
+	}
 
 	require.NoError(t, err)
 	assert.Equal(t, expected, components)
@@ -194,7 +197,10 @@ func TestSetComponentsStatus_WhenSomePodFailed(t *testing.T) {
 		LastTransitionTime: now,
 		Status:             metav1.ConditionTrue,
 	})
-	components.Conditions[0].LastTransitionTime = now
+	expected.Conditions = append(expected.Conditions, expectedComponentConditions(now, metav1.ConditionFalse, v1alpha1.ReasonFailedComponents, "0/2 pods ready, last error: pod pod-a failed")...)
+	for i := range components.Conditions {
+		components.Conditions[i].LastTransitionTime = now
+	}
 
 	require.NoError(t, err)
 	assert.Equal(t, expected, components)
@@ -262,7 +268,10 @@ func TestSetComponentsStatus_WhenSomePodUnknow(t *testing.T) {
 		LastTransitionTime: now,
 		Status:             metav1.ConditionTrue,
 	})
-	components.Conditions[0].LastTransitionTime = now
+	expected.Conditions = append(expected.Conditions, expectedComponentConditions(now, metav1.ConditionFalse, v1alpha1.ReasonFailedComponents, "0/2 pods ready")...)
+	for i := range components.Conditions {
+		components.Conditions[i].LastTransitionTime = now
+	}
 
 	require.NoError(t, err)
 	assert.Equal(t, expected, components)
@@ -279,7 +288,8 @@ func TestSetComponentsStatus_WhenAllReady(t *testing.T) {
 						Name: "pod-a",
 					},
 					Status: v1.PodStatus{
-						Phase: v1.PodRunning,
+						Phase:      v1.PodRunning,
+						Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
 					},
 				},
 				{
@@ -287,7 +297,8 @@ func TestSetComponentsStatus_WhenAllReady(t *testing.T) {
 						Name: "pod-b",
 					},
 					Status: v1.PodStatus{
-						Phase: v1.PodRunning,
+						Phase:      v1.PodRunning,
+						Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
 					},
 				},
 			},
@@ -329,8 +340,163 @@ func TestSetComponentsStatus_WhenAllReady(t *testing.T) {
 		LastTransitionTime: now,
 		Status:             metav1.ConditionTrue,
 	})
-	components.Conditions[0].LastTransitionTime = now
+	expected.Conditions = append(expected.Conditions, expectedComponentConditions(now, metav1.ConditionTrue, v1alpha1.ReasonReady, "2/2 pods ready")...)
+	for i := range components.Conditions {
+		components.Conditions[i].LastTransitionTime = now
+	}
 
 	require.NoError(t, err)
 	assert.Equal(t, expected, components)
 }
+
+func expectedComponentConditions(now metav1.Time, status metav1.ConditionStatus, reason v1alpha1.ConditionReason, message string) []metav1.Condition {
+	var conditions []metav1.Condition
+	for _, c := range []v1alpha1.ConditionStatus{
+		v1alpha1.ConditionCompactorReady,
+		v1alpha1.ConditionDistributorReady,
+		v1alpha1.ConditionIngesterReady,
+		v1alpha1.ConditionQuerierReady,
+		v1alpha1.ConditionQueryFrontendReady,
+	} {
+		conditions = append(conditions, metav1.Condition{
+			Type:               string(c),
+			Status:             status,
+			Reason:             string(reason),
+			Message:            message,
+			LastTransitionTime: now,
+		})
+	}
+	return conditions
+}
+
+func TestSetComponentsStatus_ComponentConditions(t *testing.T) {
+	k := &statusClientStub{}
+
+	k.GetPodsComponentStub = func(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*corev1.PodList, error) {
+		ready := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: componentName + "-a"},
+			Status: v1.PodStatus{
+				Phase:      v1.PodRunning,
+				Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+			},
+		}
+		pods := v1.PodList{Items: []v1.Pod{ready}}
+
+		switch componentName {
+		case "ingester":
+			pods.Items = append(pods.Items, v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "ingester-b"},
+				Status: v1.PodStatus{
+					Phase: v1.PodRunning,
+					ContainerStatuses: []v1.ContainerStatus{{
+						Name: "tempo",
+						State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{
+							Reason:  "CrashLoopBackOff",
+							Message: "back-off 5m0s restarting failed container",
+						}},
+					}},
+				},
+			})
+		case "querier":
+			pods.Items = append(pods.Items, v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "querier-b"},
+				Status: v1.PodStatus{
+					Phase: v1.PodRunning,
+					ContainerStatuses: []v1.ContainerStatus{{
+						Name:  "tempo",
+						State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+					}},
+				},
+			})
+		case "metrics-generator":
+			pods.Items = nil
+		}
+		return &pods, nil
+	}
+
+	s := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			Template: v1alpha1.TempoTemplateSpec{
+				Gateway:          v1alpha1.TempoGatewaySpec{Enabled: true},
+				MetricsGenerator: v1alpha1.TempoMetricsGeneratorSpec{Enabled: true},
+			},
+		},
+		Status: v1alpha1.TempoStackStatus{
+			Conditions: []metav1.Condition{{
+				Type:   string(v1alpha1.ConditionMetricsGeneratorReady),
+				Status: metav1.ConditionTrue,
+			}},
+		},
+	}
+
+	status, err := GetComponentsStatus(context.TODO(), k, s)
+	require.NoError(t, err)
+
+	conditions := map[string]metav1.Condition{}
+	for _, c := range status.Conditions {
+		conditions[c.Type] = c
+	}
+	assert.Len(t, conditions, 7)
+	assert.Equal(t, metav1.ConditionTrue, conditions[string(v1alpha1.ConditionReady)].Status)
+
+	for _, c := range []v1alpha1.ConditionStatus{
+		v1alpha1.ConditionCompactorReady,
+		v1alpha1.ConditionDistributorReady,
+		v1alpha1.ConditionQueryFrontendReady,
+		v1alpha1.ConditionGatewayReady,
+	} {
+		assert.Equal(t, metav1.ConditionTrue, conditions[string(c)].Status)
+		assert.Equal(t, "1/1 pods ready", conditions[string(c)].Message)
+	}
+
+	ingester := conditions[string(v1alpha1.ConditionIngesterReady)]
+	assert.Equal(t, metav1.ConditionFalse, ingester.Status)
+	assert.Equal(t, string(v1alpha1.ReasonFailedComponents), ingester.Reason)
+	assert.Equal(t, "1/2 pods ready, last error: pod ingester-b, container tempo: CrashLoopBackOff back-off 5m0s restarting failed container", ingester.Message)
+
+	querier := conditions[string(v1alpha1.ConditionQuerierReady)]
+	assert.Equal(t, metav1.ConditionFalse, querier.Status)
+	assert.Equal(t, string(v1alpha1.ReasonPendingComponents), querier.Reason)
+	assert.Equal(t, "1/2 pods ready", querier.Message)
+
+	// the metrics-generator has no pods
+	assert.NotContains(t, conditions, string(v1alpha1.ConditionMetricsGeneratorReady))
+}
+
+func TestSetComponentsStatus_ImageDigests(t *testing.T) {
+	k := &statusClientStub{}
+	k.GetPodsComponentStub = func(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*corev1.PodList, error) {
+		pod := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod-a"},
+			Status: v1.PodStatus{
+				Phase: v1.PodRunning,
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						Name:    "tempo",
+						Image:   "docker.io/grafana/tempo:x.y.z",
+						ImageID: "docker-pullable://docker.io/grafana/tempo@sha256:1234",
+					},
+					{
+						Name:  "tempo-query",
+						Image: "docker.io/grafana/tempo-query:x.y.z",
+					},
+				},
+			},
+		}
+		if componentName == "query-frontend" {
+			pod.Status.ContainerStatuses[1].ImageID = "docker.io/grafana/tempo-query@sha256:5678"
+		}
+		return &v1.PodList{Items: []v1.Pod{pod}}, nil
+	}
+
+	s, err := GetComponentsStatus(context.TODO(), k, v1alpha1.TempoStack{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"docker.io/grafana/tempo:x.y.z":       "docker.io/grafana/tempo@sha256:1234",
+		"docker.io/grafana/tempo-query:x.y.z": "docker.io/grafana/tempo-query@sha256:5678",
+	}, s.ImageDigests)
+}
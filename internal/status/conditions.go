@@ -9,9 +9,10 @@ import (
 )
 
 const (
-	messageReady   = "All components are operational"
-	messageFailed  = "Some TempoStack components failed"
-	messagePending = "Some TempoStack components are pending on dependencies"
+	messageReady     = "All components are operational"
+	messageFailed    = "Some TempoStack components failed"
+	messagePending   = "Some TempoStack components are pending on dependencies"
+	messageUnmanaged = "The TempoStack is not managed by the operator, changes of the generated resources are not reverted"
 )
 
 // ConfigurationError contains information about why the managed TempoStack has an invalid configuration.
@@ -60,8 +61,20 @@ func PendingCondition(tempo v1alpha1.TempoStack) []metav1.Condition {
 	return UpdateCondition(tempo, pending)
 }
 
+// UnmanagedCondition updates or appends the condition Unmanaged to the TempoStack status conditions.
+// In addition it resets all other Status conditions to false.
+func UnmanagedCondition(tempo v1alpha1.TempoStack) []metav1.Condition {
+	unmanaged := metav1.Condition{
+		Type:    string(v1alpha1.ConditionUnmanaged),
+		Message: messageUnmanaged,
+		Reason:  string(v1alpha1.ReasonUnmanaged),
+	}
+
+	return UpdateCondition(tempo, unmanaged)
+}
+
 // UpdateCondition updates or appends the condition to the TempoStack status conditions.
-// In addition it resets all other status conditions to false.
+// In addition it resets all other status conditions to false, except the conditions of the individual components.
 func UpdateCondition(tempo v1alpha1.TempoStack, condition metav1.Condition) []metav1.Condition {
 
 	for _, c := range tempo.Status.Conditions {
@@ -82,6 +95,10 @@ func UpdateCondition(tempo v1alpha1.TempoStack, condition metav1.Condition) []me
 
 	index := -1
 	for i := range status.Conditions {
+		if isComponentCondition(status.Conditions[i].Type) {
+			continue
+		}
+
 		// Reset all other conditions first
 		status.Conditions[i].Status = metav1.ConditionFalse
 		status.Conditions[i].LastTransitionTime = now
@@ -100,3 +117,12 @@ func UpdateCondition(tempo v1alpha1.TempoStack, condition metav1.Condition) []me
 
 	return status.Conditions
 }
+
+func isComponentCondition(conditionType string) bool {
+	for _, c := range v1alpha1.AllComponentConditions {
+		if string(c) == conditionType {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
@@ -379,3 +380,26 @@ func TestConfigurationError(t *testing.T) {
 	}
 	assert.Equal(t, "invalid configuration: my message", err.Error())
 }
+
+func TestUnmanagedCondition(t *testing.T) {
+	stack := v1alpha1.TempoStack{
+		Status: v1alpha1.TempoStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(v1alpha1.ConditionReady),
+					Message: messageReady,
+					Reason:  string(v1alpha1.ReasonReady),
+					Status:  metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	conditions := UnmanagedCondition(stack)
+	require.Len(t, conditions, 2)
+	assert.Equal(t, metav1.ConditionFalse, conditions[0].Status)
+	assert.Equal(t, string(v1alpha1.ConditionUnmanaged), conditions[1].Type)
+	assert.Equal(t, string(v1alpha1.ReasonUnmanaged), conditions[1].Reason)
+	assert.Equal(t, messageUnmanaged, conditions[1].Message)
+	assert.Equal(t, metav1.ConditionTrue, conditions[1].Status)
+}
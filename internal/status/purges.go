@@ -0,0 +1,65 @@
+package status
+
+import (
+	"context"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/manifests/backup"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+// tenantPurgesStatus returns the phase of the Job of every tenant purge of the TempoStack.
+func tenantPurgesStatus(ctx context.Context, c StatusClient, s v1alpha1.TempoStack) ([]v1alpha1.TenantPurgeStatus, error) {
+	if len(s.Spec.TenantPurges) == 0 {
+		return nil, nil
+	}
+
+	jobs, err := c.GetJobsComponent(ctx, manifestutils.TenantPurgeComponentName, s)
+	if err != nil {
+		return nil, kverrors.Wrap(err, "failed to list the tenant purge jobs", "name", s.Name)
+	}
+	byName := map[string]batchv1.Job{}
+	for _, job := range jobs.Items {
+		byName[job.Name] = job
+	}
+
+	purges := make([]v1alpha1.TenantPurgeStatus, 0, len(s.Spec.TenantPurges))
+	for _, spec := range s.Spec.TenantPurges {
+		purge := v1alpha1.TenantPurgeStatus{
+			Name:   spec.Name,
+			Tenant: spec.Tenant,
+			Phase:  v1alpha1.TenantPurgePending,
+		}
+		if job, found := byName[backup.TenantPurgeJobName(s, spec)]; found {
+			purge.Phase = jobPhase(job)
+			if purge.Phase == v1alpha1.TenantPurgeSucceeded {
+				purge.CompletionTime = job.Status.CompletionTime
+			}
+		}
+		purges = append(purges, purge)
+	}
+	return purges, nil
+}
+
+// jobPhase returns the phase of a Job from its Complete and Failed conditions and its active pods.
+func jobPhase(job batchv1.Job) v1alpha1.TenantPurgePhase {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return v1alpha1.TenantPurgeSucceeded
+		case batchv1.JobFailed:
+			return v1alpha1.TenantPurgeFailed
+		}
+	}
+	if job.Status.Active > 0 {
+		return v1alpha1.TenantPurgeRunning
+	}
+	return v1alpha1.TenantPurgePending
+}
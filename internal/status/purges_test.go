@@ -0,0 +1,67 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestTenantPurgesStatus(t *testing.T) {
+	completed := metav1.Now()
+	s := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Spec: v1alpha1.TempoStackSpec{
+			TenantPurges: []v1alpha1.TenantPurgeSpec{
+				{Name: "dev", Tenant: "dev"},
+				{Name: "prod", Tenant: "prod"},
+				{Name: "staging", Tenant: "staging"},
+				{Name: "test", Tenant: "test"},
+			},
+		},
+	}
+
+	k := &statusClientStub{}
+	k.GetJobsComponentStub = func(ctx context.Context, componentName string, stack v1alpha1.TempoStack) (*batchv1.JobList, error) {
+		assert.Equal(t, "purge", componentName)
+		return &batchv1.JobList{
+			Items: []batchv1.Job{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "tempo-my-stack-purge-dev"},
+					Status: batchv1.JobStatus{
+						CompletionTime: &completed,
+						Conditions:     []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "tempo-my-stack-purge-prod"},
+					Status: batchv1.JobStatus{
+						Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "tempo-my-stack-purge-staging"},
+					Status:     batchv1.JobStatus{Active: 1},
+				},
+			},
+		}, nil
+	}
+
+	purges, err := tenantPurgesStatus(context.Background(), k, s)
+	require.NoError(t, err)
+	assert.Equal(t, []v1alpha1.TenantPurgeStatus{
+		{Name: "dev", Tenant: "dev", Phase: v1alpha1.TenantPurgeSucceeded, CompletionTime: &completed},
+		{Name: "prod", Tenant: "prod", Phase: v1alpha1.TenantPurgeFailed},
+		{Name: "staging", Tenant: "staging", Phase: v1alpha1.TenantPurgeRunning},
+		{Name: "test", Tenant: "test", Phase: v1alpha1.TenantPurgePending},
+	}, purges)
+}
@@ -18,6 +18,12 @@ var (
 		Name:      "status_condition",
 		Help:      "The status condition of a TempoStack instance.",
 	}, []string{"stack_namespace", "stack_name", "condition"})
+
+	metricTempoStackDegraded = promauto.With(metrics.Registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "tempostack",
+		Name:      "degraded",
+		Help:      "Whether a TempoStack instance has failed components or failed to reconcile.",
+	}, []string{"stack_namespace", "stack_name"})
 )
 
 // Refresh updates the status field with the Tempo versions and updates the tempostack_status_condition metric.
@@ -27,13 +33,10 @@ func Refresh(ctx context.Context, k StatusClient, tempo v1alpha1.TempoStack, sta
 
 	// The .status.version field is empty for new CRs and cannot be set in the Defaulter webhook.
 	// The upgrade procedure only runs once at operator startup, therefore we need to set
-	// the initial status field versions here.
+	// the initial status field versions here. The Tempo version is set once the workloads are rolled out.
 	if status.OperatorVersion == "" {
 		changed.Status.OperatorVersion = version.Get().OperatorVersion
 	}
-	if status.TempoVersion == "" {
-		changed.Status.TempoVersion = version.Get().TempoVersion
-	}
 
 	// Update all status condition metrics.
 	// In some cases not all status conditions are present in the status.Conditions list, for example:
@@ -46,11 +49,13 @@ func Refresh(ctx context.Context, k StatusClient, tempo v1alpha1.TempoStack, sta
 			activeConditions[cond.Type] = 1
 		}
 	}
-	for _, cond := range v1alpha1.AllStatusConditions {
+	allConditions := append(append([]v1alpha1.ConditionStatus{}, v1alpha1.AllStatusConditions...), v1alpha1.AllComponentConditions...)
+	for _, cond := range allConditions {
 		condStr := string(cond)
 		isActive := activeConditions[condStr] // isActive will be 0 if the condition is not found in the map
 		metricTempoStackStatusCondition.WithLabelValues(tempo.Namespace, tempo.Name, condStr).Set(isActive)
 	}
+	metricTempoStackDegraded.WithLabelValues(tempo.Namespace, tempo.Name).Set(degraded(status.Conditions))
 
 	err := k.PatchStatus(ctx, changed, &tempo)
 	if err != nil {
@@ -59,3 +64,27 @@ func Refresh(ctx context.Context, k StatusClient, tempo v1alpha1.TempoStack, sta
 
 	return nil
 }
+
+// degraded returns 1 if the Failed condition or the condition of any component reports failed pods.
+func degraded(conditions []metav1.Condition) float64 {
+	for _, cond := range conditions {
+		if cond.Type == string(v1alpha1.ConditionFailed) && cond.Status == metav1.ConditionTrue {
+			return 1
+		}
+		if cond.Reason == string(v1alpha1.ReasonFailedComponents) && cond.Status == metav1.ConditionFalse {
+			for _, c := range v1alpha1.AllComponentConditions {
+				if cond.Type == string(c) {
+					return 1
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// DeleteMetrics removes the status metrics of a deleted TempoStack instance.
+func DeleteMetrics(namespace string, name string) {
+	labels := prometheus.Labels{"stack_namespace": namespace, "stack_name": name}
+	metricTempoStackStatusCondition.DeletePartialMatch(labels)
+	metricTempoStackDegraded.DeletePartialMatch(labels)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -68,3 +69,39 @@ func TestRefreshNoError(t *testing.T) {
 	err := Refresh(context.Background(), c, stack, &s)
 	assert.NoError(t, err)
 }
+
+func TestRefreshMetrics(t *testing.T) {
+	c := &statusClientStub{}
+	c.PatchStatusStub = func(ctx context.Context, changed, original *v1alpha1.TempoStack) error {
+		return nil
+	}
+
+	stack := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "metrics",
+			Namespace: "some-ns",
+		},
+	}
+	s := v1alpha1.TempoStackStatus{
+		Conditions: append(ReadyCondition(stack), metav1.Condition{
+			Type:   string(v1alpha1.ConditionIngesterReady),
+			Status: metav1.ConditionFalse,
+			Reason: string(v1alpha1.ReasonFailedComponents),
+		}, metav1.Condition{
+			Type:   string(v1alpha1.ConditionQuerierReady),
+			Status: metav1.ConditionTrue,
+			Reason: string(v1alpha1.ReasonReady),
+		}),
+	}
+
+	err := Refresh(context.Background(), c, stack, &s)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metricTempoStackStatusCondition.WithLabelValues("some-ns", "metrics", "Ready")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metricTempoStackStatusCondition.WithLabelValues("some-ns", "metrics", "IngesterReady")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metricTempoStackStatusCondition.WithLabelValues("some-ns", "metrics", "QuerierReady")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metricTempoStackDegraded.WithLabelValues("some-ns", "metrics")))
+
+	count := testutil.CollectAndCount(metricTempoStackDegraded)
+	DeleteMetrics("some-ns", "metrics")
+	assert.Equal(t, count-1, testutil.CollectAndCount(metricTempoStackDegraded))
+}
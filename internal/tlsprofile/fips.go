@@ -0,0 +1,49 @@
+package tlsprofile
+
+import (
+	openshiftconfigv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/library-go/pkg/crypto"
+)
+
+// fipsCipherSuites are the IANA names of the FIPS 140 approved cipher suites (AES-GCM with ECDHE key exchange).
+var fipsCipherSuites = map[string]bool{
+	"TLS_AES_128_GCM_SHA256":                  true,
+	"TLS_AES_256_GCM_SHA384":                  true,
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256": true,
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384": true,
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":   true,
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":   true,
+}
+
+// FIPS returns the TLS settings restricted to FIPS 140 approved cipher suites and TLS versions.
+func (o TLSProfileOptions) FIPS() TLSProfileOptions {
+	ciphers := []string{}
+	for _, cipher := range o.Ciphers {
+		if fipsCipherSuites[cipher] {
+			ciphers = append(ciphers, cipher)
+		}
+	}
+
+	minTLSVersion := o.MinTLSVersion
+	switch minTLSVersion {
+	case string(openshiftconfigv1.VersionTLS10), string(openshiftconfigv1.VersionTLS11):
+		minTLSVersion = string(openshiftconfigv1.VersionTLS12)
+	}
+
+	return TLSProfileOptions{
+		Ciphers:       ciphers,
+		MinTLSVersion: minTLSVersion,
+	}
+}
+
+// NonFIPSCiphers returns the cipher suites, using the OpenSSL names, which are not FIPS 140 approved.
+func NonFIPSCiphers(ciphers []string) []string {
+	var nonFIPS []string
+	for _, cipher := range ciphers {
+		iana := crypto.OpenSSLToIANACipherSuites([]string{cipher})
+		if len(iana) != 1 || !fipsCipherSuites[iana[0]] {
+			nonFIPS = append(nonFIPS, cipher)
+		}
+	}
+	return nonFIPS
+}
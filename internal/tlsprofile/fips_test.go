@@ -0,0 +1,69 @@
+package tlsprofile
+
+import (
+	"testing"
+
+	openshiftconfigv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFIPS(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    TLSProfileOptions
+		expected TLSProfileOptions
+	}{
+		{
+			name: "intermediate profile",
+			input: TLSProfileOptions{
+				Ciphers: []string{
+					"TLS_AES_128_GCM_SHA256",
+					"TLS_CHACHA20_POLY1305_SHA256",
+					"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+					"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+				},
+				MinTLSVersion: string(openshiftconfigv1.VersionTLS12),
+			},
+			expected: TLSProfileOptions{
+				Ciphers:       []string{"TLS_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+				MinTLSVersion: string(openshiftconfigv1.VersionTLS12),
+			},
+		},
+		{
+			name: "old TLS version",
+			input: TLSProfileOptions{
+				Ciphers:       []string{"TLS_RSA_WITH_AES_128_CBC_SHA"},
+				MinTLSVersion: string(openshiftconfigv1.VersionTLS10),
+			},
+			expected: TLSProfileOptions{
+				Ciphers:       []string{},
+				MinTLSVersion: string(openshiftconfigv1.VersionTLS12),
+			},
+		},
+		{
+			name: "TLS 1.3",
+			input: TLSProfileOptions{
+				Ciphers:       []string{"TLS_AES_256_GCM_SHA384"},
+				MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+			},
+			expected: TLSProfileOptions{
+				Ciphers:       []string{"TLS_AES_256_GCM_SHA384"},
+				MinTLSVersion: string(openshiftconfigv1.VersionTLS13),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.input.FIPS())
+		})
+	}
+}
+
+func TestNonFIPSCiphers(t *testing.T) {
+	assert.Nil(t, NonFIPSCiphers([]string{"ECDHE-RSA-AES128-GCM-SHA256", "ECDHE-ECDSA-AES256-GCM-SHA384"}))
+	assert.Equal(t,
+		[]string{"ECDHE-RSA-CHACHA20-POLY1305", "AES128-SHA", "unknown"},
+		NonFIPSCiphers([]string{"ECDHE-RSA-AES128-GCM-SHA256", "ECDHE-RSA-CHACHA20-POLY1305", "AES128-SHA", "unknown"}),
+	)
+}
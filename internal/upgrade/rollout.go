@@ -0,0 +1,259 @@
+package upgrade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/httpclient"
+	"github.com/grafana/tempo-operator/internal/handlers/ingester"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+	"github.com/grafana/tempo-operator/internal/manifests/naming"
+)
+
+// OrderRollout orders the rollout of a new Tempo version to the components of a TempoStack,
+// instead of updating the image of all components at the same time.
+//
+// The ingesters are updated one pod at a time, and with zone-aware replication one zone at a time.
+// Every ingester flushes its traces to the object storage before it is restarted with the new version.
+// The compactor is updated last, once all other components run the new version, because the compactor rewrites
+// the blocks in the object storage, possibly in a new block format which the previous version cannot read.
+//
+// The workloads which must not be updated yet keep their current pod template.
+// Their update is resumed in a later reconciliation, which is triggered by the status changes of the other workloads.
+// The HTTP client to flush the ingesters is created by newHTTPClient.
+func OrderRollout(ctx context.Context, k8sClient client.Client, newHTTPClient httpclient.Factory, tempo v1alpha1.TempoStack, tlsEnabled bool, objs []client.Object) error {
+	// rolledOut tracks all components, ingestersRolledOut only the ingester zones.
+	rolledOut, ingestersRolledOut := true, true
+	var compactor *appsv1.Deployment
+	flushing := false
+	errs := []error{}
+
+	for _, obj := range objs {
+		var done bool
+		var err error
+
+		switch o := obj.(type) {
+		case *appsv1.StatefulSet:
+			switch {
+			case !ingester.IsIngester(o):
+				done, err = isStatefulSetRolledOut(ctx, k8sClient, o)
+			case !ingestersRolledOut:
+				// Update one ingester zone at a time.
+				err = keepTemplate(ctx, k8sClient, o)
+			default:
+				done, err = ingester.RollOutVersion(ctx, k8sClient, newHTTPClient, tempo, tlsEnabled, o)
+				ingestersRolledOut = done
+			}
+
+		case *appsv1.Deployment:
+			if o.Labels["app.kubernetes.io/component"] == manifestutils.CompactorComponentName {
+				compactor = o
+				continue
+			}
+			done, err = isDeploymentRolledOut(ctx, k8sClient, o)
+
+		default:
+			continue
+		}
+
+		if errors.Is(err, ingester.ErrFlushInProgress) {
+			flushing = true
+		} else if err != nil {
+			errs = append(errs, err)
+		}
+		rolledOut = rolledOut && done
+	}
+
+	if compactor != nil && !rolledOut {
+		if err := keepTemplate(ctx, k8sClient, compactor); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	// Other errors take precedence, the reconciliation is requeued in either case.
+	if len(errs) == 0 && flushing {
+		return ingester.ErrFlushInProgress
+	}
+	return errors.Join(errs...)
+}
+
+// VersionRolledOut returns true if all existing workloads are configured with the Tempo version of the desired workloads,
+// i.e. if no rollout of a new Tempo version is in progress. The pods of the workloads might still be restarting.
+func VersionRolledOut(ctx context.Context, k8sClient client.Client, objs []client.Object) (bool, error) {
+	for _, obj := range objs {
+		template := podTemplate(obj)
+		if template == nil || ingester.TempoImage(*template) == "" {
+			continue
+		}
+
+		existing, err := getExisting(ctx, k8sClient, obj)
+		if err != nil {
+			return false, err
+		}
+		if existing == nil {
+			continue
+		}
+		if ingester.TempoImage(*podTemplate(existing)) != ingester.TempoImage(*template) {
+			return false, nil
+		}
+		// The ingesters are updated one pod at a time by lowering the partition of the rolling update.
+		if ss, ok := existing.(*appsv1.StatefulSet); ok && ss.Spec.UpdateStrategy.RollingUpdate != nil &&
+			pointer.Int32Deref(ss.Spec.UpdateStrategy.RollingUpdate.Partition, 0) > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// RolloutConfigMapName returns the name of the ConfigMap with the Tempo configuration of the new Tempo version,
+// which exists while a new Tempo version is rolled out.
+func RolloutConfigMapName(tempo v1alpha1.TempoStack) string {
+	return naming.Name("rollout", tempo.Name)
+}
+
+// StageConfig versions the Tempo configuration while a new Tempo version is rolled out.
+//
+// The workloads which run the previous version might restart at any time during the rollout,
+// therefore they must keep the configuration of the previous version. The ConfigMap of the Tempo configuration
+// is held back, and the desired configuration is written to a separate ConfigMap, which is mounted by the
+// workloads running the new version. Once all workloads run the new version, the separate ConfigMap is removed
+// and all workloads mount the ConfigMap of the Tempo configuration again.
+func StageConfig(ctx context.Context, k8sClient client.Client, tempo v1alpha1.TempoStack, objs []client.Object) ([]client.Object, error) {
+	var desired *corev1.ConfigMap
+	for _, obj := range objs {
+		if cm, ok := obj.(*corev1.ConfigMap); ok && cm.Name == naming.Name("", tempo.Name) {
+			desired = cm
+		}
+	}
+	if desired == nil {
+		return objs, nil
+	}
+
+	existing := &corev1.ConfigMap{}
+	err := k8sClient.Get(ctx, client.ObjectKey{Namespace: tempo.Namespace, Name: desired.Name}, existing)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return objs, nil
+		}
+		return objs, fmt.Errorf("failed to get %s: %w", desired.Name, err)
+	}
+
+	staged := desired.DeepCopy()
+	staged.Name = RolloutConfigMapName(tempo)
+	desired.Data = existing.Data
+
+	for _, obj := range objs {
+		template := podTemplate(obj)
+		if template == nil || ingester.TempoImage(*template) != tempo.Spec.Images.Tempo {
+			continue
+		}
+		for i := range template.Spec.Volumes {
+			volume := &template.Spec.Volumes[i]
+			if volume.ConfigMap != nil && volume.ConfigMap.Name == desired.Name {
+				volume.ConfigMap.Name = staged.Name
+			}
+		}
+	}
+	return append(objs, staged), nil
+}
+
+// keepTemplate resets the pod template of a desired workload to the pod template of the existing workload,
+// if the existing workload runs a different Tempo version.
+func keepTemplate(ctx context.Context, k8sClient client.Client, desired client.Object) error {
+	existing, err := getExisting(ctx, k8sClient, desired)
+	if err != nil || existing == nil {
+		return err
+	}
+
+	template, existingTemplate := podTemplate(desired), podTemplate(existing)
+	if ingester.TempoImage(*existingTemplate) != ingester.TempoImage(*template) {
+		*template = *existingTemplate.DeepCopy()
+	}
+	return nil
+}
+
+func podTemplate(obj client.Object) *corev1.PodTemplateSpec {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return &o.Spec.Template
+	case *appsv1.StatefulSet:
+		return &o.Spec.Template
+	default:
+		return nil
+	}
+}
+
+// isDeploymentRolledOut returns true if all pods of a Deployment run the desired Tempo version.
+func isDeploymentRolledOut(ctx context.Context, k8sClient client.Client, desired *appsv1.Deployment) (bool, error) {
+	if ingester.TempoImage(desired.Spec.Template) == "" {
+		return true, nil
+	}
+
+	obj, err := getExisting(ctx, k8sClient, desired)
+	if err != nil {
+		return false, err
+	}
+	if obj == nil {
+		return true, nil
+	}
+
+	existing := obj.(*appsv1.Deployment)
+	if ingester.TempoImage(existing.Spec.Template) != ingester.TempoImage(desired.Spec.Template) {
+		return false, nil
+	}
+	return existing.Status.ObservedGeneration >= existing.Generation &&
+		existing.Status.UpdatedReplicas == existing.Status.Replicas &&
+		existing.Status.AvailableReplicas == existing.Status.Replicas, nil
+}
+
+// isStatefulSetRolledOut returns true if all pods of a StatefulSet run the desired Tempo version.
+func isStatefulSetRolledOut(ctx context.Context, k8sClient client.Client, desired *appsv1.StatefulSet) (bool, error) {
+	if ingester.TempoImage(desired.Spec.Template) == "" {
+		return true, nil
+	}
+
+	obj, err := getExisting(ctx, k8sClient, desired)
+	if err != nil {
+		return false, err
+	}
+	if obj == nil {
+		return true, nil
+	}
+
+	existing := obj.(*appsv1.StatefulSet)
+	if ingester.TempoImage(existing.Spec.Template) != ingester.TempoImage(desired.Spec.Template) {
+		return false, nil
+	}
+	return existing.Status.ObservedGeneration >= existing.Generation &&
+		existing.Status.CurrentRevision == existing.Status.UpdateRevision &&
+		existing.Status.ReadyReplicas == existing.Status.Replicas, nil
+}
+
+// getExisting returns the existing workload in the cluster, or nil if the workload does not exist yet.
+func getExisting(ctx context.Context, k8sClient client.Client, desired client.Object) (client.Object, error) {
+	var existing client.Object
+	switch desired.(type) {
+	case *appsv1.Deployment:
+		existing = &appsv1.Deployment{}
+	case *appsv1.StatefulSet:
+		existing = &appsv1.StatefulSet{}
+	default:
+		return nil, fmt.Errorf("unsupported workload type %T", desired)
+	}
+
+	err := k8sClient.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get %s: %w", desired.GetName(), err)
+	}
+	return existing, nil
+}
@@ -0,0 +1,191 @@
+package upgrade
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	configv1alpha1 "github.com/grafana/tempo-operator/apis/config/v1alpha1"
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/handlers/httpclient"
+	"github.com/grafana/tempo-operator/internal/manifests/manifestutils"
+)
+
+func deployment(component string, image string, available int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      component,
+			Namespace: "project1",
+			Labels:    manifestutils.ComponentLabels(component, "test"),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "tempo", Image: image}},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:          1,
+			UpdatedReplicas:   1,
+			AvailableReplicas: available,
+		},
+	}
+}
+
+func TestOrderRollout(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      []client.Object
+		expectedImage string
+	}{
+		{
+			name: "new TempoStack",
+			// the compactor is created with the new version
+			expectedImage: "tempo:new",
+		},
+		{
+			name: "querier is not updated yet",
+			existing: []client.Object{
+				deployment(manifestutils.QuerierComponentName, "tempo:old", 1),
+				deployment(manifestutils.CompactorComponentName, "tempo:old", 1),
+			},
+			expectedImage: "tempo:old",
+		},
+		{
+			name: "querier is rolling out",
+			existing: []client.Object{
+				deployment(manifestutils.QuerierComponentName, "tempo:new", 0),
+				deployment(manifestutils.CompactorComponentName, "tempo:old", 1),
+			},
+			expectedImage: "tempo:old",
+		},
+		{
+			name: "querier is rolled out",
+			existing: []client.Object{
+				deployment(manifestutils.QuerierComponentName, "tempo:new", 1),
+				deployment(manifestutils.CompactorComponentName, "tempo:old", 1),
+			},
+			expectedImage: "tempo:new",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			k8sClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(test.existing...).Build()
+
+			querier := deployment(manifestutils.QuerierComponentName, "tempo:new", 0)
+			compactor := deployment(manifestutils.CompactorComponentName, "tempo:new", 0)
+			err := OrderRollout(context.Background(), k8sClient, httpclient.ForComponent, v1alpha1.TempoStack{}, false, []client.Object{compactor, querier})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedImage, compactor.Spec.Template.Spec.Containers[0].Image)
+			// the other components are not held back
+			assert.Equal(t, "tempo:new", querier.Spec.Template.Spec.Containers[0].Image)
+		})
+	}
+}
+
+func TestVersionRolledOut(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []client.Object
+		expected bool
+	}{
+		{
+			name:     "new TempoStack",
+			expected: true,
+		},
+		{
+			name: "querier is not updated yet",
+			existing: []client.Object{
+				deployment(manifestutils.QuerierComponentName, "tempo:old", 1),
+				deployment(manifestutils.CompactorComponentName, "tempo:new", 1),
+			},
+			expected: false,
+		},
+		{
+			// the pods might still be restarting
+			name: "all workloads are updated",
+			existing: []client.Object{
+				deployment(manifestutils.QuerierComponentName, "tempo:new", 0),
+				deployment(manifestutils.CompactorComponentName, "tempo:new", 0),
+			},
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			k8sClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(test.existing...).Build()
+
+			querier := deployment(manifestutils.QuerierComponentName, "tempo:new", 0)
+			compactor := deployment(manifestutils.CompactorComponentName, "tempo:new", 0)
+			rolledOut, err := VersionRolledOut(context.Background(), k8sClient, []client.Object{compactor, querier})
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, rolledOut)
+		})
+	}
+}
+
+func TestStageConfig(t *testing.T) {
+	tempo := v1alpha1.TempoStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "project1"},
+		Spec: v1alpha1.TempoStackSpec{
+			Images: configv1alpha1.ImagesSpec{Tempo: "tempo:new"},
+		},
+	}
+	withConfig := func(d *appsv1.Deployment) *appsv1.Deployment {
+		d.Spec.Template.Spec.Volumes = []corev1.Volume{{
+			Name: "tempo-conf",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "tempo-test"},
+				},
+			},
+		}}
+		return d
+	}
+	configMap := func(config string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "tempo-test", Namespace: "project1"},
+			Data:       map[string]string{"tempo.yaml": config},
+		}
+	}
+
+	t.Run("new TempoStack", func(t *testing.T) {
+		k8sClient := fake.NewClientBuilder().WithScheme(testScheme).Build()
+
+		objs := []client.Object{configMap("new"), withConfig(deployment(manifestutils.QuerierComponentName, "tempo:new", 0))}
+		staged, err := StageConfig(context.Background(), k8sClient, tempo, objs)
+		require.NoError(t, err)
+		assert.Equal(t, objs, staged)
+	})
+
+	t.Run("rollout in progress", func(t *testing.T) {
+		k8sClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(configMap("old")).Build()
+
+		querier := withConfig(deployment(manifestutils.QuerierComponentName, "tempo:new", 0))
+		compactor := withConfig(deployment(manifestutils.CompactorComponentName, "tempo:old", 0))
+		staged, err := StageConfig(context.Background(), k8sClient, tempo, []client.Object{configMap("new"), compactor, querier})
+		require.NoError(t, err)
+		require.Len(t, staged, 4)
+
+		// the workloads running the previous version keep the previous configuration
+		assert.Equal(t, "old", staged[0].(*corev1.ConfigMap).Data["tempo.yaml"])
+		assert.Equal(t, "tempo-test", compactor.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+
+		// the workloads running the new version mount the new configuration
+		rolloutConfig := staged[3].(*corev1.ConfigMap)
+		assert.Equal(t, "tempo-test-rollout", rolloutConfig.Name)
+		assert.Equal(t, "new", rolloutConfig.Data["tempo.yaml"])
+		assert.Equal(t, "tempo-test-rollout", querier.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+	})
+}
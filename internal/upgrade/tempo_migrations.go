@@ -0,0 +1,87 @@
+package upgrade
+
+import (
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+	"github.com/grafana/tempo-operator/internal/version"
+)
+
+type tempoMigrationFunc func(tempo *v1alpha1.TempoStack)
+
+type tempoMigration struct {
+	version semver.Version
+	migrate tempoMigrationFunc
+}
+
+var (
+	// List of all Tempo versions requiring migration steps while the components are rolled out to this or a later version.
+	// The steps adapt the TempoStack which is used to build the manifests until all components run the new version.
+	// This list needs to be sorted by the version ascending.
+	tempoMigrations = []tempoMigration{
+		{
+			// Tempo 2.2 writes vParquet2 blocks by default, which cannot be read by the previous versions.
+			version: *semver.MustParse("2.2.0"),
+			migrate: keepBlockFormat(v1alpha1.BlockFormatVParquet),
+		},
+		{
+			// Tempo 2.4 writes vParquet3 blocks by default, which cannot be read by the versions before Tempo 2.3.
+			version: *semver.MustParse("2.4.0"),
+			migrate: keepBlockFormat(v1alpha1.BlockFormatVParquet2),
+		},
+		{
+			// Tempo 2.6 writes vParquet4 blocks by default, which cannot be read by the versions before Tempo 2.5.
+			version: *semver.MustParse("2.6.0"),
+			migrate: keepBlockFormat(v1alpha1.BlockFormatVParquet3),
+		},
+	}
+)
+
+// keepBlockFormat keeps writing the default block format of the previous Tempo version during the rollout,
+// otherwise the components running the previous version cannot read the blocks written by the updated components.
+// A block format configured in the TempoStack takes precedence.
+func keepBlockFormat(format v1alpha1.BlockFormat) tempoMigrationFunc {
+	return func(tempo *v1alpha1.TempoStack) {
+		if tempo.Spec.Storage.BlockFormat == "" {
+			tempo.Spec.Storage.BlockFormat = format
+		}
+	}
+}
+
+// TempoVersion returns the Tempo version of a tempo image. The version is the tag of the image,
+// or the Tempo version of the operator if the image is the default tempo image of the operator.
+// It returns an empty string if the version is unknown.
+func TempoVersion(image string, defaultImage string, defaultVersion string) string {
+	if v := version.ImageVersion(image); v != nil {
+		return v.String()
+	}
+	if image == defaultImage {
+		return defaultVersion
+	}
+	return ""
+}
+
+// MigrateTempo runs all migration steps of the Tempo versions newer than the from version,
+// up to and including the to version, in order. The steps are only required while the components are
+// rolled out from one version to the other, the returned TempoStack must not be persisted.
+// It returns false if no migration step applies, or if one of the versions is unknown.
+func MigrateTempo(tempo v1alpha1.TempoStack, from string, to string) (v1alpha1.TempoStack, bool) {
+	fromVersion, err := semver.NewVersion(from)
+	if err != nil {
+		return tempo, false
+	}
+	toVersion, err := semver.NewVersion(to)
+	if err != nil {
+		return tempo, false
+	}
+
+	migrated := tempo.DeepCopy()
+	applied := false
+	for _, migration := range tempoMigrations {
+		if migration.version.GreaterThan(fromVersion) && !migration.version.GreaterThan(toVersion) {
+			migration.migrate(migrated)
+			applied = true
+		}
+	}
+	return *migrated, applied
+}
@@ -0,0 +1,74 @@
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grafana/tempo-operator/apis/tempo/v1alpha1"
+)
+
+func TestTempoVersion(t *testing.T) {
+	assert.Equal(t, "2.3.1", TempoVersion("docker.io/grafana/tempo:2.3.1", "docker.io/grafana/tempo:2.4.0", "2.4.0"))
+	assert.Equal(t, "2.4.0", TempoVersion("docker.io/grafana/tempo:latest", "docker.io/grafana/tempo:latest", "2.4.0"))
+	assert.Equal(t, "", TempoVersion("docker.io/grafana/tempo:main", "docker.io/grafana/tempo:2.4.0", "2.4.0"))
+}
+
+func TestMigrateTempo(t *testing.T) {
+	tests := []struct {
+		name          string
+		from          string
+		to            string
+		blockFormat   v1alpha1.BlockFormat
+		expected      v1alpha1.BlockFormat
+		expectApplied bool
+	}{
+		{
+			name:          "no migration step",
+			from:          "2.2.0",
+			to:            "2.3.1",
+			expectApplied: false,
+		},
+		{
+			name:          "unknown previous version",
+			from:          "",
+			to:            "2.4.0",
+			expectApplied: false,
+		},
+		{
+			name:          "keep the block format of the previous version",
+			from:          "2.3.1",
+			to:            "2.4.0",
+			expected:      v1alpha1.BlockFormatVParquet2,
+			expectApplied: true,
+		},
+		{
+			name:          "multiple migration steps",
+			from:          "2.1.1",
+			to:            "2.4.0",
+			expected:      v1alpha1.BlockFormatVParquet,
+			expectApplied: true,
+		},
+		{
+			name:          "block format of the TempoStack",
+			from:          "2.3.1",
+			to:            "2.4.0",
+			blockFormat:   v1alpha1.BlockFormatVParquet3,
+			expected:      v1alpha1.BlockFormatVParquet3,
+			expectApplied: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempo := v1alpha1.TempoStack{}
+			tempo.Spec.Storage.BlockFormat = test.blockFormat
+
+			migrated, applied := MigrateTempo(tempo, test.from, test.to)
+			assert.Equal(t, test.expectApplied, applied)
+			assert.Equal(t, test.expected, migrated.Spec.Storage.BlockFormat)
+			// the TempoStack itself is not modified
+			assert.Equal(t, test.blockFormat, tempo.Spec.Storage.BlockFormat)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -76,7 +77,7 @@ func (u Upgrade) TempoStack(ctx context.Context, original v1alpha1.TempoStack) e
 	if err != nil {
 		msg := "automated upgrade is not possible, the CR instance must be corrected and re-created manually"
 		itemLogger.Info(msg)
-		u.Recorder.Event(&original, "Error", "Upgrade", msg)
+		u.Recorder.Event(&original, corev1.EventTypeWarning, "Upgrade", msg)
 		metricUpgrades.WithLabelValues(metricUpgradesStateFailed).Inc()
 		return err
 	}
@@ -101,6 +102,8 @@ func (u Upgrade) TempoStack(ctx context.Context, original v1alpha1.TempoStack) e
 		}
 
 		itemLogger.Info("upgraded instance")
+		u.Recorder.Eventf(&upgraded, corev1.EventTypeNormal, "Upgrade", "upgraded instance from operator version %s to %s",
+			original.Status.OperatorVersion, upgraded.Status.OperatorVersion)
 		metricUpgrades.WithLabelValues(metricUpgradesStateUpgraded).Inc()
 	} else {
 		metricUpgrades.WithLabelValues(metricUpgradesStateUpToDate).Inc()
@@ -166,8 +169,8 @@ func (u Upgrade) updateTempoStackCR(ctx context.Context, tempo v1alpha1.TempoSta
 	// update all tempo images to the new default images on every upgrade
 	updateTempoStackImages(u, &tempo)
 
-	// at the end of the upgrade process, the CR is up to date with the current running component versions (Operator, Tempo, TempoQuery)
-	// update all component versions in the Status field of the CR with the current running versions
+	// at the end of the upgrade process, the CR is up to date with the current running operator version.
+	// The Tempo version is updated by the reconciliation, once the new Tempo version is rolled out
 	updateTempoStackVersions(u, &tempo)
 
 	return tempo, nil
@@ -190,11 +193,30 @@ func updateTempoStackImages(u Upgrade, tempo *v1alpha1.TempoStack) {
 	if u.CtrlConfig.DefaultImages.TempoGatewayOpa != "" {
 		tempo.Spec.Images.TempoGatewayOpa = u.CtrlConfig.DefaultImages.TempoGatewayOpa
 	}
+
+	if u.CtrlConfig.DefaultImages.SPIFFEHelper != "" {
+		tempo.Spec.Images.SPIFFEHelper = u.CtrlConfig.DefaultImages.SPIFFEHelper
+	}
+
+	if u.CtrlConfig.DefaultImages.OauthProxy != "" {
+		tempo.Spec.Images.OauthProxy = u.CtrlConfig.DefaultImages.OauthProxy
+	}
+
+	if u.CtrlConfig.DefaultImages.Memcached != "" {
+		tempo.Spec.Images.Memcached = u.CtrlConfig.DefaultImages.Memcached
+	}
+
+	if u.CtrlConfig.DefaultImages.TempoCLI != "" {
+		tempo.Spec.Images.TempoCLI = u.CtrlConfig.DefaultImages.TempoCLI
+	}
+
+	if u.CtrlConfig.DefaultImages.Backup != "" {
+		tempo.Spec.Images.Backup = u.CtrlConfig.DefaultImages.Backup
+	}
 }
 
-// updateTempoStackVersions updates all component versions in the CR with the current running component versions.
+// updateTempoStackVersions updates the operator version in the CR with the current running operator version.
 func updateTempoStackVersions(u Upgrade, tempo *v1alpha1.TempoStack) {
 	tempo.Status.OperatorVersion = u.Version.OperatorVersion
-	tempo.Status.TempoVersion = u.Version.TempoVersion
 	tempo.Status.TempoQueryVersion = "" // this field should be removed in the next version of the CRD
 }
@@ -64,7 +64,7 @@ func TestUpgradeToLatest(t *testing.T) {
 
 	upgrade := &Upgrade{
 		Client:   k8sClient,
-		Recorder: record.NewFakeRecorder(1),
+		Recorder: record.NewFakeRecorder(100),
 		CtrlConfig: configv1alpha1.ProjectConfig{
 			DefaultImages: configv1alpha1.ImagesSpec{
 				Tempo:           "docker.io/grafana/tempo:latest",
@@ -83,7 +83,8 @@ func TestUpgradeToLatest(t *testing.T) {
 	err = k8sClient.Get(context.Background(), nsn, &upgradedTempo)
 	assert.NoError(t, err)
 	assert.Equal(t, currentV.OperatorVersion, upgradedTempo.Status.OperatorVersion)
-	assert.Equal(t, currentV.TempoVersion, upgradedTempo.Status.TempoVersion)
+	// the Tempo version is recorded once the new Tempo version is rolled out
+	assert.Empty(t, upgradedTempo.Status.TempoVersion)
 
 	// assert images were updated
 	assert.Equal(t, "docker.io/grafana/tempo:latest", upgradedTempo.Spec.Images.Tempo)
@@ -123,7 +124,7 @@ func TestSkipUpgrade(t *testing.T) {
 
 			upgrade := &Upgrade{
 				Client:   k8sClient,
-				Recorder: record.NewFakeRecorder(1),
+				Recorder: record.NewFakeRecorder(100),
 				CtrlConfig: configv1alpha1.ProjectConfig{
 					DefaultImages: configv1alpha1.ImagesSpec{
 						Tempo:           "docker.io/grafana/tempo:latest",
@@ -36,7 +36,7 @@ func TestRemoveDeprecatedFields(t *testing.T) {
 	version.OperatorVersion = "0.3.0"
 	upgrade := &Upgrade{
 		Client:   k8sClient,
-		Recorder: record.NewFakeRecorder(1),
+		Recorder: record.NewFakeRecorder(100),
 		Version:  version,
 		Log:      logger,
 	}
@@ -0,0 +1,32 @@
+package version
+
+import (
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// ImageTag returns the tag of a container image, or an empty string if the image is not tagged.
+func ImageTag(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return ""
+	}
+	return image[i+1:]
+}
+
+// ImageVersion returns the semantic version of the tag of a container image, or nil if the tag is not a version.
+func ImageVersion(image string) *semver.Version {
+	tag := ImageTag(image)
+	if tag == "" {
+		return nil
+	}
+	version, err := semver.NewVersion(tag)
+	if err != nil {
+		return nil
+	}
+	return version
+}
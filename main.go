@@ -6,6 +6,7 @@ import (
 
 	"github.com/grafana/tempo-operator/cmd"
 	"github.com/grafana/tempo-operator/cmd/generate"
+	"github.com/grafana/tempo-operator/cmd/migrate"
 	"github.com/grafana/tempo-operator/cmd/start"
 	"github.com/grafana/tempo-operator/cmd/version"
 	"github.com/grafana/tempo-operator/internal/logging"
@@ -15,6 +16,7 @@ func main() {
 	rootCmd := cmd.NewRootCommand()
 	rootCmd.AddCommand(start.NewStartCommand())
 	rootCmd.AddCommand(generate.NewGenerateCommand())
+	rootCmd.AddCommand(migrate.NewMigrateCommand())
 	rootCmd.AddCommand(version.NewVersionCommand())
 
 	logging.SetupLogging()